@@ -99,3 +99,21 @@ var TinkerbellBMCCustomPayloadDotLocation = Flag[string]{
 	Name:  "tinkerbell-bmc-custom-payload-dot-location",
 	Usage: "The dot location of the custom payload used in RPC BMC interactions, must be used with tinkerbell-bmc-custom-payload",
 }
+
+// TinkerbellCollectInventory enables collection of hardware inventory from machine BMCs.
+var TinkerbellCollectInventory = Flag[bool]{
+	Name:  "tinkerbell-collect-inventory",
+	Usage: "Collect firmware, CPU, memory, disk and health inventory from machine BMCs and apply it as hardware labels",
+}
+
+// TinkerbellMinimumBIOSVersion is the minimum BIOS version used to label hardware when collecting inventory.
+var TinkerbellMinimumBIOSVersion = Flag[string]{
+	Name:  "tinkerbell-minimum-bios-version",
+	Usage: "Label hardware with whether the BIOS version collected from its BMC meets this minimum",
+}
+
+// TinkerbellBurnInImage is the container image used to run hardware burn-in validation workflows.
+var TinkerbellBurnInImage = Flag[string]{
+	Name:  "tinkerbell-burn-in-image",
+	Usage: "Include burn-in validation workflows running disk, memory and NIC checks with this image in the generated hardware",
+}
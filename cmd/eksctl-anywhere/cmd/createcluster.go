@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -25,11 +26,13 @@ import (
 type createClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	imageVerificationOptions
 	forceClean            bool
 	skipIpCheck           bool
 	hardwareCSVPath       string
 	tinkerbellBootstrapIP string
 	installPackages       string
+	bootstrapKubeconfig   string
 	skipValidations       []string
 	providerOptions       *dependencies.ProviderOptions
 }
@@ -40,6 +43,7 @@ var cc = &createClusterOptions{
 			BMCOptions: &hardware.BMCOptions{
 				RPC: &hardware.RPCOpts{},
 			},
+			InventoryOptions: &hardware.InventoryOptions{},
 		},
 	},
 }
@@ -57,14 +61,17 @@ func init() {
 	createCmd.AddCommand(createClusterCmd)
 	applyClusterOptionFlags(createClusterCmd.Flags(), &cc.clusterOptions)
 	applyTimeoutFlags(createClusterCmd.Flags(), &cc.timeoutOptions)
+	applyImageVerificationFlags(createClusterCmd.Flags(), &cc.imageVerificationOptions)
 	applyTinkerbellHardwareFlag(createClusterCmd.Flags(), &cc.hardwareCSVPath)
 	aflag.String(aflag.TinkerbellBootstrapIP, &cc.tinkerbellBootstrapIP, createClusterCmd.Flags())
 	createClusterCmd.Flags().BoolVar(&cc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
 	hideForceCleanup(createClusterCmd.Flags())
 	createClusterCmd.Flags().BoolVar(&cc.skipIpCheck, "skip-ip-check", false, "Skip check for whether cluster control plane ip is in use")
 	createClusterCmd.Flags().StringVar(&cc.installPackages, "install-packages", "", "Location of curated packages configuration files to install to the cluster")
+	createClusterCmd.Flags().StringVar(&cc.bootstrapKubeconfig, "bootstrap-kubeconfig", "", "Kubeconfig of an existing cluster to use as bootstrap cluster instead of creating a local kind cluster")
 	createClusterCmd.Flags().StringArrayVar(&cc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass create validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(createvalidations.SkippableValidations[:], ",")))
 	tinkerbellFlags(createClusterCmd.Flags(), cc.providerOptions.Tinkerbell.BMCOptions.RPC)
+	tinkerbellInventoryFlags(createClusterCmd.Flags(), cc.providerOptions.Tinkerbell.InventoryOptions)
 
 	aflag.MarkRequired(createClusterCmd.Flags(), aflag.ClusterConfig.Name)
 }
@@ -96,6 +103,17 @@ func tinkerbellFlags(fs *pflag.FlagSet, r *hardware.RPCOpts) {
 	aflag.MarkHidden(fs, aflag.TinkerbellBMCCustomPayloadDotLocation.Name)
 }
 
+func tinkerbellInventoryFlags(fs *pflag.FlagSet, o *hardware.InventoryOptions) {
+	tinkerbellCollectInventoryFlags(fs, o)
+	aflag.String(aflag.TinkerbellMinimumBIOSVersion, &o.MinimumBIOSVersion, fs)
+}
+
+// tinkerbellCollectInventoryFlags registers the inventory collection flag for commands that don't
+// select hardware for a cluster.
+func tinkerbellCollectInventoryFlags(fs *pflag.FlagSet, o *hardware.InventoryOptions) {
+	aflag.Bool(aflag.TinkerbellCollectInventory, &o.Enabled, fs)
+}
+
 func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) error {
 	if cc.forceClean {
 		logger.MarkFail(forceCleanupDeprecationMessageForCreateDelete)
@@ -124,13 +142,26 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		return errors.New("etcdEncryption is not supported during cluster creation")
 	}
 
-	docker := executables.BuildDockerExecutable()
-
-	if err := validations.CheckMinimumDockerVersion(ctx, docker); err != nil {
-		return fmt.Errorf("failed to validate docker: %v", err)
+	if cc.bootstrapKubeconfig != "" {
+		if err := validateBootstrapKubeconfig(clusterConfig, cc.bootstrapKubeconfig); err != nil {
+			return err
+		}
+		if cc.bootstrapKubeconfig, err = filepath.Abs(cc.bootstrapKubeconfig); err != nil {
+			return err
+		}
 	}
 
-	validations.CheckDockerAllocatedMemory(ctx, docker)
+	// Docker runs the kind bootstrap cluster and the tools image, it's not needed
+	// with an existing bootstrap cluster and the executables of the host.
+	if cc.bootstrapKubeconfig == "" || executables.ExecutablesInDocker() {
+		docker := executables.BuildDockerExecutable()
+
+		if err := validations.CheckMinimumDockerVersion(ctx, docker); err != nil {
+			return fmt.Errorf("failed to validate docker: %v", err)
+		}
+
+		validations.CheckDockerAllocatedMemory(ctx, docker)
+	}
 
 	kubeconfigPath := kubeconfig.FromClusterName(clusterConfig.Name)
 	if validations.FileExistsAndIsNotEmpty(kubeconfigPath) {
@@ -149,8 +180,16 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		return err
 	}
 
+	if err := cc.verifyClusterImages(ctx, clusterSpec); err != nil {
+		return err
+	}
+
 	cliConfig := buildCliConfig(clusterSpec)
-	dirs, err := cc.directoriesToMount(clusterSpec, cliConfig, cc.installPackages)
+	mountFiles := []string{cc.installPackages}
+	if cc.bootstrapKubeconfig != "" {
+		mountFiles = append(mountFiles, cc.bootstrapKubeconfig)
+	}
+	dirs, err := cc.directoriesToMount(clusterSpec, cliConfig, mountFiles...)
 	if err != nil {
 		return err
 	}
@@ -194,6 +233,10 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 		factory.WithNoTimeouts()
 	}
 
+	if cc.bootstrapKubeconfig != "" {
+		factory.WithExistingBootstrapCluster(cc.bootstrapKubeconfig)
+	}
+
 	deps, err := factory.Build(ctx)
 	if err != nil {
 		return err
@@ -261,3 +304,21 @@ func (cc *createClusterOptions) createCluster(cmd *cobra.Command, _ []string) er
 	cleanup(deps, &err)
 	return err
 }
+
+// validateBootstrapKubeconfig checks an existing bootstrap cluster can be used to create the cluster.
+func validateBootstrapKubeconfig(clusterConfig *v1alpha1.Cluster, kubeconfig string) error {
+	if !validations.FileExists(kubeconfig) {
+		return fmt.Errorf("the bootstrap kubeconfig file %s does not exist", kubeconfig)
+	}
+
+	if clusterConfig.IsManaged() {
+		return errors.New("--bootstrap-kubeconfig is only supported when creating management clusters")
+	}
+
+	switch clusterConfig.Spec.DatacenterRef.Kind {
+	case v1alpha1.DockerDatacenterKind, v1alpha1.TinkerbellDatacenterKind:
+		return fmt.Errorf("--bootstrap-kubeconfig is not supported for %s clusters, their bootstrap cluster has to run in the admin machine", clusterConfig.Spec.DatacenterRef.Kind)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type describeClusterOptions struct {
+	kubeConfig string
+	namespace  string
+	output     string
+}
+
+var dco = &describeClusterOptions{}
+
+var describeClusterCmd = &cobra.Command{
+	Use:          "cluster <cluster-name>",
+	Short:        "Describe an EKS Anywhere cluster",
+	Long:         "This command shows the status conditions, certificates, node group machines and reconciliation state of an EKS Anywhere cluster",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterName, err := validations.ValidateClusterNameArg(args)
+		if err != nil {
+			return err
+		}
+		if err := validateOutputFormat(dco.output); err != nil {
+			return err
+		}
+		return dco.describeCluster(cmd.Context(), clusterName)
+	},
+}
+
+func init() {
+	describeCmd.AddCommand(describeClusterCmd)
+	describeClusterCmd.Flags().StringVar(&dco.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file, required for workload clusters. Defaults to the cluster kubeconfig")
+	describeClusterCmd.Flags().StringVarP(&dco.namespace, "namespace", "n", "default", "Namespace of the cluster in the management cluster")
+	describeClusterCmd.Flags().StringVarP(&dco.output, outputFlagName, "o", outputText, "Output format: text|json|yaml")
+}
+
+func (o *describeClusterOptions) describeCluster(ctx context.Context, clusterName string) error {
+	client, closer, err := newManagementClient(ctx, o.kubeConfig, clusterName)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	d, err := clusterinfo.DescribeCluster(ctx, client, clusterName, o.namespace)
+	if err != nil {
+		return err
+	}
+	// The machines of a workload cluster live in its management cluster, the cluster kubeconfig can't read them.
+	if o.kubeConfig == "" && d.Type == clusterinfo.WorkloadType {
+		return fmt.Errorf("cluster %s is a workload cluster, pass the kubeconfig of its management cluster %s with --kubeconfig", clusterName, d.ManagementCluster)
+	}
+
+	if o.output == outputText {
+		return clusterinfo.WriteDescription(os.Stdout, d)
+	}
+
+	return writeStructured(os.Stdout, d, o.output)
+}
@@ -19,7 +19,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/logger"
-	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/manifests/releases"
 	"github.com/aws/eks-anywhere/pkg/version"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -72,7 +71,7 @@ func downloadArtifacts(context context.Context, opts *downloadArtifactsOptions)
 
 	var b *releasev1.Bundles
 	if opts.bundlesOverride != "" {
-		b, err = bundles.Read(reader, opts.bundlesOverride)
+		b, err = deps.ManifestReader.ReadBundles(opts.bundlesOverride)
 		if err != nil {
 			return err
 		}
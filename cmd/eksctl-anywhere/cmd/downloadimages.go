@@ -45,11 +45,13 @@ func init() {
 	downloadImagesCmd.Flag("include-packages").Deprecated = "use copy packages command"
 	downloadImagesCmd.Flags().StringVarP(&downloadImagesRunner.bundlesOverride, "bundles-override", "", "", "Override default Bundles manifest (not recommended)")
 	downloadImagesCmd.Flags().BoolVar(&downloadImagesRunner.insecure, "insecure", false, "Flag to indicate skipping TLS verification while downloading helm charts")
+	applyImageVerificationFlags(downloadImagesCmd.Flags(), &downloadImagesRunner.imageVerificationOptions)
 }
 
 var downloadImagesRunner = downloadImagesCommand{}
 
 type downloadImagesCommand struct {
+	imageVerificationOptions
 	outputFile      string
 	bundlesOverride string
 	includePackages bool
@@ -57,13 +59,17 @@ type downloadImagesCommand struct {
 }
 
 func (c downloadImagesCommand) Run(ctx context.Context) error {
+	imageVerifier, err := c.imageVerifier(nil, nil)
+	if err != nil {
+		return err
+	}
+
 	factory := dependencies.NewFactory()
 	helmOpts := []helm.Opt{}
 	if c.insecure {
 		helmOpts = append(helmOpts, helm.WithInsecure())
 	}
 	deps, err := factory.
-		WithFileReader().
 		WithManifestReader().
 		WithHelm(helmOpts...).
 		WithLogger().
@@ -79,8 +85,7 @@ func (c downloadImagesCommand) Run(ctx context.Context) error {
 	eksaToolsImageFile := filepath.Join(downloadFolder, eksaToolsImageTarFile)
 
 	downloadArtifacts := artifacts.Download{
-		Reader:     deps.ManifestReader,
-		FileReader: deps.FileReader,
+		Reader: deps.ManifestReader,
 		BundlesImagesDownloader: docker.NewImageMover(
 			docker.NewOriginalRegistrySource(dockerClient),
 			docker.NewDiskDestination(dockerClient, imagesFile),
@@ -96,6 +101,7 @@ func (c downloadImagesCommand) Run(ctx context.Context) error {
 		Packager:           packagerForFile(c.outputFile),
 		ManifestDownloader: oras.NewBundleDownloader(deps.Logger, downloadFolder),
 		BundlesOverride:    c.bundlesOverride,
+		ImageVerifier:      imageVerifier,
 	}
 
 	return downloadArtifacts.Run(ctx)
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -13,7 +15,13 @@ import (
 	"github.com/aws/eks-anywhere/internal/pkg/api"
 	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig"
+	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
 	"github.com/aws/eks-anywhere/pkg/templater"
 	"github.com/aws/eks-anywhere/pkg/validations"
 )
@@ -30,7 +38,7 @@ var generateClusterConfigCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		err = generateClusterConfig(clusterName)
+		err = generateClusterConfig(cmd.Context(), clusterName)
 		if err != nil {
 			return fmt.Errorf("generating eks-a cluster config: %v", err) // need to have better error handling here in own func
 		}
@@ -50,47 +58,41 @@ func preRunGenerateClusterConfig(cmd *cobra.Command, args []string) {
 func init() {
 	generateCmd.AddCommand(generateClusterConfigCmd)
 	generateClusterConfigCmd.Flags().StringP("provider", "p", "", fmt.Sprintf("Provider to use (%s)", strings.Join(constants.SupportedProviders, " or ")))
+	generateClusterConfigCmd.Flags().BoolP("interactive", "i", false, "Ask for the cluster config values, offering the resources found in the provider, and validate them as create does")
+	generateClusterConfigCmd.Flags().StringP("output-file", "o", "", "File to write the cluster config to, defaults to stdout or to <cluster-name>.yaml in interactive mode")
 	err := generateClusterConfigCmd.MarkFlagRequired("provider")
 	if err != nil {
 		log.Fatalf("marking flag as required: %v", err)
 	}
 }
 
-func generateClusterConfig(clusterName string) error {
-	var resources [][]byte
-	var datacenterYaml []byte
-	var machineGroupYaml [][]byte
+func generateClusterConfig(ctx context.Context, clusterName string) error {
+	var datacenterConfig interface{}
+	var machineConfigs []interface{}
 	var clusterConfigOpts []v1alpha1.ClusterGenerateOpt
+	var configureProvider func(ctx context.Context, p *guidedconfig.Prompter, c *v1alpha1.ClusterGenerate) error
 	switch strings.ToLower(viper.GetString("provider")) {
 	case constants.DockerProviderName:
-		datacenterConfig := v1alpha1.NewDockerDatacenterConfigGenerate(clusterName)
-		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
+		dockerDatacenterConfig := v1alpha1.NewDockerDatacenterConfigGenerate(clusterName)
+		datacenterConfig = dockerDatacenterConfig
+		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(dockerDatacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.ControlPlaneConfigCount(1),
 			v1alpha1.ExternalETCDConfigCount(1),
 			v1alpha1.WorkerNodeConfigCount(1),
 			v1alpha1.WorkerNodeConfigName(constants.DefaultWorkerNodeGroupName),
 		)
-		dcyaml, err := yaml.Marshal(datacenterConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		datacenterYaml = dcyaml
 	case constants.VSphereProviderName:
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithClusterEndpoint())
-		datacenterConfig := v1alpha1.NewVSphereDatacenterConfigGenerate(clusterName)
-		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
+		vsphereDatacenterConfig := v1alpha1.NewVSphereDatacenterConfigGenerate(clusterName)
+		datacenterConfig = vsphereDatacenterConfig
+		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(vsphereDatacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.ControlPlaneConfigCount(2),
 			v1alpha1.ExternalETCDConfigCount(3),
 			v1alpha1.WorkerNodeConfigCount(2),
 			v1alpha1.WorkerNodeConfigName(constants.DefaultWorkerNodeGroupName),
 		)
-		dcyaml, err := yaml.Marshal(datacenterConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		datacenterYaml = dcyaml
 		// need to default control plane config name to something different from the cluster name based on assumption
 		// in controller code
 		cpMachineConfig := v1alpha1.NewVSphereMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
@@ -101,33 +103,26 @@ func generateClusterConfig(clusterName string) error {
 			v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
 			v1alpha1.WithEtcdMachineGroupRef(etcdMachineConfig),
 		)
-		cpMcYaml, err := yaml.Marshal(cpMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		workerMcYaml, err := yaml.Marshal(workerMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		etcdMcYaml, err := yaml.Marshal(etcdMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
+		machineConfigs = append(machineConfigs, cpMachineConfig, workerMachineConfig, etcdMachineConfig)
+		configureProvider = func(ctx context.Context, p *guidedconfig.Prompter, _ *v1alpha1.ClusterGenerate) error {
+			deps, err := dependencies.NewFactory().WithVSphereValidator().Build(ctx)
+			if err != nil {
+				return err
+			}
+			defer close(ctx, deps)
+
+			return guidedconfig.ConfigureVSphere(ctx, p, deps.Govc, deps.VSphereValidator, vsphereDatacenterConfig, cpMachineConfig, workerMachineConfig, etcdMachineConfig)
 		}
-		machineGroupYaml = append(machineGroupYaml, cpMcYaml, workerMcYaml, etcdMcYaml)
 	case constants.SnowProviderName:
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithClusterEndpoint())
-		datacenterConfig := v1alpha1.NewSnowDatacenterConfigGenerate(clusterName)
-		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
+		snowDatacenterConfig := v1alpha1.NewSnowDatacenterConfigGenerate(clusterName)
+		datacenterConfig = snowDatacenterConfig
+		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(snowDatacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.ControlPlaneConfigCount(3),
 			v1alpha1.WorkerNodeConfigCount(3),
 			v1alpha1.WorkerNodeConfigName(constants.DefaultWorkerNodeGroupName),
 		)
-		dcyaml, err := yaml.Marshal(datacenterConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		datacenterYaml = dcyaml
 
 		cpMachineConfig := v1alpha1.NewSnowMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
 		workerMachineConfig := v1alpha1.NewSnowMachineConfigGenerate(clusterName)
@@ -135,30 +130,18 @@ func generateClusterConfig(clusterName string) error {
 			v1alpha1.WithCPMachineGroupRef(cpMachineConfig),
 			v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
 		)
-		cpMcYaml, err := yaml.Marshal(cpMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		workerMcYaml, err := yaml.Marshal(workerMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		machineGroupYaml = append(machineGroupYaml, cpMcYaml, workerMcYaml)
+		machineConfigs = append(machineConfigs, cpMachineConfig, workerMachineConfig)
 	case constants.CloudStackProviderName:
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithClusterEndpoint())
-		datacenterConfig := v1alpha1.NewCloudStackDatacenterConfigGenerate(clusterName)
-		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
+		cloudstackDatacenterConfig := v1alpha1.NewCloudStackDatacenterConfigGenerate(clusterName)
+		datacenterConfig = cloudstackDatacenterConfig
+		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(cloudstackDatacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.ControlPlaneConfigCount(2),
 			v1alpha1.ExternalETCDConfigCount(3),
 			v1alpha1.WorkerNodeConfigCount(2),
 			v1alpha1.WorkerNodeConfigName(constants.DefaultWorkerNodeGroupName),
 		)
-		dcyaml, err := yaml.Marshal(datacenterConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		datacenterYaml = dcyaml
 		// need to default control plane config name to something different from the cluster name based on assumption
 		// in controller code
 		cpMachineConfig := v1alpha1.NewCloudStackMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
@@ -169,33 +152,41 @@ func generateClusterConfig(clusterName string) error {
 			v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
 			v1alpha1.WithEtcdMachineGroupRef(etcdMachineConfig),
 		)
-		cpMcYaml, err := yaml.Marshal(cpMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		workerMcYaml, err := yaml.Marshal(workerMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		etcdMcYaml, err := yaml.Marshal(etcdMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
+		machineConfigs = append(machineConfigs, cpMachineConfig, workerMachineConfig, etcdMachineConfig)
+		configureProvider = func(ctx context.Context, p *guidedconfig.Prompter, c *v1alpha1.ClusterGenerate) error {
+			execConfig, err := decoder.ParseCloudStackCredsFromEnv()
+			if err != nil {
+				return fmt.Errorf("parsing CloudStack credentials: %v", err)
+			}
+			deps, err := dependencies.NewFactory().WithExecutableBuilder().WithWriter().Build(ctx)
+			if err != nil {
+				return err
+			}
+			defer close(ctx, deps)
+
+			cmk, err := deps.ExecutableBuilder.BuildCmkExecutable(deps.Writer, execConfig)
+			if err != nil {
+				return fmt.Errorf("building cmk executable: %v", err)
+			}
+			defer close(ctx, cmk)
+			profiles := make([]string, 0, len(execConfig.Profiles))
+			for _, profile := range execConfig.Profiles {
+				profiles = append(profiles, profile.Name)
+			}
+			validator := cloudstack.NewValidator(cmk, &networkutils.DefaultNetClient{}, false)
+
+			return guidedconfig.ConfigureCloudStack(ctx, p, cmk, validator, profiles, c, cloudstackDatacenterConfig, cpMachineConfig, workerMachineConfig, etcdMachineConfig)
 		}
-		machineGroupYaml = append(machineGroupYaml, cpMcYaml, workerMcYaml, etcdMcYaml)
 	case constants.TinkerbellProviderName:
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithClusterEndpoint())
-		datacenterConfig := v1alpha1.NewTinkerbellDatacenterConfigGenerate(clusterName)
-		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
+		tinkerbellDatacenterConfig := v1alpha1.NewTinkerbellDatacenterConfigGenerate(clusterName)
+		datacenterConfig = tinkerbellDatacenterConfig
+		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(tinkerbellDatacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.ControlPlaneConfigCount(1),
 			v1alpha1.WorkerNodeConfigCount(1),
 			v1alpha1.WorkerNodeConfigName(constants.DefaultWorkerNodeGroupName),
 		)
-		dcyaml, err := yaml.Marshal(datacenterConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		datacenterYaml = dcyaml
 
 		cpMachineConfig := v1alpha1.NewTinkerbellMachineConfigGenerate(providers.GetControlPlaneNodeName(clusterName))
 		workerMachineConfig := v1alpha1.NewTinkerbellMachineConfigGenerate(clusterName)
@@ -203,23 +194,14 @@ func generateClusterConfig(clusterName string) error {
 			v1alpha1.WithCPMachineGroupRef(cpMachineConfig),
 			v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
 		)
-		cpMcYaml, err := yaml.Marshal(cpMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
-		}
-		workerMcYaml, err := yaml.Marshal(workerMachineConfig)
-		if err != nil {
-			return fmt.Errorf("generating cluster yaml: %v", err)
+		machineConfigs = append(machineConfigs, cpMachineConfig, workerMachineConfig)
+		configureProvider = func(_ context.Context, p *guidedconfig.Prompter, c *v1alpha1.ClusterGenerate) error {
+			return guidedconfig.ConfigureTinkerbell(p, c, tinkerbellDatacenterConfig, cpMachineConfig, workerMachineConfig)
 		}
-		machineGroupYaml = append(machineGroupYaml, cpMcYaml, workerMcYaml)
 	case constants.NutanixProviderName:
-		datacenterConfig := v1alpha1.NewNutanixDatacenterConfigGenerate(clusterName)
-		dcYaml, err := yaml.Marshal(datacenterConfig)
-		if err != nil {
-			return fmt.Errorf("failed to generate cluster yaml: %v", err)
-		}
-		datacenterYaml = dcYaml
-		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(datacenterConfig))
+		nutanixDatacenterConfig := v1alpha1.NewNutanixDatacenterConfigGenerate(clusterName)
+		datacenterConfig = nutanixDatacenterConfig
+		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithDatacenterRef(nutanixDatacenterConfig))
 		clusterConfigOpts = append(clusterConfigOpts, v1alpha1.WithClusterEndpoint())
 		clusterConfigOpts = append(clusterConfigOpts,
 			v1alpha1.ControlPlaneConfigCount(2),
@@ -237,28 +219,47 @@ func generateClusterConfig(clusterName string) error {
 			v1alpha1.WithEtcdMachineGroupRef(etcdMachineConfig),
 			v1alpha1.WithWorkerMachineGroupRef(workerMachineConfig),
 		)
+		machineConfigs = append(machineConfigs, cpMachineConfig, workerMachineConfig, etcdMachineConfig)
+		configureProvider = func(ctx context.Context, p *guidedconfig.Prompter, c *v1alpha1.ClusterGenerate) error {
+			deps, err := dependencies.NewFactory().WithNutanixValidator().Build(ctx)
+			if err != nil {
+				return err
+			}
+			defer close(ctx, deps)
 
-		cpMcYaml, err := yaml.Marshal(cpMachineConfig)
-		if err != nil {
-			return fmt.Errorf("failed to generate cluster yaml: %v", err)
-		}
+			// A new client cache for every attempt, since the cache is keyed by the datacenter config
+			// name and the endpoint might have been entered again.
+			newClient := func(dc *v1alpha1.NutanixDatacenterConfig) (nutanix.Client, error) {
+				return nutanix.NewClientCache().GetNutanixClient(dc, nutanix.GetCredsFromEnv())
+			}
 
-		etcdMcYaml, err := yaml.Marshal(etcdMachineConfig)
-		if err != nil {
-			return fmt.Errorf("failed to generate cluster yaml: %v", err)
-		}
-
-		workerMcYaml, err := yaml.Marshal(workerMachineConfig)
-		if err != nil {
-			return fmt.Errorf("failed to generate cluster yaml: %v", err)
+			return guidedconfig.ConfigureNutanix(ctx, p, newClient, deps.NutanixValidator, c, nutanixDatacenterConfig, cpMachineConfig, workerMachineConfig, etcdMachineConfig)
 		}
-
-		machineGroupYaml = append(machineGroupYaml, cpMcYaml, workerMcYaml, etcdMcYaml)
 	default:
 		return fmt.Errorf("not a valid provider")
 	}
 	config := v1alpha1.NewClusterGenerate(clusterName, clusterConfigOpts...)
 
+	interactive := viper.GetBool("interactive")
+	outputFile := viper.GetString("output-file")
+	if interactive {
+		p := guidedconfig.NewPrompter(os.Stdin, os.Stderr)
+		if err := guidedconfig.ConfigureCluster(p, config); err != nil {
+			return err
+		}
+		if configureProvider != nil {
+			if err := configureProvider(ctx, p, config); err != nil {
+				return err
+			}
+		}
+		if _, err := guidedconfig.ValidateConfig(config, append([]interface{}{datacenterConfig}, machineConfigs...)...); err != nil {
+			return err
+		}
+		if outputFile == "" {
+			outputFile = clusterName + ".yaml"
+		}
+	}
+
 	configMarshal, err := yaml.Marshal(config)
 	if err != nil {
 		return fmt.Errorf("generating cluster yaml: %v", err)
@@ -267,11 +268,26 @@ func generateClusterConfig(clusterName string) error {
 	if err != nil {
 		return fmt.Errorf("cleaning up paths from yaml: %v", err)
 	}
-	resources = append(resources, clusterYaml, datacenterYaml)
-	if len(machineGroupYaml) > 0 {
-		resources = append(resources, machineGroupYaml...)
+	resources := [][]byte{clusterYaml}
+	for _, o := range append([]interface{}{datacenterConfig}, machineConfigs...) {
+		r, err := yaml.Marshal(o)
+		if err != nil {
+			return fmt.Errorf("generating cluster yaml: %v", err)
+		}
+		resources = append(resources, r)
+	}
+	content := templater.AppendYamlResources(resources...)
+
+	if outputFile == "" {
+		fmt.Println(string(content))
+		return nil
+	}
+	if err := os.WriteFile(outputFile, content, 0o644); err != nil {
+		return fmt.Errorf("writing cluster config file: %v", err)
+	}
+	if interactive {
+		fmt.Fprintf(os.Stderr, "Cluster config written to %s\n", outputFile)
 	}
 
-	fmt.Println(string(templater.AppendYamlResources(resources...)))
 	return nil
 }
@@ -6,13 +6,16 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/aflag"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
 
 type hardwareOptions struct {
 	csvPath         string
 	outputPath      string
+	burnInImage     string
 	providerOptions *dependencies.ProviderOptions
 }
 
@@ -22,6 +25,7 @@ var hOpts = &hardwareOptions{
 			BMCOptions: &hardware.BMCOptions{
 				RPC: &hardware.RPCOpts{},
 			},
+			InventoryOptions: &hardware.InventoryOptions{},
 		},
 	},
 }
@@ -51,10 +55,19 @@ func init() {
 		panic(err)
 	}
 	tinkerbellFlags(fset, hOpts.providerOptions.Tinkerbell.BMCOptions.RPC)
+	tinkerbellCollectInventoryFlags(fset, hOpts.providerOptions.Tinkerbell.InventoryOptions)
+	aflag.String(aflag.TinkerbellBurnInImage, &hOpts.burnInImage, fset)
 }
 
 func (hOpts *hardwareOptions) generateHardware(cmd *cobra.Command, args []string) error {
-	hardwareYaml, err := hardware.BuildHardwareYAML(hOpts.csvPath, hOpts.providerOptions.Tinkerbell.BMCOptions)
+	tinkerbellOpts := hOpts.providerOptions.Tinkerbell
+	reader, err := hardware.NewNormalizedCSVReaderFromFile(hOpts.csvPath, tinkerbellOpts.BMCOptions)
+	if err != nil {
+		return fmt.Errorf("reading csv: %v", err)
+	}
+	reader = hardware.WithInventoryCollection(reader, tinkerbellOpts.InventoryOptions, logger.Get())
+
+	hardwareYaml, err := hardware.BuildHardwareYAMLFromReader(reader, hOpts.burnInImage)
 	if err != nil {
 		return fmt.Errorf("building hardware yaml from csv: %v", err)
 	}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+type snowCapacityPlanOptions struct {
+	fileName string
+}
+
+var scpo = &snowCapacityPlanOptions{}
+
+var generateSnowCapacityPlanCmd = &cobra.Command{
+	Use:          "snow-capacity-plan -f <cluster-config-file>",
+	Short:        "Generate a capacity plan for a Snow cluster",
+	Long:         "This command places the machines of a Snow cluster, including the machines created by rolling upgrades, on the cluster devices and reports any capacity shortfall",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scpo.generateSnowCapacityPlan(cmd.Context())
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(generateSnowCapacityPlanCmd)
+	generateSnowCapacityPlanCmd.Flags().StringVarP(&scpo.fileName, "filename", "f", "", "Filename that contains EKS-A cluster configuration")
+	if err := generateSnowCapacityPlanCmd.MarkFlagRequired("filename"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *snowCapacityPlanOptions) generateSnowCapacityPlan(ctx context.Context) error {
+	clusterSpec, err := readAndValidateClusterSpec(o.fileName, version.Get())
+	if err != nil {
+		return err
+	}
+
+	if clusterSpec.Cluster.Spec.DatacenterRef.Kind != v1alpha1.SnowDatacenterKind {
+		return fmt.Errorf("capacity plans are only supported for %s, got %s", v1alpha1.SnowDatacenterKind, clusterSpec.Cluster.Spec.DatacenterRef.Kind)
+	}
+
+	deps, err := dependencies.ForSpec(clusterSpec).WithAwsSnow().Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	plan, err := snow.NewValidator(deps.SnowAwsClientRegistry).PlanCapacity(ctx, clusterSpec.Config)
+	if err != nil {
+		return err
+	}
+
+	out, err := serializeSnowCapacityPlan(plan)
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+
+	if !plan.Fits() {
+		return fmt.Errorf("the cluster machines don't fit on the snow devices")
+	}
+
+	return nil
+}
+
+func serializeSnowCapacityPlan(plan *snow.CapacityPlan) (string, error) {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "DEVICE\tMACHINES\tVCPU\tMEMORY (MIB)\tSTORAGE (GIB)")
+	for _, d := range plan.Devices {
+		machines := ""
+		for i, p := range d.Placements {
+			if i > 0 {
+				machines += ", "
+			}
+			machines += fmt.Sprintf("%s: %d", p.Group, p.Machines)
+		}
+		if machines == "" {
+			machines = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d/%d\t%d/%d\t%d/%d\n", d.Device, machines,
+			d.Requested.VCPU, d.Available.VCPU,
+			d.Requested.MemoryMiB, d.Available.MemoryMiB,
+			d.Requested.StorageGiB, d.Available.StorageGiB,
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	for _, s := range plan.Shortfalls {
+		fmt.Fprintf(&buffer, "\nShortfall: %s", s)
+	}
+	if len(plan.Shortfalls) > 0 {
+		buffer.WriteString("\n")
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+)
+
+const outputYaml = "yaml"
+
+type getClustersOptions struct {
+	kubeConfig    string
+	namespace     string
+	allNamespaces bool
+	output        string
+}
+
+var gco = &getClustersOptions{}
+
+var getClustersCmd = &cobra.Command{
+	Use:          "clusters",
+	Aliases:      []string{"cluster"},
+	Short:        "Get EKS Anywhere clusters",
+	Long:         "This command lists the EKS Anywhere clusters of a management cluster with their type, versions and readiness",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(gco.output); err != nil {
+			return err
+		}
+		return gco.getClusters(cmd.Context())
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getClustersCmd)
+	getClustersCmd.Flags().StringVar(&gco.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file")
+	getClustersCmd.Flags().StringVarP(&gco.namespace, "namespace", "n", "default", "Namespace of the clusters")
+	getClustersCmd.Flags().BoolVarP(&gco.allNamespaces, "all-namespaces", "A", false, "List the clusters in all namespaces")
+	getClustersCmd.Flags().StringVarP(&gco.output, outputFlagName, "o", outputText, "Output format: text|json|yaml")
+}
+
+func (o *getClustersOptions) getClusters(ctx context.Context) error {
+	client, closer, err := newManagementClient(ctx, o.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	namespace := o.namespace
+	if o.allNamespaces {
+		namespace = ""
+	}
+	clusters, err := clusterinfo.ListClusters(ctx, client, namespace)
+	if err != nil {
+		return err
+	}
+
+	if o.output == outputText {
+		if len(clusters) == 0 {
+			fmt.Println("No clusters found")
+			return nil
+		}
+		return clusterinfo.WriteTable(os.Stdout, clusters)
+	}
+
+	return writeStructured(os.Stdout, clusters, o.output)
+}
+
+// newManagementClient returns a client for the management cluster, reading the kubeconfig from the flag
+// or, when empty, from the cluster folder or the environment. The returned func closes the dependencies.
+func newManagementClient(ctx context.Context, kubeConfigFlag, clusterName string) (kubernetes.Client, func(), error) {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(kubeConfigFlag, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(kubeConfig).
+		WithExecutableBuilder().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return deps.UnAuthKubeClient.KubeconfigClient(kubeConfig), func() { close(ctx, deps) }, nil
+}
+
+func validateOutputFormat(output string) error {
+	switch output {
+	case outputText, outputJson, outputYaml:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format [%s], must be one of %s|%s|%s", output, outputText, outputJson, outputYaml)
+	}
+}
+
+func writeStructured(w io.Writer, v interface{}, output string) error {
+	var b []byte
+	var err error
+	if output == outputJson {
+		b, err = json.MarshalIndent(v, "", "  ")
+		b = append(b, '\n')
+	} else {
+		b, err = yaml.Marshal(v)
+	}
+	if err != nil {
+		return fmt.Errorf("serializing to %s: %v", output, err)
+	}
+
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+)
+
+func TestValidateOutputFormat(t *testing.T) {
+	g := NewWithT(t)
+
+	for _, output := range []string{outputText, outputJson, outputYaml} {
+		g.Expect(validateOutputFormat(output)).To(Succeed())
+	}
+	g.Expect(validateOutputFormat("wide")).To(MatchError("invalid output format [wide], must be one of text|json|yaml"))
+}
+
+func TestWriteStructured(t *testing.T) {
+	g := NewWithT(t)
+	clusters := []clusterinfo.Summary{{Name: "mgmt", Namespace: "default", Type: clusterinfo.ManagementType, Ready: "True"}}
+
+	b := &bytes.Buffer{}
+	g.Expect(writeStructured(b, clusters, outputJson)).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring(`"name": "mgmt"`))
+	g.Expect(b.String()).To(HaveSuffix("]\n"))
+
+	b.Reset()
+	g.Expect(writeStructured(b, clusters, outputYaml)).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring("  name: mgmt\n  namespace: default\n"))
+}
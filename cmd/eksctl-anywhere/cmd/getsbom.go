@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/dependencies"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/sbom"
+	"github.com/aws/eks-anywhere/pkg/validations"
+	"github.com/aws/eks-anywhere/pkg/version"
+)
+
+const (
+	sbomFormatCycloneDX = "cyclonedx"
+	sbomFormatSPDX      = "spdx"
+)
+
+type getSBOMOptions struct {
+	wConfig              string
+	managementKubeconfig string
+	namespace            string
+	format               string
+	outputFile           string
+	includeAttestations  bool
+	imageVerification    imageVerificationOptions
+}
+
+var gso = &getSBOMOptions{}
+
+var getSBOMCmd = &cobra.Command{
+	Use:          "sbom <cluster-name>",
+	Short:        "Generate the SBOM of a cluster",
+	Long:         "This command generates a CycloneDX or SPDX software bill of materials with the images of the EKS Anywhere components running in a cluster",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterName, err := validations.ValidateClusterNameArg(args)
+		if err != nil {
+			return err
+		}
+		if err := gso.validate(); err != nil {
+			return err
+		}
+		if err := gso.getSBOM(cmd.Context(), clusterName); err != nil {
+			return fmt.Errorf("failed to generate sbom: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getSBOMCmd)
+	getSBOMCmd.Flags().StringVarP(&gso.wConfig, "w-config", "w", "", "Kubeconfig file of the cluster, used to list the images running in it")
+	getSBOMCmd.Flags().StringVar(&gso.managementKubeconfig, "kubeconfig", "", "Management cluster kubeconfig file, defaults to the cluster kubeconfig")
+	getSBOMCmd.Flags().StringVarP(&gso.namespace, "namespace", "n", "default", "Namespace of the cluster in the management cluster")
+	getSBOMCmd.Flags().StringVar(&gso.format, "format", sbomFormatCycloneDX, "SBOM format: cyclonedx|spdx")
+	getSBOMCmd.Flags().StringVarP(&gso.outputFile, "output-file", "o", "", "File to write the SBOM to, defaults to stdout")
+	getSBOMCmd.Flags().BoolVar(&gso.includeAttestations, "include-attestations", false, "Merge the attestations of the images signed by the trust root, like their SBOMs, in the SBOM")
+	getSBOMCmd.Flags().StringSliceVar(&gso.imageVerification.trustRoot, imageTrustRootFlag, nil, "PEM files with the public keys or certificates trusted to sign the image attestations")
+	getSBOMCmd.Flags().StringVar(&gso.imageVerification.signaturesDir, "image-signatures-dir", "", "Directory with the cosign attestations of the images, used instead of the registry")
+}
+
+func (o *getSBOMOptions) validate() error {
+	if o.format != sbomFormatCycloneDX && o.format != sbomFormatSPDX {
+		return fmt.Errorf("invalid sbom format %s, must be one of %s|%s", o.format, sbomFormatCycloneDX, sbomFormatSPDX)
+	}
+	if o.includeAttestations && !o.imageVerification.enabled() {
+		return fmt.Errorf("--%s is required to include attestations", imageTrustRootFlag)
+	}
+
+	return o.imageVerification.validate()
+}
+
+func (o *getSBOMOptions) getSBOM(ctx context.Context, clusterName string) error {
+	clusterKubeconfig := getKubeconfigPath(clusterName, o.wConfig)
+	if err := kubeconfig.ValidateFilename(clusterKubeconfig); err != nil {
+		return err
+	}
+	managementKubeconfig := o.managementKubeconfig
+	if managementKubeconfig == "" {
+		managementKubeconfig = clusterKubeconfig
+	}
+
+	deps, err := dependencies.NewFactory().
+		WithExecutableMountDirs(clusterKubeconfig, managementKubeconfig).
+		WithExecutableBuilder().
+		WithUnAuthKubeClient().
+		Build(ctx)
+	if err != nil {
+		return err
+	}
+	defer close(ctx, deps)
+
+	managementClient := deps.UnAuthKubeClient.KubeconfigClient(managementKubeconfig)
+	var opts []sbom.CollectorOpt
+	if o.includeAttestations {
+		eksaCluster := &anywherev1.Cluster{}
+		if err := managementClient.Get(ctx, clusterName, o.namespace, eksaCluster); err != nil {
+			return fmt.Errorf("reading cluster %s: %v", clusterName, err)
+		}
+		verifier, err := o.imageVerification.signatureVerifier(registrymirror.FromCluster(eksaCluster))
+		if err != nil {
+			return err
+		}
+		opts = append(opts, sbom.WithAttestations(verifier))
+	}
+
+	logger.V(1).Info("Collecting the images running in the cluster", "cluster", clusterName)
+	collector := sbom.NewCollector(managementClient, deps.UnAuthKubeClient.KubeconfigClient(clusterKubeconfig), opts...)
+	s, err := collector.Collect(ctx, clusterName, o.namespace)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if o.outputFile != "" {
+		f, err := os.Create(o.outputFile)
+		if err != nil {
+			return fmt.Errorf("creating sbom file: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return writeSBOM(w, s, o.format)
+}
+
+func writeSBOM(w io.Writer, s *sbom.SBOM, format string) error {
+	toolVersion := version.Get().GitVersion
+	if format == sbomFormatSPDX {
+		return sbom.WriteSPDX(w, s, toolVersion)
+	}
+
+	return sbom.WriteCycloneDX(w, s, toolVersion)
+}
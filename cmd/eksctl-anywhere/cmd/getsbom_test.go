@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/sbom"
+)
+
+func TestGetSBOMOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    getSBOMOptions
+		wantErr string
+	}{
+		{
+			name: "cyclonedx",
+			opts: getSBOMOptions{format: sbomFormatCycloneDX},
+		},
+		{
+			name: "spdx with attestations",
+			opts: getSBOMOptions{
+				format:              sbomFormatSPDX,
+				includeAttestations: true,
+				imageVerification:   imageVerificationOptions{trustRoot: []string{"key.pub"}},
+			},
+		},
+		{
+			name:    "invalid format",
+			opts:    getSBOMOptions{format: "json"},
+			wantErr: "invalid sbom format json",
+		},
+		{
+			name:    "attestations without trust root",
+			opts:    getSBOMOptions{format: sbomFormatCycloneDX, includeAttestations: true},
+			wantErr: "--image-trust-root is required to include attestations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tt.opts.validate()
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestWriteSBOM(t *testing.T) {
+	g := NewWithT(t)
+	s := &sbom.SBOM{ClusterName: "prod", Timestamp: time.Now()}
+
+	b := &bytes.Buffer{}
+	g.Expect(writeSBOM(b, s, sbomFormatSPDX)).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring(`"spdxVersion": "SPDX-2.3"`))
+
+	b.Reset()
+	g.Expect(writeSBOM(b, s, sbomFormatCycloneDX)).To(Succeed())
+	g.Expect(b.String()).To(ContainSubstring(`"bomFormat": "CycloneDX"`))
+}
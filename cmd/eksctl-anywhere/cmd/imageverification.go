@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/pflag"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/config"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/registry"
+	"github.com/aws/eks-anywhere/pkg/registrymirror"
+	"github.com/aws/eks-anywhere/pkg/signature"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const imageTrustRootFlag = "image-trust-root"
+
+// imageVerificationOptions configures the verification of the cosign signatures of the bundle images.
+// Verification is enabled by passing a trust root.
+type imageVerificationOptions struct {
+	trustRoot           []string
+	signaturesDir       string
+	requireAttestations bool
+	// tagResolver resolves the tags of the images. Defaults to the registry the images are pulled from.
+	tagResolver signature.TagResolver
+}
+
+func applyImageVerificationFlags(flagSet *pflag.FlagSet, o *imageVerificationOptions) {
+	flagSet.StringSliceVar(&o.trustRoot, imageTrustRootFlag, nil, "PEM files with the public keys or certificates trusted to sign the bundle images. Enables the verification of the cosign signatures of the images")
+	flagSet.StringVar(&o.signaturesDir, "image-signatures-dir", "", "Directory with the cosign signatures and attestations of the bundle images, used instead of the registry to read them offline")
+	flagSet.BoolVar(&o.requireAttestations, "require-image-attestations", false, "Require a cosign attestation signed by the trust root for every bundle image")
+}
+
+func (o imageVerificationOptions) enabled() bool {
+	return len(o.trustRoot) > 0
+}
+
+func (o imageVerificationOptions) validate() error {
+	if !o.enabled() && (o.signaturesDir != "" || o.requireAttestations) {
+		return fmt.Errorf("--%s is required to verify the image signatures", imageTrustRootFlag)
+	}
+
+	return nil
+}
+
+// imageVerifier builds the verifier of the bundle images. It reads the signatures from the signatures directory,
+// or from the registry of the images or signaturesMirror when not nil, and checks that the image tags point to
+// the signed digests in the registry of the images, or imagesMirror when not nil.
+// It returns nil when verification is disabled.
+func (o imageVerificationOptions) imageVerifier(signaturesMirror, imagesMirror *registrymirror.RegistryMirror) (artifacts.ImageVerifier, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	if !o.enabled() {
+		return nil, nil
+	}
+
+	resolver := o.tagResolver
+	if resolver == nil {
+		source, err := registrySource(imagesMirror)
+		if err != nil {
+			return nil, err
+		}
+		resolver = source
+	}
+
+	return o.signatureVerifier(signaturesMirror, signature.WithTagResolver(resolver))
+}
+
+// signatureVerifier builds the verifier of the bundle images from the trust root, it must be enabled.
+func (o imageVerificationOptions) signatureVerifier(mirror *registrymirror.RegistryMirror, opts ...signature.ImageVerifierOpt) (*signature.ImageVerifier, error) {
+	var trustRoot []crypto.PublicKey
+	for _, file := range o.trustRoot {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading image trust root: %v", err)
+		}
+		keys, err := signature.ParseTrustRoot(content)
+		if err != nil {
+			return nil, fmt.Errorf("reading image trust root %s: %v", file, err)
+		}
+		trustRoot = append(trustRoot, keys...)
+	}
+
+	if o.requireAttestations {
+		opts = append(opts, signature.WithRequiredAttestations())
+	}
+
+	if o.signaturesDir != "" {
+		return signature.NewImageVerifier(trustRoot, signature.NewDirectorySource(o.signaturesDir), opts...), nil
+	}
+
+	source, err := registrySource(mirror)
+	if err != nil {
+		return nil, err
+	}
+
+	return signature.NewImageVerifier(trustRoot, source, opts...), nil
+}
+
+// registrySource builds a source reading from the registry of the images, or from the mirror when not nil.
+func registrySource(mirror *registrymirror.RegistryMirror) (*signature.RegistrySource, error) {
+	client, err := imageSignaturesRegistryClient(mirror)
+	if err != nil {
+		return nil, err
+	}
+	var opts []signature.RegistrySourceOpt
+	if mirror != nil {
+		opts = append(opts, signature.WithRegistryMirror(mirror))
+	}
+
+	return signature.NewRegistrySource(client, opts...), nil
+}
+
+// verifyClusterImages verifies the images of all the versions bundles of a cluster spec
+// before a workflow starts using them.
+func (o imageVerificationOptions) verifyClusterImages(ctx context.Context, clusterSpec *cluster.Spec) error {
+	mirror := registrymirror.FromCluster(clusterSpec.Cluster)
+	verifier, err := o.imageVerifier(mirror, mirror)
+	if err != nil || verifier == nil {
+		return err
+	}
+
+	logger.Info("Verifying image signatures")
+	var images []releasev1.Image
+	for _, vb := range clusterSpec.VersionsBundles {
+		images = append(images, vb.VersionsBundle.Images()...)
+	}
+	if err := verifier.Verify(ctx, images...); err != nil {
+		return fmt.Errorf("verifying image signatures: %v", err)
+	}
+
+	return nil
+}
+
+func imageSignaturesRegistryClient(mirror *registrymirror.RegistryMirror) (*auth.Client, error) {
+	credentialStore := registry.NewCredentialStore()
+	if err := credentialStore.Init(); err != nil {
+		return nil, fmt.Errorf("reading registry credentials: %v", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	var mirrorCredential auth.Credential
+	if mirror != nil {
+		certificates, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, err
+		}
+		if mirror.CACertContent != "" && !certificates.AppendCertsFromPEM([]byte(mirror.CACertContent)) {
+			return nil, errors.New("parsing registry mirror CA certificate")
+		}
+		// #nosec G402
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:            certificates,
+			InsecureSkipVerify: mirror.InsecureSkipVerify,
+		}
+
+		if mirror.Auth {
+			username, password, err := config.ReadCredentials()
+			if err != nil {
+				return nil, err
+			}
+			mirrorCredential = auth.Credential{Username: username, Password: password}
+		}
+	}
+
+	client := &auth.Client{
+		Client: &http.Client{Transport: transport},
+		Cache:  auth.NewCache(),
+		Credential: func(_ context.Context, host string) (auth.Credential, error) {
+			if mirror != nil && mirror.Auth && host == mirror.BaseRegistry {
+				return mirrorCredential, nil
+			}
+			return credentialStore.Credential(host)
+		},
+	}
+	client.SetUserAgent("eksa")
+
+	return client, nil
+}
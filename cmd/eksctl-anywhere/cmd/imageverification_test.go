@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/signature"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const verifiedImageDigest = "sha256:0ae2a2c1b3c1a5b6f3b3a0d5c6f1e4f0c2a8c8c1f8e1f2a3b4c5d6e7f8091a2b"
+
+func writeImageSignature(t *testing.T, dir string, key *ecdsa.PrivateKey, imageDigest string) {
+	t.Helper()
+	payload := []byte(fmt.Sprintf(`{"critical":{"image":{"docker-manifest-digest":%q}}}`, imageDigest))
+	digest := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := json.Marshal(signature.ImageSignature{Base64Signature: base64.StdEncoding.EncodeToString(sig), Payload: payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sha256-"+imageDigest[len("sha256:"):]+".sig"), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTrustRoot(t *testing.T, dir string, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "cosign.pub")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+type fakeTagResolver map[string]string
+
+func (r fakeTagResolver) ResolveTag(_ context.Context, image releasev1.Image) (string, error) {
+	return r[image.URI], nil
+}
+
+func TestImageVerificationOptionsVerifyClusterImages(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	spec := test.NewClusterSpec(func(s *cluster.Spec) {
+		s.VersionsBundles["1.19"].Eksa.CliTools = releasev1.Image{URI: "public.ecr.aws/eks-anywhere/cli-tools:v1", ImageDigest: verifiedImageDigest}
+	})
+
+	tags := fakeTagResolver{"public.ecr.aws/eks-anywhere/cli-tools:v1": verifiedImageDigest}
+	o := imageVerificationOptions{
+		trustRoot:     []string{writeTrustRoot(t, dir, key)},
+		signaturesDir: dir,
+		tagResolver:   tags,
+	}
+	g.Expect(o.verifyClusterImages(ctx, spec)).To(MatchError(ContainSubstring("no valid signature for digest " + verifiedImageDigest)))
+
+	writeImageSignature(t, dir, key, verifiedImageDigest)
+	g.Expect(o.verifyClusterImages(ctx, spec)).To(Succeed())
+
+	tags["public.ecr.aws/eks-anywhere/cli-tools:v1"] = "sha256:" + strings.Repeat("1", 64)
+	g.Expect(o.verifyClusterImages(ctx, spec)).To(MatchError(ContainSubstring("image tag points to digest sha256:1111")))
+}
+
+func TestImageVerificationOptionsDisabled(t *testing.T) {
+	g := NewWithT(t)
+	verifier, err := imageVerificationOptions{}.imageVerifier(nil, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifier).To(BeNil())
+
+	_, err = imageVerificationOptions{requireAttestations: true}.imageVerifier(nil, nil)
+	g.Expect(err).To(MatchError("--image-trust-root is required to verify the image signatures"))
+}
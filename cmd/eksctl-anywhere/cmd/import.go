@@ -8,7 +8,7 @@ import (
 var importCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import resources",
-	Long:  "Use eksctl anywhere import to import resources, such as images and helm charts",
+	Long:  "Use eksctl anywhere import to import resources, such as images, helm charts and clusters",
 }
 
 func init() {
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"path/filepath"
 
@@ -15,7 +16,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/docker"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/helm"
-	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -51,6 +51,7 @@ func init() {
 	importImagesCmd.Flags().BoolVar(&importImagesCommand.includePackages, "include-packages", false, "Flag to indicate inclusion of curated packages in imported images")
 	importImagesCmd.Flag("include-packages").Deprecated = "use copy packages command"
 	importImagesCmd.Flags().BoolVar(&importImagesCommand.insecure, "insecure", false, "Flag to indicate skipping TLS verification while pushing helm charts and bundles")
+	applyImageVerificationFlags(importImagesCmd.Flags(), &importImagesCommand.imageVerificationOptions)
 }
 
 var importImagesCommand = ImportImagesCommand{}
@@ -61,6 +62,7 @@ type ImportImagesCommand struct {
 	BundlesFile      string
 	includePackages  bool
 	insecure         bool
+	imageVerificationOptions
 }
 
 func (c ImportImagesCommand) Call(ctx context.Context) error {
@@ -77,7 +79,22 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 		return err
 	}
 
-	bundle, err := bundles.Read(deps.ManifestReader, c.BundlesFile)
+	bundle, err := deps.ManifestReader.ReadBundles(c.BundlesFile)
+	if err != nil {
+		return err
+	}
+
+	// The images are verified once imported, checking that their tags in the registry point to the signed
+	// digests. The signatures are read from their original registries or from the signatures directory.
+	imageVerifier, err := c.imageVerifier(nil, &registrymirror.RegistryMirror{
+		BaseRegistry: c.RegistryEndpoint,
+		NamespacedRegistryMap: map[string]string{
+			constants.DefaultCoreEKSARegistry:        c.RegistryEndpoint,
+			constants.DefaultCuratedPackagesRegistry: c.RegistryEndpoint,
+		},
+		Auth:               true,
+		InsecureSkipVerify: c.insecure,
+	})
 	if err != nil {
 		return err
 	}
@@ -149,5 +166,15 @@ func (c ImportImagesCommand) Call(ctx context.Context) error {
 		FileImporter:       oras.NewFileRegistryImporter(c.RegistryEndpoint, username, password, artifactsFolder),
 	}
 
-	return importArtifacts.Run(context.WithValue(ctx, types.InsecureRegistry, c.insecure))
+	if err = importArtifacts.Run(context.WithValue(ctx, types.InsecureRegistry, c.insecure)); err != nil {
+		return err
+	}
+
+	if imageVerifier != nil {
+		if err = (artifacts.VerifyImages{Bundles: bundle, Verifier: imageVerifier}).Run(ctx); err != nil {
+			return fmt.Errorf("imported images failed verification, don't use them: %v", err)
+		}
+	}
+
+	return nil
 }
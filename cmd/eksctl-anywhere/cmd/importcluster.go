@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/templater"
+	"github.com/aws/eks-anywhere/pkg/validations"
+)
+
+type importClusterOptions struct {
+	kubeConfig        string
+	namespace         string
+	managementCluster string
+	outputFile        string
+	dryRun            bool
+}
+
+var imco = &importClusterOptions{}
+
+var importClusterCmd = &cobra.Command{
+	Use:   "cluster <cluster-name>",
+	Short: "Import an existing CAPI cluster into EKS Anywhere management",
+	Long: "This command builds the EKS Anywhere cluster, datacenter and machine configs equivalent to a CAPI cluster running in the " +
+		"eksa-system namespace of a management cluster, checks that the EKS Anywhere controller would reconcile the same CAPI objects " +
+		"and creates the configs so the controller adopts the cluster without rolling its machines. Docker (CAPD), vSphere (CAPV), " +
+		"CloudStack (CAPC), Nutanix (CAPX), Snow (CAPAS) and Tinkerbell (CAPT) clusters with a KubeadmControlPlane, and stacked or " +
+		"EtcdadmCluster external etcd, can be imported. The CAPI objects must follow the EKS Anywhere naming: the KubeadmControlPlane " +
+		"named after the cluster, the MachineDeployments <cluster-name>-<worker-node-group> and the EtcdadmCluster <cluster-name>-etcd",
+	PreRunE:      bindFlagsToViper,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clusterName, err := validations.ValidateClusterNameArg(args)
+		if err != nil {
+			return err
+		}
+		return imco.importCluster(cmd.Context(), clusterName)
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importClusterCmd)
+	importClusterCmd.Flags().StringVar(&imco.kubeConfig, "kubeconfig", "", "Management cluster kubeconfig file, defaults to the management cluster kubeconfig")
+	importClusterCmd.Flags().StringVarP(&imco.namespace, "namespace", "n", "default", "Namespace to create the EKS Anywhere objects in the management cluster")
+	importClusterCmd.Flags().StringVar(&imco.managementCluster, "management-cluster", "", "Name of the EKS Anywhere management cluster adopting the cluster")
+	importClusterCmd.Flags().StringVarP(&imco.outputFile, "output-file", "o", "", "File to write the generated cluster config to")
+	importClusterCmd.Flags().BoolVar(&imco.dryRun, "dry-run", false, "Generate and validate the cluster config without importing the cluster")
+	if err := importClusterCmd.MarkFlagRequired("management-cluster"); err != nil {
+		logger.Fatal(err, "marking flag as required")
+	}
+}
+
+func (o *importClusterOptions) importCluster(ctx context.Context, clusterName string) error {
+	client, closer, err := newManagementClient(ctx, o.kubeConfig, o.managementCluster)
+	if err != nil {
+		return err
+	}
+	defer closer()
+
+	importer := clusterimport.NewImporter(client, logger.Get(),
+		clusterimport.CloudStackProvider{},
+		clusterimport.DockerProvider{},
+		clusterimport.NutanixProvider{},
+		clusterimport.SnowProvider{},
+		clusterimport.TinkerbellProvider{},
+		clusterimport.VSphereProvider{},
+	)
+
+	config, err := importer.Synthesize(ctx, clusterName, clusterimport.Options{
+		Namespace:         o.namespace,
+		ManagementCluster: o.managementCluster,
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.outputFile != "" {
+		content, err := marshalConfig(config)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(o.outputFile, content, 0o644); err != nil {
+			return fmt.Errorf("writing cluster config file: %v", err)
+		}
+		logger.Info("Cluster config written", "file", o.outputFile)
+	}
+
+	logger.Info("Validating cluster can be imported without changes", "cluster", clusterName)
+	if err := importer.Validate(ctx, config); err != nil {
+		return err
+	}
+
+	if o.dryRun {
+		logger.Info("Cluster can be imported, skipping import in dry run", "cluster", clusterName)
+		return nil
+	}
+
+	if err := importer.Adopt(ctx, config); err != nil {
+		return err
+	}
+	logger.MarkSuccess("Cluster imported", "cluster", clusterName, "managementCluster", o.managementCluster)
+
+	return nil
+}
+
+func marshalConfig(config *cluster.Config) ([]byte, error) {
+	objs := config.ClusterAndChildren()
+	resources := make([][]byte, 0, len(objs))
+	for _, obj := range objs {
+		r, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("generating cluster yaml: %v", err)
+		}
+		resources = append(resources, r)
+	}
+
+	return templater.AppendYamlResources(resources...), nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+func TestMarshalConfig(t *testing.T) {
+	g := NewWithT(t)
+	config := &cluster.Config{
+		Cluster: &v1alpha1.Cluster{
+			TypeMeta:   metav1.TypeMeta{Kind: v1alpha1.ClusterKind, APIVersion: v1alpha1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+			Spec: v1alpha1.ClusterSpec{
+				DatacenterRef: v1alpha1.Ref{Kind: v1alpha1.DockerDatacenterKind, Name: "workload"},
+			},
+		},
+		DockerDatacenter: &v1alpha1.DockerDatacenterConfig{
+			TypeMeta:   metav1.TypeMeta{Kind: v1alpha1.DockerDatacenterKind, APIVersion: v1alpha1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		},
+	}
+
+	content, err := marshalConfig(config)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(content)).To(ContainSubstring("kind: Cluster\n"))
+	g.Expect(string(content)).To(ContainSubstring("\n---\n"))
+	g.Expect(string(content)).To(ContainSubstring("kind: DockerDatacenterConfig\n"))
+}
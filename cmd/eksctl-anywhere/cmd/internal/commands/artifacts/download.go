@@ -5,15 +5,14 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/logger"
-	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/version"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
 
 type Reader interface {
 	ReadBundlesForVersion(eksaVersion string) (*releasev1.Bundles, error)
+	ReadBundles(url string) (*releasev1.Bundles, error)
 	ReadImagesFromBundles(ctx context.Context, bundles *releasev1.Bundles) ([]releasev1.Image, error)
 	ReadChartsFromBundles(ctx context.Context, bundles *releasev1.Bundles) []releasev1.Image
 }
@@ -36,7 +35,6 @@ type Packager interface {
 
 type Download struct {
 	Reader                   Reader
-	FileReader               *files.Reader
 	Version                  version.Info
 	BundlesImagesDownloader  ImageMover
 	EksaToolsImageDownloader ImageMover
@@ -46,6 +44,8 @@ type Download struct {
 	DstFile                  string
 	ManifestDownloader       ManifestDownloader
 	BundlesOverride          string
+	// ImageVerifier verifies the images of the bundles before downloading them. Optional.
+	ImageVerifier ImageVerifier
 }
 
 func (d Download) Run(ctx context.Context) error {
@@ -56,7 +56,7 @@ func (d Download) Run(ctx context.Context) error {
 	var b *releasev1.Bundles
 	var err error
 	if d.BundlesOverride != "" {
-		b, err = bundles.Read(d.FileReader, d.BundlesOverride)
+		b, err = d.Reader.ReadBundles(d.BundlesOverride)
 		if err != nil {
 			return fmt.Errorf("reading bundles override: %v", err)
 		}
@@ -67,6 +67,12 @@ func (d Download) Run(ctx context.Context) error {
 		}
 	}
 
+	if d.ImageVerifier != nil {
+		if err = (VerifyImages{Bundles: b, Verifier: d.ImageVerifier}).Run(ctx); err != nil {
+			return err
+		}
+	}
+
 	toolsImage := b.DefaultEksAToolsImage().VersionedImage()
 	if err = d.EksaToolsImageDownloader.Move(ctx, toolsImage); err != nil {
 		return fmt.Errorf("downloading eksa tools image: %v", err)
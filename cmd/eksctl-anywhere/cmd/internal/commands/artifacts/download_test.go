@@ -127,3 +127,30 @@ func TestDownloadErrorReadingImages(t *testing.T) {
 
 	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError(ContainSubstring("downloading images: error reading images")))
 }
+
+func TestDownloadRunVerifyImages(t *testing.T) {
+	tt := newDownloadArtifactsTest(t)
+	verifier := mocks.NewMockImageVerifier(gomock.NewController(t))
+	tt.command.ImageVerifier = verifier
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+	verifier.EXPECT().Verify(tt.ctx, tt.bundles.Spec.VersionsBundles[0].Images())
+	tt.toolsDownloader.EXPECT().Move(tt.ctx, "tools:v1.0.0")
+	tt.reader.EXPECT().ReadImagesFromBundles(tt.ctx, tt.bundles).Return(tt.images, nil)
+	tt.mover.EXPECT().Move(tt.ctx, "image1:1", "image2:1")
+	tt.reader.EXPECT().ReadChartsFromBundles(tt.ctx, tt.bundles).Return(tt.charts)
+	tt.downloader.EXPECT().Download(tt.ctx, "chart:v1.0.0", "package-chart:v1.0.0")
+	tt.packager.EXPECT().Package("tmp-folder", "artifacts.tar")
+	tt.manifestDownloader.EXPECT().Download(tt.ctx, tt.bundles)
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(Succeed())
+}
+
+func TestDownloadErrorVerifyingImages(t *testing.T) {
+	tt := newDownloadArtifactsTest(t)
+	verifier := mocks.NewMockImageVerifier(gomock.NewController(t))
+	tt.command.ImageVerifier = verifier
+	tt.reader.EXPECT().ReadBundlesForVersion("v1.0.0").Return(tt.bundles, nil)
+	verifier.EXPECT().Verify(tt.ctx, gomock.Any()).Return(errors.New("no valid signature"))
+
+	tt.Expect(tt.command.Run(tt.ctx)).To(MatchError("verifying image signatures: no valid signature"))
+}
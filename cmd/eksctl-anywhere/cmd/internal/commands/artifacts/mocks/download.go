@@ -35,6 +35,21 @@ func (m *MockReader) EXPECT() *MockReaderMockRecorder {
 	return m.recorder
 }
 
+// ReadBundles mocks base method.
+func (m *MockReader) ReadBundles(url string) (*v1alpha1.Bundles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadBundles", url)
+	ret0, _ := ret[0].(*v1alpha1.Bundles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadBundles indicates an expected call of ReadBundles.
+func (mr *MockReaderMockRecorder) ReadBundles(url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBundles", reflect.TypeOf((*MockReader)(nil).ReadBundles), url)
+}
+
 // ReadBundlesForVersion mocks base method.
 func (m *MockReader) ReadBundlesForVersion(eksaVersion string) (*v1alpha1.Bundles, error) {
 	m.ctrl.T.Helper()
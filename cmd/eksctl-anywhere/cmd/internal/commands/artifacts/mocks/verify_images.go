@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cmd/eksctl-anywhere/cmd/internal/commands/artifacts/verify_images.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockImageVerifier is a mock of ImageVerifier interface.
+type MockImageVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageVerifierMockRecorder
+}
+
+// MockImageVerifierMockRecorder is the mock recorder for MockImageVerifier.
+type MockImageVerifierMockRecorder struct {
+	mock *MockImageVerifier
+}
+
+// NewMockImageVerifier creates a new mock instance.
+func NewMockImageVerifier(ctrl *gomock.Controller) *MockImageVerifier {
+	mock := &MockImageVerifier{ctrl: ctrl}
+	mock.recorder = &MockImageVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageVerifier) EXPECT() *MockImageVerifierMockRecorder {
+	return m.recorder
+}
+
+// Verify mocks base method.
+func (m *MockImageVerifier) Verify(ctx context.Context, images ...v1alpha1.Image) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range images {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Verify", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockImageVerifierMockRecorder) Verify(ctx interface{}, images ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, images...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockImageVerifier)(nil).Verify), varargs...)
+}
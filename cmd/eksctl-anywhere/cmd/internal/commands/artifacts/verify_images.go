@@ -0,0 +1,35 @@
+package artifacts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/logger"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// ImageVerifier verifies the signatures of images.
+type ImageVerifier interface {
+	Verify(ctx context.Context, images ...releasev1.Image) error
+}
+
+// VerifyImages verifies the signatures of the images of all the versions bundles.
+type VerifyImages struct {
+	Bundles  *releasev1.Bundles
+	Verifier ImageVerifier
+}
+
+// Run verifies the images of the bundles.
+func (v VerifyImages) Run(ctx context.Context) error {
+	logger.Info("Verifying image signatures")
+	var images []releasev1.Image
+	for i := range v.Bundles.Spec.VersionsBundles {
+		images = append(images, v.Bundles.Spec.VersionsBundles[i].Images()...)
+	}
+
+	if err := v.Verifier.Verify(ctx, images...); err != nil {
+		return fmt.Errorf("verifying image signatures: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package artifacts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts"
+	"github.com/aws/eks-anywhere/cmd/eksctl-anywhere/cmd/internal/commands/artifacts/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func TestVerifyImagesRun(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	verifier := mocks.NewMockImageVerifier(gomock.NewController(t))
+	bundles := &releasev1.Bundles{
+		Spec: releasev1.BundlesSpec{
+			VersionsBundles: []releasev1.VersionsBundle{
+				{Eksa: releasev1.EksaBundle{CliTools: releasev1.Image{URI: "tools:v1.0.0", ImageDigest: "sha256:1"}}},
+				{Eksa: releasev1.EksaBundle{CliTools: releasev1.Image{URI: "tools:v1.0.1", ImageDigest: "sha256:2"}}},
+			},
+		},
+	}
+	images := append(bundles.Spec.VersionsBundles[0].Images(), bundles.Spec.VersionsBundles[1].Images()...)
+	verifier.EXPECT().Verify(ctx, images)
+
+	command := artifacts.VerifyImages{Bundles: bundles, Verifier: verifier}
+	g.Expect(command.Run(ctx)).To(Succeed())
+}
+
+func TestVerifyImagesRunError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	verifier := mocks.NewMockImageVerifier(gomock.NewController(t))
+	verifier.EXPECT().Verify(ctx, gomock.Any()).Return(errors.New("no valid signature"))
+
+	command := artifacts.VerifyImages{Bundles: &releasev1.Bundles{}, Verifier: verifier}
+	g.Expect(command.Run(ctx)).To(MatchError("verifying image signatures: no valid signature"))
+}
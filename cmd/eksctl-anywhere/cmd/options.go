@@ -22,7 +22,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/files"
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/manifests"
-	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/types"
 	"github.com/aws/eks-anywhere/pkg/validations"
@@ -146,7 +145,7 @@ func getBundles(cliVersion version.Info, bundlesManifestURL string) (*releasev1.
 		return manifestReader.ReadBundlesForVersion(cliVersion.GitVersion)
 	}
 
-	return bundles.Read(reader, bundlesManifestURL)
+	return manifestReader.ReadBundles(bundlesManifestURL)
 }
 
 func getEksaRelease(cliVersion version.Info) (*releasev1.EksARelease, error) {
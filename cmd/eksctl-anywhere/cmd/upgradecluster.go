@@ -24,6 +24,7 @@ import (
 type upgradeClusterOptions struct {
 	clusterOptions
 	timeoutOptions
+	imageVerificationOptions
 	wConfig               string
 	forceClean            bool
 	hardwareCSVPath       string
@@ -38,6 +39,7 @@ var uc = &upgradeClusterOptions{
 			BMCOptions: &hardware.BMCOptions{
 				RPC: &hardware.RPCOpts{},
 			},
+			InventoryOptions: &hardware.InventoryOptions{},
 		},
 	},
 }
@@ -66,6 +68,7 @@ func init() {
 	upgradeCmd.AddCommand(upgradeClusterCmd)
 	applyClusterOptionFlags(upgradeClusterCmd.Flags(), &uc.clusterOptions)
 	applyTimeoutFlags(upgradeClusterCmd.Flags(), &uc.timeoutOptions)
+	applyImageVerificationFlags(upgradeClusterCmd.Flags(), &uc.imageVerificationOptions)
 	applyTinkerbellHardwareFlag(upgradeClusterCmd.Flags(), &uc.hardwareCSVPath)
 	upgradeClusterCmd.Flags().StringVarP(&uc.wConfig, "w-config", "w", "", "Kubeconfig file to use when upgrading a workload cluster")
 	upgradeClusterCmd.Flags().BoolVar(&uc.forceClean, "force-cleanup", false, "Force deletion of previously created bootstrap cluster")
@@ -73,6 +76,7 @@ func init() {
 	upgradeClusterCmd.Flags().StringArrayVar(&uc.skipValidations, "skip-validations", []string{}, fmt.Sprintf("Bypass upgrade validations by name. Valid arguments you can pass are --skip-validations=%s", strings.Join(upgradevalidations.SkippableValidations[:], ",")))
 	aflag.MarkRequired(createClusterCmd.Flags(), aflag.ClusterConfig.Name)
 	tinkerbellFlags(upgradeClusterCmd.Flags(), uc.providerOptions.Tinkerbell.BMCOptions.RPC)
+	tinkerbellInventoryFlags(upgradeClusterCmd.Flags(), uc.providerOptions.Tinkerbell.InventoryOptions)
 }
 
 // nolint:gocyclo
@@ -122,6 +126,10 @@ func (uc *upgradeClusterOptions) upgradeCluster(cmd *cobra.Command, args []strin
 		return err
 	}
 
+	if err := uc.verifyClusterImages(ctx, clusterSpec); err != nil {
+		return err
+	}
+
 	cliConfig := buildCliConfig(clusterSpec)
 	dirs, err := uc.directoriesToMount(clusterSpec, cliConfig)
 	if err != nil {
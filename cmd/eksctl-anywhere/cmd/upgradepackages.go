@@ -18,6 +18,8 @@ type upgradePackageOptions struct {
 	kubeConfig      string
 	clusterName     string
 	bundlesOverride string
+	// pin lists the packages that keep their current version.
+	pin []string
 }
 
 var upo = &upgradePackageOptions{}
@@ -33,6 +35,8 @@ func init() {
 		"", "Cluster to upgrade.")
 	upgradePackagesCommand.Flags().StringVar(&upo.bundlesOverride, "bundles-override", "",
 		"Override default Bundles manifest (not recommended)")
+	upgradePackagesCommand.Flags().StringSliceVar(&upo.pin, "pin", nil,
+		"Packages to keep at their current version during the bundle upgrade. Use upgrade plan packages to review the upgrade")
 
 	err := upgradePackagesCommand.MarkFlagRequired("bundle-version")
 	if err != nil {
@@ -74,5 +78,18 @@ func upgradePackages(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	return b.UpgradeBundle(ctx, activeController, upo.bundleVersion)
+	var pinned []curatedpackages.PinnedPackage
+	if len(upo.pin) > 0 {
+		if pinned, err = b.PinPackages(ctx, upo.pin, upo.bundleVersion); err != nil {
+			return err
+		}
+	}
+	if err := b.UpgradeBundle(ctx, activeController, upo.bundleVersion); err != nil {
+		if unpinErr := b.UnpinPackages(ctx, pinned); unpinErr != nil {
+			return fmt.Errorf("%v, reverting pinned packages: %v", err, unpinErr)
+		}
+		return err
+	}
+
+	return nil
 }
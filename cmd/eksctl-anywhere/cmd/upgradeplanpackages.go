@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+	"github.com/aws/eks-anywhere/pkg/kubeconfig"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+type upgradePlanPackagesOptions struct {
+	bundleVersion string
+	// kubeConfig is an optional kubeconfig file to use when querying an
+	// existing cluster.
+	kubeConfig      string
+	clusterName     string
+	bundlesOverride string
+}
+
+var uppo = &upgradePlanPackagesOptions{}
+
+var upgradePlanPackagesCmd = &cobra.Command{
+	Use:          "packages",
+	Short:        "Lists the current and target versions of the curated packages for a package bundle upgrade",
+	Long:         "Provides, for each curated package installed in a cluster, the current and target versions when upgrading to a new package bundle, the default values that change and the breaking changes",
+	PreRunE:      preRunPackages,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := uppo.upgradePlanPackages(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to display upgrade plan: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	upgradePlanCmd.AddCommand(upgradePlanPackagesCmd)
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.bundleVersion, "bundle-version", "", "Bundle version to upgrade to")
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.kubeConfig, "kubeconfig", "", "Path to an optional kubeconfig file to use.")
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.clusterName, "cluster", "", "Cluster to upgrade.")
+	upgradePlanPackagesCmd.Flags().StringVar(&uppo.bundlesOverride, "bundles-override", "", "Override default Bundles manifest (not recommended)")
+	upgradePlanPackagesCmd.Flags().StringVarP(&output, outputFlagName, "o", outputDefault, "Output format: text|json")
+
+	if err := upgradePlanPackagesCmd.MarkFlagRequired("bundle-version"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+	if err := upgradePlanPackagesCmd.MarkFlagRequired("cluster"); err != nil {
+		log.Fatalf("Error marking flag as required: %v", err)
+	}
+}
+
+func (o *upgradePlanPackagesOptions) upgradePlanPackages(ctx context.Context) error {
+	kubeConfig, err := kubeconfig.ResolveAndValidateFilename(o.kubeConfig, "")
+	if err != nil {
+		return err
+	}
+
+	deps, err := NewDependenciesForPackages(ctx, WithMountPaths(kubeConfig), WithBundlesOverride(o.bundlesOverride))
+	if err != nil {
+		return fmt.Errorf("unable to initialize executables: %v", err)
+	}
+
+	b := curatedpackages.NewBundleReader(kubeConfig, o.clusterName, deps.Kubectl, nil, nil)
+	plan, err := b.PlanUpgrade(ctx, o.bundleVersion)
+	if err != nil {
+		return err
+	}
+
+	serializedPlan, err := serializePackagesUpgradePlan(plan, output)
+	if err != nil {
+		return err
+	}
+
+	logger.V(0).Info(serializedPlan)
+
+	return nil
+}
+
+func serializePackagesUpgradePlan(plan *curatedpackages.UpgradePlan, outputFormat string) (string, error) {
+	switch outputFormat {
+	case outputText:
+		return serializePackagesUpgradePlanToText(plan)
+	case outputJson:
+		jsonPlan, err := json.Marshal(plan)
+		if err != nil {
+			return "", fmt.Errorf("failed serializing the packages upgrade plan to json: %v", err)
+		}
+		return string(jsonPlan), nil
+	default:
+		return "", fmt.Errorf("invalid output format [%s]", outputFormat)
+	}
+}
+
+func serializePackagesUpgradePlanToText(plan *curatedpackages.UpgradePlan) (string, error) {
+	if len(plan.Packages) == 0 {
+		return "No curated packages installed", nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPACKAGE\tCURRENT VERSION\tTARGET VERSION")
+	for _, p := range plan.Packages {
+		target := p.TargetVersion
+		if target == "" {
+			target = "-"
+		}
+		if p.Pinned {
+			target += " (pinned)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Name, p.PackageName, p.CurrentVersion, target)
+	}
+	if err := w.Flush(); err != nil {
+		return "", fmt.Errorf("failed flushing table writer: %v", err)
+	}
+
+	for _, p := range plan.Packages {
+		if len(p.ChangedDefaults) == 0 && len(p.BreakingChanges) == 0 && p.PinError == "" {
+			continue
+		}
+		fmt.Fprintf(&buffer, "\n%s:\n", p.Name)
+		for _, c := range p.ChangedDefaults {
+			fmt.Fprintf(&buffer, "  Changed default %s\n", c)
+		}
+		for _, c := range p.BreakingChanges {
+			fmt.Fprintf(&buffer, "  Breaking change: %s\n", c)
+		}
+		if p.PinError != "" {
+			fmt.Fprintf(&buffer, "  Can't be pinned: %s\n", p.PinError)
+		}
+	}
+
+	return buffer.String(), nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+func TestSerializePackagesUpgradePlan(t *testing.T) {
+	g := NewWithT(t)
+	plan := &curatedpackages.UpgradePlan{
+		CurrentBundle: "v1-27-125",
+		TargetBundle:  "v1-27-126",
+		Packages: []curatedpackages.PackageUpgrade{
+			{
+				Name:           "my-harbor",
+				PackageName:    "harbor",
+				CurrentVersion: "2.7.1",
+				TargetVersion:  "2.9.1",
+				ChangedDefaults: []curatedpackages.DefaultChange{
+					{Key: "config.replicas", Current: float64(1), Target: float64(2)},
+				},
+				BreakingChanges: []string{"configuration not valid for the new version: config.database is required"},
+			},
+			{Name: "my-certs", PackageName: "cert-manager", CurrentVersion: "1.9.1", TargetVersion: "1.9.1", Pinned: true},
+			{Name: "my-old", PackageName: "old", CurrentVersion: "1.0.0", BreakingChanges: []string{"package is not in bundle v1-27-126"}},
+			{Name: "my-adot", PackageName: "adot", CurrentVersion: "0.40.0", TargetVersion: "0.41.0", PinError: "version 0.40.0 has no digest in bundle v1-27-125"},
+		},
+	}
+
+	text, err := serializePackagesUpgradePlan(plan, outputText)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(text).To(Equal(`NAME        PACKAGE        CURRENT VERSION   TARGET VERSION
+my-harbor   harbor         2.7.1             2.9.1
+my-certs    cert-manager   1.9.1             1.9.1 (pinned)
+my-old      old            1.0.0             -
+my-adot     adot           0.40.0            0.41.0
+
+my-harbor:
+  Changed default config.replicas: 1 -> 2
+  Breaking change: configuration not valid for the new version: config.database is required
+
+my-old:
+  Breaking change: package is not in bundle v1-27-126
+
+my-adot:
+  Can't be pinned: version 0.40.0 has no digest in bundle v1-27-125
+`))
+
+	json, err := serializePackagesUpgradePlan(plan, outputJson)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(json).To(ContainSubstring(`{"name":"my-certs","packageName":"cert-manager","currentVersion":"1.9.1","targetVersion":"1.9.1","pinned":true}`))
+
+	_, err = serializePackagesUpgradePlan(plan, "yaml")
+	g.Expect(err).To(MatchError("invalid output format [yaml]"))
+
+	text, err = serializePackagesUpgradePlan(&curatedpackages.UpgradePlan{}, outputText)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(text).To(Equal("No curated packages installed"))
+}
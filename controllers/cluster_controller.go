@@ -9,6 +9,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
@@ -53,6 +54,7 @@ type ClusterReconciler struct {
 	packagesClient             PackagesClient
 	machineHealthCheck         MachineHealthCheckReconciler
 	vSpherefailureDomainMover  FailureDomainApplier
+	etcdMaintenance            EtcdMaintenanceReconciler
 }
 
 // PackagesClient handles curated packages operations from within the cluster
@@ -80,6 +82,11 @@ type MachineHealthCheckReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) error
 }
 
+// EtcdMaintenanceReconciler checks and defragments the etcd members of an eks-a cluster.
+type EtcdMaintenanceReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error)
+}
+
 // ClusterValidator runs cluster level preflight validations before it goes to provider reconciler.
 type ClusterValidator interface {
 	ValidateManagementClusterName(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error
@@ -88,6 +95,13 @@ type ClusterValidator interface {
 // ClusterReconcilerOption allows to configure the ClusterReconciler.
 type ClusterReconcilerOption func(*ClusterReconciler)
 
+// WithEtcdMaintenanceReconciler configures the reconciler that runs the etcd maintenance of the clusters.
+func WithEtcdMaintenanceReconciler(etcdMaintenance EtcdMaintenanceReconciler) ClusterReconcilerOption {
+	return func(r *ClusterReconciler) {
+		r.etcdMaintenance = etcdMaintenance
+	}
+}
+
 // SpecBuilder builds a cluster specification from an EKS Anywhere Cluster object.
 type SpecBuilder interface {
 	BuildSpec(ctx context.Context, eksaCluster *anywherev1.Cluster) (*c.Spec, error)
@@ -268,6 +282,7 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager, log logr.Logger)
 // +kubebuilder:rbac:groups="cluster.x-k8s.io",resources=machinedeployments,verbs=list;watch;get;patch;update;create;delete
 // +kubebuilder:rbac:groups="cluster.x-k8s.io",resources=clusters,verbs=list;watch;get;patch;update;create;delete
 // +kubebuilder:rbac:groups="cluster.x-k8s.io",resources=machinehealthchecks,verbs=list;watch;get;patch;create
+// +kubebuilder:rbac:groups="cluster.x-k8s.io",resources=machines,verbs=list;watch;get
 // +kubebuilder:rbac:groups=clusterctl.cluster.x-k8s.io,resources=providers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes,verbs=list;get;watch;patch;update;create;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=create;get;list;update;watch;delete
@@ -382,7 +397,20 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 			cluster.ClearFailure()
 		}
 
-		return ctrl.Result{}, nil
+		// The machines can still be rolling out after the spec is reconciled, the upgrade is
+		// only complete once the cluster is ready.
+		if !v1beta1conditions.IsTrue(cluster, anywherev1.ReadyCondition) {
+			rolledBack, err := r.rollBackFailedUpgrade(ctx, log, cluster, aggregatedGeneration, time.Now())
+			if err != nil || rolledBack {
+				return ctrl.Result{}, err
+			}
+		}
+
+		if err := r.saveUpgradeRollbackSnapshot(ctx, log, cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return r.etcdMaintenanceReconcile(ctx, log, cluster)
 	}
 
 	return r.reconcile(ctx, log, cluster, aggregatedGeneration)
@@ -394,6 +422,25 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 	var reconcileResult controller.Result
 	var err error
 
+	now := time.Now()
+	ctx, deferredRollouts := deferMachineRollouts(ctx, cluster, now)
+
+	rolledBack := cluster.IsUpgradeRolledBack(aggregatedGeneration)
+	if !rolledBack {
+		v1beta1conditions.Delete(cluster, anywherev1.RolledBackCondition)
+		if rolledBack, err = r.rollBackFailedUpgrade(ctx, log, cluster, aggregatedGeneration, now); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if rolledBack {
+		// The rolled back changes are held back like the ones waiting for a maintenance window.
+		ctx, deferredRollouts = clusters.DeferMachineRollouts(ctx)
+	} else if deferredRollouts == nil {
+		// Waiting for the maintenance window doesn't count towards the rollback timeout.
+		startUpgradeRollbackTimer(cluster, now)
+	}
+
 	reconcileResult, err = r.preClusterProviderReconcile(ctx, log, cluster)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -421,12 +468,26 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
-	// At the end of the reconciliation, if there have been no requeues or errors, we update the cluster's status.
-	// NOTE: This update must be the last step in the reconciliation process to denote the complete reconciliation.
-	// No other mutating changes or reconciliations must happen in this loop after this step, so all such changes must
-	// be placed above this line.
-	cluster.Status.ReconciledGeneration = cluster.Generation
-	cluster.Status.ChildrenReconciledGeneration = aggregatedGeneration
+	// The reconciliation is not complete while there are changes waiting for the maintenance window, the
+	// generations are only updated once they have been applied.
+	if rolledBack {
+		log.Info("Machine rollouts held back after rolling back a failed upgrade", "objects", deferredRollouts.Objects())
+		v1beta1conditions.Delete(cluster, anywherev1.PendingUpgradeCondition)
+	} else if deferredRollouts.Deferred() {
+		nextStart := cluster.Spec.MaintenanceWindow.NextStart(now)
+		log.Info("Machine rollouts deferred until the maintenance window opens", "objects", deferredRollouts.Objects(), "nextStart", nextStart)
+		v1beta1conditions.MarkTrueWithNegativePolarity(cluster, anywherev1.PendingUpgradeCondition, anywherev1.MaintenanceWindowClosedReason, clusterv1.ConditionSeverityInfo,
+			"Changes that roll machines are deferred until the maintenance window opens at %s", nextStart.Format(time.RFC3339))
+	} else {
+		v1beta1conditions.Delete(cluster, anywherev1.PendingUpgradeCondition)
+
+		// At the end of the reconciliation, if there have been no requeues or errors, we update the cluster's status.
+		// NOTE: This update must be the last step in the reconciliation process to denote the complete reconciliation.
+		// No other mutating changes or reconciliations must happen in this loop after this step, so all such changes must
+		// be placed above this line.
+		cluster.Status.ReconciledGeneration = cluster.Generation
+		cluster.Status.ChildrenReconciledGeneration = aggregatedGeneration
+	}
 
 	// TODO(eksa-controller-SME): properly handle packages reconcile error and not triggering machine upgrade when
 	// packages reconcile is still in progress.
@@ -441,7 +502,118 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, log logr.Logger, clus
 		return reconcileResult.ToCtrlResult(), nil
 	}
 
-	return ctrl.Result{}, nil
+	result, err := r.etcdMaintenanceReconcile(ctx, log, cluster)
+	if err != nil || rolledBack || !deferredRollouts.Deferred() {
+		return result, err
+	}
+
+	return requeueBeforeWindow(result, cluster.Spec.MaintenanceWindow, now), nil
+}
+
+// deferMachineRollouts holds back the changes that roll machines when the cluster has a maintenance
+// window and it's closed. The returned DeferredRollouts is nil when the rollouts are allowed.
+func deferMachineRollouts(ctx context.Context, cluster *anywherev1.Cluster, now time.Time) (context.Context, *clusters.DeferredRollouts) {
+	if w := cluster.Spec.MaintenanceWindow; w == nil || w.Contains(now) {
+		return ctx, nil
+	}
+
+	return clusters.DeferMachineRollouts(ctx)
+}
+
+// rollBackFailedUpgrade rolls back the changes to the cluster machines when the upgrade in progress failed
+// according to the cluster rollback policy. It returns true when the upgrade was rolled back.
+func (r *ClusterReconciler) rollBackFailedUpgrade(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster, aggregatedGeneration int64, now time.Time) (bool, error) {
+	status := cluster.Status.UpgradeRollback
+	if cluster.Spec.UpgradeRollbackPolicy == nil || status == nil || status.UpgradeStartTime == nil {
+		return false, nil
+	}
+
+	reason, message, err := clusters.UpgradeFailure(ctx, r.client, cluster, status.UpgradeStartTime.Time, now)
+	if err != nil || reason == "" {
+		return false, err
+	}
+
+	log.Info("Rolling back failed upgrade", "reason", message, "generation", status.SnapshotGeneration)
+	err = clusters.RollBackUpgrade(ctx, log, r.client, cluster)
+	if errors.Is(err, clusters.ErrRollbackNotSupported) {
+		// The upgrade keeps going, the condition is reported until it completes or the cluster spec changes.
+		log.Info("Failed upgrade can't be rolled back", "error", err.Error())
+		v1beta1conditions.MarkFalse(cluster, anywherev1.RolledBackCondition, anywherev1.RollbackNotSupportedReason, clusterv1.ConditionSeverityWarning,
+			"%s, %v", message, err)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	status.UpgradeStartTime = nil
+	status.RolledBackGeneration = cluster.Generation
+	status.RolledBackChildrenGeneration = aggregatedGeneration
+	// The rolled back generation is not reconciled anymore, the controller keeps holding back
+	// its changes that roll machines until the cluster spec changes.
+	cluster.Status.ReconciledGeneration = status.SnapshotGeneration
+	cluster.Status.ChildrenReconciledGeneration = status.SnapshotChildrenGeneration
+	v1beta1conditions.MarkTrueWithNegativePolarity(cluster, anywherev1.RolledBackCondition, reason, clusterv1.ConditionSeverityWarning,
+		"Changes that roll machines were rolled back to generation %d: %s. They are applied again when the cluster spec changes", status.SnapshotGeneration, message)
+
+	return true, nil
+}
+
+// startUpgradeRollbackTimer records when the upgrade of a cluster with a rollback policy starts. There is nothing
+// to roll back to until the cluster has been fully reconciled once with the policy.
+func startUpgradeRollbackTimer(cluster *anywherev1.Cluster, now time.Time) {
+	status := cluster.Status.UpgradeRollback
+	if cluster.Spec.UpgradeRollbackPolicy == nil || status == nil || status.SnapshotGeneration == 0 || status.UpgradeStartTime != nil {
+		return
+	}
+	status.UpgradeStartTime = &metav1.Time{Time: now}
+}
+
+// saveUpgradeRollbackSnapshot saves the objects the upgrades of a fully reconciled cluster are rolled back to,
+// once the cluster is ready. That completes the upgrade in progress, if any.
+func (r *ClusterReconciler) saveUpgradeRollbackSnapshot(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
+	if cluster.Spec.UpgradeRollbackPolicy == nil {
+		cluster.Status.UpgradeRollback = nil
+		return nil
+	}
+
+	if !v1beta1conditions.IsTrue(cluster, anywherev1.ReadyCondition) {
+		return nil
+	}
+
+	if cluster.Status.UpgradeRollback == nil {
+		cluster.Status.UpgradeRollback = &anywherev1.UpgradeRollbackStatus{}
+	}
+	status := cluster.Status.UpgradeRollback
+	status.UpgradeStartTime = nil
+
+	if status.SnapshotGeneration == cluster.Status.ReconciledGeneration &&
+		status.SnapshotChildrenGeneration == cluster.Status.ChildrenReconciledGeneration {
+		return nil
+	}
+
+	log.Info("Saving upgrade rollback snapshot", "generation", cluster.Status.ReconciledGeneration)
+	if err := clusters.SaveRollbackSnapshot(ctx, r.client, cluster); err != nil {
+		return err
+	}
+	status.SnapshotGeneration = cluster.Status.ReconciledGeneration
+	status.SnapshotChildrenGeneration = cluster.Status.ChildrenReconciledGeneration
+
+	return nil
+}
+
+// requeueBeforeWindow makes sure the cluster is reconciled again when the maintenance window opens.
+func requeueBeforeWindow(result ctrl.Result, window *anywherev1.MaintenanceWindow, now time.Time) ctrl.Result {
+	nextStart := window.NextStart(now)
+	if nextStart.IsZero() {
+		return result
+	}
+
+	if untilWindow := nextStart.Sub(now); result.RequeueAfter <= 0 || untilWindow < result.RequeueAfter {
+		result.RequeueAfter = untilWindow
+	}
+
+	return result
 }
 
 func (r *ClusterReconciler) preClusterProviderReconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
@@ -470,6 +642,10 @@ func (r *ClusterReconciler) preClusterProviderReconcile(ctx context.Context, log
 		}
 	}
 
+	if err := validateDualStackOS(ctx, r.client, cluster); err != nil {
+		return controller.Result{}, err
+	}
+
 	if err := validateEksaRelease(ctx, r.client, cluster); err != nil {
 		return controller.Result{}, err
 	}
@@ -525,6 +701,21 @@ func (r *ClusterReconciler) packagesReconcile(ctx context.Context, log logr.Logg
 	return controller.Result{}, nil
 }
 
+// etcdMaintenanceReconcile runs the etcd maintenance. It runs even when there are no changes to reconcile,
+// since it has to check the etcd members periodically, so it's the last step of the reconciliation.
+func (r *ClusterReconciler) etcdMaintenanceReconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (ctrl.Result, error) {
+	if r.etcdMaintenance == nil {
+		return ctrl.Result{}, nil
+	}
+
+	result, err := r.etcdMaintenance.Reconcile(ctx, log, cluster)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "running etcd maintenance")
+	}
+
+	return result.ToCtrlResult(), nil
+}
+
 func (r *ClusterReconciler) updateStatus(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) error {
 	// When EKS-A cluster is fully deleted, we do not need to update the status. Without this check
 	// the subsequent patch operations would fail if the status is updated after it is fully deleted.
@@ -740,6 +931,10 @@ func patchCluster(ctx context.Context, patchHelper *v1beta1patch.Helper, cluster
 			anywherev1.ControlPlaneReadyCondition,
 			anywherev1.WorkersReadyCondition,
 			anywherev1.DefaultCNIConfiguredCondition,
+			anywherev1.CNIMigratedCondition,
+			anywherev1.ServiceLoadBalancerReadyCondition,
+			anywherev1.PendingUpgradeCondition,
+			anywherev1.RolledBackCondition,
 		}},
 	}, patchOpts...)
 
@@ -794,6 +989,31 @@ func (r *ClusterReconciler) setDefaultBundlesRefOrEksaVersion(ctx context.Contex
 	return fmt.Errorf("could not set default values")
 }
 
+// validateDualStackOS checks a dual-stack cluster doesn't use Bottlerocket machines, which don't set the kubelet
+// node IP of each IP family. The Cluster webhook can't read the machine configs, so this is checked before the
+// provider reconciler creates any machine.
+func validateDualStackOS(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
+	if !cluster.Spec.ClusterNetwork.IsDualStack() {
+		return nil
+	}
+
+	config, err := c.NewDefaultConfigClientBuilder().Build(ctx, clientutil.NewKubeClient(client), cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range config.ChildObjects() {
+		machineConfig, ok := obj.(interface{ OSFamily() anywherev1.OSFamily })
+		if ok && machineConfig.OSFamily() == anywherev1.Bottlerocket {
+			err := fmt.Errorf("dual-stack clusters are not supported for bottlerocket, machine config %s uses it", obj.GetName())
+			cluster.SetFailure(anywherev1.MachineConfigInvalidReason, err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
 func validateEksaRelease(ctx context.Context, client client.Client, cluster *anywherev1.Cluster) error {
 	if cluster.Spec.EksaVersion == nil {
 		return nil
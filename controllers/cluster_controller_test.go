@@ -127,6 +127,7 @@ func newVsphereClusterReconcilerTest(t *testing.T, objs ...runtime.Object) *vsph
 		cniReconciler,
 		nil,
 		ipValidator,
+		nil,
 	)
 	registry := clusters.NewProviderClusterReconcilerRegistryBuilder().
 		Add(anywherev1.VSphereDatacenterKind, reconciler).
@@ -168,6 +169,7 @@ func newVsphereClusterReconcilerWithFailureDomainsTest(t *testing.T, cl client.C
 		cniReconciler,
 		nil,
 		ipValidator,
+		nil,
 	)
 	registry := clusters.NewProviderClusterReconcilerRegistryBuilder().
 		Add(anywherev1.VSphereDatacenterKind, reconciler).
@@ -243,6 +245,379 @@ func TestClusterReconcilerReconcileSelfManagedCluster(t *testing.T) {
 	g.Expect(result).To(Equal(ctrl.Result{}))
 }
 
+func TestClusterReconcilerReconcileEtcdMaintenance(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := test.DevEksaVersion()
+
+	selfManagedCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "my-management-cluster",
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube132,
+			EksaVersion:       &version,
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			EtcdMaintenance: &anywherev1.EtcdMaintenanceConfiguration{
+				SSHKeySecretName: "etcd-ssh",
+			},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+
+	kcp := testKubeadmControlPlaneFromCluster(selfManagedCluster)
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	etcdMaintenance := mocks.NewMockEtcdMaintenanceReconciler(mockCtrl)
+
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	c := fake.NewClientBuilder().WithRuntimeObjects(selfManagedCluster, kcp, test.EKSARelease(), createBundle(), createEKSDRelease()).
+		WithStatusSubresource(selfManagedCluster).
+		Build()
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+	providerReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster))
+	mhcReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).Return(nil)
+	etcdMaintenance.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(selfManagedCluster)).
+		Return(controller.Result{Result: &ctrl.Result{RequeueAfter: time.Hour}}, nil)
+
+	r := controllers.NewClusterReconciler(c, registry, iam, clusterValidator, mockPkgs, mhcReconciler, nil, controllers.WithEtcdMaintenanceReconciler(etcdMaintenance))
+	result, err := r.Reconcile(ctx, clusterRequest(selfManagedCluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: time.Hour}))
+}
+
+func TestClusterReconcilerReconcileEtcdMaintenanceWithoutChanges(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Generation = 1
+
+	kcp := testKubeadmControlPlaneFromCluster(config.Cluster)
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), kcp}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	client := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	etcdMaintenance := mocks.NewMockEtcdMaintenanceReconciler(mockCtrl)
+
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	etcdMaintenance.EXPECT().Reconcile(ctx, gomock.Any(), sameName(config.Cluster)).Return(controller.Result{}, errors.New("ssh error"))
+
+	r := controllers.NewClusterReconciler(client, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mocks.NewMockMachineHealthCheckReconciler(mockCtrl), nil,
+		controllers.WithEtcdMaintenanceReconciler(etcdMaintenance))
+
+	_, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).To(MatchError(ContainSubstring("running etcd maintenance: ssh error")))
+}
+
+func maintenanceWindowTestCluster(window *anywherev1.MaintenanceWindow) *anywherev1.Cluster {
+	version := test.DevEksaVersion()
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "my-management-cluster",
+			Generation: 2,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube132,
+			EksaVersion:       &version,
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				CNIConfig: &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				},
+			},
+			MaintenanceWindow: window,
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+}
+
+// closedMaintenanceWindow returns a daily window that opens 12 hours from now.
+func closedMaintenanceWindow() *anywherev1.MaintenanceWindow {
+	return &anywherev1.MaintenanceWindow{
+		Schedule: fmt.Sprintf("0 %d * * *", (time.Now().UTC().Hour()+12)%24),
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+}
+
+func TestClusterReconcilerReconcileMaintenanceWindowClosed(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := maintenanceWindowTestCluster(closedMaintenanceWindow())
+	kcp := testKubeadmControlPlaneFromCluster(cluster)
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster, kcp, test.EKSARelease(), createBundle(), createEKSDRelease()).
+		WithStatusSubresource(cluster).
+		Build()
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).
+		DoAndReturn(func(ctx context.Context, log logr.Logger, _ *anywherev1.Cluster) (controller.Result, error) {
+			clusters.DeferredRolloutsFrom(ctx).Add(log, kcp)
+			return controller.Result{}, nil
+		})
+	mhcReconciler.EXPECT().Reconcile(gomock.Any(), gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mhcReconciler, nil)
+	result, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 11*time.Hour))
+	g.Expect(result.RequeueAfter).To(BeNumerically("<=", 12*time.Hour))
+
+	api := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+	g.Expect(api.Status.ReconciledGeneration).To(Equal(int64(1)))
+	condition := v1beta1conditions.Get(api, anywherev1.PendingUpgradeCondition)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(apiv1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(anywherev1.MaintenanceWindowClosedReason))
+	g.Expect(condition.Message).To(ContainSubstring("deferred until the maintenance window opens at"))
+}
+
+func TestClusterReconcilerReconcileMaintenanceWindowClosedNoRollouts(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := maintenanceWindowTestCluster(closedMaintenanceWindow())
+	v1beta1conditions.MarkTrueWithNegativePolarity(cluster, anywherev1.PendingUpgradeCondition, anywherev1.MaintenanceWindowClosedReason, clusterv1.ConditionSeverityInfo, "")
+	kcp := testKubeadmControlPlaneFromCluster(cluster)
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster, kcp, test.EKSARelease(), createBundle(), createEKSDRelease()).
+		WithStatusSubresource(cluster).
+		Build()
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).
+		DoAndReturn(func(ctx context.Context, _ logr.Logger, _ *anywherev1.Cluster) (controller.Result, error) {
+			g.Expect(clusters.DeferredRolloutsFrom(ctx)).ToNot(BeNil())
+			return controller.Result{}, nil
+		})
+	mhcReconciler.EXPECT().Reconcile(gomock.Any(), gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mhcReconciler, nil)
+	result, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+
+	api := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+	g.Expect(api.Status.ReconciledGeneration).To(Equal(int64(2)))
+	g.Expect(v1beta1conditions.Has(api, anywherev1.PendingUpgradeCondition)).To(BeFalse())
+}
+
+func TestClusterReconcilerReconcileMaintenanceWindowOpen(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := maintenanceWindowTestCluster(&anywherev1.MaintenanceWindow{
+		Schedule: "* * * * *",
+		Duration: metav1.Duration{Duration: time.Hour},
+	})
+	kcp := testKubeadmControlPlaneFromCluster(cluster)
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster, kcp, test.EKSARelease(), createBundle(), createEKSDRelease()).
+		WithStatusSubresource(cluster).
+		Build()
+	providerReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster))
+	mhcReconciler.EXPECT().Reconcile(ctx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mhcReconciler, nil)
+	result, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+}
+
+func TestClusterReconcilerReconcileRollBackFailedUpgrade(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Spec.UpgradeRollbackPolicy = &anywherev1.UpgradeRollbackPolicy{
+		Timeout: &metav1.Duration{Duration: 30 * time.Minute},
+	}
+
+	kcp := testKubeadmControlPlaneFromCluster(config.Cluster)
+	kcp.Spec.Version = "v1.31.0-eks-1-31-1"
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), kcp}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	fakeClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	g.Expect(clusters.SaveRollbackSnapshot(ctx, fakeClient, config.Cluster)).To(Succeed())
+
+	// The new spec was applied an hour ago, and the control plane is still rolling out.
+	api := &anywherev1.Cluster{}
+	g.Expect(fakeClient.Get(ctx, clusterRequest(config.Cluster).NamespacedName, api)).To(Succeed())
+	api.Spec.ControlPlaneConfiguration.Labels = map[string]string{"upgraded": "true"}
+	g.Expect(fakeClient.Update(ctx, api)).To(Succeed())
+	api.Status.ReconciledGeneration = api.Generation
+	api.Status.ChildrenReconciledGeneration = 0
+	api.Status.UpgradeRollback = &anywherev1.UpgradeRollbackStatus{
+		SnapshotGeneration: api.Generation - 1,
+		UpgradeStartTime:   &metav1.Time{Time: time.Now().Add(-time.Hour)},
+	}
+	g.Expect(fakeClient.Status().Update(ctx, api)).To(Succeed())
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(kcp), kcp)).To(Succeed())
+	kcp.Spec.Version = "v1.31.2-eks-1-31-5"
+	g.Expect(fakeClient.Update(ctx, kcp)).To(Succeed())
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	r := controllers.NewClusterReconciler(fakeClient, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mocks.NewMockMachineHealthCheckReconciler(mockCtrl), nil)
+	_, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(fakeClient.Get(ctx, clusterRequest(config.Cluster).NamespacedName, api)).To(Succeed())
+	g.Expect(api.Status.ReconciledGeneration).To(Equal(api.Generation - 1))
+	g.Expect(api.Status.UpgradeRollback.RolledBackGeneration).To(Equal(api.Generation))
+	g.Expect(api.Status.UpgradeRollback.UpgradeStartTime).To(BeNil())
+	condition := v1beta1conditions.Get(api, anywherev1.RolledBackCondition)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(apiv1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(anywherev1.UpgradeTimeoutReason))
+
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(kcp), kcp)).To(Succeed())
+	g.Expect(kcp.Spec.Version).To(Equal("v1.31.0-eks-1-31-1"))
+}
+
+func TestClusterReconcilerReconcileRollBackFailedUpgradeMinorVersionChanged(t *testing.T) {
+	config, bundles := baseTestVsphereCluster()
+	version := test.DevEksaVersion()
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Spec.UpgradeRollbackPolicy = &anywherev1.UpgradeRollbackPolicy{
+		Timeout: &metav1.Duration{Duration: 30 * time.Minute},
+	}
+
+	kcp := testKubeadmControlPlaneFromCluster(config.Cluster)
+	kcp.Spec.Version = "v1.31.0-eks-1-31-1"
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := []runtime.Object{config.Cluster, bundles, test.EKSARelease(), kcp}
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	fakeClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+	g.Expect(clusters.SaveRollbackSnapshot(ctx, fakeClient, config.Cluster)).To(Succeed())
+
+	// The new spec was applied an hour ago, and the control plane is still rolling out.
+	api := &anywherev1.Cluster{}
+	g.Expect(fakeClient.Get(ctx, clusterRequest(config.Cluster).NamespacedName, api)).To(Succeed())
+	api.Spec.KubernetesVersion = anywherev1.Kube132
+	g.Expect(fakeClient.Update(ctx, api)).To(Succeed())
+	api.Status.ReconciledGeneration = api.Generation
+	api.Status.ChildrenReconciledGeneration = 0
+	api.Status.UpgradeRollback = &anywherev1.UpgradeRollbackStatus{
+		SnapshotGeneration: api.Generation - 1,
+		UpgradeStartTime:   &metav1.Time{Time: time.Now().Add(-time.Hour)},
+	}
+	g.Expect(fakeClient.Status().Update(ctx, api)).To(Succeed())
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(kcp), kcp)).To(Succeed())
+	kcp.Spec.Version = "v1.32.0-eks-1-32-1"
+	g.Expect(fakeClient.Update(ctx, kcp)).To(Succeed())
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	r := controllers.NewClusterReconciler(fakeClient, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mocks.NewMockMachineHealthCheckReconciler(mockCtrl), nil)
+	_, err := r.Reconcile(ctx, clusterRequest(config.Cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(fakeClient.Get(ctx, clusterRequest(config.Cluster).NamespacedName, api)).To(Succeed())
+	g.Expect(api.Status.ReconciledGeneration).To(Equal(api.Generation))
+	g.Expect(api.Status.UpgradeRollback.RolledBackGeneration).To(BeZero())
+	condition := v1beta1conditions.Get(api, anywherev1.RolledBackCondition)
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(apiv1.ConditionFalse))
+	g.Expect(condition.Reason).To(Equal(anywherev1.RollbackNotSupportedReason))
+	g.Expect(condition.Message).To(ContainSubstring("minor version changed from v1.31.0-eks-1-31-1 to v1.32.0-eks-1-32-1"))
+
+	// The control plane is left to complete the upgrade.
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(kcp), kcp)).To(Succeed())
+	g.Expect(kcp.Spec.Version).To(Equal("v1.32.0-eks-1-32-1"))
+}
+
+func TestClusterReconcilerReconcileRolledBackUpgradeHoldsBackRollouts(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := maintenanceWindowTestCluster(nil)
+	cluster.Generation = 2
+	cluster.Spec.UpgradeRollbackPolicy = &anywherev1.UpgradeRollbackPolicy{MaxUnhealthyMachines: ptr.Int(1)}
+	cluster.Status.UpgradeRollback = &anywherev1.UpgradeRollbackStatus{
+		SnapshotGeneration:   1,
+		RolledBackGeneration: 2,
+	}
+	v1beta1conditions.MarkTrueWithNegativePolarity(cluster, anywherev1.RolledBackCondition, anywherev1.UnhealthyMachinesReason, clusterv1.ConditionSeverityWarning, "")
+	kcp := testKubeadmControlPlaneFromCluster(cluster)
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	mhcReconciler := mocks.NewMockMachineHealthCheckReconciler(mockCtrl)
+	c := fake.NewClientBuilder().WithRuntimeObjects(cluster, kcp, test.EKSARelease(), createBundle(), createEKSDRelease()).
+		WithStatusSubresource(cluster).
+		Build()
+	providerReconciler.EXPECT().Reconcile(gomock.Any(), gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).
+		DoAndReturn(func(ctx context.Context, log logr.Logger, _ *anywherev1.Cluster) (controller.Result, error) {
+			clusters.DeferredRolloutsFrom(ctx).Add(log, kcp)
+			return controller.Result{}, nil
+		})
+	mhcReconciler.EXPECT().Reconcile(gomock.Any(), gomock.AssignableToTypeOf(logr.Logger{}), sameName(cluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(c, newRegistryMock(providerReconciler), mocks.NewMockAWSIamConfigReconciler(mockCtrl),
+		mocks.NewMockClusterValidator(mockCtrl), mocks.NewMockPackagesClient(mockCtrl), mhcReconciler, nil)
+	_, err := r.Reconcile(ctx, clusterRequest(cluster))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	api := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), api)).To(Succeed())
+	g.Expect(api.Status.ReconciledGeneration).To(Equal(int64(1)))
+	g.Expect(api.Status.UpgradeRollback.UpgradeStartTime).To(BeNil())
+	g.Expect(v1beta1conditions.IsTrue(api, anywherev1.RolledBackCondition)).To(BeTrue())
+	g.Expect(v1beta1conditions.Has(api, anywherev1.PendingUpgradeCondition)).To(BeFalse())
+}
+
 func TestClusterReconcilerReconcileUnclearedClusterFailure(t *testing.T) {
 	config, bundles := baseTestVsphereCluster()
 	version := test.DevEksaVersion()
@@ -1933,6 +2308,60 @@ func TestClusterReconcilerNotAvailableEksaVersion(t *testing.T) {
 	g.Expect(eksaCluster.Status.FailureMessage).To(HaveValue(Equal(expectedError)))
 }
 
+func TestClusterReconcilerDualStackBottlerocket(t *testing.T) {
+	version := test.DevEksaVersion()
+	config, _ := baseTestVsphereCluster()
+	config.Cluster.Name = "test-cluster"
+	config.Cluster.Spec.ManagementCluster = anywherev1.ManagementCluster{Name: "management-cluster"}
+	config.Cluster.Spec.BundlesRef = nil
+	config.Cluster.Spec.EksaVersion = &version
+	config.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00:1::/56"}
+	config.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.96.0.0/12", "fd00:2::/108"}
+	for _, m := range config.VSphereMachineConfigs {
+		m.Spec.OSFamily = anywherev1.Bottlerocket
+	}
+
+	mgmt := config.DeepCopy()
+	mgmt.Cluster.Name = "management-cluster"
+
+	g := NewWithT(t)
+
+	objs := make([]runtime.Object, 0, 4+len(config.ChildObjects()))
+	objs = append(objs, config.Cluster, mgmt.Cluster)
+
+	for _, o := range config.ChildObjects() {
+		objs = append(objs, o)
+	}
+
+	testClient := fake.NewClientBuilder().WithRuntimeObjects(objs...).
+		WithStatusSubresource(config.Cluster).
+		Build()
+
+	mockCtrl := gomock.NewController(t)
+	providerReconciler := mocks.NewMockProviderClusterReconciler(mockCtrl)
+	iam := mocks.NewMockAWSIamConfigReconciler(mockCtrl)
+	clusterValidator := mocks.NewMockClusterValidator(mockCtrl)
+	registry := newRegistryMock(providerReconciler)
+	mockPkgs := mocks.NewMockPackagesClient(mockCtrl)
+
+	ctx := context.Background()
+	log := testr.New(t)
+	logCtx := ctrl.LoggerInto(ctx, log)
+
+	iam.EXPECT().EnsureCASecret(logCtx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).Return(controller.Result{}, nil)
+	clusterValidator.EXPECT().ValidateManagementClusterName(logCtx, gomock.AssignableToTypeOf(logr.Logger{}), sameName(config.Cluster)).Return(nil)
+
+	r := controllers.NewClusterReconciler(testClient, registry, iam, clusterValidator, mockPkgs, nil, nil)
+
+	req := clusterRequest(config.Cluster)
+	_, err := r.Reconcile(logCtx, req)
+
+	g.Expect(err).To(MatchError(ContainSubstring("dual-stack clusters are not supported for bottlerocket")))
+	eksaCluster := &anywherev1.Cluster{}
+	g.Expect(testClient.Get(ctx, req.NamespacedName, eksaCluster)).To(Succeed())
+	g.Expect(eksaCluster.Status.FailureReason).To(HaveValue(Equal(anywherev1.MachineConfigInvalidReason)))
+}
+
 func TestValidateExtendedKubernetesVersionSupport_BundleNotFoundError(t *testing.T) {
 	version := anywherev1.EksaVersion("v0.22.0") // Use version >= v0.22.0 to avoid skip
 	config, _ := baseTestVsphereCluster()
@@ -0,0 +1,386 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// clusterUpgradePolicyRequeueAfter is how often an upgrading policy checks the health of its clusters.
+const clusterUpgradePolicyRequeueAfter = 30 * time.Second
+
+// ClusterUpgradePolicyReconciler reconciles a ClusterUpgradePolicy object.
+type ClusterUpgradePolicyReconciler struct {
+	client               client.Client
+	remoteClientRegistry RemoteClientRegistry
+	log                  logr.Logger
+}
+
+// NewClusterUpgradePolicyReconciler returns a new instance of ClusterUpgradePolicyReconciler.
+func NewClusterUpgradePolicyReconciler(client client.Client, remoteClientRegistry RemoteClientRegistry) *ClusterUpgradePolicyReconciler {
+	return &ClusterUpgradePolicyReconciler{
+		client:               client,
+		remoteClientRegistry: remoteClientRegistry,
+		log:                  ctrl.Log.WithName("ClusterUpgradePolicyController"),
+	}
+}
+
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusterupgradepolicies,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusterupgradepolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=anywhere.eks.amazonaws.com,resources=clusters,verbs=get;list;watch;update;patch
+
+// Reconcile reconciles a ClusterUpgradePolicy object.
+func (r *ClusterUpgradePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
+	log := r.log.WithValues("ClusterUpgradePolicy", req.NamespacedName)
+
+	log.Info("Reconciling ClusterUpgradePolicy object")
+	policy := &anywherev1.ClusterUpgradePolicy{}
+	if err := r.client.Get(ctx, req.NamespacedName, policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !policy.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(policy, r.client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		patchOpts := []patch.Option{}
+		// Patch ObservedGeneration only if the reconciliation completed without error.
+		if reterr == nil {
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
+		}
+		// Always attempt to patch the object and status after each reconciliation.
+		if err := patchHelper.Patch(ctx, policy, patchOpts...); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	return r.reconcile(ctx, log, policy)
+}
+
+func (r *ClusterUpgradePolicyReconciler) reconcile(ctx context.Context, log logr.Logger, policy *anywherev1.ClusterUpgradePolicy) (ctrl.Result, error) {
+	if err := policy.Validate(); err != nil {
+		log.Error(err, "Invalid ClusterUpgradePolicy")
+		policy.Status.Phase = anywherev1.ClusterUpgradePolicyInvalid
+		policy.Status.FailureMessage = err.Error()
+		return ctrl.Result{}, nil
+	}
+
+	// A paused policy keeps the status of the last reconciliation, including the failed clusters.
+	if policy.Spec.Paused {
+		log.Info("ClusterUpgradePolicy is paused")
+		policy.Status.Phase = anywherev1.ClusterUpgradePolicyPaused
+		return ctrl.Result{}, nil
+	}
+	policy.Status.FailureMessage = ""
+
+	clusterList := &anywherev1.ClusterList{}
+	if err := r.client.List(ctx, clusterList, client.InNamespace(policy.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing clusters: %v", err)
+	}
+
+	clusters := make([]*anywherev1.Cluster, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		if policy.Selects(&clusterList.Items[i]) {
+			clusters = append(clusters, &clusterList.Items[i])
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Name < clusters[j].Name })
+
+	previous := make(map[string]anywherev1.ClusterUpgradeStatus, len(policy.Status.Clusters))
+	for _, s := range policy.Status.Clusters {
+		previous[s.Name] = s
+	}
+
+	now := time.Now()
+	statuses := make([]anywherev1.ClusterUpgradeStatus, 0, len(clusters))
+	for _, c := range clusters {
+		s, err := r.clusterUpgradeStatus(ctx, policy, c, previous[c.Name], now)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		statuses = append(statuses, s)
+	}
+	policy.Status.Clusters = statuses
+	policy.Status.TotalClusters = len(clusters)
+
+	defer func() {
+		policy.Status.UpgradedClusters = 0
+		for _, s := range policy.Status.Clusters {
+			if s.Phase == anywherev1.ClusterUpgradeSucceeded {
+				policy.Status.UpgradedClusters++
+			}
+		}
+	}()
+
+	if failed := failedClusterUpgrade(statuses); failed != nil {
+		pauseClusterUpgradePolicy(log, policy, failed)
+		return ctrl.Result{}, nil
+	}
+
+	wave := currentUpgradeWave(policy, statuses)
+	policy.Status.CurrentWave = wave
+	if wave == "" {
+		log.Info("All clusters are upgraded")
+		policy.Status.Phase = anywherev1.ClusterUpgradePolicyCompleted
+		return ctrl.Result{}, nil
+	}
+	policy.Status.Phase = anywherev1.ClusterUpgradePolicyUpgrading
+
+	if err := r.upgradeWave(ctx, log, policy, clusters, wave, now); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: clusterUpgradePolicyRequeueAfter}, nil
+}
+
+// upgradeWave starts the upgrade of the pending clusters of the wave, keeping the
+// number of clusters upgrading at the same time under the max concurrency.
+func (r *ClusterUpgradePolicyReconciler) upgradeWave(ctx context.Context, log logr.Logger, policy *anywherev1.ClusterUpgradePolicy, clusters []*anywherev1.Cluster, wave string, now time.Time) error {
+	upgrading := 0
+	for _, s := range policy.Status.Clusters {
+		if s.Wave == wave && s.Phase == anywherev1.ClusterUpgradeInProgress {
+			upgrading++
+		}
+	}
+
+	for i := range policy.Status.Clusters {
+		if upgrading >= policy.MaxConcurrency() {
+			return nil
+		}
+
+		s := &policy.Status.Clusters[i]
+		if s.Wave != wave || s.Phase != anywherev1.ClusterUpgradePending {
+			continue
+		}
+
+		cluster := clusters[i]
+		if err := policy.UpgradeCluster(cluster); err != nil {
+			s.Phase = anywherev1.ClusterUpgradeFailed
+			s.Message = fmt.Sprintf("upgrade is not supported: %v", err)
+			pauseClusterUpgradePolicy(log, policy, s)
+			return nil
+		}
+
+		log.Info("Upgrading cluster", "cluster", cluster.Name, "wave", wave)
+		if err := r.client.Update(ctx, cluster); err != nil {
+			if !apierrors.IsInvalid(err) && !apierrors.IsForbidden(err) {
+				return fmt.Errorf("upgrading cluster %s: %v", cluster.Name, err)
+			}
+			s.Phase = anywherev1.ClusterUpgradeFailed
+			s.Message = fmt.Sprintf("upgrade was rejected: %v", err)
+			pauseClusterUpgradePolicy(log, policy, s)
+			return nil
+		}
+
+		s.Phase = anywherev1.ClusterUpgradeInProgress
+		s.StartTime = &metav1.Time{Time: now}
+		s.Message = "upgrade started"
+		upgrading++
+	}
+
+	return nil
+}
+
+// clusterUpgradeStatus computes the upgrade status of a cluster from its current state and its previous status.
+func (r *ClusterUpgradePolicyReconciler) clusterUpgradeStatus(ctx context.Context, policy *anywherev1.ClusterUpgradePolicy, cluster *anywherev1.Cluster, previous anywherev1.ClusterUpgradeStatus, now time.Time) (anywherev1.ClusterUpgradeStatus, error) {
+	status := anywherev1.ClusterUpgradeStatus{
+		Name:  cluster.Name,
+		Wave:  policy.WaveFor(cluster),
+		Phase: anywherev1.ClusterUpgradePending,
+	}
+
+	if !policy.IsAtTarget(cluster) {
+		return status, nil
+	}
+
+	failures, err := r.healthCheck(ctx, policy, cluster)
+	if err != nil {
+		return status, err
+	}
+	if len(failures) == 0 {
+		status.Phase = anywherev1.ClusterUpgradeSucceeded
+		return status, nil
+	}
+
+	status.Phase = anywherev1.ClusterUpgradeInProgress
+	status.Message = strings.Join(failures, "; ")
+
+	// The upgrade doesn't start until the maintenance window of the cluster opens, so
+	// the health checks timeout only counts from there.
+	if v1beta1conditions.IsTrue(cluster, anywherev1.PendingUpgradeCondition) {
+		status.Message = "waiting for the maintenance window"
+		return status, nil
+	}
+
+	// The clock restarts for the clusters that failed before the policy was resumed.
+	status.StartTime = &metav1.Time{Time: now}
+	if previous.StartTime != nil && previous.Phase != anywherev1.ClusterUpgradeFailed {
+		status.StartTime = previous.StartTime
+	}
+
+	if timeout := policy.HealthCheckTimeout(); now.Sub(status.StartTime.Time) > timeout {
+		status.Phase = anywherev1.ClusterUpgradeFailed
+		status.Message = fmt.Sprintf("health checks didn't pass in %s: %s", timeout, status.Message)
+	}
+
+	return status, nil
+}
+
+// healthCheck returns the failed health checks of a cluster.
+func (r *ClusterUpgradePolicyReconciler) healthCheck(ctx context.Context, policy *anywherev1.ClusterUpgradePolicy, cluster *anywherev1.Cluster) ([]string, error) {
+	if cluster.Status.ReconciledGeneration != cluster.Generation {
+		return []string{"cluster spec is not reconciled yet"}, nil
+	}
+
+	var failures []string
+	if cluster.Status.FailureMessage != nil {
+		failures = append(failures, fmt.Sprintf("cluster has a failure: %s", *cluster.Status.FailureMessage))
+	}
+	if !v1beta1conditions.IsTrue(cluster, anywherev1.ReadyCondition) {
+		failures = append(failures, "cluster is not ready")
+	}
+
+	for _, check := range policy.Spec.HealthChecks {
+		if check.Condition != "" {
+			if !v1beta1conditions.IsTrue(cluster, check.Condition) {
+				failures = append(failures, fmt.Sprintf("%s: condition %s is not true", check.Name, check.Condition))
+			}
+			continue
+		}
+
+		// Only the clusters passing the previous checks are checked remotely.
+		if len(failures) > 0 {
+			continue
+		}
+
+		message, err := r.checkDeployment(ctx, cluster, check.Deployment)
+		if err != nil {
+			return nil, err
+		}
+		if message != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", check.Name, message))
+		}
+	}
+
+	return failures, nil
+}
+
+func (r *ClusterUpgradePolicyReconciler) checkDeployment(ctx context.Context, cluster *anywherev1.Cluster, ref *anywherev1.DeploymentReference) (string, error) {
+	remoteClient, err := r.remoteClientRegistry.GetClient(ctx, GetNamespacedNameType(cluster.Name, constants.EksaSystemNamespace))
+	if err != nil {
+		return "", fmt.Errorf("getting client for cluster %s: %v", cluster.Name, err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := remoteClient.Get(ctx, GetNamespacedNameType(ref.Name, ref.Namespace), deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("deployment %s/%s not found", ref.Namespace, ref.Name), nil
+		}
+		return "", fmt.Errorf("getting deployment %s/%s in cluster %s: %v", ref.Namespace, ref.Name, cluster.Name, err)
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation ||
+		deployment.Status.UpdatedReplicas != replicas ||
+		deployment.Status.AvailableReplicas != replicas {
+		return fmt.Sprintf("deployment %s/%s has %d/%d replicas available", ref.Namespace, ref.Name, deployment.Status.AvailableReplicas, replicas), nil
+	}
+
+	return "", nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterUpgradePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&anywherev1.ClusterUpgradePolicy{}).
+		Watches(
+			&anywherev1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToPolicies),
+		).
+		Complete(r)
+}
+
+// clusterToPolicies returns a request for each ClusterUpgradePolicy in the namespace of the cluster.
+func (r *ClusterUpgradePolicyReconciler) clusterToPolicies(ctx context.Context, o client.Object) []reconcile.Request {
+	policies := &anywherev1.ClusterUpgradePolicyList{}
+	if err := r.client.List(ctx, policies, client.InNamespace(o.GetNamespace())); err != nil {
+		r.log.Error(err, "Listing ClusterUpgradePolicies", "namespace", o.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(policies.Items))
+	for _, p := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: GetNamespacedNameType(p.Name, p.Namespace)})
+	}
+	return requests
+}
+
+func failedClusterUpgrade(statuses []anywherev1.ClusterUpgradeStatus) *anywherev1.ClusterUpgradeStatus {
+	for i := range statuses {
+		if statuses[i].Phase == anywherev1.ClusterUpgradeFailed {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// currentUpgradeWave returns the first wave with clusters not upgraded yet, or an empty string when all of them are.
+func currentUpgradeWave(policy *anywherev1.ClusterUpgradePolicy, statuses []anywherev1.ClusterUpgradeStatus) string {
+	for _, wave := range policy.WaveNames() {
+		for _, s := range statuses {
+			if s.Wave == wave && s.Phase != anywherev1.ClusterUpgradeSucceeded {
+				return wave
+			}
+		}
+	}
+	return ""
+}
+
+func pauseClusterUpgradePolicy(log logr.Logger, policy *anywherev1.ClusterUpgradePolicy, failed *anywherev1.ClusterUpgradeStatus) {
+	log.Info("Pausing ClusterUpgradePolicy after a failed cluster upgrade", "cluster", failed.Name, "reason", failed.Message)
+	policy.Spec.Paused = true
+	policy.Status.Phase = anywherev1.ClusterUpgradePolicyPaused
+	policy.Status.FailureMessage = fmt.Sprintf("upgrade of cluster %s failed: %s", failed.Name, failed.Message)
+}
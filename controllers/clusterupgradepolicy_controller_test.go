@@ -0,0 +1,404 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/controllers/mocks"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func TestClusterUpgradePolicyReconcileStartsFirstWave(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	policy.Spec.MaxConcurrency = 2
+	clusters := []client.Object{
+		upgradePolicyCluster("canary-a", "canary", anywherev1.Kube129),
+		upgradePolicyCluster("canary-b", "canary", anywherev1.Kube129),
+		upgradePolicyCluster("canary-c", "canary", anywherev1.Kube129),
+		upgradePolicyCluster("prod-a", "prod", anywherev1.Kube129),
+		upgradePolicyCluster("other", "other", anywherev1.Kube129),
+		upgradePolicyManagementCluster(),
+	}
+	c := clusterUpgradePolicyClient(policy, clusters...)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	result, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "canary-a").Spec.KubernetesVersion).To(Equal(anywherev1.Kube130))
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "canary-b").Spec.KubernetesVersion).To(Equal(anywherev1.Kube130))
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "canary-c").Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "prod-a").Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "mgmt").Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Status.Phase).To(Equal(anywherev1.ClusterUpgradePolicyUpgrading))
+	g.Expect(got.Status.CurrentWave).To(Equal("canary"))
+	g.Expect(got.Status.TotalClusters).To(Equal(5))
+	g.Expect(got.Status.UpgradedClusters).To(Equal(0))
+	g.Expect(clusterUpgradePhases(got)).To(Equal(map[string]anywherev1.ClusterUpgradePhase{
+		"canary-a": anywherev1.ClusterUpgradeInProgress,
+		"canary-b": anywherev1.ClusterUpgradeInProgress,
+		"canary-c": anywherev1.ClusterUpgradePending,
+		"other":    anywherev1.ClusterUpgradePending,
+		"prod-a":   anywherev1.ClusterUpgradePending,
+	}))
+}
+
+func TestClusterUpgradePolicyReconcileNextWave(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	clusters := []client.Object{
+		readyUpgradePolicyCluster(upgradePolicyCluster("canary-a", "canary", anywherev1.Kube130)),
+		upgradePolicyCluster("prod-a", "prod", anywherev1.Kube129),
+		upgradePolicyCluster("prod-b", "prod", anywherev1.Kube129),
+	}
+	c := clusterUpgradePolicyClient(policy, clusters...)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "prod-a").Spec.KubernetesVersion).To(Equal(anywherev1.Kube130))
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "prod-b").Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Status.CurrentWave).To(Equal("prod"))
+	g.Expect(got.Status.UpgradedClusters).To(Equal(1))
+}
+
+func TestClusterUpgradePolicyReconcileWaitsForUpgradingClusters(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	policy.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		{Name: "canary-a", Wave: "canary", Phase: anywherev1.ClusterUpgradeInProgress, StartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)}},
+	}
+	clusters := []client.Object{
+		upgradePolicyCluster("canary-a", "canary", anywherev1.Kube130),
+		upgradePolicyCluster("canary-b", "canary", anywherev1.Kube129),
+	}
+	c := clusterUpgradePolicyClient(policy, clusters...)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "canary-b").Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Spec.Paused).To(BeFalse())
+	g.Expect(got.Status.Clusters[0].Message).To(Equal("cluster is not ready"))
+	g.Expect(got.Status.Clusters[0].StartTime.Time).To(BeTemporally("~", policy.Status.Clusters[0].StartTime.Time, time.Second))
+}
+
+func TestClusterUpgradePolicyReconcilePausesOnHealthCheckTimeout(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	policy.Spec.HealthChecks = []anywherev1.ClusterHealthCheck{
+		{Name: "workers", Condition: anywherev1.WorkersReadyCondition},
+	}
+	policy.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		{Name: "canary-a", Wave: "canary", Phase: anywherev1.ClusterUpgradeInProgress, StartTime: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}},
+	}
+	clusters := []client.Object{
+		readyUpgradePolicyCluster(upgradePolicyCluster("canary-a", "canary", anywherev1.Kube130)),
+		upgradePolicyCluster("canary-b", "canary", anywherev1.Kube129),
+	}
+	c := clusterUpgradePolicyClient(policy, clusters...)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	result, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(reconcile.Result{}))
+
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "canary-b").Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Spec.Paused).To(BeTrue())
+	g.Expect(got.Status.Phase).To(Equal(anywherev1.ClusterUpgradePolicyPaused))
+	g.Expect(got.Status.FailureMessage).To(Equal("upgrade of cluster canary-a failed: health checks didn't pass in 1h0m0s: workers: condition WorkersReady is not true"))
+	g.Expect(got.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradeFailed))
+}
+
+func TestClusterUpgradePolicyReconcilePausesOnVersionSkew(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	clusters := []client.Object{
+		upgradePolicyCluster("canary-a", "canary", anywherev1.Kube128),
+	}
+	c := clusterUpgradePolicyClient(policy, clusters...)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "canary-a").Spec.KubernetesVersion).To(Equal(anywherev1.Kube128))
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Spec.Paused).To(BeTrue())
+	g.Expect(got.Status.FailureMessage).To(ContainSubstring("only +1 minor version skew is supported"))
+	g.Expect(got.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradeFailed))
+}
+
+func TestClusterUpgradePolicyReconcileResumeRestartsHealthChecks(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	policy.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		{Name: "canary-a", Wave: "canary", Phase: anywherev1.ClusterUpgradeFailed, StartTime: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}},
+	}
+	clusters := []client.Object{
+		upgradePolicyCluster("canary-a", "canary", anywherev1.Kube130),
+	}
+	c := clusterUpgradePolicyClient(policy, clusters...)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Spec.Paused).To(BeFalse())
+	g.Expect(got.Status.Phase).To(Equal(anywherev1.ClusterUpgradePolicyUpgrading))
+	g.Expect(got.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradeInProgress))
+	g.Expect(got.Status.Clusters[0].StartTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+}
+
+func TestClusterUpgradePolicyReconcileWaitsForMaintenanceWindow(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	policy.Status.Clusters = []anywherev1.ClusterUpgradeStatus{
+		{Name: "canary-a", Wave: "canary", Phase: anywherev1.ClusterUpgradeInProgress, StartTime: &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}},
+	}
+	cluster := upgradePolicyCluster("canary-a", "canary", anywherev1.Kube130)
+	v1beta1conditions.MarkTrueWithNegativePolarity(cluster, anywherev1.PendingUpgradeCondition, anywherev1.MaintenanceWindowClosedReason, clusterv1.ConditionSeverityInfo, "")
+	c := clusterUpgradePolicyClient(policy, cluster)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Spec.Paused).To(BeFalse())
+	g.Expect(got.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradeInProgress))
+	g.Expect(got.Status.Clusters[0].Message).To(Equal("waiting for the maintenance window"))
+	g.Expect(got.Status.Clusters[0].StartTime).To(BeNil())
+}
+
+func TestClusterUpgradePolicyReconcileDeploymentHealthCheck(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+	policy := clusterUpgradePolicy()
+	policy.Spec.HealthChecks = []anywherev1.ClusterHealthCheck{
+		{Name: "ingress", Deployment: &anywherev1.DeploymentReference{Namespace: "ingress", Name: "controller"}},
+	}
+	c := clusterUpgradePolicyClient(policy, readyUpgradePolicyCluster(upgradePolicyCluster("canary-a", "canary", anywherev1.Kube130)))
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "controller", Namespace: "ingress"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+		Status:     appsv1.DeploymentStatus{UpdatedReplicas: 2, AvailableReplicas: 1},
+	}
+	remoteClient := fake.NewClientBuilder().WithObjects(deployment).Build()
+	clientRegistry.EXPECT().GetClient(ctx, types.NamespacedName{Name: "canary-a", Namespace: constants.EksaSystemNamespace}).Return(remoteClient, nil)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, clientRegistry)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Status.Clusters[0].Phase).To(Equal(anywherev1.ClusterUpgradeInProgress))
+	g.Expect(got.Status.Clusters[0].Message).To(Equal("ingress: deployment ingress/controller has 1/2 replicas available"))
+}
+
+func TestClusterUpgradePolicyReconcileDeploymentHealthCheckError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+	policy := clusterUpgradePolicy()
+	policy.Spec.HealthChecks = []anywherev1.ClusterHealthCheck{
+		{Name: "ingress", Deployment: &anywherev1.DeploymentReference{Namespace: "ingress", Name: "controller"}},
+	}
+	c := clusterUpgradePolicyClient(policy, readyUpgradePolicyCluster(upgradePolicyCluster("canary-a", "canary", anywherev1.Kube130)))
+	clientRegistry.EXPECT().GetClient(ctx, gomock.Any()).Return(nil, errors.New("unreachable"))
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, clientRegistry)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).To(MatchError(ContainSubstring("getting client for cluster canary-a: unreachable")))
+}
+
+func TestClusterUpgradePolicyReconcileCompleted(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	c := clusterUpgradePolicyClient(policy,
+		readyUpgradePolicyCluster(upgradePolicyCluster("canary-a", "canary", anywherev1.Kube130)),
+		readyUpgradePolicyCluster(upgradePolicyCluster("prod-a", "prod", anywherev1.Kube130)),
+	)
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	result, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result).To(Equal(reconcile.Result{}))
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Status.Phase).To(Equal(anywherev1.ClusterUpgradePolicyCompleted))
+	g.Expect(got.Status.CurrentWave).To(BeEmpty())
+	g.Expect(got.Status.UpgradedClusters).To(Equal(2))
+}
+
+func TestClusterUpgradePolicyReconcilePaused(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	policy.Spec.Paused = true
+	c := clusterUpgradePolicyClient(policy, upgradePolicyCluster("canary-a", "canary", anywherev1.Kube129))
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(getUpgradePolicyCluster(ctx, g, c, "canary-a").Spec.KubernetesVersion).To(Equal(anywherev1.Kube129))
+	g.Expect(getClusterUpgradePolicy(ctx, g, c, policy).Status.Phase).To(Equal(anywherev1.ClusterUpgradePolicyPaused))
+}
+
+func TestClusterUpgradePolicyReconcileInvalid(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	policy := clusterUpgradePolicy()
+	policy.Spec.KubernetesVersion = ""
+	c := clusterUpgradePolicyClient(policy, upgradePolicyCluster("canary-a", "canary", anywherev1.Kube129))
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	_, err := r.Reconcile(ctx, clusterUpgradePolicyRequest(policy))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	got := getClusterUpgradePolicy(ctx, g, c, policy)
+	g.Expect(got.Status.Phase).To(Equal(anywherev1.ClusterUpgradePolicyInvalid))
+	g.Expect(got.Status.FailureMessage).To(Equal("at least one of kubernetesVersion and eksaVersion must be set"))
+}
+
+func TestClusterUpgradePolicyReconcileNotFound(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().Build()
+
+	r := controllers.NewClusterUpgradePolicyReconciler(c, nil)
+	_, err := r.Reconcile(context.Background(), clusterUpgradePolicyRequest(clusterUpgradePolicy()))
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func clusterUpgradePolicy() *anywherev1.ClusterUpgradePolicy {
+	return &anywherev1.ClusterUpgradePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fleet",
+			Namespace: "default",
+		},
+		Spec: anywherev1.ClusterUpgradePolicySpec{
+			ClusterSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"fleet": "true"},
+			},
+			KubernetesVersion: anywherev1.Kube130,
+			Waves: []anywherev1.UpgradeWave{
+				{
+					Name:            "canary",
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"stage": "canary"}},
+				},
+				{
+					Name:            "prod",
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"stage": "prod"}},
+				},
+			},
+		},
+	}
+}
+
+func upgradePolicyCluster(name, stage string, version anywherev1.KubernetesVersion) *anywherev1.Cluster {
+	return &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "default",
+			Labels:     map[string]string{"fleet": "true", "stage": stage},
+			Generation: 1,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: version,
+			ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"},
+		},
+		Status: anywherev1.ClusterStatus{
+			ReconciledGeneration: 1,
+		},
+	}
+}
+
+func upgradePolicyManagementCluster() *anywherev1.Cluster {
+	cluster := upgradePolicyCluster("mgmt", "canary", anywherev1.Kube129)
+	cluster.Spec.ManagementCluster.Name = "mgmt"
+	return cluster
+}
+
+func readyUpgradePolicyCluster(cluster *anywherev1.Cluster) *anywherev1.Cluster {
+	v1beta1conditions.MarkTrue(cluster, anywherev1.ReadyCondition)
+	return cluster
+}
+
+func clusterUpgradePolicyClient(policy *anywherev1.ClusterUpgradePolicy, clusters ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithObjects(append(clusters, policy)...).
+		WithStatusSubresource(policy).
+		Build()
+}
+
+func clusterUpgradePolicyRequest(policy *anywherev1.ClusterUpgradePolicy) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      policy.Name,
+			Namespace: policy.Namespace,
+		},
+	}
+}
+
+func getClusterUpgradePolicy(ctx context.Context, g *WithT, c client.Client, policy *anywherev1.ClusterUpgradePolicy) *anywherev1.ClusterUpgradePolicy {
+	got := &anywherev1.ClusterUpgradePolicy{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(policy), got)).To(Succeed())
+	return got
+}
+
+func getUpgradePolicyCluster(ctx context.Context, g *WithT, c client.Client, name string) *anywherev1.Cluster {
+	got := &anywherev1.Cluster{}
+	g.Expect(c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, got)).To(Succeed())
+	return got
+}
+
+func clusterUpgradePhases(policy *anywherev1.ClusterUpgradePolicy) map[string]anywherev1.ClusterUpgradePhase {
+	phases := map[string]anywherev1.ClusterUpgradePhase{}
+	for _, s := range policy.Status.Clusters {
+		phases[s.Name] = s.Phase
+	}
+	return phases
+}
@@ -167,7 +167,7 @@ func (r *ControlPlaneUpgradeReconciler) reconcile(ctx context.Context, log logr.
 
 	for idx, machineRef := range cpUpgrade.Spec.MachinesRequireUpgrade {
 		firstControlPlane = idx == 0
-		nodeUpgrade := nodeUpgrader(machineRef, cpUpgrade.Spec.KubernetesVersion, cpUpgrade.Spec.EtcdVersion, firstControlPlane)
+		nodeUpgrade := nodeUpgrader(machineRef, cpUpgrade.Spec.KubernetesVersion, cpUpgrade.Spec.EtcdVersion, cpUpgrade.Spec.OSImageURL, firstControlPlane)
 		if err := r.client.Get(ctx, GetNamespacedNameType(nodeUpgraderName(machineRef.Name), constants.EksaSystemNamespace), nodeUpgrade); err != nil {
 			if apierrors.IsNotFound(err) {
 				if err := r.client.Create(ctx, nodeUpgrade); client.IgnoreAlreadyExists(err) != nil {
@@ -184,7 +184,7 @@ func (r *ControlPlaneUpgradeReconciler) reconcile(ctx context.Context, log logr.
 	return ctrl.Result{}, nil
 }
 
-func nodeUpgrader(machineRef corev1.ObjectReference, kubernetesVersion, etcdVersion string, firstControlPlane bool) *anywherev1.NodeUpgrade {
+func nodeUpgrader(machineRef corev1.ObjectReference, kubernetesVersion, etcdVersion, osImageURL string, firstControlPlane bool) *anywherev1.NodeUpgrade {
 	return &anywherev1.NodeUpgrade{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      nodeUpgraderName(machineRef.Name),
@@ -199,6 +199,7 @@ func nodeUpgrader(machineRef corev1.ObjectReference, kubernetesVersion, etcdVers
 			KubernetesVersion:     kubernetesVersion,
 			EtcdVersion:           &etcdVersion,
 			FirstNodeToBeUpgraded: firstControlPlane,
+			OSImageURL:            osImageURL,
 		},
 	}
 }
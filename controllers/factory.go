@@ -21,11 +21,14 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
+	etcdmaintenance "github.com/aws/eks-anywhere/pkg/etcd/maintenance"
 	"github.com/aws/eks-anywhere/pkg/executables"
 	"github.com/aws/eks-anywhere/pkg/executables/cmk"
 	"github.com/aws/eks-anywhere/pkg/helm"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	ciliumreconciler "github.com/aws/eks-anywhere/pkg/networking/cilium/reconciler"
+	"github.com/aws/eks-anywhere/pkg/networking/metallb"
+	metallbreconciler "github.com/aws/eks-anywhere/pkg/networking/metallb/reconciler"
 	cnireconciler "github.com/aws/eks-anywhere/pkg/networking/reconciler"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
 	cloudstackreconciler "github.com/aws/eks-anywhere/pkg/providers/cloudstack/reconciler"
@@ -40,29 +43,31 @@ import (
 type Manager = manager.Manager
 
 type Factory struct {
-	buildSteps                   []buildStep
-	dependencyFactory            *dependencies.Factory
-	manager                      Manager
-	registryBuilder              *clusters.ProviderClusterReconcilerRegistryBuilder
-	reconcilers                  Reconcilers
-	tracker                      clustercache.ClusterCache
-	registry                     *clusters.ProviderClusterReconcilerRegistry
-	dockerClusterReconciler      *dockerreconciler.Reconciler
-	vsphereClusterReconciler     *vspherereconciler.Reconciler
-	tinkerbellClusterReconciler  *tinkerbellreconciler.Reconciler
-	snowClusterReconciler        *snowreconciler.Reconciler
-	cloudstackClusterReconciler  *cloudstackreconciler.Reconciler
-	nutanixClusterReconciler     *nutanixreconciler.Reconciler
-	cniReconciler                *cnireconciler.Reconciler
-	ipValidator                  *clusters.IPValidator
-	awsIamConfigReconciler       *awsiamconfigreconciler.Reconciler
-	machineHealthCheckReconciler *mhcreconciler.Reconciler
-	logger                       logr.Logger
-	deps                         *dependencies.Dependencies
-	packageControllerClient      *curatedpackages.PackageControllerClient
-	cloudStackValidatorRegistry  cloudstack.ValidatorRegistry
-	ciliumTemplater              *cilium.Templater
-	helmClientFactory            cilium.HelmClientFactory
+	buildSteps                    []buildStep
+	dependencyFactory             *dependencies.Factory
+	manager                       Manager
+	registryBuilder               *clusters.ProviderClusterReconcilerRegistryBuilder
+	reconcilers                   Reconcilers
+	tracker                       clustercache.ClusterCache
+	registry                      *clusters.ProviderClusterReconcilerRegistry
+	dockerClusterReconciler       *dockerreconciler.Reconciler
+	vsphereClusterReconciler      *vspherereconciler.Reconciler
+	tinkerbellClusterReconciler   *tinkerbellreconciler.Reconciler
+	snowClusterReconciler         *snowreconciler.Reconciler
+	cloudstackClusterReconciler   *cloudstackreconciler.Reconciler
+	nutanixClusterReconciler      *nutanixreconciler.Reconciler
+	cniReconciler                 *cnireconciler.Reconciler
+	serviceLoadBalancerReconciler *metallbreconciler.Reconciler
+	ipValidator                   *clusters.IPValidator
+	awsIamConfigReconciler        *awsiamconfigreconciler.Reconciler
+	machineHealthCheckReconciler  *mhcreconciler.Reconciler
+	etcdMaintenanceReconciler     *etcdmaintenance.Reconciler
+	logger                        logr.Logger
+	deps                          *dependencies.Dependencies
+	packageControllerClient       *curatedpackages.PackageControllerClient
+	cloudStackValidatorRegistry   cloudstack.ValidatorRegistry
+	ciliumTemplater               *cilium.Templater
+	helmClientFactory             cilium.HelmClientFactory
 }
 
 type Reconcilers struct {
@@ -76,8 +81,10 @@ type Reconcilers struct {
 	KubeadmControlPlaneReconciler      *KubeadmControlPlaneReconciler
 	MachineDeploymentReconciler        *MachineDeploymentReconciler
 	ControlPlaneUpgradeReconciler      *ControlPlaneUpgradeReconciler
+	ClusterUpgradePolicyReconciler     *ClusterUpgradePolicyReconciler
 	MachineDeploymentUpgradeReconciler *MachineDeploymentUpgradeReconciler
 	NodeUpgradeReconciler              *NodeUpgradeReconciler
+	HardwareValidationReconciler       *HardwareValidationReconciler
 }
 
 type buildStep func(ctx context.Context) error
@@ -122,13 +129,16 @@ func (f *Factory) WithClusterReconciler(capiProviders []clusterctlv1.Provider, o
 		WithProviderClusterReconcilerRegistry(capiProviders).
 		withAWSIamConfigReconciler().
 		withPackageControllerClient().
-		withMachineHealthCheckReconciler()
+		withMachineHealthCheckReconciler().
+		withEtcdMaintenanceReconciler()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.reconcilers.ClusterReconciler != nil {
 			return nil
 		}
 
+		opts = append([]ClusterReconcilerOption{WithEtcdMaintenanceReconciler(f.etcdMaintenanceReconciler)}, opts...)
+
 		f.reconcilers.ClusterReconciler = NewClusterReconciler(
 			f.manager.GetClient(),
 			f.registry,
@@ -253,7 +263,7 @@ func (f *Factory) WithNutanixDatacenterReconciler() *Factory {
 // withNutanixClusterReconciler adds the NutanixClusterReconciler to the controller factory.
 func (f *Factory) withNutanixClusterReconciler() *Factory {
 	f.dependencyFactory.WithNutanixDefaulter().WithNutanixValidator()
-	f.withTracker().withCNIReconciler(f.getProviderNamespace(constants.NutanixProviderName)).withIPValidator()
+	f.withTracker().withCNIReconciler(f.getProviderNamespace(constants.NutanixProviderName)).withIPValidator().withServiceLoadBalancerReconciler()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.nutanixClusterReconciler != nil {
 			return nil
@@ -265,6 +275,7 @@ func (f *Factory) withNutanixClusterReconciler() *Factory {
 			f.cniReconciler,
 			f.tracker,
 			f.ipValidator,
+			f.serviceLoadBalancerReconciler,
 		)
 		f.registryBuilder.Add(anywherev1.NutanixDatacenterKind, f.nutanixClusterReconciler)
 
@@ -363,7 +374,7 @@ func (f *Factory) WithProviderClusterReconcilerRegistry(capiProviders []clusterc
 }
 
 func (f *Factory) withDockerClusterReconciler() *Factory {
-	f.withCNIReconciler(f.getProviderNamespace(constants.DockerProviderName)).withTracker()
+	f.withCNIReconciler(f.getProviderNamespace(constants.DockerProviderName)).withTracker().withServiceLoadBalancerReconciler()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.dockerClusterReconciler != nil {
 			return nil
@@ -373,6 +384,7 @@ func (f *Factory) withDockerClusterReconciler() *Factory {
 			f.manager.GetClient(),
 			f.cniReconciler,
 			f.tracker,
+			f.serviceLoadBalancerReconciler,
 		)
 		f.registryBuilder.Add(anywherev1.DockerDatacenterKind, f.dockerClusterReconciler)
 
@@ -384,7 +396,7 @@ func (f *Factory) withDockerClusterReconciler() *Factory {
 
 func (f *Factory) withVSphereClusterReconciler() *Factory {
 	f.dependencyFactory.WithVSphereDefaulter().WithVSphereValidator()
-	f.withTracker().withCNIReconciler(f.getProviderNamespace(constants.VSphereProviderName)).withIPValidator()
+	f.withTracker().withCNIReconciler(f.getProviderNamespace(constants.VSphereProviderName)).withIPValidator().withServiceLoadBalancerReconciler()
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.vsphereClusterReconciler != nil {
 			return nil
@@ -397,6 +409,7 @@ func (f *Factory) withVSphereClusterReconciler() *Factory {
 			f.cniReconciler,
 			f.tracker,
 			f.ipValidator,
+			f.serviceLoadBalancerReconciler,
 		)
 		f.registryBuilder.Add(anywherev1.VSphereDatacenterKind, f.vsphereClusterReconciler)
 
@@ -407,7 +420,7 @@ func (f *Factory) withVSphereClusterReconciler() *Factory {
 }
 
 func (f *Factory) withSnowClusterReconciler() *Factory {
-	f.withCNIReconciler(f.getProviderNamespace(constants.SnowProviderName)).withTracker().withIPValidator()
+	f.withCNIReconciler(f.getProviderNamespace(constants.SnowProviderName)).withTracker().withIPValidator().withServiceLoadBalancerReconciler()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.snowClusterReconciler != nil {
@@ -419,6 +432,7 @@ func (f *Factory) withSnowClusterReconciler() *Factory {
 			f.cniReconciler,
 			f.tracker,
 			f.ipValidator,
+			f.serviceLoadBalancerReconciler,
 		)
 		f.registryBuilder.Add(anywherev1.SnowDatacenterKind, f.snowClusterReconciler)
 
@@ -429,7 +443,7 @@ func (f *Factory) withSnowClusterReconciler() *Factory {
 }
 
 func (f *Factory) withTinkerbellClusterReconciler() *Factory {
-	f.withCNIReconciler(f.getProviderNamespace(constants.TinkerbellProviderName)).withTracker().withIPValidator()
+	f.withCNIReconciler(f.getProviderNamespace(constants.TinkerbellProviderName)).withTracker().withIPValidator().withServiceLoadBalancerReconciler()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.tinkerbellClusterReconciler != nil {
@@ -441,6 +455,7 @@ func (f *Factory) withTinkerbellClusterReconciler() *Factory {
 			f.cniReconciler,
 			f.tracker,
 			f.ipValidator,
+			f.serviceLoadBalancerReconciler,
 		)
 		f.registryBuilder.Add(anywherev1.TinkerbellDatacenterKind, f.tinkerbellClusterReconciler)
 
@@ -451,7 +466,7 @@ func (f *Factory) withTinkerbellClusterReconciler() *Factory {
 }
 
 func (f *Factory) withCloudStackClusterReconciler() *Factory {
-	f.withCNIReconciler(f.getProviderNamespace(constants.CloudStackProviderName)).withTracker().withIPValidator().withCloudStackValidatorRegistry()
+	f.withCNIReconciler(f.getProviderNamespace(constants.CloudStackProviderName)).withTracker().withIPValidator().withCloudStackValidatorRegistry().withServiceLoadBalancerReconciler()
 
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
 		if f.cloudstackClusterReconciler != nil {
@@ -464,6 +479,7 @@ func (f *Factory) withCloudStackClusterReconciler() *Factory {
 			f.cniReconciler,
 			f.tracker,
 			f.cloudStackValidatorRegistry,
+			f.serviceLoadBalancerReconciler,
 		)
 		f.registryBuilder.Add(anywherev1.CloudStackDatacenterKind, f.cloudstackClusterReconciler)
 
@@ -538,6 +554,22 @@ func (f *Factory) withCNIReconciler(providerNamespace string) *Factory {
 	return f
 }
 
+func (f *Factory) withServiceLoadBalancerReconciler() *Factory {
+	f.withHelmClientFactory()
+
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.serviceLoadBalancerReconciler != nil {
+			return nil
+		}
+
+		f.serviceLoadBalancerReconciler = metallbreconciler.New(metallb.NewTemplater(f.helmClientFactory))
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) withIPValidator() *Factory {
 	f.dependencyFactory.WithIPValidator()
 
@@ -611,6 +643,20 @@ func (f *Factory) withMachineHealthCheckReconciler() *Factory {
 	return f
 }
 
+func (f *Factory) withEtcdMaintenanceReconciler() *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.etcdMaintenanceReconciler != nil {
+			return nil
+		}
+
+		f.etcdMaintenanceReconciler = etcdmaintenance.New(f.manager.GetClient(), etcdmaintenance.NewSSHEtcdctl)
+
+		return nil
+	})
+
+	return f
+}
+
 // WithKubeadmControlPlaneReconciler builds the KubeadmControlPlane reconciler.
 func (f *Factory) WithKubeadmControlPlaneReconciler() *Factory {
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -666,6 +712,25 @@ func (f *Factory) WithControlPlaneUpgradeReconciler() *Factory {
 	return f
 }
 
+// WithClusterUpgradePolicyReconciler builds the ClusterUpgradePolicy reconciler.
+func (f *Factory) WithClusterUpgradePolicyReconciler() *Factory {
+	f.withTracker()
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.ClusterUpgradePolicyReconciler != nil {
+			return nil
+		}
+
+		f.reconcilers.ClusterUpgradePolicyReconciler = NewClusterUpgradePolicyReconciler(
+			f.manager.GetClient(),
+			f.tracker,
+		)
+
+		return nil
+	})
+
+	return f
+}
+
 // WithMachineDeploymentUpgradeReconciler builds the WithMachineDeploymentUpgrade reconciler.
 func (f *Factory) WithMachineDeploymentUpgradeReconciler() *Factory {
 	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
@@ -702,6 +767,27 @@ func (f *Factory) WithNodeUpgradeReconciler() *Factory {
 	return f
 }
 
+// WithHardwareValidationReconciler builds the HardwareValidationReconciler when the Tinkerbell
+// CAPI provider is installed.
+func (f *Factory) WithHardwareValidationReconciler(capiProviders []clusterctlv1.Provider) *Factory {
+	f.buildSteps = append(f.buildSteps, func(ctx context.Context) error {
+		if f.reconcilers.HardwareValidationReconciler != nil {
+			return nil
+		}
+
+		for _, p := range capiProviders {
+			if p.Type == string(clusterctlv1.InfrastructureProviderType) && p.ProviderName == tinkerbellProviderName {
+				f.reconcilers.HardwareValidationReconciler = NewHardwareValidationReconciler(f.manager.GetClient())
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return f
+}
+
 func (f *Factory) getProviderNamespace(providerName string) string {
 	var providerNamespace string
 	switch providerName {
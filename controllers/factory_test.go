@@ -351,6 +351,35 @@ func TestFactoryWithControlPlaneUpgradeReconciler(t *testing.T) {
 	g.Expect(reconcilers.ControlPlaneUpgradeReconciler).NotTo(BeNil())
 }
 
+func TestFactoryWithClusterUpgradePolicyReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+	scheme := runtime.NewScheme()
+	_ = kubernetes.InitScheme(scheme)
+	manager.EXPECT().GetScheme().AnyTimes().Return(scheme)
+	manager.EXPECT().GetConfig().AnyTimes().Return(&rest.Config{})
+	manager.EXPECT().GetHTTPClient().AnyTimes()
+	manager.EXPECT().GetCache().AnyTimes()
+	manager.EXPECT().GetControllerOptions().AnyTimes().Return(config.Controller{})
+	manager.EXPECT().GetLogger().AnyTimes().Return(logger)
+	manager.EXPECT().Add(gomock.Any()).AnyTimes().Return(nil)
+
+	f := controllers.NewFactory(logger, manager).
+		WithClusterUpgradePolicyReconciler()
+
+	// testing idempotence
+	f.WithClusterUpgradePolicyReconciler()
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.ClusterUpgradePolicyReconciler).NotTo(BeNil())
+}
+
 func TestFactoryWithMachineDeploymentUpgradeReconciler(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -370,3 +399,50 @@ func TestFactoryWithMachineDeploymentUpgradeReconciler(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(reconcilers.MachineDeploymentUpgradeReconciler).NotTo(BeNil())
 }
+
+func TestFactoryWithHardwareValidationReconciler(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().GetClient().AnyTimes()
+
+	providers := []clusterctlv1.Provider{
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "tinkerbell",
+		},
+	}
+
+	f := controllers.NewFactory(logger, manager).
+		WithHardwareValidationReconciler(providers)
+
+	// testing idempotence
+	f.WithHardwareValidationReconciler(providers)
+
+	reconcilers, err := f.Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.HardwareValidationReconciler).NotTo(BeNil())
+}
+
+func TestFactoryWithHardwareValidationReconcilerNoTinkerbell(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	logger := nullLog()
+	ctrl := gomock.NewController(t)
+	manager := mocks.NewMockManager(ctrl)
+
+	providers := []clusterctlv1.Provider{
+		{
+			Type:         string(clusterctlv1.InfrastructureProviderType),
+			ProviderName: "vsphere",
+		},
+	}
+
+	reconcilers, err := controllers.NewFactory(logger, manager).
+		WithHardwareValidationReconciler(providers).
+		Build(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reconcilers.HardwareValidationReconciler).To(BeNil())
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// HardwareValidationReconciler records the result of hardware burn-in validation workflows on
+// the validated Tinkerbell Hardware objects.
+type HardwareValidationReconciler struct {
+	client client.Client
+}
+
+// NewHardwareValidationReconciler returns a new instance of HardwareValidationReconciler.
+func NewHardwareValidationReconciler(client client.Client) *HardwareValidationReconciler {
+	return &HardwareValidationReconciler{
+		client: client,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HardwareValidationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("hardwarevalidation").
+		For(&tinkv1alpha1.Workflow{}, builder.WithPredicates(predicate.NewPredicateFuncs(isBurnInWorkflow))).
+		Complete(r)
+}
+
+func isBurnInWorkflow(o client.Object) bool {
+	_, ok := o.GetLabels()[hardware.BurnInHardwareLabel]
+	return ok
+}
+
+//+kubebuilder:rbac:groups=tinkerbell.org,resources=workflows,verbs=get;list;watch
+//+kubebuilder:rbac:groups=tinkerbell.org,resources=hardware,verbs=get;list;watch;patch;update
+
+// Reconcile labels the Hardware referenced by a completed burn-in Workflow as validated or quarantined.
+func (r *HardwareValidationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	workflow := &tinkv1alpha1.Workflow{}
+	if err := r.client.Get(ctx, req.NamespacedName, workflow); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	hardwareName, ok := workflow.Labels[hardware.BurnInHardwareLabel]
+	if !ok || !workflow.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	status := hardware.ValidationStatusFromWorkflow(workflow)
+	if status == "" {
+		log.V(4).Info("Burn-in workflow has not completed", "state", workflow.Status.State)
+		return ctrl.Result{}, nil
+	}
+
+	hw := &tinkv1alpha1.Hardware{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: hardwareName, Namespace: workflow.Namespace}, hw); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Hardware referenced by burn-in workflow not found", "hardware", hardwareName)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting hardware %s: %v", hardwareName, err)
+	}
+
+	if hw.Labels[hardware.ValidationStatusLabel] == status {
+		return ctrl.Result{}, nil
+	}
+
+	patch := client.MergeFrom(hw.DeepCopy())
+	if hw.Labels == nil {
+		hw.Labels = map[string]string{}
+	}
+	hw.Labels[hardware.ValidationStatusLabel] = status
+	if err := r.client.Patch(ctx, hw, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("labeling hardware %s as %s: %v", hardwareName, status, err)
+	}
+
+	log.Info("Recorded hardware burn-in validation result", "hardware", hardwareName, "status", status)
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,125 @@
+package controllers_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/eks-anywhere/controllers"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func TestHardwareValidationReconcilerReconcile(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      tinkv1alpha1.WorkflowState
+		wantStatus string
+	}{
+		{
+			name:       "success",
+			state:      tinkv1alpha1.WorkflowStateSuccess,
+			wantStatus: hardware.ValidationValidated,
+		},
+		{
+			name:       "failed",
+			state:      tinkv1alpha1.WorkflowStateFailed,
+			wantStatus: hardware.ValidationQuarantined,
+		},
+		{
+			name:       "timeout",
+			state:      tinkv1alpha1.WorkflowStateTimeout,
+			wantStatus: hardware.ValidationQuarantined,
+		},
+		{
+			name:       "running",
+			state:      tinkv1alpha1.WorkflowStateRunning,
+			wantStatus: hardware.ValidationPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			hw, workflow := getObjectsForHardwareValidationTest()
+			workflow.Status.State = tt.state
+
+			client := newHardwareValidationClient(hw, workflow)
+			r := controllers.NewHardwareValidationReconciler(client)
+
+			_, err := r.Reconcile(ctx, hardwareValidationRequest(workflow))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got := &tinkv1alpha1.Hardware{}
+			g.Expect(client.Get(ctx, types.NamespacedName{Name: hw.Name, Namespace: hw.Namespace}, got)).To(Succeed())
+			g.Expect(got.Labels).To(HaveKeyWithValue(hardware.ValidationStatusLabel, tt.wantStatus))
+			g.Expect(got.Labels).To(HaveKeyWithValue("type", "cp"))
+		})
+	}
+}
+
+func TestHardwareValidationReconcilerReconcileHardwareNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, workflow := getObjectsForHardwareValidationTest()
+	workflow.Status.State = tinkv1alpha1.WorkflowStateSuccess
+
+	r := controllers.NewHardwareValidationReconciler(newHardwareValidationClient(workflow))
+
+	_, err := r.Reconcile(ctx, hardwareValidationRequest(workflow))
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestHardwareValidationReconcilerReconcileWorkflowNotFound(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, workflow := getObjectsForHardwareValidationTest()
+
+	r := controllers.NewHardwareValidationReconciler(newHardwareValidationClient())
+
+	_, err := r.Reconcile(ctx, hardwareValidationRequest(workflow))
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func getObjectsForHardwareValidationTest() (*tinkv1alpha1.Hardware, *tinkv1alpha1.Workflow) {
+	hw := &tinkv1alpha1.Hardware{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hw1",
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				"type":                         "cp",
+				hardware.ValidationStatusLabel: hardware.ValidationPending,
+			},
+		},
+	}
+	workflow := hardware.NewBurnInWorkflow(hardware.Machine{
+		Hostname:   hw.Name,
+		MACAddress: "00:00:00:00:00:01",
+		Disk:       "/dev/sda",
+	})
+
+	return hw, workflow
+}
+
+func newHardwareValidationClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = tinkv1alpha1.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func hardwareValidationRequest(workflow *tinkv1alpha1.Workflow) reconcile.Request {
+	return reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      workflow.Name,
+			Namespace: workflow.Namespace,
+		},
+	}
+}
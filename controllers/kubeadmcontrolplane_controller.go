@@ -249,6 +249,7 @@ func controlPlaneUpgrade(kcp *controlplanev1.KubeadmControlPlane, machines []cor
 			},
 			KubernetesVersion:      kcp.Spec.Version,
 			EtcdVersion:            kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local.ImageTag,
+			OSImageURL:             kcp.Annotations[constants.InPlaceOSImageURLAnnotation],
 			MachinesRequireUpgrade: machines,
 			ControlPlaneSpecData:   base64.StdEncoding.EncodeToString(kcpSpec),
 		},
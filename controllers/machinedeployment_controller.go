@@ -249,6 +249,7 @@ func machineDeploymentUpgrade(md *clusterv1.MachineDeployment, machines []corev1
 				Name:      md.ObjectMeta.Name,
 			},
 			KubernetesVersion:      *md.Spec.Template.Spec.Version,
+			OSImageURL:             md.Annotations[constants.InPlaceOSImageURLAnnotation],
 			MachinesRequireUpgrade: machines,
 			MachineSpecData:        base64.StdEncoding.EncodeToString(msSpec),
 		},
@@ -150,7 +150,7 @@ func (r *MachineDeploymentUpgradeReconciler) reconcile(ctx context.Context, log
 		nodeUpgrade, err := getNodeUpgrade(ctx, r.client, nodeUpgraderName(machineRef.Name))
 		if err != nil {
 			if apierrors.IsNotFound(err) {
-				nodeUpgrade = mdNodeUpgrader(machineRef, mdUpgrade.Spec.KubernetesVersion)
+				nodeUpgrade = mdNodeUpgrader(machineRef, mdUpgrade.Spec.KubernetesVersion, mdUpgrade.Spec.OSImageURL)
 				if err := r.client.Create(ctx, nodeUpgrade); err != nil {
 					return ctrl.Result{}, fmt.Errorf("failed to create node upgrader for machine %s:  %v", machineRef.Name, err)
 				}
@@ -310,7 +310,7 @@ func (r *MachineDeploymentUpgradeReconciler) updateMachineVersion(ctx context.Co
 	return nil
 }
 
-func mdNodeUpgrader(machineRef corev1.ObjectReference, kubernetesVersion string) *anywherev1.NodeUpgrade {
+func mdNodeUpgrader(machineRef corev1.ObjectReference, kubernetesVersion, osImageURL string) *anywherev1.NodeUpgrade {
 	return &anywherev1.NodeUpgrade{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      nodeUpgraderName(machineRef.Name),
@@ -323,6 +323,7 @@ func mdNodeUpgrader(machineRef corev1.ObjectReference, kubernetesVersion string)
 				Name:      machineRef.Name,
 			},
 			KubernetesVersion: kubernetesVersion,
+			OSImageURL:        osImageURL,
 		},
 	}
 }
@@ -246,6 +246,44 @@ func (mr *MockMachineHealthCheckReconcilerMockRecorder) Reconcile(ctx, logger, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockMachineHealthCheckReconciler)(nil).Reconcile), ctx, logger, cluster)
 }
 
+// MockEtcdMaintenanceReconciler is a mock of EtcdMaintenanceReconciler interface.
+type MockEtcdMaintenanceReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockEtcdMaintenanceReconcilerMockRecorder
+}
+
+// MockEtcdMaintenanceReconcilerMockRecorder is the mock recorder for MockEtcdMaintenanceReconciler.
+type MockEtcdMaintenanceReconcilerMockRecorder struct {
+	mock *MockEtcdMaintenanceReconciler
+}
+
+// NewMockEtcdMaintenanceReconciler creates a new mock instance.
+func NewMockEtcdMaintenanceReconciler(ctrl *gomock.Controller) *MockEtcdMaintenanceReconciler {
+	mock := &MockEtcdMaintenanceReconciler{ctrl: ctrl}
+	mock.recorder = &MockEtcdMaintenanceReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEtcdMaintenanceReconciler) EXPECT() *MockEtcdMaintenanceReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockEtcdMaintenanceReconciler) Reconcile(ctx context.Context, logger logr.Logger, cluster *v1alpha1.Cluster) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, cluster)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockEtcdMaintenanceReconcilerMockRecorder) Reconcile(ctx, logger, cluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockEtcdMaintenanceReconciler)(nil).Reconcile), ctx, logger, cluster)
+}
+
 // MockClusterValidator is a mock of ClusterValidator interface.
 type MockClusterValidator struct {
 	ctrl     *gomock.Controller
@@ -190,6 +190,11 @@ func (r *NodeUpgradeReconciler) reconcile(ctx context.Context, log logr.Logger,
 		upgraderPod = upgrader.UpgradeWorkerPod(node.Name, upgraderImage)
 	}
 
+	if nodeUpgrade.Spec.OSImageURL != "" {
+		log.Info("Upgrading node OS image", "Node", node.Name, "OSImageURL", nodeUpgrade.Spec.OSImageURL)
+		upgraderPod = upgrader.WithOSImageUpgrade(upgraderPod, upgraderImage, nodeUpgrade.Spec.OSImageURL)
+	}
+
 	if err := remoteClient.Create(ctx, upgraderPod); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to create the upgrader pod on node %s: %v", node.Name, err)
 	}
@@ -281,10 +286,11 @@ func (r *NodeUpgradeReconciler) updateStatus(ctx context.Context, log logr.Logge
 }
 
 func updateComponentsConditions(pod *corev1.Pod, nodeUpgrade *anywherev1.NodeUpgrade) {
-	containersMap := []struct {
+	type containerCondition struct {
 		name      string
 		condition clusterv1.ConditionType
-	}{
+	}
+	containersMap := []containerCondition{
 		{
 			name:      upgrader.CopierContainerName,
 			condition: anywherev1.BinariesCopied,
@@ -310,6 +316,12 @@ func updateComponentsConditions(pod *corev1.Pod, nodeUpgrade *anywherev1.NodeUpg
 			condition: anywherev1.PostUpgradeCleanupCompleted,
 		},
 	}
+	if nodeUpgrade.Spec.OSImageURL != "" {
+		containersMap = append(containersMap, containerCondition{
+			name:      upgrader.OSImageUpgraderContainerName,
+			condition: anywherev1.OSImageUpgraded,
+		})
+	}
 
 	completed := true
 	for _, container := range containersMap {
@@ -362,6 +374,9 @@ func markAllConditionsFalse(nodeUpgrade *anywherev1.NodeUpgrade, message string,
 	v1beta1conditions.MarkFalse(nodeUpgrade, anywherev1.CNIPluginsUpgraded, message, clusterv1.ConditionSeverityError, "")
 	v1beta1conditions.MarkFalse(nodeUpgrade, anywherev1.KubeadmUpgraded, message, clusterv1.ConditionSeverityError, "")
 	v1beta1conditions.MarkFalse(nodeUpgrade, anywherev1.KubeletUpgraded, message, clusterv1.ConditionSeverityError, "")
+	if nodeUpgrade.Spec.OSImageURL != "" {
+		v1beta1conditions.MarkFalse(nodeUpgrade, anywherev1.OSImageUpgraded, message, clusterv1.ConditionSeverityError, "")
+	}
 }
 
 func isControlPlane(node *corev1.Node) bool {
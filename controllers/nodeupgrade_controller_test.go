@@ -98,6 +98,31 @@ func TestNodeUpgradeReconcilerReconcileWorker(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestNodeUpgradeReconcilerReconcileWorkerOSImageUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	clientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
+
+	cluster, machine, node, nodeUpgrade, configMap := getObjectsForNodeUpgradeTest()
+	nodeUpgrade.Spec.OSImageURL = "http://tinkerbell-example:8080/ubuntu-2204-kube-1-28.gz"
+	client := fake.NewClientBuilder().WithRuntimeObjects(cluster, machine, node, nodeUpgrade, configMap).
+		WithStatusSubresource(nodeUpgrade).
+		Build()
+
+	clientRegistry.EXPECT().GetClient(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}).Return(client, nil)
+
+	r := controllers.NewNodeUpgradeReconciler(client, clientRegistry)
+	req := nodeUpgradeRequest(nodeUpgrade)
+	_, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	pod := &corev1.Pod{}
+	err = client.Get(ctx, types.NamespacedName{Name: upgrader.PodName(node.Name), Namespace: "eksa-system"}, pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pod.Spec.InitContainers).To(ContainElement(HaveField("Name", upgrader.OSImageUpgraderContainerName)))
+}
+
 func TestNodeUpgradeReconcilerReconcileCreateUpgraderPodState(t *testing.T) {
 	g := NewWithT(t)
 	ctx := context.Background()
@@ -195,8 +195,10 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		WithKubeadmControlPlaneReconciler().
 		WithMachineDeploymentReconciler().
 		WithControlPlaneUpgradeReconciler().
+		WithClusterUpgradePolicyReconciler().
 		WithMachineDeploymentUpgradeReconciler().
-		WithNodeUpgradeReconciler()
+		WithNodeUpgradeReconciler().
+		WithHardwareValidationReconciler(providers)
 
 	reconcilers, err := factory.Build(ctx)
 	if err != nil {
@@ -252,6 +254,12 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		failed = true
 	}
 
+	setupLog.Info("Setting up clusterupgradepolicy controller")
+	if err := (reconcilers.ClusterUpgradePolicyReconciler).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", anywherev1.ClusterUpgradePolicyKind)
+		failed = true
+	}
+
 	setupLog.Info("Setting up machinedeploymentupgrade controller")
 	if err := (reconcilers.MachineDeploymentUpgradeReconciler).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", anywherev1.MachineDeploymentUpgradeKind)
@@ -264,6 +272,14 @@ func setupReconcilers(ctx context.Context, setupLog logr.Logger, mgr ctrl.Manage
 		failed = true
 	}
 
+	if reconcilers.HardwareValidationReconciler != nil {
+		setupLog.Info("Setting up hardwarevalidation controller")
+		if err := (reconcilers.HardwareValidationReconciler).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HardwareValidation")
+			failed = true
+		}
+	}
+
 	if failed {
 		if err := factory.Close(ctx); err != nil {
 			setupLog.Error(err, "Failed closing controller factory")
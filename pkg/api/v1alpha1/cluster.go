@@ -197,6 +197,10 @@ var clusterConfigValidations = []func(*Cluster) error{
 	validateControlPlaneKubeletConfiguration,
 	validateWorkerNodeKubeletConfiguration,
 	validateAuditPolicyContent,
+	validateServiceLoadBalancer,
+	validateEtcdMaintenance,
+	validateMaintenanceWindow,
+	validateUpgradeRollbackPolicy,
 }
 
 // GetClusterConfig parses a Cluster object from a multiobject yaml file in disk
@@ -745,54 +749,189 @@ func validateNetworking(clusterConfig *Cluster) error {
 	if len(clusterNetwork.Services.CidrBlocks) <= 0 {
 		return errors.New("services CIDR block not specified or empty")
 	}
-	if len(clusterNetwork.Pods.CidrBlocks) > 1 {
-		return fmt.Errorf("multiple CIDR blocks for Pods are not yet supported")
+	if len(clusterNetwork.Services.CidrBlocks) > 2 {
+		return fmt.Errorf("at most two CIDR blocks for Services are supported, one per IP family")
 	}
-	if len(clusterNetwork.Services.CidrBlocks) > 1 {
-		return fmt.Errorf("multiple CIDR blocks for Services are not yet supported")
-	}
-	_, podCIDRIPNet, err := net.ParseCIDR(clusterNetwork.Pods.CidrBlocks[0])
+	podCIDRIPNets, err := parseCIDRBlocks(clusterNetwork.Pods.CidrBlocks)
 	if err != nil {
 		return fmt.Errorf("invalid CIDR block format for Pods: %s. Please specify a valid CIDR block for pod subnet", clusterNetwork.Pods)
 	}
-	_, serviceCIDRIPNet, err := net.ParseCIDR(clusterNetwork.Services.CidrBlocks[0])
+	serviceCIDRIPNets, err := parseCIDRBlocks(clusterNetwork.Services.CidrBlocks)
 	if err != nil {
 		return fmt.Errorf("invalid CIDR block for Services: %s. Please specify a valid CIDR block for service subnet", clusterNetwork.Services)
 	}
+	primaryPodCIDRIPNets, _ := parseCIDRBlocks(clusterNetwork.PrimaryPodCIDRBlocks())
+	if err := validateDualStackCIDRBlocks(primaryPodCIDRIPNets, serviceCIDRIPNets); err != nil {
+		return err
+	}
+	if err := validateAdditionalPodCIDRBlocks(clusterNetwork, podCIDRIPNets, serviceCIDRIPNets); err != nil {
+		return err
+	}
+	if err := validateIPFamilies(clusterConfig); err != nil {
+		return err
+	}
 
 	if clusterConfig.Spec.DatacenterRef.Kind == SnowDatacenterKind {
 		controlPlaneEndpoint := net.ParseIP(clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host)
 		if controlPlaneEndpoint == nil {
 			return fmt.Errorf("control plane endpoint %s is invalid", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host)
 		}
-		if podCIDRIPNet.Contains(controlPlaneEndpoint) {
-			return fmt.Errorf("control plane endpoint %s conflicts with pods CIDR block %s", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, clusterNetwork.Pods.CidrBlocks[0])
+		for i, podCIDRIPNet := range podCIDRIPNets {
+			if podCIDRIPNet.Contains(controlPlaneEndpoint) {
+				return fmt.Errorf("control plane endpoint %s conflicts with pods CIDR block %s", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, clusterNetwork.Pods.CidrBlocks[i])
+			}
 		}
-		if serviceCIDRIPNet.Contains(controlPlaneEndpoint) {
-			return fmt.Errorf("control plane endpoint %s conflicts with services CIDR block %s", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, clusterNetwork.Services.CidrBlocks[0])
+		for i, serviceCIDRIPNet := range serviceCIDRIPNets {
+			if serviceCIDRIPNet.Contains(controlPlaneEndpoint) {
+				return fmt.Errorf("control plane endpoint %s conflicts with services CIDR block %s", clusterConfig.Spec.ControlPlaneConfiguration.Endpoint.Host, clusterNetwork.Services.CidrBlocks[i])
+			}
 		}
 	}
 
-	podMaskSize, _ := podCIDRIPNet.Mask.Size()
-	nodeCidrMaskSize := constants.DefaultNodeCidrMaskSize
+	for _, podCIDRIPNet := range podCIDRIPNets {
+		podMaskSize, _ := podCIDRIPNet.Mask.Size()
+		nodeCidrMaskSize := nodeCIDRMaskSize(clusterNetwork.Nodes, ipFamily(podCIDRIPNet.IP))
 
-	if clusterNetwork.Nodes != nil && clusterNetwork.Nodes.CIDRMaskSize != nil {
-		nodeCidrMaskSize = *clusterNetwork.Nodes.CIDRMaskSize
-	}
-	// the pod subnet mask needs to allow one or multiple node-masks
-	// i.e. if it has a /24 the node mask must be between 24 and 32 for ipv4
-	// the below validations are run by kubeadm and we are bubbling those up here for better customer experience
-	if podMaskSize >= nodeCidrMaskSize {
-		return fmt.Errorf("the size of pod subnet with mask %d is smaller than or equal to the size of node subnet with mask %d", podMaskSize, nodeCidrMaskSize)
-	} else if (nodeCidrMaskSize - podMaskSize) > podSubnetNodeMaskMaxDiff {
-		// PodSubnetNodeMaskMaxDiff is limited to 16 due to an issue with uncompressed IP bitmap in core
-		// The node subnet mask size must be no more than the pod subnet mask size + 16
-		return fmt.Errorf("pod subnet mask (%d) and node-mask (%d) difference is greater than %d", podMaskSize, nodeCidrMaskSize, podSubnetNodeMaskMaxDiff)
+		// the pod subnet mask needs to allow one or multiple node-masks
+		// i.e. if it has a /24 the node mask must be between 24 and 32 for ipv4
+		// the below validations are run by kubeadm and we are bubbling those up here for better customer experience
+		if podMaskSize >= nodeCidrMaskSize {
+			return fmt.Errorf("the size of pod subnet with mask %d is smaller than or equal to the size of node subnet with mask %d", podMaskSize, nodeCidrMaskSize)
+		} else if (nodeCidrMaskSize - podMaskSize) > podSubnetNodeMaskMaxDiff {
+			// PodSubnetNodeMaskMaxDiff is limited to 16 due to an issue with uncompressed IP bitmap in core
+			// The node subnet mask size must be no more than the pod subnet mask size + 16
+			return fmt.Errorf("pod subnet mask (%d) and node-mask (%d) difference is greater than %d", podMaskSize, nodeCidrMaskSize, podSubnetNodeMaskMaxDiff)
+		}
 	}
 
 	return validateCNIPlugin(clusterNetwork)
 }
 
+func parseCIDRBlocks(blocks []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(blocks))
+	for _, block := range blocks {
+		_, ipNet, err := net.ParseCIDR(block)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+// validateDualStackCIDRBlocks validates that the primary pod CIDR blocks and the services use the same
+// IP families, in the same order, and that dual-stack networks have one CIDR block per IP family.
+func validateDualStackCIDRBlocks(pods, services []*net.IPNet) error {
+	if len(services) == 2 && ipFamily(services[0].IP) == ipFamily(services[1].IP) {
+		return errors.New("dual-stack CIDR blocks for Services must have one IPv4 and one IPv6 CIDR block")
+	}
+	if len(pods) != len(services) {
+		return errors.New("pods and services must have the same number of CIDR blocks, dual-stack clusters need one IPv4 and one IPv6 CIDR block for both")
+	}
+	if ipFamily(pods[0].IP) != ipFamily(services[0].IP) {
+		return fmt.Errorf("the first CIDR block of pods (%s) and services (%s) must be of the same IP family", ipFamily(pods[0].IP), ipFamily(services[0].IP))
+	}
+	return nil
+}
+
+// validateAdditionalPodCIDRBlocks validates the pod CIDR blocks added after the first block of their IP family.
+// They extend the Cilium IPAM pool so they require Cilium and can't overlap with any other CIDR block.
+func validateAdditionalPodCIDRBlocks(network ClusterNetwork, pods, services []*net.IPNet) error {
+	if len(network.AdditionalPodCIDRBlocks()) == 0 {
+		return nil
+	}
+
+	cni := getCNIConfig(&network)
+	if cni == nil || cni.Cilium == nil {
+		return errors.New("multiple CIDR blocks for Pods of the same IP family are only supported with Cilium CNI")
+	}
+	if !cni.Cilium.IsManaged() {
+		return errors.New("multiple CIDR blocks for Pods of the same IP family are not supported when EKS Anywhere Cilium upgrades are skipped")
+	}
+	if cni.Cilium.HelmValues != nil {
+		return errors.New("multiple CIDR blocks for Pods of the same IP family are not supported when Cilium helmValues are set")
+	}
+
+	for i, pod := range pods {
+		for _, other := range pods[i+1:] {
+			if pod.Contains(other.IP) || other.Contains(pod.IP) {
+				return fmt.Errorf("pods CIDR blocks %s and %s overlap", pod, other)
+			}
+		}
+		for _, service := range services {
+			if pod.Contains(service.IP) || service.Contains(pod.IP) {
+				return fmt.Errorf("pods CIDR block %s overlaps with services CIDR block %s", pod, service)
+			}
+		}
+	}
+
+	return nil
+}
+
+// nodeCIDRMaskSize returns the node CIDR mask size used for the pod CIDR blocks of an IP family.
+// IPv6 CIDR blocks, only supported in dual-stack clusters, use cidrMaskSizeIPv6.
+func nodeCIDRMaskSize(nodes *Nodes, family IPFamily) int {
+	if family == IPv6Family {
+		if nodes != nil && nodes.CIDRMaskSizeIPv6 != nil {
+			return *nodes.CIDRMaskSizeIPv6
+		}
+		return constants.DefaultNodeCidrMaskSizeIPv6
+	}
+	if nodes != nil && nodes.CIDRMaskSize != nil {
+		return *nodes.CIDRMaskSize
+	}
+	return constants.DefaultNodeCidrMaskSize
+}
+
+// dualStackNodeIPsMinKubeVersion is the first Kubernetes version where kubelet and the cloud
+// controller manager handle dual-stack node IPs with an external cloud provider by default, with the
+// CloudDualStackNodeIPs feature gate.
+const dualStackNodeIPsMinKubeVersion = Kube129
+
+// validateIPFamilies validates the IP families of the cluster network are supported by the provider.
+// IPv6 is only supported in dual-stack clusters and Docker clusters are IPv4 only. Providers using an
+// external cloud provider need all the nodes to run a Kubernetes version with CloudDualStackNodeIPs
+// enabled, otherwise kubelet rejects dual-stack node IPs.
+func validateIPFamilies(cluster *Cluster) error {
+	network := cluster.Spec.ClusterNetwork
+	if !network.IsDualStack() {
+		if families := network.IPFamilies(); len(families) > 0 && families[0] == IPv6Family {
+			return errors.New("IPv6 single-stack clusters are not supported, use one IPv4 and one IPv6 CIDR block for pods and services for dual-stack")
+		}
+		return nil
+	}
+
+	switch cluster.Spec.DatacenterRef.Kind {
+	case DockerDatacenterKind:
+		return errors.New("dual-stack clusters are not supported on Docker provider")
+	case VSphereDatacenterKind, CloudStackDatacenterKind, NutanixDatacenterKind:
+		versions := []KubernetesVersion{cluster.Spec.KubernetesVersion}
+		for _, w := range cluster.Spec.WorkerNodeGroupConfigurations {
+			if w.KubernetesVersion != nil {
+				versions = append(versions, *w.KubernetesVersion)
+			}
+		}
+		minVersion, err := KubeVersionToSemver(dualStackNodeIPsMinKubeVersion)
+		if err != nil {
+			return err
+		}
+		for _, v := range versions {
+			if v == "" {
+				continue
+			}
+			version, err := KubeVersionToSemver(v)
+			if err != nil {
+				return fmt.Errorf("converting kubernetes version %s to semver: %v", v, err)
+			}
+			if version.LessThan(minVersion) {
+				return fmt.Errorf("dual-stack clusters on %s require Kubernetes %s or later for all nodes, found %s", cluster.Spec.DatacenterRef.Kind, dualStackNodeIPsMinKubeVersion, v)
+			}
+		}
+	}
+
+	return nil
+}
+
 func validateCNIPlugin(network ClusterNetwork) error {
 	if network.CNI != "" {
 		if network.CNIConfig != nil {
@@ -992,6 +1131,9 @@ func validateCPUpgradeRolloutStrategy(clusterConfig *Cluster) error {
 
 	switch cpUpgradeRolloutStrategy.Type {
 	case RollingUpdateStrategyType:
+		if cpUpgradeRolloutStrategy.InPlace != nil {
+			return fmt.Errorf("ControlPlaneConfiguration: InPlace field must be empty for 'RollingUpdate' upgrade rollout strategy type")
+		}
 		if cpUpgradeRolloutStrategy.RollingUpdate != nil {
 			if cpUpgradeRolloutStrategy.RollingUpdate.MaxSurge < 0 {
 				return fmt.Errorf("ControlPlaneConfiguration: maxSurge for control plane cannot be a negative value")
@@ -1011,6 +1153,9 @@ func validateCPUpgradeRolloutStrategy(clusterConfig *Cluster) error {
 			if clusterConfig.Spec.ExternalEtcdConfiguration != nil {
 				return errors.New("stacked etcd must be configured when performing in place upgrades")
 			}
+			if cpUpgradeRolloutStrategy.InPlace.OSImageUpgradeEnabled() {
+				return errors.New("ControlPlaneConfiguration: in place OS image upgrades are only supported on Bare Metal")
+			}
 			return nil
 		}
 		if clusterConfig.Spec.DatacenterRef.Kind != TinkerbellDatacenterKind {
@@ -1030,6 +1175,9 @@ func validateMDUpgradeRolloutStrategy(w *WorkerNodeGroupConfiguration, datacente
 
 	switch w.UpgradeRolloutStrategy.Type {
 	case RollingUpdateStrategyType:
+		if w.UpgradeRolloutStrategy.InPlace != nil {
+			return fmt.Errorf("WorkerNodeGroupConfiguration: InPlace field must be empty for 'RollingUpdate' upgrade rollout strategy type")
+		}
 		if w.UpgradeRolloutStrategy.RollingUpdate == nil {
 			return fmt.Errorf("WorkerNodeGroupConfiguration: upgradeRolloutStrategy.rollingUpdate field is required for upgradeRolloutStrategy.type RollingUpdate")
 		}
@@ -1046,10 +1194,13 @@ func validateMDUpgradeRolloutStrategy(w *WorkerNodeGroupConfiguration, datacente
 			return fmt.Errorf("WorkerNodeGroupConfiguration: RollingUpdate field must be empty for 'InPlace' upgrade rollout strategy type")
 		}
 		if datacenterRefKind == VSphereDatacenterKind {
-			if features.IsActive(features.VSphereInPlaceUpgradeEnabled()) {
-				return nil
+			if !features.IsActive(features.VSphereInPlaceUpgradeEnabled()) {
+				return errors.New("in place upgrades are not supported on vSphere")
 			}
-			return errors.New("in place upgrades are not supported on vSphere")
+			if w.UpgradeRolloutStrategy.InPlace.OSImageUpgradeEnabled() {
+				return errors.New("WorkerNodeGroupConfiguration: in place OS image upgrades are only supported on Bare Metal")
+			}
+			return nil
 		}
 		if datacenterRefKind != TinkerbellDatacenterKind {
 			return fmt.Errorf("WorkerNodeGroupConfiguration: 'InPlace' upgrade rollout strategy type is only supported on Bare Metal")
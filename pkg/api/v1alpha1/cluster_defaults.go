@@ -14,6 +14,8 @@ var clusterDefaults = []func(*Cluster) error{
 	setWorkerNodeGroupDefaults,
 	setCNIConfigDefault,
 	setEtcdEncryptionConfigDefaults,
+	setServiceLoadBalancerDefaults,
+	setEtcdMaintenanceDefaults,
 }
 
 func setClusterDefaults(cluster *Cluster) error {
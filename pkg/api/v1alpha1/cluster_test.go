@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
@@ -2524,6 +2525,52 @@ func TestClusterNetworkEquals(t *testing.T) {
 	}
 }
 
+func TestIsKindnetdToCiliumMigration(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+		prev ClusterNetwork
+		new  ClusterNetwork
+	}{
+		{
+			name: "kindnetd to cilium",
+			want: true,
+			prev: ClusterNetwork{CNIConfig: &CNIConfig{Kindnetd: &KindnetdConfig{}}},
+			new:  ClusterNetwork{CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}}},
+		},
+		{
+			name: "old cni field kindnetd to cilium",
+			want: true,
+			prev: ClusterNetwork{CNI: Kindnetd},
+			new:  ClusterNetwork{CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}}},
+		},
+		{
+			name: "cilium to kindnetd",
+			want: false,
+			prev: ClusterNetwork{CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}}},
+			new:  ClusterNetwork{CNIConfig: &CNIConfig{Kindnetd: &KindnetdConfig{}}},
+		},
+		{
+			name: "same cilium cni",
+			want: false,
+			prev: ClusterNetwork{CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}}},
+			new:  ClusterNetwork{CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}}},
+		},
+		{
+			name: "new cniConfig nil",
+			want: false,
+			prev: ClusterNetwork{CNIConfig: &CNIConfig{Kindnetd: &KindnetdConfig{}}},
+			new:  ClusterNetwork{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(IsKindnetdToCiliumMigration(tt.new, tt.prev)).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestRefEquals(t *testing.T) {
 	tests := []struct {
 		name string
@@ -3043,6 +3090,315 @@ func TestValidateNetworking(t *testing.T) {
 	}
 }
 
+func TestValidateNetworkingDualStack(t *testing.T) {
+	cluster := func(kind, endpoint string, pods, services []string) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				KubernetesVersion: Kube129,
+				DatacenterRef: Ref{
+					Kind: kind,
+				},
+				ControlPlaneConfiguration: ControlPlaneConfiguration{
+					Endpoint: &Endpoint{
+						Host: endpoint,
+					},
+				},
+				ClusterNetwork: ClusterNetwork{
+					Pods: Pods{
+						CidrBlocks: pods,
+					},
+					Services: Services{
+						CidrBlocks: services,
+					},
+					CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		wantErr error
+		cluster *Cluster
+	}{
+		{
+			name:    "valid dual-stack",
+			wantErr: nil,
+			cluster: cluster(VSphereDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108"}),
+		},
+		{
+			name:    "valid ipv6 primary dual-stack",
+			wantErr: nil,
+			cluster: cluster(VSphereDatacenterKind, "fd00:3::10", []string{"fd00:1::/56", "10.1.0.0/16"}, []string{"fd00:2::/108", "10.96.0.0/12"}),
+		},
+		{
+			name:    "ipv6 single-stack",
+			wantErr: errors.New("IPv6 single-stack clusters are not supported, use one IPv4 and one IPv6 CIDR block for pods and services for dual-stack"),
+			cluster: cluster(VSphereDatacenterKind, "fd00:3::10", []string{"fd00:1::/56"}, []string{"fd00:2::/108"}),
+		},
+		{
+			name:    "dual-stack on docker",
+			wantErr: errors.New("dual-stack clusters are not supported on Docker provider"),
+			cluster: cluster(DockerDatacenterKind, "", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108"}),
+		},
+		{
+			name:    "dual-stack with external cloud provider before CloudDualStackNodeIPs",
+			wantErr: errors.New("dual-stack clusters on CloudStackDatacenterConfig require Kubernetes 1.29 or later for all nodes, found 1.28"),
+			cluster: func() *Cluster {
+				c := cluster(CloudStackDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108"})
+				c.Spec.KubernetesVersion = Kube128
+				return c
+			}(),
+		},
+		{
+			name:    "dual-stack with external cloud provider and worker node group before CloudDualStackNodeIPs",
+			wantErr: errors.New("dual-stack clusters on NutanixDatacenterConfig require Kubernetes 1.29 or later for all nodes, found 1.28"),
+			cluster: func() *Cluster {
+				c := cluster(NutanixDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108"})
+				version := Kube128
+				c.Spec.WorkerNodeGroupConfigurations = []WorkerNodeGroupConfiguration{{KubernetesVersion: &version}}
+				return c
+			}(),
+		},
+		{
+			name:    "dual-stack without external cloud provider before CloudDualStackNodeIPs",
+			wantErr: nil,
+			cluster: func() *Cluster {
+				c := cluster(TinkerbellDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108"})
+				c.Spec.KubernetesVersion = Kube128
+				return c
+			}(),
+		},
+		{
+			name:    "valid dual-stack with additional pods CIDR block",
+			wantErr: nil,
+			cluster: cluster(VSphereDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56", "10.2.0.0/16"}, []string{"10.96.0.0/12", "fd00:2::/108"}),
+		},
+		{
+			name:    "too many services CIDR blocks",
+			wantErr: fmt.Errorf("at most two CIDR blocks for Services are supported, one per IP family"),
+			cluster: cluster(VSphereDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108", "10.97.0.0/16"}),
+		},
+		{
+			name:    "pods CIDR blocks of the same family",
+			wantErr: errors.New("pods and services must have the same number of CIDR blocks, dual-stack clusters need one IPv4 and one IPv6 CIDR block for both"),
+			cluster: cluster(VSphereDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "10.2.0.0/16"}, []string{"10.96.0.0/12", "fd00:2::/108"}),
+		},
+		{
+			name:    "services CIDR blocks of the same family",
+			wantErr: errors.New("dual-stack CIDR blocks for Services must have one IPv4 and one IPv6 CIDR block"),
+			cluster: cluster(VSphereDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"fd00:3::/108", "fd00:2::/108"}),
+		},
+		{
+			name:    "single-stack services with dual-stack pods",
+			wantErr: errors.New("pods and services must have the same number of CIDR blocks, dual-stack clusters need one IPv4 and one IPv6 CIDR block for both"),
+			cluster: cluster(VSphereDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12"}),
+		},
+		{
+			name:    "different primary families",
+			wantErr: fmt.Errorf("the first CIDR block of pods (IPv4) and services (IPv6) must be of the same IP family"),
+			cluster: cluster(VSphereDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"fd00:2::/108", "10.96.0.0/12"}),
+		},
+		{
+			name:    "control plane endpoint conflicts with ipv6 pods CIDR block",
+			wantErr: fmt.Errorf("control plane endpoint fd00:1::10 conflicts with pods CIDR block fd00:1::/56"),
+			cluster: cluster(SnowDatacenterKind, "fd00:1::10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108"}),
+		},
+		{
+			name:    "control plane endpoint conflicts with ipv6 services CIDR block",
+			wantErr: fmt.Errorf("control plane endpoint fd00:2::10 conflicts with services CIDR block fd00:2::/108"),
+			cluster: cluster(SnowDatacenterKind, "fd00:2::10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108"}),
+		},
+		{
+			name:    "control plane endpoint in pods CIDR block is only checked for snow",
+			wantErr: nil,
+			cluster: cluster(VSphereDatacenterKind, "fd00:1::10", []string{"10.1.0.0/16", "fd00:1::/56"}, []string{"10.96.0.0/12", "fd00:2::/108"}),
+		},
+		{
+			name:    "ipv6 pods CIDR block too small for node mask",
+			wantErr: fmt.Errorf("the size of pod subnet with mask 64 is smaller than or equal to the size of node subnet with mask 64"),
+			cluster: cluster(VSphereDatacenterKind, "192.168.1.10", []string{"10.1.0.0/16", "fd00:1::/64"}, []string{"10.96.0.0/12", "fd00:2::/108"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateNetworking(tt.cluster)
+			if !reflect.DeepEqual(tt.wantErr, got) {
+				t.Errorf("%v got = %v, want %v", tt.name, got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkingDualStackNodeCIDRMaskSize(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{
+		Spec: ClusterSpec{
+			KubernetesVersion: Kube129,
+			DatacenterRef: Ref{
+				Kind: VSphereDatacenterKind,
+			},
+			ClusterNetwork: ClusterNetwork{
+				Pods: Pods{
+					CidrBlocks: []string{"10.1.0.0/16", "fd00:1::/64"},
+				},
+				Services: Services{
+					CidrBlocks: []string{"10.96.0.0/12", "fd00:2::/108"},
+				},
+				Nodes: &Nodes{
+					CIDRMaskSizeIPv6: ptr.Int(72),
+				},
+				CNIConfig: &CNIConfig{Cilium: &CiliumConfig{}},
+			},
+		},
+	}
+	g.Expect(validateNetworking(c)).To(Succeed())
+	g.Expect(c.Spec.ClusterNetwork.IsDualStack()).To(BeTrue())
+	g.Expect(c.Spec.ClusterNetwork.IPFamilies()).To(Equal([]IPFamily{IPv4Family, IPv6Family}))
+}
+
+func TestValidateNetworkingAdditionalPodCIDRBlocks(t *testing.T) {
+	cluster := func(pods []string, cni *CNIConfig) *Cluster {
+		return &Cluster{
+			Spec: ClusterSpec{
+				DatacenterRef: Ref{
+					Kind: DockerDatacenterKind,
+				},
+				ControlPlaneConfiguration: ControlPlaneConfiguration{
+					Endpoint: &Endpoint{
+						Host: "192.168.1.10",
+					},
+				},
+				ClusterNetwork: ClusterNetwork{
+					Pods: Pods{
+						CidrBlocks: pods,
+					},
+					Services: Services{
+						CidrBlocks: []string{"10.96.0.0/12"},
+					},
+					CNIConfig: cni,
+				},
+			},
+		}
+	}
+	cilium := &CNIConfig{Cilium: &CiliumConfig{}}
+	tests := []struct {
+		name    string
+		wantErr string
+		cluster *Cluster
+	}{
+		{
+			name:    "valid additional pods CIDR blocks",
+			cluster: cluster([]string{"10.1.0.0/16", "10.2.0.0/16", "10.3.0.0/16"}, cilium),
+		},
+		{
+			name:    "kindnetd",
+			wantErr: "multiple CIDR blocks for Pods of the same IP family are only supported with Cilium CNI",
+			cluster: cluster([]string{"10.1.0.0/16", "10.2.0.0/16"}, &CNIConfig{Kindnetd: &KindnetdConfig{}}),
+		},
+		{
+			name:    "unmanaged cilium",
+			wantErr: "multiple CIDR blocks for Pods of the same IP family are not supported when EKS Anywhere Cilium upgrades are skipped",
+			cluster: cluster([]string{"10.1.0.0/16", "10.2.0.0/16"}, &CNIConfig{Cilium: &CiliumConfig{SkipUpgrade: ptr.Bool(true)}}),
+		},
+		{
+			name:    "cilium helm values",
+			wantErr: "multiple CIDR blocks for Pods of the same IP family are not supported when Cilium helmValues are set",
+			cluster: cluster([]string{"10.1.0.0/16", "10.2.0.0/16"}, &CNIConfig{Cilium: &CiliumConfig{HelmValues: &apiextensionsv1.JSON{Raw: []byte(`{"ipam":{"mode":"kubernetes"}}`)}}}),
+		},
+		{
+			name:    "overlapping pods CIDR blocks",
+			wantErr: "pods CIDR blocks 10.1.0.0/16 and 10.0.0.0/14 overlap",
+			cluster: cluster([]string{"10.1.0.0/16", "10.0.0.0/14"}, cilium),
+		},
+		{
+			name:    "pods CIDR block overlapping with services",
+			wantErr: "pods CIDR block 10.96.0.0/16 overlaps with services CIDR block 10.96.0.0/12",
+			cluster: cluster([]string{"10.1.0.0/16", "10.96.0.0/16"}, cilium),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateNetworking(tt.cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestClusterNetworkPodCIDRBlocks(t *testing.T) {
+	g := NewWithT(t)
+	n := &ClusterNetwork{
+		Pods: Pods{
+			CidrBlocks: []string{"10.1.0.0/16", "fd00:1::/56", "10.2.0.0/16", "fd00:2::/56"},
+		},
+	}
+
+	g.Expect(n.PrimaryPodCIDRBlocks()).To(Equal([]string{"10.1.0.0/16", "fd00:1::/56"}))
+	g.Expect(n.AdditionalPodCIDRBlocks()).To(Equal([]string{"10.2.0.0/16", "fd00:2::/56"}))
+	g.Expect(n.PodCIDRBlocksForFamily(IPv4Family)).To(Equal([]string{"10.1.0.0/16", "10.2.0.0/16"}))
+	g.Expect(n.IPFamilies()).To(Equal([]IPFamily{IPv4Family, IPv6Family}))
+	g.Expect(n.IsDualStack()).To(BeTrue())
+}
+
+func TestClusterNetworkIsPodNetworkExpansionOf(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+		old  []string
+		new  []string
+	}{
+		{name: "appended block", want: true, old: []string{"10.1.0.0/16", "10.2.0.0/16"}, new: []string{"10.1.0.0/16", "10.2.0.0/16", "10.3.0.0/16"}},
+		{name: "appended block to single block", want: true, old: []string{"10.1.0.0/16"}, new: []string{"10.1.0.0/16", "10.2.0.0/16"}},
+		{name: "appended ip family", want: false, old: []string{"10.1.0.0/16", "10.2.0.0/16"}, new: []string{"10.1.0.0/16", "10.2.0.0/16", "fd00:1::/56"}},
+		{name: "same blocks", want: false, old: []string{"10.1.0.0/16", "10.2.0.0/16"}, new: []string{"10.1.0.0/16", "10.2.0.0/16"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			n := &ClusterNetwork{Pods: Pods{CidrBlocks: tt.new}}
+			g.Expect(n.IsPodNetworkExpansionOf(&ClusterNetwork{Pods: Pods{CidrBlocks: tt.old}})).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterUsesCiliumClusterPoolIPAM(t *testing.T) {
+	g := NewWithT(t)
+	c := &Cluster{Spec: ClusterSpec{ClusterNetwork: ClusterNetwork{Pods: Pods{CidrBlocks: []string{"10.1.0.0/16"}}}}}
+	g.Expect(c.UsesCiliumClusterPoolIPAM()).To(BeFalse())
+
+	c.SetCiliumClusterPoolIPAM()
+	g.Expect(c.UsesCiliumClusterPoolIPAM()).To(BeTrue())
+
+	c = &Cluster{Spec: ClusterSpec{ClusterNetwork: ClusterNetwork{Pods: Pods{CidrBlocks: []string{"10.1.0.0/16", "10.2.0.0/16"}}}}}
+	g.Expect(c.UsesCiliumClusterPoolIPAM()).To(BeTrue())
+}
+
+func TestPodsIsExpansionOf(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+		old  []string
+		new  []string
+	}{
+		{name: "appended block", want: true, old: []string{"10.1.0.0/16"}, new: []string{"10.1.0.0/16", "10.2.0.0/16"}},
+		{name: "same blocks", want: false, old: []string{"10.1.0.0/16"}, new: []string{"10.1.0.0/16"}},
+		{name: "replaced block", want: false, old: []string{"10.1.0.0/16"}, new: []string{"10.3.0.0/16", "10.2.0.0/16"}},
+		{name: "prepended block", want: false, old: []string{"10.1.0.0/16"}, new: []string{"10.2.0.0/16", "10.1.0.0/16"}},
+		{name: "removed block", want: false, old: []string{"10.1.0.0/16", "10.2.0.0/16"}, new: []string{"10.1.0.0/16"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			n := &Pods{CidrBlocks: tt.new}
+			g.Expect(n.IsExpansionOf(&Pods{CidrBlocks: tt.old})).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestValidateCNIConfig(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -3738,6 +4094,46 @@ func TestValidateCPUpgradeRolloutStrategy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "in place upgrade - tinkerbell, os image upgrade",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: TinkerbellDatacenterKind,
+					},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace", InPlace: &InPlaceUpgradeParams{OSImageUpgrade: true}},
+					},
+				},
+			},
+		},
+		{
+			name:           "in place upgrade - vsphere, os image upgrade",
+			wantErr:        "ControlPlaneConfiguration: in place OS image upgrades are only supported on Bare Metal",
+			featureEnvVars: []string{features.VSphereInPlaceEnvVar},
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: VSphereDatacenterKind,
+					},
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "InPlace", InPlace: &InPlaceUpgradeParams{OSImageUpgrade: true}},
+					},
+				},
+			},
+		},
+		{
+			name:    "rolling upgrade - inPlace field specified",
+			wantErr: "ControlPlaneConfiguration: InPlace field must be empty for 'RollingUpdate' upgrade rollout strategy type",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					ControlPlaneConfiguration: ControlPlaneConfiguration{
+						UpgradeRolloutStrategy: &ControlPlaneUpgradeRolloutStrategy{Type: "RollingUpdate", InPlace: &InPlaceUpgradeParams{OSImageUpgrade: true}},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3908,6 +4304,31 @@ func TestValidateMDUpgradeRolloutStrategy(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "in place upgrade - tinkerbell, os image upgrade",
+			wantErr: "",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef: Ref{
+						Kind: TinkerbellDatacenterKind,
+					},
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
+						UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "InPlace", InPlace: &InPlaceUpgradeParams{OSImageUpgrade: true}},
+					}},
+				},
+			},
+		},
+		{
+			name:    "rolling upgrade - inPlace field specified",
+			wantErr: "WorkerNodeGroupConfiguration: InPlace field must be empty for 'RollingUpdate' upgrade rollout strategy type",
+			cluster: &Cluster{
+				Spec: ClusterSpec{
+					WorkerNodeGroupConfigurations: []WorkerNodeGroupConfiguration{{
+						UpgradeRolloutStrategy: &WorkerNodesUpgradeRolloutStrategy{Type: "RollingUpdate", InPlace: &InPlaceUpgradeParams{OSImageUpgrade: true}},
+					}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
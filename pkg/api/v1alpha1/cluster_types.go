@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -66,6 +67,10 @@ const (
 	// AllowDeleteWhenPausedAnnotation is an annotation applied to an EKS-A cluster that allows the deletion of the cluster
 	// when paused.
 	AllowDeleteWhenPausedAnnotation = "anywhere.eks.amazonaws.com/allow-delete-when-paused"
+
+	// ciliumClusterPoolIPAMAnnotation is applied by the EKS-A controller to clusters where EKS-A Cilium was installed
+	// with cluster-pool IPAM. This is an internal EKS-A managed annotation, not meant to be updated manually.
+	ciliumClusterPoolIPAMAnnotation = "anywhere.eks.amazonaws.com/cilium-cluster-pool-ipam"
 )
 
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
@@ -93,6 +98,17 @@ type ClusterSpec struct {
 	MachineHealthCheck *MachineHealthCheck `json:"machineHealthCheck,omitempty"`
 	EtcdEncryption     *[]EtcdEncryption   `json:"etcdEncryption,omitempty"`
 	LicenseToken       string              `json:"licenseToken,omitempty"`
+	// ServiceLoadBalancer configures the load balancer installed in the cluster to serve
+	// Services of type LoadBalancer.
+	ServiceLoadBalancer *ServiceLoadBalancerConfiguration `json:"serviceLoadBalancer,omitempty"`
+	// EtcdMaintenance configures the periodic defragmentation of the etcd members by the cluster controller.
+	EtcdMaintenance *EtcdMaintenanceConfiguration `json:"etcdMaintenance,omitempty"`
+	// MaintenanceWindow restricts the changes that roll the cluster machines, like a Kubernetes version
+	// upgrade, to recurring time windows. The changes that don't roll machines are applied right away.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// UpgradeRollbackPolicy makes the controller roll back the changes to the cluster machines when
+	// they fail to roll out, restoring the machines of the last successfully reconciled spec.
+	UpgradeRollbackPolicy *UpgradeRollbackPolicy `json:"upgradeRollbackPolicy,omitempty"`
 }
 
 // EksaVersion is the semver identifying the release of eks-a used to populate the cluster components.
@@ -194,6 +210,18 @@ func (n *Cluster) Equal(o *Cluster) bool {
 	if n.Spec.LicenseToken != o.Spec.LicenseToken {
 		return false
 	}
+	if !n.Spec.ServiceLoadBalancer.Equal(o.Spec.ServiceLoadBalancer) {
+		return false
+	}
+	if !n.Spec.EtcdMaintenance.Equal(o.Spec.EtcdMaintenance) {
+		return false
+	}
+	if !n.Spec.MaintenanceWindow.Equal(o.Spec.MaintenanceWindow) {
+		return false
+	}
+	if !n.Spec.UpgradeRollbackPolicy.Equal(o.Spec.UpgradeRollbackPolicy) {
+		return false
+	}
 
 	return true
 }
@@ -569,6 +597,8 @@ func WorkerNodeGroupConfigurationKubeVersionUnchanged(o, n *WorkerNodeGroupConfi
 type ClusterNetwork struct {
 	// Comma-separated list of CIDR blocks to use for pod and service subnets.
 	// Defaults to 192.168.0.0/16 for pod subnet.
+	// Dual-stack clusters take one IPv4 and one IPv6 CIDR block for pods and services,
+	// the first block defines the primary IP family.
 	Pods     Pods     `json:"pods,omitempty"`
 	Services Services `json:"services,omitempty"`
 	// Deprecated. Use CNIConfig
@@ -621,10 +651,113 @@ func getCNIConfig(cn *ClusterNetwork) *CNIConfig {
 	return tempCNIConfig
 }
 
+// IPFamily is the IP address family of a CIDR block.
+type IPFamily string
+
+const (
+	// IPv4Family is the IPv4 address family.
+	IPv4Family IPFamily = "IPv4"
+	// IPv6Family is the IPv6 address family.
+	IPv6Family IPFamily = "IPv6"
+)
+
+// IPFamilies returns the IP families of the pod CIDR blocks, in order. The first one is the primary family.
+// CIDR blocks that can't be parsed are ignored.
+func (n *ClusterNetwork) IPFamilies() []IPFamily {
+	families := make([]IPFamily, 0, 2)
+	for _, block := range n.PrimaryPodCIDRBlocks() {
+		ip, _, _ := net.ParseCIDR(block)
+		families = append(families, ipFamily(ip))
+	}
+	return families
+}
+
+// PrimaryPodCIDRBlocks returns the first pod CIDR block of each IP family, in order. These are the
+// ranges used by kube-controller-manager to allocate node CIDRs. CIDR blocks that can't be parsed are ignored.
+func (n *ClusterNetwork) PrimaryPodCIDRBlocks() []string {
+	primary, _ := n.splitPodCIDRBlocks()
+	return primary
+}
+
+// AdditionalPodCIDRBlocks returns the pod CIDR blocks appended to expand the pod network after
+// the first block of their IP family. They are only used by the Cilium IPAM pool.
+func (n *ClusterNetwork) AdditionalPodCIDRBlocks() []string {
+	_, additional := n.splitPodCIDRBlocks()
+	return additional
+}
+
+// PodCIDRBlocksForFamily returns all the pod CIDR blocks of an IP family, primary block first.
+func (n *ClusterNetwork) PodCIDRBlocksForFamily(family IPFamily) []string {
+	var blocks []string
+	for _, block := range n.Pods.CidrBlocks {
+		ip, _, err := net.ParseCIDR(block)
+		if err != nil || ipFamily(ip) != family {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// IsPodNetworkExpansionOf returns true if n appends pod CIDR blocks to the pod network of o without
+// changing its primary CIDR blocks, so kube-controller-manager's cluster-cidr stays the same.
+func (n *ClusterNetwork) IsPodNetworkExpansionOf(o *ClusterNetwork) bool {
+	return n.Pods.IsExpansionOf(&o.Pods) &&
+		slices.Equal(n.PrimaryPodCIDRBlocks(), o.PrimaryPodCIDRBlocks())
+}
+
+// NodeCIDRMaskSize returns the mask size of the node CIDRs allocated from the pod CIDR blocks of an IP family.
+func (n *ClusterNetwork) NodeCIDRMaskSize(family IPFamily) int {
+	return nodeCIDRMaskSize(n.Nodes, family)
+}
+
+func (n *ClusterNetwork) splitPodCIDRBlocks() (primary, additional []string) {
+	seen := map[IPFamily]bool{}
+	for _, block := range n.Pods.CidrBlocks {
+		ip, _, err := net.ParseCIDR(block)
+		if err != nil {
+			continue
+		}
+		if family := ipFamily(ip); !seen[family] {
+			seen[family] = true
+			primary = append(primary, block)
+		} else {
+			additional = append(additional, block)
+		}
+	}
+	return primary, additional
+}
+
+// IsDualStack returns true if the cluster network has one IPv4 and one IPv6 CIDR block for pods.
+func (n *ClusterNetwork) IsDualStack() bool {
+	families := n.IPFamilies()
+	return len(families) == 2 && families[0] != families[1]
+}
+
+func ipFamily(ip net.IP) IPFamily {
+	if ip.To4() != nil {
+		return IPv4Family
+	}
+	return IPv6Family
+}
+
 func (n *Pods) Equal(o *Pods) bool {
 	return SliceEqual(n.CidrBlocks, o.CidrBlocks)
 }
 
+// IsExpansionOf returns true if n keeps all the CIDR blocks of o, in the same order, and appends new ones.
+func (n *Pods) IsExpansionOf(o *Pods) bool {
+	if len(n.CidrBlocks) <= len(o.CidrBlocks) {
+		return false
+	}
+	for i, block := range o.CidrBlocks {
+		if n.CidrBlocks[i] != block {
+			return false
+		}
+	}
+	return true
+}
+
 func (n *Services) Equal(o *Services) bool {
 	return SliceEqual(n.CidrBlocks, o.CidrBlocks)
 }
@@ -774,6 +907,16 @@ func CNIPluginSame(n ClusterNetwork, o ClusterNetwork) bool {
 	return true
 }
 
+// IsKindnetdToCiliumMigration returns true if going from the old ClusterNetwork o to the new
+// ClusterNetwork n switches the CNI from Kindnetd to Cilium, the only supported CNI change.
+func IsKindnetdToCiliumMigration(n ClusterNetwork, o ClusterNetwork) bool {
+	if n.CNIConfig == nil || n.CNIConfig.Cilium == nil {
+		return false
+	}
+
+	return o.CNI == Kindnetd || (o.CNIConfig != nil && o.CNIConfig.Kindnetd != nil)
+}
+
 func SliceEqual(a, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -837,6 +980,8 @@ type ResolvConf struct {
 type Nodes struct {
 	// CIDRMaskSize defines the mask size for node cidr in the cluster, default for ipv4 is 24. This is an optional field
 	CIDRMaskSize *int `json:"cidrMaskSize,omitempty"`
+	// CIDRMaskSizeIPv6 defines the mask size for the IPv6 node cidr in dual-stack clusters, default is 64. This is an optional field
+	CIDRMaskSizeIPv6 *int `json:"cidrMaskSizeIPv6,omitempty"`
 }
 
 // Equal compares two Nodes definitions and return true if the are equivalent.
@@ -848,14 +993,7 @@ func (n *Nodes) Equal(o *Nodes) bool {
 		return false
 	}
 
-	if n.CIDRMaskSize == o.CIDRMaskSize {
-		return true
-	}
-	if n.CIDRMaskSize == nil || o.CIDRMaskSize == nil {
-		return false
-	}
-
-	return *n.CIDRMaskSize == *o.CIDRMaskSize
+	return intPtrEqual(n.CIDRMaskSize, o.CIDRMaskSize) && intPtrEqual(n.CIDRMaskSizeIPv6, o.CIDRMaskSizeIPv6)
 }
 
 func (n *ResolvConf) Equal(o *ResolvConf) bool {
@@ -1069,6 +1207,33 @@ type ClusterCertificateInfo struct {
 	ExpiresInDays int `json:"expiresInDays"`
 }
 
+// EtcdMaintenanceStatus contains the state of the etcd members observed by the etcd maintenance.
+type EtcdMaintenanceStatus struct {
+	// LastCheckTime is the last time the etcd members were checked.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// Members contains the database sizes of each etcd member.
+	// +optional
+	Members []EtcdMemberStatus `json:"members,omitempty"`
+}
+
+// EtcdMemberStatus contains the database sizes of an etcd member.
+type EtcdMemberStatus struct {
+	// Machine is the name of the machine running the member.
+	Machine string `json:"machine"`
+	// MemberID is the etcd member ID in hexadecimal.
+	MemberID string `json:"memberID,omitempty"`
+	// Leader is true when the member was the raft leader at the last check.
+	Leader bool `json:"leader,omitempty"`
+	// DBSizeBytes is the size of the member database.
+	DBSizeBytes int64 `json:"dbSizeBytes"`
+	// DBSizeInUseBytes is the size of the member database in use, the rest can be reclaimed by defragmenting the member.
+	DBSizeInUseBytes int64 `json:"dbSizeInUseBytes"`
+	// LastDefragTime is the last time the member was defragmented.
+	// +optional
+	LastDefragTime *metav1.Time `json:"lastDefragTime,omitempty"`
+}
+
 // ClusterStatus defines the observed state of Cluster.
 type ClusterStatus struct {
 	// Descriptive message about a fatal problem while reconciling a cluster
@@ -1089,6 +1254,14 @@ type ClusterStatus struct {
 	// +optional
 	ClusterCertificateInfo []ClusterCertificateInfo `json:"clusterCertificateInfo,omitempty"`
 
+	// EtcdMaintenance contains the state of the etcd members observed by the etcd maintenance.
+	// +optional
+	EtcdMaintenance *EtcdMaintenanceStatus `json:"etcdMaintenance,omitempty"`
+
+	// UpgradeRollback contains the state of the upgrade rollback policy.
+	// +optional
+	UpgradeRollback *UpgradeRollbackStatus `json:"upgradeRollback,omitempty"`
+
 	// ReconciledGeneration represents the .metadata.generation the last time the
 	// cluster was successfully reconciled. It is the latest generation observed
 	// by the controller.
@@ -1174,6 +1347,75 @@ type KindAccessor interface {
 	ExpectedKind() string
 }
 
+// ServiceLoadBalancerMode defines how the service load balancer announces Service IPs to the network.
+type ServiceLoadBalancerMode string
+
+const (
+	// ServiceLoadBalancerModeL2 announces Service IPs with ARP/NDP from one of the nodes.
+	ServiceLoadBalancerModeL2 ServiceLoadBalancerMode = "L2"
+	// ServiceLoadBalancerModeBGP announces Service IPs to BGP peers from all the nodes.
+	ServiceLoadBalancerModeBGP ServiceLoadBalancerMode = "BGP"
+)
+
+// ServiceLoadBalancerConfiguration configures the load balancer serving Services of type LoadBalancer.
+type ServiceLoadBalancerConfiguration struct {
+	// Mode defines how Service IPs are announced. Defaults to L2.
+	// +kubebuilder:validation:Enum=L2;BGP
+	Mode ServiceLoadBalancerMode `json:"mode,omitempty"`
+
+	// AddressPools are the pools of IPs assigned to Services of type LoadBalancer.
+	AddressPools []ServiceLoadBalancerAddressPool `json:"addressPools,omitempty"`
+
+	// Interfaces restricts L2 announcements to the given node network interfaces.
+	// When empty, Service IPs are announced from all interfaces.
+	Interfaces []string `json:"interfaces,omitempty"`
+
+	// BGP configures the BGP sessions used in BGP mode.
+	BGP *ServiceLoadBalancerBGPConfiguration `json:"bgp,omitempty"`
+}
+
+// ServiceLoadBalancerAddressPool is a named pool of IPs for Services of type LoadBalancer.
+type ServiceLoadBalancerAddressPool struct {
+	// Name of the pool. Services can request a specific pool with the
+	// metallb.universe.tf/address-pool annotation.
+	Name string `json:"name"`
+
+	// Addresses is a list of CIDR blocks or IP ranges in the form start-end.
+	Addresses []string `json:"addresses"`
+}
+
+// ServiceLoadBalancerBGPConfiguration configures the BGP sessions of the service load balancer.
+type ServiceLoadBalancerBGPConfiguration struct {
+	// LocalASN is the AS number used by the nodes.
+	LocalASN uint32 `json:"localASN"`
+
+	// Peers are the BGP routers the nodes establish sessions with.
+	Peers []ServiceLoadBalancerBGPPeer `json:"peers"`
+}
+
+// ServiceLoadBalancerBGPPeer is a BGP router the nodes peer with.
+type ServiceLoadBalancerBGPPeer struct {
+	// Address is the IP of the router.
+	Address string `json:"address"`
+
+	// ASN is the AS number of the router.
+	ASN uint32 `json:"asn"`
+
+	// Port of the router BGP session. Defaults to 179.
+	Port int `json:"port,omitempty"`
+}
+
+// Equal for ServiceLoadBalancerConfiguration.
+func (n *ServiceLoadBalancerConfiguration) Equal(o *ServiceLoadBalancerConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return reflect.DeepEqual(n, o)
+}
+
 // PackageConfiguration for installing EKS Anywhere curated packages.
 type PackageConfiguration struct {
 	// Disable package controller on cluster
@@ -1301,6 +1543,111 @@ type ExternalEtcdConfiguration struct {
 	MachineGroupRef *Ref `json:"machineGroupRef,omitempty"`
 }
 
+// EtcdMaintenanceConfiguration configures the periodic maintenance of the etcd members.
+// The etcd machines are accessed over SSH to check the size of each member database and
+// defragment the members whose database has too much unused space.
+type EtcdMaintenanceConfiguration struct {
+	// SSHKeySecretName is the name of a Secret in the eksa-system namespace with the SSH credentials
+	// for the etcd machines: the user in the "username" key, the private key in the "ssh-privatekey" key
+	// and the host keys of the machines, in the OpenSSH known_hosts format, in the "known_hosts" key.
+	// A "passphrase" key can be set when the private key is protected with a passphrase.
+	SSHKeySecretName string `json:"sshKeySecretName"`
+	// CheckInterval is how often the etcd members are checked. Defaults to 1h.
+	// +optional
+	CheckInterval *metav1.Duration `json:"checkInterval,omitempty"`
+	// DefragThresholdPercent is the percentage of the database size not in use above which
+	// a member is defragmented. Defaults to 50.
+	// +optional
+	DefragThresholdPercent int `json:"defragThresholdPercent,omitempty"`
+	// Window restricts the defragmentation of the members to a daily window. The members
+	// are checked outside the window, but only defragmented inside of it.
+	// +optional
+	Window *EtcdMaintenanceWindow `json:"window,omitempty"`
+}
+
+// EtcdMaintenanceWindow is a daily time window.
+type EtcdMaintenanceWindow struct {
+	// Start is the time of the day the window starts at, in UTC and in the format HH:MM.
+	Start string `json:"start"`
+	// Duration is the length of the window.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// Equal for EtcdMaintenanceConfiguration.
+func (n *EtcdMaintenanceConfiguration) Equal(o *EtcdMaintenanceConfiguration) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return reflect.DeepEqual(n, o)
+}
+
+// MaintenanceWindow is a recurring time window in which the cluster machines can be rolled.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression with the times the window opens at, in the format
+	// "minute hour day-of-month month day-of-week". For example, "0 22 * * 1-5" opens
+	// the window at 22:00 from Monday to Friday.
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open each time it opens.
+	Duration metav1.Duration `json:"duration"`
+	// Timezone is the IANA time zone the schedule is evaluated in, like "America/New_York". Defaults to UTC.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Equal for MaintenanceWindow.
+func (n *MaintenanceWindow) Equal(o *MaintenanceWindow) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return *n == *o
+}
+
+// UpgradeRollbackPolicy configures when the controller rolls back a failed upgrade. At least one of
+// Timeout and MaxUnhealthyMachines must be set.
+type UpgradeRollbackPolicy struct {
+	// Timeout is how long the controller waits for the changes to the cluster to be applied before rolling them back.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// MaxUnhealthyMachines is the number of machines created during the upgrade and failing their
+	// MachineHealthCheck that makes the controller roll back the upgrade.
+	// +optional
+	MaxUnhealthyMachines *int `json:"maxUnhealthyMachines,omitempty"`
+}
+
+// Equal for UpgradeRollbackPolicy.
+func (n *UpgradeRollbackPolicy) Equal(o *UpgradeRollbackPolicy) bool {
+	if n == o {
+		return true
+	}
+	if n == nil || o == nil {
+		return false
+	}
+	return reflect.DeepEqual(n, o)
+}
+
+// UpgradeRollbackStatus is the state of the upgrade rollback policy of a cluster.
+type UpgradeRollbackStatus struct {
+	// SnapshotGeneration and SnapshotChildrenGeneration are the reconciled generations of the cluster
+	// when the objects the upgrades are rolled back to were saved.
+	SnapshotGeneration         int64 `json:"snapshotGeneration,omitempty"`
+	SnapshotChildrenGeneration int64 `json:"snapshotChildrenGeneration,omitempty"`
+
+	// UpgradeStartTime is when the controller started applying the changes not reconciled yet.
+	// +optional
+	UpgradeStartTime *metav1.Time `json:"upgradeStartTime,omitempty"`
+
+	// RolledBackGeneration and RolledBackChildrenGeneration are the generations of the cluster that were
+	// rolled back. The changes that roll machines are not applied again until the cluster spec changes.
+	RolledBackGeneration         int64 `json:"rolledBackGeneration,omitempty"`
+	RolledBackChildrenGeneration int64 `json:"rolledBackChildrenGeneration,omitempty"`
+}
+
 func (n *ExternalEtcdConfiguration) Equal(o *ExternalEtcdConfiguration) bool {
 	if n == o {
 		return true
@@ -1372,6 +1719,9 @@ const (
 type ControlPlaneUpgradeRolloutStrategy struct {
 	Type          UpgradeRolloutStrategyType       `json:"type,omitempty"`
 	RollingUpdate *ControlPlaneRollingUpdateParams `json:"rollingUpdate,omitempty"`
+	// InPlace holds the knobs for the 'InPlace' upgrade rollout strategy type.
+	// +optional
+	InPlace *InPlaceUpgradeParams `json:"inPlace,omitempty"`
 }
 
 // ControlPlaneRollingUpdateParams is API for rolling update strategy knobs.
@@ -1383,6 +1733,9 @@ type ControlPlaneRollingUpdateParams struct {
 type WorkerNodesUpgradeRolloutStrategy struct {
 	Type          UpgradeRolloutStrategyType      `json:"type,omitempty"`
 	RollingUpdate *WorkerNodesRollingUpdateParams `json:"rollingUpdate,omitempty"`
+	// InPlace holds the knobs for the 'InPlace' upgrade rollout strategy type.
+	// +optional
+	InPlace *InPlaceUpgradeParams `json:"inPlace,omitempty"`
 }
 
 // Equal compares two WorkerNodesUpgradeRolloutStrategies.
@@ -1399,6 +1752,10 @@ func (w *WorkerNodesUpgradeRolloutStrategy) Equal(other *WorkerNodesUpgradeRollo
 		return false
 	}
 
+	if !w.InPlace.Equal(other.InPlace) {
+		return false
+	}
+
 	if w.RollingUpdate == other.RollingUpdate {
 		return true
 	}
@@ -1416,6 +1773,33 @@ type WorkerNodesRollingUpdateParams struct {
 	MaxUnavailable int `json:"maxUnavailable"`
 }
 
+// InPlaceUpgradeParams is API for in-place upgrade strategy knobs.
+type InPlaceUpgradeParams struct {
+	// OSImageUpgrade enables upgrading the node OS image as part of an in-place upgrade.
+	// The new image is written to the inactive partition of the node and the node is rebooted
+	// into it, instead of reprovisioning the machine. Only supported on Bare Metal.
+	// +optional
+	OSImageUpgrade bool `json:"osImageUpgrade,omitempty"`
+}
+
+// Equal compares two InPlaceUpgradeParams.
+func (i *InPlaceUpgradeParams) Equal(other *InPlaceUpgradeParams) bool {
+	if i == other {
+		return true
+	}
+
+	if i == nil || other == nil {
+		return false
+	}
+
+	return i.OSImageUpgrade == other.OSImageUpgrade
+}
+
+// OSImageUpgradeEnabled returns true if the OS image should be upgraded in place.
+func (i *InPlaceUpgradeParams) OSImageUpgradeEnabled() bool {
+	return i != nil && i.OSImageUpgrade
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // Cluster is the Schema for the clusters API.
@@ -1479,6 +1863,25 @@ func (c *Cluster) SetManagementComponentsVersion(version string) {
 	c.Annotations[managementComponentsVersionAnnotation] = version
 }
 
+// UsesCiliumClusterPoolIPAM returns true if Cilium allocates the node pod CIDRs of the cluster with
+// cluster-pool IPAM, either because EKS-A Cilium was installed with it or because the cluster has more
+// than one pod CIDR block per IP family. Only the pod network of these clusters can be expanded:
+// Cilium doesn't support switching the IPAM mode of a running cluster.
+func (c *Cluster) UsesCiliumClusterPoolIPAM() bool {
+	if _, ok := c.Annotations[ciliumClusterPoolIPAMAnnotation]; ok {
+		return true
+	}
+	return len(c.Spec.ClusterNetwork.AdditionalPodCIDRBlocks()) > 0
+}
+
+// SetCiliumClusterPoolIPAM sets the `cilium-cluster-pool-ipam` annotation on the Cluster object.
+func (c *Cluster) SetCiliumClusterPoolIPAM() {
+	if c.Annotations == nil {
+		c.Annotations = make(map[string]string, 1)
+	}
+	c.Annotations[ciliumClusterPoolIPAMAnnotation] = "true"
+}
+
 // DisableControlPlaneIPCheck sets the `skip-ip-check` annotation on the Cluster object.
 func (c *Cluster) DisableControlPlaneIPCheck() {
 	if c.Annotations == nil {
@@ -1661,6 +2064,8 @@ func (c *Cluster) ConvertConfigToConfigGenerateStruct() *ClusterGenerate {
 			MachineHealthCheck:            c.Spec.MachineHealthCheck,
 			EtcdEncryption:                c.Spec.EtcdEncryption,
 			LicenseToken:                  c.Spec.LicenseToken,
+			ServiceLoadBalancer:           c.Spec.ServiceLoadBalancer,
+			EtcdMaintenance:               c.Spec.EtcdMaintenance,
 		},
 	}
 
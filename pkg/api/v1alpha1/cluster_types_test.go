@@ -3526,6 +3526,35 @@ func TestWorkerNodesUpgradeRolloutStrategyEqual(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "diff in place",
+			a: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+				Type: "InPlace",
+			},
+			b: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+				Type: "InPlace",
+				InPlace: &v1alpha1.InPlaceUpgradeParams{
+					OSImageUpgrade: true,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "equal in place",
+			a: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+				Type: "InPlace",
+				InPlace: &v1alpha1.InPlaceUpgradeParams{
+					OSImageUpgrade: true,
+				},
+			},
+			b: &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+				Type: "InPlace",
+				InPlace: &v1alpha1.InPlaceUpgradeParams{
+					OSImageUpgrade: true,
+				},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -131,6 +131,8 @@ func (r *Cluster) ValidateUpdate(_ context.Context, old, obj runtime.Object) (ad
 
 	allErrs = append(allErrs, validateEtcdEncryptionSupport(newCluster)...)
 
+	allErrs = append(allErrs, validateEtcdScaling(newCluster, oldCluster)...)
+
 	if len(allErrs) != 0 {
 		return nil, apierrors.NewInvalid(GroupVersion.WithKind(ClusterKind).GroupKind(), newCluster.Name, allErrs)
 	}
@@ -313,9 +315,15 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 	}
 
 	if !new.Spec.ClusterNetwork.Pods.Equal(&old.Spec.ClusterNetwork.Pods) {
-		allErrs = append(
-			allErrs,
-			field.Forbidden(specPath.Child("clusterNetwork", "pods"), "field is immutable"))
+		if !new.Spec.ClusterNetwork.IsPodNetworkExpansionOf(&old.Spec.ClusterNetwork) {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(specPath.Child("clusterNetwork", "pods"), "field is immutable, only appending new CIDR blocks is supported"))
+		} else if !old.UsesCiliumClusterPoolIPAM() {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(specPath.Child("clusterNetwork", "pods"), "appending CIDR blocks requires Cilium cluster-pool IPAM, the cluster uses kubernetes IPAM"))
+		}
 	}
 
 	if !new.Spec.ClusterNetwork.Services.Equal(&old.Spec.ClusterNetwork.Services) {
@@ -336,6 +344,14 @@ func validateImmutableFieldsCluster(new, old *Cluster) field.ErrorList {
 			field.Forbidden(specPath.Child("clusterNetwork", "nodes"), "field is immutable"))
 	}
 
+	if IsKindnetdToCiliumMigration(new.Spec.ClusterNetwork, old.Spec.ClusterNetwork) &&
+		new.Spec.ClusterNetwork.CNIConfig.Cilium.RoutingMode != CiliumRoutingModeDirect {
+		allErrs = append(
+			allErrs,
+			field.Invalid(specPath.Child("clusterNetwork", "cniConfig", "cilium", "routingMode"), new.Spec.ClusterNetwork.CNIConfig.Cilium.RoutingMode,
+				fmt.Sprintf("migrating from Kindnetd to Cilium requires %s routing mode", CiliumRoutingModeDirect)))
+	}
+
 	if !new.Spec.ProxyConfiguration.Equal(old.Spec.ProxyConfiguration) {
 		allErrs = append(
 			allErrs,
@@ -429,6 +445,27 @@ func ValidateKubernetesVersionSkew(new, old *Cluster) field.ErrorList {
 	return validateKubeVersionSkew(newVersion, oldVersion, path)
 }
 
+// validateEtcdScaling rejects shrinking an external etcd cluster below the quorum of its current members,
+// since losing one of the remaining members while the others are being removed would make etcd unavailable.
+func validateEtcdScaling(new, old *Cluster) field.ErrorList {
+	if new.Spec.ExternalEtcdConfiguration == nil || old.Spec.ExternalEtcdConfiguration == nil {
+		return nil
+	}
+
+	current := old.Spec.ExternalEtcdConfiguration.Count
+	desired := new.Spec.ExternalEtcdConfiguration.Count
+	if quorum := current/2 + 1; desired < quorum {
+		return field.ErrorList{
+			field.Forbidden(
+				field.NewPath("spec", "externalEtcdConfiguration", "count"),
+				fmt.Sprintf("scaling etcd from %d to %d members would drop below the current quorum of %d members", current, desired, quorum),
+			),
+		}
+	}
+
+	return nil
+}
+
 func validateKubeVersionSkew(newVersion, oldVersion KubernetesVersion, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
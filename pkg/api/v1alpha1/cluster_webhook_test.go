@@ -374,6 +374,52 @@ func TestWorkloadClusterValidateUpdateExternalEtcdConfigurationNewMachineGroupRe
 	g.Expect(c.ValidateUpdate(context.TODO(), cOld, c)).Error().To(MatchError(ContainSubstring("must specify machineGroupRef for etcd machines")))
 }
 
+func TestClusterValidateUpdateExternalEtcdScaling(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   int
+		desired   int
+		expectErr string
+	}{
+		{
+			name:    "scale up",
+			current: 3,
+			desired: 5,
+		},
+		{
+			name:    "scale down keeping quorum",
+			current: 5,
+			desired: 3,
+		},
+		{
+			name:      "scale down below quorum",
+			current:   3,
+			desired:   1,
+			expectErr: "spec.externalEtcdConfiguration.count: Forbidden: scaling etcd from 3 to 1 members would drop below the current quorum of 2 members",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cOld := baseCluster()
+			cOld.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+				MachineGroupRef: &v1alpha1.Ref{Name: "test", Kind: "MachineConfig"},
+				Count:           tt.current,
+			}
+			c := cOld.DeepCopy()
+			c.Spec.ExternalEtcdConfiguration.Count = tt.desired
+
+			_, err := c.ValidateUpdate(context.TODO(), cOld, c)
+			if tt.expectErr == "" {
+				g.Expect(err).To(Succeed())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.expectErr)))
+			}
+		})
+	}
+}
+
 func TestClusterValidateUpdateDatacenterRefImmutableEqual(t *testing.T) {
 	cOld := baseCluster()
 	cOld.Spec.DatacenterRef = v1alpha1.Ref{
@@ -483,6 +529,67 @@ func TestClusterValidateUpdateClusterNetworkPodsImmutable(t *testing.T) {
 	g.Expect(c.ValidateUpdate(context.TODO(), cOld, c)).Error().To(MatchError(ContainSubstring("spec.clusterNetwork.pods: Forbidden: field is immutable")))
 }
 
+func TestClusterValidateUpdateClusterNetworkPodsExpansion(t *testing.T) {
+	features.ClearCache()
+	cOld := baseCluster()
+	cOld.SetCiliumClusterPoolIPAM()
+	c := cOld.DeepCopy()
+	c.Spec.ClusterNetwork.Pods.CidrBlocks = append(c.Spec.ClusterNetwork.Pods.CidrBlocks, "10.10.0.0/16")
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(context.TODO(), cOld, c)).Error().NotTo(HaveOccurred())
+}
+
+func TestClusterValidateUpdateClusterNetworkPodsExpansionAdditionalBlocks(t *testing.T) {
+	features.ClearCache()
+	cOld := baseCluster()
+	cOld.Spec.ClusterNetwork.Pods.CidrBlocks = append(cOld.Spec.ClusterNetwork.Pods.CidrBlocks, "10.10.0.0/16")
+	c := cOld.DeepCopy()
+	c.Spec.ClusterNetwork.Pods.CidrBlocks = append(c.Spec.ClusterNetwork.Pods.CidrBlocks, "10.20.0.0/16")
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(context.TODO(), cOld, c)).Error().NotTo(HaveOccurred())
+}
+
+func TestClusterValidateUpdateClusterNetworkPodsExpansionSwitchesIPAM(t *testing.T) {
+	features.ClearCache()
+	cOld := baseCluster()
+	c := cOld.DeepCopy()
+	c.Spec.ClusterNetwork.Pods.CidrBlocks = append(c.Spec.ClusterNetwork.Pods.CidrBlocks, "10.10.0.0/16")
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(context.TODO(), cOld, c)).Error().To(MatchError(ContainSubstring(
+		"spec.clusterNetwork.pods: Forbidden: appending CIDR blocks requires Cilium cluster-pool IPAM, the cluster uses kubernetes IPAM",
+	)))
+}
+
+func TestClusterValidateUpdateClusterNetworkKindnetdToCiliumDirectRouting(t *testing.T) {
+	features.ClearCache()
+	cOld := baseCluster()
+	cOld.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Kindnetd: &v1alpha1.KindnetdConfig{}}
+	c := cOld.DeepCopy()
+	c.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+		Cilium: &v1alpha1.CiliumConfig{
+			RoutingMode:           v1alpha1.CiliumRoutingModeDirect,
+			IPv4NativeRoutingCIDR: "192.168.0.0/16",
+		},
+	}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(context.TODO(), cOld, c)).Error().NotTo(HaveOccurred())
+}
+
+func TestClusterValidateUpdateClusterNetworkKindnetdToCiliumOverlayRouting(t *testing.T) {
+	features.ClearCache()
+	cOld := baseCluster()
+	cOld.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Kindnetd: &v1alpha1.KindnetdConfig{}}
+	c := cOld.DeepCopy()
+	c.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{Cilium: &v1alpha1.CiliumConfig{}}
+
+	g := NewWithT(t)
+	g.Expect(c.ValidateUpdate(context.TODO(), cOld, c)).Error().To(MatchError(ContainSubstring("migrating from Kindnetd to Cilium requires direct routing mode")))
+}
+
 func TestClusterValidateUpdateClusterNetworkServicesImmutable(t *testing.T) {
 	features.ClearCache()
 	cOld := baseCluster()
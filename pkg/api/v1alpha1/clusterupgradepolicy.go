@@ -0,0 +1,172 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/aws/eks-anywhere/pkg/semver"
+)
+
+const (
+	// DefaultClusterUpgradeMaxConcurrency is the default number of clusters of a wave upgrading at the same time.
+	DefaultClusterUpgradeMaxConcurrency = 1
+	// DefaultClusterUpgradeHealthCheckTimeout is the default time a cluster has to pass the health checks after its upgrade.
+	DefaultClusterUpgradeHealthCheckTimeout = time.Hour
+	// DefaultUpgradeWaveName is the name of the wave with the selected clusters not included in any of the policy waves.
+	DefaultUpgradeWaveName = "default"
+)
+
+// MaxConcurrency returns the maximum number of clusters of a wave upgrading at the same time.
+func (p *ClusterUpgradePolicy) MaxConcurrency() int {
+	if p.Spec.MaxConcurrency <= 0 {
+		return DefaultClusterUpgradeMaxConcurrency
+	}
+	return p.Spec.MaxConcurrency
+}
+
+// HealthCheckTimeout returns how long a cluster has to pass the health checks after its upgrade.
+func (p *ClusterUpgradePolicy) HealthCheckTimeout() time.Duration {
+	if p.Spec.HealthCheckTimeout == nil || p.Spec.HealthCheckTimeout.Duration <= 0 {
+		return DefaultClusterUpgradeHealthCheckTimeout
+	}
+	return p.Spec.HealthCheckTimeout.Duration
+}
+
+// Validate validates the ClusterUpgradePolicy spec.
+func (p *ClusterUpgradePolicy) Validate() error {
+	if p.Spec.KubernetesVersion == "" && p.Spec.EksaVersion == nil {
+		return errors.New("at least one of kubernetesVersion and eksaVersion must be set")
+	}
+
+	if p.Spec.KubernetesVersion != "" {
+		if _, err := version.ParseGeneric(string(p.Spec.KubernetesVersion)); err != nil {
+			return fmt.Errorf("kubernetesVersion %s is invalid: %v", p.Spec.KubernetesVersion, err)
+		}
+	}
+
+	if p.Spec.EksaVersion != nil {
+		if _, err := semver.New(string(*p.Spec.EksaVersion)); err != nil {
+			return fmt.Errorf("eksaVersion %s is not a valid semver", *p.Spec.EksaVersion)
+		}
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(&p.Spec.ClusterSelector); err != nil {
+		return fmt.Errorf("clusterSelector is invalid: %v", err)
+	}
+
+	if p.Spec.MaxConcurrency < 0 {
+		return fmt.Errorf("maxConcurrency %d can't be negative", p.Spec.MaxConcurrency)
+	}
+
+	waves := map[string]struct{}{DefaultUpgradeWaveName: {}}
+	for _, w := range p.Spec.Waves {
+		if w.Name == "" {
+			return errors.New("waves must have a name")
+		}
+		if _, ok := waves[w.Name]; ok {
+			return fmt.Errorf("wave name %s is duplicated or reserved", w.Name)
+		}
+		waves[w.Name] = struct{}{}
+
+		if _, err := metav1.LabelSelectorAsSelector(&w.ClusterSelector); err != nil {
+			return fmt.Errorf("clusterSelector of wave %s is invalid: %v", w.Name, err)
+		}
+	}
+
+	checks := map[string]struct{}{}
+	for _, c := range p.Spec.HealthChecks {
+		if c.Name == "" {
+			return errors.New("healthChecks must have a name")
+		}
+		if _, ok := checks[c.Name]; ok {
+			return fmt.Errorf("healthCheck name %s is duplicated", c.Name)
+		}
+		checks[c.Name] = struct{}{}
+
+		if (c.Condition == "") == (c.Deployment == nil) {
+			return fmt.Errorf("healthCheck %s must set exactly one of condition and deployment", c.Name)
+		}
+		if c.Deployment != nil && (c.Deployment.Namespace == "" || c.Deployment.Name == "") {
+			return fmt.Errorf("healthCheck %s must set the deployment namespace and name", c.Name)
+		}
+	}
+
+	return nil
+}
+
+// Selects returns true if the policy selects the cluster. The policy must be valid.
+func (p *ClusterUpgradePolicy) Selects(cluster *Cluster) bool {
+	if cluster.IsSelfManaged() || cluster.Namespace != p.Namespace {
+		return false
+	}
+	return selects(&p.Spec.ClusterSelector, cluster)
+}
+
+// WaveFor returns the name of the wave of a cluster selected by the policy. The policy must be valid.
+func (p *ClusterUpgradePolicy) WaveFor(cluster *Cluster) string {
+	for _, w := range p.Spec.Waves {
+		if selects(&w.ClusterSelector, cluster) {
+			return w.Name
+		}
+	}
+	return DefaultUpgradeWaveName
+}
+
+// WaveNames returns the names of the waves in the order they are upgraded.
+func (p *ClusterUpgradePolicy) WaveNames() []string {
+	names := make([]string, 0, len(p.Spec.Waves)+1)
+	for _, w := range p.Spec.Waves {
+		names = append(names, w.Name)
+	}
+	return append(names, DefaultUpgradeWaveName)
+}
+
+// IsAtTarget returns true if the cluster spec has the target versions of the policy.
+func (p *ClusterUpgradePolicy) IsAtTarget(cluster *Cluster) bool {
+	if p.Spec.KubernetesVersion != "" && cluster.Spec.KubernetesVersion != p.Spec.KubernetesVersion {
+		return false
+	}
+	if p.Spec.EksaVersion != nil && !p.Spec.EksaVersion.Equal(cluster.Spec.EksaVersion) {
+		return false
+	}
+	return true
+}
+
+// UpgradeCluster sets the target versions of the policy in the cluster spec. It returns an
+// error without modifying the cluster if the upgrade doesn't respect the supported version skew.
+func (p *ClusterUpgradePolicy) UpgradeCluster(cluster *Cluster) error {
+	upgraded := cluster.DeepCopy()
+	if p.Spec.KubernetesVersion != "" {
+		upgraded.Spec.KubernetesVersion = p.Spec.KubernetesVersion
+	}
+	if p.Spec.EksaVersion != nil {
+		v := *p.Spec.EksaVersion
+		upgraded.Spec.EksaVersion = &v
+	}
+
+	allErrs := ValidateKubernetesVersionSkew(upgraded, cluster)
+	allErrs = append(allErrs, ValidateWorkerKubernetesVersionSkew(upgraded, cluster)...)
+	if !cluster.EksaVersionSkewCheckDisabled() {
+		allErrs = append(allErrs, ValidateEksaVersionSkew(upgraded, cluster)...)
+	}
+	if err := allErrs.ToAggregate(); err != nil {
+		return err
+	}
+
+	cluster.Spec.KubernetesVersion = upgraded.Spec.KubernetesVersion
+	cluster.Spec.EksaVersion = upgraded.Spec.EksaVersion
+	return nil
+}
+
+func selects(selector *metav1.LabelSelector, cluster *Cluster) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(cluster.Labels))
+}
@@ -0,0 +1,212 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterUpgradePolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*ClusterUpgradePolicy)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*ClusterUpgradePolicy) {},
+		},
+		{
+			name: "no target version",
+			mutate: func(p *ClusterUpgradePolicy) {
+				p.Spec.KubernetesVersion = ""
+			},
+			wantErr: "at least one of kubernetesVersion and eksaVersion must be set",
+		},
+		{
+			name: "invalid kubernetes version",
+			mutate: func(p *ClusterUpgradePolicy) {
+				p.Spec.KubernetesVersion = "latest"
+			},
+			wantErr: "kubernetesVersion latest is invalid",
+		},
+		{
+			name: "invalid eksa version",
+			mutate: func(p *ClusterUpgradePolicy) {
+				v := EksaVersion("v0.x")
+				p.Spec.EksaVersion = &v
+			},
+			wantErr: "eksaVersion v0.x is not a valid semver",
+		},
+		{
+			name: "invalid cluster selector",
+			mutate: func(p *ClusterUpgradePolicy) {
+				p.Spec.ClusterSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "stage", Operator: "Is"}}
+			},
+			wantErr: "clusterSelector is invalid",
+		},
+		{
+			name: "negative max concurrency",
+			mutate: func(p *ClusterUpgradePolicy) {
+				p.Spec.MaxConcurrency = -1
+			},
+			wantErr: "maxConcurrency -1 can't be negative",
+		},
+		{
+			name: "reserved wave name",
+			mutate: func(p *ClusterUpgradePolicy) {
+				p.Spec.Waves[1].Name = DefaultUpgradeWaveName
+			},
+			wantErr: "wave name default is duplicated or reserved",
+		},
+		{
+			name: "health check without condition or deployment",
+			mutate: func(p *ClusterUpgradePolicy) {
+				p.Spec.HealthChecks = []ClusterHealthCheck{{Name: "empty"}}
+			},
+			wantErr: "healthCheck empty must set exactly one of condition and deployment",
+		},
+		{
+			name: "health check with incomplete deployment",
+			mutate: func(p *ClusterUpgradePolicy) {
+				p.Spec.HealthChecks = []ClusterHealthCheck{{Name: "app", Deployment: &DeploymentReference{Name: "app"}}}
+			},
+			wantErr: "healthCheck app must set the deployment namespace and name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p := upgradePolicy()
+			tt.mutate(p)
+			err := p.Validate()
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestClusterUpgradePolicyDefaults(t *testing.T) {
+	g := NewWithT(t)
+	p := upgradePolicy()
+	g.Expect(p.MaxConcurrency()).To(Equal(1))
+	g.Expect(p.HealthCheckTimeout()).To(Equal(time.Hour))
+
+	p.Spec.MaxConcurrency = 3
+	p.Spec.HealthCheckTimeout = &metav1.Duration{Duration: 10 * time.Minute}
+	g.Expect(p.MaxConcurrency()).To(Equal(3))
+	g.Expect(p.HealthCheckTimeout()).To(Equal(10 * time.Minute))
+}
+
+func TestClusterUpgradePolicySelectsAndWaveFor(t *testing.T) {
+	g := NewWithT(t)
+	p := upgradePolicy()
+
+	canary := upgradePolicyTestCluster(map[string]string{"fleet": "true", "stage": "canary"})
+	g.Expect(p.Selects(canary)).To(BeTrue())
+	g.Expect(p.WaveFor(canary)).To(Equal("canary"))
+
+	other := upgradePolicyTestCluster(map[string]string{"fleet": "true"})
+	g.Expect(p.Selects(other)).To(BeTrue())
+	g.Expect(p.WaveFor(other)).To(Equal(DefaultUpgradeWaveName))
+
+	g.Expect(p.Selects(upgradePolicyTestCluster(map[string]string{"stage": "canary"}))).To(BeFalse())
+
+	selfManaged := upgradePolicyTestCluster(map[string]string{"fleet": "true"})
+	selfManaged.Spec.ManagementCluster.Name = selfManaged.Name
+	g.Expect(p.Selects(selfManaged)).To(BeFalse())
+
+	otherNamespace := upgradePolicyTestCluster(map[string]string{"fleet": "true"})
+	otherNamespace.Namespace = "other"
+	g.Expect(p.Selects(otherNamespace)).To(BeFalse())
+
+	g.Expect(p.WaveNames()).To(Equal([]string{"canary", "prod", DefaultUpgradeWaveName}))
+}
+
+func TestClusterUpgradePolicyUpgradeCluster(t *testing.T) {
+	g := NewWithT(t)
+	p := upgradePolicy()
+	eksaVersion := EksaVersion("v0.22.0")
+	p.Spec.EksaVersion = &eksaVersion
+
+	cluster := upgradePolicyTestCluster(nil)
+	oldEksaVersion := EksaVersion("v0.21.3")
+	cluster.Spec.EksaVersion = &oldEksaVersion
+	g.Expect(p.IsAtTarget(cluster)).To(BeFalse())
+
+	g.Expect(p.UpgradeCluster(cluster)).To(Succeed())
+	g.Expect(cluster.Spec.KubernetesVersion).To(Equal(Kube130))
+	g.Expect(*cluster.Spec.EksaVersion).To(Equal(eksaVersion))
+	g.Expect(p.IsAtTarget(cluster)).To(BeTrue())
+}
+
+func TestClusterUpgradePolicyUpgradeClusterVersionSkew(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Cluster)
+		wantErr string
+	}{
+		{
+			name: "kubernetes minor versions skipped",
+			mutate: func(c *Cluster) {
+				c.Spec.KubernetesVersion = Kube128
+			},
+			wantErr: "only +1 minor version skew is supported",
+		},
+		{
+			name: "kubernetes downgrade",
+			mutate: func(c *Cluster) {
+				c.Spec.KubernetesVersion = Kube131
+			},
+			wantErr: "kubernetes version downgrade is not supported",
+		},
+		{
+			name: "worker node group too old",
+			mutate: func(c *Cluster) {
+				v := Kube127
+				c.Spec.WorkerNodeGroupConfigurations = []WorkerNodeGroupConfiguration{{Name: "md-0", KubernetesVersion: &v}}
+			},
+			wantErr: "cluster level minor version must be within 2 versions greater than worker node group version",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := upgradePolicyTestCluster(nil)
+			tt.mutate(cluster)
+			want := cluster.DeepCopy()
+
+			g.Expect(upgradePolicy().UpgradeCluster(cluster)).To(MatchError(ContainSubstring(tt.wantErr)))
+			g.Expect(cluster).To(Equal(want))
+		})
+	}
+}
+
+func upgradePolicy() *ClusterUpgradePolicy {
+	return &ClusterUpgradePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet", Namespace: "default"},
+		Spec: ClusterUpgradePolicySpec{
+			ClusterSelector:   metav1.LabelSelector{MatchLabels: map[string]string{"fleet": "true"}},
+			KubernetesVersion: Kube130,
+			Waves: []UpgradeWave{
+				{Name: "canary", ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"stage": "canary"}}},
+				{Name: "prod", ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"stage": "prod"}}},
+			},
+		},
+	}
+}
+
+func upgradePolicyTestCluster(labels map[string]string) *Cluster {
+	return &Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default", Labels: labels},
+		Spec: ClusterSpec{
+			KubernetesVersion: Kube129,
+			ManagementCluster: ManagementCluster{Name: "mgmt"},
+		},
+	}
+}
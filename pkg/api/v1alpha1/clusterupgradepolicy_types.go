@@ -0,0 +1,186 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterUpgradePolicyKind stores the kind for ClusterUpgradePolicy.
+const ClusterUpgradePolicyKind = "ClusterUpgradePolicy"
+
+// ClusterUpgradePolicySpec defines the desired state of ClusterUpgradePolicy.
+type ClusterUpgradePolicySpec struct {
+	// ClusterSelector selects the workload clusters upgraded by the policy, in the namespace of the policy.
+	// Self-managed clusters are never selected.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// KubernetesVersion is the Kubernetes version to upgrade the clusters to.
+	// +optional
+	KubernetesVersion KubernetesVersion `json:"kubernetesVersion,omitempty"`
+
+	// EksaVersion is the EKS Anywhere version to upgrade the clusters to.
+	// +optional
+	EksaVersion *EksaVersion `json:"eksaVersion,omitempty"`
+
+	// Waves are the ordered groups of clusters to upgrade. A wave starts when all the clusters of the
+	// previous waves are upgraded and healthy. A cluster belongs to the first wave selecting it,
+	// the selected clusters not included in any wave are upgraded in a last wave.
+	// +optional
+	Waves []UpgradeWave `json:"waves,omitempty"`
+
+	// MaxConcurrency is the maximum number of clusters of a wave upgrading at the same time. Defaults to 1.
+	// +optional
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+
+	// HealthChecks are the checks, in addition to the Ready condition, a cluster must pass after
+	// its upgrade for the next clusters to be upgraded.
+	// +optional
+	HealthChecks []ClusterHealthCheck `json:"healthChecks,omitempty"`
+
+	// HealthCheckTimeout is how long a cluster has to pass the health checks after its upgrade starts.
+	// The policy is paused when a cluster doesn't pass them in time. Defaults to 1h.
+	// +optional
+	HealthCheckTimeout *metav1.Duration `json:"healthCheckTimeout,omitempty"`
+
+	// Paused stops the policy from upgrading more clusters. The controller pauses the policy when a
+	// cluster upgrade fails, setting it back to false retries the failed cluster.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// UpgradeWave is a group of clusters upgraded together.
+type UpgradeWave struct {
+	// Name identifies the wave in the status.
+	Name string `json:"name"`
+
+	// ClusterSelector selects the clusters of the wave among the clusters selected by the policy.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+}
+
+// ClusterHealthCheck is a check a cluster must pass after its upgrade. Only one of Condition and Deployment can be set.
+type ClusterHealthCheck struct {
+	// Name identifies the check in the status.
+	Name string `json:"name"`
+
+	// Condition is a condition of the Cluster that must be True.
+	// +optional
+	Condition ConditionType `json:"condition,omitempty"`
+
+	// Deployment is a Deployment in the workload cluster that must have all its replicas updated and available.
+	// +optional
+	Deployment *DeploymentReference `json:"deployment,omitempty"`
+}
+
+// DeploymentReference is a reference to a Deployment in a workload cluster.
+type DeploymentReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// ClusterUpgradePolicyPhase is the phase of a ClusterUpgradePolicy.
+type ClusterUpgradePolicyPhase string
+
+const (
+	// ClusterUpgradePolicyUpgrading means the policy is upgrading clusters.
+	ClusterUpgradePolicyUpgrading ClusterUpgradePolicyPhase = "Upgrading"
+	// ClusterUpgradePolicyPaused means the policy is paused, by the user or after a failure.
+	ClusterUpgradePolicyPaused ClusterUpgradePolicyPhase = "Paused"
+	// ClusterUpgradePolicyCompleted means all the selected clusters are upgraded and healthy.
+	ClusterUpgradePolicyCompleted ClusterUpgradePolicyPhase = "Completed"
+	// ClusterUpgradePolicyInvalid means the policy spec is invalid.
+	ClusterUpgradePolicyInvalid ClusterUpgradePolicyPhase = "Invalid"
+)
+
+// ClusterUpgradePhase is the upgrade phase of a cluster selected by a ClusterUpgradePolicy.
+type ClusterUpgradePhase string
+
+const (
+	// ClusterUpgradePending means the cluster is waiting for its upgrade to start.
+	ClusterUpgradePending ClusterUpgradePhase = "Pending"
+	// ClusterUpgradeInProgress means the cluster was updated to the target versions and it's not healthy yet.
+	ClusterUpgradeInProgress ClusterUpgradePhase = "Upgrading"
+	// ClusterUpgradeSucceeded means the cluster is at the target versions and healthy.
+	ClusterUpgradeSucceeded ClusterUpgradePhase = "Upgraded"
+	// ClusterUpgradeFailed means the cluster couldn't be upgraded or didn't pass the health checks in time.
+	ClusterUpgradeFailed ClusterUpgradePhase = "Failed"
+)
+
+// ClusterUpgradePolicyStatus defines the observed state of ClusterUpgradePolicy.
+type ClusterUpgradePolicyStatus struct {
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the phase of the policy.
+	// +optional
+	Phase ClusterUpgradePolicyPhase `json:"phase,omitempty"`
+
+	// CurrentWave is the name of the wave being upgraded.
+	// +optional
+	CurrentWave string `json:"currentWave,omitempty"`
+
+	// UpgradedClusters is the number of selected clusters upgraded and healthy.
+	UpgradedClusters int `json:"upgradedClusters"`
+
+	// TotalClusters is the number of selected clusters.
+	TotalClusters int `json:"totalClusters"`
+
+	// Clusters is the upgrade state of each selected cluster.
+	// +optional
+	Clusters []ClusterUpgradeStatus `json:"clusters,omitempty"`
+
+	// FailureMessage describes why the policy was paused or is invalid.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
+
+// ClusterUpgradeStatus is the upgrade state of a cluster selected by a ClusterUpgradePolicy.
+type ClusterUpgradeStatus struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+
+	// Wave is the name of the wave of the cluster.
+	Wave string `json:"wave"`
+
+	// Phase is the upgrade phase of the cluster.
+	Phase ClusterUpgradePhase `json:"phase"`
+
+	// StartTime is when the health checks of the cluster started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Message gives details about the phase, like the failed health checks.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=clusterupgradepolicies,shortName=cup,scope=Namespaced,singular=clusterupgradepolicy
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase of the policy"
+//+kubebuilder:printcolumn:name="Wave",type="string",JSONPath=".status.currentWave",description="Wave being upgraded"
+//+kubebuilder:printcolumn:name="Upgraded",type="integer",JSONPath=".status.upgradedClusters",description="Clusters upgraded and healthy"
+//+kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.totalClusters",description="Clusters selected by the policy"
+//+kubebuilder:printcolumn:name="KubernetesVersion",type="string",JSONPath=".spec.kubernetesVersion",description="Target Kubernetes version"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of the policy"
+
+// ClusterUpgradePolicy is the Schema for the clusterupgradepolicies API.
+type ClusterUpgradePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterUpgradePolicySpec   `json:"spec,omitempty"`
+	Status ClusterUpgradePolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterUpgradePolicyList contains a list of ClusterUpgradePolicy.
+type ClusterUpgradePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUpgradePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUpgradePolicy{}, &ClusterUpgradePolicyList{})
+}
@@ -77,4 +77,39 @@ const (
 	// upgrades for the default cni. The default cni may still be installed, for example to successfully
 	// create a cluster.
 	SkipUpgradesForDefaultCNIConfiguredReason = "SkipUpgradesForDefaultCNIConfigured"
+
+	// CNIMigratedCondition reports the cluster has been migrated from Kindnetd to Cilium. It is only
+	// present on clusters that went through a CNI migration.
+	CNIMigratedCondition ConditionType = "CNIMigrated"
+
+	// CNIMigrationInProgressReason used when the cluster nodes are being migrated from Kindnetd to Cilium.
+	CNIMigrationInProgressReason = "CNIMigrationInProgress"
+
+	// ServiceLoadBalancerReadyCondition reports the built-in service load balancer has been installed and
+	// configured with the address pools in the cluster spec. It is only present on clusters with a
+	// service load balancer.
+	ServiceLoadBalancerReadyCondition ConditionType = "ServiceLoadBalancerReady"
+
+	// ServiceLoadBalancerNotReadyReason used when the service load balancer components are being installed or upgraded.
+	ServiceLoadBalancerNotReadyReason = "ServiceLoadBalancerNotReady"
+
+	// PendingUpgradeCondition reports the cluster has changes that roll machines waiting for the maintenance
+	// window to open. It is only present while there are changes waiting.
+	PendingUpgradeCondition ConditionType = "PendingUpgrade"
+
+	// MaintenanceWindowClosedReason used when the changes that roll machines are deferred until the maintenance window opens.
+	MaintenanceWindowClosedReason = "MaintenanceWindowClosed"
+
+	// RolledBackCondition reports the changes to the cluster machines were rolled back after a failed upgrade.
+	// It is only present until the cluster spec changes again. It is false while a failed upgrade can't be rolled back.
+	RolledBackCondition ConditionType = "RolledBack"
+
+	// UpgradeTimeoutReason used when an upgrade is rolled back because it didn't complete before the rollback policy timeout.
+	UpgradeTimeoutReason = "UpgradeTimeout"
+
+	// UnhealthyMachinesReason used when an upgrade is rolled back because too many new machines failed their health checks.
+	UnhealthyMachinesReason = "UnhealthyMachines"
+
+	// RollbackNotSupportedReason used when a failed upgrade is not rolled back because its changes can't be reverted.
+	RollbackNotSupportedReason = "RollbackNotSupported"
 )
@@ -22,6 +22,10 @@ type ControlPlaneUpgradeSpec struct {
 	// EtcdVersion refers to the version of ETCD to upgrade to.
 	EtcdVersion string `json:"etcdVersion"`
 
+	// OSImageURL refers to the OS image to upgrade the control planes to.
+	// +optional
+	OSImageURL string `json:"osImageURL,omitempty"`
+
 	// ControlPlaneSpecData contains base64 encoded KCP spec that's used to update
 	// the statuses of CAPI objects once the control plane upgrade is done.
 	// This field is needed so that we have a static copy of the control plane spec
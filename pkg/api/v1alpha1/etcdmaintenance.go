@@ -0,0 +1,122 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// DefaultEtcdMaintenanceCheckInterval is the default interval between etcd member checks.
+	DefaultEtcdMaintenanceCheckInterval = time.Hour
+	// DefaultEtcdDefragThresholdPercent is the default percentage of unused database size that triggers a defragmentation.
+	DefaultEtcdDefragThresholdPercent = 50
+
+	// minEtcdMaintenanceCheckInterval avoids opening SSH sessions to the etcd machines too often.
+	minEtcdMaintenanceCheckInterval = 5 * time.Minute
+	etcdMaintenanceWindowLayout     = "15:04"
+)
+
+// Interval returns the interval between etcd member checks, the default one when not set.
+func (n *EtcdMaintenanceConfiguration) Interval() time.Duration {
+	if n.CheckInterval == nil {
+		return DefaultEtcdMaintenanceCheckInterval
+	}
+	return n.CheckInterval.Duration
+}
+
+// Threshold returns the percentage of unused database size that triggers a defragmentation, the default one when not set.
+func (n *EtcdMaintenanceConfiguration) Threshold() int {
+	if n.DefragThresholdPercent == 0 {
+		return DefaultEtcdDefragThresholdPercent
+	}
+	return n.DefragThresholdPercent
+}
+
+// Contains returns true when t is inside the window.
+func (w *EtcdMaintenanceWindow) Contains(t time.Time) bool {
+	start, ok := w.lastStart(t)
+	return ok && t.Sub(start) < w.Duration.Duration
+}
+
+// NextStart returns the first time the window starts at after t.
+func (w *EtcdMaintenanceWindow) NextStart(t time.Time) time.Time {
+	start, ok := w.lastStart(t)
+	if !ok {
+		return t
+	}
+	return start.Add(24 * time.Hour)
+}
+
+// lastStart returns the last time the window started at before or at t.
+func (w *EtcdMaintenanceWindow) lastStart(t time.Time) (time.Time, bool) {
+	clock, err := time.Parse(etcdMaintenanceWindowLayout, w.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), t.Day(), clock.Hour(), clock.Minute(), 0, 0, time.UTC)
+	if start.After(t) {
+		start = start.Add(-24 * time.Hour)
+	}
+
+	return start, true
+}
+
+func setEtcdMaintenanceDefaults(cluster *Cluster) error {
+	m := cluster.Spec.EtcdMaintenance
+	if m == nil {
+		return nil
+	}
+
+	if m.CheckInterval == nil {
+		m.CheckInterval = &metav1.Duration{Duration: DefaultEtcdMaintenanceCheckInterval}
+	}
+
+	if m.DefragThresholdPercent == 0 {
+		m.DefragThresholdPercent = DefaultEtcdDefragThresholdPercent
+	}
+
+	return nil
+}
+
+func validateEtcdMaintenance(cluster *Cluster) error {
+	m := cluster.Spec.EtcdMaintenance
+	if m == nil {
+		return nil
+	}
+
+	if cluster.Spec.DatacenterRef.Kind == DockerDatacenterKind {
+		return fmt.Errorf("etcdMaintenance is not supported for provider %s", DockerDatacenterKind)
+	}
+
+	if m.SSHKeySecretName == "" {
+		return errors.New("etcdMaintenance.sshKeySecretName is required")
+	}
+	if errs := validation.IsDNS1123Subdomain(m.SSHKeySecretName); len(errs) > 0 {
+		return fmt.Errorf("etcdMaintenance.sshKeySecretName %s is invalid: %v", m.SSHKeySecretName, errs)
+	}
+
+	if m.CheckInterval != nil && m.CheckInterval.Duration < minEtcdMaintenanceCheckInterval {
+		return fmt.Errorf("etcdMaintenance.checkInterval must be at least %s", minEtcdMaintenanceCheckInterval)
+	}
+
+	if m.DefragThresholdPercent < 0 || m.DefragThresholdPercent > 100 {
+		return fmt.Errorf("etcdMaintenance.defragThresholdPercent %d must be between 1 and 100", m.DefragThresholdPercent)
+	}
+
+	if w := m.Window; w != nil {
+		if _, err := time.Parse(etcdMaintenanceWindowLayout, w.Start); err != nil {
+			return fmt.Errorf("etcdMaintenance.window.start %s is invalid, must be in the format HH:MM", w.Start)
+		}
+		if w.Duration.Duration <= 0 || w.Duration.Duration > 24*time.Hour {
+			return fmt.Errorf("etcdMaintenance.window.duration %s must be greater than 0 and at most 24h", w.Duration.Duration)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateEtcdMaintenance(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		m       *EtcdMaintenanceConfiguration
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid",
+			m: &EtcdMaintenanceConfiguration{
+				SSHKeySecretName:       "etcd-ssh",
+				CheckInterval:          &metav1.Duration{Duration: 30 * time.Minute},
+				DefragThresholdPercent: 40,
+				Window:                 &EtcdMaintenanceWindow{Start: "22:30", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			},
+		},
+		{
+			name:    "docker",
+			kind:    DockerDatacenterKind,
+			m:       &EtcdMaintenanceConfiguration{SSHKeySecretName: "etcd-ssh"},
+			wantErr: "etcdMaintenance is not supported for provider DockerDatacenterConfig",
+		},
+		{
+			name:    "no secret",
+			m:       &EtcdMaintenanceConfiguration{},
+			wantErr: "etcdMaintenance.sshKeySecretName is required",
+		},
+		{
+			name:    "invalid secret name",
+			m:       &EtcdMaintenanceConfiguration{SSHKeySecretName: "Etcd_SSH"},
+			wantErr: "etcdMaintenance.sshKeySecretName Etcd_SSH is invalid",
+		},
+		{
+			name: "interval too short",
+			m: &EtcdMaintenanceConfiguration{
+				SSHKeySecretName: "etcd-ssh",
+				CheckInterval:    &metav1.Duration{Duration: time.Minute},
+			},
+			wantErr: "etcdMaintenance.checkInterval must be at least 5m0s",
+		},
+		{
+			name:    "invalid threshold",
+			m:       &EtcdMaintenanceConfiguration{SSHKeySecretName: "etcd-ssh", DefragThresholdPercent: 120},
+			wantErr: "etcdMaintenance.defragThresholdPercent 120 must be between 1 and 100",
+		},
+		{
+			name: "invalid window start",
+			m: &EtcdMaintenanceConfiguration{
+				SSHKeySecretName: "etcd-ssh",
+				Window:           &EtcdMaintenanceWindow{Start: "10pm", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			wantErr: "etcdMaintenance.window.start 10pm is invalid",
+		},
+		{
+			name: "invalid window duration",
+			m: &EtcdMaintenanceConfiguration{
+				SSHKeySecretName: "etcd-ssh",
+				Window:           &EtcdMaintenanceWindow{Start: "22:00"},
+			},
+			wantErr: "etcdMaintenance.window.duration 0s must be greater than 0 and at most 24h",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					DatacenterRef:   Ref{Kind: VSphereDatacenterKind},
+					EtcdMaintenance: tt.m,
+				},
+			}
+			if tt.kind != "" {
+				cluster.Spec.DatacenterRef.Kind = tt.kind
+			}
+
+			err := validateEtcdMaintenance(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestSetEtcdMaintenanceDefaults(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			EtcdMaintenance: &EtcdMaintenanceConfiguration{SSHKeySecretName: "etcd-ssh"},
+		},
+	}
+
+	g.Expect(setEtcdMaintenanceDefaults(cluster)).To(Succeed())
+	g.Expect(cluster.Spec.EtcdMaintenance.CheckInterval.Duration).To(Equal(DefaultEtcdMaintenanceCheckInterval))
+	g.Expect(cluster.Spec.EtcdMaintenance.DefragThresholdPercent).To(Equal(DefaultEtcdDefragThresholdPercent))
+}
+
+func TestEtcdMaintenanceWindow(t *testing.T) {
+	w := &EtcdMaintenanceWindow{Start: "22:00", Duration: metav1.Duration{Duration: 4 * time.Hour}}
+	tests := []struct {
+		name      string
+		now       time.Time
+		contains  bool
+		nextStart time.Time
+	}{
+		{
+			name:      "before window",
+			now:       time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC),
+			contains:  false,
+			nextStart: time.Date(2024, 5, 10, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "inside window",
+			now:       time.Date(2024, 5, 10, 23, 0, 0, 0, time.UTC),
+			contains:  true,
+			nextStart: time.Date(2024, 5, 11, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "inside window after midnight",
+			now:       time.Date(2024, 5, 11, 1, 30, 0, 0, time.UTC),
+			contains:  true,
+			nextStart: time.Date(2024, 5, 11, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "after window",
+			now:       time.Date(2024, 5, 11, 2, 0, 0, 0, time.UTC),
+			contains:  false,
+			nextStart: time.Date(2024, 5, 11, 22, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(w.Contains(tt.now)).To(Equal(tt.contains))
+			g.Expect(w.NextStart(tt.now)).To(Equal(tt.nextStart))
+		})
+	}
+}
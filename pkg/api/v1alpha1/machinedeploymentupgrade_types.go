@@ -19,6 +19,10 @@ type MachineDeploymentUpgradeSpec struct {
 	// KubernetesVersion refers to the Kubernetes version to upgrade the control planes to.
 	KubernetesVersion string `json:"kubernetesVersion"`
 
+	// OSImageURL refers to the OS image to upgrade the machines to.
+	// +optional
+	OSImageURL string `json:"osImageURL,omitempty"`
+
 	// MachineSpecData is a base64 encoded json string value of the machineDeplopyment.Spec.Template.Spec field that's specification of the desired behavior of the machine.
 	MachineSpecData string `json:"machineSpecData"`
 }
@@ -0,0 +1,73 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+	// The time zone database is embedded since the images running the controller don't include it.
+	_ "time/tzdata"
+
+	"github.com/aws/eks-anywhere/pkg/cron"
+)
+
+// Contains returns true when t is inside the window. A window that can't be parsed is
+// always open, so it never blocks changes; these are rejected by the validations.
+func (w *MaintenanceWindow) Contains(t time.Time) bool {
+	schedule, loc, err := w.parse()
+	if err != nil {
+		return true
+	}
+
+	// The window contains t if it opened after t-duration and before or at t.
+	start := schedule.Next(t.In(loc).Add(-w.Duration.Duration))
+	return !start.IsZero() && !start.After(t)
+}
+
+// NextStart returns the first time the window opens at after t. It returns
+// the zero time if the window can't be parsed or never opens.
+func (w *MaintenanceWindow) NextStart(t time.Time) time.Time {
+	schedule, loc, err := w.parse()
+	if err != nil {
+		return time.Time{}
+	}
+
+	return schedule.Next(t.In(loc))
+}
+
+func (w *MaintenanceWindow) parse() (*cron.Schedule, *time.Location, error) {
+	schedule, err := cron.Parse(w.Schedule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schedule, loc, nil
+}
+
+func validateMaintenanceWindow(cluster *Cluster) error {
+	w := cluster.Spec.MaintenanceWindow
+	if w == nil {
+		return nil
+	}
+
+	if _, err := cron.Parse(w.Schedule); err != nil {
+		return fmt.Errorf("maintenanceWindow.schedule is invalid: %v", err)
+	}
+
+	if w.Duration.Duration < time.Minute {
+		return fmt.Errorf("maintenanceWindow.duration %s must be at least 1m", w.Duration.Duration)
+	}
+
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("maintenanceWindow.timezone %s is invalid: %v", w.Timezone, err)
+	}
+
+	if w.NextStart(time.Now()).IsZero() {
+		return fmt.Errorf("maintenanceWindow.schedule %s never opens the window", w.Schedule)
+	}
+
+	return nil
+}
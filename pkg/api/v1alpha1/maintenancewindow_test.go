@@ -0,0 +1,127 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		w       *MaintenanceWindow
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid",
+			w:    &MaintenanceWindow{Schedule: "0 22 * * 1-5", Duration: metav1.Duration{Duration: 6 * time.Hour}, Timezone: "Europe/Madrid"},
+		},
+		{
+			name: "valid without timezone",
+			w:    &MaintenanceWindow{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+		},
+		{
+			name:    "invalid schedule",
+			w:       &MaintenanceWindow{Schedule: "0 25 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+			wantErr: "maintenanceWindow.schedule is invalid",
+		},
+		{
+			name:    "schedule never matches",
+			w:       &MaintenanceWindow{Schedule: "0 0 31 2 *", Duration: metav1.Duration{Duration: time.Hour}},
+			wantErr: "maintenanceWindow.schedule 0 0 31 2 * never opens the window",
+		},
+		{
+			name:    "duration too short",
+			w:       &MaintenanceWindow{Schedule: "0 2 * * *"},
+			wantErr: "maintenanceWindow.duration 0s must be at least 1m",
+		},
+		{
+			name:    "invalid timezone",
+			w:       &MaintenanceWindow{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}, Timezone: "Mars/Olympus"},
+			wantErr: "maintenanceWindow.timezone Mars/Olympus is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{
+				Spec: ClusterSpec{
+					MaintenanceWindow: tt.w,
+				},
+			}
+
+			err := validateMaintenanceWindow(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	w := &MaintenanceWindow{
+		Schedule: "0 22 * * 1-5",
+		Duration: metav1.Duration{Duration: 6 * time.Hour},
+		Timezone: "America/New_York",
+	}
+	tests := []struct {
+		name      string
+		now       time.Time
+		contains  bool
+		nextStart time.Time
+	}{
+		{
+			name:      "before window",
+			now:       time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			contains:  false,
+			nextStart: time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "at the start",
+			now:       time.Date(2026, 10, 20, 2, 0, 0, 0, time.UTC),
+			contains:  true,
+			nextStart: time.Date(2026, 10, 21, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "inside window after midnight",
+			now:       time.Date(2026, 10, 20, 7, 59, 0, 0, time.UTC),
+			contains:  true,
+			nextStart: time.Date(2026, 10, 21, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "at the end",
+			now:       time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC),
+			contains:  false,
+			nextStart: time.Date(2026, 10, 21, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "weekend",
+			now:       time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC),
+			contains:  false,
+			nextStart: time.Date(2026, 10, 27, 2, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(w.Contains(tt.now)).To(Equal(tt.contains))
+			g.Expect(w.NextStart(tt.now)).To(BeTemporally("==", tt.nextStart))
+		})
+	}
+}
+
+func TestMaintenanceWindowInvalid(t *testing.T) {
+	g := NewWithT(t)
+	w := &MaintenanceWindow{Schedule: "every night", Duration: metav1.Duration{Duration: time.Hour}}
+	now := time.Now()
+
+	g.Expect(w.Contains(now)).To(BeTrue())
+	g.Expect(w.NextStart(now).IsZero()).To(BeTrue())
+}
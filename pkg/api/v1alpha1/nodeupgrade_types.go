@@ -28,6 +28,9 @@ const (
 	// KubeletUpgraded reports whether kubelet has been upgraded.
 	KubeletUpgraded ConditionType = "KubeletUpgraded"
 
+	// OSImageUpgraded reports whether the OS image has been written to the node and the node has been rebooted into it.
+	OSImageUpgraded ConditionType = "OSImageUpgraded"
+
 	// PostUpgradeCleanupCompleted reports whether the post upgrade operations have been completed.
 	PostUpgradeCleanupCompleted ConditionType = "PostUpgradeCleanupCompleted"
 )
@@ -49,6 +52,11 @@ type NodeUpgradeSpec struct {
 	// This flag is only valid for control plane nodes and ignored for worker nodes.
 	// +optional
 	FirstNodeToBeUpgraded bool `json:"firstNodeToBeUpgraded,omitempty"`
+
+	// OSImageURL refers to the OS image to upgrade the node to.
+	// When empty, the node OS is left untouched and only the Kubernetes components are upgraded.
+	// +optional
+	OSImageURL string `json:"osImageURL,omitempty"`
 }
 
 // NodeUpgradeStatus defines the observed state of NodeUpgrade.
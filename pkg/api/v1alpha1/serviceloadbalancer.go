@@ -0,0 +1,223 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultServiceLoadBalancerBGPPort is the default port of the BGP sessions with the peers.
+const DefaultServiceLoadBalancerBGPPort = 179
+
+// LoadBalancerMode returns the mode of the service load balancer, L2 when not set.
+func (n *ServiceLoadBalancerConfiguration) LoadBalancerMode() ServiceLoadBalancerMode {
+	if n.Mode == "" {
+		return ServiceLoadBalancerModeL2
+	}
+	return n.Mode
+}
+
+func setServiceLoadBalancerDefaults(cluster *Cluster) error {
+	lb := cluster.Spec.ServiceLoadBalancer
+	if lb == nil {
+		return nil
+	}
+
+	if lb.Mode == "" {
+		lb.Mode = ServiceLoadBalancerModeL2
+	}
+
+	if lb.BGP != nil {
+		for i := range lb.BGP.Peers {
+			if lb.BGP.Peers[i].Port == 0 {
+				lb.BGP.Peers[i].Port = DefaultServiceLoadBalancerBGPPort
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateServiceLoadBalancer(cluster *Cluster) error {
+	lb := cluster.Spec.ServiceLoadBalancer
+	if lb == nil {
+		return nil
+	}
+
+	if err := validateServiceLoadBalancerAddressPools(cluster); err != nil {
+		return err
+	}
+
+	switch lb.LoadBalancerMode() {
+	case ServiceLoadBalancerModeL2:
+		if lb.BGP != nil {
+			return errors.New("serviceLoadBalancer.bgp can only be set in BGP mode")
+		}
+	case ServiceLoadBalancerModeBGP:
+		if len(lb.Interfaces) > 0 {
+			return errors.New("serviceLoadBalancer.interfaces can only be set in L2 mode")
+		}
+		if err := validateServiceLoadBalancerBGP(lb.BGP); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("serviceLoadBalancer.mode %s is not supported, must be one of [%s, %s]", lb.Mode, ServiceLoadBalancerModeL2, ServiceLoadBalancerModeBGP)
+	}
+
+	for i, iface := range lb.Interfaces {
+		if strings.TrimSpace(iface) == "" {
+			return fmt.Errorf("serviceLoadBalancer.interfaces[%d] cannot be empty", i)
+		}
+	}
+
+	return nil
+}
+
+func validateServiceLoadBalancerAddressPools(cluster *Cluster) error {
+	pools := cluster.Spec.ServiceLoadBalancer.AddressPools
+	if len(pools) == 0 {
+		return errors.New("serviceLoadBalancer.addressPools cannot be empty")
+	}
+
+	names := map[string]struct{}{}
+	var ranges []addressRange
+	for i, pool := range pools {
+		if errs := validation.IsDNS1123Label(pool.Name); len(errs) > 0 {
+			return fmt.Errorf("serviceLoadBalancer.addressPools[%d].name %q is invalid: %s", i, pool.Name, strings.Join(errs, ", "))
+		}
+		if _, ok := names[pool.Name]; ok {
+			return fmt.Errorf("serviceLoadBalancer.addressPools[%d].name %s is duplicated", i, pool.Name)
+		}
+		names[pool.Name] = struct{}{}
+
+		if len(pool.Addresses) == 0 {
+			return fmt.Errorf("serviceLoadBalancer.addressPools[%d].addresses cannot be empty", i)
+		}
+		for _, a := range pool.Addresses {
+			r, err := parseAddressRange(a)
+			if err != nil {
+				return fmt.Errorf("serviceLoadBalancer.addressPools[%d] address %s is invalid: %v", i, a, err)
+			}
+			for _, o := range ranges {
+				if r.overlaps(o) {
+					return fmt.Errorf("serviceLoadBalancer address %s overlaps with address %s", r, o)
+				}
+			}
+			ranges = append(ranges, r)
+		}
+	}
+
+	if host := cluster.Spec.ControlPlaneConfiguration.Endpoint; host != nil {
+		if ip, err := netip.ParseAddr(host.Host); err == nil {
+			for _, r := range ranges {
+				if r.contains(ip) {
+					return fmt.Errorf("serviceLoadBalancer address %s contains the control plane endpoint %s", r, host.Host)
+				}
+			}
+		}
+	}
+
+	network := cluster.Spec.ClusterNetwork
+	for _, cidr := range append(append([]string{}, network.Pods.CidrBlocks...), network.Services.CidrBlocks...) {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			// Invalid CIDR blocks are reported by the network validations.
+			continue
+		}
+		c := prefixRange(prefix)
+		for _, r := range ranges {
+			if r.overlaps(c) {
+				return fmt.Errorf("serviceLoadBalancer address %s overlaps with cluster network CIDR block %s", r, cidr)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateServiceLoadBalancerBGP(bgp *ServiceLoadBalancerBGPConfiguration) error {
+	if bgp == nil {
+		return errors.New("serviceLoadBalancer.bgp is required in BGP mode")
+	}
+	if bgp.LocalASN == 0 {
+		return errors.New("serviceLoadBalancer.bgp.localASN cannot be 0")
+	}
+	if len(bgp.Peers) == 0 {
+		return errors.New("serviceLoadBalancer.bgp.peers cannot be empty")
+	}
+	for i, p := range bgp.Peers {
+		if _, err := netip.ParseAddr(p.Address); err != nil {
+			return fmt.Errorf("serviceLoadBalancer.bgp.peers[%d].address %s is not a valid IP", i, p.Address)
+		}
+		if p.ASN == 0 {
+			return fmt.Errorf("serviceLoadBalancer.bgp.peers[%d].asn cannot be 0", i)
+		}
+		if p.Port < 0 || p.Port > 65535 {
+			return fmt.Errorf("serviceLoadBalancer.bgp.peers[%d].port %d is invalid", i, p.Port)
+		}
+	}
+
+	return nil
+}
+
+// addressRange is an inclusive range of IPs of the same family.
+type addressRange struct {
+	start, end netip.Addr
+}
+
+// parseAddressRange parses a CIDR block or an IP range in the form start-end.
+func parseAddressRange(s string) (addressRange, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return addressRange{}, err
+		}
+		return prefixRange(prefix), nil
+	}
+
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return addressRange{}, errors.New("must be a CIDR block or an IP range in the form start-end")
+	}
+	r := addressRange{}
+	var err error
+	if r.start, err = netip.ParseAddr(strings.TrimSpace(start)); err != nil {
+		return addressRange{}, err
+	}
+	if r.end, err = netip.ParseAddr(strings.TrimSpace(end)); err != nil {
+		return addressRange{}, err
+	}
+	if r.start.Is4() != r.end.Is4() {
+		return addressRange{}, errors.New("range start and end must be of the same IP family")
+	}
+	if r.end.Less(r.start) {
+		return addressRange{}, errors.New("range start must not be greater than range end")
+	}
+
+	return r, nil
+}
+
+func prefixRange(prefix netip.Prefix) addressRange {
+	prefix = prefix.Masked()
+	last := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(last)*8; i++ {
+		last[i/8] |= 1 << (7 - i%8)
+	}
+	end, _ := netip.AddrFromSlice(last)
+	return addressRange{start: prefix.Addr(), end: end}
+}
+
+func (r addressRange) contains(ip netip.Addr) bool {
+	return r.start.Is4() == ip.Is4() && !ip.Less(r.start) && !r.end.Less(ip)
+}
+
+func (r addressRange) overlaps(o addressRange) bool {
+	return r.start.Is4() == o.start.Is4() && !o.end.Less(r.start) && !r.end.Less(o.start)
+}
+
+func (r addressRange) String() string {
+	return fmt.Sprintf("%s-%s", r.start, r.end)
+}
@@ -0,0 +1,228 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func serviceLoadBalancerCluster(lb *ServiceLoadBalancerConfiguration) *Cluster {
+	return &Cluster{
+		Spec: ClusterSpec{
+			DatacenterRef: Ref{Kind: VSphereDatacenterKind},
+			ControlPlaneConfiguration: ControlPlaneConfiguration{
+				Endpoint: &Endpoint{Host: "10.0.0.10"},
+			},
+			ClusterNetwork: ClusterNetwork{
+				Pods:     Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+				Services: Services{CidrBlocks: []string{"10.96.0.0/12"}},
+			},
+			ServiceLoadBalancer: lb,
+		},
+	}
+}
+
+func TestValidateServiceLoadBalancer(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		lb      *ServiceLoadBalancerConfiguration
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "l2 with cidr and range",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{
+					{Name: "default", Addresses: []string{"10.0.1.0/28", "10.0.2.10-10.0.2.20"}},
+					{Name: "ipv6", Addresses: []string{"fd00:10::/120"}},
+				},
+				Interfaces: []string{"eth0"},
+			},
+		},
+		{
+			name: "bgp",
+			kind: TinkerbellDatacenterKind,
+			lb: &ServiceLoadBalancerConfiguration{
+				Mode:         ServiceLoadBalancerModeBGP,
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+				BGP: &ServiceLoadBalancerBGPConfiguration{
+					LocalASN: 64500,
+					Peers:    []ServiceLoadBalancerBGPPeer{{Address: "10.0.0.1", ASN: 64501}},
+				},
+			},
+		},
+		{
+			name: "docker",
+			kind: DockerDatacenterKind,
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+			},
+		},
+		{
+			name:    "no pools",
+			lb:      &ServiceLoadBalancerConfiguration{},
+			wantErr: "serviceLoadBalancer.addressPools cannot be empty",
+		},
+		{
+			name: "invalid pool name",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "Default", Addresses: []string{"10.0.1.0/28"}}},
+			},
+			wantErr: "serviceLoadBalancer.addressPools[0].name \"Default\" is invalid",
+		},
+		{
+			name: "duplicated pool name",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{
+					{Name: "default", Addresses: []string{"10.0.1.0/28"}},
+					{Name: "default", Addresses: []string{"10.0.2.0/28"}},
+				},
+			},
+			wantErr: "serviceLoadBalancer.addressPools[1].name default is duplicated",
+		},
+		{
+			name: "pool without addresses",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default"}},
+			},
+			wantErr: "serviceLoadBalancer.addressPools[0].addresses cannot be empty",
+		},
+		{
+			name: "invalid address",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.1"}}},
+			},
+			wantErr: "must be a CIDR block or an IP range in the form start-end",
+		},
+		{
+			name: "inverted range",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.20-10.0.1.10"}}},
+			},
+			wantErr: "range start must not be greater than range end",
+		},
+		{
+			name: "mixed family range",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.1-fd00::1"}}},
+			},
+			wantErr: "range start and end must be of the same IP family",
+		},
+		{
+			name: "overlapping pools",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{
+					{Name: "a", Addresses: []string{"10.0.1.0/24"}},
+					{Name: "b", Addresses: []string{"10.0.1.100-10.0.1.110"}},
+				},
+			},
+			wantErr: "serviceLoadBalancer address 10.0.1.100-10.0.1.110 overlaps with address 10.0.1.0-10.0.1.255",
+		},
+		{
+			name: "pool contains control plane endpoint",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.0.0/28"}}},
+			},
+			wantErr: "contains the control plane endpoint 10.0.0.10",
+		},
+		{
+			name: "pool overlaps services",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.100.0.0/28"}}},
+			},
+			wantErr: "overlaps with cluster network CIDR block 10.96.0.0/12",
+		},
+		{
+			name: "bgp config in l2 mode",
+			lb: &ServiceLoadBalancerConfiguration{
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+				BGP:          &ServiceLoadBalancerBGPConfiguration{},
+			},
+			wantErr: "serviceLoadBalancer.bgp can only be set in BGP mode",
+		},
+		{
+			name: "interfaces in bgp mode",
+			lb: &ServiceLoadBalancerConfiguration{
+				Mode:         ServiceLoadBalancerModeBGP,
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+				Interfaces:   []string{"eth0"},
+			},
+			wantErr: "serviceLoadBalancer.interfaces can only be set in L2 mode",
+		},
+		{
+			name: "bgp mode without bgp config",
+			lb: &ServiceLoadBalancerConfiguration{
+				Mode:         ServiceLoadBalancerModeBGP,
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+			},
+			wantErr: "serviceLoadBalancer.bgp is required in BGP mode",
+		},
+		{
+			name: "bgp peer with invalid address",
+			lb: &ServiceLoadBalancerConfiguration{
+				Mode:         ServiceLoadBalancerModeBGP,
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+				BGP: &ServiceLoadBalancerBGPConfiguration{
+					LocalASN: 64500,
+					Peers:    []ServiceLoadBalancerBGPPeer{{Address: "router", ASN: 64501}},
+				},
+			},
+			wantErr: "serviceLoadBalancer.bgp.peers[0].address router is not a valid IP",
+		},
+		{
+			name: "unsupported mode",
+			lb: &ServiceLoadBalancerConfiguration{
+				Mode:         "ECMP",
+				AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+			},
+			wantErr: "serviceLoadBalancer.mode ECMP is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := serviceLoadBalancerCluster(tt.lb)
+			if tt.kind != "" {
+				cluster.Spec.DatacenterRef.Kind = tt.kind
+			}
+
+			err := validateServiceLoadBalancer(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).To(BeNil())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestSetServiceLoadBalancerDefaults(t *testing.T) {
+	g := NewWithT(t)
+	cluster := serviceLoadBalancerCluster(&ServiceLoadBalancerConfiguration{
+		BGP: &ServiceLoadBalancerBGPConfiguration{
+			Peers: []ServiceLoadBalancerBGPPeer{{Address: "10.0.0.1"}, {Address: "10.0.0.2", Port: 1179}},
+		},
+	})
+
+	g.Expect(setServiceLoadBalancerDefaults(cluster)).To(Succeed())
+	g.Expect(cluster.Spec.ServiceLoadBalancer.Mode).To(Equal(ServiceLoadBalancerModeL2))
+	g.Expect(cluster.Spec.ServiceLoadBalancer.BGP.Peers[0].Port).To(Equal(DefaultServiceLoadBalancerBGPPort))
+	g.Expect(cluster.Spec.ServiceLoadBalancer.BGP.Peers[1].Port).To(Equal(1179))
+}
+
+func TestServiceLoadBalancerConfigurationEqual(t *testing.T) {
+	g := NewWithT(t)
+	a := &ServiceLoadBalancerConfiguration{
+		AddressPools: []ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+	}
+	b := a.DeepCopy()
+
+	g.Expect(a.Equal(b)).To(BeTrue())
+	g.Expect(a.Equal(nil)).To(BeFalse())
+
+	b.AddressPools[0].Addresses = append(b.AddressPools[0].Addresses, "10.0.2.0/28")
+	g.Expect(a.Equal(b)).To(BeFalse())
+}
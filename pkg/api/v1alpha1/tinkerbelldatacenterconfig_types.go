@@ -34,6 +34,11 @@ type TinkerbellDatacenterConfigSpec struct {
 	// It can be used to override the default Hook OS ISO image to pull from a local server.
 	//+optional
 	HookIsoURL string `json:"hookIsoURL,omitempty"`
+	// RequireValidatedHardware restricts provisioning to hardware that passed burn-in validation.
+	// Hardware is validated when it is labeled with hardware.anywhere.eks.amazonaws.com/validation=validated.
+	// Hardware labeled as quarantined is never used, regardless of this setting.
+	//+optional
+	RequireValidatedHardware bool `json:"requireValidatedHardware,omitempty"`
 }
 
 // TinkerbellDatacenterConfigStatus defines the observed state of TinkerbellDatacenterConfig
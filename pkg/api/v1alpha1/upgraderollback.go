@@ -0,0 +1,36 @@
+package v1alpha1
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+func validateUpgradeRollbackPolicy(cluster *Cluster) error {
+	p := cluster.Spec.UpgradeRollbackPolicy
+	if p == nil {
+		return nil
+	}
+
+	if p.Timeout == nil && p.MaxUnhealthyMachines == nil {
+		return errors.New("upgradeRollbackPolicy must set at least one of timeout and maxUnhealthyMachines")
+	}
+
+	if p.Timeout != nil && p.Timeout.Duration < time.Minute {
+		return fmt.Errorf("upgradeRollbackPolicy.timeout %s must be at least 1m", p.Timeout.Duration)
+	}
+
+	if p.MaxUnhealthyMachines != nil && *p.MaxUnhealthyMachines < 1 {
+		return fmt.Errorf("upgradeRollbackPolicy.maxUnhealthyMachines %d must be at least 1", *p.MaxUnhealthyMachines)
+	}
+
+	return nil
+}
+
+// IsUpgradeRolledBack returns true when the changes to the cluster at the given children generation
+// were rolled back, so the changes that roll machines must not be applied again.
+func (c *Cluster) IsUpgradeRolledBack(childrenGeneration int64) bool {
+	s := c.Status.UpgradeRollback
+	return s != nil && s.RolledBackGeneration != 0 &&
+		s.RolledBackGeneration == c.Generation && s.RolledBackChildrenGeneration == childrenGeneration
+}
@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestValidateUpgradeRollbackPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       *UpgradeRollbackPolicy
+		wantErr string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "valid",
+			p: &UpgradeRollbackPolicy{
+				Timeout:              &metav1.Duration{Duration: time.Hour},
+				MaxUnhealthyMachines: ptr.To(2),
+			},
+		},
+		{
+			name:    "empty",
+			p:       &UpgradeRollbackPolicy{},
+			wantErr: "upgradeRollbackPolicy must set at least one of timeout and maxUnhealthyMachines",
+		},
+		{
+			name:    "timeout too short",
+			p:       &UpgradeRollbackPolicy{Timeout: &metav1.Duration{Duration: time.Second}},
+			wantErr: "upgradeRollbackPolicy.timeout 1s must be at least 1m",
+		},
+		{
+			name:    "no unhealthy machines",
+			p:       &UpgradeRollbackPolicy{MaxUnhealthyMachines: ptr.To(0)},
+			wantErr: "upgradeRollbackPolicy.maxUnhealthyMachines 0 must be at least 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := &Cluster{Spec: ClusterSpec{UpgradeRollbackPolicy: tt.p}}
+			err := validateUpgradeRollbackPolicy(cluster)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestClusterIsUpgradeRolledBack(t *testing.T) {
+	g := NewWithT(t)
+	cluster := &Cluster{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+	g.Expect(cluster.IsUpgradeRolledBack(10)).To(BeFalse())
+
+	cluster.Status.UpgradeRollback = &UpgradeRollbackStatus{RolledBackGeneration: 3, RolledBackChildrenGeneration: 10}
+	g.Expect(cluster.IsUpgradeRolledBack(10)).To(BeTrue())
+	g.Expect(cluster.IsUpgradeRolledBack(11)).To(BeFalse())
+
+	cluster.Generation = 4
+	g.Expect(cluster.IsUpgradeRolledBack(10)).To(BeFalse())
+}
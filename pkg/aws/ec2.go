@@ -43,6 +43,31 @@ func (c *Client) EC2ImageExists(ctx context.Context, imageID string) (bool, erro
 	return false, fmt.Errorf("aws describe image [imageID=%s]: %v", imageID, err)
 }
 
+// EC2ImageRootVolumeSize calls aws sdk ec2.DescribeImages to get the size, in GiB, of the root
+// device volume of an image. It returns 0 when the image doesn't specify the root volume size.
+func (c *Client) EC2ImageRootVolumeSize(ctx context.Context, imageID string) (int64, error) {
+	out, err := c.ec2.DescribeImages(ctx, &ec2.DescribeImagesInput{
+		ImageIds: []string{imageID},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("aws describe image [imageID=%s]: %v", imageID, err)
+	}
+	if len(out.Images) == 0 {
+		return 0, fmt.Errorf("aws image [%s] does not exist", imageID)
+	}
+
+	image := out.Images[0]
+	for _, m := range image.BlockDeviceMappings {
+		if m.DeviceName == nil || image.RootDeviceName == nil || *m.DeviceName != *image.RootDeviceName {
+			continue
+		}
+		if m.Ebs != nil && m.Ebs.VolumeSize != nil {
+			return int64(*m.Ebs.VolumeSize), nil
+		}
+	}
+	return 0, nil
+}
+
 // EC2KeyNameExists calls aws sdk ec2.DescribeKeyPairs with filter keyName to fetch a
 // specified key pair available in aws.
 // Returns (false, nil) if the key pair does not exist, (true, nil) if key pair exists,
@@ -78,6 +103,7 @@ func (c *Client) EC2ImportKeyPair(ctx context.Context, keyName string, keyMateri
 type EC2InstanceType struct {
 	Name        string
 	DefaultVCPU *int32
+	MemoryMiB   *int64
 }
 
 // EC2InstanceTypes calls aws sdk ec2.DescribeInstanceTypes to get a list of supported instance type for a device.
@@ -89,10 +115,14 @@ func (c *Client) EC2InstanceTypes(ctx context.Context) ([]EC2InstanceType, error
 
 	instanceTypes := make([]EC2InstanceType, 0, len(out.InstanceTypes))
 	for _, it := range out.InstanceTypes {
-		instanceTypes = append(instanceTypes, EC2InstanceType{
+		instanceType := EC2InstanceType{
 			Name:        string(it.InstanceType),
 			DefaultVCPU: it.VCpuInfo.DefaultVCpus,
-		})
+		}
+		if it.MemoryInfo != nil {
+			instanceType.MemoryMiB = it.MemoryInfo.SizeInMiB
+		}
+		instanceTypes = append(instanceTypes, instanceType)
 	}
 	return instanceTypes, nil
 }
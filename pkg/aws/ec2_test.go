@@ -58,6 +58,51 @@ func TestEC2ImageExistsError(t *testing.T) {
 	g.Expect(got).To(Equal(false))
 }
 
+func TestEC2ImageRootVolumeSize(t *testing.T) {
+	g := newEC2Test(t)
+	image := "image-1"
+	params := &ec2.DescribeImagesInput{
+		ImageIds: []string{image},
+	}
+	out := &ec2.DescribeImagesOutput{
+		Images: []types.Image{
+			{
+				RootDeviceName: ptr.String("/dev/sda1"),
+				BlockDeviceMappings: []types.BlockDeviceMapping{
+					{DeviceName: ptr.String("/dev/sdb"), Ebs: &types.EbsBlockDevice{VolumeSize: ptr.Int32(100)}},
+					{DeviceName: ptr.String("/dev/sda1"), Ebs: &types.EbsBlockDevice{VolumeSize: ptr.Int32(25)}},
+				},
+			},
+		},
+	}
+	g.ec2.EXPECT().DescribeImages(g.ctx, params).Return(out, nil)
+	got, err := g.client.EC2ImageRootVolumeSize(g.ctx, image)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal(int64(25)))
+}
+
+func TestEC2ImageRootVolumeSizeNotFound(t *testing.T) {
+	g := newEC2Test(t)
+	image := "image-1"
+	params := &ec2.DescribeImagesInput{
+		ImageIds: []string{image},
+	}
+	g.ec2.EXPECT().DescribeImages(g.ctx, params).Return(&ec2.DescribeImagesOutput{}, nil)
+	_, err := g.client.EC2ImageRootVolumeSize(g.ctx, image)
+	g.Expect(err).To(MatchError(ContainSubstring("aws image [image-1] does not exist")))
+}
+
+func TestEC2ImageRootVolumeSizeError(t *testing.T) {
+	g := newEC2Test(t)
+	image := "image-1"
+	params := &ec2.DescribeImagesInput{
+		ImageIds: []string{image},
+	}
+	g.ec2.EXPECT().DescribeImages(g.ctx, params).Return(nil, errors.New("error"))
+	_, err := g.client.EC2ImageRootVolumeSize(g.ctx, image)
+	g.Expect(err).To(MatchError(ContainSubstring("aws describe image [imageID=image-1]")))
+}
+
 func TestEC2KeyNameExists(t *testing.T) {
 	g := newEC2Test(t)
 	key := "default"
@@ -127,6 +172,9 @@ func TestEC2InstanceTypes(t *testing.T) {
 				VCpuInfo: &types.VCpuInfo{
 					DefaultVCpus: ptr.Int32(8),
 				},
+				MemoryInfo: &types.MemoryInfo{
+					SizeInMiB: ptr.Int64(16384),
+				},
 			},
 			{
 				InstanceType: types.InstanceTypeA1Large,
@@ -140,6 +188,7 @@ func TestEC2InstanceTypes(t *testing.T) {
 		{
 			Name:        "c1.medium",
 			DefaultVCPU: ptr.Int32(8),
+			MemoryMiB:   ptr.Int64(16384),
 		},
 		{
 			Name:        "a1.large",
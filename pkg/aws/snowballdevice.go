@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 
@@ -34,3 +35,76 @@ func (c *Client) SnowballDeviceSoftwareVersion(ctx context.Context) (string, err
 	}
 	return *out.InstalledVersion, nil
 }
+
+// SnowballDeviceCapacity has the compute and storage capacity available for new instances on a
+// snowball device.
+type SnowballDeviceCapacity struct {
+	VCPU         int64
+	MemoryBytes  int64
+	StorageBytes int64
+}
+
+// SnowballDeviceCapacity calls snowballdevice.DescribeDevice to get the vCPU, memory and storage
+// capacity available on the device. Storage is the sum of the available HDD and SSD storage.
+// Memory and storage are converted to bytes from the unit reported by the device.
+func (c *Client) SnowballDeviceCapacity(ctx context.Context) (*SnowballDeviceCapacity, error) {
+	out, err := c.snowballDevice.DescribeDevice(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("describing snowball device: %v", err)
+	}
+
+	capacity := &SnowballDeviceCapacity{}
+	for _, dc := range out.DeviceCapacities {
+		if dc.Name == nil || dc.Available == nil {
+			continue
+		}
+		name := strings.ToLower(*dc.Name)
+		switch {
+		case name == "vcpu":
+			capacity.VCPU = *dc.Available
+		case name == "memory":
+			bytes, err := capacityBytes(*dc.Available, dc.Unit)
+			if err != nil {
+				return nil, fmt.Errorf("converting %s capacity: %v", *dc.Name, err)
+			}
+			capacity.MemoryBytes = bytes
+		case strings.HasSuffix(name, "storage"):
+			bytes, err := capacityBytes(*dc.Available, dc.Unit)
+			if err != nil {
+				return nil, fmt.Errorf("converting %s capacity: %v", *dc.Name, err)
+			}
+			capacity.StorageBytes += bytes
+		}
+	}
+
+	return capacity, nil
+}
+
+var capacityUnitBytes = map[string]int64{
+	"":      1,
+	"b":     1,
+	"byte":  1,
+	"bytes": 1,
+	"kb":    1e3,
+	"mb":    1e6,
+	"gb":    1e9,
+	"tb":    1e12,
+	"kib":   1 << 10,
+	"mib":   1 << 20,
+	"gib":   1 << 30,
+	"tib":   1 << 40,
+}
+
+// capacityBytes converts a device capacity expressed in unit to bytes. A capacity without unit is
+// in bytes.
+func capacityBytes(value int64, unit *string) (int64, error) {
+	u := ""
+	if unit != nil {
+		u = strings.ToLower(strings.TrimSpace(*unit))
+	}
+	multiplier, ok := capacityUnitBytes[u]
+	if !ok {
+		return 0, fmt.Errorf("unsupported capacity unit [%s]", *unit)
+	}
+	return value * multiplier, nil
+}
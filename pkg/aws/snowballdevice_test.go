@@ -86,3 +86,69 @@ func TestSnowballDeviceSoftwareVersionDescribeDeviceSoftwareError(t *testing.T)
 	g.Expect(err).NotTo(Succeed())
 	g.Expect(got).To(Equal(""))
 }
+
+func TestSnowballDeviceCapacitySuccess(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	capacity := func(name string, available int64) types.Capacity {
+		return types.Capacity{Name: &name, Available: &available}
+	}
+	out := &snowballdevice.DescribeDeviceOutput{
+		DeviceCapacities: []types.Capacity{
+			capacity("HDD Storage", 100),
+			capacity("SSD Storage", 20),
+			capacity("vCPU", 52),
+			capacity("Memory", 1024),
+			capacity("GPU", 1),
+			{Name: nil},
+		},
+	}
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(out, nil)
+	got, err := g.client.SnowballDeviceCapacity(g.ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal(&aws.SnowballDeviceCapacity{
+		VCPU:         52,
+		MemoryBytes:  1024,
+		StorageBytes: 120,
+	}))
+}
+
+func TestSnowballDeviceCapacityConvertsUnits(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	capacity := func(name string, available int64, unit string) types.Capacity {
+		return types.Capacity{Name: &name, Available: &available, Unit: &unit}
+	}
+	out := &snowballdevice.DescribeDeviceOutput{
+		DeviceCapacities: []types.Capacity{
+			capacity("HDD Storage", 2, "TiB"),
+			capacity("SSD Storage", 500, "GB"),
+			capacity("vCPU", 52, "Count"),
+			capacity("Memory", 8, "gib"),
+		},
+	}
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(out, nil)
+	got, err := g.client.SnowballDeviceCapacity(g.ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(got).To(Equal(&aws.SnowballDeviceCapacity{
+		VCPU:         52,
+		MemoryBytes:  8 << 30,
+		StorageBytes: 2<<40 + 500e9,
+	}))
+}
+
+func TestSnowballDeviceCapacityUnsupportedUnit(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	name, available, unit := "Memory", int64(8), "pages"
+	out := &snowballdevice.DescribeDeviceOutput{
+		DeviceCapacities: []types.Capacity{{Name: &name, Available: &available, Unit: &unit}},
+	}
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(out, nil)
+	_, err := g.client.SnowballDeviceCapacity(g.ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("converting Memory capacity: unsupported capacity unit [pages]")))
+}
+
+func TestSnowballDeviceCapacityDescribeDeviceError(t *testing.T) {
+	g := newSnowballDeviceTest(t)
+	g.snowballDevice.EXPECT().DescribeDevice(g.ctx, nil).Return(nil, errors.New("error"))
+	_, err := g.client.SnowballDeviceCapacity(g.ctx)
+	g.Expect(err).To(MatchError(ContainSubstring("describing snowball device")))
+}
@@ -12,7 +12,8 @@ import (
 )
 
 type Bootstrapper struct {
-	clusterClient ClusterClient
+	clusterClient   ClusterClient
+	existingCluster *existingCluster
 }
 
 type ClusterClient interface {
@@ -34,14 +35,27 @@ type (
 	BootstrapClusterOption       func(b *Bootstrapper) BootstrapClusterClientOption
 )
 
+// BootstrapperOpt allows to customize a Bootstrapper on construction.
+type BootstrapperOpt func(*Bootstrapper)
+
 // New constructs a new bootstrapper.
-func New(clusterClient ClusterClient) *Bootstrapper {
-	return &Bootstrapper{
+func New(clusterClient ClusterClient, opts ...BootstrapperOpt) *Bootstrapper {
+	b := &Bootstrapper{
 		clusterClient: clusterClient,
 	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
 }
 
 func (b *Bootstrapper) CreateBootstrapCluster(ctx context.Context, clusterSpec *cluster.Spec, opts ...BootstrapClusterOption) (*types.Cluster, error) {
+	if b.existingCluster != nil {
+		return b.useExistingCluster(ctx, clusterSpec)
+	}
+
 	kubeconfigFile, err := b.clusterClient.CreateBootstrapCluster(ctx, clusterSpec, b.getClientOptions(opts)...)
 	if err != nil {
 		return nil, fmt.Errorf("creating bootstrap cluster: %v", err)
@@ -60,6 +74,10 @@ func (b *Bootstrapper) CreateBootstrapCluster(ctx context.Context, clusterSpec *
 }
 
 func (b *Bootstrapper) DeleteBootstrapCluster(ctx context.Context, cluster *types.Cluster, operationType constants.Operation, isForceCleanup bool) error {
+	if b.existingCluster != nil {
+		return b.cleanUpExistingCluster(ctx, cluster, operationType, isForceCleanup)
+	}
+
 	clusterExists, err := b.clusterClient.KindClusterExists(ctx, cluster.Name)
 	if err != nil {
 		return fmt.Errorf("deleting bootstrap cluster: %v", err)
@@ -68,6 +86,15 @@ func (b *Bootstrapper) DeleteBootstrapCluster(ctx context.Context, cluster *type
 		logger.V(4).Info("Skipping delete bootstrap cluster, cluster doesn't exist")
 		return nil
 	}
+	if err := b.validateManagementMoved(ctx, cluster, operationType, isForceCleanup); err != nil {
+		return err
+	}
+
+	return b.clusterClient.DeleteKindCluster(ctx, cluster)
+}
+
+// validateManagementMoved fails when the bootstrap cluster still manages the cluster, unless the cleanup is forced.
+func (b *Bootstrapper) validateManagementMoved(ctx context.Context, cluster *types.Cluster, operationType constants.Operation, isForceCleanup bool) error {
 	mgmtCluster, err := b.managementInCluster(ctx, cluster)
 	if err != nil {
 		return fmt.Errorf("deleting bootstrap cluster: %v", err)
@@ -79,7 +106,7 @@ func (b *Bootstrapper) DeleteBootstrapCluster(ctx context.Context, cluster *type
 		}
 	}
 
-	return b.clusterClient.DeleteKindCluster(ctx, cluster)
+	return nil
 }
 
 func (b *Bootstrapper) managementInCluster(ctx context.Context, cluster *types.Cluster) (*types.CAPICluster, error) {
@@ -0,0 +1,393 @@
+package bootstrapper
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+// installedCertManagerAnnotation is set in the eksa-system namespace of an existing bootstrap cluster
+// when cert-manager is not installed in the cluster before the bootstrap components.
+const installedCertManagerAnnotation = "anywhere.eks.amazonaws.com/bootstrap-installs-cert-manager"
+
+var (
+	// eksaAPIGroups are the groups of the CRDs installed by the EKS Anywhere components.
+	eksaAPIGroups = map[string]bool{
+		"anywhere.eks.amazonaws.com": true,
+		"distro.eks.amazonaws.com":   true,
+	}
+
+	// eksaClusterObjects are the names of the cluster scoped objects installed by the EKS Anywhere components.
+	eksaClusterObjects = map[string]bool{
+		"eksa-manager-role":                     true,
+		"eksa-manager-rolebinding":              true,
+		"eksa-mutating-webhook-configuration":   true,
+		"eksa-validating-webhook-configuration": true,
+	}
+
+	crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinitionList"}
+
+	namespaceDeletionRetrier = retrier.NewWithMaxRetries(60, 5*time.Second)
+)
+
+// KubeClientFactory builds Kubernetes clients from a kubeconfig file.
+type KubeClientFactory interface {
+	BuildClientFromKubeconfig(kubeconfigPath string) (client.Client, error)
+}
+
+// existingCluster is a long-lived Kubernetes cluster used as the bootstrap cluster instead of a kind cluster.
+type existingCluster struct {
+	kubeconfig    string
+	clientFactory KubeClientFactory
+}
+
+// WithExistingCluster configures the Bootstrapper to use an existing Kubernetes cluster as the bootstrap
+// cluster instead of creating a kind cluster. Deleting the bootstrap cluster uninstalls the Cluster API and
+// EKS Anywhere components from it, and leaves everything else in place.
+func WithExistingCluster(kubeconfig string, clientFactory KubeClientFactory) BootstrapperOpt {
+	return func(b *Bootstrapper) {
+		b.existingCluster = &existingCluster{
+			kubeconfig:    kubeconfig,
+			clientFactory: clientFactory,
+		}
+	}
+}
+
+func (b *Bootstrapper) useExistingCluster(ctx context.Context, clusterSpec *cluster.Spec) (*types.Cluster, error) {
+	logger.V(3).Info("Using existing cluster as bootstrap cluster", "kubeconfig", b.existingCluster.kubeconfig)
+	c, err := b.existingCluster.clientFactory.BuildClientFromKubeconfig(b.existingCluster.kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("building client for existing bootstrap cluster: %v", err)
+	}
+
+	installCertManager, err := b.validateExistingCluster(ctx, c, clusterSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: constants.EksaSystemNamespace,
+			Annotations: map[string]string{
+				installedCertManagerAnnotation: strconv.FormatBool(installCertManager),
+			},
+		},
+	}
+	if err := c.Create(ctx, namespace); err != nil {
+		return nil, fmt.Errorf("creating namespace %s in existing bootstrap cluster: %v", constants.EksaSystemNamespace, err)
+	}
+
+	return &types.Cluster{
+		Name:           clusterSpec.Cluster.Name,
+		KubeconfigFile: b.existingCluster.kubeconfig,
+	}, nil
+}
+
+// validateExistingCluster checks that the existing cluster doesn't run Cluster API or EKS Anywhere, since
+// everything they install is uninstalled when the bootstrap cluster is deleted. The components left by a
+// previous bootstrap that failed before the bootstrap cluster was deleted are uninstalled first, so the
+// operation can be retried. It returns whether the bootstrap components install cert-manager.
+func (b *Bootstrapper) validateExistingCluster(ctx context.Context, c client.Client, clusterSpec *cluster.Spec) (bool, error) {
+	namespace := &corev1.Namespace{}
+	err := c.Get(ctx, client.ObjectKey{Name: constants.EksaSystemNamespace}, namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("validating existing bootstrap cluster: %v", err)
+	}
+
+	var installedCertManager, leftover bool
+	if err == nil {
+		if _, leftover = namespace.Annotations[installedCertManagerAnnotation]; !leftover {
+			return false, fmt.Errorf("existing bootstrap cluster already has namespace %s: it can't be used as bootstrap cluster while it runs Cluster API or EKS Anywhere", constants.EksaSystemNamespace)
+		}
+		installedCertManager = namespace.Annotations[installedCertManagerAnnotation] == "true"
+	}
+
+	if leftover {
+		logger.Info("Uninstalling bootstrap components left in existing bootstrap cluster by a previous run")
+		cluster := &types.Cluster{Name: clusterSpec.Cluster.Name, KubeconfigFile: b.existingCluster.kubeconfig}
+		if err := b.validateManagementMoved(ctx, cluster, constants.Create, false); err != nil {
+			return false, err
+		}
+		if err := uninstallBootstrapComponents(ctx, c, installedCertManager); err != nil {
+			return false, fmt.Errorf("cleaning up existing bootstrap cluster: %v", err)
+		}
+		if err := waitForNamespacesDeleted(ctx, c, bootstrapNamespace(installedCertManager)); err != nil {
+			return false, fmt.Errorf("cleaning up existing bootstrap cluster: %v", err)
+		}
+	}
+
+	exists, err := namespaceExists(ctx, c, constants.CapiSystemNamespace)
+	if err != nil {
+		return false, fmt.Errorf("validating existing bootstrap cluster: %v", err)
+	}
+	if exists {
+		return false, fmt.Errorf("existing bootstrap cluster already has namespace %s: it can't be used as bootstrap cluster while it runs Cluster API or EKS Anywhere", constants.CapiSystemNamespace)
+	}
+
+	// The CRDs are deleted by label and group when the bootstrap cluster is deleted, so none can exist
+	// before the components are installed. The clusterctl inventory CRD is labeled as well, so this
+	// also rejects clusters with clusterctl Provider objects.
+	crds := &unstructured.UnstructuredList{}
+	crds.SetGroupVersionKind(crdGVK)
+	if err := c.List(ctx, crds); err != nil {
+		return false, fmt.Errorf("validating existing bootstrap cluster: listing custom resource definitions: %v", err)
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		if bootstrapComponent(false)(crd) || eksaAPIGroups[group] {
+			return false, fmt.Errorf("existing bootstrap cluster already has CRD %s: it can't be used as bootstrap cluster while it runs Cluster API or EKS Anywhere", crd.GetName())
+		}
+	}
+
+	providers := &unstructured.UnstructuredList{}
+	providers.SetGroupVersionKind(clusterctlv1.GroupVersion.WithKind("ProviderList"))
+	if err := c.List(ctx, providers); err != nil && !meta.IsNoMatchError(err) && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("validating existing bootstrap cluster: listing clusterctl providers: %v", err)
+	}
+	if len(providers.Items) > 0 {
+		return false, fmt.Errorf("existing bootstrap cluster already has clusterctl provider %s/%s: it can't be used as bootstrap cluster while it runs Cluster API", providers.Items[0].GetNamespace(), providers.Items[0].GetName())
+	}
+
+	certManagerExists, err := namespaceExists(ctx, c, constants.CertManagerNamespace)
+	if err != nil {
+		return false, fmt.Errorf("validating existing bootstrap cluster: %v", err)
+	}
+
+	return !certManagerExists, nil
+}
+
+func (b *Bootstrapper) cleanUpExistingCluster(ctx context.Context, cluster *types.Cluster, operationType constants.Operation, isForceCleanup bool) error {
+	c, err := b.existingCluster.clientFactory.BuildClientFromKubeconfig(b.existingCluster.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("building client for existing bootstrap cluster: %v", err)
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: constants.EksaSystemNamespace}, namespace); apierrors.IsNotFound(err) {
+		logger.V(4).Info("Skipping cleanup of existing bootstrap cluster, bootstrap components aren't installed")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("cleaning up existing bootstrap cluster: %v", err)
+	}
+
+	if cluster.KubeconfigFile == "" {
+		cluster.KubeconfigFile = b.existingCluster.kubeconfig
+	}
+	if err := b.validateManagementMoved(ctx, cluster, operationType, isForceCleanup); err != nil {
+		return err
+	}
+
+	logger.V(3).Info("Uninstalling bootstrap components from existing cluster")
+	removeCertManager := namespace.Annotations[installedCertManagerAnnotation] == "true"
+	if err := uninstallBootstrapComponents(ctx, c, removeCertManager); err != nil {
+		return fmt.Errorf("cleaning up existing bootstrap cluster: %v", err)
+	}
+
+	return nil
+}
+
+// uninstallBootstrapComponents deletes the objects installed in a bootstrap cluster by clusterctl and
+// the EKS Anywhere components. cert-manager is only deleted when removeCertManager is true.
+func uninstallBootstrapComponents(ctx context.Context, c client.Client, removeCertManager bool) error {
+	installed := bootstrapComponent(removeCertManager)
+	installedClusterObject := func(obj client.Object) bool {
+		return installed(obj) || eksaClusterObjects[obj.GetName()]
+	}
+
+	// The webhooks go first, their servers are removed with the rest of the components and they would
+	// block the updates that remove the finalizers.
+	mutatingWebhooks := &admissionregistrationv1.MutatingWebhookConfigurationList{}
+	if err := c.List(ctx, mutatingWebhooks); err != nil {
+		return fmt.Errorf("listing mutating webhook configurations: %v", err)
+	}
+	for i := range mutatingWebhooks.Items {
+		if err := deleteIf(ctx, c, &mutatingWebhooks.Items[i], installedClusterObject); err != nil {
+			return err
+		}
+	}
+
+	validatingWebhooks := &admissionregistrationv1.ValidatingWebhookConfigurationList{}
+	if err := c.List(ctx, validatingWebhooks); err != nil {
+		return fmt.Errorf("listing validating webhook configurations: %v", err)
+	}
+	for i := range validatingWebhooks.Items {
+		if err := deleteIf(ctx, c, &validatingWebhooks.Items[i], installedClusterObject); err != nil {
+			return err
+		}
+	}
+
+	crds := &unstructured.UnstructuredList{}
+	crds.SetGroupVersionKind(crdGVK)
+	if err := c.List(ctx, crds); err != nil {
+		return fmt.Errorf("listing custom resource definitions: %v", err)
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		if !installed(crd) && !eksaAPIGroups[group] {
+			continue
+		}
+		// The controllers are removed with the components, so nothing would remove the finalizers of
+		// the objects left in the cluster, like the EKS Anywhere cluster, and the CRD would never be deleted.
+		if err := removeFinalizers(ctx, c, crd); err != nil {
+			return err
+		}
+		if err := deleteIf(ctx, c, crd, func(client.Object) bool { return true }); err != nil {
+			return err
+		}
+	}
+
+	clusterRoleBindings := &rbacv1.ClusterRoleBindingList{}
+	if err := c.List(ctx, clusterRoleBindings); err != nil {
+		return fmt.Errorf("listing cluster role bindings: %v", err)
+	}
+	for i := range clusterRoleBindings.Items {
+		if err := deleteIf(ctx, c, &clusterRoleBindings.Items[i], installedClusterObject); err != nil {
+			return err
+		}
+	}
+
+	clusterRoles := &rbacv1.ClusterRoleList{}
+	if err := c.List(ctx, clusterRoles); err != nil {
+		return fmt.Errorf("listing cluster roles: %v", err)
+	}
+	for i := range clusterRoles.Items {
+		if err := deleteIf(ctx, c, &clusterRoles.Items[i], installedClusterObject); err != nil {
+			return err
+		}
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaces); err != nil {
+		return fmt.Errorf("listing namespaces: %v", err)
+	}
+	for i := range namespaces.Items {
+		if err := deleteIf(ctx, c, &namespaces.Items[i], bootstrapNamespace(removeCertManager)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bootstrapComponent returns whether an object was installed by clusterctl. cert-manager objects are only
+// included when withCertManager is true.
+func bootstrapComponent(withCertManager bool) func(client.Object) bool {
+	return func(obj client.Object) bool {
+		labels := obj.GetLabels()
+		if _, ok := labels[clusterctlv1.ClusterctlLabel]; !ok {
+			return false
+		}
+		return withCertManager || labels[clusterctlv1.ClusterctlCoreLabel] != clusterctlv1.ClusterctlCoreLabelCertManagerValue
+	}
+}
+
+// bootstrapNamespace returns whether a namespace was created by the bootstrap components.
+func bootstrapNamespace(withCertManager bool) func(client.Object) bool {
+	installed := bootstrapComponent(withCertManager)
+	return func(obj client.Object) bool {
+		return installed(obj) || obj.GetName() == constants.EksaSystemNamespace
+	}
+}
+
+// waitForNamespacesDeleted waits until the namespaces matching deleted are gone, so the components can
+// be installed again in them.
+func waitForNamespacesDeleted(ctx context.Context, c client.Client, deleted func(client.Object) bool) error {
+	return namespaceDeletionRetrier.Retry(func() error {
+		namespaces := &corev1.NamespaceList{}
+		if err := c.List(ctx, namespaces); err != nil {
+			return fmt.Errorf("listing namespaces: %v", err)
+		}
+		for i := range namespaces.Items {
+			if deleted(&namespaces.Items[i]) {
+				return fmt.Errorf("namespace %s is still being deleted", namespaces.Items[i].Name)
+			}
+		}
+		return nil
+	})
+}
+
+// removeFinalizers removes the finalizers of all the objects of a CRD.
+func removeFinalizers(ctx context.Context, c client.Client, crd *unstructured.Unstructured) error {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+
+	var version string
+	for _, v := range versions {
+		v, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if storage, _ := v["storage"].(bool); storage {
+			version, _ = v["name"].(string)
+		}
+	}
+	if version == "" {
+		return nil
+	}
+
+	objs := &unstructured.UnstructuredList{}
+	objs.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: version, Kind: kind + "List"})
+	if err := c.List(ctx, objs); err != nil {
+		return fmt.Errorf("listing %s: %v", crd.GetName(), err)
+	}
+
+	for i := range objs.Items {
+		obj := &objs.Items[i]
+		if len(obj.GetFinalizers()) == 0 {
+			continue
+		}
+		patch := client.MergeFrom(obj.DeepCopy())
+		obj.SetFinalizers(nil)
+		if err := c.Patch(ctx, obj, patch); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("removing finalizers from %s %s: %v", kind, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func deleteIf(ctx context.Context, c client.Client, obj client.Object, shouldDelete func(client.Object) bool) error {
+	if !shouldDelete(obj) {
+		return nil
+	}
+
+	logger.V(4).Info("Deleting bootstrap component", "name", obj.GetName())
+	if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting %s: %v", obj.GetName(), err)
+	}
+
+	return nil
+}
+
+func namespaceExists(ctx context.Context, c client.Client, name string) (bool, error) {
+	err := c.Get(ctx, client.ObjectKey{Name: name}, &corev1.Namespace{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
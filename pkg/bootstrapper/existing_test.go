@@ -0,0 +1,264 @@
+package bootstrapper_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/bootstrapper"
+	"github.com/aws/eks-anywhere/pkg/bootstrapper/mocks"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/types"
+)
+
+const existingKubeconfig = "ops.kubeconfig"
+
+type fakeClientFactory struct {
+	client client.Client
+}
+
+func (f fakeClientFactory) BuildClientFromKubeconfig(kubeconfig string) (client.Client, error) {
+	if kubeconfig != existingKubeconfig {
+		return nil, errors.New("unexpected kubeconfig")
+	}
+	return f.client, nil
+}
+
+func newExistingClusterBootstrapper(t *testing.T, objs ...client.Object) (*bootstrapper.Bootstrapper, *mocks.MockClusterClient, client.Client) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, apiextensionsv1.AddToScheme, anywherev1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	clusterClient := mocks.NewMockClusterClient(gomock.NewController(t))
+
+	return bootstrapper.New(clusterClient, bootstrapper.WithExistingCluster(existingKubeconfig, fakeClientFactory{client: c})), clusterClient, c
+}
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func crd(name, group string, labels map[string]string) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Cluster"},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Storage: true},
+			},
+		},
+	}
+}
+
+func TestBootstrapperCreateBootstrapClusterExistingCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec, _ := given(t, "cluster-name", existingKubeconfig)
+	certManager := map[string]string{"clusterctl.cluster.x-k8s.io": "", "clusterctl.cluster.x-k8s.io/core": "cert-manager"}
+	b, _, c := newExistingClusterBootstrapper(t,
+		namespace(constants.CertManagerNamespace, nil),
+		crd("certificates.cert-manager.io", "cert-manager.io", certManager),
+		crd("widgets.example.com", "example.com", nil),
+	)
+
+	got, err := b.CreateBootstrapCluster(ctx, clusterSpec, bootstrapper.WithExtraDockerMounts())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(&types.Cluster{Name: "cluster-name", KubeconfigFile: existingKubeconfig}))
+
+	ns := &corev1.Namespace{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: constants.EksaSystemNamespace}, ns)).To(Succeed())
+	g.Expect(ns.Annotations).To(HaveKeyWithValue("anywhere.eks.amazonaws.com/bootstrap-installs-cert-manager", "false"))
+}
+
+func TestBootstrapperCreateBootstrapClusterExistingClusterWithCAPI(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec, _ := given(t, "cluster-name", existingKubeconfig)
+	b, _, _ := newExistingClusterBootstrapper(t, namespace(constants.CapiSystemNamespace, nil))
+
+	_, err := b.CreateBootstrapCluster(context.Background(), clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("existing bootstrap cluster already has namespace capi-system")))
+}
+
+func TestBootstrapperCreateBootstrapClusterExistingClusterWithEksaSystem(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec, _ := given(t, "cluster-name", existingKubeconfig)
+	b, _, _ := newExistingClusterBootstrapper(t, namespace(constants.EksaSystemNamespace, nil))
+
+	_, err := b.CreateBootstrapCluster(context.Background(), clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("existing bootstrap cluster already has namespace eksa-system")))
+}
+
+func TestBootstrapperCreateBootstrapClusterExistingClusterWithCAPICRD(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec, _ := given(t, "cluster-name", existingKubeconfig)
+	b, _, _ := newExistingClusterBootstrapper(t,
+		crd("providers.clusterctl.cluster.x-k8s.io", "clusterctl.cluster.x-k8s.io", map[string]string{"clusterctl.cluster.x-k8s.io": "", "clusterctl.cluster.x-k8s.io/core": "inventory"}),
+	)
+
+	_, err := b.CreateBootstrapCluster(context.Background(), clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("existing bootstrap cluster already has CRD providers.clusterctl.cluster.x-k8s.io")))
+}
+
+func TestBootstrapperCreateBootstrapClusterExistingClusterWithEksaCRD(t *testing.T) {
+	g := NewWithT(t)
+	clusterSpec, _ := given(t, "cluster-name", existingKubeconfig)
+	b, _, _ := newExistingClusterBootstrapper(t, crd("clusters.anywhere.eks.amazonaws.com", "anywhere.eks.amazonaws.com", nil))
+
+	_, err := b.CreateBootstrapCluster(context.Background(), clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("existing bootstrap cluster already has CRD clusters.anywhere.eks.amazonaws.com")))
+}
+
+func TestBootstrapperCreateBootstrapClusterExistingClusterLeftFromFailedCreate(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec, _ := given(t, "cluster-name", existingKubeconfig)
+	clusterctl := map[string]string{"clusterctl.cluster.x-k8s.io": ""}
+	certManager := map[string]string{"clusterctl.cluster.x-k8s.io": "", "clusterctl.cluster.x-k8s.io/core": "cert-manager"}
+	eksaSystem := namespace(constants.EksaSystemNamespace, nil)
+	eksaSystem.Annotations = map[string]string{"anywhere.eks.amazonaws.com/bootstrap-installs-cert-manager": "true"}
+
+	b, clusterClient, c := newExistingClusterBootstrapper(t,
+		eksaSystem,
+		namespace(constants.CapiSystemNamespace, clusterctl),
+		namespace(constants.CertManagerNamespace, certManager),
+		crd("clusters.cluster.x-k8s.io", "cluster.x-k8s.io", clusterctl),
+		crd("certificates.cert-manager.io", "cert-manager.io", certManager),
+		crd("clusters.anywhere.eks.amazonaws.com", "anywhere.eks.amazonaws.com", nil),
+	)
+	cluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: existingKubeconfig}
+	clusterClient.EXPECT().GetCAPIClusterCRD(ctx, cluster).Return(nil)
+	clusterClient.EXPECT().GetCAPIClusters(ctx, cluster).Return(nil, nil)
+
+	got, err := b.CreateBootstrapCluster(ctx, clusterSpec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(cluster))
+
+	ns := &corev1.Namespace{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: constants.EksaSystemNamespace}, ns)).To(Succeed())
+	g.Expect(ns.Annotations).To(HaveKeyWithValue("anywhere.eks.amazonaws.com/bootstrap-installs-cert-manager", "true"))
+
+	for _, obj := range []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.CapiSystemNamespace}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.CertManagerNamespace}},
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "clusters.cluster.x-k8s.io"}},
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert-manager.io"}},
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "clusters.anywhere.eks.amazonaws.com"}},
+	} {
+		err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s should be deleted", obj.GetName())
+	}
+}
+
+func TestBootstrapperCreateBootstrapClusterExistingClusterLeftWithManagement(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterSpec, _ := given(t, "cluster-name", existingKubeconfig)
+	eksaSystem := namespace(constants.EksaSystemNamespace, nil)
+	eksaSystem.Annotations = map[string]string{"anywhere.eks.amazonaws.com/bootstrap-installs-cert-manager": "false"}
+
+	b, clusterClient, c := newExistingClusterBootstrapper(t, eksaSystem)
+	cluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: existingKubeconfig}
+	clusterClient.EXPECT().GetCAPIClusterCRD(ctx, cluster).Return(nil)
+	clusterClient.EXPECT().GetCAPIClusters(ctx, cluster).Return([]types.CAPICluster{{Status: types.ClusterStatus{Phase: "Provisioned"}}}, nil)
+
+	_, err := b.CreateBootstrapCluster(ctx, clusterSpec)
+	g.Expect(err).To(MatchError(ContainSubstring("management cluster in bootstrap cluster")))
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: constants.EksaSystemNamespace}, &corev1.Namespace{})).To(Succeed())
+}
+
+func TestBootstrapperDeleteBootstrapClusterExistingCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	clusterctl := map[string]string{"clusterctl.cluster.x-k8s.io": ""}
+	certManager := map[string]string{"clusterctl.cluster.x-k8s.io": "", "clusterctl.cluster.x-k8s.io/core": "cert-manager"}
+	eksaSystem := namespace(constants.EksaSystemNamespace, nil)
+	eksaSystem.Annotations = map[string]string{"anywhere.eks.amazonaws.com/bootstrap-installs-cert-manager": "false"}
+	eksaCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-name", Namespace: "default", Finalizers: []string{"clusters.anywhere.eks.amazonaws.com/finalizer"}},
+	}
+
+	b, clusterClient, c := newExistingClusterBootstrapper(t,
+		eksaSystem,
+		namespace(constants.CapiSystemNamespace, clusterctl),
+		namespace(constants.CertManagerNamespace, certManager),
+		namespace("ops", nil),
+		crd("clusters.cluster.x-k8s.io", "cluster.x-k8s.io", clusterctl),
+		crd("certificates.cert-manager.io", "cert-manager.io", certManager),
+		crd("clusters.anywhere.eks.amazonaws.com", "anywhere.eks.amazonaws.com", nil),
+		crd("widgets.example.com", "example.com", nil),
+		eksaCluster,
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "eksa-manager-role"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "capi-manager-role", Labels: clusterctl}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "ops-admin"}},
+		&admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "eksa-mutating-webhook-configuration"}},
+	)
+	cluster := &types.Cluster{Name: "cluster-name"}
+	clusterClient.EXPECT().GetCAPIClusterCRD(ctx, cluster).Return(nil)
+	clusterClient.EXPECT().GetCAPIClusters(ctx, cluster).Return(nil, nil)
+
+	g.Expect(b.DeleteBootstrapCluster(ctx, cluster, constants.Create, false)).To(Succeed())
+	g.Expect(cluster.KubeconfigFile).To(Equal(existingKubeconfig))
+
+	for _, obj := range []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.EksaSystemNamespace}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.CapiSystemNamespace}},
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "clusters.cluster.x-k8s.io"}},
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "clusters.anywhere.eks.amazonaws.com"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "eksa-manager-role"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "capi-manager-role"}},
+		&admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "eksa-mutating-webhook-configuration"}},
+	} {
+		err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s should be deleted", obj.GetName())
+	}
+
+	for _, obj := range []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: constants.CertManagerNamespace}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ops"}},
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "certificates.cert-manager.io"}},
+		&apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "ops-admin"}},
+	} {
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed(), "%s should be kept", obj.GetName())
+	}
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(eksaCluster), eksaCluster)).To(Succeed())
+	g.Expect(eksaCluster.Finalizers).To(BeEmpty())
+}
+
+func TestBootstrapperDeleteBootstrapClusterExistingClusterWithManagement(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	b, clusterClient, c := newExistingClusterBootstrapper(t, namespace(constants.EksaSystemNamespace, nil))
+	cluster := &types.Cluster{Name: "cluster-name", KubeconfigFile: existingKubeconfig}
+	clusterClient.EXPECT().GetCAPIClusterCRD(ctx, cluster).Return(nil)
+	clusterClient.EXPECT().GetCAPIClusters(ctx, cluster).Return([]types.CAPICluster{{Metadata: types.Metadata{Name: "cluster-name"}}}, nil)
+
+	g.Expect(b.DeleteBootstrapCluster(ctx, cluster, constants.Upgrade, false)).To(MatchError(ContainSubstring("management cluster in bootstrap cluster")))
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: constants.EksaSystemNamespace}, &corev1.Namespace{})).To(Succeed())
+}
+
+func TestBootstrapperDeleteBootstrapClusterExistingClusterNotInstalled(t *testing.T) {
+	g := NewWithT(t)
+	b, _, _ := newExistingClusterBootstrapper(t)
+
+	g.Expect(b.DeleteBootstrapCluster(context.Background(), &types.Cluster{Name: "cluster-name"}, constants.Create, false)).To(Succeed())
+}
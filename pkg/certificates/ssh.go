@@ -1,13 +1,16 @@
 package certificates
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 
 	"github.com/aws/eks-anywhere/pkg/logger"
 )
@@ -57,18 +60,38 @@ func WithSSHLogging(display bool) SSHOption {
 
 // NewSSHRunner creates a new SSH runner with the given configuration.
 func NewSSHRunner(cfg SSHConfig) (*DefaultSSHRunner, error) {
-	r := &DefaultSSHRunner{
-		sshDialer: func(network, addr string, config *ssh.ClientConfig) (sshClient, error) {
-			return ssh.Dial(network, addr, config)
-		},
+	key, err := os.ReadFile(cfg.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading SSH key: %v", err)
 	}
 
+	r, err := newSSHRunner(cfg.User, key, cfg.Password, ssh.InsecureIgnoreHostKey())
+	if err != nil {
+		return nil, err
+	}
 	r.sshKeyPath = cfg.KeyPath
-	r.sshPasswd = cfg.Password
 
-	key, err := os.ReadFile(cfg.KeyPath)
+	return r, nil
+}
+
+// NewSSHRunnerWithKey creates a new SSH runner from the content of the private key, for callers
+// that don't read the key from a file, like the controllers reading it from a Secret.
+// The host keys of the nodes are verified against knownHosts, in the OpenSSH known_hosts format.
+func NewSSHRunnerWithKey(user string, key []byte, passphrase string, knownHosts []byte) (*DefaultSSHRunner, error) {
+	hostKeyCallback, err := knownHostsCallback(knownHosts)
 	if err != nil {
-		return nil, fmt.Errorf("reading SSH key: %v", err)
+		return nil, err
+	}
+
+	return newSSHRunner(user, key, passphrase, hostKeyCallback)
+}
+
+func newSSHRunner(user string, key []byte, passphrase string, hostKeyCallback ssh.HostKeyCallback) (*DefaultSSHRunner, error) {
+	r := &DefaultSSHRunner{
+		sshDialer: func(network, addr string, config *ssh.ClientConfig) (sshClient, error) {
+			return ssh.Dial(network, addr, config)
+		},
+		sshPasswd: passphrase,
 	}
 
 	signer, err := r.parsePrivateKey(key)
@@ -77,17 +100,43 @@ func NewSSHRunner(cfg SSHConfig) (*DefaultSSHRunner, error) {
 	}
 
 	r.sshConfig = &ssh.ClientConfig{
-		User: cfg.User,
+		User: user,
 		Auth: []ssh.AuthMethod{
 			ssh.PublicKeys(signer),
 		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		HostKeyCallback: hostKeyCallback,
 		Timeout:         30 * time.Second,
 	}
 
 	return r, nil
 }
 
+// knownHostsCallback returns a host key callback that only accepts the host keys in knownHosts.
+// The knownhosts package only reads files, so the content is written to a temporary one.
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	if len(bytes.TrimSpace(knownHosts)) == 0 {
+		return nil, errors.New("known hosts are required to verify the SSH host keys")
+	}
+
+	f, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, fmt.Errorf("creating known hosts file: %v", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if _, err := f.Write(knownHosts); err != nil {
+		return nil, fmt.Errorf("writing known hosts file: %v", err)
+	}
+
+	callback, err := knownhosts.New(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("parsing known hosts: %v", err)
+	}
+
+	return callback, nil
+}
+
 // parsePrivateKey only get password from enviroment variables.
 func (r *DefaultSSHRunner) parsePrivateKey(key []byte) (ssh.Signer, error) {
 	signer, err := ssh.ParsePrivateKey(key)
@@ -0,0 +1,69 @@
+package certificates_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+func TestNewSSHRunnerWithKey(t *testing.T) {
+	hostKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostPublicKey, err := ssh.NewPublicKey(hostKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	knownHosts := knownhosts.Line([]string{"10.0.0.1"}, hostPublicKey)
+
+	tests := []struct {
+		name       string
+		knownHosts []byte
+		wantErr    string
+	}{
+		{
+			name:       "valid known hosts",
+			knownHosts: []byte(knownHosts),
+		},
+		{
+			name:    "missing known hosts",
+			wantErr: "known hosts are required to verify the SSH host keys",
+		},
+		{
+			name:       "invalid known hosts",
+			knownHosts: []byte("10.0.0.1 ssh-ed25519 invalid"),
+			wantErr:    "parsing known hosts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := certificates.NewSSHRunnerWithKey("ec2-user", privateKey(t), "", tt.knownHosts)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func privateKey(t *testing.T) []byte {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(block)
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/manifests"
-	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/version"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
 )
@@ -114,7 +113,7 @@ func (b FileSpecBuilder) Build(clusterConfigURL string) (*Spec, error) {
 		return nil, err
 	}
 
-	eksdReleases, err := getAllEksdReleases(config.Cluster, bundlesManifest, b.reader)
+	eksdReleases, err := getAllEksdReleases(config.Cluster, bundlesManifest, mReader)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +130,7 @@ func (b FileSpecBuilder) Build(clusterConfigURL string) (*Spec, error) {
 	return NewSpec(config, bundlesManifest, eksdReleases, eksaRelease)
 }
 
-func getAllEksdReleases(cluster *v1alpha1.Cluster, bundlesManifest *releasev1.Bundles, reader bundles.Reader) ([]eksdv1.Release, error) {
+func getAllEksdReleases(cluster *v1alpha1.Cluster, bundlesManifest *releasev1.Bundles, reader *manifests.Reader) ([]eksdv1.Release, error) {
 	versions := cluster.KubernetesVersions()
 	m := make([]eksdv1.Release, 0, len(versions))
 	for _, version := range versions {
@@ -144,13 +143,13 @@ func getAllEksdReleases(cluster *v1alpha1.Cluster, bundlesManifest *releasev1.Bu
 	return m, nil
 }
 
-func getEksdReleases(version v1alpha1.KubernetesVersion, bundlesManifest *releasev1.Bundles, reader bundles.Reader) (*eksdv1.Release, error) {
+func getEksdReleases(version v1alpha1.KubernetesVersion, bundlesManifest *releasev1.Bundles, reader *manifests.Reader) (*eksdv1.Release, error) {
 	versionsBundle, err := GetVersionsBundle(version, bundlesManifest)
 	if err != nil {
 		return nil, err
 	}
 
-	eksd, err := bundles.ReadEKSD(reader, *versionsBundle)
+	eksd, err := reader.ReadEKSDForBundles(bundlesManifest, *versionsBundle)
 	if err != nil {
 		return nil, err
 	}
@@ -181,7 +180,7 @@ func (b FileSpecBuilder) getBundles(manifestReader *manifests.Reader) (*releasev
 		return manifestReader.ReadBundlesForVersion(b.cliVersion.GitVersion)
 	}
 
-	return bundles.Read(b.reader, bundlesURL)
+	return manifestReader.ReadBundles(bundlesURL)
 }
 
 func (b FileSpecBuilder) getEksaRelease(mReader *manifests.Reader) (*releasev1.EksARelease, error) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/files"
+	"github.com/aws/eks-anywhere/pkg/manifests"
 	"github.com/aws/eks-anywhere/pkg/version"
 )
 
@@ -76,6 +77,7 @@ func TestFileSpecBuilderBuildSuccess(t *testing.T) {
 
 func TestNewSpecWithBundlesOverrideValid(t *testing.T) {
 	g := NewWithT(t)
+	t.Setenv(manifests.SkipSignatureVerificationEnvVar, "unsigned test bundle")
 
 	v := version.Info{GitVersion: "v0.0.1"}
 	reader := files.NewReader()
@@ -89,3 +91,18 @@ func TestNewSpecWithBundlesOverrideValid(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	validateSpecFromSimpleBundle(t, gotSpec)
 }
+
+func TestNewSpecWithBundlesOverrideUnsigned(t *testing.T) {
+	g := NewWithT(t)
+
+	v := version.Info{GitVersion: "v0.0.1"}
+	reader := files.NewReader()
+	b := cluster.NewFileSpecBuilder(reader, v,
+		cluster.WithReleasesManifest("testdata/simple_release.yaml"),
+		cluster.WithOverrideBundlesManifest("testdata/simple_bundle.yaml"),
+	)
+
+	_, err := b.Build("testdata/cluster_1_19.yaml")
+
+	g.Expect(err).To(MatchError(ContainSubstring("verifying signature of Bundles manifest testdata/simple_bundle.yaml: missing bundle signature annotation")))
+}
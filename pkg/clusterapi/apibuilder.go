@@ -98,7 +98,7 @@ func Cluster(clusterSpec *cluster.Spec, infrastructureObject, controlPlaneObject
 		Spec: clusterv1.ClusterSpec{
 			ClusterNetwork: &clusterv1.ClusterNetwork{
 				Pods: &clusterv1.NetworkRanges{
-					CIDRBlocks: clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
+					CIDRBlocks: clusterSpec.Cluster.Spec.ClusterNetwork.PrimaryPodCIDRBlocks(),
 				},
 				Services: &clusterv1.NetworkRanges{
 					CIDRBlocks: clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
@@ -270,6 +270,13 @@ func TestCluster(t *testing.T) {
 	tt.Expect(got).To(Equal(want))
 }
 
+func TestClusterAdditionalPodCIDRBlocks(t *testing.T) {
+	tt := newApiBuilerTest(t)
+	tt.clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"1.2.3.4/5", "10.10.0.0/16"}
+	got := clusterapi.Cluster(tt.clusterSpec, tt.providerCluster, tt.controlPlane, nil)
+	tt.Expect(got).To(Equal(wantCluster()))
+}
+
 type kubeadmControlPlaneOpt func(k *controlplanev1.KubeadmControlPlane)
 
 func wantKubeadmControlPlane(opts ...kubeadmControlPlaneOpt) *controlplanev1.KubeadmControlPlane {
@@ -1,6 +1,8 @@
 package clusterapi
 
 import (
+	"fmt"
+
 	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
 	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	v1 "k8s.io/api/core/v1"
@@ -75,3 +77,45 @@ func setStackedEtcdConfigInKubeadmControlPlane(kcp *controlplanev1.KubeadmContro
 		ExtraArgs: SecureEtcdTlsCipherSuitesExtraArgs(),
 	}
 }
+
+// EtcdQuorum returns the number of members an etcd cluster of the given size needs to keep quorum.
+func EtcdQuorum(members int32) int32 {
+	return members/2 + 1
+}
+
+// ValidateEtcdScaling checks an etcd cluster can be safely scaled from current to desired members.
+// Shrinking the cluster is refused when the remaining members wouldn't make a quorum of the current
+// cluster, since losing one of them while the members are being removed would make etcd unavailable.
+func ValidateEtcdScaling(current, desired int32) error {
+	if desired < 1 {
+		return fmt.Errorf("etcd can't be scaled to %d members", desired)
+	}
+
+	if quorum := EtcdQuorum(current); desired < quorum {
+		return fmt.Errorf("scaling etcd from %d to %d members would drop below the current quorum of %d members", current, desired, quorum)
+	}
+
+	return nil
+}
+
+// NextEtcdReplicas returns the replicas for the next step of scaling an etcd cluster from current
+// towards desired members. Members are added or removed one at a time.
+func NextEtcdReplicas(current, desired int32) int32 {
+	switch {
+	case desired > current:
+		return current + 1
+	case desired < current:
+		return current - 1
+	default:
+		return current
+	}
+}
+
+// EtcdadmClusterHealthy returns true when the etcdadm controller has observed the latest spec and all
+// the desired members are running and passing the health check.
+func EtcdadmClusterHealthy(etcd *etcdv1.EtcdadmCluster) bool {
+	return etcd.Spec.Replicas != nil &&
+		etcd.Generation == etcd.Status.ObservedGeneration &&
+		etcd.Status.Ready &&
+		etcd.Status.ReadyReplicas == *etcd.Spec.Replicas
+}
@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
 
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
@@ -112,3 +114,73 @@ func TestSetStackedEtcdConfigInKubeadmControlPlane(t *testing.T) {
 	tt.Expect(err).To(Succeed())
 	tt.Expect(got).To(Equal(want))
 }
+
+func TestValidateEtcdScaling(t *testing.T) {
+	tests := []struct {
+		name             string
+		current, desired int32
+		wantErr          string
+	}{
+		{name: "scale up from 1 to 3", current: 1, desired: 3},
+		{name: "scale up from 3 to 5", current: 3, desired: 5},
+		{name: "scale down from 5 to 3", current: 5, desired: 3},
+		{name: "no change", current: 3, desired: 3},
+		{name: "scale down from 3 to 1", current: 3, desired: 1, wantErr: "scaling etcd from 3 to 1 members would drop below the current quorum of 2 members"},
+		{name: "scale down from 5 to 1", current: 5, desired: 1, wantErr: "scaling etcd from 5 to 1 members would drop below the current quorum of 3 members"},
+		{name: "scale to 0", current: 1, desired: 0, wantErr: "etcd can't be scaled to 0 members"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := clusterapi.ValidateEtcdScaling(tt.current, tt.desired)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
+
+func TestNextEtcdReplicas(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(clusterapi.NextEtcdReplicas(1, 3)).To(Equal(int32(2)))
+	g.Expect(clusterapi.NextEtcdReplicas(2, 3)).To(Equal(int32(3)))
+	g.Expect(clusterapi.NextEtcdReplicas(5, 3)).To(Equal(int32(4)))
+	g.Expect(clusterapi.NextEtcdReplicas(3, 3)).To(Equal(int32(3)))
+}
+
+func TestEtcdadmClusterHealthy(t *testing.T) {
+	replicas := int32(3)
+	healthy := func() *etcdv1.EtcdadmCluster {
+		return &etcdv1.EtcdadmCluster{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       etcdv1.EtcdadmClusterSpec{Replicas: &replicas},
+			Status: etcdv1.EtcdadmClusterStatus{
+				ObservedGeneration: 2,
+				Ready:              true,
+				ReadyReplicas:      3,
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*etcdv1.EtcdadmCluster)
+		want   bool
+	}{
+		{name: "healthy", modify: func(*etcdv1.EtcdadmCluster) {}, want: true},
+		{name: "spec not observed", modify: func(e *etcdv1.EtcdadmCluster) { e.Status.ObservedGeneration = 1 }},
+		{name: "not ready", modify: func(e *etcdv1.EtcdadmCluster) { e.Status.Ready = false }},
+		{name: "member missing", modify: func(e *etcdv1.EtcdadmCluster) { e.Status.ReadyReplicas = 2 }},
+		{name: "no replicas", modify: func(e *etcdv1.EtcdadmCluster) { e.Spec.Replicas = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			e := healthy()
+			tt.modify(e)
+			g.Expect(clusterapi.EtcdadmClusterHealthy(e)).To(Equal(tt.want))
+		})
+	}
+}
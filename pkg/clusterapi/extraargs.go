@@ -71,8 +71,26 @@ func PodIAMAuthExtraArgs(podIAMConfig *v1alpha1.PodIAMConfig) ExtraArgs {
 	return args
 }
 
+// NodeCIDRMaskExtraArgs returns the kube-controller-manager node cidr mask size args.
+// Dual-stack clusters use a mask size flag per IP family.
 func NodeCIDRMaskExtraArgs(clusterNetwork *v1alpha1.ClusterNetwork) ExtraArgs {
-	if clusterNetwork == nil || clusterNetwork.Nodes == nil || clusterNetwork.Nodes.CIDRMaskSize == nil {
+	if clusterNetwork == nil || clusterNetwork.Nodes == nil {
+		return nil
+	}
+	if clusterNetwork.IsDualStack() {
+		if clusterNetwork.Nodes.CIDRMaskSize == nil && clusterNetwork.Nodes.CIDRMaskSizeIPv6 == nil {
+			return nil
+		}
+		args := ExtraArgs{}
+		if clusterNetwork.Nodes.CIDRMaskSize != nil {
+			args.AddIfNotEmpty("node-cidr-mask-size-ipv4", strconv.Itoa(*clusterNetwork.Nodes.CIDRMaskSize))
+		}
+		if clusterNetwork.Nodes.CIDRMaskSizeIPv6 != nil {
+			args.AddIfNotEmpty("node-cidr-mask-size-ipv6", strconv.Itoa(*clusterNetwork.Nodes.CIDRMaskSizeIPv6))
+		}
+		return args
+	}
+	if clusterNetwork.Nodes.CIDRMaskSize == nil {
 		return nil
 	}
 	args := ExtraArgs{}
@@ -579,6 +579,25 @@ func TestNodeCIDRMaskExtraArgs(t *testing.T) {
 				"node-cidr-mask-size": "28",
 			},
 		},
+		{
+			testName: "with dual-stack nodes config",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods:  v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16", "fd00::/56"}},
+				Nodes: &v1alpha1.Nodes{CIDRMaskSize: nodeCidrMaskSize, CIDRMaskSizeIPv6: ptr.Int(72)},
+			},
+			want: clusterapi.ExtraArgs{
+				"node-cidr-mask-size-ipv4": "28",
+				"node-cidr-mask-size-ipv6": "72",
+			},
+		},
+		{
+			testName: "with dual-stack nodes config empty",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods:  v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16", "fd00::/56"}},
+				Nodes: &v1alpha1.Nodes{},
+			},
+			want: nil,
+		},
 		{
 			testName: "with nodes config empty",
 			clusterNetwork: &v1alpha1.ClusterNetwork{
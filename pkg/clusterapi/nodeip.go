@@ -0,0 +1,49 @@
+package clusterapi
+
+import (
+	"fmt"
+	"strings"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+)
+
+// nodeIPRouteTargets are the addresses used to find the source address of the default route of each IP family.
+// They don't need to be reachable, only routable.
+var nodeIPRouteTargets = map[v1alpha1.IPFamily]string{
+	v1alpha1.IPv4Family: "ip -o -4 route get 1.1.1.1",
+	v1alpha1.IPv6Family: "ip -o -6 route get 2606:4700:4700::1111",
+}
+
+// KubeletNodeIPCommand returns the prekubeadm command that sets the kubelet node-ip to the default route
+// address of each IP family of a dual-stack cluster, in the order of the pod CIDR blocks. Kubelet only
+// detects one node address by default. It returns an empty string for single-stack clusters.
+func KubeletNodeIPCommand(clusterNetwork *v1alpha1.ClusterNetwork) string {
+	if !clusterNetwork.IsDualStack() {
+		return ""
+	}
+
+	addresses := make([]string, 0, 2)
+	for _, family := range clusterNetwork.IPFamilies() {
+		addresses = append(addresses, fmt.Sprintf("$(%s | grep -o 'src [^ ]*' | cut -d' ' -f2)", nodeIPRouteTargets[family]))
+	}
+
+	return fmt.Sprintf("for f in /etc/default/kubelet /etc/sysconfig/kubelet; do if [ -d $(dirname $f) ]; then echo KUBELET_EXTRA_ARGS=--node-ip=%s >> $f; fi; done",
+		strings.Join(addresses, ","))
+}
+
+// SetKubeletNodeIPInKubeadmControlPlane adds the prekubeadm command to set the kubelet node-ip in kubeadmControlPlane for dual-stack clusters.
+func SetKubeletNodeIPInKubeadmControlPlane(kcp *controlplanev1.KubeadmControlPlane, cluster *v1alpha1.Cluster) {
+	if command := KubeletNodeIPCommand(&cluster.Spec.ClusterNetwork); command != "" {
+		kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands, command)
+	}
+}
+
+// SetKubeletNodeIPInKubeadmConfigTemplate adds the prekubeadm command to set the kubelet node-ip in kubeadmConfigTemplate for dual-stack clusters.
+func SetKubeletNodeIPInKubeadmConfigTemplate(kct *bootstrapv1.KubeadmConfigTemplate, cluster *v1alpha1.Cluster) {
+	if command := KubeletNodeIPCommand(&cluster.Spec.ClusterNetwork); command != "" {
+		kct.Spec.Template.Spec.PreKubeadmCommands = append(kct.Spec.Template.Spec.PreKubeadmCommands, command)
+	}
+}
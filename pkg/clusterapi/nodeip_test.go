@@ -0,0 +1,76 @@
+package clusterapi_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+const dualStackNodeIPCommand = "for f in /etc/default/kubelet /etc/sysconfig/kubelet; do if [ -d $(dirname $f) ]; then echo KUBELET_EXTRA_ARGS=--node-ip=" +
+	"$(ip -o -4 route get 1.1.1.1 | grep -o 'src [^ ]*' | cut -d' ' -f2)," +
+	"$(ip -o -6 route get 2606:4700:4700::1111 | grep -o 'src [^ ]*' | cut -d' ' -f2) >> $f; fi; done"
+
+func TestKubeletNodeIPCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		clusterNetwork *v1alpha1.ClusterNetwork
+		want           string
+	}{
+		{
+			name: "single-stack",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+			},
+			want: "",
+		},
+		{
+			name: "dual-stack",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"192.168.0.0/16", "fd00::/56"}},
+			},
+			want: dualStackNodeIPCommand,
+		},
+		{
+			name: "dual-stack ipv6 primary",
+			clusterNetwork: &v1alpha1.ClusterNetwork{
+				Pods: v1alpha1.Pods{CidrBlocks: []string{"fd00::/56", "192.168.0.0/16"}},
+			},
+			want: "for f in /etc/default/kubelet /etc/sysconfig/kubelet; do if [ -d $(dirname $f) ]; then echo KUBELET_EXTRA_ARGS=--node-ip=" +
+				"$(ip -o -6 route get 2606:4700:4700::1111 | grep -o 'src [^ ]*' | cut -d' ' -f2)," +
+				"$(ip -o -4 route get 1.1.1.1 | grep -o 'src [^ ]*' | cut -d' ' -f2) >> $f; fi; done",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(clusterapi.KubeletNodeIPCommand(tt.clusterNetwork)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSetKubeletNodeIPInKubeadmControlPlane(t *testing.T) {
+	g := newApiBuilerTest(t)
+	g.clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00::/56"}
+	got := wantKubeadmControlPlane()
+	clusterapi.SetKubeletNodeIPInKubeadmControlPlane(got, g.clusterSpec.Cluster)
+	want := wantKubeadmControlPlane()
+	want.Spec.KubeadmConfigSpec.PreKubeadmCommands = append(want.Spec.KubeadmConfigSpec.PreKubeadmCommands, dualStackNodeIPCommand)
+
+	g.Expect(got).To(Equal(want))
+}
+
+func TestSetKubeletNodeIPInKubeadmConfigTemplate(t *testing.T) {
+	g := newApiBuilerTest(t)
+	got := wantKubeadmConfigTemplate()
+	clusterapi.SetKubeletNodeIPInKubeadmConfigTemplate(got, g.clusterSpec.Cluster)
+	g.Expect(got).To(Equal(wantKubeadmConfigTemplate()))
+
+	g.clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00::/56"}
+	clusterapi.SetKubeletNodeIPInKubeadmConfigTemplate(got, g.clusterSpec.Cluster)
+	want := wantKubeadmConfigTemplate()
+	want.Spec.Template.Spec.PreKubeadmCommands = append(want.Spec.Template.Spec.PreKubeadmCommands, dualStackNodeIPCommand)
+	g.Expect(got).To(Equal(want))
+}
@@ -35,3 +35,58 @@ func SetUpgradeRolloutStrategyInMachineDeployment(md *clusterv1.MachineDeploymen
 		}
 	}
 }
+
+// DefaultUpgradeMaxSurge is the number of extra machines created per machine set during a rolling
+// upgrade when no upgrade rollout strategy is specified.
+const DefaultUpgradeMaxSurge = 1
+
+// ControlPlaneUpgradeMaxSurge returns the number of extra control plane machines created during an
+// upgrade with the upgrade rollout strategy defined in an eksa cluster.
+func ControlPlaneUpgradeMaxSurge(rolloutStrategy *anywherev1.ControlPlaneUpgradeRolloutStrategy) int {
+	if rolloutStrategy == nil {
+		return DefaultUpgradeMaxSurge
+	}
+	if rolloutStrategy.Type == anywherev1.InPlaceStrategyType {
+		return 0
+	}
+	if rolloutStrategy.RollingUpdate == nil {
+		return DefaultUpgradeMaxSurge
+	}
+	return rolloutStrategy.RollingUpdate.MaxSurge
+}
+
+// WorkerNodeGroupUpgradeMaxSurge returns the number of extra machines created for a worker node group
+// during an upgrade with the upgrade rollout strategy defined in an eksa cluster.
+func WorkerNodeGroupUpgradeMaxSurge(rolloutStrategy *anywherev1.WorkerNodesUpgradeRolloutStrategy) int {
+	if rolloutStrategy == nil {
+		return DefaultUpgradeMaxSurge
+	}
+	if rolloutStrategy.Type == anywherev1.InPlaceStrategyType {
+		return 0
+	}
+	if rolloutStrategy.RollingUpdate == nil {
+		return DefaultUpgradeMaxSurge
+	}
+	return rolloutStrategy.RollingUpdate.MaxSurge
+}
+
+// WorkerNodeGroupMaxCount returns the maximum number of machines a worker node group can have, which
+// is the autoscaler max count when autoscaling is configured above the group count.
+func WorkerNodeGroupMaxCount(w anywherev1.WorkerNodeGroupConfiguration) int {
+	count := 0
+	if w.Count != nil {
+		count = *w.Count
+	}
+	if w.AutoScalingConfiguration != nil && w.AutoScalingConfiguration.MaxCount > count {
+		count = w.AutoScalingConfiguration.MaxCount
+	}
+	return count
+}
+
+// AddedMachines returns the number of machines scaling a machine set from currentCount to count adds.
+func AddedMachines(count, currentCount int) int {
+	if count < currentCount {
+		return 0
+	}
+	return count - currentCount
+}
@@ -90,3 +90,111 @@ func TestSetUpgradeRolloutStrategyInMachineDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestControlPlaneUpgradeMaxSurge(t *testing.T) {
+	tests := []struct {
+		name            string
+		rolloutStrategy *anywherev1.ControlPlaneUpgradeRolloutStrategy
+		want            int
+	}{
+		{
+			name: "no upgrade rollout strategy",
+			want: clusterapi.DefaultUpgradeMaxSurge,
+		},
+		{
+			name:            "rolling update without params",
+			rolloutStrategy: &anywherev1.ControlPlaneUpgradeRolloutStrategy{Type: anywherev1.RollingUpdateStrategyType},
+			want:            clusterapi.DefaultUpgradeMaxSurge,
+		},
+		{
+			name: "with maxSurge",
+			rolloutStrategy: &anywherev1.ControlPlaneUpgradeRolloutStrategy{
+				RollingUpdate: &anywherev1.ControlPlaneRollingUpdateParams{MaxSurge: 2},
+			},
+			want: 2,
+		},
+		{
+			name:            "in place",
+			rolloutStrategy: &anywherev1.ControlPlaneUpgradeRolloutStrategy{Type: anywherev1.InPlaceStrategyType},
+			want:            0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clusterapi.ControlPlaneUpgradeMaxSurge(tt.rolloutStrategy))
+		})
+	}
+}
+
+func TestWorkerNodeGroupUpgradeMaxSurge(t *testing.T) {
+	tests := []struct {
+		name            string
+		rolloutStrategy *anywherev1.WorkerNodesUpgradeRolloutStrategy
+		want            int
+	}{
+		{
+			name: "no upgrade rollout strategy",
+			want: clusterapi.DefaultUpgradeMaxSurge,
+		},
+		{
+			name:            "rolling update without params",
+			rolloutStrategy: &anywherev1.WorkerNodesUpgradeRolloutStrategy{Type: anywherev1.RollingUpdateStrategyType},
+			want:            clusterapi.DefaultUpgradeMaxSurge,
+		},
+		{
+			name: "with maxSurge",
+			rolloutStrategy: &anywherev1.WorkerNodesUpgradeRolloutStrategy{
+				RollingUpdate: &anywherev1.WorkerNodesRollingUpdateParams{MaxSurge: 3},
+			},
+			want: 3,
+		},
+		{
+			name:            "in place",
+			rolloutStrategy: &anywherev1.WorkerNodesUpgradeRolloutStrategy{Type: anywherev1.InPlaceStrategyType},
+			want:            0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clusterapi.WorkerNodeGroupUpgradeMaxSurge(tt.rolloutStrategy))
+		})
+	}
+}
+
+func TestWorkerNodeGroupMaxCount(t *testing.T) {
+	count := 2
+	tests := []struct {
+		name string
+		w    anywherev1.WorkerNodeGroupConfiguration
+		want int
+	}{
+		{
+			name: "no count",
+			want: 0,
+		},
+		{
+			name: "count",
+			w:    anywherev1.WorkerNodeGroupConfiguration{Count: &count},
+			want: 2,
+		},
+		{
+			name: "autoscaler max count above count",
+			w: anywherev1.WorkerNodeGroupConfiguration{
+				Count:                    &count,
+				AutoScalingConfiguration: &anywherev1.AutoScalingConfiguration{MinCount: 1, MaxCount: 5},
+			},
+			want: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, clusterapi.WorkerNodeGroupMaxCount(tt.w))
+		})
+	}
+}
+
+func TestAddedMachines(t *testing.T) {
+	assert.Equal(t, 2, clusterapi.AddedMachines(3, 1))
+	assert.Equal(t, 0, clusterapi.AddedMachines(3, 3))
+	assert.Equal(t, 0, clusterapi.AddedMachines(1, 3))
+}
@@ -0,0 +1,165 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta3"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
+)
+
+// CloudStackProvider imports clusters running on CAPC.
+type CloudStackProvider struct{}
+
+// InfrastructureKind returns the kind of the CAPC infrastructure cluster.
+func (CloudStackProvider) InfrastructureKind() string {
+	return "CloudStackCluster"
+}
+
+// SetProviderConfig adds the CloudStackDatacenterConfig and CloudStackMachineConfigs built from the CAPC
+// cluster and machine templates to the config. The availability zones use the CAPC credentials secrets
+// of the failure domains, which EKS Anywhere shares with CAPC.
+func (CloudStackProvider) SetProviderConfig(ctx context.Context, client kubernetes.Reader, capi *CAPICluster, config *cluster.Config) error {
+	cloudstackCluster := &cloudstackv1.CloudStackCluster{}
+	if err := client.Get(ctx, capi.Cluster.Spec.InfrastructureRef.Name, constants.EksaSystemNamespace, cloudstackCluster); err != nil {
+		return fmt.Errorf("reading CloudStackCluster %s: %v", capi.Cluster.Spec.InfrastructureRef.Name, err)
+	}
+
+	config.CloudStackDatacenter = &anywherev1.CloudStackDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.CloudStackDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capi.Cluster.Name,
+			Namespace: config.Cluster.Namespace,
+		},
+	}
+	for _, fd := range cloudstackCluster.Spec.FailureDomains {
+		secret := &corev1.Secret{}
+		if err := client.Get(ctx, fd.ACSEndpoint.Name, constants.EksaSystemNamespace, secret); err != nil {
+			return fmt.Errorf("reading credentials Secret %s of failure domain %s: %v", fd.ACSEndpoint.Name, fd.Name, err)
+		}
+		config.CloudStackDatacenter.Spec.AvailabilityZones = append(config.CloudStackDatacenter.Spec.AvailabilityZones, anywherev1.CloudStackAvailabilityZone{
+			Name:           fd.Name,
+			CredentialsRef: fd.ACSEndpoint.Name,
+			Zone: anywherev1.CloudStackZone{
+				Id:   fd.Zone.ID,
+				Name: fd.Zone.Name,
+				Network: anywherev1.CloudStackResourceIdentifier{
+					Id:   fd.Zone.Network.ID,
+					Name: fd.Zone.Network.Name,
+				},
+			},
+			Domain:                fd.Domain,
+			Account:               fd.Account,
+			ManagementApiEndpoint: string(secret.Data[decoder.APIUrlKey]),
+		})
+	}
+	config.Cluster.Spec.DatacenterRef = anywherev1.Ref{
+		Kind: anywherev1.CloudStackDatacenterKind,
+		Name: config.CloudStackDatacenter.Name,
+	}
+
+	groups, err := machineGroups(ctx, client, capi, config.Cluster)
+	if err != nil {
+		return err
+	}
+	config.CloudStackMachineConfigs = map[string]*anywherev1.CloudStackMachineConfig{}
+	for _, g := range groups {
+		template := &cloudstackv1.CloudStackMachineTemplate{}
+		if err := client.Get(ctx, g.templateName, constants.EksaSystemNamespace, template); err != nil {
+			return fmt.Errorf("reading CloudStackMachineTemplate %s: %v", g.templateName, err)
+		}
+		machineConfig := cloudstackMachineConfig(g, config.Cluster.Namespace, template)
+		config.CloudStackMachineConfigs[machineConfig.Name] = machineConfig
+		g.setRef(anywherev1.CloudStackMachineConfigKind)
+	}
+
+	return nil
+}
+
+// Generate returns the CAPC objects the EKS Anywhere controller would reconcile for the spec.
+func (CloudStackProvider) Generate(ctx context.Context, log logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Generated, error) {
+	cp, err := cloudstack.ControlPlaneSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := cloudstack.WorkersSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return generated(&cp.BaseControlPlane, workers), nil
+}
+
+func cloudstackMachineConfig(g machineGroup, namespace string, template *cloudstackv1.CloudStackMachineTemplate) *anywherev1.CloudStackMachineConfig {
+	spec := template.Spec.Template.Spec
+	machineConfig := &anywherev1.CloudStackMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.CloudStackMachineConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      g.name,
+			Namespace: namespace,
+		},
+		Spec: anywherev1.CloudStackMachineConfigSpec{
+			Template: anywherev1.CloudStackResourceIdentifier{
+				Id:   spec.Template.ID,
+				Name: spec.Template.Name,
+			},
+			ComputeOffering: anywherev1.CloudStackResourceIdentifier{
+				Id:   spec.Offering.ID,
+				Name: spec.Offering.Name,
+			},
+			Users:             g.userConfigurations(),
+			Affinity:          spec.Affinity,
+			AffinityGroupIds:  spec.AffinityGroupIDs,
+			UserCustomDetails: spec.Details,
+			Symlinks:          cloudstackSymlinks(template),
+		},
+	}
+	if disk := spec.DiskOffering; disk.ID != "" || disk.Name != "" {
+		machineConfig.Spec.DiskOffering = &anywherev1.CloudStackResourceDiskOffering{
+			CloudStackResourceIdentifier: anywherev1.CloudStackResourceIdentifier{
+				Id:   disk.ID,
+				Name: disk.Name,
+			},
+			CustomSize: disk.CustomSize,
+			MountPath:  disk.MountPath,
+			Device:     disk.Device,
+			Filesystem: disk.Filesystem,
+			Label:      disk.Label,
+		}
+	}
+
+	return machineConfig
+}
+
+// cloudstackSymlinks returns the symlinks EKS Anywhere records in the annotations of the machine
+// templates as a comma separated list of source:target.
+func cloudstackSymlinks(template *cloudstackv1.CloudStackMachineTemplate) anywherev1.SymlinkMaps {
+	annotation := template.Annotations[fmt.Sprintf("symlinks.%s", constants.CloudstackAnnotationSuffix)]
+	if annotation == "" {
+		return nil
+	}
+	symlinks := anywherev1.SymlinkMaps{}
+	for _, link := range strings.Split(annotation, ",") {
+		if source, target, found := strings.Cut(link, ":"); found {
+			symlinks[source] = target
+		}
+	}
+
+	return symlinks
+}
@@ -0,0 +1,140 @@
+package clusterimport_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cloudstackv1 "sigs.k8s.io/cluster-api-provider-cloudstack/api/v1beta3"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func TestCloudStackProviderSetProviderConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	capi := capiCluster("CloudStackCluster", "CloudStackMachineTemplate")
+	worker := cloudstackMachineTemplate("workload-md-0-1")
+	worker.Annotations = map[string]string{"symlinks." + constants.CloudstackAnnotationSuffix: "/var/log:/data/var/log,/var/lib:/data/var/lib"}
+	worker.Spec.Template.Spec.DiskOffering = cloudstackv1.CloudStackResourceDiskOffering{
+		CloudStackResourceIdentifier: cloudstackv1.CloudStackResourceIdentifier{Name: "Small"},
+		CustomSize:                   20,
+		MountPath:                    "/data",
+		Device:                       "/dev/vdb",
+		Filesystem:                   "ext4",
+		Label:                        "data_disk",
+	}
+	client := test.NewFakeKubeClient(
+		&cloudstackv1.CloudStackCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec: cloudstackv1.CloudStackClusterSpec{
+				FailureDomains: []cloudstackv1.CloudStackFailureDomainSpec{{
+					Name:        "az-1",
+					Zone:        cloudstackv1.CloudStackZoneSpec{Name: "zone1", Network: cloudstackv1.Network{Name: "net1"}},
+					Account:     "admin",
+					Domain:      "ROOT",
+					ACSEndpoint: corev1.SecretReference{Name: "global", Namespace: constants.EksaSystemNamespace},
+				}},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "global", Namespace: constants.EksaSystemNamespace},
+			Data:       map[string][]byte{"api-url": []byte("https://cloudstack.example.com:8080/client/api")},
+		},
+		cloudstackMachineTemplate("workload-control-plane-1"),
+		worker,
+		&bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-1", Namespace: constants.EksaSystemNamespace},
+			Spec: bootstrapv1.KubeadmConfigTemplateSpec{
+				Template: bootstrapv1.KubeadmConfigTemplateResource{
+					Spec: bootstrapv1.KubeadmConfigSpec{
+						Users: []bootstrapv1.User{{Name: "capc", SSHAuthorizedKeys: []string{"ssh-rsa AAAA"}}},
+					},
+				},
+			},
+		},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}}
+
+	g.Expect(clusterimport.CloudStackProvider{}.SetProviderConfig(ctx, client, capi, config)).To(Succeed())
+
+	g.Expect(config.CloudStackDatacenter.Spec.AvailabilityZones).To(Equal([]anywherev1.CloudStackAvailabilityZone{{
+		Name:                  "az-1",
+		CredentialsRef:        "global",
+		Zone:                  anywherev1.CloudStackZone{Name: "zone1", Network: anywherev1.CloudStackResourceIdentifier{Name: "net1"}},
+		Domain:                "ROOT",
+		Account:               "admin",
+		ManagementApiEndpoint: "https://cloudstack.example.com:8080/client/api",
+	}}))
+	g.Expect(config.Cluster.Spec.DatacenterRef).To(Equal(anywherev1.Ref{Kind: anywherev1.CloudStackDatacenterKind, Name: "workload"}))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.CloudStackMachineConfigKind, Name: "workload-cp"},
+	))
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.CloudStackMachineConfigKind, Name: "workload-md-0"},
+	))
+
+	g.Expect(config.CloudStackMachineConfigs).To(HaveLen(2))
+	cp := config.CloudStackMachineConfigs["workload-cp"]
+	g.Expect(cp.Namespace).To(Equal("default"))
+	g.Expect(cp.Spec.Template).To(Equal(anywherev1.CloudStackResourceIdentifier{Name: "rhel8-kube-v1.30"}))
+	g.Expect(cp.Spec.ComputeOffering).To(Equal(anywherev1.CloudStackResourceIdentifier{Name: "m4-large"}))
+	g.Expect(cp.Spec.DiskOffering).To(BeNil())
+	g.Expect(cp.Spec.Symlinks).To(BeNil())
+	md := config.CloudStackMachineConfigs["workload-md-0"]
+	g.Expect(md.Spec.Users).To(Equal([]anywherev1.UserConfiguration{{Name: "capc", SshAuthorizedKeys: []string{"ssh-rsa AAAA"}}}))
+	g.Expect(md.Spec.DiskOffering).To(Equal(&anywherev1.CloudStackResourceDiskOffering{
+		CloudStackResourceIdentifier: anywherev1.CloudStackResourceIdentifier{Name: "Small"},
+		CustomSize:                   20,
+		MountPath:                    "/data",
+		Device:                       "/dev/vdb",
+		Filesystem:                   "ext4",
+		Label:                        "data_disk",
+	}))
+	g.Expect(md.Spec.Symlinks).To(Equal(anywherev1.SymlinkMaps{"/var/log": "/data/var/log", "/var/lib": "/data/var/lib"}))
+}
+
+func TestCloudStackProviderSetProviderConfigMissingCredentials(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient(
+		&cloudstackv1.CloudStackCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec: cloudstackv1.CloudStackClusterSpec{
+				FailureDomains: []cloudstackv1.CloudStackFailureDomainSpec{{
+					Name:        "az-1",
+					ACSEndpoint: corev1.SecretReference{Name: "global", Namespace: constants.EksaSystemNamespace},
+				}},
+			},
+		},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{}}
+
+	err := clusterimport.CloudStackProvider{}.SetProviderConfig(context.Background(), client, capiCluster("CloudStackCluster", "CloudStackMachineTemplate"), config)
+	g.Expect(err).To(MatchError(ContainSubstring("reading credentials Secret global of failure domain az-1")))
+}
+
+func cloudstackMachineTemplate(name string) *cloudstackv1.CloudStackMachineTemplate {
+	return &cloudstackv1.CloudStackMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Spec: cloudstackv1.CloudStackMachineTemplateSpec{
+			Template: cloudstackv1.CloudStackMachineTemplateResource{
+				Spec: cloudstackv1.CloudStackMachineSpec{
+					Template: cloudstackv1.CloudStackResourceIdentifier{Name: "rhel8-kube-v1.30"},
+					Offering: cloudstackv1.CloudStackResourceIdentifier{Name: "m4-large"},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package clusterimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// appliedDiff returns the paths of the fields set in generated with a different value in current.
+// Fields only set in current, like the ones defaulted by the API server, are ignored since the
+// controller applies the generated objects server side and leaves them as they are.
+func appliedDiff(generated, current interface{}) []string {
+	g, err := toUnstructured(generated)
+	if err != nil {
+		return []string{fmt.Sprintf("converting generated object: %v", err)}
+	}
+	c, err := toUnstructured(current)
+	if err != nil {
+		return []string{fmt.Sprintf("converting current object: %v", err)}
+	}
+
+	return diffValue("spec", g, c)
+}
+
+func toUnstructured(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var u interface{}
+	if err := json.Unmarshal(b, &u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func diffValue(path string, generated, current interface{}) []string {
+	switch g := generated.(type) {
+	case map[string]interface{}:
+		c, ok := current.(map[string]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: %s", path, describe(generated, current))}
+		}
+		keys := make([]string, 0, len(g))
+		for k := range g {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var diffs []string
+		for _, k := range keys {
+			diffs = append(diffs, diffValue(path+"."+k, g[k], c[k])...)
+		}
+		return diffs
+	case []interface{}:
+		c, ok := current.([]interface{})
+		if !ok || len(c) != len(g) {
+			return []string{fmt.Sprintf("%s: %s", path, describe(generated, current))}
+		}
+
+		var diffs []string
+		for i := range g {
+			diffs = append(diffs, diffValue(fmt.Sprintf("%s[%d]", path, i), g[i], c[i])...)
+		}
+		return diffs
+	default:
+		if reflect.DeepEqual(generated, current) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: %s", path, describe(generated, current))}
+	}
+}
+
+func describe(generated, current interface{}) string {
+	if current == nil {
+		return fmt.Sprintf("would be set to %v", generated)
+	}
+	if _, ok := generated.(map[string]interface{}); ok {
+		return "would be changed"
+	}
+	if _, ok := generated.([]interface{}); ok {
+		return "would be changed"
+	}
+
+	return fmt.Sprintf("would change from %v to %v", current, generated)
+}
+
+func prefix(p string, diffs []string) []string {
+	for i := range diffs {
+		diffs[i] = p + " " + diffs[i]
+	}
+	return diffs
+}
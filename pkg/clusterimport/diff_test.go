@@ -0,0 +1,69 @@
+package clusterimport
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAppliedDiff(t *testing.T) {
+	type spec struct {
+		Replicas *int              `json:"replicas,omitempty"`
+		Version  string            `json:"version,omitempty"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Args     []string          `json:"args,omitempty"`
+	}
+	replicas := func(r int) *int { return &r }
+
+	tests := []struct {
+		name      string
+		generated spec
+		current   spec
+		want      []string
+	}{
+		{
+			name:      "equal",
+			generated: spec{Replicas: replicas(3), Version: "v1.30.1", Args: []string{"a"}},
+			current:   spec{Replicas: replicas(3), Version: "v1.30.1", Args: []string{"a"}},
+		},
+		{
+			name:      "fields only set in current",
+			generated: spec{Version: "v1.30.1"},
+			current:   spec{Replicas: replicas(3), Version: "v1.30.1", Labels: map[string]string{"a": "b"}},
+		},
+		{
+			name:      "changed and new fields",
+			generated: spec{Replicas: replicas(3), Version: "v1.30.2", Labels: map[string]string{"a": "b"}},
+			current:   spec{Replicas: replicas(3), Version: "v1.30.1"},
+			want: []string{
+				"spec.labels: would be set to map[a:b]",
+				"spec.version: would change from v1.30.1 to v1.30.2",
+			},
+		},
+		{
+			name:      "different list",
+			generated: spec{Args: []string{"a", "b"}},
+			current:   spec{Args: []string{"a"}},
+			want:      []string{"spec.args: would be changed"},
+		},
+		{
+			name:      "changed list element",
+			generated: spec{Args: []string{"a", "c"}},
+			current:   spec{Args: []string{"a", "b"}},
+			want:      []string{"spec.args[1]: would change from b to c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(appliedDiff(tt.generated, tt.current)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(prefix("KubeadmControlPlane test", []string{"spec.replicas: would change from 1 to 3"})).To(Equal(
+		[]string{"KubeadmControlPlane test spec.replicas: would change from 1 to 3"},
+	))
+}
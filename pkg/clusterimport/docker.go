@@ -0,0 +1,69 @@
+package clusterimport
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+)
+
+// DockerProvider imports clusters running on CAPD.
+type DockerProvider struct{}
+
+// InfrastructureKind returns the kind of the CAPD infrastructure cluster.
+func (DockerProvider) InfrastructureKind() string {
+	return "DockerCluster"
+}
+
+// SetProviderConfig adds a DockerDatacenterConfig to the config. Docker clusters don't use machine configs.
+func (DockerProvider) SetProviderConfig(_ context.Context, _ kubernetes.Reader, capi *CAPICluster, config *cluster.Config) error {
+	config.DockerDatacenter = &anywherev1.DockerDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.DockerDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capi.Cluster.Name,
+			Namespace: config.Cluster.Namespace,
+		},
+	}
+	config.Cluster.Spec.DatacenterRef = anywherev1.Ref{
+		Kind: anywherev1.DockerDatacenterKind,
+		Name: config.DockerDatacenter.Name,
+	}
+
+	return nil
+}
+
+// Generate returns the CAPD objects the EKS Anywhere controller would reconcile for the spec.
+func (DockerProvider) Generate(ctx context.Context, log logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Generated, error) {
+	cp, err := docker.ControlPlaneSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := docker.WorkersSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return generated(cp, workers), nil
+}
+
+func generated[C clusterapi.Object[C], M clusterapi.Object[M]](cp *clusterapi.ControlPlane[C, M], workers *clusterapi.Workers[M]) *Generated {
+	g := &Generated{
+		Cluster:             cp.Cluster,
+		KubeadmControlPlane: cp.KubeadmControlPlane,
+		EtcdadmCluster:      cp.EtcdCluster,
+	}
+	for _, group := range workers.Groups {
+		g.MachineDeployments = append(g.MachineDeployments, group.MachineDeployment)
+	}
+
+	return g
+}
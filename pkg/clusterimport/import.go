@@ -0,0 +1,403 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/semver"
+)
+
+// CAPICluster holds the CAPI objects of a cluster to import.
+type CAPICluster struct {
+	Cluster             *clusterv1.Cluster
+	KubeadmControlPlane *controlplanev1.KubeadmControlPlane
+	// EtcdadmCluster is nil for clusters with stacked etcd.
+	EtcdadmCluster     *etcdv1.EtcdadmCluster
+	MachineDeployments []*clusterv1.MachineDeployment
+}
+
+// Generated holds the CAPI objects the EKS Anywhere controller reconciles for a cluster spec.
+type Generated struct {
+	Cluster             *clusterv1.Cluster
+	KubeadmControlPlane *controlplanev1.KubeadmControlPlane
+	EtcdadmCluster      *etcdv1.EtcdadmCluster
+	MachineDeployments  []*clusterv1.MachineDeployment
+}
+
+// Provider synthesizes the EKS Anywhere provider objects of a CAPI cluster and generates
+// the CAPI objects EKS Anywhere would reconcile for them.
+type Provider interface {
+	// InfrastructureKind is the kind of the CAPI infrastructure cluster the provider imports.
+	InfrastructureKind() string
+
+	// SetProviderConfig adds the datacenter and machine configs equivalent to the CAPI objects to the
+	// config and sets the references to them in the EKS Anywhere cluster.
+	SetProviderConfig(ctx context.Context, client kubernetes.Reader, capi *CAPICluster, config *cluster.Config) error
+
+	// Generate returns the CAPI objects the EKS Anywhere controller would reconcile for the spec.
+	Generate(ctx context.Context, log logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Generated, error)
+}
+
+// Options configures the EKS Anywhere cluster synthesized for a CAPI cluster.
+type Options struct {
+	// Namespace is the namespace of the EKS Anywhere objects in the management cluster.
+	Namespace string
+	// ManagementCluster is the name of the EKS Anywhere management cluster adopting the cluster.
+	ManagementCluster string
+}
+
+// Importer adopts CAPI clusters created with the providers EKS Anywhere ships under an EKS Anywhere
+// management cluster.
+type Importer struct {
+	client    kubernetes.Client
+	log       logr.Logger
+	providers map[string]Provider
+}
+
+// NewImporter builds an Importer. The client reads and writes the management cluster, which holds the
+// CAPI objects of the clusters to import.
+func NewImporter(client kubernetes.Client, log logr.Logger, providers ...Provider) *Importer {
+	i := &Importer{
+		client:    client,
+		log:       log,
+		providers: map[string]Provider{},
+	}
+	for _, p := range providers {
+		i.providers[p.InfrastructureKind()] = p
+	}
+
+	return i
+}
+
+// Synthesize reads the CAPI objects of a cluster and returns the equivalent EKS Anywhere config.
+// The EKS Anywhere controller reconciles the CAPI objects of its clusters in the eksa-system namespace,
+// with names derived from the cluster name, and would create new objects, and new machines, for any
+// other. So the CAPI objects must be in the eksa-system namespace and follow the EKS Anywhere naming.
+func (i *Importer) Synthesize(ctx context.Context, clusterName string, opts Options) (*cluster.Config, error) {
+	capi, err := i.readCAPICluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := i.provider(capi)
+	if err != nil {
+		return nil, err
+	}
+
+	management := &anywherev1.Cluster{}
+	if err := i.client.Get(ctx, opts.ManagementCluster, opts.Namespace, management); err != nil {
+		return nil, fmt.Errorf("reading management cluster %s: %v", opts.ManagementCluster, err)
+	}
+
+	eksaCluster, err := synthesizeCluster(capi, management, opts.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	config := &cluster.Config{Cluster: eksaCluster}
+	if err := provider.SetProviderConfig(ctx, i.client, capi, config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Validate checks the config is valid and that the EKS Anywhere controller would reconcile the same CAPI
+// objects that exist for the cluster, so adopting it doesn't roll any machine. It returns the differences
+// otherwise.
+func (i *Importer) Validate(ctx context.Context, config *cluster.Config) error {
+	if err := cluster.SetConfigDefaults(config); err != nil {
+		return fmt.Errorf("setting cluster config defaults: %v", err)
+	}
+	if err := cluster.ValidateConfig(config); err != nil {
+		return fmt.Errorf("validating cluster config: %v", err)
+	}
+
+	capi, err := i.readCAPICluster(ctx, config.Cluster.Name)
+	if err != nil {
+		return err
+	}
+	provider, err := i.provider(capi)
+	if err != nil {
+		return err
+	}
+
+	spec, err := cluster.BuildSpecFromConfig(ctx, i.client, config)
+	if err != nil {
+		return fmt.Errorf("building cluster spec: %v", err)
+	}
+	generated, err := provider.Generate(ctx, i.log, i.client, spec)
+	if err != nil {
+		return fmt.Errorf("generating CAPI objects: %v", err)
+	}
+
+	return compare(capi, generated)
+}
+
+// Adopt creates the EKS Anywhere objects of a validated config in the management cluster, handing the
+// cluster over to the EKS Anywhere controller. The cluster is created last so the controller finds its
+// datacenter and machine configs when it first reconciles it. If an object can't be created, the objects
+// already created are deleted, so the import can be retried. The Snow credentials secret is only created
+// if it doesn't exist.
+func (i *Importer) Adopt(ctx context.Context, config *cluster.Config) error {
+	var created []kubernetes.Object
+	objs := append(config.ChildObjects(), config.Cluster)
+	if secret := config.SnowCredentialsSecret; secret != nil {
+		err := i.client.Get(ctx, secret.Name, secret.Namespace, &corev1.Secret{})
+		if apierrors.IsNotFound(err) {
+			objs = append([]kubernetes.Object{secret}, objs...)
+		} else if err != nil {
+			return fmt.Errorf("reading Secret %s: %v", secret.Name, err)
+		}
+	}
+	for _, obj := range objs {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		i.log.V(3).Info("Creating object", "kind", kind, "name", obj.GetName())
+		if err := i.client.Create(ctx, obj); err != nil {
+			err = fmt.Errorf("creating %s %s: %v", kind, obj.GetName(), err)
+			if rollbackErr := i.deleteObjects(ctx, created); rollbackErr != nil {
+				return fmt.Errorf("%v, deleting the objects already created: %v", err, rollbackErr)
+			}
+			return err
+		}
+		created = append(created, obj)
+	}
+
+	return nil
+}
+
+// deleteObjects deletes objects in the reverse order they were created.
+func (i *Importer) deleteObjects(ctx context.Context, objs []kubernetes.Object) error {
+	for idx := len(objs) - 1; idx >= 0; idx-- {
+		obj := objs[idx]
+		i.log.V(3).Info("Deleting object", "name", obj.GetName())
+		if err := i.client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting %s: %v", obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (i *Importer) provider(capi *CAPICluster) (Provider, error) {
+	ref := capi.Cluster.Spec.InfrastructureRef
+	if ref == nil {
+		return nil, fmt.Errorf("cluster %s doesn't have an infrastructure reference", capi.Cluster.Name)
+	}
+	p, ok := i.providers[ref.Kind]
+	if !ok {
+		return nil, fmt.Errorf("importing clusters with infrastructure %s is not supported, supported infrastructures: %s", ref.Kind, strings.Join(i.SupportedInfrastructures(), ", "))
+	}
+
+	return p, nil
+}
+
+// SupportedInfrastructures returns the sorted kinds of the CAPI infrastructure clusters that can be imported.
+func (i *Importer) SupportedInfrastructures() []string {
+	kinds := make([]string, 0, len(i.providers))
+	for kind := range i.providers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+func (i *Importer) readCAPICluster(ctx context.Context, clusterName string) (*CAPICluster, error) {
+	capi := &CAPICluster{Cluster: &clusterv1.Cluster{}}
+	if err := i.client.Get(ctx, clusterName, constants.EksaSystemNamespace, capi.Cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			if namespace := i.capiClusterNamespace(ctx, clusterName); namespace != "" {
+				return nil, fmt.Errorf("CAPI cluster %s is in namespace %s, only clusters in namespace %s can be imported", clusterName, namespace, constants.EksaSystemNamespace)
+			}
+		}
+		return nil, fmt.Errorf("reading CAPI cluster %s in namespace %s: %v", clusterName, constants.EksaSystemNamespace, err)
+	}
+
+	ref := capi.Cluster.Spec.ControlPlaneRef
+	if ref == nil || ref.Kind != "KubeadmControlPlane" {
+		return nil, fmt.Errorf("cluster %s must use a KubeadmControlPlane to be imported", clusterName)
+	}
+	if ref.Name != clusterName {
+		return nil, fmt.Errorf("KubeadmControlPlane %s must be named %s to be imported", ref.Name, clusterName)
+	}
+	capi.KubeadmControlPlane = &controlplanev1.KubeadmControlPlane{}
+	if err := i.client.Get(ctx, ref.Name, constants.EksaSystemNamespace, capi.KubeadmControlPlane); err != nil {
+		return nil, fmt.Errorf("reading KubeadmControlPlane %s: %v", ref.Name, err)
+	}
+
+	if etcdRef := capi.Cluster.Spec.ManagedExternalEtcdRef; etcdRef != nil {
+		etcdName := clusterapi.EtcdClusterName(clusterName)
+		if etcdRef.Kind != "EtcdadmCluster" {
+			return nil, fmt.Errorf("cluster %s must use an EtcdadmCluster for external etcd to be imported", clusterName)
+		}
+		if etcdRef.Name != etcdName {
+			return nil, fmt.Errorf("EtcdadmCluster %s must be named %s to be imported", etcdRef.Name, etcdName)
+		}
+		capi.EtcdadmCluster = &etcdv1.EtcdadmCluster{}
+		if err := i.client.Get(ctx, etcdRef.Name, constants.EksaSystemNamespace, capi.EtcdadmCluster); err != nil {
+			return nil, fmt.Errorf("reading EtcdadmCluster %s: %v", etcdRef.Name, err)
+		}
+	}
+
+	mds := &clusterv1.MachineDeploymentList{}
+	if err := i.client.List(ctx, mds, kubernetes.ListOptions{Namespace: constants.EksaSystemNamespace}); err != nil {
+		return nil, fmt.Errorf("listing machine deployments: %v", err)
+	}
+	for idx := range mds.Items {
+		if mds.Items[idx].Spec.ClusterName == clusterName {
+			capi.MachineDeployments = append(capi.MachineDeployments, &mds.Items[idx])
+		}
+	}
+	sort.Slice(capi.MachineDeployments, func(a, b int) bool {
+		return capi.MachineDeployments[a].Name < capi.MachineDeployments[b].Name
+	})
+
+	return capi, nil
+}
+
+// capiClusterNamespace returns the namespace of a CAPI cluster outside of eksa-system, empty if there
+// isn't one.
+func (i *Importer) capiClusterNamespace(ctx context.Context, clusterName string) string {
+	clusters := &clusterv1.ClusterList{}
+	if err := i.client.List(ctx, clusters); err != nil {
+		return ""
+	}
+	for _, c := range clusters.Items {
+		if c.Name == clusterName {
+			return c.Namespace
+		}
+	}
+
+	return ""
+}
+
+func synthesizeCluster(capi *CAPICluster, management *anywherev1.Cluster, namespace string) (*anywherev1.Cluster, error) {
+	kcp := capi.KubeadmControlPlane
+	kubeVersion, err := kubernetesVersion(kcp.Spec.Version)
+	if err != nil {
+		return nil, fmt.Errorf("reading KubeadmControlPlane %s version: %v", kcp.Name, err)
+	}
+
+	c := &anywherev1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.ClusterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capi.Cluster.Name,
+			Namespace: namespace,
+		},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: kubeVersion,
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+				Endpoint: &anywherev1.Endpoint{Host: capi.Cluster.Spec.ControlPlaneEndpoint.Host},
+			},
+			ClusterNetwork: anywherev1.ClusterNetwork{
+				// The CNI running in the cluster is left as it is.
+				CNIConfig: &anywherev1.CNIConfig{Cilium: &anywherev1.CiliumConfig{SkipUpgrade: ptr(true)}},
+			},
+			ManagementCluster: anywherev1.ManagementCluster{Name: management.Name},
+			EksaVersion:       management.Spec.EksaVersion,
+			BundlesRef:        management.Spec.BundlesRef,
+		},
+	}
+	if kcp.Spec.Replicas != nil {
+		c.Spec.ControlPlaneConfiguration.Count = int(*kcp.Spec.Replicas)
+	}
+	if etcd := capi.EtcdadmCluster; etcd != nil {
+		c.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{}
+		if etcd.Spec.Replicas != nil {
+			c.Spec.ExternalEtcdConfiguration.Count = int(*etcd.Spec.Replicas)
+		}
+	}
+	if network := capi.Cluster.Spec.ClusterNetwork; network != nil {
+		if network.Pods != nil {
+			c.Spec.ClusterNetwork.Pods.CidrBlocks = network.Pods.CIDRBlocks
+		}
+		if network.Services != nil {
+			c.Spec.ClusterNetwork.Services.CidrBlocks = network.Services.CIDRBlocks
+		}
+	}
+
+	for _, md := range capi.MachineDeployments {
+		name, found := strings.CutPrefix(md.Name, capi.Cluster.Name+"-")
+		if !found {
+			return nil, fmt.Errorf("MachineDeployment %s must be named %s-<worker node group> to be imported", md.Name, capi.Cluster.Name)
+		}
+		wng := anywherev1.WorkerNodeGroupConfiguration{Name: name}
+		if md.Spec.Replicas != nil {
+			wng.Count = ptr(int(*md.Spec.Replicas))
+		}
+		c.Spec.WorkerNodeGroupConfigurations = append(c.Spec.WorkerNodeGroupConfigurations, wng)
+	}
+
+	return c, nil
+}
+
+// kubernetesVersion returns the EKS Anywhere Kubernetes version, major.minor, of a CAPI version.
+func kubernetesVersion(version string) (anywherev1.KubernetesVersion, error) {
+	v, err := semver.New(version)
+	if err != nil {
+		return "", err
+	}
+
+	return anywherev1.KubernetesVersion(fmt.Sprintf("%d.%d", v.Major, v.Minor)), nil
+}
+
+// compare returns an error listing the fields the EKS Anywhere controller would change in the CAPI
+// objects of the cluster. Changes to the KubeadmControlPlane, EtcdadmCluster or MachineDeployment
+// templates, including new machine template names, roll the machines.
+func compare(capi *CAPICluster, generated *Generated) error {
+	var diffs []string
+	diffs = append(diffs, prefix("Cluster "+capi.Cluster.Name, appliedDiff(generated.Cluster.Spec, capi.Cluster.Spec))...)
+
+	kcp := generated.KubeadmControlPlane.DeepCopy()
+	// Like the controller, keep the endpoints of the external etcd members the generated object only
+	// has a placeholder for.
+	currentConfig, generatedConfig := capi.KubeadmControlPlane.Spec.KubeadmConfigSpec.ClusterConfiguration, kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
+	if currentConfig != nil && currentConfig.Etcd.External != nil && generatedConfig != nil && generatedConfig.Etcd.External != nil {
+		generatedConfig.Etcd.External.Endpoints = currentConfig.Etcd.External.Endpoints
+	}
+	diffs = append(diffs, prefix("KubeadmControlPlane "+capi.KubeadmControlPlane.Name, appliedDiff(kcp.Spec, capi.KubeadmControlPlane.Spec))...)
+
+	if generated.EtcdadmCluster != nil && capi.EtcdadmCluster != nil {
+		diffs = append(diffs, prefix("EtcdadmCluster "+capi.EtcdadmCluster.Name, appliedDiff(generated.EtcdadmCluster.Spec, capi.EtcdadmCluster.Spec))...)
+	}
+
+	current := map[string]*clusterv1.MachineDeployment{}
+	for _, md := range capi.MachineDeployments {
+		current[md.Name] = md
+	}
+	for _, md := range generated.MachineDeployments {
+		existing, ok := current[md.Name]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("MachineDeployment %s would be created", md.Name))
+			continue
+		}
+		diffs = append(diffs, prefix("MachineDeployment "+md.Name, appliedDiff(md.Spec, existing.Spec))...)
+	}
+
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("adopting the cluster would change its CAPI objects and could roll its machines:\n%s", strings.Join(diffs, "\n"))
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,337 @@
+package clusterimport_test
+
+import (
+	"context"
+	"testing"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/docker"
+)
+
+type importTest struct {
+	*WithT
+	ctx     context.Context
+	client  kubernetes.Client
+	capi    []kubernetes.Object
+	options clusterimport.Options
+}
+
+func newImportTest(t *testing.T, opts ...func(*cluster.Config)) *importTest {
+	tt := &importTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		options: clusterimport.Options{Namespace: "default", ManagementCluster: "mgmt"},
+	}
+
+	// The CAPI objects of the cluster to import are the ones EKS Anywhere would create for it.
+	config := dockerConfig("workload")
+	for _, opt := range opts {
+		opt(config)
+	}
+	spec, err := cluster.NewSpec(config, test.Bundle(), test.EksdReleases(), test.EKSARelease())
+	tt.Expect(err).NotTo(HaveOccurred())
+	cp, err := docker.ControlPlaneSpec(tt.ctx, test.NewNullLogger(), test.NewFakeKubeClient(), spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	workers, err := docker.WorkersSpec(tt.ctx, test.NewNullLogger(), test.NewFakeKubeClient(), spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.capi = append(cp.Objects(), workers.WorkerObjects()...)
+
+	return tt
+}
+
+func (tt *importTest) withClient() {
+	objs := make([]client.Object, 0, len(tt.capi)+4)
+	for _, o := range tt.capi {
+		objs = append(objs, o)
+	}
+	objs = append(objs,
+		managementCluster(),
+		test.Bundle(),
+		test.EksdRelease("1-19"),
+		test.EKSARelease(),
+	)
+	tt.client = test.NewFakeKubeClient(objs...)
+}
+
+func (tt *importTest) importer() *clusterimport.Importer {
+	return clusterimport.NewImporter(tt.client, test.NewNullLogger(), clusterimport.DockerProvider{})
+}
+
+func (tt *importTest) machineDeployment() *clusterv1.MachineDeployment {
+	for _, o := range tt.capi {
+		if md, ok := o.(*clusterv1.MachineDeployment); ok {
+			return md
+		}
+	}
+	tt.Fail("no machine deployment")
+	return nil
+}
+
+func TestImporterSynthesizeValidateAdopt(t *testing.T) {
+	tt := newImportTest(t)
+	tt.withClient()
+	importer := tt.importer()
+
+	config, err := importer.Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(config.Cluster.Spec.KubernetesVersion).To(Equal(anywherev1.Kube119))
+	tt.Expect(config.Cluster.Spec.ControlPlaneConfiguration.Count).To(Equal(3))
+	tt.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations).To(ConsistOf(
+		anywherev1.WorkerNodeGroupConfiguration{Name: "md-0", Count: ptr(2)},
+	))
+	tt.Expect(config.Cluster.Spec.ManagementCluster.Name).To(Equal("mgmt"))
+	tt.Expect(config.Cluster.Spec.DatacenterRef).To(Equal(anywherev1.Ref{Kind: anywherev1.DockerDatacenterKind, Name: "workload"}))
+	tt.Expect(config.DockerDatacenter.Namespace).To(Equal("default"))
+
+	tt.Expect(importer.Validate(tt.ctx, config)).To(Succeed())
+
+	tt.Expect(importer.Adopt(tt.ctx, config)).To(Succeed())
+	adopted := &anywherev1.Cluster{}
+	tt.Expect(tt.client.Get(tt.ctx, "workload", "default", adopted)).To(Succeed())
+	tt.Expect(adopted.Spec.ManagementCluster.Name).To(Equal("mgmt"))
+	tt.Expect(tt.client.Get(tt.ctx, "workload", "default", &anywherev1.DockerDatacenterConfig{})).To(Succeed())
+}
+
+func TestImporterSynthesizeValidateExternalEtcd(t *testing.T) {
+	tt := newImportTest(t, func(c *cluster.Config) {
+		c.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+	})
+	for _, o := range tt.capi {
+		// The etcdadm controller sets the endpoints of the members.
+		if kcp, ok := o.(*controlplanev1.KubeadmControlPlane); ok {
+			kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.Endpoints = []string{"https://10.0.0.1:2379"}
+		}
+	}
+	tt.withClient()
+	importer := tt.importer()
+
+	config, err := importer.Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(config.Cluster.Spec.ExternalEtcdConfiguration).To(Equal(&anywherev1.ExternalEtcdConfiguration{Count: 3}))
+
+	tt.Expect(importer.Validate(tt.ctx, config)).To(Succeed())
+}
+
+func TestImporterValidateChangedEtcdadmCluster(t *testing.T) {
+	tt := newImportTest(t, func(c *cluster.Config) {
+		c.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+	})
+	for _, o := range tt.capi {
+		if etcd, ok := o.(*etcdv1.EtcdadmCluster); ok {
+			etcd.Spec.EtcdadmConfigSpec.EtcdadmBuiltin = false
+		}
+	}
+	tt.withClient()
+	importer := tt.importer()
+
+	config, err := importer.Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	err = importer.Validate(tt.ctx, config)
+	tt.Expect(err).To(MatchError(ContainSubstring("EtcdadmCluster workload-etcd spec.etcdadmConfigSpec.etcdadmBuiltin: would be set to true")))
+}
+
+func TestImporterValidateChangedMachineDeployment(t *testing.T) {
+	tt := newImportTest(t)
+	md := tt.machineDeployment()
+	md.Spec.Template.Spec.Version = ptr("v1.21.5")
+	tt.withClient()
+	importer := tt.importer()
+
+	config, err := importer.Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	err = importer.Validate(tt.ctx, config)
+	tt.Expect(err).To(MatchError(ContainSubstring("adopting the cluster would change its CAPI objects and could roll its machines")))
+	tt.Expect(err).To(MatchError(ContainSubstring("MachineDeployment workload-md-0 spec.template.spec.version: would change from v1.21.5 to")))
+}
+
+func TestImporterSynthesizeUnsupportedProvider(t *testing.T) {
+	tt := newImportTest(t)
+	tt.withClient()
+	importer := clusterimport.NewImporter(tt.client, test.NewNullLogger(), clusterimport.VSphereProvider{})
+
+	_, err := importer.Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).To(MatchError("importing clusters with infrastructure DockerCluster is not supported, supported infrastructures: VSphereCluster"))
+}
+
+func TestImporterAdoptDeletesCreatedObjectsOnFailure(t *testing.T) {
+	tt := newImportTest(t)
+	tt.withClient()
+	importer := tt.importer()
+
+	config, err := importer.Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).NotTo(HaveOccurred())
+	existing := config.Cluster.DeepCopy()
+	tt.Expect(tt.client.Create(tt.ctx, existing)).To(Succeed())
+
+	err = importer.Adopt(tt.ctx, config)
+	tt.Expect(err).To(MatchError(ContainSubstring("creating Cluster workload")))
+	err = tt.client.Get(tt.ctx, "workload", "default", &anywherev1.DockerDatacenterConfig{})
+	tt.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "DockerDatacenterConfig should be deleted")
+	tt.Expect(tt.client.Get(tt.ctx, "workload", "default", &anywherev1.Cluster{})).To(Succeed())
+
+	tt.Expect(tt.client.Delete(tt.ctx, existing)).To(Succeed())
+	config, err = importer.Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(importer.Adopt(tt.ctx, config)).To(Succeed())
+}
+
+func TestImporterSupportedInfrastructures(t *testing.T) {
+	g := NewWithT(t)
+	importer := clusterimport.NewImporter(test.NewFakeKubeClient(), test.NewNullLogger(), clusterimport.VSphereProvider{}, clusterimport.DockerProvider{})
+
+	g.Expect(importer.SupportedInfrastructures()).To(Equal([]string{"DockerCluster", "VSphereCluster"}))
+}
+
+func TestImporterSynthesizeMissingCluster(t *testing.T) {
+	tt := newImportTest(t)
+	tt.withClient()
+
+	_, err := tt.importer().Synthesize(tt.ctx, "other", tt.options)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading CAPI cluster other in namespace eksa-system")))
+}
+
+func TestImporterSynthesizeClusterInOtherNamespace(t *testing.T) {
+	tt := newImportTest(t)
+	for _, o := range tt.capi {
+		if c, ok := o.(*clusterv1.Cluster); ok {
+			c.Namespace = "capi"
+		}
+	}
+	tt.withClient()
+
+	_, err := tt.importer().Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).To(MatchError("CAPI cluster workload is in namespace capi, only clusters in namespace eksa-system can be imported"))
+}
+
+func TestImporterSynthesizeMissingManagementCluster(t *testing.T) {
+	tt := newImportTest(t)
+	tt.withClient()
+	tt.options.ManagementCluster = "other"
+
+	_, err := tt.importer().Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading management cluster other")))
+}
+
+func TestImporterSynthesizeMachineDeploymentName(t *testing.T) {
+	tt := newImportTest(t)
+	tt.machineDeployment().Name = "md-0"
+	tt.withClient()
+
+	_, err := tt.importer().Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).To(MatchError("MachineDeployment md-0 must be named workload-<worker node group> to be imported"))
+}
+
+func TestImporterSynthesizeKubeadmControlPlaneName(t *testing.T) {
+	tt := newImportTest(t)
+	for _, o := range tt.capi {
+		if c, ok := o.(*clusterv1.Cluster); ok {
+			c.Spec.ControlPlaneRef.Name = "workload-cp"
+		}
+	}
+	tt.withClient()
+
+	_, err := tt.importer().Synthesize(tt.ctx, "workload", tt.options)
+	tt.Expect(err).To(MatchError("KubeadmControlPlane workload-cp must be named workload to be imported"))
+}
+
+func dockerConfig(name string) *cluster.Config {
+	version := test.DevEksaVersion()
+	return &cluster.Config{
+		Cluster: &anywherev1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       anywherev1.ClusterKind,
+				APIVersion: anywherev1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: anywherev1.ClusterSpec{
+				KubernetesVersion: anywherev1.Kube119,
+				ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{
+					Count:    3,
+					Endpoint: &anywherev1.Endpoint{},
+				},
+				ClusterNetwork: anywherev1.ClusterNetwork{
+					Pods:      anywherev1.Pods{CidrBlocks: []string{"192.168.0.0/16"}},
+					Services:  anywherev1.Services{CidrBlocks: []string{"10.96.0.0/12"}},
+					CNIConfig: &anywherev1.CNIConfig{Cilium: &anywherev1.CiliumConfig{}},
+				},
+				WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+					{Name: "md-0", Count: ptr(2)},
+				},
+				DatacenterRef:     anywherev1.Ref{Kind: anywherev1.DockerDatacenterKind, Name: name},
+				ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"},
+				EksaVersion:       &version,
+			},
+		},
+		DockerDatacenter: &anywherev1.DockerDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		},
+	}
+}
+
+// capiCluster returns a CAPI cluster named workload with a control plane and a worker MachineDeployment md-0
+// using the given kinds of infrastructure cluster and machine template.
+func capiCluster(clusterKind, templateKind string) *clusterimport.CAPICluster {
+	return &clusterimport.CAPICluster{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{Kind: clusterKind, Name: "workload"},
+			},
+		},
+		KubeadmControlPlane: &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+					InfrastructureRef: corev1.ObjectReference{Kind: templateKind, Name: "workload-control-plane-1"},
+				},
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{Format: bootstrapv1.Bottlerocket},
+			},
+		},
+		MachineDeployments: []*clusterv1.MachineDeployment{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0", Namespace: constants.EksaSystemNamespace},
+				Spec: clusterv1.MachineDeploymentSpec{
+					ClusterName: "workload",
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							Bootstrap: clusterv1.Bootstrap{
+								ConfigRef: &corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: "workload-md-0-1"},
+							},
+							InfrastructureRef: corev1.ObjectReference{Kind: templateKind, Name: "workload-md-0-1"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func managementCluster() *anywherev1.Cluster {
+	c := dockerConfig("mgmt").Cluster
+	c.Spec.ManagementCluster.Name = "mgmt"
+	return c
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
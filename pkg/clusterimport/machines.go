@@ -0,0 +1,107 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// machineGroup is a group of machines of a CAPI cluster that maps to an EKS Anywhere machine config:
+// the control plane, the external etcd or a worker node group.
+type machineGroup struct {
+	// name is the name of the machine config of the group.
+	name string
+	// templateName is the name of the provider machine template of the machines.
+	templateName string
+	// imageName is the name of the OS image of the machines, if the provider machine template has one.
+	imageName    string
+	users        []bootstrapv1.User
+	bottlerocket bool
+	// ref is the machine group reference of the cluster spec that points to the machine config.
+	ref **anywherev1.Ref
+}
+
+// setRef points the machine group reference of the cluster spec to the machine config of the group.
+func (g machineGroup) setRef(kind string) {
+	*g.ref = &anywherev1.Ref{Kind: kind, Name: g.name}
+}
+
+// osFamily infers the machine OS from the bootstrap format and, for cloud-config, the image name,
+// since the CAPI objects don't record it.
+func (g machineGroup) osFamily() anywherev1.OSFamily {
+	if g.bottlerocket {
+		return anywherev1.Bottlerocket
+	}
+	name := strings.ToLower(g.imageName)
+	if strings.Contains(name, "rhel") || strings.Contains(name, "redhat") {
+		return anywherev1.RedHat
+	}
+
+	return anywherev1.Ubuntu
+}
+
+// userConfigurations returns the users of the machines of the group.
+func (g machineGroup) userConfigurations() []anywherev1.UserConfiguration {
+	var users []anywherev1.UserConfiguration
+	for _, u := range g.users {
+		users = append(users, anywherev1.UserConfiguration{
+			Name:              u.Name,
+			SshAuthorizedKeys: u.SSHAuthorizedKeys,
+		})
+	}
+
+	return users
+}
+
+// machineGroups returns the machine groups of the control plane, the external etcd, if any, and the
+// worker node groups of a CAPI cluster, in that order. The machine configs are named after the cluster
+// and the group.
+func machineGroups(ctx context.Context, client kubernetes.Reader, capi *CAPICluster, c *anywherev1.Cluster) ([]machineGroup, error) {
+	kcp := capi.KubeadmControlPlane
+	groups := []machineGroup{{
+		name:         c.Name + "-cp",
+		templateName: kcp.Spec.MachineTemplate.InfrastructureRef.Name,
+		users:        kcp.Spec.KubeadmConfigSpec.Users,
+		bottlerocket: kcp.Spec.KubeadmConfigSpec.Format == bootstrapv1.Bottlerocket,
+		ref:          &c.Spec.ControlPlaneConfiguration.MachineGroupRef,
+	}}
+
+	if etcd := capi.EtcdadmCluster; etcd != nil {
+		groups = append(groups, machineGroup{
+			name:         c.Name + "-etcd",
+			templateName: etcd.Spec.InfrastructureTemplate.Name,
+			users:        etcd.Spec.EtcdadmConfigSpec.Users,
+			bottlerocket: etcd.Spec.EtcdadmConfigSpec.Format == etcdbootstrapv1.Bottlerocket,
+			ref:          &c.Spec.ExternalEtcdConfiguration.MachineGroupRef,
+		})
+	}
+
+	for idx, md := range capi.MachineDeployments {
+		configRef := md.Spec.Template.Spec.Bootstrap.ConfigRef
+		if configRef == nil {
+			return nil, fmt.Errorf("MachineDeployment %s doesn't have a bootstrap config reference", md.Name)
+		}
+		kubeadmConfig := &bootstrapv1.KubeadmConfigTemplate{}
+		if err := client.Get(ctx, configRef.Name, constants.EksaSystemNamespace, kubeadmConfig); err != nil {
+			return nil, fmt.Errorf("reading KubeadmConfigTemplate %s: %v", configRef.Name, err)
+		}
+
+		wng := &c.Spec.WorkerNodeGroupConfigurations[idx]
+		groups = append(groups, machineGroup{
+			name:         c.Name + "-" + wng.Name,
+			templateName: md.Spec.Template.Spec.InfrastructureRef.Name,
+			users:        kubeadmConfig.Spec.Template.Spec.Users,
+			bottlerocket: kubeadmConfig.Spec.Template.Spec.Format == bootstrapv1.Bottlerocket,
+			ref:          &wng.MachineGroupRef,
+		})
+	}
+
+	return groups, nil
+}
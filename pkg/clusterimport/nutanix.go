@@ -0,0 +1,165 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	nutanixv1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+// NutanixProvider imports clusters running on CAPX.
+type NutanixProvider struct{}
+
+// InfrastructureKind returns the kind of the CAPX infrastructure cluster.
+func (NutanixProvider) InfrastructureKind() string {
+	return "NutanixCluster"
+}
+
+// SetProviderConfig adds the NutanixDatacenterConfig and NutanixMachineConfigs built from the CAPX
+// cluster and machine templates to the config. The datacenter references the CAPX credentials secret,
+// which has the same format as the EKS Anywhere one. Clusters with failure domains aren't supported:
+// EKS Anywhere spreads their workers over one MachineDeployment per failure domain.
+func (NutanixProvider) SetProviderConfig(ctx context.Context, client kubernetes.Reader, capi *CAPICluster, config *cluster.Config) error {
+	nutanixCluster := &nutanixv1.NutanixCluster{}
+	if err := client.Get(ctx, capi.Cluster.Spec.InfrastructureRef.Name, constants.EksaSystemNamespace, nutanixCluster); err != nil {
+		return fmt.Errorf("reading NutanixCluster %s: %v", capi.Cluster.Spec.InfrastructureRef.Name, err)
+	}
+	if len(nutanixCluster.Spec.FailureDomains) > 0 {
+		return fmt.Errorf("NutanixCluster %s has failure domains, importing them is not supported", nutanixCluster.Name)
+	}
+	prismCentral := nutanixCluster.Spec.PrismCentral
+	if prismCentral == nil {
+		return fmt.Errorf("NutanixCluster %s doesn't have a Prism Central endpoint", nutanixCluster.Name)
+	}
+
+	config.NutanixDatacenter = &anywherev1.NutanixDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.NutanixDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capi.Cluster.Name,
+			Namespace: config.Cluster.Namespace,
+		},
+		Spec: anywherev1.NutanixDatacenterConfigSpec{
+			Endpoint: prismCentral.Address,
+			Port:     int(prismCentral.Port),
+			Insecure: prismCentral.Insecure,
+		},
+	}
+	if prismCentral.CredentialRef != nil {
+		config.NutanixDatacenter.Spec.CredentialRef = &anywherev1.Ref{
+			Kind: constants.SecretKind,
+			Name: prismCentral.CredentialRef.Name,
+		}
+	}
+	if trustBundle := prismCentral.AdditionalTrustBundle; trustBundle != nil && trustBundle.Name != "" {
+		configMap := &corev1.ConfigMap{}
+		if err := client.Get(ctx, trustBundle.Name, constants.EksaSystemNamespace, configMap); err != nil {
+			return fmt.Errorf("reading trust bundle ConfigMap %s: %v", trustBundle.Name, err)
+		}
+		config.NutanixDatacenter.Spec.AdditionalTrustBundle = configMap.Data["ca.crt"]
+	}
+	config.Cluster.Spec.DatacenterRef = anywherev1.Ref{
+		Kind: anywherev1.NutanixDatacenterKind,
+		Name: config.NutanixDatacenter.Name,
+	}
+
+	groups, err := machineGroups(ctx, client, capi, config.Cluster)
+	if err != nil {
+		return err
+	}
+	config.NutanixMachineConfigs = map[string]*anywherev1.NutanixMachineConfig{}
+	for _, g := range groups {
+		template := &nutanixv1.NutanixMachineTemplate{}
+		if err := client.Get(ctx, g.templateName, constants.EksaSystemNamespace, template); err != nil {
+			return fmt.Errorf("reading NutanixMachineTemplate %s: %v", g.templateName, err)
+		}
+		machineConfig := nutanixMachineConfig(g, config.Cluster.Namespace, template)
+		config.NutanixMachineConfigs[machineConfig.Name] = machineConfig
+		g.setRef(anywherev1.NutanixMachineConfigKind)
+	}
+
+	return nil
+}
+
+// Generate returns the CAPX objects the EKS Anywhere controller would reconcile for the spec.
+func (NutanixProvider) Generate(ctx context.Context, log logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Generated, error) {
+	cp, err := nutanix.ControlPlaneSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := nutanix.WorkersSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return generated(&cp.BaseControlPlane, workers), nil
+}
+
+func nutanixMachineConfig(g machineGroup, namespace string, template *nutanixv1.NutanixMachineTemplate) *anywherev1.NutanixMachineConfig {
+	spec := template.Spec.Template.Spec
+	if spec.Image.Name != nil {
+		g.imageName = *spec.Image.Name
+	}
+	machineConfig := &anywherev1.NutanixMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.NutanixMachineConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      g.name,
+			Namespace: namespace,
+		},
+		Spec: anywherev1.NutanixMachineConfigSpec{
+			OSFamily:       g.osFamily(),
+			Users:          g.userConfigurations(),
+			VCPUsPerSocket: spec.VCPUsPerSocket,
+			VCPUSockets:    spec.VCPUSockets,
+			MemorySize:     spec.MemorySize,
+			Image:          nutanixResourceIdentifier(spec.Image),
+			Cluster:        nutanixResourceIdentifier(spec.Cluster),
+			SystemDiskSize: spec.SystemDiskSize,
+			BootType:       anywherev1.NutanixBootType(spec.BootType),
+		},
+	}
+	if len(spec.Subnets) > 0 {
+		machineConfig.Spec.Subnet = nutanixResourceIdentifier(spec.Subnets[0])
+	}
+	if spec.Project != nil {
+		project := nutanixResourceIdentifier(*spec.Project)
+		machineConfig.Spec.Project = &project
+	}
+	for _, c := range spec.AdditionalCategories {
+		machineConfig.Spec.AdditionalCategories = append(machineConfig.Spec.AdditionalCategories, anywherev1.NutanixCategoryIdentifier{
+			Key:   c.Key,
+			Value: c.Value,
+		})
+	}
+	for _, gpu := range spec.GPUs {
+		machineConfig.Spec.GPUs = append(machineConfig.Spec.GPUs, anywherev1.NutanixGPUIdentifier{
+			Type:     anywherev1.NutanixGPUIdentifierType(gpu.Type),
+			DeviceID: gpu.DeviceID,
+			Name:     stringValue(gpu.Name),
+		})
+	}
+
+	return machineConfig
+}
+
+func nutanixResourceIdentifier(id nutanixv1.NutanixResourceIdentifier) anywherev1.NutanixResourceIdentifier {
+	return anywherev1.NutanixResourceIdentifier{
+		Type: anywherev1.NutanixIdentifierType(id.Type),
+		UUID: id.UUID,
+		Name: id.Name,
+	}
+}
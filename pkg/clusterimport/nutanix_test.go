@@ -0,0 +1,147 @@
+package clusterimport_test
+
+import (
+	"context"
+	"testing"
+
+	nutanixv1 "github.com/nutanix-cloud-native/cluster-api-provider-nutanix/api/v1beta1"
+	credentials "github.com/nutanix-cloud-native/prism-go-client/environment/credentials"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func TestNutanixProviderSetProviderConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	capi := capiCluster("NutanixCluster", "NutanixMachineTemplate")
+	client := newNutanixClient(t,
+		nutanixCluster(),
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-trust-bundle", Namespace: constants.EksaSystemNamespace},
+			Data:       map[string]string{"ca.crt": "-----BEGIN CERTIFICATE-----"},
+		},
+		nutanixMachineTemplate("workload-control-plane-1", "ubuntu-2204-kube-v1-30"),
+		nutanixMachineTemplate("workload-md-0-1", "rhel-9-kube-v1-30"),
+		&bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-1", Namespace: constants.EksaSystemNamespace},
+			Spec: bootstrapv1.KubeadmConfigTemplateSpec{
+				Template: bootstrapv1.KubeadmConfigTemplateResource{
+					Spec: bootstrapv1.KubeadmConfigSpec{
+						Users: []bootstrapv1.User{{Name: "eksa", SSHAuthorizedKeys: []string{"ssh-rsa AAAA"}}},
+					},
+				},
+			},
+		},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}}
+
+	g.Expect(clusterimport.NutanixProvider{}.SetProviderConfig(ctx, client, capi, config)).To(Succeed())
+
+	g.Expect(config.NutanixDatacenter.Spec).To(Equal(anywherev1.NutanixDatacenterConfigSpec{
+		Endpoint:              "prism.example.com",
+		Port:                  9440,
+		AdditionalTrustBundle: "-----BEGIN CERTIFICATE-----",
+		CredentialRef:         &anywherev1.Ref{Kind: "Secret", Name: "capx-workload"},
+	}))
+	g.Expect(config.Cluster.Spec.DatacenterRef).To(Equal(anywherev1.Ref{Kind: anywherev1.NutanixDatacenterKind, Name: "workload"}))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.NutanixMachineConfigKind, Name: "workload-cp"},
+	))
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.NutanixMachineConfigKind, Name: "workload-md-0"},
+	))
+
+	g.Expect(config.NutanixMachineConfigs).To(HaveLen(2))
+	cp := config.NutanixMachineConfigs["workload-cp"]
+	g.Expect(cp.Namespace).To(Equal("default"))
+	g.Expect(cp.Spec.OSFamily).To(Equal(anywherev1.Bottlerocket))
+	g.Expect(cp.Spec.VCPUsPerSocket).To(Equal(int32(1)))
+	g.Expect(cp.Spec.VCPUSockets).To(Equal(int32(4)))
+	g.Expect(cp.Spec.MemorySize).To(Equal(resource.MustParse("8Gi")))
+	g.Expect(cp.Spec.SystemDiskSize).To(Equal(resource.MustParse("40Gi")))
+	g.Expect(cp.Spec.Image).To(Equal(anywherev1.NutanixResourceIdentifier{Type: "name", Name: ptr("ubuntu-2204-kube-v1-30")}))
+	g.Expect(cp.Spec.Cluster).To(Equal(anywherev1.NutanixResourceIdentifier{Type: "name", Name: ptr("pe-1")}))
+	g.Expect(cp.Spec.Subnet).To(Equal(anywherev1.NutanixResourceIdentifier{Type: "name", Name: ptr("vlan-1")}))
+	worker := config.NutanixMachineConfigs["workload-md-0"]
+	g.Expect(worker.Spec.OSFamily).To(Equal(anywherev1.RedHat))
+	g.Expect(worker.Spec.Users).To(Equal([]anywherev1.UserConfiguration{{Name: "eksa", SshAuthorizedKeys: []string{"ssh-rsa AAAA"}}}))
+}
+
+func TestNutanixProviderSetProviderConfigFailureDomains(t *testing.T) {
+	g := NewWithT(t)
+	nc := nutanixCluster()
+	nc.Spec.FailureDomains = []nutanixv1.NutanixFailureDomain{{Name: "fd-1"}}
+	client := newNutanixClient(t, nc)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{}}
+
+	err := clusterimport.NutanixProvider{}.SetProviderConfig(context.Background(), client, capiCluster("NutanixCluster", "NutanixMachineTemplate"), config)
+	g.Expect(err).To(MatchError("NutanixCluster workload has failure domains, importing them is not supported"))
+}
+
+// newNutanixClient returns a fake client with the CAPX types, which the default scheme of the fake
+// client doesn't have.
+func newNutanixClient(t *testing.T, objs ...client.Object) kubernetes.Client {
+	scheme := runtime.NewScheme()
+	if err := kubernetes.InitScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	return test.NewKubeClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build())
+}
+
+func nutanixCluster() *nutanixv1.NutanixCluster {
+	return &nutanixv1.NutanixCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+		Spec: nutanixv1.NutanixClusterSpec{
+			PrismCentral: &credentials.NutanixPrismEndpoint{
+				Address: "prism.example.com",
+				Port:    9440,
+				AdditionalTrustBundle: &credentials.NutanixTrustBundleReference{
+					Kind: credentials.NutanixTrustBundleKindConfigMap,
+					Name: "workload-trust-bundle",
+				},
+				CredentialRef: &credentials.NutanixCredentialReference{
+					Kind: credentials.SecretKind,
+					Name: "capx-workload",
+				},
+			},
+		},
+	}
+}
+
+func nutanixMachineTemplate(name, image string) *nutanixv1.NutanixMachineTemplate {
+	return &nutanixv1.NutanixMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Spec: nutanixv1.NutanixMachineTemplateSpec{
+			Template: nutanixv1.NutanixMachineTemplateResource{
+				Spec: nutanixv1.NutanixMachineSpec{
+					VCPUsPerSocket: 1,
+					VCPUSockets:    4,
+					MemorySize:     resource.MustParse("8Gi"),
+					SystemDiskSize: resource.MustParse("40Gi"),
+					Image:          nutanixv1.NutanixResourceIdentifier{Type: nutanixv1.NutanixIdentifierName, Name: ptr(image)},
+					Cluster:        nutanixv1.NutanixResourceIdentifier{Type: nutanixv1.NutanixIdentifierName, Name: ptr("pe-1")},
+					Subnets:        []nutanixv1.NutanixResourceIdentifier{{Type: nutanixv1.NutanixIdentifierName, Name: ptr("vlan-1")}},
+				},
+			},
+		},
+	}
+}
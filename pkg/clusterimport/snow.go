@@ -0,0 +1,197 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+// SnowProvider imports clusters running on CAPAS.
+type SnowProvider struct{}
+
+// InfrastructureKind returns the kind of the CAPAS infrastructure cluster.
+func (SnowProvider) InfrastructureKind() string {
+	return "AWSSnowCluster"
+}
+
+// SetProviderConfig adds the SnowDatacenterConfig, SnowMachineConfigs and SnowIPPools built from the
+// CAPAS cluster, machine templates and IP pools to the config. EKS Anywhere reads the Snow credentials from
+// a secret in the namespace of the cluster, so the config also gets a copy of the CAPAS credentials secret.
+func (SnowProvider) SetProviderConfig(ctx context.Context, client kubernetes.Reader, capi *CAPICluster, config *cluster.Config) error {
+	snowCluster := &snowv1.AWSSnowCluster{}
+	if err := client.Get(ctx, capi.Cluster.Spec.InfrastructureRef.Name, constants.EksaSystemNamespace, snowCluster); err != nil {
+		return fmt.Errorf("reading AWSSnowCluster %s: %v", capi.Cluster.Spec.InfrastructureRef.Name, err)
+	}
+	if snowCluster.Spec.IdentityRef == nil {
+		return fmt.Errorf("AWSSnowCluster %s doesn't have an identity reference", snowCluster.Name)
+	}
+	capasSecret := &corev1.Secret{}
+	if err := client.Get(ctx, snowCluster.Spec.IdentityRef.Name, constants.EksaSystemNamespace, capasSecret); err != nil {
+		return fmt.Errorf("reading credentials Secret %s: %v", snowCluster.Spec.IdentityRef.Name, err)
+	}
+	config.SnowCredentialsSecret = &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       constants.SecretKind,
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capasSecret.Name,
+			Namespace: config.Cluster.Namespace,
+		},
+		Type: capasSecret.Type,
+		Data: capasSecret.Data,
+	}
+
+	config.SnowDatacenter = &anywherev1.SnowDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.SnowDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capi.Cluster.Name,
+			Namespace: config.Cluster.Namespace,
+		},
+		Spec: anywherev1.SnowDatacenterConfigSpec{
+			IdentityRef: anywherev1.Ref{
+				Kind: constants.SecretKind,
+				Name: config.SnowCredentialsSecret.Name,
+			},
+		},
+	}
+	config.Cluster.Spec.DatacenterRef = anywherev1.Ref{
+		Kind: anywherev1.SnowDatacenterKind,
+		Name: config.SnowDatacenter.Name,
+	}
+
+	groups, err := machineGroups(ctx, client, capi, config.Cluster)
+	if err != nil {
+		return err
+	}
+	config.SnowMachineConfigs = map[string]*anywherev1.SnowMachineConfig{}
+	config.SnowIPPools = map[string]*anywherev1.SnowIPPool{}
+	for _, g := range groups {
+		template := &snowv1.AWSSnowMachineTemplate{}
+		if err := client.Get(ctx, g.templateName, constants.EksaSystemNamespace, template); err != nil {
+			return fmt.Errorf("reading AWSSnowMachineTemplate %s: %v", g.templateName, err)
+		}
+		machineConfig := snowMachineConfig(g, config.Cluster.Namespace, template)
+		config.SnowMachineConfigs[machineConfig.Name] = machineConfig
+		g.setRef(anywherev1.SnowMachineConfigKind)
+
+		for _, dni := range machineConfig.Spec.Network.DirectNetworkInterfaces {
+			if dni.IPPoolRef == nil || config.SnowIPPools[dni.IPPoolRef.Name] != nil {
+				continue
+			}
+			pool, err := snowIPPool(ctx, client, dni.IPPoolRef.Name, config.Cluster.Namespace)
+			if err != nil {
+				return err
+			}
+			config.SnowIPPools[pool.Name] = pool
+		}
+	}
+
+	return nil
+}
+
+// Generate returns the CAPAS objects the EKS Anywhere controller would reconcile for the spec.
+func (SnowProvider) Generate(ctx context.Context, log logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Generated, error) {
+	cp, err := snow.ControlPlaneSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := snow.WorkersSpec(ctx, log, spec, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return generated(&cp.BaseControlPlane, &workers.BaseWorkers), nil
+}
+
+func snowMachineConfig(g machineGroup, namespace string, template *snowv1.AWSSnowMachineTemplate) *anywherev1.SnowMachineConfig {
+	spec := template.Spec.Template.Spec
+	machineConfig := &anywherev1.SnowMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.SnowMachineConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      g.name,
+			Namespace: namespace,
+		},
+		Spec: anywherev1.SnowMachineConfigSpec{
+			AMIID:            stringValue(spec.AMI.ID),
+			InstanceType:     spec.InstanceType,
+			SshKeyName:       stringValue(spec.SSHKeyName),
+			Devices:          spec.Devices,
+			ContainersVolume: spec.ContainersVolume,
+			NonRootVolumes:   spec.NonRootVolumes,
+			OSFamily:         g.osFamily(),
+		},
+	}
+	if spec.PhysicalNetworkConnectorType != nil {
+		machineConfig.Spec.PhysicalNetworkConnector = anywherev1.PhysicalNetworkConnectorType(*spec.PhysicalNetworkConnectorType)
+	}
+	if spec.OSFamily != nil {
+		machineConfig.Spec.OSFamily = anywherev1.OSFamily(*spec.OSFamily)
+	}
+	for _, dni := range spec.Network.DirectNetworkInterfaces {
+		eksaDNI := anywherev1.SnowDirectNetworkInterface{
+			Index:   dni.Index,
+			VlanID:  dni.VlanID,
+			DHCP:    dni.DHCP,
+			Primary: dni.Primary,
+		}
+		if dni.IPPool != nil {
+			eksaDNI.IPPoolRef = &anywherev1.Ref{Kind: anywherev1.SnowIPPoolKind, Name: dni.IPPool.Name}
+		}
+		machineConfig.Spec.Network.DirectNetworkInterfaces = append(machineConfig.Spec.Network.DirectNetworkInterfaces, eksaDNI)
+	}
+
+	return machineConfig
+}
+
+// snowIPPool builds the SnowIPPool of a CAPAS IP pool. EKS Anywhere names the CAPAS pools after its own.
+func snowIPPool(ctx context.Context, client kubernetes.Reader, name, namespace string) (*anywherev1.SnowIPPool, error) {
+	capasPool := &snowv1.AWSSnowIPPool{}
+	if err := client.Get(ctx, name, constants.EksaSystemNamespace, capasPool); err != nil {
+		return nil, fmt.Errorf("reading AWSSnowIPPool %s: %v", name, err)
+	}
+	pool := &anywherev1.SnowIPPool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.SnowIPPoolKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	for _, p := range capasPool.Spec.IPPools {
+		pool.Spec.Pools = append(pool.Spec.Pools, anywherev1.IPPool{
+			IPStart: stringValue(p.IPStart),
+			IPEnd:   stringValue(p.IPEnd),
+			Subnet:  stringValue(p.Subnet),
+			Gateway: stringValue(p.Gateway),
+		})
+	}
+
+	return pool, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,133 @@
+package clusterimport_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+)
+
+func TestSnowProviderSetProviderConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	capi := capiCluster("AWSSnowCluster", "AWSSnowMachineTemplate")
+	worker := snowMachineTemplate("workload-md-0-1")
+	worker.Spec.Template.Spec.Network.DirectNetworkInterfaces = []snowv1.AWSSnowDirectNetworkInterface{
+		{Index: 1, Primary: true, IPPool: &corev1.ObjectReference{Kind: "AWSSnowIPPool", Name: "ip-pool-1"}},
+	}
+	client := test.NewFakeKubeClient(
+		&snowv1.AWSSnowCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec: snowv1.AWSSnowClusterSpec{
+				IdentityRef: &snowv1.AWSSnowIdentityReference{Kind: snowv1.SecretKind, Name: "workload-snow-credentials"},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-snow-credentials", Namespace: constants.EksaSystemNamespace},
+			Data:       map[string][]byte{"credentials": []byte("creds"), "ca-bundle": []byte("certs")},
+		},
+		&snowv1.AWSSnowIPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "ip-pool-1", Namespace: constants.EksaSystemNamespace},
+			Spec: snowv1.AWSSnowIPPoolSpec{
+				IPPools: []snowv1.IPPool{{
+					IPStart: ptr("10.0.0.10"),
+					IPEnd:   ptr("10.0.0.20"),
+					Subnet:  ptr("10.0.0.0/24"),
+					Gateway: ptr("10.0.0.1"),
+				}},
+			},
+		},
+		snowMachineTemplate("workload-control-plane-1"),
+		worker,
+		&bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-1", Namespace: constants.EksaSystemNamespace},
+		},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}}
+
+	g.Expect(clusterimport.SnowProvider{}.SetProviderConfig(ctx, client, capi, config)).To(Succeed())
+
+	g.Expect(config.SnowCredentialsSecret.Namespace).To(Equal("default"))
+	g.Expect(config.SnowCredentialsSecret.Name).To(Equal("workload-snow-credentials"))
+	g.Expect(config.SnowCredentialsSecret.Data).To(HaveKeyWithValue("credentials", []byte("creds")))
+	g.Expect(config.SnowDatacenter.Spec.IdentityRef).To(Equal(anywherev1.Ref{Kind: "Secret", Name: "workload-snow-credentials"}))
+	g.Expect(config.Cluster.Spec.DatacenterRef).To(Equal(anywherev1.Ref{Kind: anywherev1.SnowDatacenterKind, Name: "workload"}))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.SnowMachineConfigKind, Name: "workload-cp"},
+	))
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.SnowMachineConfigKind, Name: "workload-md-0"},
+	))
+
+	g.Expect(config.SnowMachineConfigs).To(HaveLen(2))
+	cp := config.SnowMachineConfigs["workload-cp"]
+	g.Expect(cp.Namespace).To(Equal("default"))
+	g.Expect(cp.Spec.AMIID).To(Equal("ami-1"))
+	g.Expect(cp.Spec.InstanceType).To(Equal("sbe-c.large"))
+	g.Expect(cp.Spec.SshKeyName).To(Equal("default"))
+	g.Expect(cp.Spec.PhysicalNetworkConnector).To(Equal(anywherev1.PhysicalNetworkConnectorType("SFP_PLUS")))
+	g.Expect(cp.Spec.OSFamily).To(Equal(anywherev1.Ubuntu))
+	md := config.SnowMachineConfigs["workload-md-0"]
+	g.Expect(md.Spec.Network.DirectNetworkInterfaces).To(Equal([]anywherev1.SnowDirectNetworkInterface{
+		{Index: 1, Primary: true, IPPoolRef: &anywherev1.Ref{Kind: anywherev1.SnowIPPoolKind, Name: "ip-pool-1"}},
+	}))
+
+	g.Expect(config.SnowIPPools).To(HaveLen(1))
+	pool := config.SnowIPPools["ip-pool-1"]
+	g.Expect(pool.Namespace).To(Equal("default"))
+	g.Expect(pool.Spec.Pools).To(Equal([]anywherev1.IPPool{{
+		IPStart: "10.0.0.10",
+		IPEnd:   "10.0.0.20",
+		Subnet:  "10.0.0.0/24",
+		Gateway: "10.0.0.1",
+	}}))
+}
+
+func TestSnowProviderSetProviderConfigMissingCredentials(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient(
+		&snowv1.AWSSnowCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec: snowv1.AWSSnowClusterSpec{
+				IdentityRef: &snowv1.AWSSnowIdentityReference{Kind: snowv1.SecretKind, Name: "workload-snow-credentials"},
+			},
+		},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{}}
+
+	err := clusterimport.SnowProvider{}.SetProviderConfig(context.Background(), client, capiCluster("AWSSnowCluster", "AWSSnowMachineTemplate"), config)
+	g.Expect(err).To(MatchError(ContainSubstring("reading credentials Secret workload-snow-credentials")))
+}
+
+func snowMachineTemplate(name string) *snowv1.AWSSnowMachineTemplate {
+	osFamily := snowv1.Ubuntu
+	return &snowv1.AWSSnowMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Spec: snowv1.AWSSnowMachineTemplateSpec{
+			Template: snowv1.AWSSnowMachineTemplateResource{
+				Spec: snowv1.AWSSnowMachineSpec{
+					AMI:                          snowv1.AWSResourceReference{ID: ptr("ami-1")},
+					InstanceType:                 "sbe-c.large",
+					SSHKeyName:                   ptr("default"),
+					PhysicalNetworkConnectorType: ptr("SFP_PLUS"),
+					OSFamily:                     &osFamily,
+				},
+			},
+		},
+	}
+}
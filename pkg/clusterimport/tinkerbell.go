@@ -0,0 +1,190 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// TinkerbellProvider imports clusters running on CAPT.
+type TinkerbellProvider struct{}
+
+// InfrastructureKind returns the kind of the CAPT infrastructure cluster.
+func (TinkerbellProvider) InfrastructureKind() string {
+	return "TinkerbellCluster"
+}
+
+// SetProviderConfig adds the TinkerbellDatacenterConfig, TinkerbellMachineConfigs and
+// TinkerbellTemplateConfigs built from the CAPT cluster and machine templates to the config. The CAPT
+// objects don't record the Tinkerbell stack, so the datacenter is a copy of the one of the management
+// cluster, which runs the stack that provisions the machines. The workflow template of each machine
+// template becomes a TinkerbellTemplateConfig, so the machines are provisioned the same way.
+func (TinkerbellProvider) SetProviderConfig(ctx context.Context, client kubernetes.Reader, capi *CAPICluster, config *cluster.Config) error {
+	tinkerbellCluster := &tinkerbellv1.TinkerbellCluster{}
+	if err := client.Get(ctx, capi.Cluster.Spec.InfrastructureRef.Name, constants.EksaSystemNamespace, tinkerbellCluster); err != nil {
+		return fmt.Errorf("reading TinkerbellCluster %s: %v", capi.Cluster.Spec.InfrastructureRef.Name, err)
+	}
+
+	management := &anywherev1.Cluster{}
+	if err := client.Get(ctx, config.Cluster.Spec.ManagementCluster.Name, config.Cluster.Namespace, management); err != nil {
+		return fmt.Errorf("reading management cluster %s: %v", config.Cluster.Spec.ManagementCluster.Name, err)
+	}
+	if management.Spec.DatacenterRef.Kind != anywherev1.TinkerbellDatacenterKind {
+		return fmt.Errorf("management cluster %s doesn't run on Tinkerbell, Tinkerbell clusters can only be imported by a Tinkerbell management cluster", management.Name)
+	}
+	managementDatacenter := &anywherev1.TinkerbellDatacenterConfig{}
+	if err := client.Get(ctx, management.Spec.DatacenterRef.Name, management.Namespace, managementDatacenter); err != nil {
+		return fmt.Errorf("reading TinkerbellDatacenterConfig %s: %v", management.Spec.DatacenterRef.Name, err)
+	}
+
+	config.TinkerbellDatacenter = &anywherev1.TinkerbellDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.TinkerbellDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capi.Cluster.Name,
+			Namespace: config.Cluster.Namespace,
+		},
+		Spec: *managementDatacenter.Spec.DeepCopy(),
+	}
+	config.Cluster.Spec.DatacenterRef = anywherev1.Ref{
+		Kind: anywherev1.TinkerbellDatacenterKind,
+		Name: config.TinkerbellDatacenter.Name,
+	}
+
+	groups, err := machineGroups(ctx, client, capi, config.Cluster)
+	if err != nil {
+		return err
+	}
+	config.TinkerbellMachineConfigs = map[string]*anywherev1.TinkerbellMachineConfig{}
+	config.TinkerbellTemplateConfigs = map[string]*anywherev1.TinkerbellTemplateConfig{}
+	for _, g := range groups {
+		template := &tinkerbellv1.TinkerbellMachineTemplate{}
+		if err := client.Get(ctx, g.templateName, constants.EksaSystemNamespace, template); err != nil {
+			return fmt.Errorf("reading TinkerbellMachineTemplate %s: %v", g.templateName, err)
+		}
+		g.imageName = tinkerbellCluster.Spec.ImageLookupFormat
+		machineConfig := tinkerbellMachineConfig(g, config.Cluster.Namespace, template)
+
+		if override := template.Spec.Template.Spec.TemplateOverride; override != "" {
+			templateConfig, err := tinkerbellTemplateConfig(g.name, config.Cluster.Namespace, override)
+			if err != nil {
+				return fmt.Errorf("reading the workflow template of TinkerbellMachineTemplate %s: %v", template.Name, err)
+			}
+			config.TinkerbellTemplateConfigs[templateConfig.Name] = templateConfig
+			machineConfig.Spec.TemplateRef = anywherev1.Ref{
+				Kind: anywherev1.TinkerbellTemplateConfigKind,
+				Name: templateConfig.Name,
+			}
+		}
+
+		config.TinkerbellMachineConfigs[machineConfig.Name] = machineConfig
+		g.setRef(anywherev1.TinkerbellMachineConfigKind)
+	}
+
+	return nil
+}
+
+// Generate returns the CAPT objects the EKS Anywhere controller would reconcile for the spec.
+func (TinkerbellProvider) Generate(ctx context.Context, log logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Generated, error) {
+	cp, err := tinkerbell.ControlPlaneSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := tinkerbell.WorkersSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return generated(&cp.BaseControlPlane, workers), nil
+}
+
+func tinkerbellMachineConfig(g machineGroup, namespace string, template *tinkerbellv1.TinkerbellMachineTemplate) *anywherev1.TinkerbellMachineConfig {
+	machineConfig := &anywherev1.TinkerbellMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.TinkerbellMachineConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      g.name,
+			Namespace: namespace,
+		},
+		Spec: anywherev1.TinkerbellMachineConfigSpec{
+			OSFamily: g.osFamily(),
+			Users:    g.userConfigurations(),
+		},
+	}
+
+	affinity := template.Spec.Template.Spec.HardwareAffinity
+	if affinity == nil {
+		return machineConfig
+	}
+	eksaAffinity := &anywherev1.HardwareAffinity{}
+	for _, term := range affinity.Required {
+		eksaAffinity.Required = append(eksaAffinity.Required, anywherev1.HardwareAffinityTerm{
+			LabelSelector: withoutEligibleHardwareRequirement(term.LabelSelector),
+		})
+	}
+	for _, term := range affinity.Preferred {
+		eksaAffinity.Preferred = append(eksaAffinity.Preferred, anywherev1.WeightedHardwareAffinityTerm{
+			Weight:               term.Weight,
+			HardwareAffinityTerm: anywherev1.HardwareAffinityTerm{LabelSelector: term.HardwareAffinityTerm.LabelSelector},
+		})
+	}
+
+	// A hardware selector is written as a single required term with only labels.
+	if len(eksaAffinity.Required) == 1 && len(eksaAffinity.Preferred) == 0 &&
+		len(eksaAffinity.Required[0].LabelSelector.MatchExpressions) == 0 {
+		machineConfig.Spec.HardwareSelector = eksaAffinity.Required[0].LabelSelector.MatchLabels
+	} else {
+		machineConfig.Spec.HardwareAffinity = eksaAffinity
+	}
+
+	return machineConfig
+}
+
+// withoutEligibleHardwareRequirement removes the requirement EKS Anywhere adds to every required term
+// to exclude hardware that isn't eligible for provisioning.
+func withoutEligibleHardwareRequirement(selector metav1.LabelSelector) metav1.LabelSelector {
+	selector = *selector.DeepCopy()
+	var expressions []metav1.LabelSelectorRequirement
+	for _, e := range selector.MatchExpressions {
+		if e.Key == hardware.ValidationStatusLabel && e.Operator == metav1.LabelSelectorOpNotIn {
+			continue
+		}
+		expressions = append(expressions, e)
+	}
+	selector.MatchExpressions = expressions
+
+	return selector
+}
+
+func tinkerbellTemplateConfig(name, namespace, workflow string) (*anywherev1.TinkerbellTemplateConfig, error) {
+	templateConfig := &anywherev1.TinkerbellTemplateConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.TinkerbellTemplateConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	if err := yaml.Unmarshal([]byte(workflow), &templateConfig.Spec.Template); err != nil {
+		return nil, err
+	}
+
+	return templateConfig, nil
+}
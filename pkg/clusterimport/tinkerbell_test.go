@@ -0,0 +1,160 @@
+package clusterimport_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+const tinkerbellWorkflow = `version: "0.1"
+id: ""
+name: workload
+global_timeout: 6000
+tasks:
+- name: workload
+  worker: '{{.device_1}}'
+  volumes:
+  - /dev:/dev
+  actions:
+  - name: stream-image
+    image: public.ecr.aws/eks-anywhere/image2disk:latest
+    timeout: 600
+    environment:
+      IMG_URL: http://10.0.0.2:8080/ubuntu.gz
+`
+
+func TestTinkerbellProviderSetProviderConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	capi := capiCluster("TinkerbellCluster", "TinkerbellMachineTemplate")
+	cp := tinkerbellMachineTemplate("workload-control-plane-1", &tinkerbellv1.HardwareAffinity{
+		Required: []tinkerbellv1.HardwareAffinityTerm{{LabelSelector: metav1.LabelSelector{
+			MatchLabels:      map[string]string{"type": "cp"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{eligibleHardwareRequirement()},
+		}}},
+	})
+	worker := tinkerbellMachineTemplate("workload-md-0-1", &tinkerbellv1.HardwareAffinity{
+		Required: []tinkerbellv1.HardwareAffinityTerm{{LabelSelector: metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "type", Operator: metav1.LabelSelectorOpIn, Values: []string{"worker", "large-worker"}},
+				eligibleHardwareRequirement(),
+			},
+		}}},
+	})
+	client := test.NewFakeKubeClient(
+		&tinkerbellv1.TinkerbellCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec:       tinkerbellv1.TinkerbellClusterSpec{ImageLookupFormat: "ubuntu-2204-kube-v1.30.raw.gz"},
+		},
+		&anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"},
+			Spec: anywherev1.ClusterSpec{
+				DatacenterRef: anywherev1.Ref{Kind: anywherev1.TinkerbellDatacenterKind, Name: "mgmt"},
+			},
+		},
+		&anywherev1.TinkerbellDatacenterConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"},
+			Spec:       anywherev1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "10.0.0.2"},
+		},
+		cp,
+		worker,
+		&bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-1", Namespace: constants.EksaSystemNamespace},
+		},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			ManagementCluster:             anywherev1.ManagementCluster{Name: "mgmt"},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}}
+
+	g.Expect(clusterimport.TinkerbellProvider{}.SetProviderConfig(ctx, client, capi, config)).To(Succeed())
+
+	g.Expect(config.TinkerbellDatacenter.Name).To(Equal("workload"))
+	g.Expect(config.TinkerbellDatacenter.Spec).To(Equal(anywherev1.TinkerbellDatacenterConfigSpec{TinkerbellIP: "10.0.0.2"}))
+	g.Expect(config.Cluster.Spec.DatacenterRef).To(Equal(anywherev1.Ref{Kind: anywherev1.TinkerbellDatacenterKind, Name: "workload"}))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.TinkerbellMachineConfigKind, Name: "workload-cp"},
+	))
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.TinkerbellMachineConfigKind, Name: "workload-md-0"},
+	))
+
+	g.Expect(config.TinkerbellMachineConfigs).To(HaveLen(2))
+	cpConfig := config.TinkerbellMachineConfigs["workload-cp"]
+	g.Expect(cpConfig.Namespace).To(Equal("default"))
+	g.Expect(cpConfig.Spec.OSFamily).To(Equal(anywherev1.Bottlerocket))
+	g.Expect(cpConfig.Spec.HardwareSelector).To(Equal(anywherev1.HardwareSelector{"type": "cp"}))
+	g.Expect(cpConfig.Spec.HardwareAffinity).To(BeNil())
+	g.Expect(cpConfig.Spec.TemplateRef).To(Equal(anywherev1.Ref{Kind: anywherev1.TinkerbellTemplateConfigKind, Name: "workload-cp"}))
+	workerConfig := config.TinkerbellMachineConfigs["workload-md-0"]
+	g.Expect(workerConfig.Spec.OSFamily).To(Equal(anywherev1.Ubuntu))
+	g.Expect(workerConfig.Spec.HardwareSelector).To(BeEmpty())
+	g.Expect(workerConfig.Spec.HardwareAffinity).To(Equal(&anywherev1.HardwareAffinity{
+		Required: []anywherev1.HardwareAffinityTerm{{LabelSelector: metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "type", Operator: metav1.LabelSelectorOpIn, Values: []string{"worker", "large-worker"}},
+			},
+		}}},
+	}))
+
+	g.Expect(config.TinkerbellTemplateConfigs).To(HaveLen(2))
+	templateConfig := config.TinkerbellTemplateConfigs["workload-md-0"]
+	g.Expect(templateConfig.Namespace).To(Equal("default"))
+	g.Expect(templateConfig.ToTemplateString()).To(MatchYAML(tinkerbellWorkflow))
+}
+
+func TestTinkerbellProviderSetProviderConfigManagementNotTinkerbell(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient(
+		&tinkerbellv1.TinkerbellCluster{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace}},
+		&anywherev1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "mgmt", Namespace: "default"},
+			Spec: anywherev1.ClusterSpec{
+				DatacenterRef: anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "mgmt"},
+			},
+		},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec:       anywherev1.ClusterSpec{ManagementCluster: anywherev1.ManagementCluster{Name: "mgmt"}},
+	}}
+
+	err := clusterimport.TinkerbellProvider{}.SetProviderConfig(context.Background(), client, capiCluster("TinkerbellCluster", "TinkerbellMachineTemplate"), config)
+	g.Expect(err).To(MatchError(ContainSubstring("management cluster mgmt doesn't run on Tinkerbell")))
+}
+
+func tinkerbellMachineTemplate(name string, affinity *tinkerbellv1.HardwareAffinity) *tinkerbellv1.TinkerbellMachineTemplate {
+	return &tinkerbellv1.TinkerbellMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Spec: tinkerbellv1.TinkerbellMachineTemplateSpec{
+			Template: tinkerbellv1.TinkerbellMachineTemplateResource{
+				Spec: tinkerbellv1.TinkerbellMachineSpec{
+					HardwareAffinity: affinity,
+					TemplateOverride: tinkerbellWorkflow,
+				},
+			},
+		},
+	}
+}
+
+func eligibleHardwareRequirement() metav1.LabelSelectorRequirement {
+	return metav1.LabelSelectorRequirement{
+		Key:      hardware.ValidationStatusLabel,
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{hardware.ValidationPending, hardware.ValidationQuarantined},
+	}
+}
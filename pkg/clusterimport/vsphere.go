@@ -0,0 +1,130 @@
+package clusterimport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+)
+
+// VSphereProvider imports clusters running on CAPV.
+type VSphereProvider struct{}
+
+// InfrastructureKind returns the kind of the CAPV infrastructure cluster.
+func (VSphereProvider) InfrastructureKind() string {
+	return "VSphereCluster"
+}
+
+// SetProviderConfig adds the VSphereDatacenterConfig and VSphereMachineConfigs built from the CAPV
+// cluster and machine templates to the config.
+func (VSphereProvider) SetProviderConfig(ctx context.Context, client kubernetes.Reader, capi *CAPICluster, config *cluster.Config) error {
+	vsphereCluster := &vspherev1.VSphereCluster{}
+	if err := client.Get(ctx, capi.Cluster.Spec.InfrastructureRef.Name, constants.EksaSystemNamespace, vsphereCluster); err != nil {
+		return fmt.Errorf("reading VSphereCluster %s: %v", capi.Cluster.Spec.InfrastructureRef.Name, err)
+	}
+
+	groups, err := machineGroups(ctx, client, capi, config.Cluster)
+	if err != nil {
+		return err
+	}
+	config.VSphereMachineConfigs = map[string]*anywherev1.VSphereMachineConfig{}
+	for _, g := range groups {
+		template := &vspherev1.VSphereMachineTemplate{}
+		if err := client.Get(ctx, g.templateName, constants.EksaSystemNamespace, template); err != nil {
+			return fmt.Errorf("reading VSphereMachineTemplate %s: %v", g.templateName, err)
+		}
+		if config.VSphereDatacenter == nil {
+			if err := setVSphereDatacenter(capi, vsphereCluster, template, config); err != nil {
+				return err
+			}
+		}
+		machineConfig := vsphereMachineConfig(g, config.Cluster.Namespace, template)
+		config.VSphereMachineConfigs[machineConfig.Name] = machineConfig
+		g.setRef(anywherev1.VSphereMachineConfigKind)
+	}
+
+	return nil
+}
+
+// setVSphereDatacenter sets the VSphereDatacenterConfig built from the CAPV cluster and the machine
+// template of the control plane.
+func setVSphereDatacenter(capi *CAPICluster, vsphereCluster *vspherev1.VSphereCluster, cpTemplate *vspherev1.VSphereMachineTemplate, config *cluster.Config) error {
+	clone := cpTemplate.Spec.Template.Spec.VirtualMachineCloneSpec
+	if len(clone.Network.Devices) == 0 {
+		return fmt.Errorf("VSphereMachineTemplate %s doesn't have a network device", cpTemplate.Name)
+	}
+	config.VSphereDatacenter = &anywherev1.VSphereDatacenterConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.VSphereDatacenterKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capi.Cluster.Name,
+			Namespace: config.Cluster.Namespace,
+		},
+		Spec: anywherev1.VSphereDatacenterConfigSpec{
+			Datacenter: clone.Datacenter,
+			Network:    clone.Network.Devices[0].NetworkName,
+			Server:     vsphereCluster.Spec.Server,
+			Thumbprint: vsphereCluster.Spec.Thumbprint,
+			Insecure:   vsphereCluster.Spec.Thumbprint == "",
+		},
+	}
+	config.Cluster.Spec.DatacenterRef = anywherev1.Ref{
+		Kind: anywherev1.VSphereDatacenterKind,
+		Name: config.VSphereDatacenter.Name,
+	}
+
+	return nil
+}
+
+// Generate returns the CAPV objects the EKS Anywhere controller would reconcile for the spec.
+func (VSphereProvider) Generate(ctx context.Context, log logr.Logger, client kubernetes.Client, spec *cluster.Spec) (*Generated, error) {
+	cp, err := vsphere.ControlPlaneSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := vsphere.WorkersSpec(ctx, log, client, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return generated(&cp.BaseControlPlane, workers), nil
+}
+
+func vsphereMachineConfig(g machineGroup, namespace string, template *vspherev1.VSphereMachineTemplate) *anywherev1.VSphereMachineConfig {
+	clone := template.Spec.Template.Spec.VirtualMachineCloneSpec
+	g.imageName = clone.Template
+	return &anywherev1.VSphereMachineConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       anywherev1.VSphereMachineConfigKind,
+			APIVersion: anywherev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      g.name,
+			Namespace: namespace,
+		},
+		Spec: anywherev1.VSphereMachineConfigSpec{
+			DiskGiB:           int(clone.DiskGiB),
+			Datastore:         clone.Datastore,
+			Folder:            clone.Folder,
+			NumCPUs:           int(clone.NumCPUs),
+			MemoryMiB:         int(clone.MemoryMiB),
+			OSFamily:          g.osFamily(),
+			ResourcePool:      clone.ResourcePool,
+			StoragePolicyName: clone.StoragePolicyName,
+			Template:          clone.Template,
+			TagIDs:            clone.TagIDs,
+			CloneMode:         anywherev1.CloneMode(clone.CloneMode),
+			Users:             g.userConfigurations(),
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package clusterimport_test
+
+import (
+	"context"
+	"testing"
+
+	etcdbootstrapv1 "github.com/aws/etcdadm-bootstrap-provider/api/v1beta1"
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	vspherev1 "sigs.k8s.io/cluster-api-provider-vsphere/apis/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta1"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterimport"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func TestVSphereProviderSetProviderConfig(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	capi := vsphereCAPICluster()
+	client := test.NewFakeKubeClient(
+		&vspherev1.VSphereCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace},
+			Spec:       vspherev1.VSphereClusterSpec{Server: "vcenter.example.com", Thumbprint: "AB:CD"},
+		},
+		vsphereMachineTemplate("workload-control-plane-1", "ubuntu-2204-kube-v1-30"),
+		vsphereMachineTemplate("workload-md-0-1", "rhel-9-kube-v1-30"),
+		&bootstrapv1.KubeadmConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-1", Namespace: constants.EksaSystemNamespace},
+			Spec: bootstrapv1.KubeadmConfigTemplateSpec{
+				Template: bootstrapv1.KubeadmConfigTemplateResource{
+					Spec: bootstrapv1.KubeadmConfigSpec{
+						Users: []bootstrapv1.User{{Name: "capv", SSHAuthorizedKeys: []string{"ssh-rsa AAAA"}}},
+					},
+				},
+			},
+		},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}}
+
+	g.Expect(clusterimport.VSphereProvider{}.SetProviderConfig(ctx, client, capi, config)).To(Succeed())
+
+	g.Expect(config.VSphereDatacenter.Spec).To(Equal(anywherev1.VSphereDatacenterConfigSpec{
+		Datacenter: "SDDC-Datacenter",
+		Network:    "/SDDC-Datacenter/network/sddc-cgw-network-1",
+		Server:     "vcenter.example.com",
+		Thumbprint: "AB:CD",
+	}))
+	g.Expect(config.Cluster.Spec.DatacenterRef).To(Equal(anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: "workload"}))
+	g.Expect(config.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "workload-cp"},
+	))
+	g.Expect(config.Cluster.Spec.WorkerNodeGroupConfigurations[0].MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "workload-md-0"},
+	))
+
+	g.Expect(config.VSphereMachineConfigs).To(HaveLen(2))
+	cp := config.VSphereMachineConfigs["workload-cp"]
+	g.Expect(cp.Namespace).To(Equal("default"))
+	g.Expect(cp.Spec.OSFamily).To(Equal(anywherev1.Bottlerocket))
+	g.Expect(cp.Spec.Template).To(Equal("ubuntu-2204-kube-v1-30"))
+	g.Expect(cp.Spec.NumCPUs).To(Equal(2))
+	g.Expect(cp.Spec.MemoryMiB).To(Equal(8192))
+	g.Expect(cp.Spec.DiskGiB).To(Equal(25))
+	g.Expect(cp.Spec.Datastore).To(Equal("/SDDC-Datacenter/datastore/WorkloadDatastore"))
+	worker := config.VSphereMachineConfigs["workload-md-0"]
+	g.Expect(worker.Spec.OSFamily).To(Equal(anywherev1.RedHat))
+	g.Expect(worker.Spec.Users).To(Equal([]anywherev1.UserConfiguration{{Name: "capv", SshAuthorizedKeys: []string{"ssh-rsa AAAA"}}}))
+}
+
+func TestVSphereProviderSetProviderConfigExternalEtcd(t *testing.T) {
+	g := NewWithT(t)
+	capi := vsphereCAPICluster()
+	capi.MachineDeployments = nil
+	capi.EtcdadmCluster = &etcdv1.EtcdadmCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload-etcd", Namespace: constants.EksaSystemNamespace},
+		Spec: etcdv1.EtcdadmClusterSpec{
+			InfrastructureTemplate: corev1.ObjectReference{Kind: "VSphereMachineTemplate", Name: "workload-etcd-1"},
+			EtcdadmConfigSpec: etcdbootstrapv1.EtcdadmConfigSpec{
+				Format: etcdbootstrapv1.CloudConfig,
+				Users:  []bootstrapv1.User{{Name: "capv", SSHAuthorizedKeys: []string{"ssh-rsa AAAA"}}},
+			},
+		},
+	}
+	client := test.NewFakeKubeClient(
+		&vspherev1.VSphereCluster{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace}},
+		vsphereMachineTemplate("workload-control-plane-1", "bottlerocket-kube-v1-30"),
+		vsphereMachineTemplate("workload-etcd-1", "ubuntu-2204-kube-v1-30"),
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			ExternalEtcdConfiguration: &anywherev1.ExternalEtcdConfiguration{Count: 3},
+		},
+	}}
+
+	g.Expect(clusterimport.VSphereProvider{}.SetProviderConfig(context.Background(), client, capi, config)).To(Succeed())
+	g.Expect(config.Cluster.Spec.ExternalEtcdConfiguration.MachineGroupRef).To(Equal(
+		&anywherev1.Ref{Kind: anywherev1.VSphereMachineConfigKind, Name: "workload-etcd"},
+	))
+	etcd := config.VSphereMachineConfigs["workload-etcd"]
+	g.Expect(etcd.Spec.OSFamily).To(Equal(anywherev1.Ubuntu))
+	g.Expect(etcd.Spec.Template).To(Equal("ubuntu-2204-kube-v1-30"))
+	g.Expect(etcd.Spec.Users).To(Equal([]anywherev1.UserConfiguration{{Name: "capv", SshAuthorizedKeys: []string{"ssh-rsa AAAA"}}}))
+}
+
+func TestVSphereProviderSetProviderConfigMissingTemplate(t *testing.T) {
+	g := NewWithT(t)
+	client := test.NewFakeKubeClient(
+		&vspherev1.VSphereCluster{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: constants.EksaSystemNamespace}},
+		&bootstrapv1.KubeadmConfigTemplate{ObjectMeta: metav1.ObjectMeta{Name: "workload-md-0-1", Namespace: constants.EksaSystemNamespace}},
+	)
+	config := &cluster.Config{Cluster: &anywherev1.Cluster{
+		Spec: anywherev1.ClusterSpec{
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{{Name: "md-0"}},
+		},
+	}}
+
+	err := clusterimport.VSphereProvider{}.SetProviderConfig(context.Background(), client, vsphereCAPICluster(), config)
+	g.Expect(err).To(MatchError(ContainSubstring("reading VSphereMachineTemplate workload-control-plane-1")))
+}
+
+func vsphereCAPICluster() *clusterimport.CAPICluster {
+	return capiCluster("VSphereCluster", "VSphereMachineTemplate")
+}
+
+func vsphereMachineTemplate(name, template string) *vspherev1.VSphereMachineTemplate {
+	return &vspherev1.VSphereMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace},
+		Spec: vspherev1.VSphereMachineTemplateSpec{
+			Template: vspherev1.VSphereMachineTemplateResource{
+				Spec: vspherev1.VSphereMachineSpec{
+					VirtualMachineCloneSpec: vspherev1.VirtualMachineCloneSpec{
+						Template:   template,
+						Datacenter: "SDDC-Datacenter",
+						Datastore:  "/SDDC-Datacenter/datastore/WorkloadDatastore",
+						Network: vspherev1.NetworkSpec{
+							Devices: []vspherev1.NetworkDeviceSpec{{NetworkName: "/SDDC-Datacenter/network/sddc-cgw-network-1"}},
+						},
+						NumCPUs:   2,
+						MemoryMiB: 8192,
+						DiskGiB:   25,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,262 @@
+package clusterinfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+const (
+	// ManagementType is the type of a cluster that manages itself and, possibly, workload clusters.
+	ManagementType = "management"
+	// WorkloadType is the type of a cluster managed by a management cluster.
+	WorkloadType = "workload"
+
+	externalEtcdLabel = "cluster.x-k8s.io/etcd-cluster"
+)
+
+// Node group roles.
+const (
+	ControlPlaneRole = "control-plane"
+	EtcdRole         = "etcd"
+	WorkerRole       = "worker"
+)
+
+// Summary is the overview of an EKS Anywhere cluster.
+type Summary struct {
+	Name              string `json:"name"`
+	Namespace         string `json:"namespace"`
+	Type              string `json:"type"`
+	ManagementCluster string `json:"managementCluster"`
+	Provider          string `json:"provider"`
+	KubernetesVersion string `json:"kubernetesVersion"`
+	EKSAVersion       string `json:"eksaVersion,omitempty"`
+	Ready             string `json:"ready"`
+}
+
+// Description is the detailed state of an EKS Anywhere cluster, aggregated from its status
+// and the CAPI machines of its node groups.
+type Description struct {
+	Summary `json:",inline"`
+
+	Generation           int64 `json:"generation"`
+	ObservedGeneration   int64 `json:"observedGeneration"`
+	ReconciledGeneration int64 `json:"reconciledGeneration"`
+
+	FailureReason  string `json:"failureReason,omitempty"`
+	FailureMessage string `json:"failureMessage,omitempty"`
+
+	Conditions   []anywherev1.Condition              `json:"conditions,omitempty"`
+	Certificates []anywherev1.ClusterCertificateInfo `json:"certificates,omitempty"`
+	NodeGroups   []NodeGroup                         `json:"nodeGroups"`
+}
+
+// Reconciled returns true if the controller has successfully reconciled the latest generation of the cluster.
+func (d *Description) Reconciled() bool {
+	return d.ReconciledGeneration == d.Generation
+}
+
+// NodeGroup is the control plane, the external etcd or a worker node group of a cluster.
+type NodeGroup struct {
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	Replicas int       `json:"replicas"`
+	Machines []Machine `json:"machines"`
+}
+
+// Phases returns how many machines of the node group are in each phase, as sorted phase=count pairs.
+func (g NodeGroup) Phases() string {
+	counts := map[string]int{}
+	for _, m := range g.Machines {
+		counts[m.Phase]++
+	}
+
+	phases := make([]string, 0, len(counts))
+	for phase, count := range counts {
+		phases = append(phases, fmt.Sprintf("%s=%d", phase, count))
+	}
+	sort.Strings(phases)
+
+	return strings.Join(phases, ",")
+}
+
+// Machine is a CAPI machine of a node group.
+type Machine struct {
+	Name           string `json:"name"`
+	Phase          string `json:"phase"`
+	Node           string `json:"node,omitempty"`
+	Version        string `json:"version,omitempty"`
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
+
+// ListClusters returns the summary of the EKS Anywhere clusters in a namespace, or in all namespaces
+// if namespace is empty, sorted by namespace and name.
+func ListClusters(ctx context.Context, client kubernetes.Reader, namespace string) ([]Summary, error) {
+	clusters := &anywherev1.ClusterList{}
+	if err := client.List(ctx, clusters, kubernetes.ListOptions{Namespace: namespace}); err != nil {
+		return nil, fmt.Errorf("listing clusters: %v", err)
+	}
+
+	summaries := make([]Summary, 0, len(clusters.Items))
+	for i := range clusters.Items {
+		summaries = append(summaries, summarize(&clusters.Items[i]))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries, nil
+}
+
+// DescribeCluster returns the description of an EKS Anywhere cluster. The client must read the
+// management cluster, where the cluster and its CAPI machines live. It fails if a cluster that was
+// already reconciled has no machines, the client is then most likely reading a workload cluster.
+func DescribeCluster(ctx context.Context, client kubernetes.Reader, name, namespace string) (*Description, error) {
+	cluster := &anywherev1.Cluster{}
+	if err := client.Get(ctx, name, namespace, cluster); err != nil {
+		return nil, fmt.Errorf("reading cluster %s: %v", name, err)
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := client.List(ctx, machines, kubernetes.ListOptions{Namespace: constants.EksaSystemNamespace}); err != nil {
+		return nil, fmt.Errorf("listing machines: %v", err)
+	}
+
+	d := &Description{
+		Summary:              summarize(cluster),
+		Generation:           cluster.Generation,
+		ObservedGeneration:   cluster.Status.ObservedGeneration,
+		ReconciledGeneration: cluster.Status.ReconciledGeneration,
+		Conditions:           cluster.Status.Conditions,
+		Certificates:         cluster.Status.ClusterCertificateInfo,
+		NodeGroups:           nodeGroups(cluster, machines.Items),
+	}
+	if v1beta1conditions.Has(cluster, anywherev1.ReadyCondition) && !hasMachines(d.NodeGroups) {
+		return nil, fmt.Errorf("no machines found for cluster %s, read its management cluster %s to describe it", name, cluster.ManagedBy())
+	}
+	if cluster.Status.FailureReason != nil {
+		d.FailureReason = string(*cluster.Status.FailureReason)
+	}
+	if cluster.Status.FailureMessage != nil {
+		d.FailureMessage = *cluster.Status.FailureMessage
+	}
+
+	return d, nil
+}
+
+func summarize(cluster *anywherev1.Cluster) Summary {
+	s := Summary{
+		Name:              cluster.Name,
+		Namespace:         cluster.Namespace,
+		Type:              WorkloadType,
+		ManagementCluster: cluster.ManagedBy(),
+		Provider:          strings.TrimSuffix(cluster.Spec.DatacenterRef.Kind, "DatacenterConfig"),
+		KubernetesVersion: string(cluster.Spec.KubernetesVersion),
+		Ready:             string(corev1.ConditionUnknown),
+	}
+	if cluster.IsSelfManaged() {
+		s.Type = ManagementType
+	}
+	if cluster.Spec.EksaVersion != nil {
+		s.EKSAVersion = string(*cluster.Spec.EksaVersion)
+	}
+	if ready := v1beta1conditions.Get(cluster, anywherev1.ReadyCondition); ready != nil {
+		s.Ready = string(ready.Status)
+	}
+
+	return s
+}
+
+// nodeGroups groups the machines of a cluster by control plane, external etcd and worker node group.
+func nodeGroups(cluster *anywherev1.Cluster, machines []clusterv1.Machine) []NodeGroup {
+	groups := []NodeGroup{{
+		Name:     clusterapi.KubeadmControlPlaneName(cluster),
+		Role:     ControlPlaneRole,
+		Replicas: cluster.Spec.ControlPlaneConfiguration.Count,
+	}}
+	if cluster.Spec.ExternalEtcdConfiguration != nil {
+		groups = append(groups, NodeGroup{
+			Name:     clusterapi.EtcdClusterName(cluster.Name),
+			Role:     EtcdRole,
+			Replicas: cluster.Spec.ExternalEtcdConfiguration.Count,
+		})
+	}
+	deployments := map[string]int{}
+	for _, wng := range cluster.Spec.WorkerNodeGroupConfigurations {
+		deployments[clusterapi.MachineDeploymentName(cluster, wng)] = len(groups)
+		group := NodeGroup{Name: wng.Name, Role: WorkerRole}
+		if wng.Count != nil {
+			group.Replicas = *wng.Count
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(machines, func(i, j int) bool { return machines[i].Name < machines[j].Name })
+	for _, m := range machines {
+		if m.Labels[clusterv1.ClusterNameLabel] != cluster.Name {
+			continue
+		}
+
+		i, found := nodeGroupIndex(cluster, deployments, m)
+		if !found {
+			continue
+		}
+		groups[i].Machines = append(groups[i].Machines, machine(m))
+	}
+
+	return groups
+}
+
+func hasMachines(groups []NodeGroup) bool {
+	for _, g := range groups {
+		if len(g.Machines) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nodeGroupIndex returns the index of the node group of a machine in the groups built by nodeGroups.
+func nodeGroupIndex(cluster *anywherev1.Cluster, deployments map[string]int, m clusterv1.Machine) (int, bool) {
+	if _, isControlPlane := m.Labels[clusterv1.MachineControlPlaneLabel]; isControlPlane {
+		return 0, true
+	}
+	if cluster.Spec.ExternalEtcdConfiguration != nil && m.Labels[externalEtcdLabel] == clusterapi.EtcdClusterName(cluster.Name) {
+		return 1, true
+	}
+	i, found := deployments[m.Labels[clusterv1.MachineDeploymentNameLabel]]
+
+	return i, found
+}
+
+func machine(m clusterv1.Machine) Machine {
+	machine := Machine{
+		Name:  m.Name,
+		Phase: m.Status.Phase,
+	}
+	if m.Status.NodeRef != nil {
+		machine.Node = m.Status.NodeRef.Name
+	}
+	if m.Spec.Version != nil {
+		machine.Version = *m.Spec.Version
+	}
+	if m.Status.FailureMessage != nil {
+		machine.FailureMessage = *m.Status.FailureMessage
+	}
+
+	return machine
+}
@@ -0,0 +1,203 @@
+package clusterinfo_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+func eksaCluster(name, namespace, management string) *anywherev1.Cluster {
+	eksaVersion := anywherev1.EksaVersion("v0.24.0")
+	return &anywherev1.Cluster{
+		TypeMeta:   metav1.TypeMeta{Kind: anywherev1.ClusterKind, APIVersion: anywherev1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 3},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion:         anywherev1.Kube134,
+			EksaVersion:               &eksaVersion,
+			ManagementCluster:         anywherev1.ManagementCluster{Name: management},
+			DatacenterRef:             anywherev1.Ref{Kind: anywherev1.VSphereDatacenterKind, Name: name},
+			ControlPlaneConfiguration: anywherev1.ControlPlaneConfiguration{Count: 1},
+			WorkerNodeGroupConfigurations: []anywherev1.WorkerNodeGroupConfiguration{
+				{Name: "md-0", Count: ptr(2)},
+			},
+		},
+	}
+}
+
+func capiMachine(name string, labels map[string]string, phase clusterv1.MachinePhase) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: constants.EksaSystemNamespace, Labels: labels},
+		Spec:       clusterv1.MachineSpec{Version: ptr("v1.34.1-eks-1-34-5")},
+		Status:     clusterv1.MachineStatus{Phase: string(phase)},
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestListClusters(t *testing.T) {
+	g := NewWithT(t)
+	mgmt := eksaCluster("mgmt", "default", "mgmt")
+	mgmt.Status.Conditions = []anywherev1.Condition{{Type: anywherev1.ReadyCondition, Status: corev1.ConditionTrue}}
+	prod := eksaCluster("prod", "workloads", "mgmt")
+	prod.Status.Conditions = []anywherev1.Condition{{Type: anywherev1.ReadyCondition, Status: corev1.ConditionFalse}}
+	dev := eksaCluster("dev", "default", "mgmt")
+	dev.Spec.EksaVersion = nil
+	client := test.NewFakeKubeClient(prod, mgmt, dev)
+
+	got, err := clusterinfo.ListClusters(context.Background(), client, "")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal([]clusterinfo.Summary{
+		{
+			Name:              "dev",
+			Namespace:         "default",
+			Type:              clusterinfo.WorkloadType,
+			ManagementCluster: "mgmt",
+			Provider:          "VSphere",
+			KubernetesVersion: "1.34",
+			Ready:             "Unknown",
+		},
+		{
+			Name:              "mgmt",
+			Namespace:         "default",
+			Type:              clusterinfo.ManagementType,
+			ManagementCluster: "mgmt",
+			Provider:          "VSphere",
+			KubernetesVersion: "1.34",
+			EKSAVersion:       "v0.24.0",
+			Ready:             "True",
+		},
+		{
+			Name:              "prod",
+			Namespace:         "workloads",
+			Type:              clusterinfo.WorkloadType,
+			ManagementCluster: "mgmt",
+			Provider:          "VSphere",
+			KubernetesVersion: "1.34",
+			EKSAVersion:       "v0.24.0",
+			Ready:             "False",
+		},
+	}))
+
+	got, err = clusterinfo.ListClusters(context.Background(), client, "workloads")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(HaveLen(1))
+	g.Expect(got[0].Name).To(Equal("prod"))
+}
+
+func TestListClustersError(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := clusterinfo.ListClusters(context.Background(), test.NewFakeKubeClientAlwaysError(), "")
+	g.Expect(err).To(MatchError(ContainSubstring("listing clusters")))
+}
+
+func TestDescribeCluster(t *testing.T) {
+	g := NewWithT(t)
+	prod := eksaCluster("prod", "default", "mgmt")
+	prod.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: 3}
+	prod.Status = anywherev1.ClusterStatus{
+		FailureReason:          ptr(anywherev1.FailureReasonType("MachineConfigInvalid")),
+		FailureMessage:         ptr("template not found"),
+		Conditions:             []anywherev1.Condition{{Type: anywherev1.ReadyCondition, Status: corev1.ConditionFalse, Reason: "WorkersNotReady"}},
+		ClusterCertificateInfo: []anywherev1.ClusterCertificateInfo{{Machine: "prod-cp-abcde", ExpiresInDays: 300}},
+		ObservedGeneration:     3,
+		ReconciledGeneration:   2,
+	}
+
+	cp := capiMachine("prod-cp-abcde", map[string]string{
+		clusterv1.ClusterNameLabel:         "prod",
+		clusterv1.MachineControlPlaneLabel: "",
+	}, clusterv1.MachinePhaseRunning)
+	cp.Status.NodeRef = &corev1.ObjectReference{Name: "10.0.0.10"}
+	etcd := capiMachine("prod-etcd-abcde", map[string]string{
+		clusterv1.ClusterNameLabel:      "prod",
+		"cluster.x-k8s.io/etcd-cluster": "prod-etcd",
+	}, clusterv1.MachinePhaseRunning)
+	worker1 := capiMachine("prod-md-0-abcde", map[string]string{
+		clusterv1.ClusterNameLabel:           "prod",
+		clusterv1.MachineDeploymentNameLabel: "prod-md-0",
+	}, clusterv1.MachinePhaseRunning)
+	worker2 := capiMachine("prod-md-0-fghij", map[string]string{
+		clusterv1.ClusterNameLabel:           "prod",
+		clusterv1.MachineDeploymentNameLabel: "prod-md-0",
+	}, clusterv1.MachinePhaseFailed)
+	worker2.Status.FailureMessage = ptr("VM creation failed")
+	other := capiMachine("dev-md-0-abcde", map[string]string{
+		clusterv1.ClusterNameLabel:           "dev",
+		clusterv1.MachineDeploymentNameLabel: "dev-md-0",
+	}, clusterv1.MachinePhaseRunning)
+	objs := []client.Object{prod, worker2, cp, etcd, worker1, other}
+
+	got, err := clusterinfo.DescribeCluster(context.Background(), test.NewFakeKubeClient(objs...), "prod", "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.Summary.Name).To(Equal("prod"))
+	g.Expect(got.Generation).To(BeEquivalentTo(3))
+	g.Expect(got.ObservedGeneration).To(BeEquivalentTo(3))
+	g.Expect(got.ReconciledGeneration).To(BeEquivalentTo(2))
+	g.Expect(got.Reconciled()).To(BeFalse())
+	g.Expect(got.FailureReason).To(Equal("MachineConfigInvalid"))
+	g.Expect(got.FailureMessage).To(Equal("template not found"))
+	g.Expect(got.Conditions).To(Equal(prod.Status.Conditions))
+	g.Expect(got.Certificates).To(Equal(prod.Status.ClusterCertificateInfo))
+	g.Expect(got.NodeGroups).To(Equal([]clusterinfo.NodeGroup{
+		{
+			Name:     "prod",
+			Role:     clusterinfo.ControlPlaneRole,
+			Replicas: 1,
+			Machines: []clusterinfo.Machine{{Name: "prod-cp-abcde", Phase: "Running", Node: "10.0.0.10", Version: "v1.34.1-eks-1-34-5"}},
+		},
+		{
+			Name:     "prod-etcd",
+			Role:     clusterinfo.EtcdRole,
+			Replicas: 3,
+			Machines: []clusterinfo.Machine{{Name: "prod-etcd-abcde", Phase: "Running", Version: "v1.34.1-eks-1-34-5"}},
+		},
+		{
+			Name:     "md-0",
+			Role:     clusterinfo.WorkerRole,
+			Replicas: 2,
+			Machines: []clusterinfo.Machine{
+				{Name: "prod-md-0-abcde", Phase: "Running", Version: "v1.34.1-eks-1-34-5"},
+				{Name: "prod-md-0-fghij", Phase: "Failed", Version: "v1.34.1-eks-1-34-5", FailureMessage: "VM creation failed"},
+			},
+		},
+	}))
+	g.Expect(got.NodeGroups[2].Phases()).To(Equal("Failed=1,Running=1"))
+}
+
+func TestDescribeClusterNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := clusterinfo.DescribeCluster(context.Background(), test.NewFakeKubeClient(), "prod", "default")
+	g.Expect(err).To(MatchError(ContainSubstring("reading cluster prod")))
+}
+
+func TestDescribeClusterNoMachines(t *testing.T) {
+	g := NewWithT(t)
+	prod := eksaCluster("prod", "default", "mgmt")
+	prod.Status.Conditions = []anywherev1.Condition{{Type: anywherev1.ReadyCondition, Status: corev1.ConditionTrue}}
+
+	_, err := clusterinfo.DescribeCluster(context.Background(), test.NewFakeKubeClient(prod), "prod", "default")
+	g.Expect(err).To(MatchError("no machines found for cluster prod, read its management cluster mgmt to describe it"))
+}
+
+func TestDescribeClusterNotReconciledNoMachines(t *testing.T) {
+	g := NewWithT(t)
+	prod := eksaCluster("prod", "default", "mgmt")
+
+	got, err := clusterinfo.DescribeCluster(context.Background(), test.NewFakeKubeClient(prod), "prod", "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.NodeGroups).To(HaveLen(2))
+}
@@ -0,0 +1,102 @@
+package clusterinfo
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+const none = "<none>"
+
+// WriteTable writes the cluster summaries as a table, one cluster per row.
+func WriteTable(w io.Writer, clusters []Summary) error {
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tTYPE\tMANAGEMENT CLUSTER\tPROVIDER\tKUBERNETES\tEKS-A\tREADY")
+	for _, c := range clusters {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Namespace, c.Name, c.Type, c.ManagementCluster, c.Provider, c.KubernetesVersion, orNone(c.EKSAVersion), c.Ready)
+	}
+
+	return tw.Flush()
+}
+
+// WriteDescription writes the description of a cluster in a human readable format.
+func WriteDescription(w io.Writer, d *Description) error {
+	tw := newTabWriter(w)
+	fmt.Fprintf(tw, "Name:\t%s\n", d.Name)
+	fmt.Fprintf(tw, "Namespace:\t%s\n", d.Namespace)
+	fmt.Fprintf(tw, "Type:\t%s\n", d.Type)
+	fmt.Fprintf(tw, "Management Cluster:\t%s\n", d.ManagementCluster)
+	fmt.Fprintf(tw, "Provider:\t%s\n", d.Provider)
+	fmt.Fprintf(tw, "Kubernetes Version:\t%s\n", d.KubernetesVersion)
+	fmt.Fprintf(tw, "EKS-A Version:\t%s\n", orNone(d.EKSAVersion))
+	fmt.Fprintf(tw, "Ready:\t%s\n", d.Ready)
+	fmt.Fprintf(tw, "Generation:\t%d\n", d.Generation)
+	fmt.Fprintf(tw, "Observed Generation:\t%d\n", d.ObservedGeneration)
+	fmt.Fprintf(tw, "Reconciled Generation:\t%d\n", d.ReconciledGeneration)
+	fmt.Fprintf(tw, "Reconciled:\t%t\n", d.Reconciled())
+	if d.FailureReason != "" || d.FailureMessage != "" {
+		fmt.Fprintf(tw, "Failure Reason:\t%s\n", orNone(d.FailureReason))
+		fmt.Fprintf(tw, "Failure Message:\t%s\n", orNone(d.FailureMessage))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "Conditions:")
+	if len(d.Conditions) == 0 {
+		fmt.Fprintf(w, "  %s\n", none)
+	} else {
+		tw = newTabWriter(w)
+		fmt.Fprintln(tw, "  TYPE\tSTATUS\tSEVERITY\tREASON\tMESSAGE")
+		for _, c := range d.Conditions {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", c.Type, c.Status, orNone(string(c.Severity)), orNone(c.Reason), orNone(c.Message))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(w, "Certificates:")
+	if len(d.Certificates) == 0 {
+		fmt.Fprintf(w, "  %s\n", none)
+	} else {
+		tw = newTabWriter(w)
+		fmt.Fprintln(tw, "  MACHINE\tEXPIRES IN DAYS")
+		for _, c := range d.Certificates {
+			fmt.Fprintf(tw, "  %s\t%d\n", c.Machine, c.ExpiresInDays)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(w, "Node Groups:")
+	for _, g := range d.NodeGroups {
+		fmt.Fprintf(w, "  %s (%s): %d/%d machines %s\n", g.Name, g.Role, len(g.Machines), g.Replicas, orNone(g.Phases()))
+		if len(g.Machines) == 0 {
+			continue
+		}
+		tw = newTabWriter(w)
+		fmt.Fprintln(tw, "    MACHINE\tPHASE\tNODE\tVERSION\tFAILURE")
+		for _, m := range g.Machines {
+			fmt.Fprintf(tw, "    %s\t%s\t%s\t%s\t%s\n", m.Name, orNone(m.Phase), orNone(m.Node), orNone(m.Version), orNone(m.FailureMessage))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newTabWriter(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 10, 4, 3, ' ', 0)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return none
+	}
+	return s
+}
@@ -0,0 +1,56 @@
+package clusterinfo_test
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clusterinfo"
+)
+
+func TestWriteTable(t *testing.T) {
+	g := NewWithT(t)
+	b := &bytes.Buffer{}
+
+	g.Expect(clusterinfo.WriteTable(b, []clusterinfo.Summary{
+		{Name: "mgmt", Namespace: "default", Type: "management", ManagementCluster: "mgmt", Provider: "VSphere", KubernetesVersion: "1.34", EKSAVersion: "v0.24.0", Ready: "True"},
+		{Name: "prod", Namespace: "default", Type: "workload", ManagementCluster: "mgmt", Provider: "VSphere", KubernetesVersion: "1.33", Ready: "False"},
+	})).To(Succeed())
+	g.Expect(b.String()).To(Equal(
+		"NAMESPACE   NAME      TYPE         MANAGEMENT CLUSTER   PROVIDER   KUBERNETES   EKS-A     READY\n" +
+			"default     mgmt      management   mgmt                 VSphere    1.34         v0.24.0   True\n" +
+			"default     prod      workload     mgmt                 VSphere    1.33         <none>    False\n",
+	))
+}
+
+func TestWriteDescription(t *testing.T) {
+	g := NewWithT(t)
+	b := &bytes.Buffer{}
+	d := &clusterinfo.Description{
+		Summary:              clusterinfo.Summary{Name: "prod", Namespace: "default", Type: "workload", ManagementCluster: "mgmt", Provider: "VSphere", KubernetesVersion: "1.34", Ready: "False"},
+		Generation:           3,
+		ObservedGeneration:   3,
+		ReconciledGeneration: 2,
+		FailureMessage:       "template not found",
+		Conditions: []anywherev1.Condition{
+			{Type: anywherev1.ReadyCondition, Status: corev1.ConditionFalse, Severity: "Info", Reason: "WorkersNotReady", Message: "Scaling up worker nodes"},
+		},
+		NodeGroups: []clusterinfo.NodeGroup{
+			{Name: "prod", Role: clusterinfo.ControlPlaneRole, Replicas: 1, Machines: []clusterinfo.Machine{{Name: "prod-cp-abcde", Phase: "Running", Node: "10.0.0.10", Version: "v1.34.1"}}},
+			{Name: "md-0", Role: clusterinfo.WorkerRole, Replicas: 2},
+		},
+	}
+
+	g.Expect(clusterinfo.WriteDescription(b, d)).To(Succeed())
+	out := b.String()
+	g.Expect(out).To(ContainSubstring("Reconciled Generation:   2\nReconciled:              false\n"))
+	g.Expect(out).To(ContainSubstring("Failure Reason:          <none>\nFailure Message:         template not found\n"))
+	g.Expect(out).To(ContainSubstring("  Ready   False     Info       WorkersNotReady   Scaling up worker nodes\n"))
+	g.Expect(out).To(ContainSubstring("Certificates:\n  <none>\n"))
+	g.Expect(out).To(ContainSubstring("  prod (control-plane): 1/1 machines Running=1\n"))
+	g.Expect(out).To(ContainSubstring("    prod-cp-abcde   Running   10.0.0.10   v1.34.1   <none>\n"))
+	g.Expect(out).To(HaveSuffix("  md-0 (worker): 0/2 machines <none>\n"))
+}
@@ -35,6 +35,7 @@ const (
 	DefaultHttpsPort                        = "443"
 	DefaultWorkerNodeGroupName              = "md-0"
 	DefaultNodeCidrMaskSize                 = 24
+	DefaultNodeCidrMaskSizeIPv6             = 64
 
 	// Certificate renewal component types.
 	EtcdComponent         = "etcd"
@@ -60,6 +61,9 @@ const (
 	KubeVipConfigMapName = "kube-vip-in-place-upgrade"
 	// KubeVipManifestName is the name of kube-vip spec file.
 	KubeVipManifestName = "kube-vip.yaml"
+	// InPlaceOSImageURLAnnotation is the annotation set on KubeadmControlPlane and MachineDeployment objects
+	// with the OS image the machines should be upgraded to during an in-place upgrade.
+	InPlaceOSImageURLAnnotation = "anywhere.eks.amazonaws.com/in-place-os-image-url"
 
 	CloudstackAnnotationSuffix = "cloudstack.anywhere.eks.amazonaws.com/v1alpha1"
 
@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/controller/serverside"
@@ -107,11 +108,17 @@ func ReconcileControlPlane(ctx context.Context, log logr.Logger, c client.Client
 
 	if cp.EtcdCluster == nil {
 		// For stacked etcd, we don't need orchestration, apply directly
+		holdBackKCPRollout(ctx, log, cp.KubeadmControlPlane, kcp)
 		return controller.Result{}, applyAllControlPlaneObjects(ctx, c, cp)
 	}
 
 	// If there are changes for etcd, we only apply those changes for now and we wait.
-	if !equality.Semantic.DeepDerivative(cp.EtcdCluster.Spec, etcdadmCluster.Spec) {
+	// When the rollouts are deferred, the etcd changes are left for later and the rest of the control plane is reconciled.
+	if !equality.Semantic.DeepDerivative(cp.EtcdCluster.Spec, etcdadmCluster.Spec) && !deferEtcdRollout(ctx, log, etcdadmCluster) {
+		// Membership changes are made one member at a time, before any other etcd change.
+		if etcdScaling(cp.EtcdCluster, etcdadmCluster) {
+			return reconcileEtcdScaling(ctx, log, c, cp, kcp, etcdadmCluster)
+		}
 		return reconcileEtcdChanges(ctx, log, c, cp, kcp, etcdadmCluster)
 	}
 
@@ -195,6 +202,31 @@ func reconcileEtcdChanges(ctx context.Context, log logr.Logger, c client.Client,
 	return controller.ResultWithRequeue(10 * time.Second), nil
 }
 
+func etcdScaling(desired, current *etcdv1.EtcdadmCluster) bool {
+	return desired.Spec.Replicas != nil && current.Spec.Replicas != nil && *desired.Spec.Replicas != *current.Spec.Replicas
+}
+
+func reconcileEtcdScaling(ctx context.Context, log logr.Logger, c client.Client, desiredCP *ControlPlane, currentKCP *controlplanev1.KubeadmControlPlane, currentEtcdadmCluster *etcdv1.EtcdadmCluster) (controller.Result, error) {
+	current := *currentEtcdadmCluster.Spec.Replicas
+	desired := *desiredCP.EtcdCluster.Spec.Replicas
+	if err := clusterapi.ValidateEtcdScaling(current, desired); err != nil {
+		return controller.Result{}, err
+	}
+
+	// Only add or remove a member when all the current ones are healthy. This also waits for the
+	// member added or removed in the previous step to pass the health check before taking the next one.
+	if !clusterapi.EtcdadmClusterHealthy(currentEtcdadmCluster) {
+		log.Info("Etcd members are not healthy, waiting before scaling etcd", "readyReplicas", currentEtcdadmCluster.Status.ReadyReplicas, "replicas", current)
+		return controller.ResultWithRequeue(30 * time.Second), nil
+	}
+
+	next := clusterapi.NextEtcdReplicas(current, desired)
+	log.Info("Scaling etcd", "from", current, "to", next, "desired", desired)
+	desiredCP.EtcdCluster.Spec.Replicas = &next
+
+	return reconcileEtcdChanges(ctx, log, c, desiredCP, currentKCP, currentEtcdadmCluster)
+}
+
 func etcdadmClusterReady(etcdadmCluster *etcdv1.EtcdadmCluster) bool {
 	// It's important to use status.Ready and not the Ready condition, since the Ready condition
 	// only becomes true after the old etcd members have been deleted, which only happens after the
@@ -215,6 +247,8 @@ func reconcileControlPlaneNodeChanges(ctx context.Context, log logr.Logger, c cl
 		desiredCP.KubeadmControlPlane.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.Endpoints = externalEndpoints
 	}
 
+	holdBackKCPRollout(ctx, log, desiredCP.KubeadmControlPlane, currentKCP)
+
 	if err := serverside.ReconcileObjects(ctx, c, desiredCP.nonEtcdObjects()); err != nil {
 		return controller.Result{}, errors.Wrap(err, "applying non etcd control plane objects")
 	}
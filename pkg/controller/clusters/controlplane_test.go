@@ -198,6 +198,94 @@ func TestReconcileControlPlaneExternalEtcdNotReady(t *testing.T) {
 	)
 }
 
+func TestReconcileControlPlaneExternalEtcdScaleUpOneMemberAtATime(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	api := envtest.NewAPIExpecter(t, c)
+	ctx := context.Background()
+	ns := env.CreateNamespaceForTest(ctx, t)
+	log := test.NewNullLogger()
+	cp := controlPlaneExternalEtcd(ns)
+	cp.EtcdCluster.Spec.Replicas = ptr.Int32(1)
+	cp.EtcdCluster.Status.Ready = true
+	cp.EtcdCluster.Status.ReadyReplicas = 1
+	cp.EtcdCluster.Status.ObservedGeneration = 1
+	envtest.CreateObjs(ctx, t, c, cp.AllObjects()...)
+
+	cp.EtcdCluster.Spec.Replicas = ptr.Int32(3)
+
+	g.Expect(clusters.ReconcileControlPlane(ctx, log, c, cp)).To(
+		Equal(controller.Result{Result: &reconcile.Result{RequeueAfter: 10 * time.Second}}),
+	)
+
+	etcdadmCluster := envtest.CloneNameNamespace(cp.EtcdCluster)
+	api.ShouldEventuallyMatch(
+		ctx,
+		etcdadmCluster,
+		func(g Gomega) {
+			g.Expect(etcdadmCluster.Spec.Replicas).To(HaveValue(BeEquivalentTo(2)), "etcdadm replicas should have been increased by one")
+			g.Expect(etcdadmCluster.Annotations).To(
+				HaveKeyWithValue(etcdv1.UpgradeInProgressAnnotation, "true"),
+				"etcdadm upgrading annotation should have been added",
+			)
+		},
+	)
+	kcp := envtest.CloneNameNamespace(cp.KubeadmControlPlane)
+	api.ShouldEventuallyMatch(
+		ctx,
+		kcp,
+		func(g Gomega) {
+			g.Expect(annotations.HasPaused(kcp)).To(BeTrue(), "kcp should have been paused")
+		},
+	)
+}
+
+func TestReconcileControlPlaneExternalEtcdScaleUnhealthyMembers(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	api := envtest.NewAPIExpecter(t, c)
+	ctx := context.Background()
+	ns := env.CreateNamespaceForTest(ctx, t)
+	log := test.NewNullLogger()
+	cp := controlPlaneExternalEtcd(ns)
+	cp.EtcdCluster.Spec.Replicas = ptr.Int32(3)
+	cp.EtcdCluster.Status.Ready = false
+	cp.EtcdCluster.Status.ReadyReplicas = 2
+	cp.EtcdCluster.Status.ObservedGeneration = 1
+	envtest.CreateObjs(ctx, t, c, cp.AllObjects()...)
+
+	cp.EtcdCluster.Spec.Replicas = ptr.Int32(5)
+
+	g.Expect(clusters.ReconcileControlPlane(ctx, log, c, cp)).To(
+		Equal(controller.Result{Result: &reconcile.Result{RequeueAfter: 30 * time.Second}}),
+	)
+
+	etcdadmCluster := envtest.CloneNameNamespace(cp.EtcdCluster)
+	api.ShouldEventuallyMatch(
+		ctx,
+		etcdadmCluster,
+		func(g Gomega) {
+			g.Expect(etcdadmCluster.Spec.Replicas).To(HaveValue(BeEquivalentTo(3)), "etcdadm replicas should not have changed")
+		},
+	)
+}
+
+func TestReconcileControlPlaneExternalEtcdScaleDownBelowQuorum(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	ctx := context.Background()
+	ns := env.CreateNamespaceForTest(ctx, t)
+	log := test.NewNullLogger()
+	cp := controlPlaneExternalEtcd(ns)
+	cp.EtcdCluster.Spec.Replicas = ptr.Int32(3)
+	envtest.CreateObjs(ctx, t, c, cp.AllObjects()...)
+
+	cp.EtcdCluster.Spec.Replicas = ptr.Int32(1)
+
+	_, err := clusters.ReconcileControlPlane(ctx, log, c, cp)
+	g.Expect(err).To(MatchError(ContainSubstring("would drop below the current quorum of 2 members")))
+}
+
 func TestReconcileControlPlaneExternalEtcdReadyControlPlaneUpgrade(t *testing.T) {
 	g := NewWithT(t)
 	c := env.Client()
@@ -255,6 +343,97 @@ func TestReconcileControlPlaneExternalEtcdReadyControlPlaneUpgrade(t *testing.T)
 	)
 }
 
+func TestReconcileControlPlaneStackedEtcdDeferredRollout(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	api := envtest.NewAPIExpecter(t, c)
+	ctx := context.Background()
+	ns := env.CreateNamespaceForTest(ctx, t)
+	log := test.NewNullLogger()
+	cp := controlPlaneStackedEtcd(ns)
+	cp.KubeadmControlPlane.Spec.Replicas = ptr.Int32(3)
+	envtest.CreateObjs(ctx, t, c, cp.AllObjects()...)
+
+	cp.KubeadmControlPlane.Spec.Replicas = ptr.Int32(5)
+	cp.KubeadmControlPlane.Spec.Version = "v1.29.0"
+
+	ctx, deferred := clusters.DeferMachineRollouts(ctx)
+	g.Expect(clusters.ReconcileControlPlane(ctx, log, c, cp)).To(Equal(controller.Result{}))
+	g.Expect(deferred.Objects()).To(ConsistOf("KubeadmControlPlane " + ns + "/my-cluster"))
+
+	kcp := envtest.CloneNameNamespace(cp.KubeadmControlPlane)
+	api.ShouldEventuallyMatch(ctx, kcp, func(g Gomega) {
+		g.Expect(kcp.Spec.Replicas).To(HaveValue(BeEquivalentTo(5)), "kcp replicas should have been updated")
+		g.Expect(kcp.Spec.Version).To(Equal("v1.28.0"), "kcp version should not have changed")
+	})
+}
+
+func TestReconcileControlPlaneExternalEtcdDeferredRollout(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	api := envtest.NewAPIExpecter(t, c)
+	ctx := context.Background()
+	ns := env.CreateNamespaceForTest(ctx, t)
+	log := test.NewNullLogger()
+	cp := controlPlaneExternalEtcd(ns)
+	cp.EtcdCluster.Status.Ready = true
+	cp.EtcdCluster.Status.ObservedGeneration = 1
+	cp.KubeadmControlPlane.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.Endpoints = []string{"https://1.1.1.1:2379"}
+	envtest.CreateObjs(ctx, t, c, cp.AllObjects()...)
+
+	cp.EtcdCluster.Spec.InfrastructureTemplate.Name = "my-cluster-etcd-2"
+	cp.KubeadmControlPlane.Spec.Version = "v1.29.0"
+
+	ctx, deferred := clusters.DeferMachineRollouts(ctx)
+	g.Expect(clusters.ReconcileControlPlane(ctx, log, c, cp)).To(Equal(controller.Result{}))
+	g.Expect(deferred.Objects()).To(ConsistOf(
+		"EtcdadmCluster "+ns+"/my-cluster",
+		"KubeadmControlPlane "+ns+"/my-cluster",
+	))
+
+	etcdadmCluster := envtest.CloneNameNamespace(cp.EtcdCluster)
+	api.ShouldEventuallyMatch(ctx, etcdadmCluster, func(g Gomega) {
+		g.Expect(etcdadmCluster.Spec.InfrastructureTemplate.Name).To(BeEmpty(), "etcd should not have changed")
+	})
+	kcp := envtest.CloneNameNamespace(cp.KubeadmControlPlane)
+	api.ShouldEventuallyMatch(ctx, kcp, func(g Gomega) {
+		g.Expect(kcp.Spec.Version).To(Equal("v1.28.0"), "kcp version should not have changed")
+		g.Expect(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.Endpoints).To(HaveExactElements("https://1.1.1.1:2379"))
+	})
+}
+
+func TestReconcileControlPlaneExternalEtcdScaleDeferredRollout(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	api := envtest.NewAPIExpecter(t, c)
+	ctx := context.Background()
+	ns := env.CreateNamespaceForTest(ctx, t)
+	log := test.NewNullLogger()
+	cp := controlPlaneExternalEtcd(ns)
+	cp.EtcdCluster.Spec.Replicas = ptr.Int32(1)
+	cp.EtcdCluster.Status.Ready = true
+	cp.EtcdCluster.Status.ReadyReplicas = 1
+	cp.EtcdCluster.Status.ObservedGeneration = 1
+	cp.KubeadmControlPlane.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.Endpoints = []string{"https://1.1.1.1:2379"}
+	envtest.CreateObjs(ctx, t, c, cp.AllObjects()...)
+
+	cp.EtcdCluster.Spec.Replicas = ptr.Int32(3)
+
+	ctx, deferred := clusters.DeferMachineRollouts(ctx)
+	g.Expect(clusters.ReconcileControlPlane(ctx, log, c, cp)).To(Equal(controller.Result{}))
+	g.Expect(deferred.Objects()).To(ConsistOf("EtcdadmCluster " + ns + "/my-cluster"))
+
+	etcdadmCluster := envtest.CloneNameNamespace(cp.EtcdCluster)
+	api.ShouldEventuallyMatch(ctx, etcdadmCluster, func(g Gomega) {
+		g.Expect(etcdadmCluster.Spec.Replicas).To(HaveValue(BeEquivalentTo(1)), "etcdadm replicas should not have changed")
+		g.Expect(etcdadmCluster.Annotations).NotTo(HaveKey(etcdv1.UpgradeInProgressAnnotation), "etcdadm should not be upgrading")
+	})
+	kcp := envtest.CloneNameNamespace(cp.KubeadmControlPlane)
+	api.ShouldEventuallyMatch(ctx, kcp, func(g Gomega) {
+		g.Expect(annotations.HasPaused(kcp)).To(BeFalse(), "kcp should not have been paused")
+	})
+}
+
 func TestReconcileControlPlaneExternalEtcdWithPlaceholderEndpoints(t *testing.T) {
 	g := NewWithT(t)
 	c := env.Client()
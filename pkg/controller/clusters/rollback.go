@@ -0,0 +1,371 @@
+package clusters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/semver"
+)
+
+// rollbackSnapshotKey is the key of the Secret data with the objects of the rollback snapshot.
+const rollbackSnapshotKey = "objects"
+
+// RollbackSnapshotName returns the name of the Secret storing the objects an upgrade of the cluster is rolled back to.
+func RollbackSnapshotName(cluster *anywherev1.Cluster) string {
+	return cluster.Name + "-upgrade-rollback"
+}
+
+// SaveRollbackSnapshot stores the objects that define the cluster machines, the KubeadmControlPlane, the
+// EtcdadmCluster, the MachineDeployments and the templates they reference, so a failed upgrade can be rolled back to them.
+// The snapshot is stored in a Secret owned by the CAPI cluster, since the kubeadm configs can include credentials.
+func SaveRollbackSnapshot(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) error {
+	objs, err := rollbackObjects(ctx, c, cluster)
+	if err != nil {
+		return errors.Wrap(err, "reading objects for rollback snapshot")
+	}
+
+	data, err := json.Marshal(objs)
+	if err != nil {
+		return errors.Wrap(err, "marshalling rollback snapshot")
+	}
+
+	capiCluster, err := controller.GetCAPICluster(ctx, c, cluster)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RollbackSnapshotName(cluster),
+			Namespace: constants.EksaSystemNamespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[clusterv1.ClusterNameLabel] = cluster.Name
+		secret.Data = map[string][]byte{rollbackSnapshotKey: data}
+		if capiCluster != nil {
+			return controllerutil.SetOwnerReference(capiCluster, secret, c.Scheme())
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "saving rollback snapshot")
+	}
+
+	return nil
+}
+
+// ErrRollbackNotSupported is returned when the changes made by a failed upgrade can't be rolled back.
+var ErrRollbackNotSupported = errors.New("upgrade can't be rolled back")
+
+// RollBackUpgrade restores the KubeadmControlPlane, the EtcdadmCluster and the MachineDeployments of the cluster to
+// the rollback snapshot, recreating the templates they reference if they were deleted. The replicas are not rolled back,
+// and neither are the MachineDeployments added or removed after the snapshot.
+// Kubernetes doesn't support downgrading the control plane to a previous minor version, so it returns an
+// ErrRollbackNotSupported error without changing any object when the KubeadmControlPlane minor version changed.
+func RollBackUpgrade(ctx context.Context, log logr.Logger, c client.Client, cluster *anywherev1.Cluster) error {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Name: RollbackSnapshotName(cluster), Namespace: constants.EksaSystemNamespace}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return errors.Wrap(err, "reading rollback snapshot")
+	}
+
+	var objs []*unstructured.Unstructured
+	if err := json.Unmarshal(secret.Data[rollbackSnapshotKey], &objs); err != nil {
+		return errors.Wrap(err, "unmarshalling rollback snapshot")
+	}
+
+	var kcps, etcdadmClusters, mds, templates []*unstructured.Unstructured
+	for _, obj := range objs {
+		switch obj.GetKind() {
+		case "KubeadmControlPlane":
+			kcps = append(kcps, obj)
+		case "EtcdadmCluster":
+			etcdadmClusters = append(etcdadmClusters, obj)
+		case "MachineDeployment":
+			mds = append(mds, obj)
+		default:
+			templates = append(templates, obj)
+		}
+	}
+
+	snapshotKCP, kcp, err := kcpToRollBack(ctx, c, kcps)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range templates {
+		if err := c.Create(ctx, obj); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "restoring %s %s", obj.GetKind(), obj.GetName())
+		}
+	}
+
+	snapshotEtcd, etcdadmCluster, err := etcdadmClusterToRollBack(ctx, c, etcdadmClusters)
+	if err != nil {
+		return err
+	}
+
+	if kcp != nil {
+		replicas := kcp.Spec.Replicas
+		kcp.Spec = snapshotKCP.Spec
+		kcp.Spec.Replicas = replicas
+		// The same as for any other etcd change, the KubeadmControlPlane is paused until the etcd machines
+		// are rolled back. The control plane reconciliation unpauses it once etcd is ready.
+		if etcdadmCluster != nil {
+			clientutil.AddAnnotation(kcp, clusterv1.PausedAnnotation, "true")
+		}
+		log.Info("Rolling back KubeadmControlPlane", "name", kcp.Name, "version", kcp.Spec.Version)
+		if err := c.Update(ctx, kcp); err != nil {
+			return errors.Wrap(err, "rolling back KubeadmControlPlane")
+		}
+	}
+
+	if etcdadmCluster != nil {
+		replicas := etcdadmCluster.Spec.Replicas
+		etcdadmCluster.Spec = snapshotEtcd.Spec
+		etcdadmCluster.Spec.Replicas = replicas
+		clientutil.AddAnnotation(etcdadmCluster, etcdv1.UpgradeInProgressAnnotation, "true")
+		log.Info("Rolling back EtcdadmCluster", "name", etcdadmCluster.Name)
+		if err := c.Update(ctx, etcdadmCluster); err != nil {
+			return errors.Wrap(err, "rolling back EtcdadmCluster")
+		}
+	}
+
+	for _, obj := range mds {
+		snapshot := &clusterv1.MachineDeployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, snapshot); err != nil {
+			return errors.Wrap(err, "converting MachineDeployment from rollback snapshot")
+		}
+
+		md := &clusterv1.MachineDeployment{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(snapshot), md); apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Wrap(err, "reading MachineDeployment to roll back")
+		}
+
+		md.Spec.Template = snapshot.Spec.Template
+		log.Info("Rolling back MachineDeployment", "name", md.Name)
+		if err := c.Update(ctx, md); err != nil {
+			return errors.Wrapf(err, "rolling back MachineDeployment %s", md.Name)
+		}
+	}
+
+	return nil
+}
+
+// kcpToRollBack returns the KubeadmControlPlane in the snapshot and the current one, failing if the
+// current one is in a different Kubernetes minor version. It returns nil when there is no KubeadmControlPlane.
+func kcpToRollBack(ctx context.Context, c client.Client, objs []*unstructured.Unstructured) (snapshot, current *controlplanev1.KubeadmControlPlane, err error) {
+	if len(objs) == 0 {
+		return nil, nil, nil
+	}
+
+	snapshot = &controlplanev1.KubeadmControlPlane{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(objs[0].Object, snapshot); err != nil {
+		return nil, nil, errors.Wrap(err, "converting KubeadmControlPlane from rollback snapshot")
+	}
+
+	current = &controlplanev1.KubeadmControlPlane{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(snapshot), current); err != nil {
+		return nil, nil, errors.Wrap(err, "reading KubeadmControlPlane to roll back")
+	}
+
+	snapshotVersion, err := semver.New(snapshot.Spec.Version)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsing KubeadmControlPlane version from rollback snapshot")
+	}
+	currentVersion, err := semver.New(current.Spec.Version)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsing KubeadmControlPlane version")
+	}
+
+	if !currentVersion.SameMinor(snapshotVersion) {
+		return nil, nil, fmt.Errorf("%w: the control plane Kubernetes minor version changed from %s to %s",
+			ErrRollbackNotSupported, snapshot.Spec.Version, current.Spec.Version)
+	}
+
+	return snapshot, current, nil
+}
+
+// etcdadmClusterToRollBack returns the EtcdadmCluster in the snapshot and the current one. It returns nil when
+// there is no EtcdadmCluster or when it didn't change, so the etcd machines are not rolled for nothing.
+func etcdadmClusterToRollBack(ctx context.Context, c client.Client, objs []*unstructured.Unstructured) (snapshot, current *etcdv1.EtcdadmCluster, err error) {
+	if len(objs) == 0 {
+		return nil, nil, nil
+	}
+
+	snapshot = &etcdv1.EtcdadmCluster{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(objs[0].Object, snapshot); err != nil {
+		return nil, nil, errors.Wrap(err, "converting EtcdadmCluster from rollback snapshot")
+	}
+
+	current = &etcdv1.EtcdadmCluster{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(snapshot), current); err != nil {
+		return nil, nil, errors.Wrap(err, "reading EtcdadmCluster to roll back")
+	}
+
+	snapshot.Spec.Replicas = current.Spec.Replicas
+	if equality.Semantic.DeepEqual(snapshot.Spec, current.Spec) {
+		return nil, nil, nil
+	}
+
+	return snapshot, current, nil
+}
+
+// UpgradeFailure returns the reason and a message explaining why the upgrade of a cluster that started at
+// start failed according to its rollback policy. It returns an empty reason while the upgrade hasn't failed.
+func UpgradeFailure(ctx context.Context, c client.Client, cluster *anywherev1.Cluster, start, now time.Time) (reason, message string, err error) {
+	policy := cluster.Spec.UpgradeRollbackPolicy
+	if policy == nil {
+		return "", "", nil
+	}
+
+	if policy.MaxUnhealthyMachines != nil {
+		unhealthy, err := unhealthyMachinesSince(ctx, c, cluster, start)
+		if err != nil {
+			return "", "", err
+		}
+		if len(unhealthy) >= *policy.MaxUnhealthyMachines {
+			return anywherev1.UnhealthyMachinesReason,
+				fmt.Sprintf("%d machines created during the upgrade failed their health checks: %v", len(unhealthy), unhealthy), nil
+		}
+	}
+
+	if policy.Timeout != nil && now.Sub(start) > policy.Timeout.Duration {
+		return anywherev1.UpgradeTimeoutReason,
+			fmt.Sprintf("upgrade didn't complete in %s", policy.Timeout.Duration), nil
+	}
+
+	return "", "", nil
+}
+
+// unhealthyMachinesSince returns the names of the machines of the cluster created after start that failed their MachineHealthCheck.
+func unhealthyMachinesSince(ctx context.Context, c client.Client, cluster *anywherev1.Cluster, start time.Time) ([]string, error) {
+	machines := &clusterv1.MachineList{}
+	if err := c.List(ctx, machines,
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+		client.InNamespace(constants.EksaSystemNamespace),
+	); err != nil {
+		return nil, errors.Wrap(err, "listing machines")
+	}
+
+	var unhealthy []string
+	for i := range machines.Items {
+		m := &machines.Items[i]
+		if m.CreationTimestamp.Time.Before(start) {
+			continue
+		}
+		if v1beta1conditions.IsFalse(m, clusterv1.MachineHealthCheckSucceededCondition) {
+			unhealthy = append(unhealthy, m.Name)
+		}
+	}
+
+	return unhealthy, nil
+}
+
+// rollbackObjects returns the objects of the cluster saved in the rollback snapshot, without their status
+// and the metadata set by the API server.
+func rollbackObjects(ctx context.Context, c client.Client, cluster *anywherev1.Cluster) ([]*unstructured.Unstructured, error) {
+	var typed []client.Object
+	var refs []*corev1.ObjectReference
+
+	kcp, err := controller.GetKubeadmControlPlane(ctx, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if kcp != nil {
+		typed = append(typed, kcp)
+		refs = append(refs, &kcp.Spec.MachineTemplate.InfrastructureRef)
+	}
+
+	capiCluster, err := controller.GetCAPICluster(ctx, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if capiCluster != nil && capiCluster.Spec.ManagedExternalEtcdRef != nil {
+		etcdadmCluster, err := getEtcdadmCluster(ctx, c, capiCluster)
+		if err != nil {
+			return nil, err
+		}
+		typed = append(typed, etcdadmCluster)
+		refs = append(refs, &etcdadmCluster.Spec.InfrastructureTemplate)
+	}
+
+	mds, err := controller.GetMachineDeployments(ctx, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+	for i := range mds {
+		typed = append(typed, &mds[i])
+		refs = append(refs, &mds[i].Spec.Template.Spec.InfrastructureRef, mds[i].Spec.Template.Spec.Bootstrap.ConfigRef)
+	}
+
+	objs := make([]*unstructured.Unstructured, 0, len(typed)+len(refs))
+	for _, obj := range typed {
+		gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+		if err != nil {
+			return nil, err
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		u := &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(gvk)
+		objs = append(objs, u)
+	}
+
+	for _, ref := range refs {
+		if ref == nil || ref.Name == "" {
+			continue
+		}
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(ref.APIVersion)
+		u.SetKind(ref.Kind)
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = constants.EksaSystemNamespace
+		}
+		if err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, u); err != nil {
+			return nil, errors.Wrapf(err, "reading %s %s", ref.Kind, ref.Name)
+		}
+		objs = append(objs, u)
+	}
+
+	for _, u := range objs {
+		unstructured.RemoveNestedField(u.Object, "status")
+		u.SetResourceVersion("")
+		u.SetUID("")
+		u.SetGeneration(0)
+		u.SetCreationTimestamp(metav1.Time{})
+		u.SetManagedFields(nil)
+	}
+
+	return objs, nil
+}
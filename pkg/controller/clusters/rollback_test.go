@@ -0,0 +1,297 @@
+package clusters_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	dockerv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+func TestRollBackUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := eksaCluster()
+	capiCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: cluster.Name, Namespace: constants.EksaSystemNamespace},
+	}
+	kcp := kcpObject(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Spec.Version = "v1.29.0-eks-1-29-1"
+		k.Spec.Replicas = ptr.Int32(3)
+		k.Spec.MachineTemplate.InfrastructureRef = dockerTemplateRef("cp-1")
+	})
+	md := machineDeployment("md-0", constants.EksaSystemNamespace)
+	md.Spec.Template.Spec.Version = ptr.String("v1.29.0-eks-1-29-1")
+	md.Spec.Template.Spec.InfrastructureRef = dockerTemplateRef("md-1")
+	md.Spec.Template.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+		APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+		Kind:       "KubeadmConfigTemplate",
+		Name:       "md-kct-1",
+		Namespace:  constants.EksaSystemNamespace,
+	}
+	c := fake.NewClientBuilder().WithObjects(
+		capiCluster, kcp, md,
+		dockerMachineTemplate("cp-1", constants.EksaSystemNamespace),
+		dockerMachineTemplate("md-1", constants.EksaSystemNamespace),
+		kubeadmConfigTemplate("md-kct-1", constants.EksaSystemNamespace),
+	).Build()
+
+	g.Expect(clusters.SaveRollbackSnapshot(ctx, c, cluster)).To(Succeed())
+
+	snapshot := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "my-cluster-upgrade-rollback", Namespace: constants.EksaSystemNamespace}, snapshot)).To(Succeed())
+	g.Expect(snapshot.OwnerReferences).To(HaveLen(1))
+	g.Expect(snapshot.OwnerReferences[0].Name).To(Equal(capiCluster.Name))
+
+	// The upgrade changes the machines, scales the control plane and the old templates are deleted.
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(kcp), kcp)).To(Succeed())
+	kcp.Spec.Version = "v1.29.3-eks-1-29-8"
+	kcp.Spec.Replicas = ptr.Int32(5)
+	kcp.Spec.MachineTemplate.InfrastructureRef = dockerTemplateRef("cp-2")
+	g.Expect(c.Update(ctx, kcp)).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(md), md)).To(Succeed())
+	md.Spec.Template.Spec.Version = ptr.String("v1.29.3-eks-1-29-8")
+	md.Spec.Template.Spec.InfrastructureRef = dockerTemplateRef("md-2")
+	g.Expect(c.Update(ctx, md)).To(Succeed())
+	g.Expect(c.Delete(ctx, dockerMachineTemplate("cp-1", constants.EksaSystemNamespace))).To(Succeed())
+
+	g.Expect(clusters.RollBackUpgrade(ctx, test.NewNullLogger(), c, cluster)).To(Succeed())
+
+	gotKCP := &controlplanev1.KubeadmControlPlane{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(kcp), gotKCP)).To(Succeed())
+	g.Expect(gotKCP.Spec.Version).To(Equal("v1.29.0-eks-1-29-1"))
+	g.Expect(gotKCP.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("cp-1"))
+	g.Expect(gotKCP.Spec.Replicas).To(Equal(ptr.Int32(5)))
+	// Etcd didn't change, so there is no need to wait for it.
+	g.Expect(gotKCP.Annotations).NotTo(HaveKey(clusterv1.PausedAnnotation))
+
+	gotMD := &clusterv1.MachineDeployment{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(md), gotMD)).To(Succeed())
+	g.Expect(gotMD.Spec.Template.Spec.Version).To(Equal(ptr.String("v1.29.0-eks-1-29-1")))
+	g.Expect(gotMD.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("md-1"))
+
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "cp-1", Namespace: constants.EksaSystemNamespace}, &dockerv1.DockerMachineTemplate{})).To(Succeed())
+}
+
+func TestRollBackUpgradeMinorVersionChanged(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := eksaCluster()
+	kcp := kcpObject(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Spec.Version = "v1.29.0-eks-1-29-1"
+		k.Spec.MachineTemplate.InfrastructureRef = dockerTemplateRef("cp-1")
+	})
+	md := machineDeployment("md-0", constants.EksaSystemNamespace)
+	md.Spec.Template.Spec.InfrastructureRef = dockerTemplateRef("md-1")
+	c := fake.NewClientBuilder().WithObjects(
+		kcp, md,
+		dockerMachineTemplate("cp-1", constants.EksaSystemNamespace),
+		dockerMachineTemplate("md-1", constants.EksaSystemNamespace),
+	).Build()
+
+	g.Expect(clusters.SaveRollbackSnapshot(ctx, c, cluster)).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(kcp), kcp)).To(Succeed())
+	kcp.Spec.Version = "v1.30.0-eks-1-30-1"
+	kcp.Spec.MachineTemplate.InfrastructureRef = dockerTemplateRef("cp-2")
+	g.Expect(c.Update(ctx, kcp)).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(md), md)).To(Succeed())
+	md.Spec.Template.Spec.InfrastructureRef = dockerTemplateRef("md-2")
+	g.Expect(c.Update(ctx, md)).To(Succeed())
+	g.Expect(c.Delete(ctx, dockerMachineTemplate("cp-1", constants.EksaSystemNamespace))).To(Succeed())
+
+	err := clusters.RollBackUpgrade(ctx, test.NewNullLogger(), c, cluster)
+	g.Expect(errors.Is(err, clusters.ErrRollbackNotSupported)).To(BeTrue())
+	g.Expect(err).To(MatchError(ContainSubstring("minor version changed from v1.29.0-eks-1-29-1 to v1.30.0-eks-1-30-1")))
+
+	// Nothing is rolled back.
+	gotKCP := &controlplanev1.KubeadmControlPlane{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(kcp), gotKCP)).To(Succeed())
+	g.Expect(gotKCP.Spec.Version).To(Equal("v1.30.0-eks-1-30-1"))
+	gotMD := &clusterv1.MachineDeployment{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(md), gotMD)).To(Succeed())
+	g.Expect(gotMD.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("md-2"))
+	err = c.Get(ctx, client.ObjectKey{Name: "cp-1", Namespace: constants.EksaSystemNamespace}, &dockerv1.DockerMachineTemplate{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestRollBackUpgradeExternalEtcd(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	cluster := eksaCluster()
+	capiCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: cluster.Name, Namespace: constants.EksaSystemNamespace},
+		Spec: clusterv1.ClusterSpec{
+			ManagedExternalEtcdRef: &corev1.ObjectReference{
+				APIVersion: "etcdcluster.cluster.x-k8s.io/v1beta1",
+				Kind:       "EtcdadmCluster",
+				Name:       "my-cluster-etcd",
+				Namespace:  constants.EksaSystemNamespace,
+			},
+		},
+	}
+	kcp := kcpObject(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Spec.Version = "v1.29.0-eks-1-29-1"
+		k.Spec.MachineTemplate.InfrastructureRef = dockerTemplateRef("cp-1")
+	})
+	etcdadmCluster := &etcdv1.EtcdadmCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-etcd", Namespace: constants.EksaSystemNamespace},
+		Spec: etcdv1.EtcdadmClusterSpec{
+			Replicas:               ptr.Int32(3),
+			InfrastructureTemplate: dockerTemplateRef("etcd-1"),
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(
+		capiCluster, kcp, etcdadmCluster,
+		dockerMachineTemplate("cp-1", constants.EksaSystemNamespace),
+		dockerMachineTemplate("etcd-1", constants.EksaSystemNamespace),
+	).Build()
+
+	g.Expect(clusters.SaveRollbackSnapshot(ctx, c, cluster)).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(etcdadmCluster), etcdadmCluster)).To(Succeed())
+	etcdadmCluster.Spec.InfrastructureTemplate = dockerTemplateRef("etcd-2")
+	etcdadmCluster.Spec.Replicas = ptr.Int32(5)
+	g.Expect(c.Update(ctx, etcdadmCluster)).To(Succeed())
+	g.Expect(c.Delete(ctx, dockerMachineTemplate("etcd-1", constants.EksaSystemNamespace))).To(Succeed())
+
+	g.Expect(clusters.RollBackUpgrade(ctx, test.NewNullLogger(), c, cluster)).To(Succeed())
+
+	got := &etcdv1.EtcdadmCluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(etcdadmCluster), got)).To(Succeed())
+	g.Expect(got.Spec.InfrastructureTemplate.Name).To(Equal("etcd-1"))
+	g.Expect(got.Spec.Replicas).To(Equal(ptr.Int32(5)))
+	g.Expect(got.Annotations).To(HaveKeyWithValue(etcdv1.UpgradeInProgressAnnotation, "true"))
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "etcd-1", Namespace: constants.EksaSystemNamespace}, &dockerv1.DockerMachineTemplate{})).To(Succeed())
+
+	// The control plane waits for etcd to be rolled back.
+	gotKCP := &controlplanev1.KubeadmControlPlane{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(kcp), gotKCP)).To(Succeed())
+	g.Expect(gotKCP.Annotations).To(HaveKeyWithValue(clusterv1.PausedAnnotation, "true"))
+}
+
+func TestRollBackUpgradeNoSnapshot(t *testing.T) {
+	g := NewWithT(t)
+	c := fake.NewClientBuilder().Build()
+
+	err := clusters.RollBackUpgrade(context.Background(), test.NewNullLogger(), c, eksaCluster())
+	g.Expect(err).To(MatchError(ContainSubstring("reading rollback snapshot")))
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestSaveRollbackSnapshotMissingTemplate(t *testing.T) {
+	g := NewWithT(t)
+	kcp := kcpObject(func(k *controlplanev1.KubeadmControlPlane) {
+		k.Spec.MachineTemplate.InfrastructureRef = dockerTemplateRef("cp-1")
+	})
+	c := fake.NewClientBuilder().WithObjects(kcp).Build()
+
+	err := clusters.SaveRollbackSnapshot(context.Background(), c, eksaCluster())
+	g.Expect(err).To(MatchError(ContainSubstring("reading DockerMachineTemplate cp-1")))
+}
+
+func TestUpgradeFailure(t *testing.T) {
+	start := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	oldMachine := rollbackMachine("old", start.Add(-time.Hour), false)
+	newHealthyMachine := rollbackMachine("new-healthy", start.Add(time.Minute), true)
+	newUnhealthyMachine := rollbackMachine("new-unhealthy", start.Add(time.Minute), false)
+
+	tests := []struct {
+		name        string
+		policy      *anywherev1.UpgradeRollbackPolicy
+		machines    []client.Object
+		now         time.Time
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name:   "no policy",
+			policy: nil,
+			now:    start.Add(24 * time.Hour),
+		},
+		{
+			name:   "before timeout",
+			policy: &anywherev1.UpgradeRollbackPolicy{Timeout: &metav1.Duration{Duration: time.Hour}},
+			now:    start.Add(30 * time.Minute),
+		},
+		{
+			name:        "timeout",
+			policy:      &anywherev1.UpgradeRollbackPolicy{Timeout: &metav1.Duration{Duration: time.Hour}},
+			now:         start.Add(2 * time.Hour),
+			wantReason:  anywherev1.UpgradeTimeoutReason,
+			wantMessage: "upgrade didn't complete in 1h0m0s",
+		},
+		{
+			name:     "unhealthy machines under the limit",
+			policy:   &anywherev1.UpgradeRollbackPolicy{MaxUnhealthyMachines: ptr.Int(2)},
+			machines: []client.Object{oldMachine, newHealthyMachine, newUnhealthyMachine},
+			now:      start.Add(time.Hour),
+		},
+		{
+			name:        "unhealthy machines",
+			policy:      &anywherev1.UpgradeRollbackPolicy{MaxUnhealthyMachines: ptr.Int(1)},
+			machines:    []client.Object{oldMachine, newHealthyMachine, newUnhealthyMachine},
+			now:         start.Add(time.Hour),
+			wantReason:  anywherev1.UnhealthyMachinesReason,
+			wantMessage: "1 machines created during the upgrade failed their health checks: [new-unhealthy]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cluster := eksaCluster()
+			cluster.Spec.UpgradeRollbackPolicy = tt.policy
+			c := fake.NewClientBuilder().WithObjects(tt.machines...).Build()
+
+			reason, message, err := clusters.UpgradeFailure(context.Background(), c, cluster, start, tt.now)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(reason).To(Equal(tt.wantReason))
+			g.Expect(message).To(Equal(tt.wantMessage))
+		})
+	}
+}
+
+func dockerTemplateRef(name string) corev1.ObjectReference {
+	return corev1.ObjectReference{
+		APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2",
+		Kind:       "DockerMachineTemplate",
+		Name:       name,
+		Namespace:  constants.EksaSystemNamespace,
+	}
+}
+
+func rollbackMachine(name string, created time.Time, healthy bool) *clusterv1.Machine {
+	status := corev1.ConditionFalse
+	if healthy {
+		status = corev1.ConditionTrue
+	}
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         constants.EksaSystemNamespace,
+			Labels:            map[string]string{clusterv1.ClusterNameLabel: "my-cluster"},
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Status: clusterv1.MachineStatus{
+			Conditions: clusterv1.Conditions{
+				{Type: clusterv1.MachineHealthCheckSucceededCondition, Status: status},
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package clusters
+
+import (
+	"context"
+	"reflect"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type deferredRolloutsKey struct{}
+
+// DeferredRollouts records the objects with changes that would roll machines that were held back
+// during a reconciliation. The rest of the changes to those objects are still applied.
+type DeferredRollouts struct {
+	objects []string
+}
+
+// DeferMachineRollouts returns a context in which the control plane and workers reconciliation hold back
+// the changes that roll machines: the changes to etcd, the KubeadmControlPlane version, config and machine
+// template and the MachineDeployments machine template. New clusters and new worker groups are created as usual.
+func DeferMachineRollouts(ctx context.Context) (context.Context, *DeferredRollouts) {
+	d := &DeferredRollouts{}
+	return context.WithValue(ctx, deferredRolloutsKey{}, d), d
+}
+
+// Deferred returns true if any change that would roll machines was held back.
+func (d *DeferredRollouts) Deferred() bool {
+	return d != nil && len(d.objects) > 0
+}
+
+// Objects returns the kind and name of the objects with changes held back.
+func (d *DeferredRollouts) Objects() []string {
+	if d == nil {
+		return nil
+	}
+	return d.objects
+}
+
+// Add records obj as having changes that roll machines held back.
+func (d *DeferredRollouts) Add(log logr.Logger, obj client.Object) {
+	ref := reflect.TypeOf(obj).Elem().Name() + " " + klog.KObj(obj).String()
+	log.Info("Holding back changes that roll machines", "object", ref)
+	d.objects = append(d.objects, ref)
+}
+
+// DeferredRolloutsFrom returns the DeferredRollouts of a context created with DeferMachineRollouts.
+// It returns nil when the changes that roll machines can be applied.
+func DeferredRolloutsFrom(ctx context.Context) *DeferredRollouts {
+	d, _ := ctx.Value(deferredRolloutsKey{}).(*DeferredRollouts)
+	return d
+}
+
+// deferEtcdRollout returns true when the etcd changes are held back.
+func deferEtcdRollout(ctx context.Context, log logr.Logger, current *etcdv1.EtcdadmCluster) bool {
+	d := DeferredRolloutsFrom(ctx)
+	if d == nil {
+		return false
+	}
+
+	d.Add(log, current)
+	return true
+}
+
+// holdBackKCPRollout keeps the current version, config and machine template in the desired
+// KubeadmControlPlane when rollouts are deferred and any of them changed.
+func holdBackKCPRollout(ctx context.Context, log logr.Logger, desired, current *controlplanev1.KubeadmControlPlane) {
+	d := DeferredRolloutsFrom(ctx)
+	if d == nil || !kcpRollout(desired, current) {
+		return
+	}
+
+	d.Add(log, current)
+	desired.Spec.Version = current.Spec.Version
+	desired.Spec.MachineTemplate.InfrastructureRef = current.Spec.MachineTemplate.InfrastructureRef
+	desired.Spec.KubeadmConfigSpec = *current.Spec.KubeadmConfigSpec.DeepCopy()
+}
+
+func kcpRollout(desired, current *controlplanev1.KubeadmControlPlane) bool {
+	return desired.Spec.Version != current.Spec.Version ||
+		desired.Spec.MachineTemplate.InfrastructureRef.Name != current.Spec.MachineTemplate.InfrastructureRef.Name ||
+		!equality.Semantic.DeepDerivative(desired.Spec.KubeadmConfigSpec, current.Spec.KubeadmConfigSpec)
+}
+
+// holdBackMachineDeploymentRollouts keeps the current machine template in the desired MachineDeployments
+// when rollouts are deferred and the template changed.
+func holdBackMachineDeploymentRollouts(ctx context.Context, log logr.Logger, w *Workers, current []clusterv1.MachineDeployment) {
+	d := DeferredRolloutsFrom(ctx)
+	if d == nil {
+		return
+	}
+
+	currentByName := make(map[string]*clusterv1.MachineDeployment, len(current))
+	for i := range current {
+		currentByName[current[i].Name] = &current[i]
+	}
+
+	for _, g := range w.Groups {
+		c, ok := currentByName[g.MachineDeployment.Name]
+		if !ok || !machineDeploymentRollout(&g.MachineDeployment.Spec.Template.Spec, &c.Spec.Template.Spec) {
+			continue
+		}
+
+		d.Add(log, c)
+		g.MachineDeployment.Spec.Template.Spec = *c.Spec.Template.Spec.DeepCopy()
+	}
+}
+
+func machineDeploymentRollout(desired, current *clusterv1.MachineSpec) bool {
+	return !reflect.DeepEqual(desired.Version, current.Version) ||
+		desired.InfrastructureRef.Name != current.InfrastructureRef.Name ||
+		!reflect.DeepEqual(desired.FailureDomain, current.FailureDomain) ||
+		bootstrapConfigName(desired) != bootstrapConfigName(current)
+}
+
+func bootstrapConfigName(m *clusterv1.MachineSpec) string {
+	if m.Bootstrap.ConfigRef == nil {
+		return ""
+	}
+	return m.Bootstrap.ConfigRef.Name
+}
@@ -89,17 +89,17 @@ func ReconcileWorkersForEKSA(ctx context.Context, log logr.Logger, c client.Clie
 		return controller.ResultWithRequeue(5 * time.Second), nil
 	}
 
-	return ReconcileWorkers(ctx, c, capiCluster, w)
+	return reconcileWorkers(ctx, log, c, capiCluster, w)
 }
 
 // ReconcileWorkers orchestrates the worker node reconciliation logic.
 // It takes care of applying all desired objects in the Workers spec and deleting the
 // old MachineDeployments that are not in it.
 func ReconcileWorkers(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, w *Workers) (controller.Result, error) {
-	if err := serverside.ReconcileObjects(ctx, c, w.objects()); err != nil {
-		return controller.Result{}, errors.Wrap(err, "applying worker nodes CAPI objects")
-	}
+	return reconcileWorkers(ctx, logr.FromContextOrDiscard(ctx), c, cluster, w)
+}
 
+func reconcileWorkers(ctx context.Context, log logr.Logger, c client.Client, cluster *clusterv1.Cluster, w *Workers) (controller.Result, error) {
 	machineDeployments := &clusterv1.MachineDeploymentList{}
 	if err := c.List(ctx, machineDeployments,
 		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
@@ -107,6 +107,12 @@ func ReconcileWorkers(ctx context.Context, c client.Client, cluster *clusterv1.C
 		return controller.Result{}, errors.Wrap(err, "listing current machine deployments")
 	}
 
+	holdBackMachineDeploymentRollouts(ctx, log, w, machineDeployments.Items)
+
+	if err := serverside.ReconcileObjects(ctx, c, w.objects()); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying worker nodes CAPI objects")
+	}
+
 	desiredMachineDeploymentNames := collection.MapSet(w.Groups, func(g WorkerGroup) string {
 		return g.MachineDeployment.Name
 	})
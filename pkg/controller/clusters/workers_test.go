@@ -21,6 +21,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clusters"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 func TestReconcileWorkersSuccess(t *testing.T) {
@@ -124,6 +125,41 @@ func TestToWorkers(t *testing.T) {
 	g.Expect(clusters.ToWorkers(w)).To(Equal(want))
 }
 
+func TestReconcileWorkersDeferredRollouts(t *testing.T) {
+	g := NewWithT(t)
+	c := env.Client()
+	api := envtest.NewAPIExpecter(t, c)
+	ctx := context.Background()
+	ns := env.CreateNamespaceForTest(ctx, t)
+	w := workers(ns)
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: ns,
+		},
+	}
+	envtest.CreateObjs(ctx, t, c, w.Groups[0].MachineDeployment, w.Groups[1].MachineDeployment)
+
+	w = workers(ns)
+	w.Groups[0].MachineDeployment.Spec.Replicas = ptr.Int32(2)
+	w.Groups[0].MachineDeployment.Spec.Template.Spec.Version = ptr.String("v1.29.0")
+	w.Groups[1].MachineDeployment.Spec.Replicas = ptr.Int32(3)
+
+	ctx, deferred := clusters.DeferMachineRollouts(ctx)
+	g.Expect(clusters.ReconcileWorkers(ctx, c, cluster, w)).To(Equal(controller.Result{}))
+	g.Expect(deferred.Objects()).To(ConsistOf("MachineDeployment " + ns + "/my-cluster-md-0"))
+
+	md0 := envtest.CloneNameNamespace(w.Groups[0].MachineDeployment)
+	api.ShouldEventuallyMatch(ctx, md0, func(g Gomega) {
+		g.Expect(md0.Spec.Replicas).To(HaveValue(BeEquivalentTo(2)), "replicas should have been updated")
+		g.Expect(md0.Spec.Template.Spec.Version).To(BeNil(), "version should not have been updated")
+	})
+	md1 := envtest.CloneNameNamespace(w.Groups[1].MachineDeployment)
+	api.ShouldEventuallyMatch(ctx, md1, func(g Gomega) {
+		g.Expect(md1.Spec.Replicas).To(HaveValue(BeEquivalentTo(3)), "replicas should have been updated")
+	})
+}
+
 func workers(namespace string) *clusters.Workers {
 	return &clusters.Workers{
 		Groups: []clusters.WorkerGroup{
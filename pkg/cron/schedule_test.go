@@ -0,0 +1,134 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/cron"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{
+			name:    "wrong number of fields",
+			expr:    "0 22 * *",
+			wantErr: "must have 5 fields, found 4",
+		},
+		{
+			name:    "value out of range",
+			expr:    "60 22 * * *",
+			wantErr: "invalid value \"60\" in minute field, must be between 0 and 59",
+		},
+		{
+			name:    "not a number",
+			expr:    "0 22 * * mon",
+			wantErr: "invalid value \"mon\" in day of week field",
+		},
+		{
+			name:    "inverted range",
+			expr:    "0 22 * * 5-1",
+			wantErr: "invalid range \"5-1\" in day of week field",
+		},
+		{
+			name:    "invalid step",
+			expr:    "*/0 * * * *",
+			wantErr: "invalid step \"0\" in minute field",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := cron.Parse(tt.expr)
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		t    time.Time
+		want time.Time
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+			t:    time.Date(2026, 10, 19, 10, 15, 30, 0, time.UTC),
+			want: time.Date(2026, 10, 19, 10, 16, 0, 0, time.UTC),
+		},
+		{
+			name: "later the same day",
+			expr: "0 22 * * *",
+			t:    time.Date(2026, 10, 19, 10, 15, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "at the start is the next day",
+			expr: "0 22 * * *",
+			t:    time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 20, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekdays from friday",
+			expr: "30 1 * * 1-5",
+			t:    time.Date(2026, 10, 23, 2, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 26, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "sunday as 7",
+			expr: "0 0 * * 7",
+			t:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "steps and lists",
+			expr: "*/20 8,20 * * *",
+			t:    time.Date(2026, 10, 19, 8, 41, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 0 1 * 3",
+			t:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "next year",
+			expr: "0 0 1 1 *",
+			t:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "in time zone",
+			expr: "0 22 * * *",
+			t:    time.Date(2026, 10, 19, 12, 0, 0, 0, newYork),
+			want: time.Date(2026, 10, 19, 22, 0, 0, 0, newYork),
+		},
+		{
+			name: "never",
+			expr: "0 0 30 2 *",
+			t:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s, err := cron.Parse(tt.expr)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(s.Next(tt.t)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package curatedpackages
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+	"sigs.k8s.io/yaml"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+)
+
+const (
+	// configRoot prefixes the key paths of the configuration errors.
+	configRoot = "config"
+	// maxRefDepth bounds the inlining of recursive schema references.
+	maxRefDepth = 10
+)
+
+// ValidateConfig validates the configuration of a package against the values JSON schema of its
+// version in the bundle. The errors point at the offending key paths of the configuration.
+// Packages whose version doesn't have a schema are not validated.
+func ValidateConfig(bundle *packagesv1.PackageBundle, p packagesv1.Package) error {
+	messages, err := configErrors(bundle, p)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	for i := range messages {
+		messages[i] = "- " + messages[i]
+	}
+
+	return fmt.Errorf("invalid configuration for package %s:\n%s", p.Name, strings.Join(messages, "\n"))
+}
+
+// configErrors returns the sorted errors of the validation of a package configuration against its schema.
+func configErrors(bundle *packagesv1.PackageBundle, p packagesv1.Package) ([]string, error) {
+	bp, version, err := packageVersion(bundle, p)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := versionSchema(bp, version)
+	if err != nil || schema == nil {
+		return nil, err
+	}
+
+	var config map[string]interface{}
+	if err := yaml.Unmarshal([]byte(p.Spec.Config), &config); err != nil {
+		return nil, fmt.Errorf("reading configuration of package %s: %v", p.Name, err)
+	}
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+
+	result := validate.NewSchemaValidator(schema, schema, configRoot, strfmt.Default).Validate(config)
+	messages := make([]string, 0, len(result.Errors))
+	for _, e := range result.Errors {
+		messages = append(messages, validationMessage(e))
+	}
+	sort.Strings(messages)
+
+	return messages, nil
+}
+
+// versionSchema returns the values schema of a package version, nil if it doesn't have one.
+func versionSchema(bp packagesv1.BundlePackage, version packagesv1.SourceVersion) (*spec.Schema, error) {
+	if version.Schema == "" {
+		return nil, nil
+	}
+	schemaJSON, err := bp.GetJsonSchema(&version)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema of package %s: %v", bp.Name, err)
+	}
+	schema, err := parseSchema(schemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema of package %s: %v", bp.Name, err)
+	}
+
+	return schema, nil
+}
+
+func validationMessage(err error) string {
+	if v, ok := err.(*errors.Validation); ok && v.In != "" {
+		return strings.Replace(v.Error(), " in "+v.In, "", 1)
+	}
+	return err.Error()
+}
+
+// parseSchema reads a JSON schema, inlining its local references since the validator doesn't resolve them.
+// References to other documents are not followed and accept any value.
+func parseSchema(schemaJSON []byte) (*spec.Schema, error) {
+	var root interface{}
+	if err := json.Unmarshal(schemaJSON, &root); err != nil {
+		return nil, err
+	}
+	inlined, err := json.Marshal(inlineRefs(root, root, 0))
+	if err != nil {
+		return nil, err
+	}
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(inlined, schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+func inlineRefs(node, root interface{}, depth int) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := n["$ref"].(string); ok {
+			target, found := resolveRef(root, ref)
+			if !found || depth >= maxRefDepth {
+				return map[string]interface{}{}
+			}
+			return inlineRefs(target, root, depth+1)
+		}
+		inlined := make(map[string]interface{}, len(n))
+		for k, v := range n {
+			inlined[k] = inlineRefs(v, root, depth)
+		}
+		return inlined
+	case []interface{}:
+		inlined := make([]interface{}, 0, len(n))
+		for _, v := range n {
+			inlined = append(inlined, inlineRefs(v, root, depth))
+		}
+		return inlined
+	default:
+		return node
+	}
+}
+
+// resolveRef resolves a JSON pointer reference in the same document, like #/definitions/port.
+func resolveRef(root interface{}, ref string) (interface{}, bool) {
+	pointer, local := strings.CutPrefix(ref, "#")
+	if !local {
+		return nil, false
+	}
+	node := root
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if node, ok = m[token]; !ok {
+			return nil, false
+		}
+	}
+
+	return node, true
+}
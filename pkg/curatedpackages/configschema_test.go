@@ -0,0 +1,151 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+const harborSchema = `{
+  "$schema": "http://json-schema.org/schema#",
+  "type": "object",
+  "additionalProperties": false,
+  "definitions": {
+    "port": {"type": "integer", "minimum": 1}
+  },
+  "properties": {
+    "externalURL": {"type": "string"},
+    "replicas": {"type": "integer"},
+    "expose": {
+      "type": "object",
+      "properties": {
+        "type": {"type": "string", "enum": ["clusterIP", "nodePort", "loadBalancer"]},
+        "nodePort": {
+          "type": "object",
+          "properties": {"ports": {"type": "array", "items": {"$ref": "#/definitions/port"}}}
+        },
+        "tls": {"$ref": "#/properties/tls"}
+      }
+    },
+    "tls": {
+      "type": "object",
+      "properties": {"enabled": {"type": "boolean"}, "tls": {"$ref": "#/properties/tls"}}
+    },
+    "remote": {"$ref": "https://example.com/schema.json"}
+  },
+  "required": ["externalURL"]
+}`
+
+func encodeSchema(t *testing.T, schema string) string {
+	t.Helper()
+	b := &bytes.Buffer{}
+	w := gzip.NewWriter(b)
+	if _, err := w.Write([]byte(schema)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes())
+}
+
+func schemaBundle(t *testing.T) *packagesv1.PackageBundle {
+	bundle := dependenciesBundle()
+	harbor := bundlePackage("harbor")
+	harbor.Source.Versions[0].Schema = encodeSchema(t, harborSchema)
+	bundle.Spec.Packages = append(bundle.Spec.Packages, harbor)
+	return bundle
+}
+
+func configuredPackage(packageName, config string) packagesv1.Package {
+	p := namedPackage("my-"+packageName, packageName)
+	p.Spec.Config = config
+	return p
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       packagesv1.Package
+		wantErr string
+	}{
+		{
+			name: "valid",
+			p: configuredPackage("harbor", `
+externalURL: https://harbor.local
+expose:
+  type: nodePort
+  nodePort:
+    ports: [30003]
+  tls:
+    enabled: true
+remote: anything
+`),
+		},
+		{
+			name: "invalid key paths",
+			p: configuredPackage("harbor", `
+externalURL: https://harbor.local
+replica: 2
+expose:
+  type: ingress
+  nodePort:
+    ports: [0, "https"]
+  tls:
+    enabled: "yes"
+`),
+			wantErr: `invalid configuration for package my-harbor:
+- config.expose.nodePort.ports[0] should be greater than or equal to 1
+- config.expose.nodePort.ports[1] must be of type integer: "string"
+- config.expose.tls.enabled must be of type boolean: "string"
+- config.expose.type should be one of [clusterIP nodePort loadBalancer]
+- config.replica is a forbidden property`,
+		},
+		{
+			name:    "empty config",
+			p:       configuredPackage("harbor", ""),
+			wantErr: "- config.externalURL is required",
+		},
+		{
+			name:    "not a map",
+			p:       configuredPackage("harbor", "externalURL"),
+			wantErr: "reading configuration of package my-harbor",
+		},
+		{
+			name: "no schema",
+			p:    configuredPackage("adot", "anything: goes"),
+		},
+		{
+			name:    "unknown package",
+			p:       configuredPackage("unknown", ""),
+			wantErr: "package not found in bundle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := curatedpackages.ValidateConfig(schemaBundle(t), tt.p)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestValidateConfigInvalidSchema(t *testing.T) {
+	g := NewWithT(t)
+	bundle := schemaBundle(t)
+	bundle.Spec.Packages[len(bundle.Spec.Packages)-1].Source.Versions[0].Schema = encodeSchema(t, "{")
+
+	err := curatedpackages.ValidateConfig(bundle, configuredPackage("harbor", ""))
+	g.Expect(err).To(MatchError(ContainSubstring("reading schema of package harbor")))
+}
@@ -0,0 +1,163 @@
+package curatedpackages
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// Dependencies returns the names of the bundle packages the package depends on, as declared by
+// its version in the bundle. Packages without a version use the latest one.
+func Dependencies(bundle *packagesv1.PackageBundle, p packagesv1.Package) ([]string, error) {
+	_, v, err := packageVersion(bundle, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Dependencies, nil
+}
+
+// packageVersion returns the bundle package of a package and its version, the latest one if not set.
+func packageVersion(bundle *packagesv1.PackageBundle, p packagesv1.Package) (packagesv1.BundlePackage, packagesv1.SourceVersion, error) {
+	bp, err := bundle.FindPackage(p.Spec.PackageName)
+	if err != nil {
+		return bp, packagesv1.SourceVersion{}, err
+	}
+	version := p.Spec.PackageVersion
+	if version == "" {
+		version = packagesv1.Latest
+	}
+	v, err := bundle.FindVersion(bp, version)
+	if err != nil {
+		return bp, v, err
+	}
+
+	return bp, v, nil
+}
+
+// InstallOrder returns the packages to create so that every package is created after the packages
+// it depends on. Dependencies neither in packages nor installed are added, named like the packages
+// generated by GeneratePackages. It fails if the dependencies have a cycle.
+func InstallOrder(bundle *packagesv1.PackageBundle, packages, installed []packagesv1.Package) ([]packagesv1.Package, error) {
+	toCreate := map[string]packagesv1.Package{}
+	for _, p := range packages {
+		toCreate[packageKey(p.Namespace, p.Spec.PackageName)] = p
+	}
+	alreadyInstalled := map[string]bool{}
+	for _, p := range installed {
+		alreadyInstalled[packageKey(p.Namespace, p.Spec.PackageName)] = true
+	}
+
+	var ordered []packagesv1.Package
+	done := map[string]bool{}
+	var visiting []string
+	var visit func(p packagesv1.Package) error
+	visit = func(p packagesv1.Package) error {
+		key := packageKey(p.Namespace, p.Spec.PackageName)
+		if done[key] {
+			return nil
+		}
+		for i, v := range visiting {
+			if v == key {
+				cycle := append(append([]string{}, visiting[i:]...), key)
+				return fmt.Errorf("packages dependency cycle: %s", strings.Join(packageNames(cycle), " -> "))
+			}
+		}
+		visiting = append(visiting, key)
+
+		dependencies, err := Dependencies(bundle, p)
+		if err != nil {
+			return err
+		}
+		for _, d := range dependencies {
+			depKey := packageKey(p.Namespace, d)
+			dependency, found := toCreate[depKey]
+			if !found && alreadyInstalled[depKey] {
+				continue
+			}
+			if !found {
+				bp, err := bundle.FindPackage(d)
+				if err != nil {
+					return fmt.Errorf("dependency of package %s: %v", p.Spec.PackageName, err)
+				}
+				clusterName := strings.TrimPrefix(p.Namespace, constants.EksaPackagesName+"-")
+				dependency = convertBundlePackageToPackage(bp, CustomName+strings.ToLower(bp.Name), clusterName, bundle.APIVersion, "")
+				toCreate[depKey] = dependency
+			}
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		visiting = visiting[:len(visiting)-1]
+		done[key] = true
+		ordered = append(ordered, p)
+		return nil
+	}
+
+	for _, p := range packages {
+		if err := visit(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// ValidateDeletion returns an error if an installed package, other than the deleted ones, depends on
+// a deleted package. Deleted packages are referenced by the name of their Package object.
+func ValidateDeletion(bundle *packagesv1.PackageBundle, installed []packagesv1.Package, deleted []string) error {
+	isDeleted := map[string]bool{}
+	for _, name := range deleted {
+		isDeleted[name] = true
+	}
+	// A bundle package installed under several names is still installed while one of them remains.
+	removed, remaining := map[string]bool{}, map[string]bool{}
+	for _, p := range installed {
+		if isDeleted[p.Name] {
+			removed[packageKey(p.Namespace, p.Spec.PackageName)] = true
+		} else {
+			remaining[packageKey(p.Namespace, p.Spec.PackageName)] = true
+		}
+	}
+
+	var required []string
+	for _, p := range installed {
+		if isDeleted[p.Name] {
+			continue
+		}
+		dependencies, err := Dependencies(bundle, p)
+		if err != nil {
+			// Packages not in the active bundle anymore can't declare dependencies.
+			continue
+		}
+		for _, d := range dependencies {
+			key := packageKey(p.Namespace, d)
+			if !removed[key] || remaining[key] {
+				continue
+			}
+			required = append(required, fmt.Sprintf("%s is required by %s", d, p.Name))
+		}
+	}
+	if len(required) == 0 {
+		return nil
+	}
+	sort.Strings(required)
+
+	return fmt.Errorf("packages are dependencies of installed packages: %s", strings.Join(required, ", "))
+}
+
+func packageKey(namespace, packageName string) string {
+	return namespace + "/" + strings.ToLower(packageName)
+}
+
+func packageNames(keys []string) []string {
+	names := make([]string, 0, len(keys))
+	for _, k := range keys {
+		names = append(names, k[strings.Index(k, "/")+1:])
+	}
+	return names
+}
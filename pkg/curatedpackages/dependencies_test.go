@@ -0,0 +1,176 @@
+package curatedpackages_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+const dependenciesNamespace = "eksa-packages-billy"
+
+func bundlePackage(name string, dependencies ...string) packagesv1.BundlePackage {
+	return packagesv1.BundlePackage{
+		Name: name,
+		Source: packagesv1.BundlePackageSource{
+			Versions: []packagesv1.SourceVersion{{Name: "1.0.0", Dependencies: dependencies}},
+		},
+	}
+}
+
+func dependenciesBundle() *packagesv1.PackageBundle {
+	return &packagesv1.PackageBundle{
+		TypeMeta: metav1.TypeMeta{APIVersion: "packages.eks.amazonaws.com/v1alpha1"},
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{
+				bundlePackage("cert-manager"),
+				bundlePackage("adot", "cert-manager"),
+				bundlePackage("emissary", "cert-manager"),
+				bundlePackage("prometheus"),
+				bundlePackage("dashboards", "prometheus", "adot"),
+			},
+		},
+	}
+}
+
+func namedPackage(name, packageName string) packagesv1.Package {
+	return packagesv1.Package{
+		TypeMeta:   metav1.TypeMeta{Kind: "Package", APIVersion: "packages.eks.amazonaws.com/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: dependenciesNamespace},
+		Spec:       packagesv1.PackageSpec{PackageName: packageName},
+	}
+}
+
+func packageObjectNames(packages []packagesv1.Package) []string {
+	names := make([]string, 0, len(packages))
+	for _, p := range packages {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestDependencies(t *testing.T) {
+	g := NewWithT(t)
+	bundle := dependenciesBundle()
+
+	g.Expect(curatedpackages.Dependencies(bundle, namedPackage("my-adot", "ADOT"))).To(Equal([]string{"cert-manager"}))
+	g.Expect(curatedpackages.Dependencies(bundle, namedPackage("my-certs", "cert-manager"))).To(BeEmpty())
+
+	_, err := curatedpackages.Dependencies(bundle, namedPackage("my-harbor", "harbor"))
+	g.Expect(err).To(MatchError(ContainSubstring("package not found in bundle")))
+
+	p := namedPackage("my-adot", "adot")
+	p.Spec.PackageVersion = "2.0.0"
+	_, err = curatedpackages.Dependencies(bundle, p)
+	g.Expect(err).To(MatchError(ContainSubstring("package version not found in bundle")))
+}
+
+func TestInstallOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		packages  []packagesv1.Package
+		installed []packagesv1.Package
+		want      []string
+	}{
+		{
+			name:     "dependencies in the packages",
+			packages: []packagesv1.Package{namedPackage("my-emissary", "emissary"), namedPackage("my-adot", "adot"), namedPackage("my-certs", "cert-manager")},
+			want:     []string{"my-certs", "my-emissary", "my-adot"},
+		},
+		{
+			name:     "missing dependencies",
+			packages: []packagesv1.Package{namedPackage("my-dashboards", "dashboards")},
+			want:     []string{"generated-prometheus", "generated-cert-manager", "generated-adot", "my-dashboards"},
+		},
+		{
+			name:      "installed dependencies",
+			packages:  []packagesv1.Package{namedPackage("my-dashboards", "dashboards")},
+			installed: []packagesv1.Package{namedPackage("certs", "cert-manager"), namedPackage("prom", "prometheus")},
+			want:      []string{"generated-adot", "my-dashboards"},
+		},
+		{
+			name:     "no dependencies",
+			packages: []packagesv1.Package{namedPackage("my-prometheus", "prometheus")},
+			want:     []string{"my-prometheus"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := curatedpackages.InstallOrder(dependenciesBundle(), tt.packages, tt.installed)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(packageObjectNames(got)).To(Equal(tt.want))
+			for _, p := range got {
+				g.Expect(p.Namespace).To(Equal(dependenciesNamespace))
+			}
+		})
+	}
+}
+
+func TestInstallOrderErrors(t *testing.T) {
+	g := NewWithT(t)
+	bundle := dependenciesBundle()
+	bundle.Spec.Packages = append(bundle.Spec.Packages,
+		bundlePackage("a", "b"),
+		bundlePackage("b", "c"),
+		bundlePackage("c", "a"),
+		bundlePackage("broken", "unknown"),
+	)
+
+	_, err := curatedpackages.InstallOrder(bundle, []packagesv1.Package{namedPackage("my-a", "a")}, nil)
+	g.Expect(err).To(MatchError("packages dependency cycle: a -> b -> c -> a"))
+
+	_, err = curatedpackages.InstallOrder(bundle, []packagesv1.Package{namedPackage("my-broken", "broken")}, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("dependency of package broken")))
+}
+
+func TestValidateDeletion(t *testing.T) {
+	installed := []packagesv1.Package{
+		namedPackage("certs", "cert-manager"),
+		namedPackage("my-adot", "adot"),
+		namedPackage("my-emissary", "emissary"),
+		namedPackage("old", "removed-from-bundle"),
+	}
+
+	tests := []struct {
+		name      string
+		installed []packagesv1.Package
+		deleted   []string
+		wantErr   string
+	}{
+		{
+			name:    "dependency of installed packages",
+			deleted: []string{"certs"},
+			wantErr: "packages are dependencies of installed packages: cert-manager is required by my-adot, cert-manager is required by my-emissary",
+		},
+		{
+			name:    "dependency deleted with its dependents",
+			deleted: []string{"certs", "my-adot", "my-emissary"},
+		},
+		{
+			name:    "dependent package",
+			deleted: []string{"my-adot", "old"},
+		},
+		{
+			name:      "dependency installed twice",
+			installed: []packagesv1.Package{namedPackage("other-certs", "cert-manager")},
+			deleted:   []string{"certs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := curatedpackages.ValidateDeletion(dependenciesBundle(), append(installed, tt.installed...), tt.deleted)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
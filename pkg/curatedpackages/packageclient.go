@@ -1,23 +1,35 @@
 package curatedpackages
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/templater"
 )
 
 const (
 	CustomName = "generated-"
 	kind       = "Package"
+
+	packageInstallTimeout = 15 * time.Minute
+	packageInstallBackoff = 5 * time.Second
 )
 
 type PackageClientOpt func(*PackageClient)
@@ -27,11 +39,13 @@ type PackageClient struct {
 	customPackages []string
 	kubectl        KubectlRunner
 	customConfigs  []string
+	retrier        *retrier.Retrier
 }
 
 func NewPackageClient(kubectl KubectlRunner, options ...PackageClientOpt) *PackageClient {
 	pc := &PackageClient{
 		kubectl: kubectl,
+		retrier: retrier.New(packageInstallTimeout, retrier.WithRetryPolicy(retrier.BackOffPolicy(packageInstallBackoff))),
 	}
 	for _, o := range options {
 		o(pc)
@@ -117,6 +131,8 @@ func (pc *PackageClient) packageMap() map[string]packagesv1.BundlePackage {
 	return pMap
 }
 
+// InstallPackage creates a package from the bundle. The packages it depends on that are not installed
+// are created first, waiting for each dependency to be installed before creating the packages depending on it.
 func (pc *PackageClient) InstallPackage(ctx context.Context, bp *packagesv1.BundlePackage, customName string, clusterName string, kubeConfig string) error {
 	configString, err := pc.getInstallConfigurations()
 	if err != nil {
@@ -124,6 +140,21 @@ func (pc *PackageClient) InstallPackage(ctx context.Context, bp *packagesv1.Bund
 	}
 
 	p := convertBundlePackageToPackage(*bp, customName, clusterName, pc.bundle.APIVersion, configString)
+	if err := ValidateConfig(pc.bundle, p); err != nil {
+		return err
+	}
+	dependencies, err := Dependencies(pc.bundle, p)
+	if err != nil {
+		return err
+	}
+	if len(dependencies) == 0 {
+		return pc.createPackage(ctx, p, kubeConfig)
+	}
+
+	return pc.createPackagesInOrder(ctx, pc.bundle, []packagesv1.Package{p}, kubeConfig)
+}
+
+func (pc *PackageClient) createPackage(ctx context.Context, p packagesv1.Package, kubeConfig string) error {
 	displayPackage := NewDisplayablePackage(&p)
 	params := []string{"create", "-f", "-", "--kubeconfig", kubeConfig}
 	packageYaml, err := yaml.Marshal(displayPackage)
@@ -138,6 +169,95 @@ func (pc *PackageClient) InstallPackage(ctx context.Context, bp *packagesv1.Bund
 	return nil
 }
 
+// createPackagesInOrder creates the packages and their missing dependencies in dependency order. Before
+// creating a package, it waits for the packages it depends on to be installed.
+func (pc *PackageClient) createPackagesInOrder(ctx context.Context, bundle *packagesv1.PackageBundle, packages []packagesv1.Package, kubeConfig string) error {
+	var installed []packagesv1.Package
+	listed := map[string]bool{}
+	for _, p := range packages {
+		if listed[p.Namespace] {
+			continue
+		}
+		listed[p.Namespace] = true
+		l, err := listPackages(ctx, pc.kubectl, p.Namespace, kubeConfig)
+		if err != nil {
+			return err
+		}
+		installed = append(installed, l...)
+	}
+
+	ordered, err := InstallOrder(bundle, packages, installed)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]string{}
+	for _, p := range append(installed, ordered...) {
+		names[packageKey(p.Namespace, p.Spec.PackageName)] = p.Name
+	}
+	for _, p := range ordered {
+		dependencies, err := Dependencies(bundle, p)
+		if err != nil {
+			return err
+		}
+		for _, d := range dependencies {
+			if err := pc.waitForPackage(ctx, names[packageKey(p.Namespace, d)], p.Namespace, kubeConfig); err != nil {
+				return err
+			}
+		}
+		logger.V(2).Info("Creating package", "package", p.Name, "namespace", p.Namespace)
+		if err := pc.createPackage(ctx, p, kubeConfig); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func listPackages(ctx context.Context, kubectl KubectlRunner, namespace, kubeConfig string) ([]packagesv1.Package, error) {
+	params := []string{"get", "packages", "-o", "json", "--kubeconfig", kubeConfig, "--namespace", namespace}
+	stdOut, err := kubectl.ExecuteCommand(ctx, params...)
+	if err != nil {
+		return nil, fmt.Errorf("listing packages: %v", err)
+	}
+	list := &packagesv1.PackageList{}
+	if err := json.Unmarshal(stdOut.Bytes(), list); err != nil {
+		return nil, fmt.Errorf("unmarshaling packages: %w", err)
+	}
+	return list.Items, nil
+}
+
+func (pc *PackageClient) waitForPackage(ctx context.Context, name, namespace, kubeConfig string) error {
+	logger.V(3).Info("Waiting for package to be installed", "package", name)
+	err := pc.retrier.Retry(func() error {
+		params := []string{"get", "package", name, "-o", "json", "--kubeconfig", kubeConfig, "--namespace", namespace}
+		stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
+		if err != nil {
+			return err
+		}
+		p := &packagesv1.Package{}
+		if err := json.Unmarshal(stdOut.Bytes(), p); err != nil {
+			return fmt.Errorf("unmarshaling package: %w", err)
+		}
+		if p.Status.State != packagesv1.StateInstalled {
+			return fmt.Errorf("package state is %q", p.Status.State)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for package %s to be installed: %v", name, err)
+	}
+	return nil
+}
+
+// packageBundle returns the client bundle or, if not set, the active bundle of the cluster.
+func (pc *PackageClient) packageBundle(ctx context.Context, kubeConfig, clusterName string) (*packagesv1.PackageBundle, error) {
+	if pc.bundle != nil {
+		return pc.bundle, nil
+	}
+	return NewBundleReader(kubeConfig, clusterName, pc.kubectl, nil, nil).GetLatestBundle(ctx, "")
+}
+
 func (pc *PackageClient) getInstallConfigurations() (string, error) {
 	installConfigs, err := ParseConfigurations(pc.customConfigs)
 	if err != nil {
@@ -146,7 +266,12 @@ func (pc *PackageClient) getInstallConfigurations() (string, error) {
 	return GenerateAllValidConfigurations(installConfigs)
 }
 
+// ApplyPackages applies the packages in a file, after validating their configuration.
 func (pc *PackageClient) ApplyPackages(ctx context.Context, fileName string, kubeConfig string) error {
+	if _, _, err := pc.readValidPackages(ctx, fileName, kubeConfig); err != nil {
+		return err
+	}
+
 	params := []string{"apply", "-f", fileName, "--kubeconfig", kubeConfig}
 	stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
 	if err != nil {
@@ -157,7 +282,59 @@ func (pc *PackageClient) ApplyPackages(ctx context.Context, fileName string, kub
 	return nil
 }
 
+// CreatePackages creates the packages in a file, after validating their configuration. When packages in the
+// file depend on each other, they are created in dependency order, with their missing dependencies, waiting
+// for each dependency to be installed.
 func (pc *PackageClient) CreatePackages(ctx context.Context, fileName string, kubeConfig string) error {
+	packages, bundle, err := pc.readValidPackages(ctx, fileName, kubeConfig)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range packages {
+		if dependencies, err := Dependencies(bundle, p); err == nil && len(dependencies) > 0 {
+			return pc.createPackagesInOrder(ctx, bundle, packages, kubeConfig)
+		}
+	}
+
+	return pc.createFile(ctx, fileName, kubeConfig)
+}
+
+// readValidPackages reads the packages in a file and the bundle they are installed from, and validates
+// their configuration against the bundle schemas. It returns no packages for files kubectl should
+// handle as they are, like files with other resources, or when the bundle can't be read.
+func (pc *PackageClient) readValidPackages(ctx context.Context, fileName, kubeConfig string) ([]packagesv1.Package, *packagesv1.PackageBundle, error) {
+	packages, err := readPackages(fileName)
+	if err != nil || len(packages) == 0 {
+		// Let kubectl report files it can't read or with other resources.
+		return nil, nil, nil
+	}
+
+	clusterName := strings.TrimPrefix(packages[0].Namespace, constants.EksaPackagesName+"-")
+	bundle, err := pc.packageBundle(ctx, kubeConfig, clusterName)
+	if err != nil {
+		logger.MarkWarning("Unable to read the package bundle, skipping packages validation and dependencies", "error", err)
+		return nil, nil, nil
+	}
+
+	var errs []error
+	for _, p := range packages {
+		if _, err := bundle.FindPackage(p.Spec.PackageName); err != nil {
+			// The package controller reports the packages not in the bundle.
+			continue
+		}
+		if err := ValidateConfig(bundle, p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, kerrors.NewAggregate(errs)
+	}
+
+	return packages, bundle, nil
+}
+
+func (pc *PackageClient) createFile(ctx context.Context, fileName string, kubeConfig string) error {
 	params := []string{"create", "-f", fileName, "--kubeconfig", kubeConfig}
 	stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
 	if err != nil {
@@ -168,8 +345,56 @@ func (pc *PackageClient) CreatePackages(ctx context.Context, fileName string, ku
 	return nil
 }
 
+// readPackages reads the packages in a file. It fails if the file has other resources
+// or packages without a namespace.
+func readPackages(fileName string) ([]packagesv1.Package, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []packagesv1.Package
+	r := yamlutil.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		p := packagesv1.Package{}
+		if err := yaml.Unmarshal(doc, &p); err != nil {
+			return nil, err
+		}
+		if p.Kind != kind || p.Namespace == "" {
+			return nil, fmt.Errorf("file %s has resources other than namespaced packages", fileName)
+		}
+		packages = append(packages, p)
+	}
+
+	return packages, nil
+}
+
+// DeletePackages deletes packages. It refuses to delete packages other installed packages depend on.
 func (pc *PackageClient) DeletePackages(ctx context.Context, packages []string, kubeConfig string, clusterName string) error {
-	params := []string{"delete", "packages", "--kubeconfig", kubeConfig, "--namespace", constants.EksaPackagesName + "-" + clusterName}
+	namespace := constants.EksaPackagesName + "-" + clusterName
+	installed, err := listPackages(ctx, pc.kubectl, namespace, kubeConfig)
+	if err != nil {
+		return err
+	}
+	bundle, err := pc.packageBundle(ctx, kubeConfig, clusterName)
+	if err != nil {
+		return err
+	}
+	if err := ValidateDeletion(bundle, installed, packages); err != nil {
+		return err
+	}
+
+	params := []string{"delete", "packages", "--kubeconfig", kubeConfig, "--namespace", namespace}
 	params = append(params, packages...)
 	stdOut, err := pc.kubectl.ExecuteCommand(ctx, params...)
 	if err != nil {
@@ -230,3 +455,10 @@ func WithCustomConfigs(customConfigs []string) func(*PackageClient) {
 		config.customConfigs = customConfigs
 	}
 }
+
+// WithPackageRetrier sets the retrier used to wait for the dependencies of packages to be installed.
+func WithPackageRetrier(r *retrier.Retrier) func(*PackageClient) {
+	return func(config *PackageClient) {
+		config.retrier = r
+	}
+}
@@ -6,15 +6,18 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
 
 	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages"
 	"github.com/aws/eks-anywhere/pkg/curatedpackages/mocks"
+	"github.com/aws/eks-anywhere/pkg/retrier"
 )
 
 type packageTest struct {
@@ -61,6 +64,11 @@ func newPackageTest(t *testing.T) *packageTest {
 	}
 }
 
+func (tt *packageTest) expectListPackages(namespace string, packages ...packagesv1.Package) {
+	params := []string{"get", "packages", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", namespace}
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(convertJsonToBytes(packagesv1.PackageList{Items: packages}), nil)
+}
+
 func TestGeneratePackagesSucceed(t *testing.T) {
 	tt := newPackageTest(t)
 	packages := []string{"harbor-test"}
@@ -193,6 +201,7 @@ func TestDeletePackagesPass(t *testing.T) {
 	params := []string{"delete", "packages", "--kubeconfig", tt.kubeConfig, "--namespace", constants.EksaPackagesName + "-susie"}
 	params = append(params, args...)
 
+	tt.expectListPackages(constants.EksaPackagesName + "-susie")
 	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(convertJsonToBytes(tt.bundle.Spec.Packages[0]), nil)
 
 	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithCustomPackages(packages))
@@ -207,6 +216,7 @@ func TestDeletePackagesFail(t *testing.T) {
 	args := []string{"non-working-package"}
 	params := []string{"delete", "packages", "--kubeconfig", tt.kubeConfig, "--namespace", constants.EksaPackagesName + "-susie"}
 	params = append(params, args...)
+	tt.expectListPackages(constants.EksaPackagesName + "-susie")
 	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(bytes.Buffer{}, errors.New("package doesn't exist"))
 	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithCustomPackages(packages))
 
@@ -270,3 +280,157 @@ func TestDisplayPackages(t *testing.T) {
 	expected := "Package\t\tVersion(s)\t\n-------\t\t----------\t\nharbor-test\t0.0.1, 0.0.2\t\nredis-test\t0.0.3, 0.0.4\t\n"
 	tt.Expect(buf.String()).To(Equal(expected))
 }
+
+func (tt *packageTest) expectPackageState(name string, state packagesv1.StateEnum) {
+	params := []string{"get", "package", name, "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", dependenciesNamespace}
+	p := namedPackage(name, "")
+	p.Status.State = state
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, params).Return(convertJsonToBytes(p), nil)
+}
+
+func (tt *packageTest) expectCreatePackage(name string) *gomock.Call {
+	return tt.kubectl.EXPECT().ExecuteFromYaml(tt.ctx, gomock.Any(), "create", "-f", "-", "--kubeconfig", tt.kubeConfig).DoAndReturn(
+		func(_ context.Context, content []byte, _ ...string) (bytes.Buffer, error) {
+			p := &packagesv1.Package{}
+			tt.Expect(yaml.Unmarshal(content, p)).To(Succeed())
+			tt.Expect(p.Name).To(Equal(name))
+			return bytes.Buffer{}, nil
+		})
+}
+
+func TestInstallPackageWithDependencies(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.bundle = dependenciesBundle()
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithPackageRetrier(retrier.NewWithMaxRetries(2, 0)))
+
+	tt.expectListPackages(dependenciesNamespace, namedPackage("prom", "prometheus"))
+	gomock.InOrder(
+		tt.expectCreatePackage("generated-cert-manager"),
+		tt.expectCreatePackage("generated-adot"),
+		tt.expectCreatePackage("my-dashboards"),
+	)
+	tt.expectPackageState("generated-cert-manager", packagesv1.StateInstalled)
+	tt.expectPackageState("prom", packagesv1.StateInstalling)
+	tt.expectPackageState("prom", packagesv1.StateInstalled)
+	tt.expectPackageState("generated-adot", packagesv1.StateInstalled)
+
+	bp, err := tt.command.GetPackageFromBundle("dashboards")
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.command.InstallPackage(tt.ctx, bp, "my-dashboards", "billy", tt.kubeConfig)).To(Succeed())
+}
+
+func TestInstallPackageDependencyNotInstalled(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.bundle = dependenciesBundle()
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithPackageRetrier(retrier.NewWithMaxRetries(1, 0)))
+
+	tt.expectListPackages(dependenciesNamespace)
+	tt.expectCreatePackage("generated-cert-manager")
+	tt.expectPackageState("generated-cert-manager", packagesv1.StateInstalling)
+
+	bp, err := tt.command.GetPackageFromBundle("adot")
+	tt.Expect(err).NotTo(HaveOccurred())
+	err = tt.command.InstallPackage(tt.ctx, bp, "my-adot", "billy", tt.kubeConfig)
+	tt.Expect(err).To(MatchError(`waiting for package generated-cert-manager to be installed: package state is "installing"`))
+}
+
+func TestCreatePackagesWithDependencies(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.bundle = dependenciesBundle()
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithPackageRetrier(retrier.NewWithMaxRetries(1, 0)))
+	fileName := filepath.Join(t.TempDir(), "packages.yaml")
+	content := []byte(`apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-emissary
+  namespace: eksa-packages-billy
+spec:
+  packageName: emissary
+---
+apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-certs
+  namespace: eksa-packages-billy
+spec:
+  packageName: cert-manager
+`)
+	tt.Expect(os.WriteFile(fileName, content, 0o644)).To(Succeed())
+
+	tt.expectListPackages(dependenciesNamespace)
+	gomock.InOrder(
+		tt.expectCreatePackage("my-certs"),
+		tt.expectCreatePackage("my-emissary"),
+	)
+	tt.expectPackageState("my-certs", packagesv1.StateInstalled)
+
+	tt.Expect(tt.command.CreatePackages(tt.ctx, fileName, tt.kubeConfig)).To(Succeed())
+}
+
+func TestCreatePackagesWithoutDependenciesReadsActiveBundle(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl)
+	fileName := filepath.Join(t.TempDir(), "packages.yaml")
+	content := []byte(`apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+  namespace: eksa-packages-billy
+spec:
+  packageName: harbor-test
+`)
+	tt.Expect(os.WriteFile(fileName, content, 0o644)).To(Succeed())
+
+	controller := packagesv1.PackageBundleController{Spec: packagesv1.PackageBundleControllerSpec{ActiveBundle: "v1-29-1"}}
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", constants.EksaPackagesName, "billy").
+		Return(convertJsonToBytes(controller), nil)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", constants.EksaPackagesName, "v1-29-1").
+		Return(convertJsonToBytes(tt.bundle), nil)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "create", "-f", fileName, "--kubeconfig", tt.kubeConfig).Return(bytes.Buffer{}, nil)
+
+	tt.Expect(tt.command.CreatePackages(tt.ctx, fileName, tt.kubeConfig)).To(Succeed())
+}
+
+func TestDeletePackagesDependencyOfInstalledPackage(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(dependenciesBundle()))
+
+	tt.expectListPackages(dependenciesNamespace, namedPackage("certs", "cert-manager"), namedPackage("my-adot", "adot"))
+
+	err := tt.command.DeletePackages(tt.ctx, []string{"certs"}, tt.kubeConfig, "billy")
+	tt.Expect(err).To(MatchError("packages are dependencies of installed packages: cert-manager is required by my-adot"))
+}
+
+func TestInstallPackageInvalidConfig(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.bundle = schemaBundle(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(tt.bundle), curatedpackages.WithCustomConfigs([]string{"externalURL=https://harbor.local", "replica=2"}))
+
+	bp, err := tt.command.GetPackageFromBundle("harbor")
+	tt.Expect(err).NotTo(HaveOccurred())
+	err = tt.command.InstallPackage(tt.ctx, bp, "my-harbor", "billy", tt.kubeConfig)
+	tt.Expect(err).To(MatchError("invalid configuration for package my-harbor:\n- config.replica is a forbidden property"))
+}
+
+func TestCreatePackagesInvalidConfig(t *testing.T) {
+	tt := newPackageTest(t)
+	tt.command = curatedpackages.NewPackageClient(tt.kubectl, curatedpackages.WithBundle(schemaBundle(t)))
+	fileName := filepath.Join(t.TempDir(), "packages.yaml")
+	content := []byte(`apiVersion: packages.eks.amazonaws.com/v1alpha1
+kind: Package
+metadata:
+  name: my-harbor
+  namespace: eksa-packages-billy
+spec:
+  packageName: harbor
+  config: |
+    externalURL: https://harbor.local
+    replicas: two
+`)
+	tt.Expect(os.WriteFile(fileName, content, 0o644)).To(Succeed())
+
+	err := tt.command.CreatePackages(tt.ctx, fileName, tt.kubeConfig)
+	tt.Expect(err).To(MatchError(ContainSubstring(`config.replicas must be of type integer: "string"`)))
+	err = tt.command.ApplyPackages(tt.ctx, fileName, tt.kubeConfig)
+	tt.Expect(err).To(MatchError(ContainSubstring(`config.replicas must be of type integer: "string"`)))
+}
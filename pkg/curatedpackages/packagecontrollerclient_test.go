@@ -103,7 +103,7 @@ func newPackageControllerTests(t *testing.T) []*packageControllerTest {
 		CACertContent:      "-----BEGIN CERTIFICATE-----\nabc\nefg\n-----END CERTIFICATE-----\n",
 		InsecureSkipVerify: true,
 	}
-	writer, _ := filewriter.NewWriter(clusterName)
+	writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 	clusterSpec := &cluster.Spec{
 		Config: &cluster.Config{
 			Cluster: &v1alpha1.Cluster{
@@ -302,7 +302,7 @@ func newPackageControllerTests(t *testing.T) []*packageControllerTest {
 func TestEnableSuccess(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -351,7 +351,7 @@ func TestEnableSucceedInWorkloadCluster(t *testing.T) {
 			curatedpackages.WithSkipWait(),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -404,7 +404,7 @@ func TestEnableSucceedInWorkloadClusterWhenPackageBundleControllerNotExist(t *te
 			curatedpackages.WithSkipWait(),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -498,7 +498,7 @@ func TestEnableWithProxy(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		httpProxy := fmt.Sprintf("proxy.HTTP_PROXY=%s", tt.httpProxy)
 		httpsProxy := fmt.Sprintf("proxy.HTTPS_PROXY=%s", tt.httpsProxy)
 		noProxy := fmt.Sprintf("proxy.NO_PROXY=%s", strings.Join(tt.noProxy, "\\,"))
@@ -561,7 +561,7 @@ func TestEnableWithEmptyProxy(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -619,7 +619,7 @@ func TestEnableWithSkipWait(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -667,7 +667,7 @@ func TestEnableWithSkipWait(t *testing.T) {
 func TestEnableFail(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -706,7 +706,7 @@ func TestEnableFail(t *testing.T) {
 func TestEnableFailNoActiveBundle(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -736,7 +736,7 @@ func TestEnableFailNoActiveBundle(t *testing.T) {
 func TestEnableSuccessWhenCronJobFails(t *testing.T) {
 	for _, tt := range newPackageControllerTests(t) {
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -813,7 +813,7 @@ func TestEnableActiveBundleCustomTimeout(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -872,7 +872,7 @@ func TestEnableActiveBundleWaitLoops(t *testing.T) {
 			curatedpackages.WithSkipWait(),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -947,7 +947,7 @@ func TestEnableActiveBundleTimesOut(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -993,7 +993,7 @@ func TestEnableActiveBundleNamespaceTimesOut(t *testing.T) {
 			curatedpackages.WithValuesFileWriter(tt.writer),
 		)
 		clusterName := fmt.Sprintf("clusterName=%s", "billy")
-		valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+		valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 		ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 		sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 		sourceRegistry = fmt.Sprintf("sourceRegistry=%s", sourceRegistry)
@@ -1058,7 +1058,7 @@ func TestEnableWaitsForPackageInstalled(t *testing.T) {
 		URI:  "test_registry/eks-anywhere/eks-anywhere-packages:v1",
 	}
 	clusterName := "billy"
-	writer, _ := filewriter.NewWriter(clusterName)
+	writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 
 	command := curatedpackages.NewPackageControllerClient(
 		cm, k, clusterName, kubeConfig, chart, nil,
@@ -1074,7 +1074,7 @@ func TestEnableWaitsForPackageInstalled(t *testing.T) {
 		"clusterName=" + clusterName,
 		"cronjob.suspend=true",
 	}
-	valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+	valueFilePath := filepath.Join(writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 	ociURI := "oci://test_registry/eks-anywhere/eks-anywhere-packages"
 
 	cm.EXPECT().InstallChart(gomock.Any(), chart.Name, ociURI, chart.Tag(), kubeConfig, constants.EksaPackagesName, valueFilePath, false, gomock.InAnyOrder(values)).Return(nil)
@@ -1117,7 +1117,7 @@ func TestEnableFailsWhenPackageGetObjectFails(t *testing.T) {
 	tt := newPackageControllerTests(t)[0] // Use first test case to reduce complexity
 
 	clusterName := fmt.Sprintf("clusterName=%s", "billy")
-	valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+	valueFilePath := filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, valueFileName)
 	ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 	sourceRegistry, defaultRegistry, defaultImageRegistry := tt.command.GetCuratedPackagesRegistries(context.Background())
 
@@ -1160,7 +1160,7 @@ func TestCreateHelmOverrideValuesYaml(t *testing.T) {
 		}
 		filePath, content, err := tt.command.CreateHelmOverrideValuesYaml()
 		tt.Expect(err).To(BeNil())
-		tt.Expect(filePath).To(Equal(filepath.Join(tt.clusterName, filewriter.DefaultTmpFolder, "values.yaml")))
+		tt.Expect(filePath).To(Equal(filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, "values.yaml")))
 		test.AssertContentToFile(t, string(content), tt.wantValueFile)
 	}
 }
@@ -1175,7 +1175,7 @@ func TestCreateHelmOverrideValuesYamlFail(t *testing.T) {
 			tt.Expect(filePath).To(Equal(""))
 		} else {
 			tt.Expect(err).To(BeNil())
-			tt.Expect(filePath).To(Equal(filepath.Join(tt.clusterName, filewriter.DefaultTmpFolder, "values.yaml")))
+			tt.Expect(filePath).To(Equal(filepath.Join(tt.writer.Dir(), filewriter.DefaultTmpFolder, "values.yaml")))
 			test.AssertContentToFile(t, string(content), tt.wantValueFile)
 		}
 	}
@@ -1423,6 +1423,8 @@ func TestReconcileDeleteHelmErrorsHandling(t *testing.T) {
 }
 
 func TestEnableFullLifecyclePath(t *testing.T) {
+	// EnableFullLifecycle writes the helm values under a folder named after the cluster.
+	t.Chdir(t.TempDir())
 	log := testr.New(t)
 	ctrl := gomock.NewController(t)
 	k := mocks.NewMockKubectlRunner(ctrl)
@@ -1449,7 +1451,7 @@ func TestEnableFullLifecyclePath(t *testing.T) {
 		wantValueFile:  "testdata/values_empty.yaml",
 	}
 
-	valueFilePath := filepath.Join("billy", filewriter.DefaultTmpFolder, valueFileName)
+	valueFilePath := filepath.Join(clusterName, filewriter.DefaultTmpFolder, valueFileName)
 	ociURI := fmt.Sprintf("%s%s", "oci://", tt.registryMirror.ReplaceRegistry(tt.chart.Image()))
 	// GetCuratedPackagesRegistries can't be used here, as when initialized
 	// via full cluster lifecycle the package controller client hasn't yet
@@ -1513,7 +1515,7 @@ func TestGetCuratedPackagesRegistries(s *testing.T) {
 		}
 		// eksaRegion := "test-region"
 		clusterName := "billy"
-		writer, _ := filewriter.NewWriter(clusterName)
+		writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 		client := curatedpackages.NewPackageControllerClient(
 			cm, k, clusterName, kubeConfig, chart, nil,
 			curatedpackages.WithManagementClusterName(clusterName),
@@ -1540,7 +1542,7 @@ func TestGetCuratedPackagesRegistries(s *testing.T) {
 		}
 		// eksaRegion := "test-region"
 		clusterName := "billy"
-		writer, _ := filewriter.NewWriter(clusterName)
+		writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 		client := curatedpackages.NewPackageControllerClient(
 			cm, k, clusterName, kubeConfig, chart, nil,
 			curatedpackages.WithManagementClusterName(clusterName),
@@ -1565,7 +1567,7 @@ func TestGetCuratedPackagesRegistries(s *testing.T) {
 			URI:  "test_registry/eks-anywhere/eks-anywhere-packages:v1",
 		}
 		clusterName := "billy"
-		writer, _ := filewriter.NewWriter(clusterName)
+		writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 		client := curatedpackages.NewPackageControllerClient(
 			cm, k, clusterName, kubeConfig, chart, nil,
 			curatedpackages.WithManagementClusterName(clusterName),
@@ -1594,7 +1596,7 @@ func TestGetCuratedPackagesRegistries(s *testing.T) {
 			URI:  "test_registry/w9m0f3l5/eks-anywhere-packages:v1",
 		}
 		clusterName := "billy"
-		writer, _ := filewriter.NewWriter(clusterName)
+		writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 		client := curatedpackages.NewPackageControllerClient(
 			cm, k, clusterName, kubeConfig, chart, nil,
 			curatedpackages.WithManagementClusterName(clusterName),
@@ -1689,7 +1691,7 @@ func TestGetCuratedPackagesRegistries_RegistryMirror(t *testing.T) {
 				URI:  "test_registry/eks-anywhere/eks-anywhere-packages:v1",
 			}
 			clusterName := "billy"
-			writer, _ := filewriter.NewWriter(clusterName)
+			writer, _ := filewriter.NewWriter(filepath.Join(t.TempDir(), clusterName))
 
 			client := curatedpackages.NewPackageControllerClient(
 				cm, k, clusterName, kubeConfig, chart, tt.registryMirror,
@@ -1707,6 +1709,8 @@ func TestGetCuratedPackagesRegistries_RegistryMirror(t *testing.T) {
 }
 
 func TestReconcile(s *testing.T) {
+	// Reconcile writes the helm values under a folder named after the cluster.
+	s.Chdir(s.TempDir())
 	s.Run("golden path", func(t *testing.T) {
 		ctx := context.Background()
 		log := testr.New(t)
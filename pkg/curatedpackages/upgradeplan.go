@@ -0,0 +1,366 @@
+package curatedpackages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/semver"
+)
+
+// UpgradePlan lists the changes of the installed packages when upgrading to a new package bundle.
+type UpgradePlan struct {
+	CurrentBundle string           `json:"currentBundle"`
+	TargetBundle  string           `json:"targetBundle"`
+	Packages      []PackageUpgrade `json:"packages"`
+}
+
+// PackageUpgrade is the upgrade of an installed package to the version of a new bundle.
+type PackageUpgrade struct {
+	// Name is the name of the Package object.
+	Name           string `json:"name"`
+	PackageName    string `json:"packageName"`
+	CurrentVersion string `json:"currentVersion"`
+	// TargetVersion is empty when the package is not in the new bundle.
+	TargetVersion string `json:"targetVersion"`
+	// Pinned is true for packages with a version set, that don't follow the bundle.
+	Pinned          bool            `json:"pinned,omitempty"`
+	ChangedDefaults []DefaultChange `json:"changedDefaults,omitempty"`
+	BreakingChanges []string        `json:"breakingChanges,omitempty"`
+	// PinError is why the package can't be kept at its current version with PinPackages, empty if it can.
+	PinError string `json:"pinError,omitempty"`
+}
+
+// DefaultChange is a default value of the package configuration that changes with the upgrade.
+// Nil values are keys without a default in a version.
+type DefaultChange struct {
+	Key     string      `json:"key"`
+	Current interface{} `json:"current"`
+	Target  interface{} `json:"target"`
+}
+
+// Upgraded is true if the package version changes.
+func (u PackageUpgrade) Upgraded() bool {
+	return u.CurrentVersion != u.TargetVersion
+}
+
+// PlanUpgrade returns the upgrade plan of the installed packages from the current to the target bundle.
+// Breaking changes include major version upgrades, configurations not valid for the target version,
+// new dependencies not installed and packages or pinned versions not in the target bundle.
+// Packages that can't be pinned to their current version have a PinError.
+func PlanUpgrade(current, target *packagesv1.PackageBundle, installed []packagesv1.Package) (*UpgradePlan, error) {
+	plan := &UpgradePlan{CurrentBundle: current.Name, TargetBundle: target.Name}
+	installedPackages := map[string]bool{}
+	for _, p := range installed {
+		installedPackages[packageKey(p.Namespace, p.Spec.PackageName)] = true
+	}
+
+	for _, p := range installed {
+		bp, currentVersion, err := installedVersion(current, p)
+		currentInBundle := err == nil
+		if !currentInBundle {
+			currentVersion = packagesv1.SourceVersion{Name: p.Status.CurrentVersion}
+		}
+		u := PackageUpgrade{
+			Name:           p.Name,
+			PackageName:    p.Spec.PackageName,
+			CurrentVersion: currentVersion.Name,
+			Pinned:         p.Spec.PackageVersion != "",
+		}
+		if _, err := pinnableDigest(current, target, p); err != nil {
+			u.PinError = err.Error()
+		}
+
+		targetPackage, targetVersion, err := packageVersion(target, p)
+		if err != nil {
+			if _, notFound := target.FindPackage(p.Spec.PackageName); notFound != nil {
+				u.BreakingChanges = append(u.BreakingChanges, fmt.Sprintf("package is not in bundle %s", target.Name))
+			} else {
+				u.BreakingChanges = append(u.BreakingChanges, fmt.Sprintf("pinned version %s is not in bundle %s", p.Spec.PackageVersion, target.Name))
+			}
+			plan.Packages = append(plan.Packages, u)
+			continue
+		}
+		u.TargetVersion = targetVersion.Name
+
+		if currentInBundle {
+			u.ChangedDefaults, err = changedDefaults(bp, currentVersion, targetPackage, targetVersion)
+			if err != nil {
+				return nil, err
+			}
+		}
+		u.BreakingChanges, err = breakingChanges(target, p, currentVersion, targetVersion, installedPackages)
+		if err != nil {
+			return nil, err
+		}
+		plan.Packages = append(plan.Packages, u)
+	}
+
+	sort.SliceStable(plan.Packages, func(i, j int) bool {
+		return plan.Packages[i].Name < plan.Packages[j].Name
+	})
+
+	return plan, nil
+}
+
+// installedVersion returns the version of an installed package in the bundle it was installed from.
+// Packages installed from an older bundle might not be in it.
+func installedVersion(bundle *packagesv1.PackageBundle, p packagesv1.Package) (packagesv1.BundlePackage, packagesv1.SourceVersion, error) {
+	bp, err := bundle.FindPackage(p.Spec.PackageName)
+	if err != nil {
+		return bp, packagesv1.SourceVersion{}, err
+	}
+	for _, version := range []string{p.Status.CurrentVersion, p.Spec.PackageVersion, packagesv1.Latest} {
+		if version == "" {
+			continue
+		}
+		if v, err := bundle.FindVersion(bp, version); err == nil {
+			return bp, v, nil
+		}
+	}
+
+	return bp, packagesv1.SourceVersion{}, fmt.Errorf("package %s has no version in bundle %s", p.Spec.PackageName, bundle.Name)
+}
+
+func breakingChanges(target *packagesv1.PackageBundle, p packagesv1.Package, currentVersion, targetVersion packagesv1.SourceVersion, installed map[string]bool) ([]string, error) {
+	var changes []string
+	currentSemver, currentErr := semver.New(currentVersion.Name)
+	targetSemver, targetErr := semver.New(targetVersion.Name)
+	if currentErr == nil && targetErr == nil && !currentSemver.SameMajor(targetSemver) {
+		changes = append(changes, fmt.Sprintf("major version upgrade from %s to %s", currentVersion.Name, targetVersion.Name))
+	}
+
+	upgraded := p.DeepCopy()
+	upgraded.Spec.PackageVersion = targetVersion.Name
+	configErrs, err := configErrors(target, *upgraded)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range configErrs {
+		changes = append(changes, "configuration not valid for the new version: "+e)
+	}
+
+	for _, d := range targetVersion.Dependencies {
+		if !installed[packageKey(p.Namespace, d)] {
+			changes = append(changes, fmt.Sprintf("new dependency %s is not installed", d))
+		}
+	}
+
+	return changes, nil
+}
+
+func changedDefaults(currentPackage packagesv1.BundlePackage, currentVersion packagesv1.SourceVersion, targetPackage packagesv1.BundlePackage, targetVersion packagesv1.SourceVersion) ([]DefaultChange, error) {
+	currentDefaults, err := versionDefaults(currentPackage, currentVersion)
+	if err != nil {
+		return nil, err
+	}
+	targetDefaults, err := versionDefaults(targetPackage, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for k := range currentDefaults {
+		keys[k] = true
+	}
+	for k := range targetDefaults {
+		keys[k] = true
+	}
+	var changes []DefaultChange
+	for k := range keys {
+		if reflect.DeepEqual(currentDefaults[k], targetDefaults[k]) {
+			continue
+		}
+		changes = append(changes, DefaultChange{Key: k, Current: currentDefaults[k], Target: targetDefaults[k]})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes, nil
+}
+
+// versionDefaults returns the default values of the values schema of a package version by key path.
+func versionDefaults(bp packagesv1.BundlePackage, version packagesv1.SourceVersion) (map[string]interface{}, error) {
+	schema, err := versionSchema(bp, version)
+	if err != nil || schema == nil {
+		return nil, err
+	}
+	defaults := map[string]interface{}{}
+	collectDefaults(schema, configRoot, defaults)
+
+	return defaults, nil
+}
+
+func collectDefaults(schema *spec.Schema, path string, defaults map[string]interface{}) {
+	if schema.Default != nil {
+		defaults[path] = schema.Default
+		return
+	}
+	for name, property := range schema.Properties {
+		property := property
+		collectDefaults(&property, path+"."+name, defaults)
+	}
+}
+
+// PlanUpgrade returns the upgrade plan of the packages of the cluster to a new bundle in the cluster.
+func (b *BundleReader) PlanUpgrade(ctx context.Context, newBundleVersion string) (*UpgradePlan, error) {
+	plan, _, _, _, err := b.planUpgrade(ctx, newBundleVersion)
+	return plan, err
+}
+
+func (b *BundleReader) planUpgrade(ctx context.Context, newBundleVersion string) (*UpgradePlan, *packagesv1.PackageBundle, *packagesv1.PackageBundle, []packagesv1.Package, error) {
+	current, err := b.getActiveBundleFromCluster(ctx)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	target, err := b.getPackageBundle(ctx, newBundleVersion)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	installed, err := listPackages(ctx, b.kubectl, constants.EksaPackagesName+"-"+b.clusterName, b.kubeConfig)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	plan, err := PlanUpgrade(current, target, installed)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return plan, current, target, installed, nil
+}
+
+// PinnedPackage is a package pinned to the digest of its current version by PinPackages.
+type PinnedPackage struct {
+	// Name is the name of the Package object.
+	Name string
+	// Digest is the digest of the version the package is pinned to.
+	Digest string
+	// PreviousVersion is the version set in the package before it was pinned, empty if it followed the bundle.
+	PreviousVersion string
+}
+
+// PinPackages sets the version of packages to the digest of their current version in the active bundle,
+// so they keep the same chart when the cluster is upgraded to a new bundle. The packages controller
+// resolves the version of a package by name or digest in the active bundle, so the new bundle must have
+// a version with the same digest. Packages are referenced by the name of their Package object.
+// If pinning a package fails, the packages already pinned are reverted.
+func (b *BundleReader) PinPackages(ctx context.Context, packages []string, newBundleVersion string) ([]PinnedPackage, error) {
+	_, current, target, installed, err := b.planUpgrade(ctx, newBundleVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	installedPackages := map[string]packagesv1.Package{}
+	for _, p := range installed {
+		installedPackages[p.Name] = p
+	}
+	var toPin []PinnedPackage
+	for _, name := range packages {
+		p, found := installedPackages[name]
+		if !found {
+			return nil, fmt.Errorf("package %s is not installed in cluster %s", name, b.clusterName)
+		}
+		digest, err := pinnableDigest(current, target, p)
+		if err != nil {
+			return nil, fmt.Errorf("package %s can't be pinned: %v", name, err)
+		}
+		toPin = append(toPin, PinnedPackage{Name: name, Digest: digest, PreviousVersion: p.Spec.PackageVersion})
+	}
+
+	var pinned []PinnedPackage
+	for _, p := range toPin {
+		if err := b.setPackageVersion(ctx, p.Name, p.Digest); err != nil {
+			err = fmt.Errorf("pinning package %s to version %s: %v", p.Name, p.Digest, err)
+			if unpinErr := b.UnpinPackages(ctx, pinned); unpinErr != nil {
+				return nil, fmt.Errorf("%v, reverting pinned packages: %v", err, unpinErr)
+			}
+			return nil, err
+		}
+		pinned = append(pinned, p)
+	}
+
+	return pinned, nil
+}
+
+// UnpinPackages sets the packages pinned by PinPackages back to the version they had before.
+func (b *BundleReader) UnpinPackages(ctx context.Context, pinned []PinnedPackage) error {
+	var errs []error
+	for _, p := range pinned {
+		if err := b.setPackageVersion(ctx, p.Name, p.PreviousVersion); err != nil {
+			errs = append(errs, fmt.Errorf("unpinning package %s: %v", p.Name, err))
+		}
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// pinnableDigest returns the digest of the current version of an installed package in the current
+// bundle, after checking the target bundle has a version with the same digest.
+func pinnableDigest(current, target *packagesv1.PackageBundle, p packagesv1.Package) (string, error) {
+	currentPackage, err := current.FindPackage(p.Spec.PackageName)
+	if err != nil {
+		return "", err
+	}
+	currentVersion, err := current.FindVersion(currentPackage, p.Status.CurrentVersion)
+	if err != nil {
+		return "", err
+	}
+	if currentVersion.Digest == "" {
+		return "", fmt.Errorf("version %s has no digest in bundle %s", currentVersion.Name, current.Name)
+	}
+
+	targetPackage, err := target.FindPackage(p.Spec.PackageName)
+	if err != nil {
+		return "", err
+	}
+	if _, err := target.FindVersion(targetPackage, currentVersion.Digest); err != nil {
+		return "", fmt.Errorf("version %s (%s) of bundle %s is not in bundle %s", currentVersion.Name, currentVersion.Digest, current.Name, target.Name)
+	}
+
+	return currentVersion.Digest, nil
+}
+
+// setPackageVersion sets the version of a package, an empty version removes it so the package follows the bundle.
+func (b *BundleReader) setPackageVersion(ctx context.Context, name, version string) error {
+	var packageVersion interface{}
+	if version != "" {
+		packageVersion = version
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"packageVersion": packageVersion},
+	})
+	if err != nil {
+		return err
+	}
+	params := []string{
+		"patch", "package", name, "--type", "merge", "-p", string(patch),
+		"--kubeconfig", b.kubeConfig, "--namespace", constants.EksaPackagesName + "-" + b.clusterName,
+	}
+	_, err = b.kubectl.ExecuteCommand(ctx, params...)
+
+	return err
+}
+
+// String returns the key and the current and target default values.
+func (c DefaultChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Key, defaultValue(c.Current), defaultValue(c.Target))
+}
+
+func defaultValue(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,299 @@
+package curatedpackages_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	packagesv1 "github.com/aws/eks-anywhere-packages/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/curatedpackages"
+)
+
+const (
+	harborSchemaV1 = `{
+  "type": "object",
+  "properties": {
+    "externalURL": {"type": "string"},
+    "replicas": {"type": "integer", "default": 1},
+    "expose": {"type": "object", "properties": {"type": {"type": "string", "default": "nodePort"}}}
+  }
+}`
+	harborSchemaV2 = `{
+  "type": "object",
+  "properties": {
+    "externalURL": {"type": "string"},
+    "replicas": {"type": "integer", "default": 2},
+    "expose": {"type": "object", "properties": {"type": {"type": "string", "default": "nodePort"}}},
+    "database": {"type": "string", "default": "internal"}
+  },
+  "required": ["database"]
+}`
+	certManagerDigest = "sha256:cert-manager-1.0.0"
+)
+
+func versionedPackage(name string, versions ...packagesv1.SourceVersion) packagesv1.BundlePackage {
+	return packagesv1.BundlePackage{
+		Name:   name,
+		Source: packagesv1.BundlePackageSource{Versions: versions},
+	}
+}
+
+func upgradeBundles(t *testing.T) (current, target *packagesv1.PackageBundle) {
+	current = &packagesv1.PackageBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "v1-21-1000"},
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{
+				versionedPackage("cert-manager", packagesv1.SourceVersion{Name: "1.0.0", Digest: certManagerDigest}),
+				versionedPackage("adot", packagesv1.SourceVersion{Name: "1.0.0", Digest: "sha256:adot-1.0.0", Dependencies: []string{"cert-manager"}}),
+				versionedPackage("harbor", packagesv1.SourceVersion{Name: "2.5.0", Schema: encodeSchema(t, harborSchemaV1)}),
+				versionedPackage("old", packagesv1.SourceVersion{Name: "1.0.0"}),
+			},
+		},
+	}
+	target = &packagesv1.PackageBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "v1-21-1001"},
+		Spec: packagesv1.PackageBundleSpec{
+			Packages: []packagesv1.BundlePackage{
+				versionedPackage("cert-manager", packagesv1.SourceVersion{Name: "2.0.0"}, packagesv1.SourceVersion{Name: "1.0.0", Digest: certManagerDigest}),
+				versionedPackage("adot", packagesv1.SourceVersion{Name: "1.1.0", Dependencies: []string{"cert-manager", "prometheus"}}),
+				versionedPackage("harbor", packagesv1.SourceVersion{Name: "2.6.0", Schema: encodeSchema(t, harborSchemaV2)}),
+				versionedPackage("prometheus", packagesv1.SourceVersion{Name: "1.0.0"}),
+			},
+		},
+	}
+	return current, target
+}
+
+func installedPackage(name, packageName, version string) packagesv1.Package {
+	p := namedPackage(name, packageName)
+	p.Status.CurrentVersion = version
+	return p
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	current, target := upgradeBundles(t)
+	pinned := installedPackage("pinned-certs", "cert-manager", "1.0.0")
+	pinned.Spec.PackageVersion = "1.0.0"
+	harbor := installedPackage("my-harbor", "harbor", "2.5.0")
+	harbor.Spec.Config = "externalURL: https://harbor.local"
+	installed := []packagesv1.Package{
+		installedPackage("my-certs", "cert-manager", "1.0.0"),
+		pinned,
+		installedPackage("my-adot", "adot", "1.0.0"),
+		harbor,
+		installedPackage("my-old", "old", "1.0.0"),
+	}
+
+	plan, err := curatedpackages.PlanUpgrade(current, target, installed)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(plan.CurrentBundle).To(Equal("v1-21-1000"))
+	g.Expect(plan.TargetBundle).To(Equal("v1-21-1001"))
+	g.Expect(plan.Packages).To(Equal([]curatedpackages.PackageUpgrade{
+		{
+			Name:            "my-adot",
+			PackageName:     "adot",
+			CurrentVersion:  "1.0.0",
+			TargetVersion:   "1.1.0",
+			BreakingChanges: []string{"new dependency prometheus is not installed"},
+			PinError:        "version 1.0.0 (sha256:adot-1.0.0) of bundle v1-21-1000 is not in bundle v1-21-1001",
+		},
+		{
+			Name:            "my-certs",
+			PackageName:     "cert-manager",
+			CurrentVersion:  "1.0.0",
+			TargetVersion:   "2.0.0",
+			BreakingChanges: []string{"major version upgrade from 1.0.0 to 2.0.0"},
+		},
+		{
+			Name:           "my-harbor",
+			PackageName:    "harbor",
+			CurrentVersion: "2.5.0",
+			TargetVersion:  "2.6.0",
+			ChangedDefaults: []curatedpackages.DefaultChange{
+				{Key: "config.database", Target: "internal"},
+				{Key: "config.replicas", Current: float64(1), Target: float64(2)},
+			},
+			BreakingChanges: []string{"configuration not valid for the new version: config.database is required"},
+			PinError:        "version 2.5.0 has no digest in bundle v1-21-1000",
+		},
+		{
+			Name:            "my-old",
+			PackageName:     "old",
+			CurrentVersion:  "1.0.0",
+			BreakingChanges: []string{"package is not in bundle v1-21-1001"},
+			PinError:        "version 1.0.0 has no digest in bundle v1-21-1000",
+		},
+		{
+			Name:           "pinned-certs",
+			PackageName:    "cert-manager",
+			CurrentVersion: "1.0.0",
+			TargetVersion:  "1.0.0",
+			Pinned:         true,
+		},
+	}))
+	g.Expect(plan.Packages[0].Upgraded()).To(BeTrue())
+	g.Expect(plan.Packages[4].Upgraded()).To(BeFalse())
+}
+
+func TestPlanUpgradePinnedVersionNotInBundle(t *testing.T) {
+	g := NewWithT(t)
+	current, target := upgradeBundles(t)
+	p := installedPackage("my-harbor", "harbor", "2.5.0")
+	p.Spec.PackageVersion = "2.5.0"
+
+	plan, err := curatedpackages.PlanUpgrade(current, target, []packagesv1.Package{p})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(plan.Packages).To(HaveLen(1))
+	g.Expect(plan.Packages[0].TargetVersion).To(BeEmpty())
+	g.Expect(plan.Packages[0].BreakingChanges).To(Equal([]string{"pinned version 2.5.0 is not in bundle v1-21-1001"}))
+}
+
+func TestPlanUpgradePackageNotInCurrentBundle(t *testing.T) {
+	g := NewWithT(t)
+	current, target := upgradeBundles(t)
+
+	plan, err := curatedpackages.PlanUpgrade(current, target, []packagesv1.Package{installedPackage("my-prometheus", "prometheus", "0.9.0")})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(plan.Packages).To(Equal([]curatedpackages.PackageUpgrade{
+		{
+			Name:            "my-prometheus",
+			PackageName:     "prometheus",
+			CurrentVersion:  "0.9.0",
+			TargetVersion:   "1.0.0",
+			BreakingChanges: []string{"major version upgrade from 0.9.0 to 1.0.0"},
+			PinError:        "package not found in bundle (v1-21-1000): prometheus",
+		},
+	}))
+}
+
+func TestDefaultChangeString(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(curatedpackages.DefaultChange{Key: "config.replicas", Current: float64(1), Target: float64(2)}.String()).To(Equal("config.replicas: 1 -> 2"))
+	g.Expect(curatedpackages.DefaultChange{Key: "config.database", Target: "internal"}.String()).To(Equal(`config.database: <none> -> "internal"`))
+}
+
+func (tt *bundleTest) expectPlanUpgrade(current, target *packagesv1.PackageBundle, installed ...packagesv1.Package) {
+	tt.bundleCtrl.Spec.ActiveBundle = current.Name
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundleController", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", tt.cluster).Return(convertJsonToBytes(tt.bundleCtrl), nil)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", current.Name).Return(convertJsonToBytes(current), nil)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packageBundle", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", "eksa-packages", target.Name).Return(convertJsonToBytes(target), nil)
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "get", "packages", "-o", "json", "--kubeconfig", tt.kubeConfig, "--namespace", dependenciesNamespace).Return(convertJsonToBytes(packagesv1.PackageList{Items: installed}), nil)
+}
+
+func TestBundleReaderPlanUpgrade(t *testing.T) {
+	tt := newBundleTest(t)
+	current, target := upgradeBundles(t)
+	tt.expectPlanUpgrade(current, target, installedPackage("my-certs", "cert-manager", "1.0.0"))
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	plan, err := tt.Command.PlanUpgrade(tt.ctx, target.Name)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(plan.Packages).To(HaveLen(1))
+	tt.Expect(plan.Packages[0].TargetVersion).To(Equal("2.0.0"))
+}
+
+func (tt *bundleTest) expectSetPackageVersion(name, version string, err error) {
+	tt.kubectl.EXPECT().ExecuteCommand(tt.ctx, "patch", "package", name, "--type", "merge", "-p", `{"spec":{"packageVersion":`+version+`}}`,
+		"--kubeconfig", tt.kubeConfig, "--namespace", dependenciesNamespace).Return(bytes.Buffer{}, err)
+}
+
+func TestBundleReaderPinPackages(t *testing.T) {
+	tt := newBundleTest(t)
+	current, target := upgradeBundles(t)
+	pinnedCerts := installedPackage("pinned-certs", "cert-manager", "1.0.0")
+	pinnedCerts.Spec.PackageVersion = "1.0.0"
+	tt.expectPlanUpgrade(current, target, installedPackage("my-certs", "cert-manager", "1.0.0"), pinnedCerts, installedPackage("my-adot", "adot", "1.0.0"))
+	tt.expectSetPackageVersion("my-certs", `"`+certManagerDigest+`"`, nil)
+	tt.expectSetPackageVersion("pinned-certs", `"`+certManagerDigest+`"`, nil)
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	pinned, err := tt.Command.PinPackages(tt.ctx, []string{"my-certs", "pinned-certs"}, target.Name)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(pinned).To(Equal([]curatedpackages.PinnedPackage{
+		{Name: "my-certs", Digest: certManagerDigest},
+		{Name: "pinned-certs", Digest: certManagerDigest, PreviousVersion: "1.0.0"},
+	}))
+}
+
+func TestBundleReaderPinPackagesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pin     []string
+		target  func(*packagesv1.PackageBundle)
+		extra   []packagesv1.Package
+		wantErr string
+	}{
+		{
+			name:    "not installed",
+			pin:     []string{"my-harbor"},
+			wantErr: "package my-harbor is not installed in cluster billy",
+		},
+		{
+			name:    "current version not in bundle",
+			pin:     []string{"my-certs", "my-adot"},
+			wantErr: "package my-adot can't be pinned: version 1.0.0 (sha256:adot-1.0.0) of bundle v1-21-1000 is not in bundle v1-21-1001",
+		},
+		{
+			name: "current version rebuilt in bundle",
+			pin:  []string{"my-certs"},
+			target: func(b *packagesv1.PackageBundle) {
+				b.Spec.Packages[0].Source.Versions[1].Digest = "sha256:cert-manager-1.0.0-rebuilt"
+			},
+			wantErr: "package my-certs can't be pinned: version 1.0.0 (" + certManagerDigest + ") of bundle v1-21-1000 is not in bundle v1-21-1001",
+		},
+		{
+			name:    "current version without digest",
+			pin:     []string{"my-harbor"},
+			extra:   []packagesv1.Package{installedPackage("my-harbor", "harbor", "2.5.0")},
+			wantErr: "package my-harbor can't be pinned: version 2.5.0 has no digest in bundle v1-21-1000",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tt := newBundleTest(t)
+			current, target := upgradeBundles(t)
+			if test.target != nil {
+				test.target(target)
+			}
+			installed := append([]packagesv1.Package{installedPackage("my-certs", "cert-manager", "1.0.0"), installedPackage("my-adot", "adot", "1.0.0")}, test.extra...)
+			tt.expectPlanUpgrade(current, target, installed...)
+			tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+			_, err := tt.Command.PinPackages(tt.ctx, test.pin, target.Name)
+			tt.Expect(err).To(MatchError(ContainSubstring(test.wantErr)))
+		})
+	}
+}
+
+func TestBundleReaderPinPackagesPatchFails(t *testing.T) {
+	tt := newBundleTest(t)
+	current, target := upgradeBundles(t)
+	pinnedCerts := installedPackage("pinned-certs", "cert-manager", "1.0.0")
+	pinnedCerts.Spec.PackageVersion = "1.0.0"
+	tt.expectPlanUpgrade(current, target, installedPackage("my-certs", "cert-manager", "1.0.0"), pinnedCerts)
+	tt.expectSetPackageVersion("pinned-certs", `"`+certManagerDigest+`"`, nil)
+	tt.expectSetPackageVersion("my-certs", `"`+certManagerDigest+`"`, errors.New("patch failed"))
+	tt.expectSetPackageVersion("pinned-certs", `"1.0.0"`, nil)
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	_, err := tt.Command.PinPackages(tt.ctx, []string{"pinned-certs", "my-certs"}, target.Name)
+	tt.Expect(err).To(MatchError("pinning package my-certs to version " + certManagerDigest + ": patch failed"))
+}
+
+func TestBundleReaderUnpinPackages(t *testing.T) {
+	tt := newBundleTest(t)
+	tt.expectSetPackageVersion("my-certs", "null", nil)
+	tt.expectSetPackageVersion("pinned-certs", `"1.0.0"`, errors.New("patch failed"))
+	tt.Command = curatedpackages.NewBundleReader(tt.kubeConfig, tt.cluster, tt.kubectl, tt.bundleManager, tt.registry)
+
+	err := tt.Command.UnpinPackages(tt.ctx, []curatedpackages.PinnedPackage{
+		{Name: "my-certs", Digest: certManagerDigest},
+		{Name: "pinned-certs", Digest: certManagerDigest, PreviousVersion: "1.0.0"},
+	})
+	tt.Expect(err).To(MatchError("unpinning package pinned-certs: patch failed"))
+}
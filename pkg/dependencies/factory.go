@@ -35,7 +35,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/kubeconfig"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/manifests"
-	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 	"github.com/aws/eks-anywhere/pkg/networkutils"
 	"github.com/aws/eks-anywhere/pkg/providers"
@@ -165,8 +164,9 @@ type executablesConfig struct {
 }
 
 type config struct {
-	bundlesOverride string
-	noTimeouts      bool
+	bundlesOverride     string
+	noTimeouts          bool
+	bootstrapKubeconfig string
 }
 
 type buildStep func(ctx context.Context) error
@@ -273,7 +273,7 @@ func (f *Factory) WithExecutableImage() *Factory {
 //
 // Handles cases where the bundle is configured with an override.
 func (f *Factory) selectImageFromBundleOverride(bundlesOverride string) (string, error) {
-	releaseBundles, err := bundles.Read(f.dependencies.ManifestReader, bundlesOverride)
+	releaseBundles, err := f.dependencies.ManifestReader.ReadBundles(bundlesOverride)
 	if err != nil {
 		return "", fmt.Errorf("retrieving executable tools image from overridden bundle in dependency factory %v", err)
 	}
@@ -420,6 +420,8 @@ type ProviderOptions struct {
 type TinkerbellOptions struct {
 	// BMCOptions contains options for configuring BMC interactions.
 	BMCOptions *hardware.BMCOptions
+	// InventoryOptions contains options for collecting hardware inventory from BMCs.
+	InventoryOptions *hardware.InventoryOptions
 }
 
 // WithProvider initializes the provider dependency and adds to the build steps.
@@ -540,6 +542,9 @@ func (f *Factory) WithProvider(clusterConfigFile string, clusterConfig *v1alpha1
 			if opts != nil && opts.Tinkerbell != nil && opts.Tinkerbell.BMCOptions != nil {
 				provider.BMCOptions = opts.Tinkerbell.BMCOptions
 			}
+			if opts != nil && opts.Tinkerbell != nil {
+				provider.InventoryOptions = opts.Tinkerbell.InventoryOptions
+			}
 
 			f.dependencies.Provider = provider
 
@@ -939,12 +944,20 @@ func (f *Factory) WithBootstrapper() *Factory {
 			)
 		}
 
+		var bootstrapperOpts []bootstrapper.BootstrapperOpt
+		if f.config.bootstrapKubeconfig != "" {
+			bootstrapperOpts = append(bootstrapperOpts,
+				bootstrapper.WithExistingCluster(f.config.bootstrapKubeconfig, kubernetes.ClientFactory{}),
+			)
+		}
+
 		f.dependencies.Bootstrapper = bootstrapper.New(
 			bootstrapper.NewRetrierClient(
 				f.dependencies.Kind,
 				f.dependencies.Kubectl,
 				opts...,
 			),
+			bootstrapperOpts...,
 		)
 		return nil
 	})
@@ -1074,6 +1087,13 @@ func (f *Factory) WithNoTimeouts() *Factory {
 	return f
 }
 
+// WithExistingBootstrapCluster makes the Bootstrapper use the cluster of the kubeconfig file as the
+// bootstrap cluster instead of creating a kind cluster.
+func (f *Factory) WithExistingBootstrapCluster(kubeconfig string) *Factory {
+	f.config.bootstrapKubeconfig = kubeconfig
+	return f
+}
+
 // WithCliConfig builds a cli config.
 func (f *Factory) WithCliConfig(cliConfig *cliconfig.CliConfig) *Factory {
 	f.dependencies.CliConfig = cliConfig
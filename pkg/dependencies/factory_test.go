@@ -17,6 +17,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/dependencies"
 	"github.com/aws/eks-anywhere/pkg/helm"
+	"github.com/aws/eks-anywhere/pkg/manifests"
 	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/decoder"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
@@ -616,6 +617,7 @@ func TestFactoryBuildWithCuratedPackagesDefaultRegistry(t *testing.T) {
 
 func TestFactoryBuildWithCuratedPackagesCustomManifestImage(t *testing.T) {
 	tt := newTest(t, vsphere)
+	t.Setenv(manifests.SkipSignatureVerificationEnvVar, "unsigned test bundle")
 	deps, err := dependencies.NewFactory().
 		WithCustomBundles("testdata/cli_tools_bundle.yaml").
 		WithManifestReader().
@@ -649,6 +651,17 @@ func TestFactoryBuildWithCuratedPackagesCustomManifestImageMissingBundle(t *test
 	tt.Expect(err).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithCuratedPackagesCustomManifestImageUnsigned(t *testing.T) {
+	tt := newTest(t, vsphere)
+	_, err := dependencies.NewFactory().
+		WithCustomBundles("testdata/cli_tools_bundle.yaml").
+		WithManifestReader().
+		WithCuratedPackagesRegistry("", "1.22", version.Info{GitVersion: "1.19"}).
+		Build(context.Background())
+
+	tt.Expect(err).To(MatchError(ContainSubstring("missing bundle signature annotation")))
+}
+
 func TestFactoryBuildWithCuratedPackagesCustomManifestWithExistingExecConfig(t *testing.T) {
 	tt := newTest(t, vsphere)
 	deps, err := dependencies.NewFactory().
@@ -736,6 +749,18 @@ func TestFactoryBuildWithBootstrapperNoTimeout(t *testing.T) {
 	tt.Expect(deps.Bootstrapper).NotTo(BeNil())
 }
 
+func TestFactoryBuildWithExistingBootstrapCluster(t *testing.T) {
+	tt := newTest(t, vsphere)
+	deps, err := dependencies.NewFactory().
+		WithLocalExecutables().
+		WithExistingBootstrapCluster("ops.kubeconfig").
+		WithBootstrapper().
+		Build(context.Background())
+
+	tt.Expect(err).To(BeNil())
+	tt.Expect(deps.Bootstrapper).NotTo(BeNil())
+}
+
 func TestFactoryBuildWithEksdUpgraderNoTimeout(t *testing.T) {
 	tt := newTest(t, vsphere)
 	deps, err := dependencies.NewFactory().
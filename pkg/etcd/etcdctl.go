@@ -0,0 +1,140 @@
+// Package etcd provides helpers to operate the etcd members of a cluster from outside of it.
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+)
+
+const (
+	// localEndpoint is used since etcdctl runs on the same machine as the member.
+	localEndpoint = "https://127.0.0.1:2379"
+
+	// The defragmentation blocks the member while it rewrites its database, which takes
+	// longer than the default 5s etcdctl timeout for large databases.
+	defragTimeout = "5m"
+
+	noSpaceAlarm = "NOSPACE"
+)
+
+// Etcdctl runs etcdctl commands on the etcd machines over SSH.
+type Etcdctl struct {
+	ssh certificates.SSHRunner
+}
+
+// NewEtcdctl returns a new Etcdctl.
+func NewEtcdctl(ssh certificates.SSHRunner) *Etcdctl {
+	return &Etcdctl{
+		ssh: ssh,
+	}
+}
+
+// MemberStatus is the status of an etcd member.
+type MemberStatus struct {
+	// MemberID is the ID of the member in hexadecimal.
+	MemberID string
+	// Leader is true when the member is the raft leader.
+	Leader      bool
+	DBSize      int64
+	DBSizeInUse int64
+}
+
+// FragmentedPercent returns the percentage of the database size that is not in use.
+func (s MemberStatus) FragmentedPercent() int {
+	if s.DBSize == 0 {
+		return 0
+	}
+	return int((s.DBSize - s.DBSizeInUse) * 100 / s.DBSize)
+}
+
+type endpointStatus struct {
+	Status struct {
+		Header struct {
+			MemberID uint64 `json:"member_id"`
+		} `json:"header"`
+		Leader      uint64 `json:"leader"`
+		DBSize      int64  `json:"dbSize"`
+		DBSizeInUse int64  `json:"dbSizeInUse"`
+	} `json:"Status"`
+}
+
+// MemberStatus returns the status of the etcd member running in node.
+// externalEtcd indicates whether the member runs in a dedicated etcd machine or in a control plane one.
+func (e *Etcdctl) MemberStatus(ctx context.Context, node string, externalEtcd bool) (*MemberStatus, error) {
+	out, err := e.ssh.RunCommand(ctx, node, command(externalEtcd, "endpoint status -w json"), certificates.WithSSHLogging(false))
+	if err != nil {
+		return nil, fmt.Errorf("getting etcd endpoint status in %s: %v", node, err)
+	}
+
+	statuses := []endpointStatus{}
+	if err := json.Unmarshal([]byte(lastLine(out)), &statuses); err != nil {
+		return nil, fmt.Errorf("parsing etcd endpoint status in %s: %v", node, err)
+	}
+	if len(statuses) != 1 {
+		return nil, fmt.Errorf("expected one etcd endpoint status in %s, got %d", node, len(statuses))
+	}
+
+	s := statuses[0].Status
+	return &MemberStatus{
+		MemberID:    fmt.Sprintf("%x", s.Header.MemberID),
+		Leader:      s.Header.MemberID == s.Leader,
+		DBSize:      s.DBSize,
+		DBSizeInUse: s.DBSizeInUse,
+	}, nil
+}
+
+// Defrag defragments the etcd member running in node and disarms the NOSPACE alarm
+// in case it was raised because the database reached its quota.
+func (e *Etcdctl) Defrag(ctx context.Context, node string, externalEtcd bool) error {
+	if _, err := e.ssh.RunCommand(ctx, node, command(externalEtcd, "defrag --command-timeout="+defragTimeout)); err != nil {
+		return fmt.Errorf("defragmenting etcd member in %s: %v", node, err)
+	}
+
+	alarms, err := e.ssh.RunCommand(ctx, node, command(externalEtcd, "alarm list"))
+	if err != nil {
+		return fmt.Errorf("listing etcd alarms in %s: %v", node, err)
+	}
+
+	if strings.Contains(alarms, noSpaceAlarm) {
+		if _, err := e.ssh.RunCommand(ctx, node, command(externalEtcd, "alarm disarm")); err != nil {
+			return fmt.Errorf("disarming etcd alarms in %s: %v", node, err)
+		}
+	}
+
+	return nil
+}
+
+// command returns a shell command that runs etcdctl against the local etcd member. Bottlerocket
+// doesn't ship etcdctl in the host, so the command is run in the etcd container through sheltie.
+// In the other OSes, the etcdadm installed binary is used for external etcd and the etcd static
+// pod container for stacked etcd.
+func command(externalEtcd bool, args string) string {
+	var linux, bottlerocket string
+	if externalEtcd {
+		linux = fmt.Sprintf("sudo ETCDCTL_API=3 etcdctl --cacert=%[1]s/ca.crt --cert=%[1]s/etcdctl-etcd-client.crt --key=%[1]s/etcdctl-etcd-client.key --endpoints=%[2]s %[3]s",
+			"/etc/etcd/pki", localEndpoint, args)
+		bottlerocket = containerEtcdctl("/var/lib/etcd/pki", args)
+	} else {
+		linux = fmt.Sprintf("sudo crictl exec $(sudo crictl ps -q --name '^etcd$' | head -1) etcdctl --cacert=%[1]s/ca.crt --cert=%[1]s/server.crt --key=%[1]s/server.key --endpoints=%[2]s %[3]s",
+			"/etc/kubernetes/pki/etcd", localEndpoint, args)
+		bottlerocket = containerEtcdctl("/etc/kubernetes/pki/etcd", args)
+	}
+
+	return fmt.Sprintf("if command -v sheltie >/dev/null 2>&1; then\nsudo sheltie << 'EOF'\nset -euo pipefail\n%s\nEOF\nelse\n%s\nfi", bottlerocket, linux)
+}
+
+func containerEtcdctl(pkiDir, args string) string {
+	return fmt.Sprintf(`ETCD_CONTAINER_ID=$(ctr -n k8s.io c ls | grep -w "etcd-io" | cut -d " " -f1 | tail -1)
+ctr -n k8s.io t exec --exec-id etcdctl-$$ ${ETCD_CONTAINER_ID} etcdctl --cacert=%[1]s/ca.crt --cert=%[1]s/server.crt --key=%[1]s/server.key --endpoints=%[2]s %[3]s`,
+		pkiDir, localEndpoint, args)
+}
+
+// lastLine returns the last line of the output, skipping the banners some OSes print on login.
+func lastLine(out string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	return lines[len(lines)-1]
+}
@@ -0,0 +1,148 @@
+package etcd_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/certificates/mocks"
+	"github.com/aws/eks-anywhere/pkg/etcd"
+)
+
+const endpointStatus = `[{"Endpoint":"https://127.0.0.1:2379","Status":{"header":{"cluster_id":14841639068965178418,"member_id":10276657743932975437,"revision":48213,"raft_term":4},"version":"3.5.9","dbSize":419430400,"leader":10276657743932975437,"raftIndex":51311,"raftTerm":4,"raftAppliedIndex":51311,"dbSizeInUse":104857600}}]`
+
+func TestEtcdctlMemberStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		wantErr string
+	}{
+		{
+			name:   "success",
+			output: endpointStatus,
+		},
+		{
+			name:   "login banner",
+			output: "Welcome to Ubuntu\n" + endpointStatus,
+		},
+		{
+			name:    "invalid output",
+			output:  "etcdctl: command not found",
+			wantErr: "parsing etcd endpoint status in 10.0.0.1",
+		},
+		{
+			name:    "multiple endpoints",
+			output:  "[]",
+			wantErr: "expected one etcd endpoint status in 10.0.0.1, got 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ssh := mocks.NewMockSSHRunner(gomock.NewController(t))
+			ssh.EXPECT().RunCommand(ctx, "10.0.0.1", gomock.Any(), gomock.Any()).Return(tt.output, nil)
+
+			status, err := etcd.NewEtcdctl(ssh).MemberStatus(ctx, "10.0.0.1", true)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(*status).To(Equal(etcd.MemberStatus{
+				MemberID:    "8e9e05c52164694d",
+				Leader:      true,
+				DBSize:      419430400,
+				DBSizeInUse: 104857600,
+			}))
+			g.Expect(status.FragmentedPercent()).To(Equal(75))
+		})
+	}
+}
+
+func TestEtcdctlMemberStatusCommand(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHRunner(gomock.NewController(t))
+	ssh.EXPECT().RunCommand(ctx, "10.0.0.1", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, cmd string, _ ...certificates.SSHOption) (string, error) {
+			g.Expect(cmd).To(ContainSubstring("sudo crictl exec"))
+			g.Expect(cmd).To(ContainSubstring("--cacert=/etc/kubernetes/pki/etcd/ca.crt"))
+			g.Expect(cmd).To(ContainSubstring("sudo sheltie"))
+			g.Expect(cmd).To(ContainSubstring("endpoint status -w json"))
+			return endpointStatus, nil
+		},
+	)
+
+	_, err := etcd.NewEtcdctl(ssh).MemberStatus(ctx, "10.0.0.1", false)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestEtcdctlMemberStatusError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHRunner(gomock.NewController(t))
+	ssh.EXPECT().RunCommand(ctx, "10.0.0.1", gomock.Any(), gomock.Any()).Return("", errors.New("connection refused"))
+
+	_, err := etcd.NewEtcdctl(ssh).MemberStatus(ctx, "10.0.0.1", true)
+	g.Expect(err).To(MatchError(ContainSubstring("getting etcd endpoint status in 10.0.0.1: connection refused")))
+}
+
+func TestEtcdctlDefrag(t *testing.T) {
+	tests := []struct {
+		name       string
+		alarms     string
+		wantDisarm bool
+	}{
+		{
+			name: "no alarms",
+		},
+		{
+			name:       "no space alarm",
+			alarms:     "memberID:10276657743932975437 alarm:NOSPACE",
+			wantDisarm: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			ssh := mocks.NewMockSSHRunner(gomock.NewController(t))
+			gomock.InOrder(
+				ssh.EXPECT().RunCommand(ctx, "10.0.0.1", containSubstring("defrag --command-timeout=5m")).Return("Finished defragmenting etcd member", nil),
+				ssh.EXPECT().RunCommand(ctx, "10.0.0.1", containSubstring("alarm list")).Return(tt.alarms, nil),
+			)
+			if tt.wantDisarm {
+				ssh.EXPECT().RunCommand(ctx, "10.0.0.1", containSubstring("alarm disarm")).Return("", nil)
+			}
+
+			g.Expect(etcd.NewEtcdctl(ssh).Defrag(ctx, "10.0.0.1", true)).To(Succeed())
+		})
+	}
+}
+
+func TestEtcdctlDefragError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ssh := mocks.NewMockSSHRunner(gomock.NewController(t))
+	ssh.EXPECT().RunCommand(ctx, "10.0.0.1", gomock.Any()).Return("", errors.New("timeout"))
+
+	g.Expect(etcd.NewEtcdctl(ssh).Defrag(ctx, "10.0.0.1", true)).To(MatchError(ContainSubstring("defragmenting etcd member in 10.0.0.1: timeout")))
+}
+
+// containSubstring matches the commands that contain it.
+type containSubstring string
+
+func (c containSubstring) Matches(x interface{}) bool {
+	cmd, ok := x.(string)
+	return ok && strings.Contains(cmd, string(c))
+}
+
+func (c containSubstring) String() string {
+	return "contains " + string(c)
+}
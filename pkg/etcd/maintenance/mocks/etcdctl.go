@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/etcd/maintenance/reconciler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	etcd "github.com/aws/eks-anywhere/pkg/etcd"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEtcdctl is a mock of Etcdctl interface.
+type MockEtcdctl struct {
+	ctrl     *gomock.Controller
+	recorder *MockEtcdctlMockRecorder
+}
+
+// MockEtcdctlMockRecorder is the mock recorder for MockEtcdctl.
+type MockEtcdctlMockRecorder struct {
+	mock *MockEtcdctl
+}
+
+// NewMockEtcdctl creates a new mock instance.
+func NewMockEtcdctl(ctrl *gomock.Controller) *MockEtcdctl {
+	mock := &MockEtcdctl{ctrl: ctrl}
+	mock.recorder = &MockEtcdctlMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEtcdctl) EXPECT() *MockEtcdctlMockRecorder {
+	return m.recorder
+}
+
+// Defrag mocks base method.
+func (m *MockEtcdctl) Defrag(ctx context.Context, node string, externalEtcd bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Defrag", ctx, node, externalEtcd)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Defrag indicates an expected call of Defrag.
+func (mr *MockEtcdctlMockRecorder) Defrag(ctx, node, externalEtcd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Defrag", reflect.TypeOf((*MockEtcdctl)(nil).Defrag), ctx, node, externalEtcd)
+}
+
+// MemberStatus mocks base method.
+func (m *MockEtcdctl) MemberStatus(ctx context.Context, node string, externalEtcd bool) (*etcd.MemberStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MemberStatus", ctx, node, externalEtcd)
+	ret0, _ := ret[0].(*etcd.MemberStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MemberStatus indicates an expected call of MemberStatus.
+func (mr *MockEtcdctlMockRecorder) MemberStatus(ctx, node, externalEtcd interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MemberStatus", reflect.TypeOf((*MockEtcdctl)(nil).MemberStatus), ctx, node, externalEtcd)
+}
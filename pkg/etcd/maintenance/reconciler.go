@@ -0,0 +1,288 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/certificates"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/etcd"
+)
+
+const (
+	externalEtcdLabel = "cluster.x-k8s.io/etcd-cluster"
+
+	sshUsernameKey   = "username"
+	sshPassphraseKey = "passphrase"
+	sshKnownHostsKey = "known_hosts"
+
+	// minDefragDBSize avoids defragmenting members with small databases, where the unused
+	// space is not worth blocking the member for.
+	minDefragDBSize = 64 * 1024 * 1024
+
+	// defragRequeueTime gives the defragmented member time to catch up with the rest of
+	// the cluster before checking the members again and defragmenting the next one.
+	defragRequeueTime = 30 * time.Second
+)
+
+// Etcdctl runs etcdctl commands in the etcd machines.
+type Etcdctl interface {
+	MemberStatus(ctx context.Context, node string, externalEtcd bool) (*etcd.MemberStatus, error)
+	Defrag(ctx context.Context, node string, externalEtcd bool) error
+}
+
+// EtcdctlBuilder builds an Etcdctl that accesses the etcd machines with the given SSH credentials,
+// only trusting the host keys in knownHosts.
+type EtcdctlBuilder func(user string, key []byte, passphrase string, knownHosts []byte) (Etcdctl, error)
+
+// NewSSHEtcdctl builds an Etcdctl that runs the commands over SSH.
+func NewSSHEtcdctl(user string, key []byte, passphrase string, knownHosts []byte) (Etcdctl, error) {
+	ssh, err := certificates.NewSSHRunnerWithKey(user, key, passphrase, knownHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	return etcd.NewEtcdctl(ssh), nil
+}
+
+// Reconciler checks the database size of the etcd members and defragments them.
+type Reconciler struct {
+	client         client.Client
+	etcdctlBuilder EtcdctlBuilder
+}
+
+// New returns a new Reconciler.
+func New(client client.Client, etcdctlBuilder EtcdctlBuilder) *Reconciler {
+	return &Reconciler{
+		client:         client,
+		etcdctlBuilder: etcdctlBuilder,
+	}
+}
+
+type member struct {
+	machine string
+	ip      string
+	status  *etcd.MemberStatus
+}
+
+// Reconcile checks the etcd members of the cluster every check interval, publishing their database
+// sizes in the cluster status, and defragments the members above the configured threshold
+// inside the maintenance window. Only one member is defragmented per call, followers first and the leader
+// last, and only when all the members are reachable, so a member being defragmented never puts the
+// cluster quorum at risk.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *anywherev1.Cluster) (controller.Result, error) {
+	config := cluster.Spec.EtcdMaintenance
+	if config == nil {
+		cluster.Status.EtcdMaintenance = nil
+		return controller.Result{}, nil
+	}
+
+	// The etcd members change while the cluster is being created or upgraded.
+	if !v1beta1conditions.IsTrue(cluster, anywherev1.ReadyCondition) {
+		return controller.Result{}, nil
+	}
+
+	log = log.WithValues("component", "etcdMaintenance")
+	now := time.Now()
+	status := cluster.Status.EtcdMaintenance
+
+	if status != nil && status.LastCheckTime != nil {
+		nextCheck := status.LastCheckTime.Add(config.Interval())
+		pending := len(defragCandidates(status.Members, config)) > 0
+		if now.Before(nextCheck) && !(pending && inWindow(config, now)) {
+			return requeueAt(now, nextRun(config, now, nextCheck, pending)), nil
+		}
+	}
+
+	etcdctl, err := r.buildEtcdctl(ctx, config)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	externalEtcd := cluster.Spec.ExternalEtcdConfiguration != nil
+	members, err := r.checkMembers(ctx, cluster, etcdctl, externalEtcd)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	status = newStatus(now, members, status)
+	cluster.Status.EtcdMaintenance = status
+	nextCheck := now.Add(config.Interval())
+
+	candidates := defragCandidates(status.Members, config)
+	if len(candidates) == 0 {
+		return requeueAt(now, nextCheck), nil
+	}
+
+	if !inWindow(config, now) {
+		log.Info("Etcd members need defragmentation, waiting for the maintenance window", "members", candidates)
+		return requeueAt(now, nextRun(config, now, nextCheck, true)), nil
+	}
+
+	target := candidates[0]
+	var m member
+	for _, mem := range members {
+		if mem.machine == target {
+			m = mem
+		}
+	}
+
+	log.Info("Defragmenting etcd member", "machine", m.machine, "dbSize", m.status.DBSize, "dbSizeInUse", m.status.DBSizeInUse)
+	if err := etcdctl.Defrag(ctx, m.ip, externalEtcd); err != nil {
+		return controller.Result{}, err
+	}
+
+	for i := range status.Members {
+		if status.Members[i].Machine == m.machine {
+			status.Members[i].LastDefragTime = &metav1.Time{Time: now}
+		}
+	}
+
+	return controller.Result{Result: &ctrl.Result{RequeueAfter: defragRequeueTime}}, nil
+}
+
+func (r *Reconciler) buildEtcdctl(ctx context.Context, config *anywherev1.EtcdMaintenanceConfiguration) (Etcdctl, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: constants.EksaSystemNamespace, Name: config.SSHKeySecretName}
+	if err := r.client.Get(ctx, key, secret); err != nil {
+		return nil, errors.Wrap(err, "reading etcd maintenance SSH key secret")
+	}
+
+	user := string(secret.Data[sshUsernameKey])
+	privateKey := secret.Data[corev1.SSHAuthPrivateKey]
+	knownHosts := secret.Data[sshKnownHostsKey]
+	if user == "" || len(privateKey) == 0 || len(knownHosts) == 0 {
+		return nil, fmt.Errorf("etcd maintenance SSH key secret %s must contain the %s, %s and %s keys",
+			config.SSHKeySecretName, sshUsernameKey, corev1.SSHAuthPrivateKey, sshKnownHostsKey)
+	}
+
+	etcdctl, err := r.etcdctlBuilder(user, privateKey, string(secret.Data[sshPassphraseKey]), knownHosts)
+	if err != nil {
+		return nil, errors.Wrap(err, "building etcd SSH client")
+	}
+
+	return etcdctl, nil
+}
+
+// checkMembers returns the status of all the etcd members, failing if any of them can't be checked.
+func (r *Reconciler) checkMembers(ctx context.Context, cluster *anywherev1.Cluster, etcdctl Etcdctl, externalEtcd bool) ([]member, error) {
+	selector := client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}
+	if externalEtcd {
+		selector[externalEtcdLabel] = cluster.Name + "-etcd"
+	} else {
+		selector[clusterv1.MachineControlPlaneLabel] = ""
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := r.client.List(ctx, machines, client.InNamespace(constants.EksaSystemNamespace), selector); err != nil {
+		return nil, errors.Wrap(err, "listing etcd machines")
+	}
+
+	members := make([]member, 0, len(machines.Items))
+	for _, machine := range machines.Items {
+		ip := machineIP(machine)
+		if ip == "" {
+			return nil, fmt.Errorf("etcd machine %s doesn't have an IP", machine.Name)
+		}
+
+		s, err := etcdctl.MemberStatus(ctx, ip, externalEtcd)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, member{machine: machine.Name, ip: ip, status: s})
+	}
+
+	sort.Slice(members, func(i, j int) bool { return members[i].machine < members[j].machine })
+
+	return members, nil
+}
+
+// machineIP returns the external IP of the machine or, for the providers that only report
+// internal addresses, its internal IP.
+func machineIP(machine clusterv1.Machine) string {
+	for _, addressType := range []clusterv1.MachineAddressType{clusterv1.MachineExternalIP, clusterv1.MachineInternalIP} {
+		for _, address := range machine.Status.Addresses {
+			if address.Type == addressType && address.Address != "" {
+				return address.Address
+			}
+		}
+	}
+	return ""
+}
+
+func newStatus(now time.Time, members []member, previous *anywherev1.EtcdMaintenanceStatus) *anywherev1.EtcdMaintenanceStatus {
+	lastDefrag := map[string]*metav1.Time{}
+	if previous != nil {
+		for _, m := range previous.Members {
+			lastDefrag[m.Machine] = m.LastDefragTime
+		}
+	}
+
+	status := &anywherev1.EtcdMaintenanceStatus{
+		LastCheckTime: &metav1.Time{Time: now},
+	}
+	for _, m := range members {
+		status.Members = append(status.Members, anywherev1.EtcdMemberStatus{
+			Machine:          m.machine,
+			MemberID:         m.status.MemberID,
+			Leader:           m.status.Leader,
+			DBSizeBytes:      m.status.DBSize,
+			DBSizeInUseBytes: m.status.DBSizeInUse,
+			LastDefragTime:   lastDefrag[m.machine],
+		})
+	}
+
+	return status
+}
+
+// defragCandidates returns the machines of the members that need to be defragmented,
+// with the leader last.
+func defragCandidates(members []anywherev1.EtcdMemberStatus, config *anywherev1.EtcdMaintenanceConfiguration) []string {
+	var followers, leaders []string
+	for _, m := range members {
+		s := etcd.MemberStatus{DBSize: m.DBSizeBytes, DBSizeInUse: m.DBSizeInUseBytes}
+		if m.DBSizeBytes < minDefragDBSize || s.FragmentedPercent() < config.Threshold() {
+			continue
+		}
+		if m.Leader {
+			leaders = append(leaders, m.Machine)
+		} else {
+			followers = append(followers, m.Machine)
+		}
+	}
+
+	return append(followers, leaders...)
+}
+
+func inWindow(config *anywherev1.EtcdMaintenanceConfiguration, now time.Time) bool {
+	return config.Window == nil || config.Window.Contains(now)
+}
+
+// nextRun returns the next time the members should be checked: at the next check or, when there are
+// members pending defragmentation, at the start of the next window if that's sooner.
+func nextRun(config *anywherev1.EtcdMaintenanceConfiguration, now, nextCheck time.Time, pending bool) time.Time {
+	if pending && config.Window != nil && !config.Window.Contains(now) {
+		if windowStart := config.Window.NextStart(now); windowStart.Before(nextCheck) {
+			return windowStart
+		}
+	}
+	return nextCheck
+}
+
+func requeueAt(now, t time.Time) controller.Result {
+	return controller.Result{Result: &ctrl.Result{RequeueAfter: t.Sub(now)}}
+}
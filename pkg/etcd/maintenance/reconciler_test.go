@@ -0,0 +1,299 @@
+package maintenance_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	"sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/etcd"
+	"github.com/aws/eks-anywhere/pkg/etcd/maintenance"
+	"github.com/aws/eks-anywhere/pkg/etcd/maintenance/mocks"
+)
+
+const (
+	mib = 1024 * 1024
+	// defragmented is the size of a member database with no space to reclaim.
+	defragmented = 100 * mib
+)
+
+type reconcileTest struct {
+	*WithT
+	ctx        context.Context
+	cluster    *anywherev1.Cluster
+	etcdctl    *mocks.MockEtcdctl
+	objs       []client.Object
+	sshUser    string
+	sshKey     []byte
+	knownHosts []byte
+	buildError error
+}
+
+func newReconcileTest(t *testing.T) *reconcileTest {
+	ctrl := gomock.NewController(t)
+	cluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			ExternalEtcdConfiguration: &anywherev1.ExternalEtcdConfiguration{Count: 3},
+			EtcdMaintenance: &anywherev1.EtcdMaintenanceConfiguration{
+				SSHKeySecretName: "etcd-ssh",
+			},
+		},
+	}
+	conditions.MarkTrue(cluster, anywherev1.ReadyCondition)
+
+	return &reconcileTest{
+		WithT:   NewWithT(t),
+		ctx:     context.Background(),
+		cluster: cluster,
+		etcdctl: mocks.NewMockEtcdctl(ctrl),
+		objs: []client.Object{
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd-ssh", Namespace: constants.EksaSystemNamespace},
+				Data: map[string][]byte{
+					"username":       []byte("ec2-user"),
+					"ssh-privatekey": []byte("key"),
+					"known_hosts":    []byte("10.0.0.1 ssh-ed25519 AAAA"),
+				},
+			},
+			etcdMachine("my-cluster-etcd-a", "10.0.0.1"),
+			etcdMachine("my-cluster-etcd-b", "10.0.0.2"),
+			etcdMachine("my-cluster-etcd-c", "10.0.0.3"),
+			// Control plane machines shouldn't be touched for external etcd.
+			controlPlaneMachine("my-cluster-cp-a", "10.0.0.10"),
+		},
+	}
+}
+
+func (tt *reconcileTest) reconciler() *maintenance.Reconciler {
+	c := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+	return maintenance.New(c, func(user string, key []byte, _ string, knownHosts []byte) (maintenance.Etcdctl, error) {
+		tt.sshUser = user
+		tt.sshKey = key
+		tt.knownHosts = knownHosts
+		return tt.etcdctl, tt.buildError
+	})
+}
+
+func (tt *reconcileTest) expectMemberStatus(ip string, leader bool, dbSize, inUse int64) {
+	tt.etcdctl.EXPECT().MemberStatus(tt.ctx, ip, true).Return(&etcd.MemberStatus{
+		MemberID:    "id-" + ip,
+		Leader:      leader,
+		DBSize:      dbSize,
+		DBSizeInUse: inUse,
+	}, nil)
+}
+
+func etcdMachine(name, ip string) *clusterv1.Machine {
+	m := machine(name, ip)
+	m.Labels["cluster.x-k8s.io/etcd-cluster"] = "my-cluster-etcd"
+	return m
+}
+
+func controlPlaneMachine(name, ip string) *clusterv1.Machine {
+	m := machine(name, ip)
+	m.Labels[clusterv1.MachineControlPlaneLabel] = ""
+	return m
+}
+
+func machine(name, ip string) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: constants.EksaSystemNamespace,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "my-cluster"},
+		},
+		Status: clusterv1.MachineStatus{
+			Addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineExternalIP, Address: ip},
+			},
+		},
+	}
+}
+
+func TestReconcilerReconcileNotConfigured(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.cluster.Spec.EtcdMaintenance = nil
+	tt.cluster.Status.EtcdMaintenance = &anywherev1.EtcdMaintenanceStatus{}
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeFalse())
+	tt.Expect(tt.cluster.Status.EtcdMaintenance).To(BeNil())
+}
+
+func TestReconcilerReconcileClusterNotReady(t *testing.T) {
+	tt := newReconcileTest(t)
+	conditions.MarkFalse(tt.cluster, anywherev1.ReadyCondition, "Upgrading", clusterv1.ConditionSeverityInfo, "")
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeFalse())
+}
+
+func TestReconcilerReconcileNoDefragNeeded(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.expectMemberStatus("10.0.0.1", false, defragmented, defragmented)
+	tt.expectMemberStatus("10.0.0.2", true, defragmented, defragmented)
+	// Small databases are not defragmented even if mostly unused.
+	tt.expectMemberStatus("10.0.0.3", false, 10*mib, 1*mib)
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Second))
+	tt.Expect(tt.sshUser).To(Equal("ec2-user"))
+	tt.Expect(tt.sshKey).To(Equal([]byte("key")))
+	tt.Expect(tt.knownHosts).To(Equal([]byte("10.0.0.1 ssh-ed25519 AAAA")))
+
+	status := tt.cluster.Status.EtcdMaintenance
+	tt.Expect(status.LastCheckTime).NotTo(BeNil())
+	tt.Expect(status.Members).To(HaveLen(3))
+	tt.Expect(status.Members[1]).To(Equal(anywherev1.EtcdMemberStatus{
+		Machine:          "my-cluster-etcd-b",
+		MemberID:         "id-10.0.0.2",
+		Leader:           true,
+		DBSizeBytes:      defragmented,
+		DBSizeInUseBytes: defragmented,
+	}))
+}
+
+func TestReconcilerReconcileDefragFollowersBeforeLeader(t *testing.T) {
+	tt := newReconcileTest(t)
+	r := tt.reconciler()
+
+	// The leader is first in the machine order, but a follower is defragmented first.
+	tt.expectMemberStatus("10.0.0.1", true, 400*mib, 100*mib)
+	tt.expectMemberStatus("10.0.0.2", false, 400*mib, 100*mib)
+	tt.expectMemberStatus("10.0.0.3", false, defragmented, defragmented)
+	tt.etcdctl.EXPECT().Defrag(tt.ctx, "10.0.0.2", true).Return(nil)
+
+	result, err := r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Result.RequeueAfter).To(Equal(30 * time.Second))
+	tt.Expect(tt.cluster.Status.EtcdMaintenance.Members[1].LastDefragTime).NotTo(BeNil())
+
+	// The pending defragmentation triggers a new check before the interval.
+	tt.expectMemberStatus("10.0.0.1", true, 400*mib, 100*mib)
+	tt.expectMemberStatus("10.0.0.2", false, 100*mib, 100*mib)
+	tt.expectMemberStatus("10.0.0.3", false, defragmented, defragmented)
+	tt.etcdctl.EXPECT().Defrag(tt.ctx, "10.0.0.1", true).Return(nil)
+
+	_, err = r.Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.EtcdMaintenance.Members[0].LastDefragTime).NotTo(BeNil())
+	tt.Expect(tt.cluster.Status.EtcdMaintenance.Members[1].LastDefragTime).NotTo(BeNil())
+}
+
+func TestReconcilerReconcileCheckNotDue(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.cluster.Status.EtcdMaintenance = &anywherev1.EtcdMaintenanceStatus{
+		LastCheckTime: &metav1.Time{Time: time.Now().Add(-20 * time.Minute)},
+		Members: []anywherev1.EtcdMemberStatus{
+			{Machine: "my-cluster-etcd-a", DBSizeBytes: defragmented, DBSizeInUseBytes: defragmented},
+		},
+	}
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Result.RequeueAfter).To(BeNumerically("~", 40*time.Minute, time.Second))
+}
+
+func TestReconcilerReconcileOutsideWindow(t *testing.T) {
+	tt := newReconcileTest(t)
+	windowStart := time.Now().UTC().Add(3 * time.Hour)
+	tt.cluster.Spec.EtcdMaintenance.CheckInterval = &metav1.Duration{Duration: 24 * time.Hour}
+	tt.cluster.Spec.EtcdMaintenance.Window = &anywherev1.EtcdMaintenanceWindow{
+		Start:    windowStart.Format("15:04"),
+		Duration: metav1.Duration{Duration: time.Hour},
+	}
+	tt.expectMemberStatus("10.0.0.1", false, 400*mib, 100*mib)
+	tt.expectMemberStatus("10.0.0.2", true, defragmented, defragmented)
+	tt.expectMemberStatus("10.0.0.3", false, defragmented, defragmented)
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Result.RequeueAfter).To(BeNumerically("~", 3*time.Hour, time.Minute))
+}
+
+func TestReconcilerReconcileErrorMemberStatus(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.expectMemberStatus("10.0.0.1", false, 400*mib, 100*mib)
+	tt.etcdctl.EXPECT().MemberStatus(tt.ctx, "10.0.0.2", true).Return(nil, errors.New("connection refused"))
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+}
+
+func TestReconcilerReconcileErrorDefrag(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.expectMemberStatus("10.0.0.1", false, 400*mib, 100*mib)
+	tt.expectMemberStatus("10.0.0.2", true, defragmented, defragmented)
+	tt.expectMemberStatus("10.0.0.3", false, defragmented, defragmented)
+	tt.etcdctl.EXPECT().Defrag(tt.ctx, "10.0.0.1", true).Return(errors.New("timeout"))
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("timeout")))
+}
+
+func TestReconcilerReconcileInternalIP(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.cluster.Spec.ExternalEtcdConfiguration = nil
+	m := controlPlaneMachine("my-cluster-cp-a", "")
+	m.Status.Addresses = clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineHostName, Address: "my-cluster-cp-a"},
+		{Type: clusterv1.MachineInternalIP, Address: "192.168.0.10"},
+	}
+	tt.objs[len(tt.objs)-1] = m
+	tt.etcdctl.EXPECT().MemberStatus(tt.ctx, "192.168.0.10", false).Return(&etcd.MemberStatus{Leader: true, DBSize: defragmented, DBSizeInUse: defragmented}, nil)
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.EtcdMaintenance.Members).To(HaveLen(1))
+}
+
+func TestReconcilerReconcileStackedEtcd(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.cluster.Spec.ExternalEtcdConfiguration = nil
+	tt.etcdctl.EXPECT().MemberStatus(tt.ctx, "10.0.0.10", false).Return(&etcd.MemberStatus{Leader: true, DBSize: defragmented, DBSizeInUse: defragmented}, nil)
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.cluster.Status.EtcdMaintenance.Members).To(HaveLen(1))
+	tt.Expect(tt.cluster.Status.EtcdMaintenance.Members[0].Machine).To(Equal("my-cluster-cp-a"))
+}
+
+func TestReconcilerReconcileErrorMissingSecret(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.objs = tt.objs[1:]
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("reading etcd maintenance SSH key secret")))
+}
+
+func TestReconcilerReconcileErrorInvalidSecret(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.objs[0].(*corev1.Secret).Data = map[string][]byte{"username": []byte("ec2-user")}
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("must contain the username, ssh-privatekey and known_hosts keys")))
+}
+
+func TestReconcilerReconcileErrorBuildingEtcdctl(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.buildError = errors.New("invalid key")
+
+	_, err := tt.reconciler().Reconcile(tt.ctx, test.NewNullLogger(), tt.cluster)
+	tt.Expect(err).To(MatchError(ContainSubstring("building etcd SSH client: invalid key")))
+}
@@ -335,6 +335,7 @@ func TestClusterctlMoveManagement(t *testing.T) {
 }
 
 func TestClusterctlUpgradeAllProvidersSucess(t *testing.T) {
+	chdirTemp(t)
 	tt := newClusterctlTest(t)
 
 	changeDiff := &clusterapi.CAPIChangeDiff{
@@ -384,6 +385,7 @@ func TestClusterctlUpgradeAllProvidersSucess(t *testing.T) {
 }
 
 func TestClusterctlUpgradeInfrastructureProvidersSucess(t *testing.T) {
+	chdirTemp(t)
 	tt := newClusterctlTest(t)
 
 	changeDiff := &clusterapi.CAPIChangeDiff{
@@ -406,6 +408,7 @@ func TestClusterctlUpgradeInfrastructureProvidersSucess(t *testing.T) {
 }
 
 func TestClusterctlUpgradeInfrastructureProvidersError(t *testing.T) {
+	chdirTemp(t)
 	tt := newClusterctlTest(t)
 
 	changeDiff := &clusterapi.CAPIChangeDiff{
@@ -5,9 +5,11 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -27,8 +29,19 @@ const (
 	defaultCloudStackPreflightTimeout = "30"
 	rootDomain                        = "ROOT"
 	domainDelimiter                   = "/"
+	cmkHostTypeRouting                = "Routing"
+	cmkHostStateUp                    = "Up"
+	cmkHostResourceStateEnabled       = "Enabled"
+
+	// cmkAPINotAvailableErrorCode is the HTTP status code CloudStack returns for the APIs the account
+	// isn't allowed to call.
+	cmkAPINotAvailableErrorCode = "HTTP 432"
 )
 
+// ErrCloudStackAPINotPermitted is returned when the account of a CloudStack profile isn't allowed to
+// call an API, like list hosts which is only available to root admins.
+var ErrCloudStackAPINotPermitted = errors.New("api not available for the account")
+
 // Cmk this struct wraps around the CloudMonkey executable CLI to perform operations against a CloudStack endpoint.
 type Cmk struct {
 	writer     filewriter.FileWriter
@@ -113,6 +126,12 @@ func (c *Cmk) SearchTemplate(ctx context.Context, profile string, template v1alp
 }
 
 func (c *Cmk) ValidateServiceOfferingPresent(ctx context.Context, profile string, zoneId string, serviceOffering v1alpha1.CloudStackResourceIdentifier) error {
+	_, err := c.GetServiceOffering(ctx, profile, zoneId, serviceOffering)
+	return err
+}
+
+// GetServiceOffering returns the compute capacity of a service offering in a zone.
+func (c *Cmk) GetServiceOffering(ctx context.Context, profile string, zoneId string, serviceOffering v1alpha1.CloudStackResourceIdentifier) (CloudStackServiceOffering, error) {
 	command := newCmkCommand("list serviceofferings")
 	if len(serviceOffering.Id) > 0 {
 		applyCmkArgs(&command, withCloudStackId(serviceOffering.Id))
@@ -122,26 +141,32 @@ func (c *Cmk) ValidateServiceOfferingPresent(ctx context.Context, profile string
 	applyCmkArgs(&command, withCloudStackZoneId(zoneId))
 	result, err := c.exec(ctx, profile, command...)
 	if err != nil {
-		return fmt.Errorf("getting service offerings info - %s: %v", result.String(), err)
+		return CloudStackServiceOffering{}, fmt.Errorf("getting service offerings info - %s: %v", result.String(), err)
 	}
 	if result.Len() == 0 {
-		return fmt.Errorf("service offering %s not found", serviceOffering)
+		return CloudStackServiceOffering{}, fmt.Errorf("service offering %s not found", serviceOffering)
 	}
 
 	response := struct {
 		CmkServiceOfferings []cmkServiceOffering `json:"serviceoffering"`
 	}{}
 	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
-		return fmt.Errorf("parsing response into json: %v", err)
+		return CloudStackServiceOffering{}, fmt.Errorf("parsing response into json: %v", err)
 	}
 	offerings := response.CmkServiceOfferings
 	if len(offerings) > 1 {
-		return fmt.Errorf("duplicate service offering %s found", serviceOffering)
+		return CloudStackServiceOffering{}, fmt.Errorf("duplicate service offering %s found", serviceOffering)
 	} else if len(offerings) == 0 {
-		return fmt.Errorf("service offering %s not found", serviceOffering)
+		return CloudStackServiceOffering{}, fmt.Errorf("service offering %s not found", serviceOffering)
 	}
 
-	return nil
+	return CloudStackServiceOffering{
+		Id:        offerings[0].Id,
+		Name:      offerings[0].Name,
+		CPUNumber: offerings[0].CpuNumber,
+		CPUSpeed:  offerings[0].CpuSpeed,
+		MemoryMiB: offerings[0].Memory,
+	}, nil
 }
 
 func (c *Cmk) ValidateDiskOfferingPresent(ctx context.Context, profile string, zoneId string, diskOffering v1alpha1.CloudStackResourceDiskOffering) error {
@@ -184,39 +209,164 @@ func (c *Cmk) ValidateDiskOfferingPresent(ctx context.Context, profile string, z
 
 func (c *Cmk) ValidateAffinityGroupsPresent(ctx context.Context, profile string, domainId string, account string, affinityGroupIds []string) error {
 	for _, affinityGroupId := range affinityGroupIds {
-		command := newCmkCommand("list affinitygroups")
-		applyCmkArgs(&command, withCloudStackId(affinityGroupId))
-		// account must be specified with a domainId
-		// domainId can be specified without account
-		if len(domainId) > 0 {
-			applyCmkArgs(&command, withCloudStackDomainId(domainId))
-			if len(account) > 0 {
-				applyCmkArgs(&command, withCloudStackAccount(account))
-			}
+		if _, err := c.GetAffinityGroup(ctx, profile, domainId, account, affinityGroupId); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		result, err := c.exec(ctx, profile, command...)
-		if err != nil {
-			return fmt.Errorf("getting affinity group info - %s: %v", result.String(), err)
+// GetAffinityGroup returns the affinity group with the given id.
+func (c *Cmk) GetAffinityGroup(ctx context.Context, profile string, domainId string, account string, affinityGroupId string) (CloudStackAffinityGroup, error) {
+	command := newCmkCommand("list affinitygroups")
+	applyCmkArgs(&command, withCloudStackId(affinityGroupId))
+	// account must be specified with a domainId
+	// domainId can be specified without account
+	if len(domainId) > 0 {
+		applyCmkArgs(&command, withCloudStackDomainId(domainId))
+		if len(account) > 0 {
+			applyCmkArgs(&command, withCloudStackAccount(account))
 		}
-		if result.Len() == 0 {
-			return fmt.Errorf("affinity group %s not found", affinityGroupId)
+	}
+
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return CloudStackAffinityGroup{}, fmt.Errorf("getting affinity group info - %s: %v", result.String(), err)
+	}
+	if result.Len() == 0 {
+		return CloudStackAffinityGroup{}, fmt.Errorf("affinity group %s not found", affinityGroupId)
+	}
+
+	response := struct {
+		CmkAffinityGroups []cmkAffinityGroup `json:"affinitygroup"`
+	}{}
+	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+		return CloudStackAffinityGroup{}, fmt.Errorf("parsing response into json: %v", err)
+	}
+	affinityGroup := response.CmkAffinityGroups
+	if len(affinityGroup) > 1 {
+		return CloudStackAffinityGroup{}, fmt.Errorf("duplicate affinity group %s found", affinityGroupId)
+	} else if len(affinityGroup) == 0 {
+		return CloudStackAffinityGroup{}, fmt.Errorf("affinity group %s not found", affinityGroupId)
+	}
+
+	return CloudStackAffinityGroup{
+		Id:   affinityGroup[0].Id,
+		Name: affinityGroup[0].Name,
+		Type: affinityGroup[0].Type,
+	}, nil
+}
+
+// ListHosts returns the enabled hypervisor hosts that are up in a zone. Listing hosts requires a root
+// admin account: it returns an error wrapping ErrCloudStackAPINotPermitted for other accounts.
+func (c *Cmk) ListHosts(ctx context.Context, profile string, zoneId string) ([]CloudStackHost, error) {
+	command := newCmkCommand("list hosts")
+	applyCmkArgs(&command, withCloudStackType(cmkHostTypeRouting), withCloudStackZoneId(zoneId))
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		if strings.Contains(err.Error(), cmkAPINotAvailableErrorCode) || strings.Contains(result.String(), cmkAPINotAvailableErrorCode) {
+			return nil, fmt.Errorf("getting hosts info: %w", ErrCloudStackAPINotPermitted)
 		}
+		return nil, fmt.Errorf("getting hosts info - %s: %v", result.String(), err)
+	}
+	if result.Len() == 0 {
+		return nil, nil
+	}
+
+	response := struct {
+		CmkHosts []cmkHost `json:"host"`
+	}{}
+	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("parsing response into json: %v", err)
+	}
 
-		response := struct {
-			CmkAffinityGroups []cmkAffinityGroup `json:"affinitygroup"`
-		}{}
-		if err = json.Unmarshal(result.Bytes(), &response); err != nil {
-			return fmt.Errorf("parsing response into json: %v", err)
+	hosts := make([]CloudStackHost, 0, len(response.CmkHosts))
+	for _, h := range response.CmkHosts {
+		if h.State != cmkHostStateUp || h.ResourceState != cmkHostResourceStateEnabled {
+			continue
+		}
+
+		cpu := int64(h.CpuNumber) * int64(h.CpuSpeed)
+		if overprovisioned, err := strconv.ParseFloat(h.CpuWithOverprovisioning, 64); err == nil {
+			cpu = int64(overprovisioned)
 		}
-		affinityGroup := response.CmkAffinityGroups
-		if len(affinityGroup) > 1 {
-			return fmt.Errorf("duplicate affinity group %s found", affinityGroupId)
-		} else if len(affinityGroup) == 0 {
-			return fmt.Errorf("affinity group %s not found", affinityGroupId)
+		memory := h.MemoryTotal
+		if overprovisioned, err := strconv.ParseFloat(h.MemoryWithOverprovisioning, 64); err == nil {
+			memory = int64(overprovisioned)
 		}
+
+		hosts = append(hosts, CloudStackHost{
+			Id:                   h.Id,
+			Name:                 h.Name,
+			CPUMHz:               cpu,
+			CPUAllocatedMHz:      h.CpuAllocatedValue,
+			MemoryBytes:          memory,
+			MemoryAllocatedBytes: h.MemoryAllocated,
+		})
 	}
-	return nil
+
+	return hosts, nil
+}
+
+// ListZones returns the names of the zones.
+func (c *Cmk) ListZones(ctx context.Context, profile string) ([]string, error) {
+	return c.listNames(ctx, profile, "zone", newCmkCommand("list zones"))
+}
+
+// ListNetworks returns the names of the networks of a zone.
+func (c *Cmk) ListNetworks(ctx context.Context, profile string, zoneId string) ([]string, error) {
+	command := newCmkCommand("list networks")
+	applyCmkArgs(&command, withCloudStackZoneId(zoneId), appendArgs("listall=true"))
+	return c.listNames(ctx, profile, "network", command)
+}
+
+// ListServiceOfferings returns the names of the service offerings of a zone.
+func (c *Cmk) ListServiceOfferings(ctx context.Context, profile string, zoneId string) ([]string, error) {
+	command := newCmkCommand("list serviceofferings")
+	applyCmkArgs(&command, withCloudStackZoneId(zoneId))
+	return c.listNames(ctx, profile, "serviceoffering", command)
+}
+
+// ListTemplates returns the names of the templates of a zone.
+func (c *Cmk) ListTemplates(ctx context.Context, profile string, zoneId string) ([]string, error) {
+	command := newCmkCommand("list templates")
+	applyCmkArgs(&command, appendArgs("templatefilter=all"), appendArgs("listall=true"), withCloudStackZoneId(zoneId))
+	return c.listNames(ctx, profile, "template", command)
+}
+
+// listNames runs a cmk list command and returns the sorted unique names of the listed resources.
+func (c *Cmk) listNames(ctx context.Context, profile, resource string, command []string) ([]string, error) {
+	result, err := c.exec(ctx, profile, command...)
+	if err != nil {
+		return nil, fmt.Errorf("getting %ss info - %s: %v", resource, result.String(), err)
+	}
+	if result.Len() == 0 {
+		return nil, nil
+	}
+
+	response := map[string]json.RawMessage{}
+	if err = json.Unmarshal(result.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("parsing response into json: %v", err)
+	}
+	resources := []cmkResourceIdentifier{}
+	if raw, ok := response[resource]; ok {
+		if err = json.Unmarshal(raw, &resources); err != nil {
+			return nil, fmt.Errorf("parsing response into json: %v", err)
+		}
+	}
+
+	names := make([]string, 0, len(resources))
+	seen := map[string]bool{}
+	for _, r := range resources {
+		if seen[r.Name] {
+			continue
+		}
+		seen[r.Name] = true
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+
+	return names, nil
 }
 
 func (c *Cmk) ValidateZoneAndGetId(ctx context.Context, profile string, zone v1alpha1.CloudStackZone) (string, error) {
@@ -538,6 +688,50 @@ type cmkAffinityGroup struct {
 	Name string `json:"name"`
 }
 
+type cmkHost struct {
+	Id                         string `json:"id"`
+	Name                       string `json:"name"`
+	State                      string `json:"state"`
+	ResourceState              string `json:"resourcestate"`
+	CpuNumber                  int    `json:"cpunumber"`
+	CpuSpeed                   int    `json:"cpuspeed"`
+	CpuAllocatedValue          int64  `json:"cpuallocatedvalue"`
+	CpuWithOverprovisioning    string `json:"cpuwithoverprovisioning"`
+	MemoryTotal                int64  `json:"memorytotal"`
+	MemoryAllocated            int64  `json:"memoryallocated"`
+	MemoryWithOverprovisioning string `json:"memorywithoverprovisioning"`
+}
+
+// CloudStackHost has the compute capacity of a CloudStack hypervisor host.
+type CloudStackHost struct {
+	Id   string
+	Name string
+	// CPUMHz is the cpu capacity of the host, including overprovisioning, in MHz.
+	CPUMHz          int64
+	CPUAllocatedMHz int64
+	// MemoryBytes is the memory capacity of the host, including overprovisioning, in bytes.
+	MemoryBytes          int64
+	MemoryAllocatedBytes int64
+}
+
+// CloudStackServiceOffering has the compute capacity of a CloudStack service offering.
+type CloudStackServiceOffering struct {
+	Id        string
+	Name      string
+	CPUNumber int
+	// CPUSpeed is the speed of each cpu, in MHz.
+	CPUSpeed  int
+	MemoryMiB int
+}
+
+// CloudStackAffinityGroup is a CloudStack affinity group.
+type CloudStackAffinityGroup struct {
+	Id   string
+	Name string
+	// Type is the affinity group type, such as "host affinity" or "host anti-affinity".
+	Type string
+}
+
 type cmkDomain struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`
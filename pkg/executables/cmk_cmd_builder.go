@@ -46,3 +46,7 @@ func withCloudStackName(name string) cmkCommandArgs {
 func withCloudStackKeyword(keyword string) cmkCommandArgs {
 	return appendArgs(fmt.Sprintf("keyword=\"%s\"", keyword))
 }
+
+func withCloudStackType(t string) cmkCommandArgs {
+	return appendArgs(fmt.Sprintf("type=\"%s\"", t))
+}
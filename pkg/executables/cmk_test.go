@@ -1117,3 +1117,183 @@ func TestCmkGetManagementApiEndpoint(t *testing.T) {
 	_, err = cmk.GetManagementApiEndpoint("xxx")
 	tt.Expect(err).NotTo(BeNil())
 }
+
+func TestCmkListHosts(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, []string{
+		"-c", configFilePath,
+		"list", "hosts", "type=\"Routing\"", fmt.Sprintf("zoneid=\"%s\"", zoneID),
+	}).Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_host_multiple.json")), nil)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	hosts, err := cmk.ListHosts(ctx, execConfig.Profiles[0].Name, zoneID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hosts).To(Equal([]executables.CloudStackHost{
+		{
+			Id:                   "8c3a1f0e-6a4a-4e44-8e8b-3a6f4c1c0a01",
+			Name:                 "host-1",
+			CPUMHz:               64000,
+			CPUAllocatedMHz:      4000,
+			MemoryBytes:          68719476736,
+			MemoryAllocatedBytes: 8589934592,
+		},
+		{
+			Id:          "8c3a1f0e-6a4a-4e44-8e8b-3a6f4c1c0a02",
+			Name:        "host-2",
+			CPUMHz:      16000,
+			MemoryBytes: 34359738368,
+		},
+	}))
+}
+
+func TestCmkListHostsError(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("cmk calling return exception"))
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	_, err := cmk.ListHosts(ctx, execConfig.Profiles[0].Name, zoneID)
+	g.Expect(err).To(MatchError(ContainSubstring("getting hosts info")))
+}
+
+func TestCmkListHostsNotPermitted(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{},
+		errors.New("Error: (HTTP 432, error code 9999) The given command does not exist or it is not available for user"))
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	_, err := cmk.ListHosts(ctx, execConfig.Profiles[0].Name, zoneID)
+	g.Expect(errors.Is(err, executables.ErrCloudStackAPINotPermitted)).To(BeTrue())
+}
+
+func TestCmkGetServiceOffering(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, gomock.Any()).
+		Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_serviceoffering_singular.json")), nil)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	offering, err := cmk.GetServiceOffering(ctx, execConfig.Profiles[0].Name, zoneID, resourceName)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(offering).To(Equal(executables.CloudStackServiceOffering{
+		Id:        "0e86db5a-3053-476a-b4c5-858455f1c2c8",
+		Name:      "Medium Instance",
+		CPUNumber: 1,
+		CPUSpeed:  1000,
+		MemoryMiB: 1024,
+	}))
+}
+
+func TestCmkGetAffinityGroup(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, gomock.Any()).
+		Return(*bytes.NewBufferString(test.ReadFile(t, "testdata/cmk_list_affinitygroup_singular.json")), nil)
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	affinityGroup, err := cmk.GetAffinityGroup(ctx, execConfig.Profiles[0].Name, domainID, accountName, "a0d5b669-5124-4c6d-ad4f-571696f62ffc")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(affinityGroup).To(Equal(executables.CloudStackAffinityGroup{
+		Id:   "a0d5b669-5124-4c6d-ad4f-571696f62ffc",
+		Name: "control-plane-ha",
+		Type: "host anti-affinity",
+	}))
+}
+
+func TestCmkListNames(t *testing.T) {
+	_, writer := test.NewWriter(t)
+	configFilePath, _ := filepath.Abs(filepath.Join(writer.Dir(), "generated", cmkConfigFileName))
+	tests := []struct {
+		testName         string
+		args             []string
+		jsonResponseFile string
+		cmkFunc          func(cmk *executables.Cmk, ctx context.Context) ([]string, error)
+		want             []string
+	}{
+		{
+			testName:         "zones",
+			args:             []string{"list", "zones"},
+			jsonResponseFile: "testdata/cmk_list_zone_singular.json",
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListZones(ctx, execConfig.Profiles[0].Name)
+			},
+			want: []string{"zone1"},
+		},
+		{
+			testName:         "networks",
+			args:             []string{"list", "networks", fmt.Sprintf("zoneid=\"%s\"", zoneID), "listall=true"},
+			jsonResponseFile: "testdata/cmk_list_network_multiple.json",
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListNetworks(ctx, execConfig.Profiles[0].Name, zoneID)
+			},
+			want: []string{"TEST_RESOURCE"},
+		},
+		{
+			testName:         "service offerings",
+			args:             []string{"list", "serviceofferings", fmt.Sprintf("zoneid=\"%s\"", zoneID)},
+			jsonResponseFile: "testdata/cmk_list_serviceoffering_singular.json",
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListServiceOfferings(ctx, execConfig.Profiles[0].Name, zoneID)
+			},
+			want: []string{"Medium Instance"},
+		},
+		{
+			testName:         "templates",
+			args:             []string{"list", "templates", "templatefilter=all", "listall=true", fmt.Sprintf("zoneid=\"%s\"", zoneID)},
+			jsonResponseFile: "testdata/cmk_list_template_multiple.json",
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListTemplates(ctx, execConfig.Profiles[0].Name, zoneID)
+			},
+			want: []string{"CentOS 5.5(64-bit) no GUI (KVM)"},
+		},
+		{
+			testName:         "empty response",
+			args:             []string{"list", "zones"},
+			jsonResponseFile: "testdata/cmk_list_empty_response.json",
+			cmkFunc: func(cmk *executables.Cmk, ctx context.Context) ([]string, error) {
+				return cmk.ListZones(ctx, execConfig.Profiles[0].Name)
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+			executable.EXPECT().Execute(ctx, append([]string{"-c", configFilePath}, tt.args...)).
+				Return(*bytes.NewBufferString(test.ReadFile(t, tt.jsonResponseFile)), nil)
+			cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+			names, err := tt.cmkFunc(cmk, ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(names).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestCmkListNamesError(t *testing.T) {
+	g := NewWithT(t)
+	_, writer := test.NewWriter(t)
+	ctx := context.Background()
+	executable := mockexecutables.NewMockExecutable(gomock.NewController(t))
+	executable.EXPECT().Execute(ctx, gomock.Any()).Return(bytes.Buffer{}, errors.New("cmk calling return exception"))
+	cmk, _ := executables.NewCmk(executable, writer, execConfig)
+
+	_, err := cmk.ListTemplates(ctx, execConfig.Profiles[0].Name, zoneID)
+	g.Expect(err).To(MatchError(ContainSubstring("getting templates info")))
+}
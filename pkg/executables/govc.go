@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -130,6 +131,91 @@ func (g *Govc) Logout(ctx context.Context) error {
 	return nil
 }
 
+// ListDatacenters returns the names of the datacenters. It doesn't require the GOVC_DATACENTER env var.
+func (g *Govc) ListDatacenters(ctx context.Context) ([]string, error) {
+	envMap, err := g.setupCreds(false)
+	if err != nil {
+		return nil, fmt.Errorf("failed govc validations: %v", err)
+	}
+
+	paths, err := g.find(ctx, envMap, "/", "d")
+	if err != nil {
+		return nil, fmt.Errorf("listing datacenters: %v", err)
+	}
+	for i, p := range paths {
+		paths[i] = strings.TrimPrefix(p, "/")
+	}
+
+	return paths, nil
+}
+
+// ListNetworks returns the paths of the networks of a datacenter.
+func (g *Govc) ListNetworks(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listDatacenterObjects(ctx, datacenter, "n", "networks")
+}
+
+// ListDatastores returns the paths of the datastores of a datacenter.
+func (g *Govc) ListDatastores(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listDatacenterObjects(ctx, datacenter, "s", "datastores")
+}
+
+// ListFolders returns the paths of the VM folders of a datacenter.
+func (g *Govc) ListFolders(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listDatacenterObjects(ctx, filepath.Join(datacenter, string(vm)), "f", "folders")
+}
+
+// ListResourcePools returns the paths of the resource pools of a datacenter.
+func (g *Govc) ListResourcePools(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listDatacenterObjects(ctx, datacenter, "p", "resource pools")
+}
+
+// ListTemplates returns the paths of the VM templates of a datacenter.
+func (g *Govc) ListTemplates(ctx context.Context, datacenter string) ([]string, error) {
+	return g.listDatacenterObjects(ctx, datacenter, "m", "templates", "-config.template", "true")
+}
+
+func (g *Govc) listDatacenterObjects(ctx context.Context, root, objectType, description string, filters ...string) ([]string, error) {
+	envMap, err := g.validateAndSetupCreds()
+	if err != nil {
+		return nil, fmt.Errorf("failed govc validations: %v", err)
+	}
+
+	paths, err := g.find(ctx, envMap, "/"+strings.TrimPrefix(root, "/"), objectType, filters...)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %v", description, err)
+	}
+
+	return paths, nil
+}
+
+// find returns the sorted paths of the objects of a type under a root path.
+func (g *Govc) find(ctx context.Context, envMap map[string]string, root, objectType string, filters ...string) ([]string, error) {
+	params := append([]string{"find", "-json", root, "-type", objectType}, filters...)
+
+	var response bytes.Buffer
+	var err error
+	err = g.Retry(func() error {
+		response, err = g.ExecuteWithEnv(ctx, envMap, params...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responseJson := strings.TrimSpace(response.String())
+	if responseJson == "null" || responseJson == "" {
+		return nil, nil
+	}
+
+	paths := make([]string, 0)
+	if err = json.Unmarshal([]byte(responseJson), &paths); err != nil {
+		return nil, fmt.Errorf("parsing govc response: %v", err)
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
 // SearchTemplate looks for a vm template with the same base name as the provided template path.
 // If found, it returns the full qualified path to the template.
 // If multiple matching templates are found, it returns an error.
@@ -545,7 +631,7 @@ func (g *Govc) markVMAsTemplate(ctx context.Context, datacenter, vmName string)
 	return nil
 }
 
-func (g *Govc) getEnvMap() (map[string]string, error) {
+func (g *Govc) getEnvMap(requireDatacenter bool) (map[string]string, error) {
 	if g.envMap != nil {
 		return g.envMap, nil
 	}
@@ -555,6 +641,9 @@ func (g *Govc) getEnvMap() (map[string]string, error) {
 		if env, ok := os.LookupEnv(key); ok && len(env) > 0 {
 			envMap[key] = env
 		} else {
+			if key == govcDatacenterKey && !requireDatacenter {
+				continue
+			}
 			if key != govcInsecure {
 				return nil, fmt.Errorf("warning required env not set %s", key)
 			}
@@ -569,6 +658,12 @@ func (g *Govc) getEnvMap() (map[string]string, error) {
 }
 
 func (g *Govc) validateAndSetupCreds() (map[string]string, error) {
+	return g.setupCreds(true)
+}
+
+// setupCreds validates and sets the govc credentials env vars. The datacenter is not required
+// for the commands that don't use the default datacenter, like listing the datacenters.
+func (g *Govc) setupCreds(requireDatacenter bool) (map[string]string, error) {
 	if g.envMap != nil {
 		return g.envMap, nil
 	}
@@ -597,11 +692,11 @@ func (g *Govc) validateAndSetupCreds() (map[string]string, error) {
 	} else if govcURL, ok := os.LookupEnv(govcURLKey); !ok || len(govcURL) <= 0 {
 		return nil, fmt.Errorf("%s is not set or is empty: %t", govcURLKey, ok)
 	}
-	if govcDatacenter, ok := os.LookupEnv(govcDatacenterKey); !ok || len(govcDatacenter) <= 0 {
+	if govcDatacenter, ok := os.LookupEnv(govcDatacenterKey); requireDatacenter && (!ok || len(govcDatacenter) <= 0) {
 		return nil, fmt.Errorf("%s is not set or is empty: %t", govcDatacenterKey, ok)
 	}
 
-	envMap, err := g.getEnvMap()
+	envMap, err := g.getEnvMap(requireDatacenter)
 	if err != nil {
 		return nil, fmt.Errorf("%v", err)
 	}
@@ -227,6 +227,116 @@ func TestSearchTemplateError(t *testing.T) {
 	}
 }
 
+func TestListDatacenters(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, govc, executable, env := setup(t)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", "/", "-type", "d").Return(*bytes.NewBufferString(`["/SDDC-Datacenter","/folder/Other-Datacenter"]`), nil)
+
+	datacenters, err := govc.ListDatacenters(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(datacenters).To(Equal([]string{"SDDC-Datacenter", "folder/Other-Datacenter"}))
+}
+
+func TestListDatacentersWithoutDatacenterEnv(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, govc, executable, _ := setup(t)
+	t.Setenv(govcDatacenter, "")
+	env := map[string]string{
+		govcUsername: "vsphere_username",
+		govcPassword: "vsphere_password",
+		govcURL:      "vsphere_server",
+		govcInsecure: "false",
+	}
+	executable.EXPECT().ExecuteWithEnv(ctx, env, "find", "-json", "/", "-type", "d").Return(*bytes.NewBufferString("null"), nil)
+
+	datacenters, err := govc.ListDatacenters(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(datacenters).To(BeEmpty())
+}
+
+func TestListDatacenterObjects(t *testing.T) {
+	datacenter := "SDDC-Datacenter"
+	tests := []struct {
+		name     string
+		args     []string
+		list     func(ctx context.Context, g *executables.Govc) ([]string, error)
+		response string
+		want     []string
+	}{
+		{
+			name: "networks",
+			args: []string{"find", "-json", "/SDDC-Datacenter", "-type", "n"},
+			list: func(ctx context.Context, g *executables.Govc) ([]string, error) {
+				return g.ListNetworks(ctx, datacenter)
+			},
+			response: `["/SDDC-Datacenter/network/sddc-cgw-network-2","/SDDC-Datacenter/network/sddc-cgw-network-1"]`,
+			want:     []string{"/SDDC-Datacenter/network/sddc-cgw-network-1", "/SDDC-Datacenter/network/sddc-cgw-network-2"},
+		},
+		{
+			name: "datastores",
+			args: []string{"find", "-json", "/SDDC-Datacenter", "-type", "s"},
+			list: func(ctx context.Context, g *executables.Govc) ([]string, error) {
+				return g.ListDatastores(ctx, datacenter)
+			},
+			response: `["/SDDC-Datacenter/datastore/WorkloadDatastore"]`,
+			want:     []string{"/SDDC-Datacenter/datastore/WorkloadDatastore"},
+		},
+		{
+			name: "folders",
+			args: []string{"find", "-json", "/SDDC-Datacenter/vm", "-type", "f"},
+			list: func(ctx context.Context, g *executables.Govc) ([]string, error) {
+				return g.ListFolders(ctx, datacenter)
+			},
+			response: `["/SDDC-Datacenter/vm/Templates"]`,
+			want:     []string{"/SDDC-Datacenter/vm/Templates"},
+		},
+		{
+			name: "resource pools",
+			args: []string{"find", "-json", "/SDDC-Datacenter", "-type", "p"},
+			list: func(ctx context.Context, g *executables.Govc) ([]string, error) {
+				return g.ListResourcePools(ctx, datacenter)
+			},
+			response: `["/SDDC-Datacenter/host/Cluster-1/Resources"]`,
+			want:     []string{"/SDDC-Datacenter/host/Cluster-1/Resources"},
+		},
+		{
+			name: "templates",
+			args: []string{"find", "-json", "/SDDC-Datacenter", "-type", "m", "-config.template", "true"},
+			list: func(ctx context.Context, g *executables.Govc) ([]string, error) {
+				return g.ListTemplates(ctx, datacenter)
+			},
+			response: `["/SDDC-Datacenter/vm/Templates/bottlerocket-kube-v1-28"]`,
+			want:     []string{"/SDDC-Datacenter/vm/Templates/bottlerocket-kube-v1-28"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			_, govc, executable, env := setup(t)
+			executable.EXPECT().ExecuteWithEnv(ctx, env, tt.args).Return(*bytes.NewBufferString(tt.response), nil)
+
+			got, err := tt.list(ctx, govc)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestListDatacenterObjectsError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	_, govc, executable, env := setup(t)
+	govc.Retrier = retrier.NewWithMaxRetries(1, 0)
+	executable.EXPECT().ExecuteWithEnv(ctx, env, gomock.Any()).Return(bytes.Buffer{}, errors.New("error from execute with env"))
+
+	_, err := govc.ListNetworks(ctx, "SDDC-Datacenter")
+	g.Expect(err).To(MatchError("listing networks: error from execute with env"))
+}
+
 func TestLibraryElementExistsItExists(t *testing.T) {
 	ctx := context.Background()
 
@@ -2,6 +2,9 @@ package executables_test
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"testing"
 
 	"github.com/golang/mock/gomock"
 
@@ -56,3 +59,18 @@ func sliceEqual(a, b []string) bool {
 	}
 	return len(m) == 0
 }
+
+// chdirTemp runs the test from a temp folder so files the executables write relative to the
+// working directory, like <cluster>/generated, don't end up in the package. testdata stays reachable.
+func chdirTemp(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getting working directory: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.Symlink(filepath.Join(wd, "testdata"), filepath.Join(dir, "testdata")); err != nil {
+		t.Fatalf("linking testdata: %v", err)
+	}
+	t.Chdir(dir)
+}
@@ -27,6 +27,7 @@ import (
 type testKindOption func(k *executables.Kind) bootstrapper.BootstrapClusterClientOption
 
 func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
+	chdirTemp(t)
 	_, writer := test.NewWriter(t)
 
 	clusterName := "test_cluster"
@@ -150,6 +151,7 @@ func TestKindCreateBootstrapClusterSuccess(t *testing.T) {
 }
 
 func TestKindCreateBootstrapClusterSuccessWithRegistryMirror(t *testing.T) {
+	chdirTemp(t)
 	_, writer := test.NewWriter(t)
 
 	clusterName := "test_cluster"
@@ -326,6 +328,7 @@ func TestKindCreateBootstrapClusterSuccessWithRegistryMirror(t *testing.T) {
 }
 
 func TestKindCreateBootstrapClusterExecutableError(t *testing.T) {
+	chdirTemp(t)
 	clusterSpec := test.NewClusterSpec(func(s *cluster.Spec) {
 		s.Cluster.Name = "clusterName"
 		s.VersionsBundles["1.19"] = versionBundle
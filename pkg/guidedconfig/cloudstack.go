@@ -0,0 +1,159 @@
+package guidedconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+)
+
+// CloudStackClient discovers the CloudStack resources.
+type CloudStackClient interface {
+	GetManagementApiEndpoint(profile string) (string, error)
+	ListZones(ctx context.Context, profile string) ([]string, error)
+	ValidateZoneAndGetId(ctx context.Context, profile string, zone v1alpha1.CloudStackZone) (string, error)
+	ListNetworks(ctx context.Context, profile string, zoneId string) ([]string, error)
+	ListServiceOfferings(ctx context.Context, profile string, zoneId string) ([]string, error)
+	ListTemplates(ctx context.Context, profile string, zoneId string) ([]string, error)
+}
+
+// CloudStackValidator validates the CloudStack datacenter and machine configs the way create does.
+type CloudStackValidator interface {
+	ValidateCloudStackDatacenterConfig(ctx context.Context, datacenterConfig *v1alpha1.CloudStackDatacenterConfig) error
+	ValidateClusterMachineConfigs(ctx context.Context, clusterSpec *cluster.Spec) error
+}
+
+// ConfigureCloudStack asks for the credentials profile of the availability zone and offers the zones,
+// networks, service offerings and templates found with it. The machine configs all get the same
+// offering and template.
+func ConfigureCloudStack(ctx context.Context, p *Prompter, cmk CloudStackClient, validator CloudStackValidator, profiles []string, c *v1alpha1.ClusterGenerate, datacenterConfig *v1alpha1.CloudStackDatacenterConfigGenerate, machineConfigs ...*v1alpha1.CloudStackMachineConfigGenerate) error {
+	if len(datacenterConfig.Spec.AvailabilityZones) == 0 {
+		return fmt.Errorf("CloudStackDatacenterConfig %s has no availability zone", datacenterConfig.Name())
+	}
+	az := &datacenterConfig.Spec.AvailabilityZones[0]
+
+	var zoneId string
+	for {
+		var err error
+		if zoneId, err = configureCloudStackZone(ctx, p, cmk, profiles, az); err != nil {
+			return err
+		}
+
+		dc := &v1alpha1.CloudStackDatacenterConfig{}
+		if err := convert(datacenterConfig, dc); err != nil {
+			return err
+		}
+		if err := validator.ValidateCloudStackDatacenterConfig(ctx, dc); err != nil {
+			p.Warn(err)
+			continue
+		}
+		break
+	}
+
+	for {
+		if err := configureCloudStackMachines(ctx, p, cmk, az.CredentialsRef, zoneId, machineConfigs...); err != nil {
+			return err
+		}
+
+		objects := []interface{}{datacenterConfig}
+		for _, m := range machineConfigs {
+			objects = append(objects, m)
+		}
+		config, err := parseConfig(c, objects...)
+		if err != nil {
+			return err
+		}
+		if err := validator.ValidateClusterMachineConfigs(ctx, &cluster.Spec{Config: config}); err != nil {
+			p.Warn(err)
+			continue
+		}
+		break
+	}
+
+	key, err := AskSSHKey(p)
+	if err != nil {
+		return err
+	}
+	for _, m := range machineConfigs {
+		setSSHKey(m.Spec.Users, key)
+	}
+
+	return nil
+}
+
+func configureCloudStackZone(ctx context.Context, p *Prompter, cmk CloudStackClient, profiles []string, az *v1alpha1.CloudStackAvailabilityZone) (zoneId string, err error) {
+	profile, err := p.Choose("Credentials profile", profiles, nil)
+	if err != nil {
+		return "", err
+	}
+	az.CredentialsRef = profile
+
+	endpoint, err := cmk.GetManagementApiEndpoint(profile)
+	if err != nil {
+		return "", err
+	}
+	az.ManagementApiEndpoint = endpoint
+
+	if az.Domain, err = p.Ask("Domain", az.Domain, required); err != nil {
+		return "", err
+	}
+	if az.Account, err = p.Ask("Account", az.Account, nil); err != nil {
+		return "", err
+	}
+
+	zones, err := cmk.ListZones(ctx, profile)
+	if err != nil {
+		return "", err
+	}
+	zone, err := p.Choose("Zone", zones, nil)
+	if err != nil {
+		return "", err
+	}
+	az.Zone.Name = zone
+	az.Zone.Id = ""
+
+	zoneId, err = cmk.ValidateZoneAndGetId(ctx, profile, az.Zone)
+	if err != nil {
+		return "", err
+	}
+
+	networks, err := cmk.ListNetworks(ctx, profile, zoneId)
+	if err != nil {
+		return "", err
+	}
+	network, err := p.Choose("Network", networks, nil)
+	if err != nil {
+		return "", err
+	}
+	az.Zone.Network = v1alpha1.CloudStackResourceIdentifier{Name: network}
+
+	return zoneId, nil
+}
+
+func configureCloudStackMachines(ctx context.Context, p *Prompter, cmk CloudStackClient, profile, zoneId string, machineConfigs ...*v1alpha1.CloudStackMachineConfigGenerate) error {
+	offerings, err := cmk.ListServiceOfferings(ctx, profile, zoneId)
+	if err != nil {
+		return err
+	}
+	offering, err := p.Choose("Compute offering", offerings, nil)
+	if err != nil {
+		return err
+	}
+
+	templates, err := cmk.ListTemplates(ctx, profile, zoneId)
+	if err != nil {
+		return err
+	}
+	template, err := p.Choose("Template", templates, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range machineConfigs {
+		m.Spec.ComputeOffering = v1alpha1.CloudStackResourceIdentifier{Name: offering}
+		m.Spec.Template = v1alpha1.CloudStackResourceIdentifier{Name: template}
+	}
+
+	return nil
+}
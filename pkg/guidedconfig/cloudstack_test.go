@@ -0,0 +1,100 @@
+package guidedconfig_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig/mocks"
+)
+
+func TestConfigureCloudStack(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	cmk := mocks.NewMockCloudStackClient(ctrl)
+	validator := mocks.NewMockCloudStackValidator(ctrl)
+	dc := v1alpha1.NewCloudStackDatacenterConfigGenerate("test")
+	cp := v1alpha1.NewCloudStackMachineConfigGenerate("test-cp")
+	worker := v1alpha1.NewCloudStackMachineConfigGenerate("test")
+	c := clusterGenerate(dc, cp, worker)
+	c.Spec.ControlPlaneConfiguration.Endpoint.Host = "10.0.0.1"
+
+	p, out := newPrompter(
+		"",     // profile
+		"root", // domain
+		"",     // account
+		"2",    // zone
+		"",     // network
+		"2",    // compute offering
+		"1",    // template
+		"1",    // compute offering, after the validation error
+		"2",    // template
+		sshKey,
+	)
+
+	cmk.EXPECT().GetManagementApiEndpoint("global").Return("http://cloudstack:8080/client/api", nil)
+	cmk.EXPECT().ListZones(ctx, "global").Return([]string{"zone1", "zone2"}, nil)
+	cmk.EXPECT().ValidateZoneAndGetId(ctx, "global", v1alpha1.CloudStackZone{Name: "zone2"}).Return("zone2-id", nil)
+	cmk.EXPECT().ListNetworks(ctx, "global", "zone2-id").Return([]string{"net1"}, nil)
+	validator.EXPECT().ValidateCloudStackDatacenterConfig(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, dc *v1alpha1.CloudStackDatacenterConfig) error {
+			g.Expect(dc.Spec.AvailabilityZones).To(HaveLen(1))
+			return nil
+		},
+	)
+	cmk.EXPECT().ListServiceOfferings(ctx, "global", "zone2-id").Return([]string{"large", "medium"}, nil).Times(2)
+	cmk.EXPECT().ListTemplates(ctx, "global", "zone2-id").Return([]string{"rhel8-kube-1.33", "rhel8-kube-1.34"}, nil).Times(2)
+	gomock.InOrder(
+		validator.EXPECT().ValidateClusterMachineConfigs(ctx, gomock.Any()).Return(errors.New("missing kube version from the machine config template name")),
+		validator.EXPECT().ValidateClusterMachineConfigs(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, spec *cluster.Spec) error {
+				g.Expect(spec.CloudStackMachineConfigs).To(HaveKey("test-cp"))
+				g.Expect(spec.CloudStackMachineConfigs["test-cp"].Spec.Template.Name).To(Equal("rhel8-kube-1.34"))
+				return nil
+			},
+		),
+	)
+
+	g.Expect(guidedconfig.ConfigureCloudStack(ctx, p, cmk, validator, []string{"global"}, c, dc, cp, worker)).To(Succeed())
+	g.Expect(dc.Spec.AvailabilityZones).To(Equal([]v1alpha1.CloudStackAvailabilityZone{
+		{
+			Name:           "az-1",
+			CredentialsRef: "global",
+			Zone: v1alpha1.CloudStackZone{
+				Name:    "zone2",
+				Network: v1alpha1.CloudStackResourceIdentifier{Name: "net1"},
+			},
+			Domain:                "root",
+			Account:               "admin",
+			ManagementApiEndpoint: "http://cloudstack:8080/client/api",
+		},
+	}))
+	for _, m := range []*v1alpha1.CloudStackMachineConfigGenerate{cp, worker} {
+		g.Expect(m.Spec.ComputeOffering).To(Equal(v1alpha1.CloudStackResourceIdentifier{Name: "large"}))
+		g.Expect(m.Spec.Template).To(Equal(v1alpha1.CloudStackResourceIdentifier{Name: "rhel8-kube-1.34"}))
+		g.Expect(m.Spec.Users[0].SshAuthorizedKeys).To(Equal([]string{sshKey}))
+	}
+	g.Expect(out.String()).To(ContainSubstring("❌ missing kube version from the machine config template name"))
+}
+
+func TestConfigureCloudStackListZonesError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	cmk := mocks.NewMockCloudStackClient(ctrl)
+	dc := v1alpha1.NewCloudStackDatacenterConfigGenerate("test")
+	p, _ := newPrompter("", "", "")
+
+	cmk.EXPECT().GetManagementApiEndpoint("global").Return("http://cloudstack:8080/client/api", nil)
+	cmk.EXPECT().ListZones(ctx, "global").Return(nil, errors.New("getting zones info"))
+
+	err := guidedconfig.ConfigureCloudStack(ctx, p, cmk, mocks.NewMockCloudStackValidator(ctrl), []string{"global"}, clusterGenerate(dc), dc)
+	g.Expect(err).To(MatchError("getting zones info"))
+}
@@ -0,0 +1,157 @@
+package guidedconfig
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/crypto/ssh"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+// ConfigureCluster asks for the control plane endpoint and the node counts of the cluster.
+func ConfigureCluster(p *Prompter, c *v1alpha1.ClusterGenerate) error {
+	if c.Spec.ControlPlaneConfiguration.Endpoint != nil {
+		host, err := p.Ask("Control plane endpoint IP", c.Spec.ControlPlaneConfiguration.Endpoint.Host, validateIP)
+		if err != nil {
+			return err
+		}
+		c.Spec.ControlPlaneConfiguration.Endpoint.Host = host
+	}
+
+	externalEtcd := c.Spec.ExternalEtcdConfiguration != nil
+	count, err := p.AskInt("Control plane node count", c.Spec.ControlPlaneConfiguration.Count, func(count int) error {
+		if count <= 0 {
+			return errors.New("control plane node count must be positive")
+		}
+		if !externalEtcd && count%2 == 0 {
+			return errors.New("control plane node count cannot be an even number when using stacked etcd topology")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	c.Spec.ControlPlaneConfiguration.Count = count
+
+	if externalEtcd {
+		count, err := p.AskInt("External etcd node count", c.Spec.ExternalEtcdConfiguration.Count, func(count int) error {
+			if count <= 0 || count%2 == 0 {
+				return errors.New("external etcd count must be a positive odd number")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		c.Spec.ExternalEtcdConfiguration.Count = count
+	}
+
+	for i := range c.Spec.WorkerNodeGroupConfigurations {
+		w := &c.Spec.WorkerNodeGroupConfigurations[i]
+		defaultCount := 1
+		if w.Count != nil {
+			defaultCount = *w.Count
+		}
+		count, err := p.AskInt(fmt.Sprintf("Worker node count for %s", w.Name), defaultCount, func(count int) error {
+			if count < 0 {
+				return errors.New("worker node count must be >= 0")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		w.Count = &count
+	}
+
+	return nil
+}
+
+// AskSSHKey asks for the SSH public key of the machines. An empty key is returned
+// when none is entered, so create generates one.
+func AskSSHKey(p *Prompter) (string, error) {
+	return p.Ask("SSH public key for the machines (leave empty to generate one on create)", "", func(key string) error {
+		if key == "" {
+			return nil
+		}
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key)); err != nil {
+			return fmt.Errorf("invalid SSH public key: %v", err)
+		}
+		return nil
+	})
+}
+
+// setSSHKey sets the SSH key of the first user, keeping the user name generated for the provider.
+func setSSHKey(users []v1alpha1.UserConfiguration, key string) {
+	if len(users) > 0 {
+		users[0].SshAuthorizedKeys = []string{key}
+	}
+}
+
+// ValidateConfig parses and defaults the generated objects and runs the static validations
+// create runs on a cluster config.
+func ValidateConfig(c *v1alpha1.ClusterGenerate, objects ...interface{}) (*cluster.Config, error) {
+	config, err := parseConfig(c, objects...)
+	if err != nil {
+		return nil, err
+	}
+	if err := cluster.ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("validating cluster config: %v", err)
+	}
+
+	return config, nil
+}
+
+// parseConfig returns the cluster config of the generated objects, the way create reads it from a file.
+func parseConfig(c *v1alpha1.ClusterGenerate, objects ...interface{}) (*cluster.Config, error) {
+	resources := make([][]byte, 0, len(objects)+1)
+	for _, o := range append([]interface{}{c}, objects...) {
+		r, err := yaml.Marshal(o)
+		if err != nil {
+			return nil, fmt.Errorf("generating cluster yaml: %v", err)
+		}
+		resources = append(resources, r)
+	}
+
+	config, err := cluster.ParseConfig(templater.AppendYamlResources(resources...))
+	if err != nil {
+		return nil, fmt.Errorf("parsing cluster config: %v", err)
+	}
+	if err := cluster.SetConfigDefaults(config); err != nil {
+		return nil, fmt.Errorf("setting defaults on cluster config: %v", err)
+	}
+
+	return config, nil
+}
+
+// convert converts a generated object to its API type.
+func convert(in, out interface{}) error {
+	b, err := yaml.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("converting generated object: %v", err)
+	}
+	if err := yaml.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("converting generated object: %v", err)
+	}
+
+	return nil
+}
+
+func validateIP(ip string) error {
+	if net.ParseIP(ip) == nil {
+		return fmt.Errorf("%q is not a valid IP", ip)
+	}
+	return nil
+}
+
+func required(value string) error {
+	if value == "" {
+		return errors.New("a value is required")
+	}
+	return nil
+}
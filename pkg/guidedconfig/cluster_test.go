@@ -0,0 +1,123 @@
+package guidedconfig_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig"
+)
+
+const sshKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOXQuoU5yhmVEAIAHesGsZtDnTGUdHC/KqU7176aHCXO"
+
+func clusterGenerate(datacenterConfig v1alpha1.ProviderRefAccessor, machineConfigs ...v1alpha1.ProviderRefAccessor) *v1alpha1.ClusterGenerate {
+	opts := []v1alpha1.ClusterGenerateOpt{
+		v1alpha1.WithClusterEndpoint(),
+		v1alpha1.WithDatacenterRef(datacenterConfig),
+		v1alpha1.ControlPlaneConfigCount(2),
+		v1alpha1.WorkerNodeConfigCount(2),
+		v1alpha1.WorkerNodeConfigName("md-0"),
+	}
+	if len(machineConfigs) > 0 {
+		opts = append(opts, v1alpha1.WithCPMachineGroupRef(machineConfigs[0]), v1alpha1.WithWorkerMachineGroupRef(machineConfigs[1]))
+	}
+	if len(machineConfigs) > 2 {
+		opts = append(opts, v1alpha1.ExternalETCDConfigCount(3), v1alpha1.WithEtcdMachineGroupRef(machineConfigs[2]))
+	}
+
+	return v1alpha1.NewClusterGenerate("test", opts...)
+}
+
+func TestConfigureCluster(t *testing.T) {
+	g := NewWithT(t)
+	dc := v1alpha1.NewVSphereDatacenterConfigGenerate("test")
+	c := clusterGenerate(dc,
+		v1alpha1.NewVSphereMachineConfigGenerate("test-cp"),
+		v1alpha1.NewVSphereMachineConfigGenerate("test"),
+		v1alpha1.NewVSphereMachineConfigGenerate("test-etcd"),
+	)
+	p, out := newPrompter("not-an-ip", "10.0.0.1", "0", "2", "2", "", "3")
+
+	g.Expect(guidedconfig.ConfigureCluster(p, c)).To(Succeed())
+	g.Expect(c.Spec.ControlPlaneConfiguration.Endpoint.Host).To(Equal("10.0.0.1"))
+	g.Expect(c.Spec.ControlPlaneConfiguration.Count).To(Equal(2))
+	g.Expect(c.Spec.ExternalEtcdConfiguration.Count).To(Equal(3))
+	g.Expect(*c.Spec.WorkerNodeGroupConfigurations[0].Count).To(Equal(3))
+	g.Expect(out.String()).To(ContainSubstring(`❌ "not-an-ip" is not a valid IP`))
+	g.Expect(out.String()).To(ContainSubstring("❌ control plane node count must be positive"))
+	g.Expect(out.String()).To(ContainSubstring("❌ external etcd count must be a positive odd number"))
+}
+
+func TestConfigureClusterStackedEtcd(t *testing.T) {
+	g := NewWithT(t)
+	dc := v1alpha1.NewTinkerbellDatacenterConfigGenerate("test")
+	c := clusterGenerate(dc,
+		v1alpha1.NewTinkerbellMachineConfigGenerate("test-cp"),
+		v1alpha1.NewTinkerbellMachineConfigGenerate("test"),
+	)
+	p, out := newPrompter("10.0.0.1", "", "3", "-1", "0")
+
+	g.Expect(guidedconfig.ConfigureCluster(p, c)).To(Succeed())
+	g.Expect(c.Spec.ControlPlaneConfiguration.Count).To(Equal(3))
+	g.Expect(c.Spec.ExternalEtcdConfiguration).To(BeNil())
+	g.Expect(*c.Spec.WorkerNodeGroupConfigurations[0].Count).To(Equal(0))
+	g.Expect(out.String()).To(ContainSubstring("❌ control plane node count cannot be an even number when using stacked etcd topology"))
+	g.Expect(out.String()).To(ContainSubstring("❌ worker node count must be >= 0"))
+}
+
+func TestAskSSHKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []string
+		want    string
+	}{
+		{
+			name:    "valid key",
+			answers: []string{sshKey},
+			want:    sshKey,
+		},
+		{
+			name:    "invalid then empty key",
+			answers: []string{"ssh-rsa AAAA...", ""},
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p, _ := newPrompter(tt.answers...)
+
+			got, err := guidedconfig.AskSSHKey(p)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	g := NewWithT(t)
+	dc := v1alpha1.NewDockerDatacenterConfigGenerate("test")
+	c := v1alpha1.NewClusterGenerate("test",
+		v1alpha1.WithDatacenterRef(dc),
+		v1alpha1.ControlPlaneConfigCount(1),
+		v1alpha1.WorkerNodeConfigCount(1),
+		v1alpha1.WorkerNodeConfigName("md-0"),
+	)
+
+	config, err := guidedconfig.ValidateConfig(c, dc)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Cluster.Name).To(Equal("test"))
+	g.Expect(config.DockerDatacenter.Name).To(Equal("test"))
+}
+
+func TestValidateConfigError(t *testing.T) {
+	g := NewWithT(t)
+	dc := v1alpha1.NewVSphereDatacenterConfigGenerate("test")
+	cp := v1alpha1.NewVSphereMachineConfigGenerate("test-cp")
+	worker := v1alpha1.NewVSphereMachineConfigGenerate("test")
+	c := clusterGenerate(dc, cp, worker)
+
+	_, err := guidedconfig.ValidateConfig(c, dc, cp, worker)
+	g.Expect(err).To(MatchError(ContainSubstring("validating cluster config")))
+}
@@ -0,0 +1,438 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/eks-anywhere/pkg/guidedconfig (interfaces: VSphereClient,VSphereValidator,CloudStackClient,CloudStackValidator,NutanixValidator)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	nutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVSphereClient is a mock of VSphereClient interface.
+type MockVSphereClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockVSphereClientMockRecorder
+}
+
+// MockVSphereClientMockRecorder is the mock recorder for MockVSphereClient.
+type MockVSphereClientMockRecorder struct {
+	mock *MockVSphereClient
+}
+
+// NewMockVSphereClient creates a new mock instance.
+func NewMockVSphereClient(ctrl *gomock.Controller) *MockVSphereClient {
+	mock := &MockVSphereClient{ctrl: ctrl}
+	mock.recorder = &MockVSphereClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVSphereClient) EXPECT() *MockVSphereClientMockRecorder {
+	return m.recorder
+}
+
+// GetCertThumbprint mocks base method.
+func (m *MockVSphereClient) GetCertThumbprint(arg0 context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertThumbprint", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCertThumbprint indicates an expected call of GetCertThumbprint.
+func (mr *MockVSphereClientMockRecorder) GetCertThumbprint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertThumbprint", reflect.TypeOf((*MockVSphereClient)(nil).GetCertThumbprint), arg0)
+}
+
+// IsCertSelfSigned mocks base method.
+func (m *MockVSphereClient) IsCertSelfSigned(arg0 context.Context) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCertSelfSigned", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsCertSelfSigned indicates an expected call of IsCertSelfSigned.
+func (mr *MockVSphereClientMockRecorder) IsCertSelfSigned(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCertSelfSigned", reflect.TypeOf((*MockVSphereClient)(nil).IsCertSelfSigned), arg0)
+}
+
+// ListDatacenters mocks base method.
+func (m *MockVSphereClient) ListDatacenters(arg0 context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatacenters", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDatacenters indicates an expected call of ListDatacenters.
+func (mr *MockVSphereClientMockRecorder) ListDatacenters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatacenters", reflect.TypeOf((*MockVSphereClient)(nil).ListDatacenters), arg0)
+}
+
+// ListDatastores mocks base method.
+func (m *MockVSphereClient) ListDatastores(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDatastores", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDatastores indicates an expected call of ListDatastores.
+func (mr *MockVSphereClientMockRecorder) ListDatastores(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDatastores", reflect.TypeOf((*MockVSphereClient)(nil).ListDatastores), arg0, arg1)
+}
+
+// ListFolders mocks base method.
+func (m *MockVSphereClient) ListFolders(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFolders", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFolders indicates an expected call of ListFolders.
+func (mr *MockVSphereClientMockRecorder) ListFolders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockVSphereClient)(nil).ListFolders), arg0, arg1)
+}
+
+// ListNetworks mocks base method.
+func (m *MockVSphereClient) ListNetworks(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworks", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworks indicates an expected call of ListNetworks.
+func (mr *MockVSphereClientMockRecorder) ListNetworks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworks", reflect.TypeOf((*MockVSphereClient)(nil).ListNetworks), arg0, arg1)
+}
+
+// ListResourcePools mocks base method.
+func (m *MockVSphereClient) ListResourcePools(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListResourcePools", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListResourcePools indicates an expected call of ListResourcePools.
+func (mr *MockVSphereClientMockRecorder) ListResourcePools(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListResourcePools", reflect.TypeOf((*MockVSphereClient)(nil).ListResourcePools), arg0, arg1)
+}
+
+// ListTemplates mocks base method.
+func (m *MockVSphereClient) ListTemplates(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockVSphereClientMockRecorder) ListTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockVSphereClient)(nil).ListTemplates), arg0, arg1)
+}
+
+// SearchTemplate mocks base method.
+func (m *MockVSphereClient) SearchTemplate(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTemplate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTemplate indicates an expected call of SearchTemplate.
+func (mr *MockVSphereClientMockRecorder) SearchTemplate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTemplate", reflect.TypeOf((*MockVSphereClient)(nil).SearchTemplate), arg0, arg1, arg2)
+}
+
+// ValidateVCenterSetupMachineConfig mocks base method.
+func (m *MockVSphereClient) ValidateVCenterSetupMachineConfig(arg0 context.Context, arg1 *v1alpha1.VSphereDatacenterConfig, arg2 *v1alpha1.VSphereMachineConfig, arg3 *bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateVCenterSetupMachineConfig", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateVCenterSetupMachineConfig indicates an expected call of ValidateVCenterSetupMachineConfig.
+func (mr *MockVSphereClientMockRecorder) ValidateVCenterSetupMachineConfig(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVCenterSetupMachineConfig", reflect.TypeOf((*MockVSphereClient)(nil).ValidateVCenterSetupMachineConfig), arg0, arg1, arg2, arg3)
+}
+
+// MockVSphereValidator is a mock of VSphereValidator interface.
+type MockVSphereValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockVSphereValidatorMockRecorder
+}
+
+// MockVSphereValidatorMockRecorder is the mock recorder for MockVSphereValidator.
+type MockVSphereValidatorMockRecorder struct {
+	mock *MockVSphereValidator
+}
+
+// NewMockVSphereValidator creates a new mock instance.
+func NewMockVSphereValidator(ctrl *gomock.Controller) *MockVSphereValidator {
+	mock := &MockVSphereValidator{ctrl: ctrl}
+	mock.recorder = &MockVSphereValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVSphereValidator) EXPECT() *MockVSphereValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateVCenterConfig mocks base method.
+func (m *MockVSphereValidator) ValidateVCenterConfig(arg0 context.Context, arg1 *v1alpha1.VSphereDatacenterConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateVCenterConfig", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateVCenterConfig indicates an expected call of ValidateVCenterConfig.
+func (mr *MockVSphereValidatorMockRecorder) ValidateVCenterConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateVCenterConfig", reflect.TypeOf((*MockVSphereValidator)(nil).ValidateVCenterConfig), arg0, arg1)
+}
+
+// MockCloudStackClient is a mock of CloudStackClient interface.
+type MockCloudStackClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloudStackClientMockRecorder
+}
+
+// MockCloudStackClientMockRecorder is the mock recorder for MockCloudStackClient.
+type MockCloudStackClientMockRecorder struct {
+	mock *MockCloudStackClient
+}
+
+// NewMockCloudStackClient creates a new mock instance.
+func NewMockCloudStackClient(ctrl *gomock.Controller) *MockCloudStackClient {
+	mock := &MockCloudStackClient{ctrl: ctrl}
+	mock.recorder = &MockCloudStackClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloudStackClient) EXPECT() *MockCloudStackClientMockRecorder {
+	return m.recorder
+}
+
+// GetManagementApiEndpoint mocks base method.
+func (m *MockCloudStackClient) GetManagementApiEndpoint(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManagementApiEndpoint", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManagementApiEndpoint indicates an expected call of GetManagementApiEndpoint.
+func (mr *MockCloudStackClientMockRecorder) GetManagementApiEndpoint(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagementApiEndpoint", reflect.TypeOf((*MockCloudStackClient)(nil).GetManagementApiEndpoint), arg0)
+}
+
+// ListNetworks mocks base method.
+func (m *MockCloudStackClient) ListNetworks(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNetworks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNetworks indicates an expected call of ListNetworks.
+func (mr *MockCloudStackClientMockRecorder) ListNetworks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNetworks", reflect.TypeOf((*MockCloudStackClient)(nil).ListNetworks), arg0, arg1, arg2)
+}
+
+// ListServiceOfferings mocks base method.
+func (m *MockCloudStackClient) ListServiceOfferings(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceOfferings", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServiceOfferings indicates an expected call of ListServiceOfferings.
+func (mr *MockCloudStackClientMockRecorder) ListServiceOfferings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceOfferings", reflect.TypeOf((*MockCloudStackClient)(nil).ListServiceOfferings), arg0, arg1, arg2)
+}
+
+// ListTemplates mocks base method.
+func (m *MockCloudStackClient) ListTemplates(arg0 context.Context, arg1, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockCloudStackClientMockRecorder) ListTemplates(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockCloudStackClient)(nil).ListTemplates), arg0, arg1, arg2)
+}
+
+// ListZones mocks base method.
+func (m *MockCloudStackClient) ListZones(arg0 context.Context, arg1 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZones", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZones indicates an expected call of ListZones.
+func (mr *MockCloudStackClientMockRecorder) ListZones(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZones", reflect.TypeOf((*MockCloudStackClient)(nil).ListZones), arg0, arg1)
+}
+
+// ValidateZoneAndGetId mocks base method.
+func (m *MockCloudStackClient) ValidateZoneAndGetId(arg0 context.Context, arg1 string, arg2 v1alpha1.CloudStackZone) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateZoneAndGetId", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateZoneAndGetId indicates an expected call of ValidateZoneAndGetId.
+func (mr *MockCloudStackClientMockRecorder) ValidateZoneAndGetId(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateZoneAndGetId", reflect.TypeOf((*MockCloudStackClient)(nil).ValidateZoneAndGetId), arg0, arg1, arg2)
+}
+
+// MockCloudStackValidator is a mock of CloudStackValidator interface.
+type MockCloudStackValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCloudStackValidatorMockRecorder
+}
+
+// MockCloudStackValidatorMockRecorder is the mock recorder for MockCloudStackValidator.
+type MockCloudStackValidatorMockRecorder struct {
+	mock *MockCloudStackValidator
+}
+
+// NewMockCloudStackValidator creates a new mock instance.
+func NewMockCloudStackValidator(ctrl *gomock.Controller) *MockCloudStackValidator {
+	mock := &MockCloudStackValidator{ctrl: ctrl}
+	mock.recorder = &MockCloudStackValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCloudStackValidator) EXPECT() *MockCloudStackValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateCloudStackDatacenterConfig mocks base method.
+func (m *MockCloudStackValidator) ValidateCloudStackDatacenterConfig(arg0 context.Context, arg1 *v1alpha1.CloudStackDatacenterConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCloudStackDatacenterConfig", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateCloudStackDatacenterConfig indicates an expected call of ValidateCloudStackDatacenterConfig.
+func (mr *MockCloudStackValidatorMockRecorder) ValidateCloudStackDatacenterConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCloudStackDatacenterConfig", reflect.TypeOf((*MockCloudStackValidator)(nil).ValidateCloudStackDatacenterConfig), arg0, arg1)
+}
+
+// ValidateClusterMachineConfigs mocks base method.
+func (m *MockCloudStackValidator) ValidateClusterMachineConfigs(arg0 context.Context, arg1 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateClusterMachineConfigs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateClusterMachineConfigs indicates an expected call of ValidateClusterMachineConfigs.
+func (mr *MockCloudStackValidatorMockRecorder) ValidateClusterMachineConfigs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateClusterMachineConfigs", reflect.TypeOf((*MockCloudStackValidator)(nil).ValidateClusterMachineConfigs), arg0, arg1)
+}
+
+// MockNutanixValidator is a mock of NutanixValidator interface.
+type MockNutanixValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockNutanixValidatorMockRecorder
+}
+
+// MockNutanixValidatorMockRecorder is the mock recorder for MockNutanixValidator.
+type MockNutanixValidatorMockRecorder struct {
+	mock *MockNutanixValidator
+}
+
+// NewMockNutanixValidator creates a new mock instance.
+func NewMockNutanixValidator(ctrl *gomock.Controller) *MockNutanixValidator {
+	mock := &MockNutanixValidator{ctrl: ctrl}
+	mock.recorder = &MockNutanixValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNutanixValidator) EXPECT() *MockNutanixValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateDatacenterConfig mocks base method.
+func (m *MockNutanixValidator) ValidateDatacenterConfig(arg0 context.Context, arg1 nutanix.Client, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateDatacenterConfig", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateDatacenterConfig indicates an expected call of ValidateDatacenterConfig.
+func (mr *MockNutanixValidatorMockRecorder) ValidateDatacenterConfig(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateDatacenterConfig", reflect.TypeOf((*MockNutanixValidator)(nil).ValidateDatacenterConfig), arg0, arg1, arg2)
+}
+
+// ValidateMachineConfig mocks base method.
+func (m *MockNutanixValidator) ValidateMachineConfig(arg0 context.Context, arg1 nutanix.Client, arg2 *v1alpha1.Cluster, arg3 *v1alpha1.NutanixMachineConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateMachineConfig", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateMachineConfig indicates an expected call of ValidateMachineConfig.
+func (mr *MockNutanixValidatorMockRecorder) ValidateMachineConfig(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateMachineConfig", reflect.TypeOf((*MockNutanixValidator)(nil).ValidateMachineConfig), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,239 @@
+package guidedconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+)
+
+// NutanixClientBuilder returns a Prism Central client for a datacenter config.
+type NutanixClientBuilder func(datacenterConfig *v1alpha1.NutanixDatacenterConfig) (nutanix.Client, error)
+
+// NutanixValidator validates the Nutanix datacenter and machine configs the way create does.
+type NutanixValidator interface {
+	ValidateDatacenterConfig(ctx context.Context, client nutanix.Client, spec *cluster.Spec) error
+	ValidateMachineConfig(ctx context.Context, client nutanix.Client, cluster *v1alpha1.Cluster, config *v1alpha1.NutanixMachineConfig) error
+}
+
+// ConfigureNutanix asks for the Prism Central endpoint and offers the Prism Element clusters,
+// subnets and images found in it. The machine configs all get the same resources.
+func ConfigureNutanix(ctx context.Context, p *Prompter, newClient NutanixClientBuilder, validator NutanixValidator, c *v1alpha1.ClusterGenerate, datacenterConfig *v1alpha1.NutanixDatacenterConfigGenerate, machineConfigs ...*v1alpha1.NutanixMachineConfigGenerate) error {
+	objects := []interface{}{datacenterConfig}
+	for _, m := range machineConfigs {
+		objects = append(objects, m)
+	}
+
+	var client nutanix.Client
+	for {
+		if err := configurePrismCentral(p, datacenterConfig); err != nil {
+			return err
+		}
+
+		config, err := parseConfig(c, objects...)
+		if err != nil {
+			return err
+		}
+		if client, err = newClient(config.NutanixDatacenter); err != nil {
+			p.Warn(err)
+			continue
+		}
+		if err := validator.ValidateDatacenterConfig(ctx, client, &cluster.Spec{Config: config}); err != nil {
+			p.Warn(err)
+			continue
+		}
+		break
+	}
+
+	for {
+		if err := configureNutanixMachines(ctx, p, client, machineConfigs...); err != nil {
+			return err
+		}
+		if err := validateNutanixMachines(ctx, client, validator, c, objects...); err != nil {
+			p.Warn(err)
+			continue
+		}
+		break
+	}
+
+	key, err := AskSSHKey(p)
+	if err != nil {
+		return err
+	}
+	for _, m := range machineConfigs {
+		setSSHKey(m.Spec.Users, key)
+	}
+
+	return nil
+}
+
+func configurePrismCentral(p *Prompter, datacenterConfig *v1alpha1.NutanixDatacenterConfigGenerate) error {
+	endpoint := datacenterConfig.Spec.Endpoint
+	if strings.HasPrefix(endpoint, "<") {
+		endpoint = ""
+	}
+	endpoint, err := p.Ask("Prism Central endpoint (FQDN or IP)", endpoint, required)
+	if err != nil {
+		return err
+	}
+	datacenterConfig.Spec.Endpoint = endpoint
+
+	port, err := p.AskInt("Prism Central port", datacenterConfig.Spec.Port, func(port int) error {
+		if port <= 0 || port > 65535 {
+			return fmt.Errorf("%d is not a valid port", port)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	datacenterConfig.Spec.Port = port
+
+	insecure, err := p.Confirm("Skip the Prism Central certificate verification", datacenterConfig.Spec.Insecure)
+	if err != nil {
+		return err
+	}
+	datacenterConfig.Spec.Insecure = insecure
+
+	return nil
+}
+
+func configureNutanixMachines(ctx context.Context, p *Prompter, client nutanix.Client, machineConfigs ...*v1alpha1.NutanixMachineConfigGenerate) error {
+	clusters, err := listPrismElementClusters(ctx, client)
+	if err != nil {
+		return err
+	}
+	clusterName, err := p.Choose("Prism Element cluster", sortedKeys(clusters), nil)
+	if err != nil {
+		return err
+	}
+
+	subnets, err := listSubnets(ctx, client, clusters[clusterName])
+	if err != nil {
+		return err
+	}
+	subnet, err := p.Choose("Subnet", subnets, nil)
+	if err != nil {
+		return err
+	}
+
+	images, err := listImages(ctx, client)
+	if err != nil {
+		return err
+	}
+	image, err := p.Choose("Image", images, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range machineConfigs {
+		m.Spec.Cluster = nutanixNameIdentifier(clusterName)
+		m.Spec.Subnet = nutanixNameIdentifier(subnet)
+		m.Spec.Image = nutanixNameIdentifier(image)
+	}
+
+	return nil
+}
+
+func validateNutanixMachines(ctx context.Context, client nutanix.Client, validator NutanixValidator, c *v1alpha1.ClusterGenerate, objects ...interface{}) error {
+	config, err := parseConfig(c, objects...)
+	if err != nil {
+		return err
+	}
+	for _, mc := range config.NutanixMachineConfigs {
+		if err := validator.ValidateMachineConfig(ctx, client, config.Cluster, mc); err != nil {
+			return fmt.Errorf("validating NutanixMachineConfig %s: %v", mc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// listPrismElementClusters returns the uuids of the Prism Element clusters by name, leaving out
+// Prism Central which is also listed as a cluster.
+func listPrismElementClusters(ctx context.Context, client nutanix.Client) (map[string]string, error) {
+	res, err := client.ListAllCluster(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %v", err)
+	}
+
+	clusters := map[string]string{}
+	for _, entity := range res.Entities {
+		if entity.Spec == nil || entity.Spec.Name == nil || entity.Metadata == nil || entity.Metadata.UUID == nil || isPrismCentral(entity) {
+			continue
+		}
+		clusters[*entity.Spec.Name] = *entity.Metadata.UUID
+	}
+
+	return clusters, nil
+}
+
+func isPrismCentral(entity *v3.ClusterIntentResponse) bool {
+	if entity.Status == nil || entity.Status.Resources == nil || entity.Status.Resources.Config == nil {
+		return false
+	}
+	for _, svc := range entity.Status.Resources.Config.ServiceList {
+		if svc != nil && strings.ToUpper(*svc) == "PRISM_CENTRAL" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func listSubnets(ctx context.Context, client nutanix.Client, clusterUUID string) ([]string, error) {
+	res, err := client.ListAllSubnet(ctx, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %v", err)
+	}
+
+	var subnets []string
+	for _, subnet := range res.Entities {
+		if subnet.Spec == nil || subnet.Spec.Name == nil || subnet.Spec.ClusterReference == nil || subnet.Spec.ClusterReference.UUID == nil {
+			continue
+		}
+		if *subnet.Spec.ClusterReference.UUID == clusterUUID {
+			subnets = append(subnets, *subnet.Spec.Name)
+		}
+	}
+	sort.Strings(subnets)
+
+	return subnets, nil
+}
+
+func listImages(ctx context.Context, client nutanix.Client) ([]string, error) {
+	res, err := client.ListAllImage(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %v", err)
+	}
+
+	var images []string
+	for _, image := range res.Entities {
+		if image.Spec != nil && image.Spec.Name != nil {
+			images = append(images, *image.Spec.Name)
+		}
+	}
+	sort.Strings(images)
+
+	return images, nil
+}
+
+func nutanixNameIdentifier(name string) v1alpha1.NutanixResourceIdentifier {
+	return v1alpha1.NutanixResourceIdentifier{Type: v1alpha1.NutanixIdentifierName, Name: &name}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
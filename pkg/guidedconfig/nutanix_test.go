@@ -0,0 +1,135 @@
+package guidedconfig_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/nutanix-cloud-native/prism-go-client/utils"
+	v3 "github.com/nutanix-cloud-native/prism-go-client/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig/mocks"
+	"github.com/aws/eks-anywhere/pkg/providers/nutanix"
+	mocknutanix "github.com/aws/eks-anywhere/pkg/providers/nutanix/mocks"
+)
+
+func nutanixCluster(name, uuid, service string) *v3.ClusterIntentResponse {
+	return &v3.ClusterIntentResponse{
+		Metadata: &v3.Metadata{UUID: utils.StringPtr(uuid)},
+		Spec:     &v3.Cluster{Name: utils.StringPtr(name)},
+		Status: &v3.ClusterDefStatus{
+			Resources: &v3.ClusterObj{
+				Config: &v3.ClusterConfig{ServiceList: []*string{utils.StringPtr(service)}},
+			},
+		},
+	}
+}
+
+func nutanixSubnet(name, clusterUUID string) *v3.SubnetIntentResponse {
+	return &v3.SubnetIntentResponse{
+		Spec: &v3.Subnet{
+			Name:             utils.StringPtr(name),
+			ClusterReference: &v3.Reference{UUID: utils.StringPtr(clusterUUID)},
+		},
+	}
+}
+
+func nutanixImage(name string) *v3.ImageIntentResponse {
+	return &v3.ImageIntentResponse{Spec: &v3.Image{Name: utils.StringPtr(name)}}
+}
+
+func TestConfigureNutanix(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	client := mocknutanix.NewMockClient(ctrl)
+	validator := mocks.NewMockNutanixValidator(ctrl)
+	dc := v1alpha1.NewNutanixDatacenterConfigGenerate("test")
+	cp := v1alpha1.NewNutanixMachineConfigGenerate("test-cp")
+	worker := v1alpha1.NewNutanixMachineConfigGenerate("test")
+	c := clusterGenerate(dc, cp, worker)
+	c.Spec.ControlPlaneConfiguration.Endpoint.Host = "10.0.0.1"
+
+	p, out := newPrompter(
+		"pc.example.com", // endpoint
+		"",               // port
+		"",               // verify the certificate
+		"",               // endpoint, after the validation error
+		"",               // port
+		"y",              // skip the certificate verification
+		"1",              // cluster
+		"",               // subnet
+		"2",              // image
+		sshKey,
+	)
+
+	var endpoints []string
+	newClient := func(dc *v1alpha1.NutanixDatacenterConfig) (nutanix.Client, error) {
+		endpoints = append(endpoints, dc.Spec.Endpoint)
+		return client, nil
+	}
+	gomock.InOrder(
+		validator.EXPECT().ValidateDatacenterConfig(ctx, client, gomock.Any()).Return(errors.New("x509: certificate signed by unknown authority")),
+		validator.EXPECT().ValidateDatacenterConfig(ctx, client, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ nutanix.Client, spec *cluster.Spec) error {
+				g.Expect(spec.NutanixDatacenter.Spec.Insecure).To(BeTrue())
+				return nil
+			},
+		),
+	)
+	client.EXPECT().ListAllCluster(ctx, "").Return(&v3.ClusterListIntentResponse{
+		Entities: []*v3.ClusterIntentResponse{
+			nutanixCluster("pe-2", "pe-2-uuid", "AOS"),
+			nutanixCluster("pc", "pc-uuid", "PRISM_CENTRAL"),
+			nutanixCluster("pe-1", "pe-1-uuid", "AOS"),
+		},
+	}, nil)
+	client.EXPECT().ListAllSubnet(ctx, "", nil).Return(&v3.SubnetListIntentResponse{
+		Entities: []*v3.SubnetIntentResponse{
+			nutanixSubnet("vlan-1", "pe-1-uuid"),
+			nutanixSubnet("vlan-2", "pe-2-uuid"),
+		},
+	}, nil)
+	client.EXPECT().ListAllImage(ctx, "").Return(&v3.ImageListIntentResponse{
+		Entities: []*v3.ImageIntentResponse{nutanixImage("ubuntu-1-34"), nutanixImage("ubuntu-1-33")},
+	}, nil)
+	validator.EXPECT().ValidateMachineConfig(ctx, client, gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	g.Expect(guidedconfig.ConfigureNutanix(ctx, p, newClient, validator, c, dc, cp, worker)).To(Succeed())
+	g.Expect(endpoints).To(Equal([]string{"pc.example.com", "pc.example.com"}))
+	g.Expect(dc.Spec.Endpoint).To(Equal("pc.example.com"))
+	g.Expect(dc.Spec.Port).To(Equal(9440))
+	g.Expect(dc.Spec.Insecure).To(BeTrue())
+	for _, m := range []*v1alpha1.NutanixMachineConfigGenerate{cp, worker} {
+		g.Expect(*m.Spec.Cluster.Name).To(Equal("pe-1"))
+		g.Expect(*m.Spec.Subnet.Name).To(Equal("vlan-1"))
+		g.Expect(*m.Spec.Image.Name).To(Equal("ubuntu-1-34"))
+		g.Expect(m.Spec.Users[0].SshAuthorizedKeys).To(Equal([]string{sshKey}))
+	}
+	g.Expect(out.String()).To(ContainSubstring("❌ x509: certificate signed by unknown authority"))
+	g.Expect(out.String()).NotTo(ContainSubstring(") pc\n"))
+}
+
+func TestConfigureNutanixListClustersError(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	client := mocknutanix.NewMockClient(ctrl)
+	validator := mocks.NewMockNutanixValidator(ctrl)
+	dc := v1alpha1.NewNutanixDatacenterConfigGenerate("test")
+	cp := v1alpha1.NewNutanixMachineConfigGenerate("test-cp")
+	worker := v1alpha1.NewNutanixMachineConfigGenerate("test")
+	p, _ := newPrompter("pc.example.com", "", "")
+
+	newClient := func(*v1alpha1.NutanixDatacenterConfig) (nutanix.Client, error) { return client, nil }
+	validator.EXPECT().ValidateDatacenterConfig(ctx, client, gomock.Any()).Return(nil)
+	client.EXPECT().ListAllCluster(ctx, "").Return(nil, errors.New("unauthorized"))
+
+	err := guidedconfig.ConfigureNutanix(ctx, p, newClient, validator, clusterGenerate(dc, cp, worker), dc, cp, worker)
+	g.Expect(err).To(MatchError("failed to list clusters: unauthorized"))
+}
@@ -0,0 +1,143 @@
+package guidedconfig
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Prompter asks questions and reads the answers, asking again until they are valid.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter returns a Prompter reading the answers from in and writing the questions to out.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// Ask asks a question. Empty answers take the default value. The validate func can be nil.
+func (p *Prompter) Ask(question, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		if defaultValue != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", question, defaultValue)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", question)
+		}
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = defaultValue
+		}
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				p.Warn(err)
+				continue
+			}
+		}
+
+		return answer, nil
+	}
+}
+
+// Choose asks to choose one of the options, by number or by value. Values not in the options are
+// accepted if they are valid, since the discovered options might not be complete.
+func (p *Prompter) Choose(question string, options []string, validate func(string) error) (string, error) {
+	if len(options) == 0 {
+		return p.Ask(question, "", validate)
+	}
+
+	fmt.Fprintf(p.out, "%s:\n", question)
+	for i, o := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, o)
+	}
+	defaultValue := ""
+	if len(options) == 1 {
+		defaultValue = options[0]
+	}
+
+	option := func(answer string) string {
+		if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(options) {
+			return options[i-1]
+		}
+		return answer
+	}
+	answer, err := p.Ask("Choose a number or enter a value", defaultValue, func(answer string) error {
+		if answer == "" {
+			return fmt.Errorf("a value is required")
+		}
+		if validate != nil {
+			return validate(option(answer))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return option(answer), nil
+}
+
+// AskInt asks a question with an integer answer.
+func (p *Prompter) AskInt(question string, defaultValue int, validate func(int) error) (int, error) {
+	answer, err := p.Ask(question, strconv.Itoa(defaultValue), func(answer string) error {
+		i, err := strconv.Atoi(answer)
+		if err != nil {
+			return fmt.Errorf("%s is not a number", answer)
+		}
+		if validate != nil {
+			return validate(i)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(answer)
+}
+
+// Confirm asks a yes or no question.
+func (p *Prompter) Confirm(question string, defaultValue bool) (bool, error) {
+	d := "n"
+	if defaultValue {
+		d = "y"
+	}
+	answer, err := p.Ask(question+" (y/n)", d, func(answer string) error {
+		switch strings.ToLower(answer) {
+		case "y", "yes", "n", "no":
+			return nil
+		default:
+			return fmt.Errorf("answer y or n")
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return strings.HasPrefix(strings.ToLower(answer), "y"), nil
+}
+
+// Info writes a message.
+func (p *Prompter) Info(format string, args ...interface{}) {
+	fmt.Fprintf(p.out, format+"\n", args...)
+}
+
+// Warn writes a validation error before asking again.
+func (p *Prompter) Warn(err error) {
+	fmt.Fprintf(p.out, "❌ %v\n", err)
+}
+
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("reading answer: %v", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
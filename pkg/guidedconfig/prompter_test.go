@@ -0,0 +1,206 @@
+package guidedconfig_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/guidedconfig"
+)
+
+func newPrompter(answers ...string) (*guidedconfig.Prompter, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	in := strings.NewReader(strings.Join(answers, "\n") + "\n")
+	return guidedconfig.NewPrompter(in, out), out
+}
+
+func TestPrompterAsk(t *testing.T) {
+	tests := []struct {
+		name         string
+		answers      []string
+		defaultValue string
+		want         string
+		wantOutput   string
+	}{
+		{
+			name:       "answer",
+			answers:    []string{"  value  "},
+			want:       "value",
+			wantOutput: "Question: ",
+		},
+		{
+			name:         "default",
+			answers:      []string{""},
+			defaultValue: "default",
+			want:         "default",
+			wantOutput:   "Question [default]: ",
+		},
+		{
+			name:       "invalid then valid",
+			answers:    []string{"invalid", "valid"},
+			want:       "valid",
+			wantOutput: "Question: ❌ invalid answer\nQuestion: ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p, out := newPrompter(tt.answers...)
+
+			got, err := p.Ask("Question", tt.defaultValue, func(answer string) error {
+				if answer == "invalid" {
+					return errors.New("invalid answer")
+				}
+				return nil
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+			g.Expect(out.String()).To(Equal(tt.wantOutput))
+		})
+	}
+}
+
+func TestPrompterAskEOF(t *testing.T) {
+	g := NewWithT(t)
+	p := guidedconfig.NewPrompter(strings.NewReader(""), &bytes.Buffer{})
+
+	_, err := p.Ask("Question", "default", nil)
+	g.Expect(err).To(MatchError(ContainSubstring("reading answer: EOF")))
+}
+
+func TestPrompterChoose(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []string
+		answers []string
+		want    string
+	}{
+		{
+			name:    "by number",
+			options: []string{"a", "b"},
+			answers: []string{"2"},
+			want:    "b",
+		},
+		{
+			name:    "by value",
+			options: []string{"a", "b"},
+			answers: []string{"c"},
+			want:    "c",
+		},
+		{
+			name:    "out of range number is a value",
+			options: []string{"a", "b"},
+			answers: []string{"3"},
+			want:    "3",
+		},
+		{
+			name:    "single option is the default",
+			options: []string{"a"},
+			answers: []string{""},
+			want:    "a",
+		},
+		{
+			name:    "empty answer asks again",
+			options: []string{"a", "b"},
+			answers: []string{"", "1"},
+			want:    "a",
+		},
+		{
+			name:    "no options",
+			answers: []string{"", "a"},
+			want:    "a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p, _ := newPrompter(tt.answers...)
+
+			got, err := p.Choose("Option", tt.options, func(answer string) error {
+				if answer == "" {
+					return errors.New("a value is required")
+				}
+				return nil
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestPrompterChooseValidatesOption(t *testing.T) {
+	g := NewWithT(t)
+	p, out := newPrompter("1", "2")
+
+	got, err := p.Choose("Option", []string{"a", "b"}, func(answer string) error {
+		if answer == "a" {
+			return errors.New("a is not valid")
+		}
+		return nil
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal("b"))
+	g.Expect(out.String()).To(ContainSubstring("  1) a\n  2) b\n"))
+	g.Expect(out.String()).To(ContainSubstring("❌ a is not valid"))
+}
+
+func TestPrompterAskInt(t *testing.T) {
+	g := NewWithT(t)
+	p, out := newPrompter("two", "-1", "")
+
+	got, err := p.AskInt("Count", 2, func(i int) error {
+		if i < 0 {
+			return errors.New("count must be positive")
+		}
+		return nil
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(2))
+	g.Expect(out.String()).To(ContainSubstring("❌ two is not a number"))
+	g.Expect(out.String()).To(ContainSubstring("❌ count must be positive"))
+}
+
+func TestPrompterConfirm(t *testing.T) {
+	tests := []struct {
+		name         string
+		answers      []string
+		defaultValue bool
+		want         bool
+	}{
+		{
+			name:    "yes",
+			answers: []string{"Yes"},
+			want:    true,
+		},
+		{
+			name:         "no",
+			answers:      []string{"n"},
+			defaultValue: true,
+			want:         false,
+		},
+		{
+			name:         "default",
+			answers:      []string{""},
+			defaultValue: true,
+			want:         true,
+		},
+		{
+			name:    "invalid then yes",
+			answers: []string{"maybe", "y"},
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			p, _ := newPrompter(tt.answers...)
+
+			got, err := p.Confirm("Continue", tt.defaultValue)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package guidedconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+// ConfigureTinkerbell asks for the Tinkerbell IP and the hardware CSV, shows the hardware found in it
+// and asks for the hardware selector of each machine config, checking there is enough hardware for
+// the cluster the way create does.
+func ConfigureTinkerbell(p *Prompter, c *v1alpha1.ClusterGenerate, datacenterConfig *v1alpha1.TinkerbellDatacenterConfigGenerate, machineConfigs ...*v1alpha1.TinkerbellMachineConfigGenerate) error {
+	tinkerbellIP, err := p.Ask("Tinkerbell IP", datacenterConfig.Spec.TinkerbellIP, func(ip string) error {
+		if err := validateIP(ip); err != nil {
+			return err
+		}
+		if endpoint := c.Spec.ControlPlaneConfiguration.Endpoint; endpoint != nil && endpoint.Host == ip {
+			return fmt.Errorf("tinkerbell IP %s cannot be the same as the control plane endpoint", ip)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	datacenterConfig.Spec.TinkerbellIP = tinkerbellIP
+
+	var catalogue *hardware.Catalogue
+	hardwareCSV, err := p.Ask("Hardware CSV file", "", func(path string) error {
+		if err := required(path); err != nil {
+			return err
+		}
+		catalogue, err = readHardwareCatalogue(path)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	p.Info("Found %d hardware:", catalogue.TotalHardware())
+	for _, l := range hardwareLabelCounts(catalogue) {
+		p.Info("  %s", l)
+	}
+
+	objects := []interface{}{datacenterConfig}
+	for _, m := range machineConfigs {
+		objects = append(objects, m)
+	}
+	for {
+		if err := configureTinkerbellOS(p, datacenterConfig, machineConfigs...); err != nil {
+			return err
+		}
+		for _, m := range machineConfigs {
+			if err := configureHardwareSelector(p, catalogue, c, m); err != nil {
+				return err
+			}
+		}
+
+		config, err := parseConfig(c, objects...)
+		if err != nil {
+			return err
+		}
+		validator := tinkerbell.NewClusterSpecValidator(
+			tinkerbell.MinimumHardwareAvailableAssertionForCreate(catalogue),
+			tinkerbell.HardwareSatisfiesOnlyOneSelectorAssertion(catalogue),
+		)
+		spec := tinkerbell.NewClusterSpec(&cluster.Spec{Config: config}, config.TinkerbellMachineConfigs, config.TinkerbellDatacenter)
+		if err := validator.Validate(spec); err != nil {
+			p.Warn(err)
+			continue
+		}
+		break
+	}
+
+	key, err := AskSSHKey(p)
+	if err != nil {
+		return err
+	}
+	for _, m := range machineConfigs {
+		setSSHKey(m.Spec.Users, key)
+	}
+	p.Info("Pass --hardware-csv %s to create the cluster", hardwareCSV)
+
+	return nil
+}
+
+// configureTinkerbellOS asks for the OS family of the machines and, since only Bottlerocket images
+// are imported automatically, for the OS image URL of the other families.
+func configureTinkerbellOS(p *Prompter, datacenterConfig *v1alpha1.TinkerbellDatacenterConfigGenerate, machineConfigs ...*v1alpha1.TinkerbellMachineConfigGenerate) error {
+	osFamily := v1alpha1.Bottlerocket
+	if len(machineConfigs) > 0 {
+		osFamily = machineConfigs[0].Spec.OSFamily
+	}
+	answer, err := p.Ask("OS family (bottlerocket, ubuntu or redhat)", string(osFamily), func(answer string) error {
+		switch v1alpha1.OSFamily(answer) {
+		case v1alpha1.Bottlerocket, v1alpha1.Ubuntu, v1alpha1.RedHat:
+			return nil
+		default:
+			return fmt.Errorf("unsupported OS family %s", answer)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	osFamily = v1alpha1.OSFamily(answer)
+	for _, m := range machineConfigs {
+		m.Spec.OSFamily = osFamily
+	}
+
+	if osFamily == v1alpha1.Bottlerocket {
+		datacenterConfig.Spec.OSImageURL = ""
+		return nil
+	}
+	url, err := p.Ask("OS image URL, including the Kubernetes version", datacenterConfig.Spec.OSImageURL, required)
+	if err != nil {
+		return err
+	}
+	datacenterConfig.Spec.OSImageURL = url
+
+	return nil
+}
+
+func readHardwareCatalogue(path string) (*hardware.Catalogue, error) {
+	reader, err := hardware.NewNormalizedCSVReaderFromFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading hardware CSV: %v", err)
+	}
+
+	catalogue := hardware.NewCatalogue()
+	writer := hardware.NewMachineCatalogueWriter(catalogue)
+	if err := hardware.TranslateAll(reader, writer, hardware.NewDefaultMachineValidator()); err != nil {
+		return nil, fmt.Errorf("reading hardware CSV: %v", err)
+	}
+
+	return catalogue, nil
+}
+
+// hardwareLabelCounts returns how many hardware have each label, as sorted key=value: count lines.
+func hardwareLabelCounts(catalogue *hardware.Catalogue) []string {
+	counts := map[string]int{}
+	for _, h := range catalogue.AllHardware() {
+		for k, v := range h.Labels {
+			counts[k+"="+v]++
+		}
+	}
+
+	lines := make([]string, 0, len(counts))
+	for l, count := range counts {
+		lines = append(lines, fmt.Sprintf("%s: %d", l, count))
+	}
+	sort.Strings(lines)
+
+	return lines
+}
+
+func configureHardwareSelector(p *Prompter, catalogue *hardware.Catalogue, c *v1alpha1.ClusterGenerate, m *v1alpha1.TinkerbellMachineConfigGenerate) error {
+	defaultSelector := "type=worker"
+	if c.Spec.ControlPlaneConfiguration.MachineGroupRef != nil && c.Spec.ControlPlaneConfiguration.MachineGroupRef.Name == m.Name() {
+		defaultSelector = "type=cp"
+	}
+	if !m.Spec.HardwareSelector.IsEmpty() {
+		defaultSelector = formatSelector(m.Spec.HardwareSelector)
+	}
+
+	answer, err := p.Ask(fmt.Sprintf("Hardware selector for %s (key=value[,key=value])", m.Name()), defaultSelector, func(answer string) error {
+		selector, err := parseSelector(answer)
+		if err != nil {
+			return err
+		}
+		if matchingHardware(catalogue, selector) == 0 {
+			return fmt.Errorf("no hardware matches %s", answer)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	selector, _ := parseSelector(answer)
+	m.Spec.HardwareSelector = selector
+	p.Info("%d hardware match %s", matchingHardware(catalogue, selector), answer)
+
+	return nil
+}
+
+func matchingHardware(catalogue *hardware.Catalogue, selector v1alpha1.HardwareSelector) int {
+	count := 0
+	for _, h := range catalogue.AllHardware() {
+		if hardware.LabelsMatchSelector(selector, h.Labels) {
+			count++
+		}
+	}
+	return count
+}
+
+func parseSelector(s string) (v1alpha1.HardwareSelector, error) {
+	selector := v1alpha1.HardwareSelector{}
+	for _, pair := range strings.Split(s, ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || k == "" {
+			return nil, fmt.Errorf("invalid hardware selector %q, expected key=value pairs", s)
+		}
+		selector[k] = v
+	}
+	return selector, nil
+}
+
+func formatSelector(selector v1alpha1.HardwareSelector) string {
+	pairs := make([]string, 0, len(selector))
+	for k, v := range selector {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
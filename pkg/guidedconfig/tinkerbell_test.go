@@ -0,0 +1,68 @@
+package guidedconfig_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig"
+)
+
+func TestConfigureTinkerbell(t *testing.T) {
+	g := NewWithT(t)
+	dc := v1alpha1.NewTinkerbellDatacenterConfigGenerate("test")
+	cp := v1alpha1.NewTinkerbellMachineConfigGenerate("test-cp")
+	worker := v1alpha1.NewTinkerbellMachineConfigGenerate("test")
+	c := clusterGenerate(dc, cp, worker)
+	c.Spec.ControlPlaneConfiguration.Endpoint.Host = "10.0.0.1"
+	c.Spec.WorkerNodeGroupConfigurations[0].Count = ptrInt(1)
+
+	p, out := newPrompter(
+		"10.0.0.1",                           // tinkerbell IP, same as the endpoint
+		"10.0.0.2",                           // tinkerbell IP
+		"testdata/missing.csv",               // hardware CSV
+		"testdata/hardware.csv",              // hardware CSV
+		"bottlerocket",                       // OS family
+		"",                                   // control plane selector
+		"type=none",                          // worker selector
+		"",                                   // worker selector
+		"ubuntu",                             // OS family, after the validation error
+		"https://example.com/ubuntu-1-34.gz", // OS image URL
+		"",                                   // control plane selector
+		"",                                   // worker selector
+		sshKey,
+	)
+
+	g.Expect(guidedconfig.ConfigureTinkerbell(p, c, dc, cp, worker)).To(Succeed())
+	g.Expect(dc.Spec.TinkerbellIP).To(Equal("10.0.0.2"))
+	g.Expect(dc.Spec.OSImageURL).To(Equal("https://example.com/ubuntu-1-34.gz"))
+	g.Expect(cp.Spec.HardwareSelector).To(Equal(v1alpha1.HardwareSelector{"type": "cp"}))
+	g.Expect(worker.Spec.HardwareSelector).To(Equal(v1alpha1.HardwareSelector{"type": "worker"}))
+	for _, m := range []*v1alpha1.TinkerbellMachineConfigGenerate{cp, worker} {
+		g.Expect(m.Spec.OSFamily).To(Equal(v1alpha1.Ubuntu))
+		g.Expect(m.Spec.Users[0].SshAuthorizedKeys).To(Equal([]string{sshKey}))
+	}
+	g.Expect(out.String()).To(ContainSubstring("❌ tinkerbell IP 10.0.0.1 cannot be the same as the control plane endpoint"))
+	g.Expect(out.String()).To(ContainSubstring("❌ reading hardware CSV"))
+	g.Expect(out.String()).To(ContainSubstring("Found 4 hardware:\n  type=cp: 2\n  type=etcd: 1\n  type=worker: 1\n"))
+	g.Expect(out.String()).To(ContainSubstring("❌ no hardware matches type=none"))
+	g.Expect(out.String()).To(ContainSubstring("❌ machineGroupRef test-cp: "))
+	g.Expect(out.String()).To(ContainSubstring("Pass --hardware-csv testdata/hardware.csv to create the cluster"))
+}
+
+func TestConfigureTinkerbellInvalidSelector(t *testing.T) {
+	g := NewWithT(t)
+	dc := v1alpha1.NewTinkerbellDatacenterConfigGenerate("test")
+	cp := v1alpha1.NewTinkerbellMachineConfigGenerate("test-cp")
+	worker := v1alpha1.NewTinkerbellMachineConfigGenerate("test")
+	c := clusterGenerate(dc, cp, worker)
+	p, out := newPrompter("10.0.0.2", "testdata/hardware.csv", "bottlerocket", "type")
+
+	g.Expect(guidedconfig.ConfigureTinkerbell(p, c, dc, cp, worker)).To(MatchError(ContainSubstring("EOF")))
+	g.Expect(out.String()).To(ContainSubstring(`❌ invalid hardware selector "type", expected key=value pairs`))
+}
+
+func ptrInt(i int) *int {
+	return &i
+}
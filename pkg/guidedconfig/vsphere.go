@@ -0,0 +1,195 @@
+package guidedconfig
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/providers/vsphere"
+)
+
+// VSphereClient discovers and validates the vSphere resources.
+type VSphereClient interface {
+	IsCertSelfSigned(ctx context.Context) bool
+	GetCertThumbprint(ctx context.Context) (string, error)
+	ListDatacenters(ctx context.Context) ([]string, error)
+	ListNetworks(ctx context.Context, datacenter string) ([]string, error)
+	ListDatastores(ctx context.Context, datacenter string) ([]string, error)
+	ListFolders(ctx context.Context, datacenter string) ([]string, error)
+	ListResourcePools(ctx context.Context, datacenter string) ([]string, error)
+	ListTemplates(ctx context.Context, datacenter string) ([]string, error)
+	SearchTemplate(ctx context.Context, datacenter, template string) (string, error)
+	ValidateVCenterSetupMachineConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig, machineConfig *v1alpha1.VSphereMachineConfig, selfSigned *bool) error
+}
+
+// VSphereValidator validates the vSphere datacenter config the way create does.
+type VSphereValidator interface {
+	ValidateVCenterConfig(ctx context.Context, datacenterConfig *v1alpha1.VSphereDatacenterConfig) error
+}
+
+// ConfigureVSphere asks for the vCenter server and offers the datacenters, networks, datastores, folders,
+// resource pools and templates found in it. The machine configs all get the same resources.
+func ConfigureVSphere(ctx context.Context, p *Prompter, govc VSphereClient, validator VSphereValidator, datacenterConfig *v1alpha1.VSphereDatacenterConfigGenerate, machineConfigs ...*v1alpha1.VSphereMachineConfigGenerate) error {
+	if err := configureVCenter(ctx, p, govc, datacenterConfig); err != nil {
+		return err
+	}
+
+	dc := &v1alpha1.VSphereDatacenterConfig{}
+	for {
+		datacenters, err := govc.ListDatacenters(ctx)
+		if err != nil {
+			return err
+		}
+		datacenter, err := p.Choose("Datacenter", datacenters, nil)
+		if err != nil {
+			return err
+		}
+		datacenterConfig.Spec.Datacenter = datacenter
+		if err := setupVSphereEnv(datacenterConfig); err != nil {
+			return err
+		}
+
+		networks, err := govc.ListNetworks(ctx, datacenter)
+		if err != nil {
+			return err
+		}
+		network, err := p.Choose("Network", networks, nil)
+		if err != nil {
+			return err
+		}
+		datacenterConfig.Spec.Network = network
+
+		if err := convert(datacenterConfig, dc); err != nil {
+			return err
+		}
+		if err := validator.ValidateVCenterConfig(ctx, dc); err != nil {
+			p.Warn(err)
+			continue
+		}
+		break
+	}
+
+	for {
+		if err := configureVSphereMachines(ctx, p, govc, datacenterConfig.Spec.Datacenter, machineConfigs...); err != nil {
+			return err
+		}
+		if err := validateVSphereMachines(ctx, govc, dc, machineConfigs...); err != nil {
+			p.Warn(err)
+			continue
+		}
+		break
+	}
+
+	key, err := AskSSHKey(p)
+	if err != nil {
+		return err
+	}
+	for _, m := range machineConfigs {
+		setSSHKey(m.Spec.Users, key)
+	}
+
+	return nil
+}
+
+func configureVCenter(ctx context.Context, p *Prompter, govc VSphereClient, datacenterConfig *v1alpha1.VSphereDatacenterConfigGenerate) error {
+	server, err := p.Ask("vCenter server", datacenterConfig.Spec.Server, required)
+	if err != nil {
+		return err
+	}
+	datacenterConfig.Spec.Server = server
+
+	insecure, err := p.Confirm("Skip the vCenter certificate verification", datacenterConfig.Spec.Insecure)
+	if err != nil {
+		return err
+	}
+	datacenterConfig.Spec.Insecure = insecure
+
+	if err := setupVSphereEnv(datacenterConfig); err != nil {
+		return err
+	}
+
+	if insecure || !govc.IsCertSelfSigned(ctx) {
+		return nil
+	}
+	thumbprint, err := govc.GetCertThumbprint(ctx)
+	if err != nil {
+		return err
+	}
+	trust, err := p.Confirm(fmt.Sprintf("vCenter uses a self-signed certificate with thumbprint %s. Trust it", thumbprint), true)
+	if err != nil {
+		return err
+	}
+	if !trust {
+		return fmt.Errorf("vCenter certificate with thumbprint %s is not trusted", thumbprint)
+	}
+	datacenterConfig.Spec.Thumbprint = thumbprint
+
+	return nil
+}
+
+func configureVSphereMachines(ctx context.Context, p *Prompter, govc VSphereClient, datacenter string, machineConfigs ...*v1alpha1.VSphereMachineConfigGenerate) error {
+	questions := []struct {
+		question string
+		list     func(context.Context, string) ([]string, error)
+		set      func(*v1alpha1.VSphereMachineConfigSpec, string)
+	}{
+		{"Datastore", govc.ListDatastores, func(s *v1alpha1.VSphereMachineConfigSpec, v string) { s.Datastore = v }},
+		{"VM folder", govc.ListFolders, func(s *v1alpha1.VSphereMachineConfigSpec, v string) { s.Folder = v }},
+		{"Resource pool", govc.ListResourcePools, func(s *v1alpha1.VSphereMachineConfigSpec, v string) { s.ResourcePool = v }},
+		{"Template", govc.ListTemplates, func(s *v1alpha1.VSphereMachineConfigSpec, v string) { s.Template = v }},
+	}
+
+	for _, q := range questions {
+		options, err := q.list(ctx, datacenter)
+		if err != nil {
+			return err
+		}
+		answer, err := p.Choose(q.question, options, nil)
+		if err != nil {
+			return err
+		}
+		for _, m := range machineConfigs {
+			q.set(&m.Spec, answer)
+		}
+	}
+
+	return nil
+}
+
+func validateVSphereMachines(ctx context.Context, govc VSphereClient, dc *v1alpha1.VSphereDatacenterConfig, machineConfigs ...*v1alpha1.VSphereMachineConfigGenerate) error {
+	validatedTemplates := map[string]bool{}
+	for _, m := range machineConfigs {
+		mc := &v1alpha1.VSphereMachineConfig{}
+		if err := convert(m, mc); err != nil {
+			return err
+		}
+		var selfSigned bool
+		if err := govc.ValidateVCenterSetupMachineConfig(ctx, dc, mc, &selfSigned); err != nil {
+			return fmt.Errorf("validating vCenter setup for VSphereMachineConfig %s: %v", m.Name(), err)
+		}
+
+		if validatedTemplates[m.Spec.Template] {
+			continue
+		}
+		path, err := govc.SearchTemplate(ctx, dc.Spec.Datacenter, m.Spec.Template)
+		if err != nil {
+			return fmt.Errorf("validating template: %v", err)
+		}
+		if path == "" {
+			return fmt.Errorf("template <%s> not found. Has the template been imported?", m.Spec.Template)
+		}
+		validatedTemplates[m.Spec.Template] = true
+	}
+
+	return nil
+}
+
+// setupVSphereEnv sets the env vars govc reads the vCenter server and datacenter from, as create does.
+func setupVSphereEnv(datacenterConfig *v1alpha1.VSphereDatacenterConfigGenerate) error {
+	dc := &v1alpha1.VSphereDatacenterConfig{}
+	if err := convert(datacenterConfig, dc); err != nil {
+		return err
+	}
+
+	return vsphere.SetupEnvVars(dc)
+}
@@ -0,0 +1,135 @@
+package guidedconfig_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig"
+	"github.com/aws/eks-anywhere/pkg/guidedconfig/mocks"
+)
+
+// setVSphereEnv sets the vSphere credentials and restores the env vars the flow sets after the test.
+func setVSphereEnv(t *testing.T) {
+	t.Setenv("EKSA_VSPHERE_USERNAME", "user")
+	t.Setenv("EKSA_VSPHERE_PASSWORD", "pass")
+	for _, key := range []string{"VSPHERE_USERNAME", "VSPHERE_PASSWORD", "VSPHERE_SERVER", "GOVC_DATACENTER", "GOVC_INSECURE", "EXP_CLUSTER_RESOURCE_SET", "EKSA_LICENSE"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestConfigureVSphere(t *testing.T) {
+	g := NewWithT(t)
+	setVSphereEnv(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	govc := mocks.NewMockVSphereClient(ctrl)
+	validator := mocks.NewMockVSphereValidator(ctrl)
+	dc := v1alpha1.NewVSphereDatacenterConfigGenerate("test")
+	cp := v1alpha1.NewVSphereMachineConfigGenerate("test-cp")
+	worker := v1alpha1.NewVSphereMachineConfigGenerate("test")
+
+	p, out := newPrompter(
+		"vcenter.example.com", // server
+		"",                    // verify the certificate
+		"",                    // trust the thumbprint
+		"2",                   // datacenter
+		"",                    // network
+		"1",                   // datacenter, after the validation error
+		"",                    // network
+		"", "", "", "",        // datastore, folder, resource pool and template
+		sshKey,
+	)
+
+	govc.EXPECT().IsCertSelfSigned(ctx).Return(true)
+	govc.EXPECT().GetCertThumbprint(ctx).Return("AB:CD", nil)
+	govc.EXPECT().ListDatacenters(ctx).Return([]string{"dc1", "dc2"}, nil).Times(2)
+	govc.EXPECT().ListNetworks(ctx, "dc2").Return([]string{"/dc2/network/VM Network"}, nil)
+	govc.EXPECT().ListNetworks(ctx, "dc1").Return([]string{"/dc1/network/VM Network"}, nil)
+	gomock.InOrder(
+		validator.EXPECT().ValidateVCenterConfig(ctx, gomock.Any()).Return(errors.New("network not found")),
+		validator.EXPECT().ValidateVCenterConfig(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, dc *v1alpha1.VSphereDatacenterConfig) error {
+				g.Expect(dc.Spec.Datacenter).To(Equal("dc1"))
+				g.Expect(os.Getenv("GOVC_DATACENTER")).To(Equal("dc1"))
+				return nil
+			},
+		),
+	)
+	govc.EXPECT().ListDatastores(ctx, "dc1").Return([]string{"/dc1/datastore/ds1"}, nil)
+	govc.EXPECT().ListFolders(ctx, "dc1").Return([]string{"/dc1/vm/eksa"}, nil)
+	govc.EXPECT().ListResourcePools(ctx, "dc1").Return([]string{"/dc1/host/cluster/Resources"}, nil)
+	govc.EXPECT().ListTemplates(ctx, "dc1").Return([]string{"/dc1/vm/Templates/ubuntu-1-34"}, nil)
+	govc.EXPECT().ValidateVCenterSetupMachineConfig(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	govc.EXPECT().SearchTemplate(ctx, "dc1", "/dc1/vm/Templates/ubuntu-1-34").Return("/dc1/vm/Templates/ubuntu-1-34", nil)
+
+	g.Expect(guidedconfig.ConfigureVSphere(ctx, p, govc, validator, dc, cp, worker)).To(Succeed())
+	g.Expect(dc.Spec).To(Equal(v1alpha1.VSphereDatacenterConfigSpec{
+		Server:     "vcenter.example.com",
+		Thumbprint: "AB:CD",
+		Datacenter: "dc1",
+		Network:    "/dc1/network/VM Network",
+	}))
+	for _, m := range []*v1alpha1.VSphereMachineConfigGenerate{cp, worker} {
+		g.Expect(m.Spec.Datastore).To(Equal("/dc1/datastore/ds1"))
+		g.Expect(m.Spec.Folder).To(Equal("/dc1/vm/eksa"))
+		g.Expect(m.Spec.ResourcePool).To(Equal("/dc1/host/cluster/Resources"))
+		g.Expect(m.Spec.Template).To(Equal("/dc1/vm/Templates/ubuntu-1-34"))
+		g.Expect(m.Spec.Users[0].SshAuthorizedKeys).To(Equal([]string{sshKey}))
+	}
+	g.Expect(out.String()).To(ContainSubstring("❌ network not found"))
+	g.Expect(os.Getenv("VSPHERE_SERVER")).To(Equal("vcenter.example.com"))
+}
+
+func TestConfigureVSphereTemplateNotFound(t *testing.T) {
+	g := NewWithT(t)
+	setVSphereEnv(t)
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	govc := mocks.NewMockVSphereClient(ctrl)
+	validator := mocks.NewMockVSphereValidator(ctrl)
+	dc := v1alpha1.NewVSphereDatacenterConfigGenerate("test")
+	cp := v1alpha1.NewVSphereMachineConfigGenerate("test-cp")
+
+	p, out := newPrompter(
+		"vcenter.example.com",
+		"y", // skip the certificate verification
+		"", "",
+		"", "", "", "missing-template",
+		"", "", "", "",
+		"",
+	)
+
+	govc.EXPECT().ListDatacenters(ctx).Return([]string{"dc1"}, nil)
+	govc.EXPECT().ListNetworks(ctx, "dc1").Return([]string{"/dc1/network/VM Network"}, nil)
+	validator.EXPECT().ValidateVCenterConfig(ctx, gomock.Any()).Return(nil)
+	govc.EXPECT().ListDatastores(ctx, "dc1").Return([]string{"/dc1/datastore/ds1"}, nil).Times(2)
+	govc.EXPECT().ListFolders(ctx, "dc1").Return([]string{"/dc1/vm/eksa"}, nil).Times(2)
+	govc.EXPECT().ListResourcePools(ctx, "dc1").Return([]string{"/dc1/host/cluster/Resources"}, nil).Times(2)
+	govc.EXPECT().ListTemplates(ctx, "dc1").Return([]string{"/dc1/vm/Templates/ubuntu-1-34"}, nil).Times(2)
+	govc.EXPECT().ValidateVCenterSetupMachineConfig(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	govc.EXPECT().SearchTemplate(ctx, "dc1", "missing-template").Return("", nil)
+	govc.EXPECT().SearchTemplate(ctx, "dc1", "/dc1/vm/Templates/ubuntu-1-34").Return("/dc1/vm/Templates/ubuntu-1-34", nil)
+
+	g.Expect(guidedconfig.ConfigureVSphere(ctx, p, govc, validator, dc, cp)).To(Succeed())
+	g.Expect(dc.Spec.Insecure).To(BeTrue())
+	g.Expect(cp.Spec.Template).To(Equal("/dc1/vm/Templates/ubuntu-1-34"))
+	g.Expect(cp.Spec.Users[0].SshAuthorizedKeys).To(Equal([]string{""}))
+	g.Expect(out.String()).To(ContainSubstring("❌ template <missing-template> not found"))
+}
+
+func TestConfigureVSphereMissingCredentials(t *testing.T) {
+	g := NewWithT(t)
+	setVSphereEnv(t)
+	t.Setenv("EKSA_VSPHERE_USERNAME", "")
+	ctrl := gomock.NewController(t)
+	p, _ := newPrompter("vcenter.example.com", "")
+
+	err := guidedconfig.ConfigureVSphere(context.Background(), p, mocks.NewMockVSphereClient(ctrl), mocks.NewMockVSphereValidator(ctrl), v1alpha1.NewVSphereDatacenterConfigGenerate("test"))
+	g.Expect(err).To(MatchError(ContainSubstring("EKSA_VSPHERE_USERNAME is not set or is empty")))
+}
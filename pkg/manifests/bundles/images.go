@@ -3,6 +3,7 @@ package bundles
 import (
 	"fmt"
 
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	"golang.org/x/exp/slices"
 
 	"github.com/aws/eks-anywhere/pkg/manifests/eksd"
@@ -26,17 +27,25 @@ func ReadImages(reader Reader, bundles *releasev1.Bundles, kubeVersions ...strin
 			return nil, fmt.Errorf("reading images from Bundle: %v", err)
 		}
 
-		for _, i := range eksd.Images(eksdRelease) {
-			images = append(images, releasev1.Image{
-				Name:        i.Name,
-				Description: i.Description,
-				ImageDigest: i.Image.ImageDigest,
-				URI:         i.Image.URI,
-				OS:          i.OS,
-				Arch:        i.Arch,
-			})
-		}
+		images = append(images, EKSDImages(eksdRelease)...)
 	}
 
 	return images, nil
 }
+
+// EKSDImages returns the images of an EKS-D Release as bundle images.
+func EKSDImages(release *eksdv1.Release) []releasev1.Image {
+	var images []releasev1.Image
+	for _, i := range eksd.Images(release) {
+		images = append(images, releasev1.Image{
+			Name:        i.Name,
+			Description: i.Description,
+			ImageDigest: i.Image.ImageDigest,
+			URI:         i.Image.URI,
+			OS:          i.OS,
+			Arch:        i.Arch,
+		})
+	}
+
+	return images
+}
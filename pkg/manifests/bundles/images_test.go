@@ -4,8 +4,10 @@ import (
 	"errors"
 	"testing"
 
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
 
 	"github.com/aws/eks-anywhere/internal/test/mocks"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
@@ -168,3 +170,26 @@ func TestReadImagesError(t *testing.T) {
 	_, err := bundles.ReadImages(reader, b)
 	g.Expect(err).To(MatchError(ContainSubstring("reading images from Bundle: error reading eksd")))
 }
+
+func TestEKSDImages(t *testing.T) {
+	g := NewWithT(t)
+	release := &eksdv1.Release{}
+	g.Expect(yaml.Unmarshal([]byte(eksdManifest), release)).To(Succeed())
+
+	g.Expect(bundles.EKSDImages(release)).To(ConsistOf(
+		releasev1.Image{
+			Name:        "node-driver-registrar-image",
+			Description: "node-driver-registrar container image",
+			OS:          "linux",
+			Arch:        []string{"amd64", "arm64"},
+			URI:         "public.ecr.aws/eks-distro/kubernetes-csi/node-driver-registrar:v2.1.0-eks-1-20-1",
+		},
+		releasev1.Image{
+			Name:        "csi-snapshotter-image",
+			Description: "csi-snapshotter container image",
+			OS:          "linux",
+			Arch:        []string{"amd64", "arm64"},
+			URI:         "public.ecr.aws/eks-distro/kubernetes-csi/external-snapshotter/csi-snapshotter:v3.0.3-eks-1-20-1",
+		},
+	))
+}
@@ -6,6 +6,7 @@ import (
 
 	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
 
+	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/manifests/releases"
 	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
@@ -15,9 +16,12 @@ type FileReader interface {
 	ReadFile(url string) ([]byte, error)
 }
 
+// Reader reads the Releases, Bundles and EKS-D manifests. The signatures of the Bundles and EKS-D
+// manifests are verified by default, see WithPublicKeys and WithoutSignatureVerification.
 type Reader struct {
 	FileReader
-	releasesManifestURL string
+	releasesManifestURL   string
+	signatureVerification signatureVerification
 }
 
 type ReaderOpt func(*Reader)
@@ -30,8 +34,9 @@ func WithReleasesManifest(manifestURL string) ReaderOpt {
 
 func NewReader(filereader FileReader, opts ...ReaderOpt) *Reader {
 	r := &Reader{
-		FileReader:          filereader,
-		releasesManifestURL: releases.ManifestURL(),
+		FileReader:            filereader,
+		releasesManifestURL:   releases.ManifestURL(),
+		signatureVerification: defaultSignatureVerification(),
 	}
 	for _, opt := range opts {
 		opt(r)
@@ -66,7 +71,53 @@ func (r *Reader) ReadBundlesForVersion(version string) (*releasev1.Bundles, erro
 		return nil, err
 	}
 
-	return releases.ReadBundlesForRelease(r, release)
+	b, err := releases.ReadBundlesForRelease(r, release)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := signedVersion(release.Version)
+	if err != nil {
+		return nil, err
+	}
+	if !signed {
+		logger.V(4).Info("Skipping signature verification of unsigned Bundles manifest", "version", release.Version)
+		return b, nil
+	}
+
+	if err := r.signatureVerification.verifyBundles(b, release.BundleManifestUrl); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ReadBundles reads and verifies the Bundles manifest from a url, like a bundles override.
+func (r *Reader) ReadBundles(url string) (*releasev1.Bundles, error) {
+	b, err := bundles.Read(r, url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.signatureVerification.verifyBundles(b, url); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// ReadEKSDForBundles reads the EKS-D manifest of a versions bundle and verifies it against its signature in the Bundles.
+func (r *Reader) ReadEKSDForBundles(b *releasev1.Bundles, versionsBundle releasev1.VersionsBundle) (*eksdv1.Release, error) {
+	release, err := bundles.ReadEKSD(r, versionsBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.signatureVerification.verifyEKSD(b, versionsBundle, release); err != nil {
+		return nil, err
+	}
+
+	return release, nil
 }
 
 func (r *Reader) ReadEKSD(eksaVersion, kubeVersion string) (*eksdv1.Release, error) {
@@ -80,7 +131,7 @@ func (r *Reader) ReadEKSD(eksaVersion, kubeVersion string) (*eksdv1.Release, err
 		return nil, fmt.Errorf("kubernetes version %s is not supported by bundles manifest %d", kubeVersion, b.Spec.Number)
 	}
 
-	return bundles.ReadEKSD(r, *versionsBundle)
+	return r.ReadEKSDForBundles(b, *versionsBundle)
 }
 
 func (r *Reader) ReadImages(eksaVersion string) ([]releasev1.Image, error) {
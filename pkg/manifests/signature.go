@@ -0,0 +1,166 @@
+package manifests
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/signature"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	// SkipSignatureVerificationEnvVar disables the verification of the signatures of the Bundles and EKS-D manifests.
+	// Its value is the reason to skip it, logged every time a manifest is read without verifying it.
+	SkipSignatureVerificationEnvVar = "EKSA_SKIP_MANIFEST_SIGNATURE_VERIFICATION"
+
+	// PublicKeysEnvVar is a comma separated list of files with PEM encoded public keys trusted to sign
+	// the Bundles and EKS-D manifests, on top of the EKS Anywhere release keys.
+	PublicKeysEnvVar = "EKSA_MANIFEST_PUBLIC_KEYS"
+
+	// firstSignedVersion is the first EKS Anywhere version with signed Bundles and EKS-D manifests.
+	firstSignedVersion = "v0.22.0"
+)
+
+// signatureVerification configures the verification of the Bundles and EKS-D manifests signatures.
+type signatureVerification struct {
+	skipReason       string
+	bundlesKeys      []crypto.PublicKey
+	eksDistroKeys    []crypto.PublicKey
+	additionalKeys   []crypto.PublicKey
+	additionalKeysFn func() ([]crypto.PublicKey, error)
+}
+
+// WithPublicKeys makes the Reader trust the signatures of the manifests made by keys,
+// on top of the EKS Anywhere release keys. This allows to use manifests re-signed internally.
+func WithPublicKeys(keys ...crypto.PublicKey) ReaderOpt {
+	return func(r *Reader) {
+		r.signatureVerification.additionalKeys = append(r.signatureVerification.additionalKeys, keys...)
+	}
+}
+
+// WithoutSignatureVerification disables the verification of the manifests signatures.
+// The reason is logged as a warning every time a manifest is read without verifying it.
+func WithoutSignatureVerification(reason string) ReaderOpt {
+	return func(r *Reader) {
+		r.signatureVerification.skipReason = reason
+	}
+}
+
+func defaultSignatureVerification() signatureVerification {
+	v := signatureVerification{
+		skipReason:       os.Getenv(SkipSignatureVerificationEnvVar),
+		additionalKeysFn: publicKeysFromEnv,
+	}
+
+	// The release keys are constants, they can't fail to parse.
+	if key, err := signature.PublicKeyFromBase64(constants.KMSPublicKey); err == nil {
+		v.bundlesKeys = append(v.bundlesKeys, key)
+	}
+	if key, err := signature.PublicKeyFromBase64(constants.EKSDistroKMSPublicKey); err == nil {
+		v.eksDistroKeys = append(v.eksDistroKeys, key)
+	}
+
+	return v
+}
+
+func publicKeysFromEnv() ([]crypto.PublicKey, error) {
+	files := os.Getenv(PublicKeysEnvVar)
+	if files == "" {
+		return nil, nil
+	}
+
+	var keys []crypto.PublicKey
+	for _, file := range strings.Split(files, ",") {
+		content, err := os.ReadFile(strings.TrimSpace(file))
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest public keys from %s: %v", PublicKeysEnvVar, err)
+		}
+		k, err := signature.ParseTrustRoot(content)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest public keys from %s: %v", file, err)
+		}
+		keys = append(keys, k...)
+	}
+
+	return keys, nil
+}
+
+func (v signatureVerification) skip(url string) bool {
+	if v.skipReason == "" {
+		return false
+	}
+
+	logger.MarkWarning("Skipping manifest signature verification", "manifest", url, "reason", v.skipReason)
+	return true
+}
+
+func (v signatureVerification) trusted(releaseKeys []crypto.PublicKey) ([]crypto.PublicKey, error) {
+	keys := append(append([]crypto.PublicKey{}, releaseKeys...), v.additionalKeys...)
+	if v.additionalKeysFn != nil {
+		k, err := v.additionalKeysFn()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k...)
+	}
+
+	return keys, nil
+}
+
+func (v signatureVerification) verifyBundles(b *releasev1.Bundles, url string) error {
+	if v.skip(url) {
+		return nil
+	}
+
+	keys, err := v.trusted(v.bundlesKeys)
+	if err != nil {
+		return err
+	}
+	if err := signature.VerifyBundlesSignature(b, keys); err != nil {
+		return fmt.Errorf("verifying signature of Bundles manifest %s: %v. Set %s to the reason to skip the verification", url, err, SkipSignatureVerificationEnvVar)
+	}
+
+	return nil
+}
+
+// verifyEKSD verifies an EKS-D release manifest against the signature stored for its release channel in the Bundles.
+// The EKS-D manifests of unsigned Bundles, from versions older than the first signed one, are not verified.
+func (v signatureVerification) verifyEKSD(b *releasev1.Bundles, versionsBundle releasev1.VersionsBundle, release *eksdv1.Release) error {
+	url := versionsBundle.EksD.EksDReleaseUrl
+	if _, signed := b.Annotations[constants.SignatureAnnotation]; !signed || v.skip(url) {
+		return nil
+	}
+
+	keys, err := v.trusted(v.eksDistroKeys)
+	if err != nil {
+		return err
+	}
+	sig := b.Annotations[constants.EKSDistroSignatureAnnotation+"-"+versionsBundle.EksD.ReleaseChannel]
+	if err := signature.VerifyEKSDistroManifestSignature(release, sig, keys); err != nil {
+		return fmt.Errorf("verifying signature of EKS-D manifest %s: %v. Set %s to the reason to skip the verification", url, err, SkipSignatureVerificationEnvVar)
+	}
+
+	return nil
+}
+
+// signedVersion returns true if the Bundles of the EKS Anywhere version are signed.
+func signedVersion(version string) (bool, error) {
+	v, err := semver.New(version)
+	if err != nil {
+		return false, fmt.Errorf("parsing eks-a version %s: %v", version, err)
+	}
+	first, err := semver.New(firstSignedVersion)
+	if err != nil {
+		return false, errors.New("parsing first signed eks-a version")
+	}
+
+	return v.Compare(first) >= 0, nil
+}
@@ -0,0 +1,206 @@
+package manifests_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/internal/test/mocks"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/manifests"
+	"github.com/aws/eks-anywhere/pkg/manifests/releases"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	signedBundlesManifest = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Bundles
+metadata:
+  annotations:
+    anywhere.eks.amazonaws.com/signature: MEYCIQCiWwxw/Nchkgtan47FzagXHgB45Op7YWxvSZjFzHau8wIhALG2kbm+H8HJEfN/rUQ0ldo298MnzyhukBptUm0jCtZZ
+spec:
+  number: 1
+  versionsBundles:
+  - kubeVersion: "1.31"`
+
+	unsignedBundlesManifest = `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Bundles
+metadata:
+  name: bundles-1
+spec:
+  number: 1`
+
+	eksdReleaseManifest = `apiVersion: distro.eks.amazonaws.com/v1alpha1
+kind: Release
+metadata:
+  name: kubernetes-1-28-46
+  namespace: eksa-system
+spec:
+  channel: 1-28
+  number: 46
+status:
+  components:
+  - name: metrics-server
+    gitTag: v0.7.2
+    assets:
+    - name: metrics-server-image`
+
+	eksdReleaseSignature = "MEUCIQC3uP3Dhfb/nhCeir0Hwtf4bddKVfVIauFWBidT18XZOwIgHjzH1mOxBm1N2l2w9wBVy9W1o6CQXpdDz7UcbCszZYc="
+)
+
+func expectReleasesManifest(reader *mocks.MockReader, version string) {
+	releasesManifest := `apiVersion: anywhere.eks.amazonaws.com/v1alpha1
+kind: Release
+metadata:
+  name: release-1
+spec:
+  releases:
+    - bundleManifestUrl: "https://bundles/bundles.yaml"
+      version: ` + version
+	reader.EXPECT().ReadFile(releases.ManifestURL()).Return([]byte(releasesManifest), nil)
+}
+
+func TestReaderReadBundlesForVersionSigned(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	expectReleasesManifest(reader, "v0.22.0")
+	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(signedBundlesManifest), nil)
+
+	r := manifests.NewReader(reader)
+	b, err := r.ReadBundlesForVersion("v0.22.0")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(b.Spec.Number).To(Equal(1))
+}
+
+func TestReaderReadBundlesForVersionUnsigned(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	expectReleasesManifest(reader, "v0.22.1")
+	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(unsignedBundlesManifest), nil)
+
+	r := manifests.NewReader(reader)
+	_, err := r.ReadBundlesForVersion("v0.22.1")
+	g.Expect(err).To(MatchError(ContainSubstring(
+		"verifying signature of Bundles manifest https://bundles/bundles.yaml: missing bundle signature annotation. Set EKSA_SKIP_MANIFEST_SIGNATURE_VERIFICATION",
+	)))
+}
+
+func TestReaderReadBundlesForVersionOlderThanFirstSigned(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	expectReleasesManifest(reader, "v0.21.5")
+	reader.EXPECT().ReadFile("https://bundles/bundles.yaml").Return([]byte(unsignedBundlesManifest), nil)
+
+	r := manifests.NewReader(reader)
+	_, err := r.ReadBundlesForVersion("v0.21.5")
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestReaderReadBundlesSkipVerificationEnvVar(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(manifests.SkipSignatureVerificationEnvVar, "testing an unsigned bundle")
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	reader.EXPECT().ReadFile("bundles.yaml").Return([]byte(unsignedBundlesManifest), nil)
+
+	r := manifests.NewReader(reader)
+	_, err := r.ReadBundles("bundles.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestReaderReadBundlesWithoutSignatureVerification(t *testing.T) {
+	g := NewWithT(t)
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	reader.EXPECT().ReadFile("bundles.yaml").Return([]byte(unsignedBundlesManifest), nil)
+
+	r := manifests.NewReader(reader, manifests.WithoutSignatureVerification("testing an unsigned bundle"))
+	_, err := r.ReadBundles("bundles.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestReaderReadBundlesWithPublicKeys(t *testing.T) {
+	g := NewWithT(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(HaveOccurred())
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	reader.EXPECT().ReadFile("bundles.yaml").Return([]byte(signedBundlesManifest), nil)
+
+	r := manifests.NewReader(reader, manifests.WithPublicKeys(key.Public()))
+	_, err = r.ReadBundles("bundles.yaml")
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestReaderReadBundlesPublicKeysEnvVarError(t *testing.T) {
+	g := NewWithT(t)
+	t.Setenv(manifests.PublicKeysEnvVar, "does-not-exist.pem")
+	reader := mocks.NewMockReader(gomock.NewController(t))
+	reader.EXPECT().ReadFile("bundles.yaml").Return([]byte(signedBundlesManifest), nil)
+
+	r := manifests.NewReader(reader)
+	_, err := r.ReadBundles("bundles.yaml")
+	g.Expect(err).To(MatchError(ContainSubstring("reading manifest public keys from EKSA_MANIFEST_PUBLIC_KEYS")))
+}
+
+func TestReaderReadEKSDForBundles(t *testing.T) {
+	versionsBundle := releasev1.VersionsBundle{
+		EksD: releasev1.EksDRelease{
+			ReleaseChannel: "1-28",
+			EksDReleaseUrl: "https://distro.eks.amazonaws.com/kubernetes-1-28/kubernetes-1-28-eks-46.yaml",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantErr     string
+	}{
+		{
+			name: "valid signature",
+			annotations: map[string]string{
+				constants.SignatureAnnotation:                    "signature",
+				constants.EKSDistroSignatureAnnotation + "-1-28": eksdReleaseSignature,
+			},
+		},
+		{
+			name: "missing signature",
+			annotations: map[string]string{
+				constants.SignatureAnnotation: "signature",
+			},
+			wantErr: "verifying signature of EKS-D manifest https://distro.eks.amazonaws.com/kubernetes-1-28/kubernetes-1-28-eks-46.yaml: missing eks distro manifest signature annotation",
+		},
+		{
+			name: "invalid signature",
+			annotations: map[string]string{
+				constants.SignatureAnnotation:                    "signature",
+				constants.EKSDistroSignatureAnnotation + "-1-28": "MEUCIQDkW3QYE1W3ZN3L0Qe0rVxS/8ZcqlGpS4f9pGqChJa7ZwIgUcbVAK1yf7mKxJg9ywyKvHFbsW/0AfSd5lqFnCDJSCY=",
+			},
+			wantErr: "signature is not valid for any of the trusted public keys",
+		},
+		{
+			name: "unsigned bundles",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			reader := mocks.NewMockReader(gomock.NewController(t))
+			reader.EXPECT().ReadFile(versionsBundle.EksD.EksDReleaseUrl).Return([]byte(eksdReleaseManifest), nil)
+			b := &releasev1.Bundles{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+
+			r := manifests.NewReader(reader)
+			release, err := r.ReadEKSDForBundles(b, versionsBundle)
+			if tc.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(release).To(BeAssignableToTypeOf(&eksdv1.Release{}))
+		})
+	}
+}
@@ -53,6 +53,11 @@ func (i Installation) Installed() bool {
 	return i.DaemonSet != nil && i.Operator != nil && isEKSACilium
 }
 
+// UsesClusterPoolIPAM determines if the installed Cilium config uses cluster-pool IPAM.
+func (i Installation) UsesClusterPoolIPAM() bool {
+	return i.ConfigMap != nil && i.ConfigMap.Data[IPAMConfigMapKey] == ipamModeClusterPool
+}
+
 // GetInstallation creates a new Installation instance. The returned installation's DaemonSet,
 // Operator and ConfigMap fields will be nil if they could not be found within the target cluster.
 func GetInstallation(ctx context.Context, client client.Client) (*Installation, error) {
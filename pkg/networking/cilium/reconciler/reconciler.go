@@ -92,10 +92,22 @@ func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client c
 		markCiliumInstalled(ctx, spec.Cluster)
 	}
 
+	// Cilium can't switch IPAM mode on a running cluster, so the mode Cilium runs with is kept.
+	// New installations use cluster-pool IPAM, unless the Helm values are provided, which allows
+	// expanding the pod network later on.
+	if installation.UsesClusterPoolIPAM() && !spec.Cluster.UsesCiliumClusterPoolIPAM() {
+		logger.Info("Cilium uses cluster-pool IPAM, applying annotation to Cluster object")
+		spec.Cluster.SetCiliumClusterPoolIPAM()
+	}
+
 	ciliumCfg := spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium
 
 	if !installation.Installed() &&
 		(ciliumCfg.IsManaged() || !ciliumWasInstalled(ctx, spec.Cluster)) {
+		if !ciliumWasInstalled(ctx, spec.Cluster) && ciliumCfg.HelmValues == nil {
+			spec.Cluster.SetCiliumClusterPoolIPAM()
+		}
+
 		if err := r.install(ctx, logger, client, spec); err != nil {
 			return controller.Result{}, err
 		}
@@ -242,12 +254,19 @@ func (r *Reconciler) updateConfig(ctx context.Context, client client.Client, spe
 }
 
 func (r *Reconciler) applyFullManifest(ctx context.Context, client client.Client, spec *cluster.Spec) error {
-	upgradeManifest, err := r.templater.GenerateManifest(ctx, spec, cilium.WithPolicyAllowedNamespaces(r.providerNamespaces))
+	return r.ApplyManifest(ctx, client, spec)
+}
+
+// ApplyManifest generates the Cilium manifest for the cluster spec and applies it to the cluster,
+// regardless of the current Cilium installation. opts allow to customize the generated manifest.
+func (r *Reconciler) ApplyManifest(ctx context.Context, client client.Client, spec *cluster.Spec, opts ...cilium.ManifestOpt) error {
+	opts = append([]cilium.ManifestOpt{cilium.WithPolicyAllowedNamespaces(r.providerNamespaces)}, opts...)
+	manifest, err := r.templater.GenerateManifest(ctx, spec, opts...)
 	if err != nil {
 		return err
 	}
 
-	return serverside.ReconcileYaml(ctx, client, upgradeManifest)
+	return serverside.ReconcileYaml(ctx, client, manifest)
 }
 
 func (r *Reconciler) deletePreflightIfExists(ctx context.Context, client client.Client, spec *cluster.Spec) (controller.Result, error) {
@@ -3,6 +3,7 @@ package reconciler_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,9 +50,23 @@ func TestReconcilerReconcileInstall(t *testing.T) {
 	tt.expectDaemonSetSemanticallyEqual(ds)
 	tt.expectOperatorSemanticallyEqual(operator)
 	tt.expectCiliumInstalledAnnotation()
+	tt.Expect(tt.spec.Cluster.UsesCiliumClusterPoolIPAM()).To(BeTrue())
 	tt.expectDefaultCNIConfigured(defaultCNIConfiguredCondition("True", "", "", ""))
 }
 
+func TestReconcilerReconcileInstallWithHelmValues(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.HelmValues = &apiextensionsv1.JSON{Raw: []byte(`{"ipam":{"mode":"kubernetes"}}`)}
+	manifest := buildManifest(tt.WithT, ciliumDaemonSet(), ciliumOperator())
+	tt.templater.EXPECT().GenerateManifest(tt.ctx, tt.spec, gomock.Not(gomock.Nil())).Return(manifest, nil)
+
+	tt.Expect(
+		tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), tt.client, tt.spec),
+	).To(Equal(controller.Result{}))
+	tt.expectCiliumInstalledAnnotation()
+	tt.Expect(tt.spec.Cluster.UsesCiliumClusterPoolIPAM()).To(BeFalse())
+}
+
 func TestReconcilerReconcileInstallErrorGeneratingManifest(t *testing.T) {
 	tt := newReconcileTest(t)
 	tt.templater.EXPECT().GenerateManifest(tt.ctx, tt.spec, gomock.Not(gomock.Nil())).Return(nil, errors.New("generating manifest"))
@@ -80,6 +96,25 @@ func TestReconcilerReconcileAlreadyUpToDate(t *testing.T) {
 	tt.expectDaemonSetSemanticallyEqual(ds)
 	tt.expectOperatorSemanticallyEqual(operator)
 	tt.expectCiliumInstalledAnnotation()
+	tt.Expect(tt.spec.Cluster.UsesCiliumClusterPoolIPAM()).To(BeFalse())
+	tt.expectDefaultCNIConfigured(defaultCNIConfiguredCondition("True", "", "", ""))
+}
+
+func TestReconcilerReconcileAlreadyUpToDateWithClusterPoolIPAM(t *testing.T) {
+	ds := ciliumDaemonSet()
+	operator := ciliumOperator()
+	cm := ciliumConfigMap()
+	tt := newReconcileTest(t)
+	cm.Data[cilium.IPAMConfigMapKey] = "cluster-pool"
+	cm.Data[cilium.ClusterPoolIPv4CIDRConfigMapKey] = strings.Join(tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks, " ")
+	tt.withObjects(ds, operator, cm)
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), tt.client, tt.spec)).To(
+		Equal(controller.Result{}),
+	)
+	tt.expectDaemonSetSemanticallyEqual(ds)
+	tt.expectOperatorSemanticallyEqual(operator)
+	tt.Expect(tt.spec.Cluster.UsesCiliumClusterPoolIPAM()).To(BeTrue())
 	tt.expectDefaultCNIConfigured(defaultCNIConfiguredCondition("True", "", "", ""))
 }
 
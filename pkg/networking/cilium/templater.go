@@ -24,6 +24,9 @@ const (
 	maxRetries           = 10
 	defaultBackOffPeriod = 5 * time.Second
 	namespace            = constants.KubeSystemNamespace
+
+	ipamModeKubernetes  = "kubernetes"
+	ipamModeClusterPool = "cluster-pool"
 )
 
 // HelmClientFactory provides a helm client for a cluster.
@@ -119,6 +122,36 @@ func WithPolicyAllowedNamespaces(namespaces []string) ManifestOpt {
 	}
 }
 
+// WithAgentNodeSelector restricts the Cilium agent DaemonSet to the nodes matching the selector.
+// This is used during CNI migrations to roll out Cilium one node at a time.
+func WithAgentNodeSelector(selector map[string]string) ManifestOpt {
+	return func(c *ManifestConfig) {
+		c.values.set(selector, "nodeSelector")
+	}
+}
+
+// WithNativeRouting configures Cilium to route pod traffic natively between nodes, without
+// encapsulation, using the node pod CIDRs like Kindnetd does. This allows nodes running Cilium
+// and nodes still running Kindnetd to reach each other during a CNI migration. The native routing
+// CIDRs default to the primary pod CIDR block of each IP family so pod traffic is not masqueraded.
+func WithNativeRouting(network anywherev1.ClusterNetwork) ManifestOpt {
+	return func(c *ManifestConfig) {
+		c.values["routingMode"] = "native"
+		c.values["autoDirectNodeRoutes"] = "true"
+		delete(c.values, "tunnelProtocol")
+
+		setNativeRoutingCIDR(c.values, "ipv4NativeRoutingCIDR", network.PodCIDRBlocksForFamily(anywherev1.IPv4Family))
+		setNativeRoutingCIDR(c.values, "ipv6NativeRoutingCIDR", network.PodCIDRBlocksForFamily(anywherev1.IPv6Family))
+	}
+}
+
+func setNativeRoutingCIDR(val values, key string, blocks []string) {
+	if _, ok := val[key]; ok || len(blocks) == 0 {
+		return
+	}
+	val[key] = blocks[0]
+}
+
 func (t *Templater) GenerateManifest(ctx context.Context, spec *cluster.Spec, opts ...ManifestOpt) ([]byte, error) {
 	versionsBundle := spec.RootVersionsBundle()
 	kubeVersion, err := getKubeVersionString(spec, versionsBundle)
@@ -256,7 +289,7 @@ func templateValues(spec *cluster.Spec, versionsBundle *cluster.VersionsBundle)
 			"chainingMode": "portmap",
 		},
 		"ipam": values{
-			"mode": "kubernetes",
+			"mode": ipamModeKubernetes,
 		},
 		"identityAllocationMode": "crd",
 		"prometheus": values{
@@ -330,9 +363,49 @@ func templateValues(spec *cluster.Spec, versionsBundle *cluster.VersionsBundle)
 
 	}
 
+	setIPFamilies(val, spec.Cluster.Spec.ClusterNetwork)
+	setClusterPoolIPAM(val, spec.Cluster)
+
 	return val
 }
 
+// setClusterPoolIPAM configures Cilium with cluster-pool IPAM for clusters using it. kube-controller-manager
+// can only allocate node CIDRs from one CIDR block per IP family, so the Cilium operator allocates node
+// CIDRs from all the pod CIDR blocks instead, and appending pod CIDR blocks only updates the Cilium pool.
+// The IPAM mode is set when Cilium is installed and never switched afterwards: clusters installed with
+// kubernetes IPAM keep it and their pod network can't be expanded.
+func setClusterPoolIPAM(val values, c *anywherev1.Cluster) {
+	if !c.UsesCiliumClusterPoolIPAM() {
+		return
+	}
+
+	network := c.Spec.ClusterNetwork
+
+	operator := values{}
+	if blocks := network.PodCIDRBlocksForFamily(anywherev1.IPv4Family); len(blocks) > 0 {
+		operator["clusterPoolIPv4PodCIDRList"] = blocks
+		operator["clusterPoolIPv4MaskSize"] = network.NodeCIDRMaskSize(anywherev1.IPv4Family)
+	}
+	if blocks := network.PodCIDRBlocksForFamily(anywherev1.IPv6Family); len(blocks) > 0 {
+		operator["clusterPoolIPv6PodCIDRList"] = blocks
+		operator["clusterPoolIPv6MaskSize"] = network.NodeCIDRMaskSize(anywherev1.IPv6Family)
+	}
+
+	val["ipam"] = values{
+		"mode":     ipamModeClusterPool,
+		"operator": operator,
+	}
+}
+
+// setIPFamilies enables IPv6 in Cilium for dual-stack clusters.
+func setIPFamilies(val values, network anywherev1.ClusterNetwork) {
+	if network.IsDualStack() {
+		val["ipv6"] = values{
+			"enabled": true,
+		}
+	}
+}
+
 func getChartURIAndVersion(versionsBundle *cluster.VersionsBundle) (uri, version string) {
 	chart := versionsBundle.Cilium.HelmChart
 	uri = fmt.Sprintf("oci://%s", chart.Image())
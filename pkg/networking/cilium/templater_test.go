@@ -20,6 +20,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/networking/cilium/mocks"
 	"github.com/aws/eks-anywhere/pkg/retrier"
 	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
 // Helper function to create JSON from map.
@@ -540,6 +541,68 @@ func TestTemplaterGenerateManifestDirectModeManualIPCIDRSuccess(t *testing.T) {
 	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
 }
 
+func TestTemplaterGenerateManifestDualStackSuccess(t *testing.T) {
+	wantValues := baseTemplateValues()
+	wantValues["ipv6"] = map[string]interface{}{
+		"enabled": true,
+	}
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ManagementCluster.Name = "managed"
+	tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00:1::/56"}
+	tt.spec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.96.0.0/12", "fd00:2::/108"}
+	tt.expectHelmClientFactoryGet("", "")
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestAdditionalPodCIDRBlocksSuccess(t *testing.T) {
+	wantValues := baseTemplateValues()
+	wantValues["ipv6"] = map[string]interface{}{
+		"enabled": true,
+	}
+	wantValues["ipam"] = map[string]interface{}{
+		"mode": "cluster-pool",
+		"operator": map[string]interface{}{
+			"clusterPoolIPv4PodCIDRList": []string{"192.168.0.0/16", "10.10.0.0/16"},
+			"clusterPoolIPv4MaskSize":    25,
+			"clusterPoolIPv6PodCIDRList": []string{"fd00:1::/56"},
+			"clusterPoolIPv6MaskSize":    64,
+		},
+	}
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ManagementCluster.Name = "managed"
+	tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16", "fd00:1::/56", "10.10.0.0/16"}
+	tt.spec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.96.0.0/12", "fd00:2::/108"}
+	tt.spec.Cluster.Spec.ClusterNetwork.Nodes = &v1alpha1.Nodes{CIDRMaskSize: ptr.Int(25)}
+	tt.expectHelmClientFactoryGet("", "")
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestClusterPoolIPAMSuccess(t *testing.T) {
+	wantValues := baseTemplateValues()
+	wantValues["ipam"] = map[string]interface{}{
+		"mode": "cluster-pool",
+		"operator": map[string]interface{}{
+			"clusterPoolIPv4PodCIDRList": []string{"192.168.0.0/16"},
+			"clusterPoolIPv4MaskSize":    24,
+		},
+	}
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ManagementCluster.Name = "managed"
+	tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+	tt.spec.Cluster.SetCiliumClusterPoolIPAM()
+	tt.expectHelmClientFactoryGet("", "")
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(tt.t.GenerateManifest(tt.ctx, tt.spec)).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
 func TestTemplaterGenerateManifestError(t *testing.T) {
 	expectedAttempts := 2
 	tt := newtemplaterTest(t)
@@ -612,6 +675,58 @@ func TestTemplaterGenerateManifestUpgradeNewKubernetesVersionSuccess(t *testing.
 	).To(Equal(tt.manifest), "templater.GenerateUpgradeManifest() should return right manifest")
 }
 
+func TestTemplaterGenerateManifestWithAgentNodeSelectorSuccess(t *testing.T) {
+	wantValues := baseTemplateValues()
+	wantValues["nodeSelector"] = map[string]interface{}{
+		"anywhere.eks.amazonaws.com/cilium": "true",
+	}
+
+	tt := newtemplaterTest(t)
+
+	tt.expectHelmClientFactoryGet("", "")
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(
+		tt.t.GenerateManifest(tt.ctx, tt.spec,
+			cilium.WithAgentNodeSelector(map[string]string{"anywhere.eks.amazonaws.com/cilium": "true"}),
+		),
+	).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestWithNativeRoutingSuccess(t *testing.T) {
+	wantValues := baseTemplateValues()
+	withDirectRouting(wantValues)
+	withNativeRoutingCIDRs(wantValues, "192.168.0.0/16", "")
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+
+	tt.expectHelmClientFactoryGet("", "")
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(
+		tt.t.GenerateManifest(tt.ctx, tt.spec, cilium.WithNativeRouting(tt.spec.Cluster.Spec.ClusterNetwork)),
+	).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
+func TestTemplaterGenerateManifestWithNativeRoutingKeepsConfiguredCIDRs(t *testing.T) {
+	wantValues := baseTemplateValues()
+	withDirectRouting(wantValues)
+	withNativeRoutingCIDRs(wantValues, "10.0.0.0/8", "")
+
+	tt := newtemplaterTest(t)
+	tt.spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"192.168.0.0/16"}
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.RoutingMode = v1alpha1.CiliumRoutingModeDirect
+	tt.spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium.IPv4NativeRoutingCIDR = "10.0.0.0/8"
+
+	tt.expectHelmClientFactoryGet("", "")
+	tt.expectHelmTemplateWith(eqMap(wantValues), "1.22").Return(tt.manifest, nil)
+
+	tt.Expect(
+		tt.t.GenerateManifest(tt.ctx, tt.spec, cilium.WithNativeRouting(tt.spec.Cluster.Spec.ClusterNetwork)),
+	).To(Equal(tt.manifest), "templater.GenerateManifest() should return right manifest")
+}
+
 func TestTemplaterGenerateNetworkPolicy(t *testing.T) {
 	tests := []struct {
 		name                    string
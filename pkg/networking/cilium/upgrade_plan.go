@@ -7,6 +7,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/types"
 )
@@ -35,6 +36,22 @@ const (
 	// CniExclusiveComponentName is the ConfigComponentUpdatePlan name for the
 	// CniExclusive configuration component.
 	CniExclusiveComponentName = "CniExclusive"
+
+	// IPAMConfigMapKey is the key used in the "cilium-config" ConfigMap to
+	// store the IPAM mode.
+	IPAMConfigMapKey = "ipam"
+
+	// ClusterPoolIPv4CIDRConfigMapKey is the key used in the "cilium-config" ConfigMap to
+	// store the IPv4 pod CIDR blocks of the cluster-pool IPAM.
+	ClusterPoolIPv4CIDRConfigMapKey = "cluster-pool-ipv4-cidr"
+
+	// ClusterPoolIPv6CIDRConfigMapKey is the key used in the "cilium-config" ConfigMap to
+	// store the IPv6 pod CIDR blocks of the cluster-pool IPAM.
+	ClusterPoolIPv6CIDRConfigMapKey = "cluster-pool-ipv6-cidr"
+
+	// IPAMComponentName is the ConfigComponentUpdatePlan name for the
+	// IPAM configuration component.
+	IPAMComponentName = "IPAM"
 )
 
 // UpgradePlan contains information about a Cilium installation upgrade.
@@ -287,11 +304,60 @@ func configMapUpgradePlan(configMap *corev1.ConfigMap, clusterSpec *cluster.Spec
 
 	updatePlan.Components = append(updatePlan.Components, cniExclusiveUpdate)
 
+	ipamUpdate := ConfigComponentUpdatePlan{
+		Name:     IPAMComponentName,
+		NewValue: ipamConfig(clusterSpec),
+	}
+
+	if configMap == nil {
+		updatePlan.UpdateReason = "Cilium config doesn't exist"
+	} else {
+		ipamUpdate.OldValue = ipamConfigFromConfigMap(configMap)
+		if ipamUpdate.OldValue != ipamUpdate.NewValue {
+			ipamUpdate.UpdateReason = fmt.Sprintf("Cilium IPAM changed: [%s] -> [%s]", ipamUpdate.OldValue, ipamUpdate.NewValue)
+		}
+	}
+
+	updatePlan.Components = append(updatePlan.Components, ipamUpdate)
+
 	updatePlan.generateUpdateReasonFromComponents()
 
 	return *updatePlan
 }
 
+// ipamConfig returns a description of the Cilium IPAM configuration for a cluster spec:
+// the IPAM mode followed by the cluster-pool CIDR blocks, if any.
+func ipamConfig(clusterSpec *cluster.Spec) string {
+	if !clusterSpec.Cluster.UsesCiliumClusterPoolIPAM() {
+		return ipamModeKubernetes
+	}
+
+	network := clusterSpec.Cluster.Spec.ClusterNetwork
+
+	return formatIPAMConfig(
+		ipamModeClusterPool,
+		network.PodCIDRBlocksForFamily(anywherev1.IPv4Family),
+		network.PodCIDRBlocksForFamily(anywherev1.IPv6Family),
+	)
+}
+
+func ipamConfigFromConfigMap(configMap *corev1.ConfigMap) string {
+	mode := configMap.Data[IPAMConfigMapKey]
+	if mode == "" || mode == ipamModeKubernetes {
+		return ipamModeKubernetes
+	}
+
+	return formatIPAMConfig(
+		mode,
+		strings.Fields(configMap.Data[ClusterPoolIPv4CIDRConfigMapKey]),
+		strings.Fields(configMap.Data[ClusterPoolIPv6CIDRConfigMapKey]),
+	)
+}
+
+func formatIPAMConfig(mode string, ipv4, ipv6 []string) string {
+	return fmt.Sprintf("%s ipv4=%v ipv6=%v", mode, ipv4, ipv6)
+}
+
 // ChangeDiff returns the change diff between the current and new cluster specs.
 func ChangeDiff(currentSpec, newSpec *cluster.Spec) *types.ChangeDiff {
 	return ciliumChangeDiff(currentSpec, newSpec)
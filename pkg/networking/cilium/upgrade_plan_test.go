@@ -62,6 +62,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -103,6 +108,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -146,6 +156,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -196,6 +211,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -237,6 +257,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -281,6 +306,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -324,6 +354,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -364,6 +399,10 @@ func TestBuildUpgradePlan(t *testing.T) {
 							Name:     cilium.CniExclusiveComponentName,
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -410,6 +449,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -458,6 +502,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -506,6 +555,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -557,6 +611,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "true",
 							NewValue: "true",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -604,6 +663,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							NewValue:     "false",
 							UpdateReason: "Cilium cni-exclusive changed: [true] -> [false]",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -651,6 +715,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							NewValue:     "true",
 							UpdateReason: "Cilium cni-exclusive changed: [false] -> [true]",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -698,6 +767,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							NewValue:     "false",
 							UpdateReason: "Cilium cni-exclusive changed: [true] -> [false]",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -744,6 +818,166 @@ func TestBuildUpgradePlan(t *testing.T) {
 							NewValue:     "true",
 							UpdateReason: "Cilium cni-exclusive changed: [false] -> [true]",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "pod CIDR block appended",
+			installation: &cilium.Installation{
+				DaemonSet: daemonSet("cilium:v1.0.0"),
+				Operator:  deployment("cilium-operator:v1.0.0"),
+				ConfigMap: ciliumConfigMap("default", ""),
+			},
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundles["1.19"].Cilium.Cilium.URI = "cilium:v1.0.0"
+				s.VersionsBundles["1.19"].Cilium.Operator.URI = "cilium-operator:v1.0.0"
+				s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"10.1.0.0/16", "10.2.0.0/16"}
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				}
+			}),
+			want: cilium.UpgradePlan{
+				DaemonSet: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium:v1.0.0",
+					NewImage: "cilium:v1.0.0",
+				},
+				Operator: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium-operator:v1.0.0",
+					NewImage: "cilium-operator:v1.0.0",
+				},
+				ConfigMap: cilium.ConfigUpdatePlan{
+					UpdateReason: "Cilium IPAM changed: [kubernetes] -> [cluster-pool ipv4=[10.1.0.0/16 10.2.0.0/16] ipv6=[]]",
+					Components: []cilium.ConfigComponentUpdatePlan{
+						{
+							Name:     cilium.PolicyEnforcementComponentName,
+							OldValue: "default",
+							NewValue: "default",
+						},
+						{
+							Name: "EgressMasqueradeInterfaces",
+						},
+						{
+							Name:     cilium.CniExclusiveComponentName,
+							OldValue: "true",
+							NewValue: "true",
+						},
+						{
+							Name:         cilium.IPAMComponentName,
+							OldValue:     "kubernetes",
+							NewValue:     "cluster-pool ipv4=[10.1.0.0/16 10.2.0.0/16] ipv6=[]",
+							UpdateReason: "Cilium IPAM changed: [kubernetes] -> [cluster-pool ipv4=[10.1.0.0/16 10.2.0.0/16] ipv6=[]]",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cluster-pool IPAM up to date",
+			installation: &cilium.Installation{
+				DaemonSet: daemonSet("cilium:v1.0.0"),
+				Operator:  deployment("cilium-operator:v1.0.0"),
+				ConfigMap: ciliumConfigMap("default", "", func(cm *corev1.ConfigMap) {
+					cm.Data[cilium.IPAMConfigMapKey] = "cluster-pool"
+					cm.Data[cilium.ClusterPoolIPv4CIDRConfigMapKey] = "10.1.0.0/16 10.2.0.0/16"
+				}),
+			},
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundles["1.19"].Cilium.Cilium.URI = "cilium:v1.0.0"
+				s.VersionsBundles["1.19"].Cilium.Operator.URI = "cilium-operator:v1.0.0"
+				s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"10.1.0.0/16", "10.2.0.0/16"}
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				}
+			}),
+			want: cilium.UpgradePlan{
+				DaemonSet: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium:v1.0.0",
+					NewImage: "cilium:v1.0.0",
+				},
+				Operator: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium-operator:v1.0.0",
+					NewImage: "cilium-operator:v1.0.0",
+				},
+				ConfigMap: cilium.ConfigUpdatePlan{
+					Components: []cilium.ConfigComponentUpdatePlan{
+						{
+							Name:     cilium.PolicyEnforcementComponentName,
+							OldValue: "default",
+							NewValue: "default",
+						},
+						{
+							Name: "EgressMasqueradeInterfaces",
+						},
+						{
+							Name:     cilium.CniExclusiveComponentName,
+							OldValue: "true",
+							NewValue: "true",
+						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "cluster-pool ipv4=[10.1.0.0/16 10.2.0.0/16] ipv6=[]",
+							NewValue: "cluster-pool ipv4=[10.1.0.0/16 10.2.0.0/16] ipv6=[]",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "cluster-pool IPAM with appended pod CIDR block",
+			installation: &cilium.Installation{
+				DaemonSet: daemonSet("cilium:v1.0.0"),
+				Operator:  deployment("cilium-operator:v1.0.0"),
+				ConfigMap: ciliumConfigMap("default", "", func(cm *corev1.ConfigMap) {
+					cm.Data[cilium.IPAMConfigMapKey] = "cluster-pool"
+					cm.Data[cilium.ClusterPoolIPv4CIDRConfigMapKey] = "10.1.0.0/16"
+				}),
+			},
+			clusterSpec: test.NewClusterSpec(func(s *cluster.Spec) {
+				s.VersionsBundles["1.19"].Cilium.Cilium.URI = "cilium:v1.0.0"
+				s.VersionsBundles["1.19"].Cilium.Operator.URI = "cilium-operator:v1.0.0"
+				s.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"10.1.0.0/16", "10.2.0.0/16"}
+				s.Cluster.Spec.ClusterNetwork.CNIConfig = &anywherev1.CNIConfig{
+					Cilium: &anywherev1.CiliumConfig{},
+				}
+				s.Cluster.SetCiliumClusterPoolIPAM()
+			}),
+			want: cilium.UpgradePlan{
+				DaemonSet: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium:v1.0.0",
+					NewImage: "cilium:v1.0.0",
+				},
+				Operator: cilium.VersionedComponentUpgradePlan{
+					OldImage: "cilium-operator:v1.0.0",
+					NewImage: "cilium-operator:v1.0.0",
+				},
+				ConfigMap: cilium.ConfigUpdatePlan{
+					UpdateReason: "Cilium IPAM changed: [cluster-pool ipv4=[10.1.0.0/16] ipv6=[]] -> [cluster-pool ipv4=[10.1.0.0/16 10.2.0.0/16] ipv6=[]]",
+					Components: []cilium.ConfigComponentUpdatePlan{
+						{
+							Name:     cilium.PolicyEnforcementComponentName,
+							OldValue: "default",
+							NewValue: "default",
+						},
+						{
+							Name: "EgressMasqueradeInterfaces",
+						},
+						{
+							Name:     cilium.CniExclusiveComponentName,
+							OldValue: "true",
+							NewValue: "true",
+						},
+						{
+							Name:         cilium.IPAMComponentName,
+							OldValue:     "cluster-pool ipv4=[10.1.0.0/16] ipv6=[]",
+							NewValue:     "cluster-pool ipv4=[10.1.0.0/16 10.2.0.0/16] ipv6=[]",
+							UpdateReason: "Cilium IPAM changed: [cluster-pool ipv4=[10.1.0.0/16] ipv6=[]] -> [cluster-pool ipv4=[10.1.0.0/16 10.2.0.0/16] ipv6=[]]",
+						},
 					},
 				},
 			},
@@ -789,6 +1023,11 @@ func TestBuildUpgradePlan(t *testing.T) {
 							OldValue: "false",
 							NewValue: "false",
 						},
+						{
+							Name:     cilium.IPAMComponentName,
+							OldValue: "kubernetes",
+							NewValue: "kubernetes",
+						},
 					},
 				},
 			},
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/networking/metallb/reconciler/reconciler.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cluster "github.com/aws/eks-anywhere/pkg/cluster"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTemplater is a mock of Templater interface.
+type MockTemplater struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplaterMockRecorder
+}
+
+// MockTemplaterMockRecorder is the mock recorder for MockTemplater.
+type MockTemplaterMockRecorder struct {
+	mock *MockTemplater
+}
+
+// NewMockTemplater creates a new mock instance.
+func NewMockTemplater(ctrl *gomock.Controller) *MockTemplater {
+	mock := &MockTemplater{ctrl: ctrl}
+	mock.recorder = &MockTemplaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplater) EXPECT() *MockTemplaterMockRecorder {
+	return m.recorder
+}
+
+// GenerateManifest mocks base method.
+func (m *MockTemplater) GenerateManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateManifest", ctx, spec)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateManifest indicates an expected call of GenerateManifest.
+func (mr *MockTemplaterMockRecorder) GenerateManifest(ctx, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateManifest", reflect.TypeOf((*MockTemplater)(nil).GenerateManifest), ctx, spec)
+}
@@ -0,0 +1,184 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
+	"github.com/aws/eks-anywhere/pkg/controller/serverside"
+	"github.com/aws/eks-anywhere/pkg/networking/metallb"
+)
+
+const defaultRequeueTime = time.Second * 10
+
+// configKinds are the MetalLB configuration kinds generated from the cluster spec.
+var configKinds = []schema.GroupVersionKind{
+	{Group: "metallb.io", Version: "v1beta1", Kind: "IPAddressPool"},
+	{Group: "metallb.io", Version: "v1beta1", Kind: "L2Advertisement"},
+	{Group: "metallb.io", Version: "v1beta1", Kind: "BGPAdvertisement"},
+	{Group: "metallb.io", Version: "v1beta2", Kind: "BGPPeer"},
+}
+
+// Templater generates the MetalLB components manifest.
+type Templater interface {
+	GenerateManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error)
+}
+
+// Reconciler installs and configures MetalLB as the built-in service load balancer.
+type Reconciler struct {
+	templater Templater
+}
+
+// New returns a new Reconciler.
+func New(templater Templater) *Reconciler {
+	return &Reconciler{
+		templater: templater,
+	}
+}
+
+// Reconcile takes MetalLB in a cluster to the desired state defined in the cluster spec service load
+// balancer configuration. client is connected to the target Kubernetes cluster, not the management cluster.
+// Removing the service load balancer configuration from the spec doesn't uninstall MetalLB, so the
+// Services keep their IPs, but EKS-A stops managing it.
+func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	if spec.Cluster.Spec.ServiceLoadBalancer == nil {
+		v1beta1conditions.Delete(spec.Cluster, anywherev1.ServiceLoadBalancerReadyCondition)
+		return controller.Result{}, nil
+	}
+	log = log.WithValues("component", "serviceLoadBalancer")
+
+	deployment, err := getControllerDeployment(ctx, client)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if needsUpgrade(deployment, spec) {
+		log.Info("Applying MetalLB manifest")
+		manifest, err := r.templater.GenerateManifest(ctx, spec)
+		if err != nil {
+			return controller.Result{}, err
+		}
+		if err := serverside.ReconcileYaml(ctx, client, manifest); err != nil {
+			return controller.Result{}, errors.Wrap(err, "applying MetalLB manifest")
+		}
+
+		return notReady(log, spec, "MetalLB components are being installed")
+	}
+
+	if err := checkDeploymentReady(deployment); err != nil {
+		return notReady(log, spec, err.Error())
+	}
+
+	config, err := metallb.GenerateConfigManifest(spec)
+	if err != nil {
+		return controller.Result{}, errors.Wrap(err, "generating MetalLB configuration")
+	}
+
+	objs, err := clientutil.YamlToClientObjects(config)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	if err := serverside.ReconcileObjects(ctx, client, objs); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying MetalLB configuration")
+	}
+
+	if err := pruneConfig(ctx, log, client, objs); err != nil {
+		return controller.Result{}, err
+	}
+
+	v1beta1conditions.MarkTrue(spec.Cluster, anywherev1.ServiceLoadBalancerReadyCondition)
+
+	return controller.Result{}, nil
+}
+
+func notReady(log logr.Logger, spec *cluster.Spec, message string) (controller.Result, error) {
+	log.Info("MetalLB is not ready, requeueing", "reason", message)
+	v1beta1conditions.MarkFalse(spec.Cluster, anywherev1.ServiceLoadBalancerReadyCondition, anywherev1.ServiceLoadBalancerNotReadyReason, clusterv1.ConditionSeverityInfo, "%s", message)
+	return controller.Result{Result: &ctrl.Result{RequeueAfter: defaultRequeueTime}}, nil
+}
+
+func getControllerDeployment(ctx context.Context, c client.Client) (*appsv1.Deployment, error) {
+	deployment := &appsv1.Deployment{}
+	key := client.ObjectKey{Namespace: metallb.Namespace, Name: metallb.ControllerDeploymentName}
+	if err := c.Get(ctx, key, deployment); apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "reading MetalLB controller deployment")
+	}
+
+	return deployment, nil
+}
+
+// needsUpgrade returns true when MetalLB is not installed or runs a different version than the one in the bundle.
+func needsUpgrade(deployment *appsv1.Deployment, spec *cluster.Spec) bool {
+	metalLB := spec.RootVersionsBundle().MetalLB
+	if deployment == nil || metalLB == nil {
+		return true
+	}
+
+	want := metalLB.Controller.VersionedImage()
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		if c.Image == want {
+			return false
+		}
+	}
+
+	return true
+}
+
+func checkDeploymentReady(deployment *appsv1.Deployment) error {
+	if deployment.Status.ObservedGeneration != deployment.Generation {
+		return fmt.Errorf("deployment %s status needs to be refreshed: observed generation is %d, want %d", deployment.Name, deployment.Status.ObservedGeneration, deployment.Generation)
+	}
+	if deployment.Status.Replicas == 0 || deployment.Status.Replicas != deployment.Status.ReadyReplicas {
+		return fmt.Errorf("deployment %s is not ready: %d/%d ready", deployment.Name, deployment.Status.ReadyReplicas, deployment.Status.Replicas)
+	}
+
+	return nil
+}
+
+// pruneConfig deletes the MetalLB configuration objects managed by EKS-A that are not in the desired objects,
+// like the address pools removed from the spec or the advertisements of the previous mode.
+func pruneConfig(ctx context.Context, log logr.Logger, c client.Client, desired []client.Object) error {
+	keep := map[string]struct{}{}
+	for _, o := range desired {
+		keep[o.GetObjectKind().GroupVersionKind().Kind+"/"+o.GetName()] = struct{}{}
+	}
+
+	for _, gvk := range configKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list, client.InNamespace(metallb.Namespace), client.HasLabels{metallb.ManagedLabel}); err != nil {
+			return errors.Wrapf(err, "listing MetalLB %s objects", gvk.Kind)
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if _, ok := keep[gvk.Kind+"/"+obj.GetName()]; ok {
+				continue
+			}
+			log.Info("Deleting MetalLB configuration object not in the cluster spec", "kind", gvk.Kind, "name", obj.GetName())
+			if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "deleting MetalLB %s %s", gvk.Kind, obj.GetName())
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package reconciler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/networking/metallb"
+	"github.com/aws/eks-anywhere/pkg/networking/metallb/reconciler"
+	"github.com/aws/eks-anywhere/pkg/networking/metallb/reconciler/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const controllerImage = "public.ecr.aws/eks-anywhere/metallb/controller:v0.14.9-eks-a-1"
+
+type reconcileTest struct {
+	*WithT
+	ctx        context.Context
+	spec       *cluster.Spec
+	templater  *mocks.MockTemplater
+	reconciler *reconciler.Reconciler
+}
+
+func newReconcileTest(t *testing.T) *reconcileTest {
+	ctrl := gomock.NewController(t)
+	templater := mocks.NewMockTemplater(ctrl)
+
+	return &reconcileTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.VersionsBundles["1.19"].MetalLB = &releasev1.MetalLBBundle{Controller: releasev1.Image{URI: controllerImage}}
+			s.Cluster.Spec.ServiceLoadBalancer = &anywherev1.ServiceLoadBalancerConfiguration{
+				Mode: anywherev1.ServiceLoadBalancerModeL2,
+				AddressPools: []anywherev1.ServiceLoadBalancerAddressPool{
+					{Name: "default", Addresses: []string{"10.0.1.0/28"}},
+				},
+			}
+		}),
+		templater:  templater,
+		reconciler: reconciler.New(templater),
+	}
+}
+
+func controllerDeployment(image string, ready bool) *appsv1.Deployment {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       metallb.ControllerDeploymentName,
+			Namespace:  metallb.Namespace,
+			Generation: 1,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "controller", Image: image}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           1,
+		},
+	}
+	if ready {
+		d.Status.ReadyReplicas = 1
+	}
+
+	return d
+}
+
+func managedObject(apiVersion, kind, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetName(name)
+	u.SetNamespace(metallb.Namespace)
+	u.SetLabels(map[string]string{metallb.ManagedLabel: "true"})
+	return u
+}
+
+func requeue() controller.Result {
+	return controller.Result{Result: &ctrl.Result{RequeueAfter: 10 * time.Second}}
+}
+
+func (tt *reconcileTest) expectCondition(status string) {
+	c := conditions.Get(tt.spec.Cluster, anywherev1.ServiceLoadBalancerReadyCondition)
+	tt.Expect(c).NotTo(BeNil())
+	tt.Expect(string(c.Status)).To(Equal(status))
+}
+
+func (tt *reconcileTest) expectExists(c client.Client, apiVersion, kind, name string, exists bool) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	err := c.Get(tt.ctx, client.ObjectKey{Namespace: metallb.Namespace, Name: name}, u)
+	if exists {
+		tt.Expect(err).NotTo(HaveOccurred())
+	} else {
+		tt.Expect(err).To(HaveOccurred())
+	}
+}
+
+func TestReconcilerReconcileNotConfigured(t *testing.T) {
+	tt := newReconcileTest(t)
+	tt.spec.Cluster.Spec.ServiceLoadBalancer = nil
+	conditions.MarkTrue(tt.spec.Cluster, anywherev1.ServiceLoadBalancerReadyCondition)
+	c := fake.NewClientBuilder().Build()
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.spec)).To(Equal(controller.Result{}))
+	tt.Expect(conditions.Get(tt.spec.Cluster, anywherev1.ServiceLoadBalancerReadyCondition)).To(BeNil())
+}
+
+func TestReconcilerReconcileInstall(t *testing.T) {
+	tt := newReconcileTest(t)
+	c := fake.NewClientBuilder().Build()
+	manifest := []byte(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: metallb-system
+`)
+	tt.templater.EXPECT().GenerateManifest(tt.ctx, tt.spec).Return(manifest, nil)
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.spec)).To(Equal(requeue()))
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: metallb.Namespace}, &corev1.Namespace{})).To(Succeed())
+	tt.expectCondition("False")
+}
+
+func TestReconcilerReconcileUpgrade(t *testing.T) {
+	tt := newReconcileTest(t)
+	c := fake.NewClientBuilder().WithObjects(controllerDeployment("public.ecr.aws/eks-anywhere/metallb/controller:v0.13.0", true)).Build()
+	tt.templater.EXPECT().GenerateManifest(tt.ctx, tt.spec).Return([]byte{}, nil)
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.spec)).To(Equal(requeue()))
+	tt.expectCondition("False")
+}
+
+func TestReconcilerReconcileErrorGeneratingManifest(t *testing.T) {
+	tt := newReconcileTest(t)
+	c := fake.NewClientBuilder().Build()
+	tt.templater.EXPECT().GenerateManifest(tt.ctx, tt.spec).Return(nil, errors.New("generating manifest"))
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("generating manifest")))
+}
+
+func TestReconcilerReconcileControllerNotReady(t *testing.T) {
+	tt := newReconcileTest(t)
+	c := fake.NewClientBuilder().WithObjects(controllerDeployment(controllerImage, false)).Build()
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.spec)).To(Equal(requeue()))
+	tt.expectCondition("False")
+}
+
+func TestReconcilerReconcileConfigure(t *testing.T) {
+	tt := newReconcileTest(t)
+	c := fake.NewClientBuilder().WithObjects(
+		controllerDeployment(controllerImage, true),
+		managedObject("metallb.io/v1beta1", "IPAddressPool", "old"),
+		managedObject("metallb.io/v1beta1", "BGPAdvertisement", "eksa-bgp"),
+		managedObject("metallb.io/v1beta2", "BGPPeer", "eksa-peer-0"),
+	).Build()
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.spec)).To(Equal(controller.Result{}))
+	tt.expectExists(c, "metallb.io/v1beta1", "IPAddressPool", "default", true)
+	tt.expectExists(c, "metallb.io/v1beta1", "L2Advertisement", "eksa-l2", true)
+	tt.expectExists(c, "metallb.io/v1beta1", "IPAddressPool", "old", false)
+	tt.expectExists(c, "metallb.io/v1beta1", "BGPAdvertisement", "eksa-bgp", false)
+	tt.expectExists(c, "metallb.io/v1beta2", "BGPPeer", "eksa-peer-0", false)
+	tt.expectCondition("True")
+}
+
+func TestReconcilerReconcileConfigureKeepsUnmanagedObjects(t *testing.T) {
+	tt := newReconcileTest(t)
+	unmanaged := managedObject("metallb.io/v1beta1", "IPAddressPool", "user-pool")
+	unmanaged.SetLabels(nil)
+	c := fake.NewClientBuilder().WithObjects(controllerDeployment(controllerImage, true), unmanaged).Build()
+
+	tt.Expect(tt.reconciler.Reconcile(tt.ctx, test.NewNullLogger(), c, tt.spec)).To(Equal(controller.Result{}))
+	tt.expectExists(c, "metallb.io/v1beta1", "IPAddressPool", "user-pool", true)
+	tt.expectCondition("True")
+}
@@ -0,0 +1,127 @@
+package metallb
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/helm"
+	"github.com/aws/eks-anywhere/pkg/semver"
+	"github.com/aws/eks-anywhere/pkg/templater"
+)
+
+//go:embed config.yaml
+var configTemplate string
+
+//go:embed namespace.yaml
+var namespaceManifest []byte
+
+const (
+	// Namespace is the namespace MetalLB is installed in.
+	Namespace = constants.MetallbNamespace
+
+	// ManagedLabel is set on the MetalLB configuration objects generated from the cluster spec.
+	// It allows to find and prune the objects that are not in the spec anymore.
+	ManagedLabel = "anywhere.eks.amazonaws.com/service-load-balancer"
+
+	// ControllerDeploymentName is the name of the MetalLB controller deployment.
+	ControllerDeploymentName = "metallb-controller"
+
+	l2AdvertisementName  = "eksa-l2"
+	bgpAdvertisementName = "eksa-bgp"
+)
+
+// HelmClientFactory provides a helm client for a cluster.
+type HelmClientFactory interface {
+	Get(ctx context.Context, clus *anywherev1.Cluster) (helm.Client, error)
+}
+
+// Templater generates the MetalLB manifests for a cluster.
+type Templater struct {
+	helmFactory HelmClientFactory
+}
+
+// NewTemplater returns a new Templater.
+func NewTemplater(helmFactory HelmClientFactory) *Templater {
+	return &Templater{
+		helmFactory: helmFactory,
+	}
+}
+
+// GenerateManifest generates the manifest with the MetalLB components, including its namespace and CRDs.
+func (t *Templater) GenerateManifest(ctx context.Context, spec *cluster.Spec) ([]byte, error) {
+	versionsBundle := spec.RootVersionsBundle()
+	if versionsBundle.MetalLB == nil || versionsBundle.MetalLB.HelmChart.URI == "" {
+		return nil, fmt.Errorf("the bundle for kubernetes version %s doesn't include MetalLB", spec.Cluster.Spec.KubernetesVersion)
+	}
+	chart := versionsBundle.MetalLB.HelmChart
+
+	kubeVersion, err := semver.New(versionsBundle.KubeDistro.Kubernetes.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubernetes version %v: %v", versionsBundle.KubeDistro.Kubernetes.Tag, err)
+	}
+
+	values := map[string]interface{}{
+		"controller": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": versionsBundle.MetalLB.Controller.Image(),
+				"tag":        versionsBundle.MetalLB.Controller.Tag(),
+			},
+		},
+		"speaker": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": versionsBundle.MetalLB.Speaker.Image(),
+				"tag":        versionsBundle.MetalLB.Speaker.Tag(),
+			},
+			// The native BGP implementation is enough for the BGP mode we expose, so we don't
+			// need to run the FRR sidecars.
+			"frr": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+	}
+
+	helm, err := t.helmFactory.Get(ctx, spec.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get helm client for cluster %s: %v", spec.Cluster.Name, err)
+	}
+
+	manifest, err := helm.Template(ctx, fmt.Sprintf("oci://%s", chart.Image()), chart.Tag(), Namespace, values, fmt.Sprintf("%d.%d", kubeVersion.Major, kubeVersion.Minor))
+	if err != nil {
+		return nil, fmt.Errorf("failed generating MetalLB manifest: %v", err)
+	}
+
+	return templater.AppendYamlResources(namespaceManifest, manifest), nil
+}
+
+// GenerateConfigManifest generates the MetalLB address pools and advertisements for the
+// cluster service load balancer configuration.
+func GenerateConfigManifest(spec *cluster.Spec) ([]byte, error) {
+	lb := spec.Cluster.Spec.ServiceLoadBalancer
+
+	pools := make([]string, 0, len(lb.AddressPools))
+	for _, p := range lb.AddressPools {
+		pools = append(pools, p.Name)
+	}
+
+	values := map[string]interface{}{
+		"namespace":            Namespace,
+		"managedLabel":         ManagedLabel,
+		"addressPools":         lb.AddressPools,
+		"poolNames":            pools,
+		"l2":                   lb.LoadBalancerMode() == anywherev1.ServiceLoadBalancerModeL2,
+		"interfaces":           lb.Interfaces,
+		"l2AdvertisementName":  l2AdvertisementName,
+		"bgpAdvertisementName": bgpAdvertisementName,
+	}
+
+	if lb.BGP != nil {
+		values["localASN"] = lb.BGP.LocalASN
+		values["peers"] = lb.BGP.Peers
+	}
+
+	return templater.Execute(configTemplate, values)
+}
@@ -0,0 +1,233 @@
+package metallb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	helmmocks "github.com/aws/eks-anywhere/pkg/helm/mocks"
+	"github.com/aws/eks-anywhere/pkg/networking/metallb"
+	"github.com/aws/eks-anywhere/pkg/networking/metallb/mocks"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+type templaterTest struct {
+	*WithT
+	ctx  context.Context
+	t    *metallb.Templater
+	hf   *mocks.MockHelmClientFactory
+	h    *helmmocks.MockClient
+	spec *cluster.Spec
+}
+
+func newTemplaterTest(t *testing.T) *templaterTest {
+	ctrl := gomock.NewController(t)
+	hf := mocks.NewMockHelmClientFactory(ctrl)
+	return &templaterTest{
+		WithT: NewWithT(t),
+		ctx:   context.Background(),
+		hf:    hf,
+		h:     helmmocks.NewMockClient(ctrl),
+		t:     metallb.NewTemplater(hf),
+		spec:  serviceLoadBalancerSpec(),
+	}
+}
+
+func serviceLoadBalancerSpec() *cluster.Spec {
+	return test.NewClusterSpec(func(s *cluster.Spec) {
+		s.Cluster.Spec.KubernetesVersion = "1.22"
+		s.VersionsBundles["1.22"] = test.VersionBundle()
+		s.VersionsBundles["1.22"].KubeDistro.Kubernetes.Tag = "v1.22.5-eks-1-22-9"
+		s.VersionsBundles["1.22"].MetalLB = &releasev1.MetalLBBundle{
+			Controller: releasev1.Image{URI: "public.ecr.aws/eks-anywhere/metallb/controller:v0.14.9-eks-a-1"},
+			Speaker:    releasev1.Image{URI: "public.ecr.aws/eks-anywhere/metallb/speaker:v0.14.9-eks-a-1"},
+			HelmChart:  releasev1.Image{URI: "public.ecr.aws/eks-anywhere/metallb/metallb:0.14.9-eks-a-1"},
+		}
+		s.Cluster.Spec.ServiceLoadBalancer = &v1alpha1.ServiceLoadBalancerConfiguration{
+			Mode: v1alpha1.ServiceLoadBalancerModeL2,
+			AddressPools: []v1alpha1.ServiceLoadBalancerAddressPool{
+				{Name: "default", Addresses: []string{"10.0.1.0/28", "10.0.2.10-10.0.2.20"}},
+				{Name: "internal", Addresses: []string{"10.0.3.0/28"}},
+			},
+			Interfaces: []string{"eth0"},
+		}
+	})
+}
+
+func TestTemplaterGenerateManifestSuccess(t *testing.T) {
+	tt := newTemplaterTest(t)
+	wantValues := map[string]interface{}{
+		"controller": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "public.ecr.aws/eks-anywhere/metallb/controller",
+				"tag":        "v0.14.9-eks-a-1",
+			},
+		},
+		"speaker": map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "public.ecr.aws/eks-anywhere/metallb/speaker",
+				"tag":        "v0.14.9-eks-a-1",
+			},
+			"frr": map[string]interface{}{
+				"enabled": false,
+			},
+		},
+	}
+
+	tt.hf.EXPECT().Get(tt.ctx, tt.spec.Cluster).Return(tt.h, nil)
+	tt.h.EXPECT().Template(tt.ctx, "oci://public.ecr.aws/eks-anywhere/metallb/metallb", "0.14.9-eks-a-1", "metallb-system", wantValues, "1.22").
+		Return([]byte("kind: Deployment"), nil)
+
+	manifest, err := tt.t.GenerateManifest(tt.ctx, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(string(manifest)).To(ContainSubstring("kind: Namespace"))
+	tt.Expect(string(manifest)).To(ContainSubstring("pod-security.kubernetes.io/enforce: privileged"))
+	tt.Expect(string(manifest)).To(ContainSubstring("---\nkind: Deployment"))
+}
+
+func TestTemplaterGenerateManifestNoChartInBundle(t *testing.T) {
+	tt := newTemplaterTest(t)
+	tt.spec.VersionsBundles["1.22"].MetalLB = nil
+
+	_, err := tt.t.GenerateManifest(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("the bundle for kubernetes version 1.22 doesn't include MetalLB")))
+}
+
+func TestTemplaterGenerateManifestHelmClientError(t *testing.T) {
+	tt := newTemplaterTest(t)
+	tt.hf.EXPECT().Get(tt.ctx, tt.spec.Cluster).Return(nil, errors.New("no helm"))
+
+	_, err := tt.t.GenerateManifest(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("failed to get helm client for cluster")))
+}
+
+func TestTemplaterGenerateManifestTemplateError(t *testing.T) {
+	tt := newTemplaterTest(t)
+	tt.hf.EXPECT().Get(tt.ctx, tt.spec.Cluster).Return(tt.h, nil)
+	tt.h.EXPECT().Template(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("pulling chart"))
+
+	_, err := tt.t.GenerateManifest(tt.ctx, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("failed generating MetalLB manifest: pulling chart")))
+}
+
+func TestGenerateConfigManifestL2(t *testing.T) {
+	g := NewWithT(t)
+	spec := serviceLoadBalancerSpec()
+
+	manifest, err := metallb.GenerateConfigManifest(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(manifest)).To(Equal(`
+---
+apiVersion: metallb.io/v1beta1
+kind: IPAddressPool
+metadata:
+  name: default
+  namespace: metallb-system
+  labels:
+    anywhere.eks.amazonaws.com/service-load-balancer: "true"
+spec:
+  addresses:
+  - 10.0.1.0/28
+  - 10.0.2.10-10.0.2.20
+---
+apiVersion: metallb.io/v1beta1
+kind: IPAddressPool
+metadata:
+  name: internal
+  namespace: metallb-system
+  labels:
+    anywhere.eks.amazonaws.com/service-load-balancer: "true"
+spec:
+  addresses:
+  - 10.0.3.0/28
+---
+apiVersion: metallb.io/v1beta1
+kind: L2Advertisement
+metadata:
+  name: eksa-l2
+  namespace: metallb-system
+  labels:
+    anywhere.eks.amazonaws.com/service-load-balancer: "true"
+spec:
+  ipAddressPools:
+  - default
+  - internal
+  interfaces:
+  - eth0
+`))
+}
+
+func TestGenerateConfigManifestBGP(t *testing.T) {
+	g := NewWithT(t)
+	spec := serviceLoadBalancerSpec()
+	spec.Cluster.Spec.ServiceLoadBalancer = &v1alpha1.ServiceLoadBalancerConfiguration{
+		Mode:         v1alpha1.ServiceLoadBalancerModeBGP,
+		AddressPools: []v1alpha1.ServiceLoadBalancerAddressPool{{Name: "default", Addresses: []string{"10.0.1.0/28"}}},
+		BGP: &v1alpha1.ServiceLoadBalancerBGPConfiguration{
+			LocalASN: 64500,
+			Peers: []v1alpha1.ServiceLoadBalancerBGPPeer{
+				{Address: "10.0.0.1", ASN: 64501, Port: 179},
+				{Address: "10.0.0.2", ASN: 64502},
+			},
+		},
+	}
+
+	manifest, err := metallb.GenerateConfigManifest(spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(manifest)).To(Equal(`
+---
+apiVersion: metallb.io/v1beta1
+kind: IPAddressPool
+metadata:
+  name: default
+  namespace: metallb-system
+  labels:
+    anywhere.eks.amazonaws.com/service-load-balancer: "true"
+spec:
+  addresses:
+  - 10.0.1.0/28
+---
+apiVersion: metallb.io/v1beta2
+kind: BGPPeer
+metadata:
+  name: eksa-peer-0
+  namespace: metallb-system
+  labels:
+    anywhere.eks.amazonaws.com/service-load-balancer: "true"
+spec:
+  myASN: 64500
+  peerASN: 64501
+  peerAddress: 10.0.0.1
+  peerPort: 179
+---
+apiVersion: metallb.io/v1beta2
+kind: BGPPeer
+metadata:
+  name: eksa-peer-1
+  namespace: metallb-system
+  labels:
+    anywhere.eks.amazonaws.com/service-load-balancer: "true"
+spec:
+  myASN: 64500
+  peerASN: 64502
+  peerAddress: 10.0.0.2
+---
+apiVersion: metallb.io/v1beta1
+kind: BGPAdvertisement
+metadata:
+  name: eksa-bgp
+  namespace: metallb-system
+  labels:
+    anywhere.eks.amazonaws.com/service-load-balancer: "true"
+spec:
+  ipAddressPools:
+  - default
+`))
+}
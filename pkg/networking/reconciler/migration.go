@@ -0,0 +1,412 @@
+package reconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
+)
+
+const (
+	// kindnetdName is the name of all the Kindnetd objects in the cluster.
+	kindnetdName = "kindnet"
+
+	// CiliumMigratedNodeLabel marks the nodes that have been moved from Kindnetd to Cilium.
+	// While a migration is in progress, the Cilium agent only runs on nodes with this label.
+	CiliumMigratedNodeLabel = "anywhere.eks.amazonaws.com/cilium-migrated"
+
+	// CNIMigrationCordonedAnnotation marks the nodes cordoned by the CNI migration. Nodes that
+	// were already unschedulable before being migrated are left cordoned.
+	CNIMigrationCordonedAnnotation = "anywhere.eks.amazonaws.com/cni-migration-cordoned"
+
+	ciliumAgentLabel      = "k8s-app"
+	controlPlaneNodeLabel = "node-role.kubernetes.io/control-plane"
+
+	cniMigrationRequeueTime = 10 * time.Second
+)
+
+// nodeGroup is a set of nodes migrated together, in order, before moving to the next group.
+type nodeGroup struct {
+	name  string
+	nodes []corev1.Node
+}
+
+// migrateFromKindnetd moves a cluster running Kindnetd to Cilium without recreating it.
+// Cilium is installed alongside Kindnetd but its agent is only scheduled on migrated nodes.
+// While both CNIs run, Cilium uses native routing over the node pod CIDR routes, like Kindnetd,
+// so pods on migrated and not yet migrated nodes can reach each other.
+// Nodes are then migrated one at a time, control plane first and then each worker node group:
+// the node is cordoned and drained, labeled so the Cilium agent replaces Kindnetd on it, and
+// uncordoned once Cilium is ready. When all nodes run Cilium, Kindnetd is removed.
+func (r *Reconciler) migrateFromKindnetd(ctx context.Context, log logr.Logger, c client.Client, spec *cluster.Spec, kindnetd *appsv1.DaemonSet) (controller.Result, error) {
+	log = log.WithValues("cniMigration", "kindnetd-to-cilium")
+
+	if err := r.ciliumReconciler.ApplyManifest(ctx, c, spec,
+		cilium.WithAgentNodeSelector(map[string]string{CiliumMigratedNodeLabel: "true"}),
+		cilium.WithNativeRouting(spec.Cluster.Spec.ClusterNetwork),
+	); err != nil {
+		return controller.Result{}, errors.Wrap(err, "installing Cilium for migrated nodes")
+	}
+
+	if err := excludeMigratedNodes(ctx, c, kindnetd); err != nil {
+		return controller.Result{}, err
+	}
+
+	groups, err := nodeGroups(ctx, c, spec)
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	total, migrated := 0, 0
+	for _, g := range groups {
+		for _, n := range g.nodes {
+			total++
+			if nodeMigrated(&n) {
+				migrated++
+			}
+		}
+	}
+
+	for _, g := range groups {
+		for i := range g.nodes {
+			node := &g.nodes[i]
+			if nodeMigrated(node) {
+				continue
+			}
+
+			log.Info("Migrating node to Cilium", "node", node.Name, "nodeGroup", g.name)
+			msg, err := migrateNode(ctx, log, c, node)
+			if err != nil {
+				return controller.Result{}, errors.Wrapf(err, "migrating node %s to Cilium", node.Name)
+			}
+
+			markCNIMigrationInProgress(spec.Cluster, fmt.Sprintf(
+				"Migrating %s node %s: %s (%d/%d nodes migrated)", g.name, node.Name, msg, migrated, total,
+			))
+
+			return controller.Result{Result: &ctrl.Result{RequeueAfter: cniMigrationRequeueTime}}, nil
+		}
+	}
+
+	log.Info("All nodes migrated to Cilium, removing Kindnetd")
+	// All nodes run Cilium at this point, so the agent can be scheduled everywhere
+	// before Kindnetd is removed.
+	if err := r.ciliumReconciler.ApplyManifest(ctx, c, spec); err != nil {
+		return controller.Result{}, errors.Wrap(err, "applying Cilium manifest for all nodes")
+	}
+
+	if err := deleteKindnetd(ctx, c); err != nil {
+		return controller.Result{}, err
+	}
+
+	v1beta1conditions.MarkTrue(spec.Cluster, anywherev1.CNIMigratedCondition)
+
+	return r.ciliumReconciler.Reconcile(ctx, log, c, spec)
+}
+
+// migrateNode moves the node forward one step in the migration and returns a message describing
+// what the migration is waiting for.
+func migrateNode(ctx context.Context, log logr.Logger, c client.Client, node *corev1.Node) (string, error) {
+	if node.Labels[CiliumMigratedNodeLabel] != "true" {
+		drained, err := drainNode(ctx, log, c, node)
+		if err != nil {
+			return "", err
+		}
+		if !drained {
+			return "waiting for node to be drained", nil
+		}
+
+		patch := client.MergeFrom(node.DeepCopy())
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		node.Labels[CiliumMigratedNodeLabel] = "true"
+		if err := c.Patch(ctx, node, patch); err != nil {
+			return "", errors.Wrap(err, "labeling node")
+		}
+
+		return "waiting for Cilium agent", nil
+	}
+
+	agent, err := ciliumAgentPod(ctx, c, node.Name)
+	if err != nil {
+		return "", err
+	}
+	if agent == nil || !podReady(agent) {
+		return "waiting for Cilium agent", nil
+	}
+
+	// DaemonSet pods are not drained and still have a Kindnetd address, restart
+	// them so they get one from Cilium.
+	pods, err := podsOnNode(ctx, c, node.Name)
+	if err != nil {
+		return "", err
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if !isDaemonSetPod(pod) || pod.Spec.HostNetwork || pod.DeletionTimestamp != nil ||
+			!pod.CreationTimestamp.Before(&agent.CreationTimestamp) {
+			continue
+		}
+		log.Info("Restarting DaemonSet pod to use Cilium networking", "pod", client.ObjectKeyFromObject(pod))
+		if err := c.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return "", errors.Wrapf(err, "deleting pod %s", client.ObjectKeyFromObject(pod))
+		}
+	}
+
+	if _, ok := node.Annotations[CNIMigrationCordonedAnnotation]; ok {
+		patch := client.MergeFrom(node.DeepCopy())
+		node.Spec.Unschedulable = false
+		delete(node.Annotations, CNIMigrationCordonedAnnotation)
+		if err := c.Patch(ctx, node, patch); err != nil {
+			return "", errors.Wrap(err, "uncordoning node")
+		}
+	}
+
+	return "node migrated", nil
+}
+
+// drainNode cordons the node and evicts its pods. It returns true once all the evictable pods are gone.
+func drainNode(ctx context.Context, log logr.Logger, c client.Client, node *corev1.Node) (bool, error) {
+	if !node.Spec.Unschedulable {
+		patch := client.MergeFrom(node.DeepCopy())
+		node.Spec.Unschedulable = true
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+		node.Annotations[CNIMigrationCordonedAnnotation] = "true"
+		if err := c.Patch(ctx, node, patch); err != nil {
+			return false, errors.Wrap(err, "cordoning node")
+		}
+	}
+
+	pods, err := podsOnNode(ctx, c, node.Name)
+	if err != nil {
+		return false, err
+	}
+
+	remaining := 0
+	for i := range pods {
+		pod := &pods[i]
+		if !evictable(pod) {
+			continue
+		}
+		remaining++
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		err := c.SubResource("eviction").Create(ctx, pod, eviction)
+		switch {
+		case err == nil, apierrors.IsNotFound(err):
+		case apierrors.IsTooManyRequests(err):
+			log.Info("Pod can't be evicted yet because of its disruption budget", "pod", client.ObjectKeyFromObject(pod))
+		default:
+			return false, errors.Wrapf(err, "evicting pod %s", client.ObjectKeyFromObject(pod))
+		}
+	}
+
+	return remaining == 0, nil
+}
+
+// excludeMigratedNodes stops scheduling Kindnetd on nodes that have been migrated to Cilium.
+func excludeMigratedNodes(ctx context.Context, c client.Client, kindnetd *appsv1.DaemonSet) error {
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      CiliumMigratedNodeLabel,
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{"true"},
+	}
+
+	affinity := kindnetd.Spec.Template.Spec.Affinity
+	if affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			for _, e := range term.MatchExpressions {
+				if e.Key == requirement.Key && e.Operator == requirement.Operator {
+					return nil
+				}
+			}
+		}
+	}
+
+	patch := client.MergeFrom(kindnetd.DeepCopy())
+	kindnetd.Spec.Template.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{requirement}},
+				},
+			},
+		},
+	}
+	if err := c.Patch(ctx, kindnetd, patch); err != nil {
+		return errors.Wrap(err, "excluding migrated nodes from Kindnetd")
+	}
+
+	return nil
+}
+
+// nodeGroups returns the cluster nodes grouped by node group, in migration order: control plane first,
+// then the worker node groups as defined in the spec. Nodes not belonging to any known group go last.
+func nodeGroups(ctx context.Context, c client.Client, spec *cluster.Spec) ([]nodeGroup, error) {
+	nodeList := &corev1.NodeList{}
+	if err := c.List(ctx, nodeList); err != nil {
+		return nil, errors.Wrap(err, "listing nodes")
+	}
+	sort.Slice(nodeList.Items, func(i, j int) bool { return nodeList.Items[i].Name < nodeList.Items[j].Name })
+
+	workerGroups := spec.Cluster.Spec.WorkerNodeGroupConfigurations
+	groups := make([]nodeGroup, len(workerGroups)+2)
+	groups[0].name = "control plane"
+	for i, w := range workerGroups {
+		groups[i+1].name = fmt.Sprintf("worker node group %s", w.Name)
+	}
+	groups[len(groups)-1].name = "unmanaged"
+
+	for _, n := range nodeList.Items {
+		i := nodeGroupIndex(&n, spec.Cluster)
+		groups[i].nodes = append(groups[i].nodes, n)
+	}
+
+	return groups, nil
+}
+
+func nodeGroupIndex(node *corev1.Node, c *anywherev1.Cluster) int {
+	if _, ok := node.Labels[controlPlaneNodeLabel]; ok {
+		return 0
+	}
+
+	// Worker nodes are annotated with the name of their MachineSet, which is prefixed by the
+	// MachineDeployment name.
+	owner := node.Annotations[clusterv1.OwnerNameAnnotation]
+	for i, w := range c.Spec.WorkerNodeGroupConfigurations {
+		if strings.HasPrefix(owner, clusterapi.MachineDeploymentName(c, w)+"-") {
+			return i + 1
+		}
+	}
+
+	return len(c.Spec.WorkerNodeGroupConfigurations) + 1
+}
+
+func deleteKindnetd(ctx context.Context, c client.Client) error {
+	objs := []client.Object{
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: kindnetdName, Namespace: constants.KubeSystemNamespace}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: kindnetdName, Namespace: constants.KubeSystemNamespace}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: kindnetdName}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: kindnetdName}},
+	}
+	for _, o := range objs {
+		if err := c.Delete(ctx, o); client.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "deleting Kindnetd %T", o)
+		}
+	}
+
+	return nil
+}
+
+func getKindnetd(ctx context.Context, c client.Client) (*appsv1.DaemonSet, error) {
+	ds := &appsv1.DaemonSet{}
+	err := c.Get(ctx, types.NamespacedName{Name: kindnetdName, Namespace: constants.KubeSystemNamespace}, ds)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "getting Kindnetd DaemonSet")
+	}
+
+	return ds, nil
+}
+
+func ciliumAgentPod(ctx context.Context, c client.Client, nodeName string) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods,
+		client.InNamespace(constants.KubeSystemNamespace),
+		client.MatchingLabels{ciliumAgentLabel: cilium.DaemonSetName},
+	); err != nil {
+		return nil, errors.Wrap(err, "listing Cilium agent pods")
+	}
+
+	for i := range pods.Items {
+		if pods.Items[i].Spec.NodeName == nodeName {
+			return &pods.Items[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func podsOnNode(ctx context.Context, c client.Client, nodeName string) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods); err != nil {
+		return nil, errors.Wrap(err, "listing pods")
+	}
+
+	onNode := make([]corev1.Pod, 0, len(pods.Items))
+	for _, p := range pods.Items {
+		if p.Spec.NodeName == nodeName {
+			onNode = append(onNode, p)
+		}
+	}
+
+	return onNode, nil
+}
+
+// evictable returns true for pods that need to be drained from a node before migrating it:
+// pods using the pod network that are not managed by a DaemonSet nor mirror pods.
+func evictable(pod *corev1.Pod) bool {
+	if pod.Spec.HostNetwork || isDaemonSetPod(pod) {
+		return false
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+
+	return pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+func isDaemonSetPod(pod *corev1.Pod) bool {
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && owner.Kind == "DaemonSet"
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func nodeMigrated(node *corev1.Node) bool {
+	_, cordoned := node.Annotations[CNIMigrationCordonedAnnotation]
+	return node.Labels[CiliumMigratedNodeLabel] == "true" && !cordoned
+}
+
+func markCNIMigrationInProgress(cluster *anywherev1.Cluster, message string) {
+	v1beta1conditions.MarkFalse(cluster, anywherev1.CNIMigratedCondition, anywherev1.CNIMigrationInProgressReason, clusterv1.ConditionSeverityInfo, "%s", message)
+	v1beta1conditions.MarkFalse(cluster, anywherev1.DefaultCNIConfiguredCondition, anywherev1.CNIMigrationInProgressReason, clusterv1.ConditionSeverityInfo, "Migrating from Kindnetd to Cilium")
+}
@@ -10,6 +10,7 @@ import (
 
 	cluster "github.com/aws/eks-anywhere/pkg/cluster"
 	controller "github.com/aws/eks-anywhere/pkg/controller"
+	cilium "github.com/aws/eks-anywhere/pkg/networking/cilium"
 	logr "github.com/go-logr/logr"
 	gomock "github.com/golang/mock/gomock"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +39,25 @@ func (m *MockCiliumReconciler) EXPECT() *MockCiliumReconcilerMockRecorder {
 	return m.recorder
 }
 
+// ApplyManifest mocks base method.
+func (m *MockCiliumReconciler) ApplyManifest(ctx context.Context, client client.Client, spec *cluster.Spec, opts ...cilium.ManifestOpt) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, client, spec}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ApplyManifest", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyManifest indicates an expected call of ApplyManifest.
+func (mr *MockCiliumReconcilerMockRecorder) ApplyManifest(ctx, client, spec interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, client, spec}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyManifest", reflect.TypeOf((*MockCiliumReconciler)(nil).ApplyManifest), varargs...)
+}
+
 // Reconcile mocks base method.
 func (m *MockCiliumReconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
 	m.ctrl.T.Helper()
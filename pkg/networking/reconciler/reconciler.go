@@ -9,10 +9,12 @@ import (
 
 	"github.com/aws/eks-anywhere/pkg/cluster"
 	"github.com/aws/eks-anywhere/pkg/controller"
+	"github.com/aws/eks-anywhere/pkg/networking/cilium"
 )
 
 type CiliumReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
+	ApplyManifest(ctx context.Context, client client.Client, spec *cluster.Spec, opts ...cilium.ManifestOpt) error
 }
 
 type Reconciler struct {
@@ -28,11 +30,19 @@ func New(ciliumReconciler CiliumReconciler) *Reconciler {
 // Reconcile takes the specified CNI in a cluster to the desired state defined in a cluster Spec
 // It uses a controller.Result to indicate when requeues are needed
 // Intended to be used in a kubernetes controller
-// Only Cilium CNI is supported for now.
+// Only Cilium CNI is supported for now. Clusters still running Kindnetd are migrated to Cilium.
 func (r *Reconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
-	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium != nil {
-		return r.ciliumReconciler.Reconcile(ctx, logger, client, spec)
-	} else {
+	if spec.Cluster.Spec.ClusterNetwork.CNIConfig.Cilium == nil {
 		return controller.Result{}, errors.New("unsupported CNI, only Cilium is supported at this time")
 	}
+
+	kindnetd, err := getKindnetd(ctx, client)
+	if err != nil {
+		return controller.Result{}, err
+	}
+	if kindnetd != nil {
+		return r.migrateFromKindnetd(ctx, logger, client, spec, kindnetd)
+	}
+
+	return r.ciliumReconciler.Reconcile(ctx, logger, client, spec)
 }
@@ -2,10 +2,20 @@ package reconciler_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	v1beta1conditions "sigs.k8s.io/cluster-api/util/deprecated/v1beta1/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/aws/eks-anywhere/internal/test"
@@ -53,3 +63,204 @@ func TestReconcilerReconcileUnsupportedCNI(t *testing.T) {
 	_, err := r.Reconcile(ctx, logger, client, spec)
 	g.Expect(err).To(MatchError(ContainSubstring("unsupported CNI, only Cilium is supported at this time")))
 }
+
+type migrationTest struct {
+	*WithT
+	ctx              context.Context
+	logger           logr.Logger
+	spec             *cluster.Spec
+	ciliumReconciler *mocks.MockCiliumReconciler
+	reconciler       *reconciler.Reconciler
+}
+
+func newMigrationTest(t *testing.T) *migrationTest {
+	ctrl := gomock.NewController(t)
+	ciliumReconciler := mocks.NewMockCiliumReconciler(ctrl)
+	return &migrationTest{
+		WithT:  NewWithT(t),
+		ctx:    context.Background(),
+		logger: test.NewNullLogger(),
+		spec: test.NewClusterSpec(func(s *cluster.Spec) {
+			s.Cluster.Name = "my-cluster"
+			s.Cluster.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+				Cilium: &v1alpha1.CiliumConfig{},
+			}
+			s.Cluster.Spec.WorkerNodeGroupConfigurations = []v1alpha1.WorkerNodeGroupConfiguration{{Name: "md-0"}}
+		}),
+		ciliumReconciler: ciliumReconciler,
+		reconciler:       reconciler.New(ciliumReconciler),
+	}
+}
+
+func kindnetdDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "kindnet", Namespace: "kube-system"},
+	}
+}
+
+func controlPlaneNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+		},
+	}
+}
+
+func workerNode(name, machineSet string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{clusterv1.OwnerNameAnnotation: machineSet},
+		},
+	}
+}
+
+func migratedNode(n *corev1.Node) *corev1.Node {
+	if n.Labels == nil {
+		n.Labels = map[string]string{}
+	}
+	n.Labels[reconciler.CiliumMigratedNodeLabel] = "true"
+	return n
+}
+
+func podOnNode(name, node, ownerKind string, created time.Time) *corev1.Pod {
+	controller := true
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: ownerKind, Name: "owner", Controller: &controller},
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+	}
+}
+
+func ciliumAgent(node string, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "cilium-" + node,
+			Namespace:         "kube-system",
+			Labels:            map[string]string{"k8s-app": "cilium"},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: corev1.PodSpec{NodeName: node, HostNetwork: true},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func (tt *migrationTest) getNode(c client.Client, name string) *corev1.Node {
+	n := &corev1.Node{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: name}, n)).To(Succeed())
+	return n
+}
+
+func TestReconcilerReconcileMigrationDrainsControlPlaneFirst(t *testing.T) {
+	tt := newMigrationTest(t)
+	now := time.Now()
+	c := fake.NewClientBuilder().WithObjects(
+		kindnetdDaemonSet(),
+		workerNode("a-worker", "my-cluster-md-0-abcde"),
+		controlPlaneNode("z-cp"),
+		podOnNode("app", "z-cp", "ReplicaSet", now),
+	).Build()
+	tt.ciliumReconciler.EXPECT().ApplyManifest(tt.ctx, c, tt.spec, gomock.Any(), gomock.Any())
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, tt.logger, c, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result.Return()).To(BeTrue())
+
+	cp := tt.getNode(c, "z-cp")
+	tt.Expect(cp.Spec.Unschedulable).To(BeTrue())
+	tt.Expect(cp.Annotations).To(HaveKey(reconciler.CNIMigrationCordonedAnnotation))
+	tt.Expect(cp.Labels).NotTo(HaveKey(reconciler.CiliumMigratedNodeLabel))
+	tt.Expect(tt.getNode(c, "a-worker").Spec.Unschedulable).To(BeFalse())
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "app", Namespace: "default"}, &corev1.Pod{})).To(MatchError(ContainSubstring("not found")))
+
+	ds := &appsv1.DaemonSet{}
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "kindnet", Namespace: "kube-system"}, ds)).To(Succeed())
+	tt.Expect(ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions[0].Key).To(
+		Equal(reconciler.CiliumMigratedNodeLabel),
+	)
+
+	condition := v1beta1conditions.Get(tt.spec.Cluster, v1alpha1.CNIMigratedCondition)
+	tt.Expect(condition).NotTo(BeNil())
+	tt.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+	tt.Expect(condition.Reason).To(Equal(v1alpha1.CNIMigrationInProgressReason))
+	tt.Expect(condition.Message).To(Equal("Migrating control plane node z-cp: waiting for node to be drained (0/2 nodes migrated)"))
+	tt.Expect(v1beta1conditions.IsFalse(tt.spec.Cluster, v1alpha1.DefaultCNIConfiguredCondition)).To(BeTrue())
+
+	// Once drained, the node is labeled so the Cilium agent is scheduled on it.
+	tt.ciliumReconciler.EXPECT().ApplyManifest(tt.ctx, c, tt.spec, gomock.Any(), gomock.Any())
+	_, err = tt.reconciler.Reconcile(tt.ctx, tt.logger, c, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(tt.getNode(c, "z-cp").Labels).To(HaveKeyWithValue(reconciler.CiliumMigratedNodeLabel, "true"))
+	tt.Expect(v1beta1conditions.GetMessage(tt.spec.Cluster, v1alpha1.CNIMigratedCondition)).To(ContainSubstring("waiting for Cilium agent"))
+}
+
+func TestReconcilerReconcileMigrationUncordonsNodeWhenCiliumReady(t *testing.T) {
+	tt := newMigrationTest(t)
+	now := time.Now()
+	cp := migratedNode(controlPlaneNode("cp"))
+	cp.Spec.Unschedulable = true
+	cp.Annotations = map[string]string{reconciler.CNIMigrationCordonedAnnotation: "true"}
+	c := fake.NewClientBuilder().WithObjects(
+		kindnetdDaemonSet(),
+		cp,
+		workerNode("worker", "my-cluster-md-0-abcde"),
+		ciliumAgent("cp", now),
+		podOnNode("old-ds-pod", "cp", "DaemonSet", now.Add(-time.Hour)),
+		podOnNode("new-ds-pod", "cp", "DaemonSet", now.Add(time.Hour)),
+	).Build()
+	tt.ciliumReconciler.EXPECT().ApplyManifest(tt.ctx, c, tt.spec, gomock.Any(), gomock.Any())
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, tt.logger, c, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+
+	got := tt.getNode(c, "cp")
+	tt.Expect(got.Spec.Unschedulable).To(BeFalse())
+	tt.Expect(got.Annotations).NotTo(HaveKey(reconciler.CNIMigrationCordonedAnnotation))
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "old-ds-pod", Namespace: "default"}, &corev1.Pod{})).To(MatchError(ContainSubstring("not found")))
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "new-ds-pod", Namespace: "default"}, &corev1.Pod{})).To(Succeed())
+}
+
+func TestReconcilerReconcileMigrationComplete(t *testing.T) {
+	tt := newMigrationTest(t)
+	c := fake.NewClientBuilder().WithObjects(
+		kindnetdDaemonSet(),
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "kindnet", Namespace: "kube-system"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "kindnet"}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "kindnet"}},
+		migratedNode(controlPlaneNode("cp")),
+		migratedNode(workerNode("worker", "my-cluster-md-0-abcde")),
+	).Build()
+	gomock.InOrder(
+		tt.ciliumReconciler.EXPECT().ApplyManifest(tt.ctx, c, tt.spec, gomock.Any(), gomock.Any()),
+		tt.ciliumReconciler.EXPECT().ApplyManifest(tt.ctx, c, tt.spec),
+		tt.ciliumReconciler.EXPECT().Reconcile(tt.ctx, gomock.Any(), c, tt.spec),
+	)
+
+	result, err := tt.reconciler.Reconcile(tt.ctx, tt.logger, c, tt.spec)
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "kindnet", Namespace: "kube-system"}, &appsv1.DaemonSet{})).To(MatchError(ContainSubstring("not found")))
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "kindnet", Namespace: "kube-system"}, &corev1.ServiceAccount{})).To(MatchError(ContainSubstring("not found")))
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "kindnet"}, &rbacv1.ClusterRole{})).To(MatchError(ContainSubstring("not found")))
+	tt.Expect(c.Get(tt.ctx, client.ObjectKey{Name: "kindnet"}, &rbacv1.ClusterRoleBinding{})).To(MatchError(ContainSubstring("not found")))
+	tt.Expect(v1beta1conditions.IsTrue(tt.spec.Cluster, v1alpha1.CNIMigratedCondition)).To(BeTrue())
+}
+
+func TestReconcilerReconcileMigrationApplyManifestError(t *testing.T) {
+	tt := newMigrationTest(t)
+	c := fake.NewClientBuilder().WithObjects(kindnetdDaemonSet()).Build()
+	tt.ciliumReconciler.EXPECT().ApplyManifest(tt.ctx, c, tt.spec, gomock.Any(), gomock.Any()).Return(errors.New("generating manifest"))
+
+	_, err := tt.reconciler.Reconcile(tt.ctx, tt.logger, c, tt.spec)
+	tt.Expect(err).To(MatchError(ContainSubstring("installing Cilium for migrated nodes: generating manifest")))
+}
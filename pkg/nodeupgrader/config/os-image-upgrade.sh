@@ -0,0 +1,84 @@
+#!/bin/sh
+# Upgrades the OS image of the node in place. It runs in the host namespaces and expects an A/B
+# disk layout: two root partitions with the GPT names EKSA_ROOT_A and EKSA_ROOT_B, a bootloader
+# that boots the partition named by the eksa_root variable of the GRUB environment block and
+# an fstab that mounts / by partition name.
+#
+# The root partition of the new image is written to the inactive partition, the node state is
+# copied over so the node rejoins the cluster as the same node, and the node is rebooted into
+# the new partition. Once the node runs the requested image the script is a no-op, so the
+# upgrader pod can rerun all its containers after the reboot.
+set -eu
+
+image_url="$1"
+marker=/etc/eksa/os-image-url
+grubenv=/boot/efi/EFI/BOOT/grubenv
+work=/var/tmp/eksa-os-image-upgrade
+new_root=/mnt/eksa-os-image-upgrade
+
+if [ -f "$marker" ] && [ "$(cat "$marker")" = "$image_url" ]; then
+	echo "Node is already running OS image $image_url"
+	exit 0
+fi
+
+root_a=$(blkid -t PARTLABEL=EKSA_ROOT_A -o device | head -n 1)
+root_b=$(blkid -t PARTLABEL=EKSA_ROOT_B -o device | head -n 1)
+if [ -z "$root_a" ] || [ -z "$root_b" ]; then
+	echo "Node has no EKSA_ROOT_A and EKSA_ROOT_B partitions, in place OS image upgrades need an A/B OS image" >&2
+	exit 1
+fi
+
+if [ "$(findmnt -n -o SOURCE /)" = "$root_a" ]; then
+	target="$root_b"
+	target_label=EKSA_ROOT_B
+else
+	target="$root_a"
+	target_label=EKSA_ROOT_A
+fi
+
+cleanup() {
+	umount "$new_root" 2>/dev/null || true
+	[ -n "${loop:-}" ] && losetup -d "$loop" 2>/dev/null || true
+	rm -rf "$work"
+}
+trap cleanup EXIT
+
+echo "Writing OS image $image_url to $target"
+mkdir -p "$work" "$new_root"
+curl -fsSL --retry 5 "$image_url" | gunzip > "$work/image.raw"
+loop=$(losetup --show -f -P "$work/image.raw")
+image_root=$(blkid -t PARTLABEL=EKSA_ROOT_A -o device "$loop"p* | head -n 1)
+if [ -z "$image_root" ]; then
+	echo "OS image $image_url has no EKSA_ROOT_A partition" >&2
+	exit 1
+fi
+dd if="$image_root" of="$target" bs=4M conv=fsync status=none
+losetup -d "$loop"
+loop=""
+
+echo "Copying node state to $target"
+# Stop kubelet and etcd so their state is consistent when copied. The node reboots right after.
+systemctl stop kubelet
+for id in $(crictl ps -q --name '^etcd$'); do
+	crictl stop "$id"
+done
+
+mount "$target" "$new_root"
+sed -i "s/PARTLABEL=EKSA_ROOT_A/PARTLABEL=$target_label/" "$new_root/etc/fstab"
+for path in /etc/kubernetes /var/lib/kubelet /var/lib/etcd /etc/hostname /etc/hosts /etc/machine-id /etc/netplan /etc/sysconfig/network-scripts /var/lib/cloud; do
+	if [ -e "$path" ]; then
+		mkdir -p "$new_root$(dirname "$path")"
+		rm -rf "$new_root$path"
+		cp -a "$path" "$new_root$path"
+	fi
+done
+mkdir -p "$new_root$(dirname "$marker")"
+echo "$image_url" > "$new_root$marker"
+sync
+umount "$new_root"
+
+editenv=grub-editenv
+command -v "$editenv" >/dev/null || editenv=grub2-editenv
+"$editenv" "$grubenv" set eksa_root="$target_label"
+echo "Rebooting into OS image $image_url"
+systemctl reboot
@@ -1,6 +1,7 @@
 package nodeupgrader
 
 import (
+	_ "embed"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -10,6 +11,9 @@ import (
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
+//go:embed config/os-image-upgrade.sh
+var osImageUpgradeScript string
+
 const (
 	upgradeBin = "/foo/eksa-upgrades/tools/upgrader"
 
@@ -28,6 +32,9 @@ const (
 	// KubeletUpgradeContainerName holds the name of the kubelet/kubectl upgrader container.
 	KubeletUpgradeContainerName = "kubelet-kubectl-upgrader"
 
+	// OSImageUpgraderContainerName holds the name of the OS image upgrader container.
+	OSImageUpgraderContainerName = "os-image-upgrader"
+
 	// PostUpgradeContainerName holds the name of the post upgrade cleanup/status report container.
 	PostUpgradeContainerName = "post-upgrade-status"
 )
@@ -58,6 +65,18 @@ func UpgradeWorkerPod(nodeName, image string) *corev1.Pod {
 	return p
 }
 
+// WithOSImageUpgrade adds a container to the upgrader pod that upgrades the node to the OS image from osImageURL
+// once the Kubernetes components have been upgraded. The container runs a script in the host namespaces that writes
+// the root partition of the image to the inactive partition of an A/B disk layout, copies the kubelet, Kubernetes
+// and etcd state over and reboots the node into it. The script is a no-op once the node runs that image, so the pod
+// can safely rerun its init containers after the reboot.
+func WithOSImageUpgrade(pod *corev1.Pod, image, osImageURL string) *corev1.Pod {
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers,
+		nsenterContainer(image, OSImageUpgraderContainerName, "/bin/sh", "-c", osImageUpgradeScript, OSImageUpgraderContainerName, osImageURL),
+	)
+	return pod
+}
+
 func upgraderPod(nodeName, image string, isCP bool) *corev1.Pod {
 	volumes := []corev1.Volume{hostComponentsVolume()}
 	if isCP {
@@ -15,6 +15,7 @@ const (
 	upgraderImage     = "public.ecr.aws/eks-anywhere/node-upgrader:latest"
 	kubernetesVersion = "v1.28.3-eks-1-28-9"
 	etcdVersion       = "v3.5.9-eks-1-28-9"
+	osImageURL        = "http://tinkerbell-example:8080/ubuntu-2204-kube-1-28.gz"
 )
 
 func TestUpgradeFirstControlPlanePod(t *testing.T) {
@@ -46,3 +47,13 @@ func TestUpgradeWorkerPod(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	test.AssertContentToFile(t, string(data), "testdata/expected_worker_upgrader_pod.yaml")
 }
+
+func TestWithOSImageUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	pod := nodeupgrader.WithOSImageUpgrade(nodeupgrader.UpgradeWorkerPod(nodeName, upgraderImage), upgraderImage, osImageURL)
+	g.Expect(pod).ToNot(BeNil())
+
+	data, err := yaml.Marshal(pod)
+	g.Expect(err).ToNot(HaveOccurred())
+	test.AssertContentToFile(t, string(data), "testdata/expected_worker_os_image_upgrader_pod.yaml")
+}
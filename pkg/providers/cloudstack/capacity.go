@@ -0,0 +1,326 @@
+package cloudstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/logger"
+)
+
+const (
+	bytesPerMiB = 1 << 20
+
+	affinityGroupTypeHostAffinity     = "host affinity"
+	affinityGroupTypeHostAntiAffinity = "host anti-affinity"
+
+	machineConfigAffinityPro  = "pro"
+	machineConfigAffinityAnti = "anti"
+)
+
+// affinityRule constrains the hosts the machines sharing the rule key can be placed on.
+// Machines sharing an anti-affinity rule must be placed on different hosts. Machines sharing
+// an affinity rule must be placed on the same host.
+type affinityRule struct {
+	key  string
+	anti bool
+}
+
+func (r affinityRule) String() string {
+	if r.anti {
+		return fmt.Sprintf("host anti-affinity %s", r.key)
+	}
+	return fmt.Sprintf("host affinity %s", r.key)
+}
+
+// capacityMachineGroup is a set of machines sharing a machine config and affinity rules.
+type capacityMachineGroup struct {
+	name          string
+	machineConfig *anywherev1.CloudStackMachineConfig
+	machines      int
+	rules         []affinityRule
+}
+
+// capacityHost tracks the resources requested from a host by the placed machines.
+type capacityHost struct {
+	executables.CloudStackHost
+	zone            string
+	requestedMHz    int64
+	requestedMemory int64
+	placements      map[string]int
+}
+
+func (h *capacityHost) freeMHz() int64 {
+	return h.CPUMHz - h.CPUAllocatedMHz - h.requestedMHz
+}
+
+func (h *capacityHost) freeMemory() int64 {
+	return h.MemoryBytes - h.MemoryAllocatedBytes - h.requestedMemory
+}
+
+// CapacityShortfall is the number of machines of a machine set that can't be placed on any host.
+type CapacityShortfall struct {
+	Group     string
+	Machines  int
+	CPUMHz    int64
+	MemoryMiB int64
+	Rules     []string
+}
+
+func (s CapacityShortfall) String() string {
+	msg := fmt.Sprintf("%d %s machine(s) requiring %d MHz cpu and %d MiB memory each can't be placed on any host",
+		s.Machines, s.Group, s.CPUMHz, s.MemoryMiB)
+	if len(s.Rules) > 0 {
+		msg += fmt.Sprintf(" satisfying [%s]", strings.Join(s.Rules, ", "))
+	}
+	return msg
+}
+
+// ValidateClusterCapacity simulates the placement of the control plane, etcd and worker machines
+// of the cluster, including the machines created by rolling upgrades, on the enabled hosts of the
+// availability zones. Placement honors the cpu and memory available on each host, the
+// affinity groups referenced by affinityGroupIds and the affinity groups created for the
+// affinity field of the machine configs. It fails with a report of the machines that can't be
+// placed instead of leaving them pending in CloudStack. Listing hosts is only available to root
+// admin accounts, so the validation is skipped with a warning for other accounts.
+func (v *Validator) ValidateClusterCapacity(ctx context.Context, clusterSpec *cluster.Spec) error {
+	return v.validateCapacity(ctx, clusterSpec, nil)
+}
+
+// ValidateUpgradeClusterCapacity simulates the placement of the machines an upgrade from currentSpec
+// to clusterSpec adds to the cluster, like ValidateClusterCapacity. The machines already running in
+// the cluster are accounted for in the resources the hosts report as allocated, so each machine
+// group only requests the machines added by scaling it up plus its rolling upgrade max surge.
+func (v *Validator) ValidateUpgradeClusterCapacity(ctx context.Context, clusterSpec, currentSpec *cluster.Spec) error {
+	return v.validateCapacity(ctx, clusterSpec, currentSpec)
+}
+
+func (v *Validator) validateCapacity(ctx context.Context, clusterSpec, currentSpec *cluster.Spec) error {
+	localAvailabilityZones, err := generateLocalAvailabilityZones(ctx, clusterSpec.CloudStackDatacenter)
+	if err != nil {
+		return err
+	}
+
+	groups, err := v.capacityMachineGroups(ctx, clusterSpec, currentSpec, localAvailabilityZones[0])
+	if err != nil {
+		return err
+	}
+
+	zoneHosts := make([][]*capacityHost, 0, len(localAvailabilityZones))
+	zoneIds := make([]string, 0, len(localAvailabilityZones))
+	for _, az := range localAvailabilityZones {
+		zoneId, err := v.cmk.ValidateZoneAndGetId(ctx, az.CredentialsRef, az.CloudStackAvailabilityZone.Zone)
+		if err != nil {
+			return err
+		}
+		zoneIds = append(zoneIds, zoneId)
+
+		hosts, err := v.cmk.ListHosts(ctx, az.CredentialsRef, zoneId)
+		if errors.Is(err, executables.ErrCloudStackAPINotPermitted) {
+			logger.Info("Warning: Skipping host capacity validation, the CloudStack account isn't allowed to list hosts", "availabilityZone", az.Name)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("listing hosts in availability zone %s: %v", az.Name, err)
+		}
+
+		capacityHosts := make([]*capacityHost, 0, len(hosts))
+		for _, h := range hosts {
+			capacityHosts = append(capacityHosts, &capacityHost{CloudStackHost: h, zone: az.Name, placements: map[string]int{}})
+		}
+		zoneHosts = append(zoneHosts, capacityHosts)
+	}
+
+	antiAffinityHosts := map[string]map[string]bool{}
+	affinityHost := map[string]string{}
+	offerings := map[string]executables.CloudStackServiceOffering{}
+	var shortfalls []CapacityShortfall
+
+	for _, g := range groups {
+		shortfall := CapacityShortfall{Group: g.name}
+		for _, r := range g.rules {
+			shortfall.Rules = append(shortfall.Rules, r.String())
+		}
+
+		for i := 0; i < g.machines; i++ {
+			var target *capacityHost
+			// Machines are spread across availability zones like failure domains, falling
+			// back to other zones when the preferred one doesn't have capacity.
+			for j := range zoneHosts {
+				z := (i + j) % len(zoneHosts)
+				az := localAvailabilityZones[z]
+				offeringKey := zoneIds[z] + "/" + g.machineConfig.Name
+				offering, ok := offerings[offeringKey]
+				if !ok {
+					offering, err = v.cmk.GetServiceOffering(ctx, az.CredentialsRef, zoneIds[z], g.machineConfig.Spec.ComputeOffering)
+					if err != nil {
+						return fmt.Errorf("machine config %s validation failed: getting service offering: %v", g.machineConfig.Name, err)
+					}
+					offerings[offeringKey] = offering
+				}
+				shortfall.CPUMHz = int64(offering.CPUNumber) * int64(offering.CPUSpeed)
+				shortfall.MemoryMiB = int64(offering.MemoryMiB)
+
+				for _, h := range zoneHosts[z] {
+					if h.freeMHz() < shortfall.CPUMHz || h.freeMemory() < shortfall.MemoryMiB*bytesPerMiB {
+						continue
+					}
+					if !satisfiesAffinityRules(h.Id, g.rules, antiAffinityHosts, affinityHost) {
+						continue
+					}
+					if target == nil || h.freeMemory() > target.freeMemory() {
+						target = h
+					}
+				}
+				if target != nil {
+					break
+				}
+			}
+
+			if target == nil {
+				shortfall.Machines++
+				continue
+			}
+
+			target.requestedMHz += shortfall.CPUMHz
+			target.requestedMemory += shortfall.MemoryMiB * bytesPerMiB
+			target.placements[g.name]++
+			for _, r := range g.rules {
+				if r.anti {
+					if antiAffinityHosts[r.key] == nil {
+						antiAffinityHosts[r.key] = map[string]bool{}
+					}
+					antiAffinityHosts[r.key][target.Id] = true
+				} else {
+					affinityHost[r.key] = target.Id
+				}
+			}
+		}
+
+		if shortfall.Machines > 0 {
+			shortfalls = append(shortfalls, shortfall)
+		}
+	}
+
+	for _, hosts := range zoneHosts {
+		for _, h := range hosts {
+			logger.V(4).Info("Planned CloudStack host capacity", "zone", h.zone, "host", h.Name, "machines", h.placements,
+				"freeCpuMHz", h.freeMHz(), "freeMemoryMiB", h.freeMemory()/bytesPerMiB)
+		}
+	}
+
+	if len(shortfalls) > 0 {
+		msgs := make([]string, 0, len(shortfalls))
+		for _, s := range shortfalls {
+			msgs = append(msgs, s.String())
+		}
+		return fmt.Errorf("insufficient host capacity in CloudStack availability zones: %s", strings.Join(msgs, "; "))
+	}
+
+	logger.MarkPass("Validated host capacity")
+	return nil
+}
+
+func satisfiesAffinityRules(hostId string, rules []affinityRule, antiAffinityHosts map[string]map[string]bool, affinityHost map[string]string) bool {
+	for _, r := range rules {
+		if r.anti && antiAffinityHosts[r.key][hostId] {
+			return false
+		}
+		if !r.anti && affinityHost[r.key] != "" && affinityHost[r.key] != hostId {
+			return false
+		}
+	}
+	return true
+}
+
+// capacityMachineGroups returns the machine groups of clusterSpec with the machines added by scaling
+// each group up from currentSpec plus its rolling upgrade max surge. All the machines of a group are
+// added when currentSpec is nil or doesn't have the group.
+func (v *Validator) capacityMachineGroups(ctx context.Context, clusterSpec, currentSpec *cluster.Spec, az localAvailabilityZone) ([]capacityMachineGroup, error) {
+	affinityGroupTypes := map[string]string{}
+	newGroup := func(name string, ref *anywherev1.Ref, machines int) (capacityMachineGroup, error) {
+		if ref == nil {
+			return capacityMachineGroup{}, fmt.Errorf("machineGroupRef is not set for %s", name)
+		}
+		m, ok := clusterSpec.CloudStackMachineConfigs[ref.Name]
+		if !ok {
+			return capacityMachineGroup{}, fmt.Errorf("cannot find CloudStackMachineConfig %v for %s", ref.Name, name)
+		}
+
+		g := capacityMachineGroup{name: name, machineConfig: m, machines: machines}
+		switch m.Spec.Affinity {
+		case machineConfigAffinityAnti:
+			g.rules = append(g.rules, affinityRule{key: fmt.Sprintf("(%s)", name), anti: true})
+		case machineConfigAffinityPro:
+			g.rules = append(g.rules, affinityRule{key: fmt.Sprintf("(%s)", name)})
+		}
+
+		for _, id := range m.Spec.AffinityGroupIds {
+			t, ok := affinityGroupTypes[id]
+			if !ok {
+				affinityGroup, err := v.cmk.GetAffinityGroup(ctx, az.CredentialsRef, az.DomainId, az.Account, id)
+				if err != nil {
+					return capacityMachineGroup{}, fmt.Errorf("machine config %s validation failed: validating affinity group ids: %v", m.Name, err)
+				}
+				t = affinityGroup.Type
+				affinityGroupTypes[id] = t
+			}
+
+			switch t {
+			case affinityGroupTypeHostAntiAffinity:
+				g.rules = append(g.rules, affinityRule{key: id, anti: true})
+			case affinityGroupTypeHostAffinity:
+				g.rules = append(g.rules, affinityRule{key: id})
+			}
+		}
+
+		return g, nil
+	}
+
+	var groups []capacityMachineGroup
+
+	cp := clusterSpec.Cluster.Spec.ControlPlaneConfiguration
+	currentCount := 0
+	if currentSpec != nil {
+		currentCount = currentSpec.Cluster.Spec.ControlPlaneConfiguration.Count
+	}
+	g, err := newGroup("control plane", cp.MachineGroupRef, clusterapi.AddedMachines(cp.Count, currentCount)+clusterapi.ControlPlaneUpgradeMaxSurge(cp.UpgradeRolloutStrategy))
+	if err != nil {
+		return nil, err
+	}
+	groups = append(groups, g)
+
+	if etcd := clusterSpec.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		currentCount := 0
+		if currentSpec != nil && currentSpec.Cluster.Spec.ExternalEtcdConfiguration != nil {
+			currentCount = currentSpec.Cluster.Spec.ExternalEtcdConfiguration.Count
+		}
+		g, err := newGroup("etcd", etcd.MachineGroupRef, clusterapi.AddedMachines(etcd.Count, currentCount)+clusterapi.DefaultUpgradeMaxSurge)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+
+	currentWorkers := map[string]int{}
+	if currentSpec != nil {
+		for _, w := range currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+			currentWorkers[w.Name] = clusterapi.WorkerNodeGroupMaxCount(w)
+		}
+	}
+	for _, w := range clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations {
+		count := clusterapi.AddedMachines(clusterapi.WorkerNodeGroupMaxCount(w), currentWorkers[w.Name]) + clusterapi.WorkerNodeGroupUpgradeMaxSurge(w.UpgradeRolloutStrategy)
+		g, err := newGroup(fmt.Sprintf("worker node group %s", w.Name), w.MachineGroupRef, count)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
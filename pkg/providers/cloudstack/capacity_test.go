@@ -0,0 +1,185 @@
+package cloudstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/executables"
+	"github.com/aws/eks-anywhere/pkg/providers/cloudstack/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+type capacityTest struct {
+	*WithT
+	ctx         context.Context
+	cmk         *mocks.MockProviderCmkClient
+	validator   *Validator
+	clusterSpec *cluster.Spec
+}
+
+func newCapacityTest(t *testing.T) *capacityTest {
+	cmk := mocks.NewMockProviderCmkClient(gomock.NewController(t))
+	return &capacityTest{
+		WithT:       NewWithT(t),
+		ctx:         context.Background(),
+		cmk:         cmk,
+		validator:   NewValidator(cmk, &DummyNetClient{}, true),
+		clusterSpec: test.NewFullClusterSpec(t, path.Join(testDataDir, testClusterConfigMainFilename)),
+	}
+}
+
+func (tt *capacityTest) expectAffinityGroups(types map[string]string) {
+	for id, t := range types {
+		tt.cmk.EXPECT().GetAffinityGroup(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), id).
+			Return(executables.CloudStackAffinityGroup{Id: id, Type: t}, nil)
+	}
+}
+
+func (tt *capacityTest) expectHosts(hosts []executables.CloudStackHost) {
+	tt.cmk.EXPECT().ValidateZoneAndGetId(tt.ctx, gomock.Any(), gomock.Any()).Return("zone1-id", nil)
+	tt.cmk.EXPECT().ListHosts(tt.ctx, gomock.Any(), "zone1-id").Return(hosts, nil)
+	tt.cmk.EXPECT().GetServiceOffering(tt.ctx, gomock.Any(), "zone1-id", testOffering).
+		Return(executables.CloudStackServiceOffering{CPUNumber: 2, CPUSpeed: 2000, MemoryMiB: 4096}, nil).Times(3)
+}
+
+func givenHosts(n int, cpuMHz, memoryMiB int64) []executables.CloudStackHost {
+	hosts := make([]executables.CloudStackHost, 0, n)
+	for i := 0; i < n; i++ {
+		hosts = append(hosts, executables.CloudStackHost{
+			Id:          fmt.Sprintf("host-%d", i),
+			Name:        fmt.Sprintf("host-%d", i),
+			CPUMHz:      cpuMHz,
+			MemoryBytes: memoryMiB * bytesPerMiB,
+		})
+	}
+	return hosts
+}
+
+func TestValidateClusterCapacitySuccess(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.expectAffinityGroups(map[string]string{
+		"control-plane-anti-affinity": affinityGroupTypeHostAntiAffinity,
+		"etcd-affinity":               affinityGroupTypeHostAntiAffinity,
+		"worker-affinity":             affinityGroupTypeHostAffinity,
+	})
+	// 4 control plane and 4 etcd machines on separate hosts and 4 workers on the same host.
+	tt.expectHosts(givenHosts(4, 40000, 32768))
+
+	tt.Expect(tt.validator.ValidateClusterCapacity(tt.ctx, tt.clusterSpec)).To(Succeed())
+}
+
+func TestValidateClusterCapacityAntiAffinityNotSatisfied(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.expectAffinityGroups(map[string]string{
+		"control-plane-anti-affinity": affinityGroupTypeHostAntiAffinity,
+		"etcd-affinity":               affinityGroupTypeHostAntiAffinity,
+		"worker-affinity":             affinityGroupTypeHostAffinity,
+	})
+	tt.expectHosts(givenHosts(3, 40000, 32768))
+
+	err := tt.validator.ValidateClusterCapacity(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("insufficient host capacity in CloudStack availability zones")))
+	tt.Expect(err).To(MatchError(ContainSubstring("1 control plane machine(s) requiring 4000 MHz cpu and 4096 MiB memory each can't be placed on any host satisfying [host anti-affinity control-plane-anti-affinity]")))
+	tt.Expect(err).To(MatchError(ContainSubstring("1 etcd machine(s)")))
+}
+
+func TestValidateClusterCapacityAffinityNotSatisfied(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.expectAffinityGroups(map[string]string{
+		"control-plane-anti-affinity": affinityGroupTypeHostAntiAffinity,
+		"etcd-affinity":               affinityGroupTypeHostAntiAffinity,
+		"worker-affinity":             affinityGroupTypeHostAffinity,
+	})
+	// Each host fits 4 machines: the 4 workers with host affinity can't share a host
+	// with the control plane and etcd machines.
+	tt.expectHosts(givenHosts(4, 16000, 16384))
+
+	err := tt.validator.ValidateClusterCapacity(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("2 worker node group md-0 machine(s)")))
+}
+
+func TestValidateClusterCapacityMachineConfigAffinity(t *testing.T) {
+	tt := newCapacityTest(t)
+	for _, m := range tt.clusterSpec.CloudStackMachineConfigs {
+		m.Spec.AffinityGroupIds = nil
+		m.Spec.Affinity = "anti"
+	}
+	tt.expectHosts(givenHosts(3, 40000, 32768))
+
+	err := tt.validator.ValidateClusterCapacity(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("host anti-affinity (control plane)")))
+}
+
+func TestValidateClusterCapacityListHostsError(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.expectAffinityGroups(map[string]string{
+		"control-plane-anti-affinity": affinityGroupTypeHostAntiAffinity,
+		"etcd-affinity":               affinityGroupTypeHostAntiAffinity,
+		"worker-affinity":             affinityGroupTypeHostAffinity,
+	})
+	tt.cmk.EXPECT().ValidateZoneAndGetId(tt.ctx, gomock.Any(), gomock.Any()).Return("zone1-id", nil)
+	tt.cmk.EXPECT().ListHosts(tt.ctx, gomock.Any(), "zone1-id").Return(nil, errors.New("list hosts error"))
+
+	err := tt.validator.ValidateClusterCapacity(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("listing hosts in availability zone default-az-0: list hosts error")))
+}
+
+func TestValidateClusterCapacityListHostsNotPermitted(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.expectAffinityGroups(map[string]string{
+		"control-plane-anti-affinity": affinityGroupTypeHostAntiAffinity,
+		"etcd-affinity":               affinityGroupTypeHostAntiAffinity,
+		"worker-affinity":             affinityGroupTypeHostAffinity,
+	})
+	tt.cmk.EXPECT().ValidateZoneAndGetId(tt.ctx, gomock.Any(), gomock.Any()).Return("zone1-id", nil)
+	tt.cmk.EXPECT().ListHosts(tt.ctx, gomock.Any(), "zone1-id").
+		Return(nil, fmt.Errorf("getting hosts info: %w", executables.ErrCloudStackAPINotPermitted))
+
+	tt.Expect(tt.validator.ValidateClusterCapacity(tt.ctx, tt.clusterSpec)).To(Succeed())
+}
+
+func TestValidateClusterCapacityAffinityGroupError(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.cmk.EXPECT().GetAffinityGroup(tt.ctx, gomock.Any(), gomock.Any(), gomock.Any(), "control-plane-anti-affinity").
+		Return(executables.CloudStackAffinityGroup{}, errors.New("affinity group control-plane-anti-affinity not found"))
+
+	err := tt.validator.ValidateClusterCapacity(tt.ctx, tt.clusterSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("validating affinity group ids: affinity group control-plane-anti-affinity not found")))
+}
+
+func TestValidateUpgradeClusterCapacityOnlyRequestsSurge(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.expectAffinityGroups(map[string]string{
+		"control-plane-anti-affinity": affinityGroupTypeHostAntiAffinity,
+		"etcd-affinity":               affinityGroupTypeHostAntiAffinity,
+		"worker-affinity":             affinityGroupTypeHostAffinity,
+	})
+	// The running machines are already allocated on the hosts, a single host fits the
+	// control plane, etcd and worker machines created by the rolling upgrade.
+	tt.expectHosts(givenHosts(1, 12000, 12288))
+
+	tt.Expect(tt.validator.ValidateUpgradeClusterCapacity(tt.ctx, tt.clusterSpec, tt.clusterSpec.DeepCopy())).To(Succeed())
+}
+
+func TestValidateUpgradeClusterCapacityScaleUp(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.expectAffinityGroups(map[string]string{
+		"control-plane-anti-affinity": affinityGroupTypeHostAntiAffinity,
+		"etcd-affinity":               affinityGroupTypeHostAntiAffinity,
+		"worker-affinity":             affinityGroupTypeHostAffinity,
+	})
+	currentSpec := tt.clusterSpec.DeepCopy()
+	currentSpec.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(1)
+	tt.expectHosts(givenHosts(1, 16000, 16384))
+
+	err := tt.validator.ValidateUpgradeClusterCapacity(tt.ctx, tt.clusterSpec, currentSpec)
+	tt.Expect(err).To(MatchError(ContainSubstring("1 worker node group md-0 machine(s)")))
+}
@@ -359,6 +359,10 @@ func (p *cloudstackProvider) SetupAndValidateCreateCluster(ctx context.Context,
 		return fmt.Errorf("validating cluster spec: %v", err)
 	}
 
+	if err := p.validator.ValidateClusterCapacity(ctx, clusterSpec); err != nil {
+		return fmt.Errorf("validating cluster capacity: %v", err)
+	}
+
 	if err := p.validator.ValidateControlPlaneEndpointUniqueness(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host); err != nil {
 		return fmt.Errorf("validating control plane endpoint uniqueness: %v", err)
 	}
@@ -408,6 +412,10 @@ func (p *cloudstackProvider) SetupAndValidateUpgradeCluster(ctx context.Context,
 		return fmt.Errorf("validating secrets unchanged: %v", err)
 	}
 
+	if err := p.validator.ValidateUpgradeClusterCapacity(ctx, clusterSpec, currentSpec); err != nil {
+		return fmt.Errorf("validating cluster capacity: %v", err)
+	}
+
 	return nil
 }
 
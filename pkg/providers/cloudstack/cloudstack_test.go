@@ -71,6 +71,8 @@ func givenClusterSpec(t *testing.T, fileName string) *cluster.Spec {
 func givenWildcardValidator(mockCtrl *gomock.Controller, clusterSpec *cluster.Spec) *MockProviderValidator {
 	validator := NewMockProviderValidator(mockCtrl)
 	validator.EXPECT().ValidateClusterMachineConfigs(gomock.Any(), gomock.Any()).SetArg(1, *clusterSpec).AnyTimes()
+	validator.EXPECT().ValidateClusterCapacity(gomock.Any(), gomock.Any()).AnyTimes()
+	validator.EXPECT().ValidateUpgradeClusterCapacity(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 	validator.EXPECT().ValidateCloudStackDatacenterConfig(gomock.Any(), clusterSpec.CloudStackDatacenter).AnyTimes()
 	validator.EXPECT().ValidateControlPlaneEndpointUniqueness(gomock.Any()).AnyTimes()
 	return validator
@@ -778,6 +780,28 @@ func TestSetupAndValidateUpgradeCluster(t *testing.T) {
 	}
 }
 
+func TestSetupAndValidateUpgradeClusterCapacityError(t *testing.T) {
+	ctx := context.Background()
+	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
+	currentSpec := clusterSpec.DeepCopy()
+	cluster := &types.Cluster{}
+	mockCtrl := gomock.NewController(t)
+	kubectl := mocks.NewMockProviderKubectlClient(mockCtrl)
+	validator := NewMockProviderValidator(mockCtrl)
+	validator.EXPECT().ValidateClusterMachineConfigs(gomock.Any(), gomock.Any()).SetArg(1, *clusterSpec).AnyTimes()
+	validator.EXPECT().ValidateCloudStackDatacenterConfig(gomock.Any(), clusterSpec.CloudStackDatacenter).AnyTimes()
+	provider := newProviderWithKubectl(t, clusterSpec.CloudStackDatacenter, clusterSpec.Cluster,
+		kubectl, validator)
+	setupContext(t)
+
+	kubectl.EXPECT().GetEksaCluster(ctx, cluster, clusterSpec.Cluster.GetName()).Return(clusterSpec.Cluster.DeepCopy(), nil)
+	validator.EXPECT().ValidateSecretsUnchanged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
+	validator.EXPECT().ValidateUpgradeClusterCapacity(ctx, clusterSpec, currentSpec).Return(errors.New("insufficient host capacity"))
+
+	err := provider.SetupAndValidateUpgradeCluster(ctx, cluster, clusterSpec, currentSpec)
+	NewWithT(t).Expect(err).To(MatchError(ContainSubstring("validating cluster capacity: insufficient host capacity")))
+}
+
 func TestSetupAndValidateUpgradeClusterCPSshNotExists(t *testing.T) {
 	ctx := context.Background()
 	clusterSpec := givenClusterSpec(t, testClusterConfigMainFilename)
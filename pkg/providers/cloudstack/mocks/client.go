@@ -41,6 +41,21 @@ func (m *MockProviderCmkClient) EXPECT() *MockProviderCmkClientMockRecorder {
 	return m.recorder
 }
 
+// GetAffinityGroup mocks base method.
+func (m *MockProviderCmkClient) GetAffinityGroup(arg0 context.Context, arg1, arg2, arg3, arg4 string) (executables.CloudStackAffinityGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAffinityGroup", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(executables.CloudStackAffinityGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAffinityGroup indicates an expected call of GetAffinityGroup.
+func (mr *MockProviderCmkClientMockRecorder) GetAffinityGroup(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAffinityGroup", reflect.TypeOf((*MockProviderCmkClient)(nil).GetAffinityGroup), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetManagementApiEndpoint mocks base method.
 func (m *MockProviderCmkClient) GetManagementApiEndpoint(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +71,36 @@ func (mr *MockProviderCmkClientMockRecorder) GetManagementApiEndpoint(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagementApiEndpoint", reflect.TypeOf((*MockProviderCmkClient)(nil).GetManagementApiEndpoint), arg0)
 }
 
+// GetServiceOffering mocks base method.
+func (m *MockProviderCmkClient) GetServiceOffering(arg0 context.Context, arg1, arg2 string, arg3 v1alpha1.CloudStackResourceIdentifier) (executables.CloudStackServiceOffering, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceOffering", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(executables.CloudStackServiceOffering)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceOffering indicates an expected call of GetServiceOffering.
+func (mr *MockProviderCmkClientMockRecorder) GetServiceOffering(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceOffering", reflect.TypeOf((*MockProviderCmkClient)(nil).GetServiceOffering), arg0, arg1, arg2, arg3)
+}
+
+// ListHosts mocks base method.
+func (m *MockProviderCmkClient) ListHosts(arg0 context.Context, arg1, arg2 string) ([]executables.CloudStackHost, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHosts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]executables.CloudStackHost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHosts indicates an expected call of ListHosts.
+func (mr *MockProviderCmkClientMockRecorder) ListHosts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHosts", reflect.TypeOf((*MockProviderCmkClient)(nil).ListHosts), arg0, arg1, arg2)
+}
+
 // ValidateAccountPresent mocks base method.
 func (m *MockProviderCmkClient) ValidateAccountPresent(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
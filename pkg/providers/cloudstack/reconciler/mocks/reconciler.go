@@ -91,6 +91,44 @@ func (mr *MockCNIReconcilerMockRecorder) Reconcile(ctx, logger, client, spec int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockCNIReconciler)(nil).Reconcile), ctx, logger, client, spec)
 }
 
+// MockServiceLoadBalancerReconciler is a mock of ServiceLoadBalancerReconciler interface.
+type MockServiceLoadBalancerReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceLoadBalancerReconcilerMockRecorder
+}
+
+// MockServiceLoadBalancerReconcilerMockRecorder is the mock recorder for MockServiceLoadBalancerReconciler.
+type MockServiceLoadBalancerReconcilerMockRecorder struct {
+	mock *MockServiceLoadBalancerReconciler
+}
+
+// NewMockServiceLoadBalancerReconciler creates a new mock instance.
+func NewMockServiceLoadBalancerReconciler(ctrl *gomock.Controller) *MockServiceLoadBalancerReconciler {
+	mock := &MockServiceLoadBalancerReconciler{ctrl: ctrl}
+	mock.recorder = &MockServiceLoadBalancerReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceLoadBalancerReconciler) EXPECT() *MockServiceLoadBalancerReconcilerMockRecorder {
+	return m.recorder
+}
+
+// Reconcile mocks base method.
+func (m *MockServiceLoadBalancerReconciler) Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, logger, client, spec)
+	ret0, _ := ret[0].(controller.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockServiceLoadBalancerReconcilerMockRecorder) Reconcile(ctx, logger, client, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockServiceLoadBalancerReconciler)(nil).Reconcile), ctx, logger, client, spec)
+}
+
 // MockRemoteClientRegistry is a mock of RemoteClientRegistry interface.
 type MockRemoteClientRegistry struct {
 	ctrl     *gomock.Controller
@@ -27,6 +27,11 @@ type CNIReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
 }
 
+// ServiceLoadBalancerReconciler is an interface for reconciling the built-in service load balancer in the CloudStack cluster reconciler.
+type ServiceLoadBalancerReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
 // RemoteClientRegistry is an interface that defines methods for remote clients.
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
@@ -34,21 +39,23 @@ type RemoteClientRegistry interface {
 
 // Reconciler for CloudStack.
 type Reconciler struct {
-	client               client.Client
-	ipValidator          IPValidator
-	cniReconciler        CNIReconciler
-	remoteClientRegistry RemoteClientRegistry
-	validatorRegistry    cloudstack.ValidatorRegistry
+	client                        client.Client
+	ipValidator                   IPValidator
+	cniReconciler                 CNIReconciler
+	remoteClientRegistry          RemoteClientRegistry
+	validatorRegistry             cloudstack.ValidatorRegistry
+	serviceLoadBalancerReconciler ServiceLoadBalancerReconciler
 }
 
 // New defines a new CloudStack reconciler.
-func New(client client.Client, ipValidator IPValidator, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, validatorRegistry cloudstack.ValidatorRegistry) *Reconciler {
+func New(client client.Client, ipValidator IPValidator, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, validatorRegistry cloudstack.ValidatorRegistry, serviceLoadBalancerReconciler ServiceLoadBalancerReconciler) *Reconciler {
 	return &Reconciler{
-		client:               client,
-		ipValidator:          ipValidator,
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		validatorRegistry:    validatorRegistry,
+		client:                        client,
+		ipValidator:                   ipValidator,
+		cniReconciler:                 cniReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		validatorRegistry:             validatorRegistry,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
 	}
 }
 
@@ -69,6 +76,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
+		r.ReconcileServiceLoadBalancer,
 	).Run(ctx, log, clusterSpec)
 }
 
@@ -165,3 +173,14 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 
 	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
+
+// ReconcileServiceLoadBalancer installs and configures the built-in service load balancer when the cluster has one.
+func (r *Reconciler) ReconcileServiceLoadBalancer(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileServiceLoadBalancer")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(spec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.serviceLoadBalancerReconciler.Reconcile(ctx, log, client, spec)
+}
@@ -59,9 +59,10 @@ func TestReconcilerReconcileSuccess(t *testing.T) {
 	tt.ipValidator.EXPECT().ValidateControlPlaneIP(tt.ctx, logger, tt.buildSpec()).Return(controller.Result{}, nil)
 	tt.remoteClientRegistry.EXPECT().GetClient(
 		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: constants.EksaSystemNamespace},
-	).Return(remoteClient, nil).Times(1)
+	).Return(remoteClient, nil).Times(2)
 
 	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
 	ctrl := gomock.NewController(t)
 	validator := cloudstack.NewMockProviderValidator(ctrl)
 	tt.validatorRegistry.EXPECT().Get(tt.execConfig).Return(validator, nil).Times(1)
@@ -351,6 +352,42 @@ func TestReconcileCNISuccess(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcileServiceLoadBalancerSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileServiceLoadBalancerErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
 func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.withFakeClient()
@@ -453,7 +490,7 @@ func (tt *reconcilerTest) allObjs() []client.Object {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.ipValidator, tt.cniReconciler, tt.remoteClientRegistry, tt.validatorRegistry)
+	return reconciler.New(tt.client, tt.ipValidator, tt.cniReconciler, tt.remoteClientRegistry, tt.validatorRegistry, tt.serviceLoadBalancerReconciler)
 }
 
 func (tt *reconcilerTest) buildSpec() *clusterspec.Spec {
@@ -468,20 +505,21 @@ type reconcilerTest struct {
 	t testing.TB
 	*WithT
 	*envtest.APIExpecter
-	ctx                       context.Context
-	cluster                   *anywherev1.Cluster
-	client                    client.Client
-	eksaSupportObjs           []client.Object
-	datacenterConfig          *anywherev1.CloudStackDatacenterConfig
-	machineConfigControlPlane *anywherev1.CloudStackMachineConfig
-	machineConfigWorker       *anywherev1.CloudStackMachineConfig
-	ipValidator               *cloudstackreconcilermocks.MockIPValidator
-	cniReconciler             *cloudstackreconcilermocks.MockCNIReconciler
-	remoteClientRegistry      *cloudstackreconcilermocks.MockRemoteClientRegistry
-	validatorRegistry         *cloudstack.MockValidatorRegistry
-	execConfig                *decoder.CloudStackExecConfig
-	secret                    *corev1.Secret
-	kcp                       *controlplanev1.KubeadmControlPlane
+	ctx                           context.Context
+	cluster                       *anywherev1.Cluster
+	client                        client.Client
+	eksaSupportObjs               []client.Object
+	datacenterConfig              *anywherev1.CloudStackDatacenterConfig
+	machineConfigControlPlane     *anywherev1.CloudStackMachineConfig
+	machineConfigWorker           *anywherev1.CloudStackMachineConfig
+	ipValidator                   *cloudstackreconcilermocks.MockIPValidator
+	cniReconciler                 *cloudstackreconcilermocks.MockCNIReconciler
+	serviceLoadBalancerReconciler *cloudstackreconcilermocks.MockServiceLoadBalancerReconciler
+	remoteClientRegistry          *cloudstackreconcilermocks.MockRemoteClientRegistry
+	validatorRegistry             *cloudstack.MockValidatorRegistry
+	execConfig                    *decoder.CloudStackExecConfig
+	secret                        *corev1.Secret
+	kcp                           *controlplanev1.KubeadmControlPlane
 }
 
 func newReconcilerTest(t testing.TB) *reconcilerTest {
@@ -490,6 +528,7 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 
 	ipValidator := cloudstackreconcilermocks.NewMockIPValidator(ctrl)
 	cniReconciler := cloudstackreconcilermocks.NewMockCNIReconciler(ctrl)
+	serviceLoadBalancerReconciler := cloudstackreconcilermocks.NewMockServiceLoadBalancerReconciler(ctrl)
 	remoteClientRegistry := cloudstackreconcilermocks.NewMockRemoteClientRegistry(ctrl)
 	validatorRegistry := cloudstack.NewMockValidatorRegistry(ctrl)
 	execConfig := &decoder.CloudStackExecConfig{
@@ -645,16 +684,17 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 			test.EksdRelease("1-22"),
 			test.EKSARelease(),
 		},
-		cluster:                   cluster,
-		datacenterConfig:          workloadClusterDatacenter,
-		machineConfigControlPlane: machineConfigCP,
-		machineConfigWorker:       machineConfigWN,
-		cniReconciler:             cniReconciler,
-		remoteClientRegistry:      remoteClientRegistry,
-		validatorRegistry:         validatorRegistry,
-		execConfig:                execConfig,
-		secret:                    secret,
-		kcp:                       kcp,
+		cluster:                       cluster,
+		datacenterConfig:              workloadClusterDatacenter,
+		machineConfigControlPlane:     machineConfigCP,
+		machineConfigWorker:           machineConfigWN,
+		cniReconciler:                 cniReconciler,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		validatorRegistry:             validatorRegistry,
+		execConfig:                    execConfig,
+		secret:                        secret,
+		kcp:                           kcp,
 	}
 
 	t.Cleanup(tt.cleanup)
@@ -146,6 +146,8 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 
 	values := map[string]interface{}{
 		"clusterName":                                clusterSpec.Cluster.Name,
+		"kubeletNodeIPCommand":                       clusterapi.KubeletNodeIPCommand(&clusterSpec.Cluster.Spec.ClusterNetwork),
+		"kubeVipCidr":                                clusterapi.KubeVipCIDR(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host),
 		"controlPlaneEndpointHost":                   host,
 		"controlPlaneEndpointPort":                   port,
 		"controlPlaneReplicas":                       clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
@@ -181,7 +183,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"controlPlaneSshUsername":                    controlPlaneMachineSpec.Users[0].Name,
 		"cloudstackControlPlaneSshAuthorizedKey":     controlPlaneSSHKey,
 		"cloudstackEtcdSshAuthorizedKey":             etcdSSHAuthorizedKey,
-		"podCidrs":                                   clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
+		"podCidrs":                                   clusterSpec.Cluster.Spec.ClusterNetwork.PrimaryPodCIDRBlocks(),
 		"serviceCidrs":                               clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"apiserverExtraArgs":                         apiServerExtraArgs.ToPartialYaml(),
 		"etcdExtraArgs":                              etcdExtraArgs.ToPartialYaml(),
@@ -383,6 +385,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupConfiguration
 
 	values := map[string]interface{}{
 		"clusterName":                      clusterSpec.Cluster.Name,
+		"kubeletNodeIPCommand":             clusterapi.KubeletNodeIPCommand(&clusterSpec.Cluster.Spec.ClusterNetwork),
 		"kubernetesVersion":                versionsBundle.KubeDistro.Kubernetes.Tag,
 		"cloudstackAnnotationSuffix":       constants.CloudstackAnnotationSuffix,
 		"cloudstackTemplateId":             workerNodeGroupMachineSpec.Template.Id,
@@ -52,6 +52,20 @@ func (mr *MockProviderValidatorMockRecorder) ValidateCloudStackDatacenterConfig(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCloudStackDatacenterConfig", reflect.TypeOf((*MockProviderValidator)(nil).ValidateCloudStackDatacenterConfig), arg0, arg1)
 }
 
+// ValidateClusterCapacity mocks base method.
+func (m *MockProviderValidator) ValidateClusterCapacity(arg0 context.Context, arg1 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateClusterCapacity", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateClusterCapacity indicates an expected call of ValidateClusterCapacity.
+func (mr *MockProviderValidatorMockRecorder) ValidateClusterCapacity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateClusterCapacity", reflect.TypeOf((*MockProviderValidator)(nil).ValidateClusterCapacity), arg0, arg1)
+}
+
 // ValidateClusterMachineConfigs mocks base method.
 func (m *MockProviderValidator) ValidateClusterMachineConfigs(arg0 context.Context, arg1 *cluster.Spec) error {
 	m.ctrl.T.Helper()
@@ -66,6 +80,20 @@ func (mr *MockProviderValidatorMockRecorder) ValidateClusterMachineConfigs(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateClusterMachineConfigs", reflect.TypeOf((*MockProviderValidator)(nil).ValidateClusterMachineConfigs), arg0, arg1)
 }
 
+// ValidateUpgradeClusterCapacity mocks base method.
+func (m *MockProviderValidator) ValidateUpgradeClusterCapacity(arg0 context.Context, arg1, arg2 *cluster.Spec) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateUpgradeClusterCapacity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateUpgradeClusterCapacity indicates an expected call of ValidateUpgradeClusterCapacity.
+func (mr *MockProviderValidatorMockRecorder) ValidateUpgradeClusterCapacity(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateUpgradeClusterCapacity", reflect.TypeOf((*MockProviderValidator)(nil).ValidateUpgradeClusterCapacity), arg0, arg1, arg2)
+}
+
 // ValidateControlPlaneEndpointUniqueness mocks base method.
 func (m *MockProviderValidator) ValidateControlPlaneEndpointUniqueness(arg0 string) error {
 	m.ctrl.T.Helper()
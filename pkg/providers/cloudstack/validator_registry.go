@@ -33,6 +33,8 @@ type ValidatorFactory struct {
 type ProviderValidator interface {
 	ValidateCloudStackDatacenterConfig(ctx context.Context, datacenterConfig *anywherev1.CloudStackDatacenterConfig) error
 	ValidateClusterMachineConfigs(ctx context.Context, clusterSpec *cluster.Spec) error
+	ValidateClusterCapacity(ctx context.Context, clusterSpec *cluster.Spec) error
+	ValidateUpgradeClusterCapacity(ctx context.Context, clusterSpec, currentSpec *cluster.Spec) error
 	ValidateControlPlaneEndpointUniqueness(endpoint string) error
 	ValidateSecretsUnchanged(ctx context.Context, cluster *types.Cluster, execConfig *decoder.CloudStackExecConfig, client ProviderKubectlClient) error
 }
@@ -292,7 +292,7 @@ func buildTemplateMapCP(clusterSpec *cluster.Spec) (map[string]interface{}, erro
 		"schedulerExtraArgs":            sharedExtraArgs.ToPartialYaml(),
 		"externalEtcdVersion":           versionsBundle.KubeDistro.EtcdVersion,
 		"eksaSystemNamespace":           constants.EksaSystemNamespace,
-		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
+		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.PrimaryPodCIDRBlocks(),
 		"serviceCidrs":                  clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"haproxyImageRepository":        getHAProxyImageRepo(versionsBundle.Haproxy.Image),
 		"haproxyImageTag":               versionsBundle.Haproxy.Image.Tag(),
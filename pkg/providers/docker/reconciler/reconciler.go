@@ -18,9 +18,10 @@ import (
 
 // Reconciler contains dependencies for a docker reconciler.
 type Reconciler struct {
-	client               client.Client
-	cniReconciler        CNIReconciler
-	remoteClientRegistry RemoteClientRegistry
+	client                        client.Client
+	cniReconciler                 CNIReconciler
+	remoteClientRegistry          RemoteClientRegistry
+	serviceLoadBalancerReconciler ServiceLoadBalancerReconciler
 	*serverside.ObjectApplier
 }
 
@@ -29,18 +30,24 @@ type CNIReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
 }
 
+// ServiceLoadBalancerReconciler is an interface for reconciling the built-in service load balancer in the Docker cluster reconciler.
+type ServiceLoadBalancerReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
+}
+
 // RemoteClientRegistry is an interface that defines methods for remote clients.
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
 }
 
 // New creates a new Docker provider reconciler.
-func New(client client.Client, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry) *Reconciler {
+func New(client client.Client, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, serviceLoadBalancerReconciler ServiceLoadBalancerReconciler) *Reconciler {
 	return &Reconciler{
-		client:               client,
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		ObjectApplier:        serverside.NewObjectApplier(client),
+		client:                        client,
+		cniReconciler:                 cniReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		ObjectApplier:                 serverside.NewObjectApplier(client),
 	}
 }
 
@@ -58,6 +65,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
+		r.ReconcileServiceLoadBalancer,
 	).Run(ctx, log, clusterSpec)
 }
 
@@ -79,6 +87,17 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
 
+// ReconcileServiceLoadBalancer installs and configures the built-in service load balancer when the cluster has one.
+func (r *Reconciler) ReconcileServiceLoadBalancer(ctx context.Context, log logr.Logger, spec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileServiceLoadBalancer")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(spec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.serviceLoadBalancerReconciler.Reconcile(ctx, log, client, spec)
+}
+
 // ReconcileWorkers applies the worker CAPI objects to the cluster.
 func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileWorkers")
@@ -50,8 +50,9 @@ func TestReconcilerReconcileSuccess(t *testing.T) {
 	remoteClient := fake.NewClientBuilder().Build()
 	tt.remoteClientRegistry.EXPECT().GetClient(
 		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: constants.EksaSystemNamespace},
-	).Return(remoteClient, nil)
+	).Return(remoteClient, nil).Times(2)
 	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
 
 	tt.Expect(tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)).To(Equal(controller.Result{}))
 	tt.Expect(tt.cluster.Status.FailureMessage).To(BeZero())
@@ -148,6 +149,42 @@ func TestReconcileCNISuccess(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcileServiceLoadBalancerSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileServiceLoadBalancerErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
 func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.withFakeClient()
@@ -340,20 +377,22 @@ type reconcilerTest struct {
 	t testing.TB
 	*WithT
 	*envtest.APIExpecter
-	ctx                  context.Context
-	cniReconciler        *dockereconcilermocks.MockCNIReconciler
-	remoteClientRegistry *dockereconcilermocks.MockRemoteClientRegistry
-	cluster              *anywherev1.Cluster
-	client               client.Client
-	env                  *envtest.Environment
-	eksaSupportObjs      []client.Object
-	datacenterConfig     *anywherev1.DockerDatacenterConfig
-	kcp                  *controlplanev1.KubeadmControlPlane
+	ctx                           context.Context
+	cniReconciler                 *dockereconcilermocks.MockCNIReconciler
+	serviceLoadBalancerReconciler *dockereconcilermocks.MockServiceLoadBalancerReconciler
+	remoteClientRegistry          *dockereconcilermocks.MockRemoteClientRegistry
+	cluster                       *anywherev1.Cluster
+	client                        client.Client
+	env                           *envtest.Environment
+	eksaSupportObjs               []client.Object
+	datacenterConfig              *anywherev1.DockerDatacenterConfig
+	kcp                           *controlplanev1.KubeadmControlPlane
 }
 
 func newReconcilerTest(t testing.TB) *reconcilerTest {
 	ctrl := gomock.NewController(t)
 	cniReconciler := dockereconcilermocks.NewMockCNIReconciler(ctrl)
+	serviceLoadBalancerReconciler := dockereconcilermocks.NewMockServiceLoadBalancerReconciler(ctrl)
 	remoteClientRegistry := dockereconcilermocks.NewMockRemoteClientRegistry(ctrl)
 	c := env.Client()
 
@@ -423,15 +462,16 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 	})
 
 	tt := &reconcilerTest{
-		t:                    t,
-		WithT:                NewWithT(t),
-		APIExpecter:          envtest.NewAPIExpecter(t, c),
-		ctx:                  context.Background(),
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		cluster:              cluster,
-		client:               c,
-		env:                  env,
+		t:                             t,
+		WithT:                         NewWithT(t),
+		APIExpecter:                   envtest.NewAPIExpecter(t, c),
+		ctx:                           context.Background(),
+		cniReconciler:                 cniReconciler,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		cluster:                       cluster,
+		client:                        c,
+		env:                           env,
 		eksaSupportObjs: []client.Object{
 			test.Namespace(clusterNamespace),
 			test.Namespace(constants.EksaSystemNamespace),
@@ -458,7 +498,7 @@ func (tt *reconcilerTest) cleanup() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.cniReconciler, tt.remoteClientRegistry)
+	return reconciler.New(tt.client, tt.cniReconciler, tt.remoteClientRegistry, tt.serviceLoadBalancerReconciler)
 }
 
 func (tt *reconcilerTest) buildSpec() *clusterspec.Spec {
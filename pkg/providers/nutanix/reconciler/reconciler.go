@@ -29,6 +29,11 @@ type CNIReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
 }
 
+// ServiceLoadBalancerReconciler is an interface for reconciling the built-in service load balancer in the Nutanix cluster reconciler.
+type ServiceLoadBalancerReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
+}
+
 // RemoteClientRegistry is an interface that defines methods for remote clients.
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
@@ -41,23 +46,25 @@ type IPValidator interface {
 
 // Reconciler reconciles a Nutanix cluster.
 type Reconciler struct {
-	client               client.Client
-	validator            *nutanix.Validator
-	cniReconciler        CNIReconciler
-	remoteClientRegistry RemoteClientRegistry
-	ipValidator          IPValidator
+	client                        client.Client
+	validator                     *nutanix.Validator
+	cniReconciler                 CNIReconciler
+	remoteClientRegistry          RemoteClientRegistry
+	ipValidator                   IPValidator
+	serviceLoadBalancerReconciler ServiceLoadBalancerReconciler
 	*serverside.ObjectApplier
 }
 
 // New defines a new Nutanix reconciler.
-func New(client client.Client, validator *nutanix.Validator, cniReconciler CNIReconciler, registry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+func New(client client.Client, validator *nutanix.Validator, cniReconciler CNIReconciler, registry RemoteClientRegistry, ipValidator IPValidator, serviceLoadBalancerReconciler ServiceLoadBalancerReconciler) *Reconciler {
 	return &Reconciler{
-		client:               client,
-		validator:            validator,
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: registry,
-		ipValidator:          ipValidator,
-		ObjectApplier:        serverside.NewObjectApplier(client),
+		client:                        client,
+		validator:                     validator,
+		cniReconciler:                 cniReconciler,
+		remoteClientRegistry:          registry,
+		ipValidator:                   ipValidator,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		ObjectApplier:                 serverside.NewObjectApplier(client),
 	}
 }
 
@@ -155,6 +162,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
+		r.ReconcileServiceLoadBalancer,
 	).Run(ctx, log, clusterSpec)
 }
 
@@ -170,6 +178,17 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 	return r.cniReconciler.Reconcile(ctx, log, c, clusterSpec)
 }
 
+// ReconcileServiceLoadBalancer installs and configures the built-in service load balancer when the cluster has one.
+func (r *Reconciler) ReconcileServiceLoadBalancer(ctx context.Context, log logr.Logger, spec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileServiceLoadBalancer")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(spec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.serviceLoadBalancerReconciler.Reconcile(ctx, log, client, spec)
+}
+
 // ValidateClusterSpec performs additional, context-aware validations on the cluster spec.
 func (r *Reconciler) ValidateClusterSpec(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "validateClusterSpec")
@@ -191,6 +191,8 @@ func buildTemplateMapCP(
 		"ccmIgnoredNodeIPs":            ccmIgnoredNodeIPs,
 		"cloudProviderImage":           versionsBundle.Nutanix.CloudProvider.VersionedImage(),
 		"clusterName":                  clusterSpec.Cluster.Name,
+		"kubeletNodeIPCommand":         clusterapi.KubeletNodeIPCommand(&clusterSpec.Cluster.Spec.ClusterNetwork),
+		"kubeVipCidr":                  clusterapi.KubeVipCIDR(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host),
 		"controlPlaneEndpointIp":       clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host,
 		"controlPlaneReplicas":         clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
 		"controlPlaneSshAuthorizedKey": controlPlaneMachineSpec.Users[0].SshAuthorizedKeys[0],
@@ -199,7 +201,7 @@ func buildTemplateMapCP(
 		"eksaSystemNamespace":          constants.EksaSystemNamespace,
 		"format":                       format,
 		"failureDomains":               failureDomains,
-		"podCidrs":                     clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
+		"podCidrs":                     clusterSpec.Cluster.Spec.ClusterNetwork.PrimaryPodCIDRBlocks(),
 		"serviceCidrs":                 clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"kubernetesVersion":            versionsBundle.KubeDistro.Kubernetes.Tag,
 		"kubernetesRepository":         versionsBundle.KubeDistro.Kubernetes.Repository,
@@ -423,6 +425,7 @@ func buildTemplateMapMD(clusterSpec *cluster.Spec, workerNodeGroupMachineSpec v1
 
 	values := map[string]interface{}{
 		"clusterName":            clusterSpec.Cluster.Name,
+		"kubeletNodeIPCommand":   clusterapi.KubeletNodeIPCommand(&clusterSpec.Cluster.Spec.ClusterNetwork),
 		"eksaSystemNamespace":    constants.EksaSystemNamespace,
 		"format":                 format,
 		"kubernetesVersion":      versionsBundle.KubeDistro.Kubernetes.Tag,
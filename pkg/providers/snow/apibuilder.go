@@ -110,6 +110,7 @@ func KubeadmControlPlane(log logr.Logger, clusterSpec *cluster.Spec, snowMachine
 		}
 		clusterapi.CreateContainerdConfigFileInKubeadmControlPlane(kcp, clusterSpec.Cluster)
 		clusterapi.RestartContainerdInKubeadmControlPlane(kcp, clusterSpec.Cluster)
+		clusterapi.SetKubeletNodeIPInKubeadmControlPlane(kcp, clusterSpec.Cluster)
 		clusterapi.SetUnstackedEtcdConfigInKubeadmControlPlaneForUbuntu(kcp, clusterSpec.Cluster.Spec.ExternalEtcdConfiguration)
 		kcp.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.IgnorePreflightErrors = append(
 			kcp.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.IgnorePreflightErrors,
@@ -160,6 +161,7 @@ func KubeadmConfigTemplate(log logr.Logger, clusterSpec *cluster.Spec, workerNod
 		}
 		clusterapi.CreateContainerdConfigFileInKubeadmConfigTemplate(kct, clusterSpec.Cluster)
 		clusterapi.RestartContainerdInKubeadmConfigTemplate(kct, clusterSpec.Cluster)
+		clusterapi.SetKubeletNodeIPInKubeadmConfigTemplate(kct, clusterSpec.Cluster)
 
 	default:
 		log.Info("Warning: unsupported OS family when setting up KubeadmConfigTemplate", "OS family", osFamily)
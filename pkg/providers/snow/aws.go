@@ -8,11 +8,13 @@ import (
 
 type AwsClient interface {
 	EC2ImageExists(ctx context.Context, imageID string) (bool, error)
+	EC2ImageRootVolumeSize(ctx context.Context, imageID string) (int64, error)
 	EC2KeyNameExists(ctx context.Context, keyName string) (bool, error)
 	EC2ImportKeyPair(ctx context.Context, keyName string, keyMaterial []byte) error
 	EC2InstanceTypes(ctx context.Context) ([]aws.EC2InstanceType, error)
 	IsSnowballDeviceUnlocked(ctx context.Context) (bool, error)
 	SnowballDeviceSoftwareVersion(ctx context.Context) (string, error)
+	SnowballDeviceCapacity(ctx context.Context) (*aws.SnowballDeviceCapacity, error)
 }
 
 // LocalIMDSClient contains methods that fetch metadata from the local imds.
@@ -0,0 +1,354 @@
+package snow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+)
+
+const (
+	bytesPerMiB = 1 << 20
+	bytesPerGiB = 1 << 30
+)
+
+// Resources is an amount of compute and storage on a snow device.
+type Resources struct {
+	VCPU       int64
+	MemoryMiB  int64
+	StorageGiB int64
+}
+
+// Add returns the sum of r and o.
+func (r Resources) Add(o Resources) Resources {
+	return Resources{
+		VCPU:       r.VCPU + o.VCPU,
+		MemoryMiB:  r.MemoryMiB + o.MemoryMiB,
+		StorageGiB: r.StorageGiB + o.StorageGiB,
+	}
+}
+
+// Fits determines if o fits in r.
+func (r Resources) Fits(o Resources) bool {
+	return o.VCPU <= r.VCPU && o.MemoryMiB <= r.MemoryMiB && o.StorageGiB <= r.StorageGiB
+}
+
+func (r Resources) String() string {
+	return fmt.Sprintf("%d vCPU, %d MiB memory, %d GiB storage", r.VCPU, r.MemoryMiB, r.StorageGiB)
+}
+
+// MachinePlacement is the number of machines of a machine group placed on a device.
+type MachinePlacement struct {
+	Group    string
+	Machines int
+}
+
+// DeviceCapacityPlan has the machines placed on a snow device and the resources they request.
+type DeviceCapacityPlan struct {
+	Device     string
+	Available  Resources
+	Requested  Resources
+	Placements []MachinePlacement
+}
+
+func (d *DeviceCapacityPlan) remaining() Resources {
+	return Resources{
+		VCPU:       d.Available.VCPU - d.Requested.VCPU,
+		MemoryMiB:  d.Available.MemoryMiB - d.Requested.MemoryMiB,
+		StorageGiB: d.Available.StorageGiB - d.Requested.StorageGiB,
+	}
+}
+
+func (d *DeviceCapacityPlan) place(group string, r Resources) {
+	d.Requested = d.Requested.Add(r)
+	for i := range d.Placements {
+		if d.Placements[i].Group == group {
+			d.Placements[i].Machines++
+			return
+		}
+	}
+	d.Placements = append(d.Placements, MachinePlacement{Group: group, Machines: 1})
+}
+
+// CapacityShortfall is the number of machines of a machine group that don't fit on the group devices.
+type CapacityShortfall struct {
+	Group      string
+	Machines   int
+	PerMachine Resources
+	Devices    []string
+}
+
+func (s CapacityShortfall) String() string {
+	return fmt.Sprintf("%d %s machine(s) requiring %s each don't fit on devices [%s]",
+		s.Machines, s.Group, s.PerMachine, strings.Join(s.Devices, ", "))
+}
+
+// CapacityPlan is the placement of all the machines of a cluster, including the machines created
+// by rolling upgrades, on the snow devices.
+type CapacityPlan struct {
+	Devices    []*DeviceCapacityPlan
+	Shortfalls []CapacityShortfall
+}
+
+// Fits determines if all the machines of the cluster can be placed on the devices.
+func (p *CapacityPlan) Fits() bool {
+	return len(p.Shortfalls) == 0
+}
+
+// machineGroup is a set of machines sharing a snow machine config.
+type machineGroup struct {
+	name          string
+	machineConfig *v1alpha1.SnowMachineConfig
+	machines      int
+}
+
+// PlanCapacity places the control plane, etcd and worker node group machines of the cluster on
+// the devices listed in their machine configs, using the vCPU, memory and storage available on
+// each device. Each machine group requests its machine count plus its rolling upgrade max surge.
+// The storage of a machine includes the root volume of its image and its data volumes.
+// Machines are placed on the device of their group with the most remaining vCPU.
+func (v *Validator) PlanCapacity(ctx context.Context, c *cluster.Config) (*CapacityPlan, error) {
+	groups, err := clusterMachineGroups(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.planCapacity(ctx, groups)
+}
+
+// PlanUpgradeCapacity places the machines an upgrade from current to c adds to the cluster on the
+// devices listed in their machine configs. The machines already running in the cluster are
+// accounted for in the capacity reported by the devices, so each machine group only requests the
+// machines added by scaling it up plus its rolling upgrade max surge.
+func (v *Validator) PlanUpgradeCapacity(ctx context.Context, c, current *cluster.Config) (*CapacityPlan, error) {
+	groups, err := upgradeMachineGroups(c, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.planCapacity(ctx, groups)
+}
+
+func (v *Validator) planCapacity(ctx context.Context, groups []machineGroup) (*CapacityPlan, error) {
+	clientMap, err := v.clientRegistry.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &CapacityPlan{}
+	devices := map[string]*DeviceCapacityPlan{}
+	instanceTypes := map[string]map[string]Resources{}
+	rootVolumes := map[string]int64{}
+
+	for _, g := range groups {
+		if g.machines == 0 {
+			continue
+		}
+
+		for _, ip := range g.machineConfig.Spec.Devices {
+			client, ok := clientMap[ip]
+			if !ok {
+				return nil, fmt.Errorf("credentials not found for device [%s]", ip)
+			}
+
+			if ami := g.machineConfig.Spec.AMIID; ami != "" {
+				key := ip + "/" + ami
+				if _, ok := rootVolumes[key]; !ok {
+					size, err := client.EC2ImageRootVolumeSize(ctx, ami)
+					if err != nil {
+						return nil, fmt.Errorf("fetching root volume size of image [%s] in device [%s]: %v", ami, ip, err)
+					}
+					rootVolumes[key] = size
+				}
+			}
+
+			if _, ok := devices[ip]; ok {
+				continue
+			}
+
+			capacity, err := client.SnowballDeviceCapacity(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("fetching capacity for device [%s]: %v", ip, err)
+			}
+
+			its, err := client.EC2InstanceTypes(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("fetching supported instance types for device [%s]: %v", ip, err)
+			}
+			instanceTypes[ip] = map[string]Resources{}
+			for _, it := range its {
+				r := Resources{}
+				if it.DefaultVCPU != nil {
+					r.VCPU = int64(*it.DefaultVCPU)
+				}
+				if it.MemoryMiB != nil {
+					r.MemoryMiB = *it.MemoryMiB
+				}
+				instanceTypes[ip][it.Name] = r
+			}
+
+			devices[ip] = &DeviceCapacityPlan{
+				Device: ip,
+				Available: Resources{
+					VCPU:       capacity.VCPU,
+					MemoryMiB:  capacity.MemoryBytes / bytesPerMiB,
+					StorageGiB: capacity.StorageBytes / bytesPerGiB,
+				},
+			}
+			plan.Devices = append(plan.Devices, devices[ip])
+		}
+
+		shortfall := CapacityShortfall{Group: g.name, Devices: g.machineConfig.Spec.Devices}
+		for i := 0; i < g.machines; i++ {
+			var target *DeviceCapacityPlan
+			var requested Resources
+			for _, ip := range g.machineConfig.Spec.Devices {
+				r, ok := instanceTypes[ip][g.machineConfig.Spec.InstanceType]
+				if !ok {
+					return nil, fmt.Errorf("the instance type [%s] is not supported in device [%s]", g.machineConfig.Spec.InstanceType, ip)
+				}
+				r.StorageGiB = rootVolumes[ip+"/"+g.machineConfig.Spec.AMIID] + machineStorageGiB(g.machineConfig)
+				shortfall.PerMachine = r
+
+				d := devices[ip]
+				if !d.remaining().Fits(r) {
+					continue
+				}
+				if target == nil || d.remaining().VCPU > target.remaining().VCPU {
+					target = d
+					requested = r
+				}
+			}
+
+			if target == nil {
+				shortfall.Machines++
+				continue
+			}
+			target.place(g.name, requested)
+		}
+
+		if shortfall.Machines > 0 {
+			plan.Shortfalls = append(plan.Shortfalls, shortfall)
+		}
+	}
+
+	return plan, nil
+}
+
+// ValidateCapacity validates the devices have enough vCPU, memory and storage available to run all
+// the machines of the cluster, including the machines created by rolling upgrades.
+func (v *Validator) ValidateCapacity(ctx context.Context, c *cluster.Config) error {
+	plan, err := v.PlanCapacity(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	return validateCapacityPlan(plan)
+}
+
+// ValidateUpgradeCapacity validates the devices have enough vCPU, memory and storage available to
+// run the machines an upgrade from current to c adds to the cluster, including the machines created
+// by the rolling upgrade.
+func (v *Validator) ValidateUpgradeCapacity(ctx context.Context, c, current *cluster.Config) error {
+	plan, err := v.PlanUpgradeCapacity(ctx, c, current)
+	if err != nil {
+		return err
+	}
+
+	return validateCapacityPlan(plan)
+}
+
+func validateCapacityPlan(plan *CapacityPlan) error {
+	if plan.Fits() {
+		return nil
+	}
+
+	shortfalls := make([]string, 0, len(plan.Shortfalls))
+	for _, s := range plan.Shortfalls {
+		shortfalls = append(shortfalls, s.String())
+	}
+
+	return fmt.Errorf("insufficient capacity on snow devices: %s", strings.Join(shortfalls, "; "))
+}
+
+func clusterMachineGroups(c *cluster.Config) ([]machineGroup, error) {
+	return upgradeMachineGroups(c, nil)
+}
+
+// upgradeMachineGroups returns the machine groups of c with the machines added by scaling each
+// group up from current plus its rolling upgrade max surge. All the machines of a group are added
+// when current is nil or doesn't have the group.
+func upgradeMachineGroups(c, current *cluster.Config) ([]machineGroup, error) {
+	var groups []machineGroup
+
+	cp := c.Cluster.Spec.ControlPlaneConfiguration
+	currentCount := 0
+	if current != nil {
+		currentCount = current.Cluster.Spec.ControlPlaneConfiguration.Count
+	}
+	g, err := newMachineGroup(c, "control plane", cp.MachineGroupRef, clusterapi.AddedMachines(cp.Count, currentCount)+clusterapi.ControlPlaneUpgradeMaxSurge(cp.UpgradeRolloutStrategy))
+	if err != nil {
+		return nil, err
+	}
+	groups = append(groups, g)
+
+	if etcd := c.Cluster.Spec.ExternalEtcdConfiguration; etcd != nil {
+		currentCount := 0
+		if current != nil && current.Cluster.Spec.ExternalEtcdConfiguration != nil {
+			currentCount = current.Cluster.Spec.ExternalEtcdConfiguration.Count
+		}
+		g, err := newMachineGroup(c, "etcd", etcd.MachineGroupRef, clusterapi.AddedMachines(etcd.Count, currentCount)+clusterapi.DefaultUpgradeMaxSurge)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+
+	currentWorkers := map[string]int{}
+	if current != nil {
+		for _, w := range current.Cluster.Spec.WorkerNodeGroupConfigurations {
+			currentWorkers[w.Name] = clusterapi.WorkerNodeGroupMaxCount(w)
+		}
+	}
+	for _, w := range c.Cluster.Spec.WorkerNodeGroupConfigurations {
+		machines := clusterapi.AddedMachines(clusterapi.WorkerNodeGroupMaxCount(w), currentWorkers[w.Name]) + clusterapi.WorkerNodeGroupUpgradeMaxSurge(w.UpgradeRolloutStrategy)
+		g, err := newMachineGroup(c, fmt.Sprintf("worker node group %s", w.Name), w.MachineGroupRef, machines)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
+
+func newMachineGroup(c *cluster.Config, name string, ref *v1alpha1.Ref, machines int) (machineGroup, error) {
+	if ref == nil {
+		return machineGroup{}, fmt.Errorf("machineGroupRef is not set for %s", name)
+	}
+
+	m, ok := c.SnowMachineConfigs[ref.Name]
+	if !ok {
+		return machineGroup{}, fmt.Errorf("SnowMachineConfig [%s] not found for %s", ref.Name, name)
+	}
+
+	return machineGroup{name: name, machineConfig: m, machines: machines}, nil
+}
+
+// machineStorageGiB returns the storage, in GiB, requested by the data volumes of a machine. The
+// root volume size comes from the machine image.
+func machineStorageGiB(m *v1alpha1.SnowMachineConfig) int64 {
+	var size int64
+	if m.Spec.ContainersVolume != nil {
+		size += m.Spec.ContainersVolume.Size
+	}
+	for _, v := range m.Spec.NonRootVolumes {
+		if v != nil {
+			size += v.Size
+		}
+	}
+	return size
+}
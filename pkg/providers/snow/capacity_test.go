@@ -0,0 +1,300 @@
+package snow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/aws"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/providers/snow"
+	snowv1 "github.com/aws/eks-anywhere/pkg/providers/snow/api/v1beta1"
+	"github.com/aws/eks-anywhere/pkg/providers/snow/mocks"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+)
+
+type capacityTest struct {
+	*WithT
+	ctx       context.Context
+	device1   *mocks.MockAwsClient
+	device2   *mocks.MockAwsClient
+	validator *snow.Validator
+	config    *cluster.Config
+}
+
+func newCapacityTest(t *testing.T) *capacityTest {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	device1 := mocks.NewMockAwsClient(ctrl)
+	device2 := mocks.NewMockAwsClient(ctrl)
+	mockClientRegistry := mocks.NewMockClientRegistry(ctrl)
+	mockClientRegistry.EXPECT().Get(ctx).Return(snow.AwsClientMap{
+		"device-1": device1,
+		"device-2": device2,
+	}, nil).AnyTimes()
+
+	machineConfig := func(name string) *v1alpha1.SnowMachineConfig {
+		return &v1alpha1.SnowMachineConfig{
+			ObjectMeta: v1.ObjectMeta{Name: name},
+			Spec: v1alpha1.SnowMachineConfigSpec{
+				InstanceType: "sbe-c.xlarge",
+				Devices:      []string{"device-1", "device-2"},
+				ContainersVolume: &snowv1.Volume{
+					Size: 25,
+				},
+			},
+		}
+	}
+
+	config := &cluster.Config{
+		Cluster: &v1alpha1.Cluster{
+			Spec: v1alpha1.ClusterSpec{
+				ControlPlaneConfiguration: v1alpha1.ControlPlaneConfiguration{
+					Count:           3,
+					MachineGroupRef: &v1alpha1.Ref{Name: "cp"},
+				},
+				WorkerNodeGroupConfigurations: []v1alpha1.WorkerNodeGroupConfiguration{
+					{
+						Name:            "md-0",
+						Count:           ptr.Int(2),
+						MachineGroupRef: &v1alpha1.Ref{Name: "worker"},
+					},
+				},
+			},
+		},
+		SnowMachineConfigs: map[string]*v1alpha1.SnowMachineConfig{
+			"cp":     machineConfig("cp"),
+			"worker": machineConfig("worker"),
+		},
+	}
+
+	return &capacityTest{
+		WithT:     NewWithT(t),
+		ctx:       ctx,
+		device1:   device1,
+		device2:   device2,
+		validator: snow.NewValidator(mockClientRegistry),
+		config:    config,
+	}
+}
+
+func (tt *capacityTest) expectDevices(device1, device2 *aws.SnowballDeviceCapacity) {
+	tt.device1.EXPECT().SnowballDeviceCapacity(tt.ctx).Return(device1, nil)
+	tt.device1.EXPECT().EC2InstanceTypes(tt.ctx).Return(supportedInstanceTypes(), nil)
+	tt.device2.EXPECT().SnowballDeviceCapacity(tt.ctx).Return(device2, nil)
+	tt.device2.EXPECT().EC2InstanceTypes(tt.ctx).Return(supportedInstanceTypes(), nil)
+}
+
+func TestPlanCapacityFits(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.expectDevices(deviceCapacity(), deviceCapacity())
+
+	plan, err := tt.validator.PlanCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(plan.Fits()).To(BeTrue())
+	tt.Expect(plan.Devices).To(HaveLen(2))
+
+	// 4 control plane and 3 worker machines, including the rolling upgrade surge,
+	// spread across both devices.
+	tt.Expect(plan.Devices[0].Placements).To(Equal([]snow.MachinePlacement{
+		{Group: "control plane", Machines: 2},
+		{Group: "worker node group md-0", Machines: 2},
+	}))
+	tt.Expect(plan.Devices[1].Placements).To(Equal([]snow.MachinePlacement{
+		{Group: "control plane", Machines: 2},
+		{Group: "worker node group md-0", Machines: 1},
+	}))
+	tt.Expect(plan.Devices[0].Requested).To(Equal(snow.Resources{VCPU: 16, MemoryMiB: 65536, StorageGiB: 100}))
+	tt.Expect(plan.Devices[1].Requested).To(Equal(snow.Resources{VCPU: 12, MemoryMiB: 49152, StorageGiB: 75}))
+}
+
+func TestPlanCapacityShortfall(t *testing.T) {
+	tt := newCapacityTest(t)
+	small := &aws.SnowballDeviceCapacity{
+		VCPU:         8,
+		MemoryBytes:  64 << 30,
+		StorageBytes: 1 << 40,
+	}
+	tt.expectDevices(small, small)
+
+	plan, err := tt.validator.PlanCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(plan.Fits()).To(BeFalse())
+	tt.Expect(plan.Shortfalls).To(Equal([]snow.CapacityShortfall{
+		{
+			Group:      "worker node group md-0",
+			Machines:   3,
+			PerMachine: snow.Resources{VCPU: 4, MemoryMiB: 16384, StorageGiB: 25},
+			Devices:    []string{"device-1", "device-2"},
+		},
+	}))
+}
+
+func TestPlanCapacityRolloutStrategy(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.config.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	tt.config.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+		Type: v1alpha1.InPlaceStrategyType,
+	}
+	tt.config.Cluster.Spec.WorkerNodeGroupConfigurations[0].AutoScalingConfiguration = &v1alpha1.AutoScalingConfiguration{
+		MinCount: 1,
+		MaxCount: 4,
+	}
+	tt.config.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+		Type: v1alpha1.RollingUpdateStrategyType,
+		RollingUpdate: &v1alpha1.WorkerNodesRollingUpdateParams{
+			MaxSurge: 2,
+		},
+	}
+	tt.expectDevices(deviceCapacity(), deviceCapacity())
+
+	plan, err := tt.validator.PlanCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(plan.Devices[0].Requested.VCPU + plan.Devices[1].Requested.VCPU).To(Equal(int64(4 * (1 + 6))))
+}
+
+func TestPlanCapacityIncludesImageRootVolume(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.config.SnowMachineConfigs["cp"].Spec.AMIID = "ami-1"
+	tt.config.SnowMachineConfigs["worker"].Spec.AMIID = "ami-1"
+	tt.device1.EXPECT().EC2ImageRootVolumeSize(tt.ctx, "ami-1").Return(int64(20), nil)
+	tt.device2.EXPECT().EC2ImageRootVolumeSize(tt.ctx, "ami-1").Return(int64(20), nil)
+	small := &aws.SnowballDeviceCapacity{
+		VCPU:         104,
+		MemoryBytes:  416 << 30,
+		StorageBytes: 100 << 30,
+	}
+	tt.expectDevices(small, small)
+
+	plan, err := tt.validator.PlanCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(plan.Fits()).To(BeFalse())
+	tt.Expect(plan.Shortfalls).To(Equal([]snow.CapacityShortfall{
+		{
+			Group:      "worker node group md-0",
+			Machines:   3,
+			PerMachine: snow.Resources{VCPU: 4, MemoryMiB: 16384, StorageGiB: 45},
+			Devices:    []string{"device-1", "device-2"},
+		},
+	}))
+}
+
+func TestPlanCapacityImageRootVolumeError(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.config.SnowMachineConfigs["cp"].Spec.AMIID = "ami-1"
+	tt.device1.EXPECT().EC2ImageRootVolumeSize(tt.ctx, "ami-1").Return(int64(0), errors.New("describe image error"))
+
+	_, err := tt.validator.PlanCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(MatchError(ContainSubstring("fetching root volume size of image [ami-1] in device [device-1]: describe image error")))
+}
+
+func TestPlanUpgradeCapacityScaleUp(t *testing.T) {
+	tt := newCapacityTest(t)
+	current := tt.config.DeepCopy()
+	tt.config.Cluster.Spec.ControlPlaneConfiguration.Count = 1
+	tt.config.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(4)
+	tt.config.Cluster.Spec.WorkerNodeGroupConfigurations = append(tt.config.Cluster.Spec.WorkerNodeGroupConfigurations,
+		v1alpha1.WorkerNodeGroupConfiguration{
+			Name:            "md-1",
+			Count:           ptr.Int(1),
+			MachineGroupRef: &v1alpha1.Ref{Name: "worker"},
+		},
+	)
+	tt.expectDevices(deviceCapacity(), deviceCapacity())
+
+	plan, err := tt.validator.PlanUpgradeCapacity(tt.ctx, tt.config, current)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(plan.Fits()).To(BeTrue())
+
+	// The surge of the scaled down control plane, the 2 machines added to md-0 plus its surge
+	// and all the machines of the new md-1 plus its surge.
+	tt.Expect(plan.Devices[0].Placements).To(ConsistOf(
+		snow.MachinePlacement{Group: "control plane", Machines: 1},
+		snow.MachinePlacement{Group: "worker node group md-0", Machines: 1},
+		snow.MachinePlacement{Group: "worker node group md-1", Machines: 1},
+	))
+	tt.Expect(plan.Devices[1].Placements).To(ConsistOf(
+		snow.MachinePlacement{Group: "worker node group md-0", Machines: 2},
+		snow.MachinePlacement{Group: "worker node group md-1", Machines: 1},
+	))
+}
+
+func TestPlanUpgradeCapacityInPlace(t *testing.T) {
+	tt := newCapacityTest(t)
+	current := tt.config.DeepCopy()
+	tt.config.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &v1alpha1.ControlPlaneUpgradeRolloutStrategy{
+		Type: v1alpha1.InPlaceStrategyType,
+	}
+	tt.config.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &v1alpha1.WorkerNodesUpgradeRolloutStrategy{
+		Type: v1alpha1.InPlaceStrategyType,
+	}
+
+	plan, err := tt.validator.PlanUpgradeCapacity(tt.ctx, tt.config, current)
+	tt.Expect(err).To(Succeed())
+	tt.Expect(plan.Fits()).To(BeTrue())
+	tt.Expect(plan.Devices).To(BeEmpty())
+}
+
+func TestPlanCapacityDeviceCapacityError(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.device1.EXPECT().SnowballDeviceCapacity(tt.ctx).Return(nil, errors.New("describe device error"))
+
+	_, err := tt.validator.PlanCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(MatchError(ContainSubstring("fetching capacity for device [device-1]")))
+}
+
+func TestPlanCapacityUnsupportedInstanceType(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.config.SnowMachineConfigs["cp"].Spec.InstanceType = "sbe-c.metal"
+	tt.expectDevices(deviceCapacity(), deviceCapacity())
+
+	_, err := tt.validator.PlanCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(MatchError(ContainSubstring("the instance type [sbe-c.metal] is not supported in device [device-1]")))
+}
+
+func TestPlanCapacityMachineConfigNotFound(t *testing.T) {
+	tt := newCapacityTest(t)
+	delete(tt.config.SnowMachineConfigs, "worker")
+
+	_, err := tt.validator.PlanCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(MatchError(ContainSubstring("SnowMachineConfig [worker] not found for worker node group md-0")))
+}
+
+func TestValidateCapacityShortfall(t *testing.T) {
+	tt := newCapacityTest(t)
+	tt.config.Cluster.Spec.ExternalEtcdConfiguration = &v1alpha1.ExternalEtcdConfiguration{
+		Count:           3,
+		MachineGroupRef: &v1alpha1.Ref{Name: "cp"},
+	}
+	small := &aws.SnowballDeviceCapacity{
+		VCPU:         16,
+		MemoryBytes:  64 << 30,
+		StorageBytes: 1 << 40,
+	}
+	tt.expectDevices(small, small)
+
+	err := tt.validator.ValidateCapacity(tt.ctx, tt.config)
+	tt.Expect(err).To(MatchError(ContainSubstring("insufficient capacity on snow devices")))
+	tt.Expect(err).To(MatchError(ContainSubstring("3 worker node group md-0 machine(s) requiring 4 vCPU, 16384 MiB memory, 25 GiB storage each don't fit on devices [device-1, device-2]")))
+}
+
+func TestValidateUpgradeCapacityShortfall(t *testing.T) {
+	tt := newCapacityTest(t)
+	current := tt.config.DeepCopy()
+	tt.config.Cluster.Spec.WorkerNodeGroupConfigurations[0].Count = ptr.Int(5)
+	small := &aws.SnowballDeviceCapacity{
+		VCPU:         8,
+		MemoryBytes:  64 << 30,
+		StorageBytes: 1 << 40,
+	}
+	tt.expectDevices(small, small)
+
+	err := tt.validator.ValidateUpgradeCapacity(tt.ctx, tt.config, current)
+	tt.Expect(err).To(MatchError(ContainSubstring("insufficient capacity on snow devices")))
+	tt.Expect(err).To(MatchError(ContainSubstring("1 worker node group md-0 machine(s) requiring 4 vCPU, 16384 MiB memory, 25 GiB storage each don't fit on devices [device-1, device-2]")))
+}
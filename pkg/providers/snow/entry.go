@@ -36,6 +36,18 @@ func (cm *ConfigManager) SetDefaultsAndValidate(ctx context.Context, config *clu
 	return nil
 }
 
+// ValidateCapacity validates the snow devices have enough capacity available to run all the
+// machines of the cluster.
+func (cm *ConfigManager) ValidateCapacity(ctx context.Context, config *cluster.Config) error {
+	return cm.validator.ValidateCapacity(ctx, config)
+}
+
+// ValidateUpgradeCapacity validates the snow devices have enough capacity available to run the
+// machines an upgrade from current to config adds to the cluster.
+func (cm *ConfigManager) ValidateUpgradeCapacity(ctx context.Context, config, current *cluster.Config) error {
+	return cm.validator.ValidateUpgradeCapacity(ctx, config, current)
+}
+
 func (cm *ConfigManager) snowEntry(ctx context.Context) *cluster.ConfigManagerEntry {
 	return &cluster.ConfigManagerEntry{
 		Defaulters: []cluster.Defaulter{
@@ -50,6 +50,21 @@ func (mr *MockAwsClientMockRecorder) EC2ImageExists(ctx, imageID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2ImageExists", reflect.TypeOf((*MockAwsClient)(nil).EC2ImageExists), ctx, imageID)
 }
 
+// EC2ImageRootVolumeSize mocks base method.
+func (m *MockAwsClient) EC2ImageRootVolumeSize(ctx context.Context, imageID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EC2ImageRootVolumeSize", ctx, imageID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EC2ImageRootVolumeSize indicates an expected call of EC2ImageRootVolumeSize.
+func (mr *MockAwsClientMockRecorder) EC2ImageRootVolumeSize(ctx, imageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EC2ImageRootVolumeSize", reflect.TypeOf((*MockAwsClient)(nil).EC2ImageRootVolumeSize), ctx, imageID)
+}
+
 // EC2ImportKeyPair mocks base method.
 func (m *MockAwsClient) EC2ImportKeyPair(ctx context.Context, keyName string, keyMaterial []byte) error {
 	m.ctrl.T.Helper()
@@ -109,6 +124,21 @@ func (mr *MockAwsClientMockRecorder) IsSnowballDeviceUnlocked(ctx interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSnowballDeviceUnlocked", reflect.TypeOf((*MockAwsClient)(nil).IsSnowballDeviceUnlocked), ctx)
 }
 
+// SnowballDeviceCapacity mocks base method.
+func (m *MockAwsClient) SnowballDeviceCapacity(ctx context.Context) (*aws.SnowballDeviceCapacity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnowballDeviceCapacity", ctx)
+	ret0, _ := ret[0].(*aws.SnowballDeviceCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnowballDeviceCapacity indicates an expected call of SnowballDeviceCapacity.
+func (mr *MockAwsClientMockRecorder) SnowballDeviceCapacity(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnowballDeviceCapacity", reflect.TypeOf((*MockAwsClient)(nil).SnowballDeviceCapacity), ctx)
+}
+
 // SnowballDeviceSoftwareVersion mocks base method.
 func (m *MockAwsClient) SnowballDeviceSoftwareVersion(ctx context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -22,6 +22,11 @@ type CNIReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
 }
 
+// ServiceLoadBalancerReconciler is an interface for reconciling the built-in service load balancer in the Snow cluster reconciler.
+type ServiceLoadBalancerReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *cluster.Spec) (controller.Result, error)
+}
+
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
 }
@@ -32,21 +37,23 @@ type IPValidator interface {
 }
 
 type Reconciler struct {
-	client               client.Client
-	cniReconciler        CNIReconciler
-	remoteClientRegistry RemoteClientRegistry
-	ipValidator          IPValidator
+	client                        client.Client
+	cniReconciler                 CNIReconciler
+	remoteClientRegistry          RemoteClientRegistry
+	ipValidator                   IPValidator
+	serviceLoadBalancerReconciler ServiceLoadBalancerReconciler
 	*serverside.ObjectApplier
 }
 
 // New initializes a new reconciler for the Snow provider.
-func New(client client.Client, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+func New(client client.Client, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator, serviceLoadBalancerReconciler ServiceLoadBalancerReconciler) *Reconciler {
 	return &Reconciler{
-		client:               client,
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		ipValidator:          ipValidator,
-		ObjectApplier:        serverside.NewObjectApplier(client),
+		client:                        client,
+		cniReconciler:                 cniReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		ipValidator:                   ipValidator,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		ObjectApplier:                 serverside.NewObjectApplier(client),
 	}
 }
 
@@ -65,6 +72,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, c *anywhere
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
+		r.ReconcileServiceLoadBalancer,
 	).Run(ctx, log, clusterSpec)
 }
 
@@ -115,6 +123,17 @@ func (s *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, clusterS
 	return s.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
 
+// ReconcileServiceLoadBalancer installs and configures the built-in service load balancer when the cluster has one.
+func (s *Reconciler) ReconcileServiceLoadBalancer(ctx context.Context, log logr.Logger, spec *cluster.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileServiceLoadBalancer")
+	client, err := s.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(spec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return s.serviceLoadBalancerReconciler.Reconcile(ctx, log, client, spec)
+}
+
 func (s *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, clusterSpec *cluster.Spec) (controller.Result, error) {
 	log = log.WithValues("phase", "reconcileWorkers")
 	log.Info("Applying worker CAPI objects")
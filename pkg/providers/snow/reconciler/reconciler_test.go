@@ -55,8 +55,9 @@ func TestReconcilerReconcileSuccess(t *testing.T) {
 
 	tt.remoteClientRegistry.EXPECT().GetClient(
 		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
-	).Return(remoteClient, nil)
+	).Return(remoteClient, nil).Times(2)
 	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
 
 	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
 
@@ -220,6 +221,42 @@ func TestReconcilerReconcileCNISuccess(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcilerReconcileServiceLoadBalancerSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcilerReconcileServiceLoadBalancerErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
 func TestReconcilerReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.withFakeClient()
@@ -243,22 +280,24 @@ type reconcilerTest struct {
 	t testing.TB
 	*WithT
 	*envtest.APIExpecter
-	ctx                       context.Context
-	cniReconciler             *mocks.MockCNIReconciler
-	remoteClientRegistry      *mocks.MockRemoteClientRegistry
-	ipValidator               *mocks.MockIPValidator
-	cluster                   *anywherev1.Cluster
-	client                    client.Client
-	env                       *envtest.Environment
-	eksaSupportObjs           []client.Object
-	machineConfigControlPlane *anywherev1.SnowMachineConfig
-	machineConfigWorker       *anywherev1.SnowMachineConfig
-	kcp                       *controlplanev1.KubeadmControlPlane
+	ctx                           context.Context
+	cniReconciler                 *mocks.MockCNIReconciler
+	serviceLoadBalancerReconciler *mocks.MockServiceLoadBalancerReconciler
+	remoteClientRegistry          *mocks.MockRemoteClientRegistry
+	ipValidator                   *mocks.MockIPValidator
+	cluster                       *anywherev1.Cluster
+	client                        client.Client
+	env                           *envtest.Environment
+	eksaSupportObjs               []client.Object
+	machineConfigControlPlane     *anywherev1.SnowMachineConfig
+	machineConfigWorker           *anywherev1.SnowMachineConfig
+	kcp                           *controlplanev1.KubeadmControlPlane
 }
 
 func newReconcilerTest(t testing.TB) *reconcilerTest {
 	ctrl := gomock.NewController(t)
 	cniReconciler := mocks.NewMockCNIReconciler(ctrl)
+	serviceLoadBalancerReconciler := mocks.NewMockServiceLoadBalancerReconciler(ctrl)
 	remoteClientRegistry := mocks.NewMockRemoteClientRegistry(ctrl)
 	ipValidator := mocks.NewMockIPValidator(ctrl)
 	c := env.Client()
@@ -365,15 +404,16 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 	})
 
 	tt := &reconcilerTest{
-		t:                    t,
-		WithT:                NewWithT(t),
-		APIExpecter:          envtest.NewAPIExpecter(t, c),
-		ctx:                  context.Background(),
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		ipValidator:          ipValidator,
-		client:               c,
-		env:                  env,
+		t:                             t,
+		WithT:                         NewWithT(t),
+		APIExpecter:                   envtest.NewAPIExpecter(t, c),
+		ctx:                           context.Background(),
+		cniReconciler:                 cniReconciler,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		ipValidator:                   ipValidator,
+		client:                        c,
+		env:                           env,
 		eksaSupportObjs: []client.Object{
 			test.Namespace(clusterNamespace),
 			test.Namespace(constants.EksaSystemNamespace),
@@ -415,7 +455,7 @@ func (tt *reconcilerTest) withFakeClient() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+	return reconciler.New(tt.client, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator, tt.serviceLoadBalancerReconciler)
 }
 
 func (tt *reconcilerTest) createAllObjs() {
@@ -548,22 +548,34 @@ func supportedInstanceTypes() []aws.EC2InstanceType {
 		{
 			Name:        "sbe-c.large",
 			DefaultVCPU: ptr.Int32(2),
+			MemoryMiB:   ptr.Int64(8192),
 		},
 		{
 			Name:        "sbe-c.xlarge",
 			DefaultVCPU: ptr.Int32(4),
+			MemoryMiB:   ptr.Int64(16384),
 		},
 	}
 }
 
+func deviceCapacity() *aws.SnowballDeviceCapacity {
+	return &aws.SnowballDeviceCapacity{
+		VCPU:         104,
+		MemoryBytes:  416 << 30,
+		StorageBytes: 39 << 40,
+	}
+}
+
 func TestSetupAndValidateCreateClusterSuccess(t *testing.T) {
 	tt := newSnowTest(t)
 	setupContext(t)
 	tt.aws.EXPECT().EC2ImageExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
 	tt.aws.EXPECT().EC2KeyNameExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
-	tt.aws.EXPECT().EC2InstanceTypes(tt.ctx).Return(supportedInstanceTypes(), nil).Times(4)
+	tt.aws.EXPECT().EC2InstanceTypes(tt.ctx).Return(supportedInstanceTypes(), nil).Times(6)
 	tt.aws.EXPECT().IsSnowballDeviceUnlocked(tt.ctx).Return(true, nil).Times(4)
 	tt.aws.EXPECT().SnowballDeviceSoftwareVersion(tt.ctx).Return("102", nil).Times(4)
+	tt.aws.EXPECT().SnowballDeviceCapacity(tt.ctx).Return(deviceCapacity(), nil).Times(2)
+	tt.aws.EXPECT().EC2ImageRootVolumeSize(tt.ctx, gomock.Any()).Return(int64(25), nil).Times(2)
 	tt.imds.EXPECT().EC2InstanceIP(tt.ctx).Return("1.2.3.5", nil)
 	err := tt.provider.SetupAndValidateCreateCluster(tt.ctx, tt.clusterSpec)
 	tt.Expect(tt.clusterSpec.SnowCredentialsSecret).To(Equal(wantEksaCredentialsSecretWithEnvCreds()))
@@ -690,9 +702,11 @@ func TestSetupAndValidateUpgradeClusterSuccess(t *testing.T) {
 	setupContext(t)
 	tt.aws.EXPECT().EC2ImageExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
 	tt.aws.EXPECT().EC2KeyNameExists(tt.ctx, gomock.Any()).Return(true, nil).Times(4)
-	tt.aws.EXPECT().EC2InstanceTypes(tt.ctx).Return(supportedInstanceTypes(), nil).Times(4)
+	tt.aws.EXPECT().EC2InstanceTypes(tt.ctx).Return(supportedInstanceTypes(), nil).Times(6)
 	tt.aws.EXPECT().IsSnowballDeviceUnlocked(tt.ctx).Return(true, nil).Times(4)
 	tt.aws.EXPECT().SnowballDeviceSoftwareVersion(tt.ctx).Return("102", nil).Times(4)
+	tt.aws.EXPECT().SnowballDeviceCapacity(tt.ctx).Return(deviceCapacity(), nil).Times(2)
+	tt.aws.EXPECT().EC2ImageRootVolumeSize(tt.ctx, gomock.Any()).Return(int64(25), nil).Times(2)
 	tt.imds.EXPECT().EC2InstanceIP(tt.ctx).Return("1.2.3.5", nil)
 	err := tt.provider.SetupAndValidateUpgradeCluster(tt.ctx, tt.cluster, tt.clusterSpec, tt.clusterSpec)
 	tt.Expect(tt.clusterSpec.SnowCredentialsSecret).To(Equal(wantEksaCredentialsSecretWithEnvCreds()))
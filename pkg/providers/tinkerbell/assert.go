@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta1"
 
@@ -197,6 +198,46 @@ func HardwareSatisfiesOnlyOneSelectorAssertion(catalogue *hardware.Catalogue) Cl
 	}
 }
 
+// ValidatedHardwareAssertion ensures that, when the datacenter requires validated hardware, no
+// hardware in catalogue selectable by the MachineConfigs of spec is missing a burn-in validation
+// result. Machine templates only exclude hardware that is pending or quarantined, so hardware that
+// never went through burn-in validation would otherwise be provisioned. It runs both in the CLI
+// and in the cluster controller.
+func ValidatedHardwareAssertion(catalogue *hardware.Catalogue) ClusterSpecAssertion {
+	return func(spec *ClusterSpec) error {
+		if !requiresValidatedHardware(spec) {
+			return nil
+		}
+
+		selectors, err := selectorsFromClusterSpec(spec)
+		if err != nil {
+			return err
+		}
+
+		var unvalidated []string
+		for _, h := range catalogue.AllHardware() {
+			if _, ok := h.Labels[hardware.ValidationStatusLabel]; ok {
+				continue
+			}
+			for _, selector := range selectors {
+				if hardware.LabelsMatchSelector(selector, h.Labels) {
+					unvalidated = append(unvalidated, h.Name)
+					break
+				}
+			}
+		}
+
+		if len(unvalidated) > 0 {
+			return fmt.Errorf(
+				"hardware selectable by machine configs has not been validated while requireValidatedHardware is set: %v",
+				strings.Join(unvalidated, ", "),
+			)
+		}
+
+		return nil
+	}
+}
+
 // selectorsFromClusterSpec extracts all selectors specified on MachineConfig's from spec.
 // When HardwareAffinity is used, it extracts matchLabels from Required terms.
 func selectorsFromClusterSpec(spec *ClusterSpec) (selectorSet, error) {
@@ -283,7 +324,7 @@ func MinimumHardwareAvailableAssertionForCreate(catalogue *hardware.Catalogue) C
 			}
 		}
 
-		return validateMinimumHardwareRequirements(requirements, catalogue)
+		return validateMinimumHardwareRequirements(requirements, catalogue, requiresValidatedHardware(spec))
 	}
 }
 
@@ -452,7 +493,7 @@ func AssertionsForScaleUpDown(catalogue *hardware.Catalogue, current Validatable
 			}
 		}
 
-		if err := validateMinimumHardwareRequirements(requirements, catalogue); err != nil {
+		if err := validateMinimumHardwareRequirements(requirements, catalogue, requiresValidatedHardware(spec)); err != nil {
 			return fmt.Errorf("for scale up, %v", err)
 		}
 		return nil
@@ -487,7 +528,7 @@ func ExtraHardwareAvailableAssertionForRollingUpgrade(catalogue *hardware.Catalo
 			return fmt.Errorf("external etcd upgrade is not supported")
 		}
 
-		if err := validateMinimumHardwareRequirements(requirements, catalogue); err != nil {
+		if err := validateMinimumHardwareRequirements(requirements, catalogue, requiresValidatedHardware(spec)); err != nil {
 			return fmt.Errorf("for rolling upgrade, %v", err)
 		}
 		return nil
@@ -568,8 +609,8 @@ func hasHardwareSelection(config *v1alpha1.TinkerbellMachineConfig) bool {
 // ExtraHardwareAvailableAssertionForNodeRollOut asserts catalogue has sufficient hardware to meet minimum requirement
 // and is component agnostic between Control Plane and worker nodes.
 func ExtraHardwareAvailableAssertionForNodeRollOut(catalogue *hardware.Catalogue, hwReq MinimumHardwareRequirements) ClusterSpecAssertion {
-	return func(_ *ClusterSpec) error {
-		if err := validateMinimumHardwareRequirements(hwReq, catalogue); err != nil {
+	return func(spec *ClusterSpec) error {
+		if err := validateMinimumHardwareRequirements(hwReq, catalogue, requiresValidatedHardware(spec)); err != nil {
 			return fmt.Errorf("for node rollout, %v", err)
 		}
 		return nil
@@ -650,6 +650,98 @@ func TestMinimumHardwareAvailableAssertionForCreate_SufficientSucceedsWithoutExt
 	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
 }
 
+func TestMinimumHardwareAvailableAssertionForCreate_QuarantinedHardwareFails(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	clusterSpec.WorkerNodeGroupConfigurations()[0].Count = ptr.Int(0)
+
+	catalogue := hardware.NewCatalogue()
+
+	labels := map[string]string{hardware.ValidationStatusLabel: hardware.ValidationQuarantined}
+	for k, v := range clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector {
+		labels[k] = v
+	}
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{Labels: labels},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.MinimumHardwareAvailableAssertionForCreate(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("minimum hardware count not met")))
+}
+
+func TestMinimumHardwareAvailableAssertionForCreate_RequireValidatedHardware(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+	clusterSpec.WorkerNodeGroupConfigurations()[0].Count = ptr.Int(0)
+	clusterSpec.DatacenterConfig.Spec.RequireValidatedHardware = true
+
+	catalogue := hardware.NewCatalogue()
+
+	// Hardware pending validation must not be counted.
+	pending := map[string]string{hardware.ValidationStatusLabel: hardware.ValidationPending}
+	for k, v := range clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector {
+		pending[k] = v
+	}
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{Name: "pending", Labels: pending},
+	})).To(gomega.Succeed())
+
+	assertion := tinkerbell.MinimumHardwareAvailableAssertionForCreate(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(gomega.ContainSubstring("minimum validated hardware count not met")))
+
+	validated := map[string]string{hardware.ValidationStatusLabel: hardware.ValidationValidated}
+	for k, v := range clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector {
+		validated[k] = v
+	}
+	g.Expect(catalogue.InsertHardware(&v1alpha1.Hardware{
+		ObjectMeta: v1.ObjectMeta{Name: "validated", Labels: validated},
+	})).To(gomega.Succeed())
+
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+}
+
+func TestValidatedHardwareAssertion(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	clusterSpec := NewDefaultValidClusterSpecBuilder().Build()
+	clusterSpec.Spec.Cluster.Spec.ExternalEtcdConfiguration = nil
+
+	newHardware := func(name, status string) *v1alpha1.Hardware {
+		labels := map[string]string{}
+		for k, v := range clusterSpec.ControlPlaneMachineConfig().Spec.HardwareSelector {
+			labels[k] = v
+		}
+		if status != "" {
+			labels[hardware.ValidationStatusLabel] = status
+		}
+		return &v1alpha1.Hardware{ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	catalogue := hardware.NewCatalogue()
+	for _, h := range []*v1alpha1.Hardware{
+		newHardware("validated", hardware.ValidationValidated),
+		newHardware("pending", hardware.ValidationPending),
+		newHardware("quarantined", hardware.ValidationQuarantined),
+		newHardware("unvalidated", ""),
+		// Hardware no machine config selects is ignored.
+		{ObjectMeta: v1.ObjectMeta{Name: "unselected"}},
+	} {
+		g.Expect(catalogue.InsertHardware(h)).To(gomega.Succeed())
+	}
+
+	assertion := tinkerbell.ValidatedHardwareAssertion(catalogue)
+	g.Expect(assertion(clusterSpec)).To(gomega.Succeed())
+
+	clusterSpec.DatacenterConfig.Spec.RequireValidatedHardware = true
+	g.Expect(assertion(clusterSpec)).To(gomega.MatchError(
+		"hardware selectable by machine configs has not been validated while requireValidatedHardware is set: unvalidated",
+	))
+}
+
 func TestMinimumHardwareAvailableAssertionForCreate_NoControlPlaneSelectorMatchesAnything(t *testing.T) {
 	g := gomega.NewWithT(t)
 
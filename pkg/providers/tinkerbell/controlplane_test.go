@@ -17,6 +17,7 @@ import (
 	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
 	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
 	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 )
 
 const (
@@ -72,6 +73,68 @@ func TestControlPlaneSpecNewCluster(t *testing.T) {
 	g.Expect(cp.ControlPlaneMachineTemplate.Name).To(Equal("test-control-plane-1"))
 }
 
+func TestControlPlaneSpecExcludesIneligibleHardware(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, testClusterConfigFilename)
+
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	affinity := cp.ControlPlaneMachineTemplate.Spec.Template.Spec.HardwareAffinity
+	g.Expect(affinity).NotTo(BeNil())
+	g.Expect(affinity.Required).To(HaveLen(1))
+	g.Expect(affinity.Required[0].LabelSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+		Key:      hardware.ValidationStatusLabel,
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values:   []string{hardware.ValidationPending, hardware.ValidationQuarantined},
+	}))
+
+	// The user's machine config must not be modified.
+	cpMachineConfig := spec.TinkerbellMachineConfigs[spec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name]
+	g.Expect(cpMachineConfig.Spec.HardwareAffinity).To(BeNil())
+}
+
+func TestControlPlaneSpecRequireValidatedHardwareNoChangesMachineTemplates(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, testClusterConfigFilename)
+	spec.TinkerbellDatacenter.Spec.RequireValidatedHardware = true
+	originalCPMachineTemplate := tinkerbellMachineTemplate("test-control-plane-1")
+	expectedCPTemplate := originalCPMachineTemplate.DeepCopy()
+
+	client := test.NewFakeKubeClient(
+		kubeadmControlPlane(),
+		originalCPMachineTemplate,
+	)
+
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp.ControlPlaneMachineTemplate).To(Equal(expectedCPTemplate))
+}
+
+func TestControlPlaneSpecInPlaceOSImageUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	client := test.NewFakeKubeClient()
+	spec := test.NewFullClusterSpec(t, testClusterConfigFilename)
+	spec.TinkerbellDatacenter.Spec.OSImageURL = "http://tinkerbell-example:8080/ubuntu-2204-kube-1-28.gz"
+	spec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy = &anywherev1.ControlPlaneUpgradeRolloutStrategy{
+		Type: anywherev1.InPlaceStrategyType,
+		InPlace: &anywherev1.InPlaceUpgradeParams{
+			OSImageUpgrade: true,
+		},
+	}
+
+	cp, err := ControlPlaneSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cp.KubeadmControlPlane.Annotations).To(HaveKeyWithValue(constants.InPlaceOSImageURLAnnotation, "http://tinkerbell-example:8080/ubuntu-2204-kube-1-28.gz"))
+}
+
 func TestControlPlaneSpecNoChangesMachineTemplates(t *testing.T) {
 	g := NewWithT(t)
 	logger := test.NewNullLogger()
@@ -129,7 +192,10 @@ func TestControlPlaneSpecUpdateMachineTemplates(t *testing.T) {
 	expectedCPTemplate.Spec.Template.Spec.HardwareAffinity = &tinkerbellv1.HardwareAffinity{
 		Required: []tinkerbellv1.HardwareAffinityTerm{
 			{
-				LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"type": "cp"}},
+				LabelSelector: metav1.LabelSelector{
+					MatchLabels:      map[string]string{"type": "cp"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{eligibleHardwareRequirement},
+				},
 			},
 		},
 	}
@@ -1056,7 +1122,10 @@ func tinkerbellMachineTemplate(name string) *tinkerbellv1.TinkerbellMachineTempl
 					HardwareAffinity: &tinkerbellv1.HardwareAffinity{
 						Required: []tinkerbellv1.HardwareAffinityTerm{
 							{
-								LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"type": "cp"}},
+								LabelSelector: metav1.LabelSelector{
+									MatchLabels:      map[string]string{"type": "cp"},
+									MatchExpressions: []metav1.LabelSelectorRequirement{eligibleHardwareRequirement},
+								},
 							},
 						},
 					},
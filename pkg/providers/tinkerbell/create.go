@@ -192,11 +192,14 @@ func (p *Provider) SetupAndValidateCreateCluster(ctx context.Context, clusterSpe
 			return fmt.Errorf("TinkerbellIP %v does not match management cluster ip %v", p.datacenterConfig.Spec.TinkerbellIP, managementDatacenterConfig.Spec.TinkerbellIP)
 		}
 	}
+	p.labelBIOSMeetsMinimum()
+
 	// TODO(chrisdoherty4) Look to inject the validator. Possibly look to use a builder for
 	// constructing the validations rather than injecting flags into the provider.
 	clusterSpecValidator := NewClusterSpecValidator(
 		MinimumHardwareAvailableAssertionForCreate(p.catalogue),
 		HardwareSatisfiesOnlyOneSelectorAssertion(p.catalogue),
+		ValidatedHardwareAssertion(p.catalogue),
 	)
 
 	clusterSpecValidator.Register(AssertPortsNotInUse(p.netClient))
@@ -268,6 +271,15 @@ func (p *Provider) readCSVToCatalogue() error {
 	if err != nil {
 		return err
 	}
+	machines = hardware.WithInventoryCollection(machines, p.InventoryOptions, logger.Get())
 
 	return hardware.TranslateAll(machines, catalogueWriter, machineValidator)
 }
+
+// labelBIOSMeetsMinimum labels the hardware in the catalogue with whether its recorded BIOS
+// version meets the configured minimum so machine config selectors can use it.
+func (p *Provider) labelBIOSMeetsMinimum() {
+	if p.InventoryOptions != nil {
+		hardware.LabelBIOSMeetsMinimum(p.catalogue, p.InventoryOptions.MinimumBIOSVersion)
+	}
+}
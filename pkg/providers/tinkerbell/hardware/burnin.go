@@ -0,0 +1,229 @@
+package hardware
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// ValidationStatusLabel is the Hardware label recording the result of the burn-in validation
+// workflow.
+const ValidationStatusLabel = InventoryLabelPrefix + "validation"
+
+// Validation statuses applied to Hardware with the ValidationStatusLabel.
+const (
+	// ValidationPending indicates the hardware is awaiting burn-in validation.
+	ValidationPending = "pending"
+	// ValidationValidated indicates the hardware passed burn-in validation.
+	ValidationValidated = "validated"
+	// ValidationQuarantined indicates the hardware failed burn-in validation and must not be used.
+	ValidationQuarantined = "quarantined"
+)
+
+// BurnInHardwareLabel is the Workflow label referencing the Hardware a burn-in workflow validates.
+const BurnInHardwareLabel = InventoryLabelPrefix + "burn-in-hardware"
+
+// BurnInTemplateName is the name of the Tinkerbell Template used for hardware burn-in validation.
+const BurnInTemplateName = "eksa-hardware-burn-in"
+
+// burnInWorkflowTimeout is the timeout, in seconds, of the burn-in validation task.
+const burnInWorkflowTimeout = 7200
+
+// IsQuarantined determines if labels mark hardware as having failed burn-in validation.
+func IsQuarantined(labels map[string]string) bool {
+	return labels[ValidationStatusLabel] == ValidationQuarantined
+}
+
+// IsValidated determines if labels mark hardware as having passed burn-in validation.
+func IsValidated(labels map[string]string) bool {
+	return labels[ValidationStatusLabel] == ValidationValidated
+}
+
+// IsPending determines if labels mark hardware as awaiting burn-in validation.
+func IsPending(labels map[string]string) bool {
+	return labels[ValidationStatusLabel] == ValidationPending
+}
+
+// IsEligible determines if hardware with labels may be used to provision cluster nodes.
+// Hardware that is quarantined or awaiting validation is never eligible. When requireValidated
+// is true only validated hardware is eligible.
+func IsEligible(labels map[string]string, requireValidated bool) bool {
+	if IsQuarantined(labels) || IsPending(labels) {
+		return false
+	}
+	return !requireValidated || IsValidated(labels)
+}
+
+// MarkValidationPending labels m as awaiting burn-in validation unless a validation status is
+// already present.
+func MarkValidationPending(m Machine) Machine {
+	if m.Labels.Has(ValidationStatusLabel) {
+		return m
+	}
+
+	labels := make(Labels, len(m.Labels)+1)
+	for k, v := range m.Labels {
+		labels[k] = v
+	}
+	labels[ValidationStatusLabel] = ValidationPending
+	m.Labels = labels
+
+	return m
+}
+
+// ValidationStatusFromWorkflow returns the validation status for hardware given the state of its
+// burn-in workflow. An empty string is returned for workflows that have not completed.
+func ValidationStatusFromWorkflow(w *tinkv1alpha1.Workflow) string {
+	switch w.Status.State {
+	case tinkv1alpha1.WorkflowStateSuccess:
+		return ValidationValidated
+	case tinkv1alpha1.WorkflowStateFailed, tinkv1alpha1.WorkflowStateTimeout:
+		return ValidationQuarantined
+	default:
+		return ""
+	}
+}
+
+// NewBurnInTemplate creates the Tinkerbell Template that runs disk, memory and NIC checks on
+// hardware. image must contain sh, smartctl, badblocks, memtester and ethtool.
+func NewBurnInTemplate(image string) *tinkv1alpha1.Template {
+	data := burnInTemplateData(image)
+	return &tinkv1alpha1.Template{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: tinkv1alpha1.GroupVersion.String(),
+			Kind:       "Template",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      BurnInTemplateName,
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: tinkv1alpha1.TemplateSpec{
+			Data: &data,
+		},
+	}
+}
+
+// NewBurnInWorkflow creates a Tinkerbell Workflow that runs the burn-in template against the
+// Hardware created for m. The workflow is labeled with the hardware name so its result can be
+// recorded on the Hardware. Machines with a BMC are netbooted automatically, others must be
+// powered on manually.
+func NewBurnInWorkflow(m Machine) *tinkv1alpha1.Workflow {
+	bootOptions := tinkv1alpha1.BootOptions{ToggleAllowNetboot: true}
+	if m.HasBMC() {
+		bootOptions.BootMode = tinkv1alpha1.BootModeNetboot
+	}
+
+	return &tinkv1alpha1.Workflow{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: tinkv1alpha1.GroupVersion.String(),
+			Kind:       "Workflow",
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      BurnInWorkflowName(m.Hostname),
+			Namespace: constants.EksaSystemNamespace,
+			Labels: map[string]string{
+				BurnInHardwareLabel: m.Hostname,
+			},
+		},
+		Spec: tinkv1alpha1.WorkflowSpec{
+			TemplateRef: BurnInTemplateName,
+			HardwareRef: m.Hostname,
+			HardwareMap: map[string]string{
+				"device_1": m.MACAddress,
+				"disk_1":   m.Disk,
+			},
+			BootOptions: bootOptions,
+		},
+	}
+}
+
+// BurnInWorkflowName returns the name of the burn-in Workflow for the named hardware.
+func BurnInWorkflowName(hardwareName string) string {
+	return fmt.Sprintf("%v-burn-in", hardwareName)
+}
+
+func burnInTemplateData(image string) string {
+	actions := []struct {
+		name   string
+		script string
+	}{
+		{
+			name:   "disk-check",
+			script: `smartctl -H {{ .disk_1 }} && badblocks -b 4096 -s {{ .disk_1 }}`,
+		},
+		{
+			name:   "memory-check",
+			script: `memtester "$(awk '/MemAvailable/ {printf "%dK", $2 * 0.9}' /proc/meminfo)" 1`,
+		},
+		{
+			name: "nic-check",
+			script: `iface=$(grep -il {{ .device_1 }} /sys/class/net/*/address | cut -d/ -f5) && ` +
+				`ethtool "$iface" | grep -q "Link detected: yes"`,
+		},
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `version: "0.1"
+name: %v
+global_timeout: %d
+tasks:
+  - name: "burn-in"
+    worker: "{{.device_1}}"
+    actions:
+`, BurnInTemplateName, burnInWorkflowTimeout)
+	for _, a := range actions {
+		fmt.Fprintf(&b, `      - name: %q
+        image: %v
+        timeout: %d
+        pid: host
+        command: ["/bin/sh", "-c", %q]
+`, a.name, image, burnInWorkflowTimeout, a.script)
+	}
+
+	return b.String()
+}
+
+// BurnInManifestYAML is a MachineWriter that writes the burn-in validation Workflow for each
+// machine. The burn-in Template is written before the first Workflow.
+type BurnInManifestYAML struct {
+	writer          io.Writer
+	image           string
+	templateWritten bool
+}
+
+// NewBurnInManifestYAML creates a BurnInManifestYAML instance that writes its manifests to w.
+// image is the container image used to run the burn-in checks.
+func NewBurnInManifestYAML(w io.Writer, image string) *BurnInManifestYAML {
+	return &BurnInManifestYAML{writer: w, image: image}
+}
+
+// Write the burn-in Workflow for m.
+func (bw *BurnInManifestYAML) Write(m Machine) error {
+	if !bw.templateWritten {
+		if err := bw.writeObject(NewBurnInTemplate(bw.image)); err != nil {
+			return fmt.Errorf("writing burn-in template yaml: %v", err)
+		}
+		bw.templateWritten = true
+	}
+
+	if err := bw.writeObject(NewBurnInWorkflow(m)); err != nil {
+		return fmt.Errorf("writing burn-in workflow yaml (mac=%v): %v", m.MACAddress, err)
+	}
+
+	return nil
+}
+
+func (bw *BurnInManifestYAML) writeObject(obj interface{}) error {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = bw.writer.Write(append(data, yamlSeparatorWithNewline...))
+	return err
+}
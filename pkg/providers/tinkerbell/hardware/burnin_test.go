@@ -0,0 +1,114 @@
+package hardware_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+)
+
+func TestIsEligible(t *testing.T) {
+	tests := []struct {
+		name             string
+		labels           map[string]string
+		requireValidated bool
+		want             bool
+	}{
+		{name: "no status", labels: map[string]string{}, want: true},
+		{name: "no status require validated", labels: map[string]string{}, requireValidated: true, want: false},
+		{name: "pending", labels: map[string]string{hardware.ValidationStatusLabel: hardware.ValidationPending}, want: false},
+		{name: "validated", labels: map[string]string{hardware.ValidationStatusLabel: hardware.ValidationValidated}, requireValidated: true, want: true},
+		{name: "quarantined", labels: map[string]string{hardware.ValidationStatusLabel: hardware.ValidationQuarantined}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(hardware.IsEligible(tt.labels, tt.requireValidated)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestMarkValidationPending(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	got := hardware.MarkValidationPending(machine)
+	g.Expect(got.Labels).To(gomega.HaveKeyWithValue(hardware.ValidationStatusLabel, hardware.ValidationPending))
+	g.Expect(machine.Labels).ToNot(gomega.HaveKey(hardware.ValidationStatusLabel))
+
+	machine.Labels[hardware.ValidationStatusLabel] = hardware.ValidationValidated
+	got = hardware.MarkValidationPending(machine)
+	g.Expect(got.Labels).To(gomega.HaveKeyWithValue(hardware.ValidationStatusLabel, hardware.ValidationValidated))
+}
+
+func TestValidationStatusFromWorkflow(t *testing.T) {
+	tests := []struct {
+		state tinkv1alpha1.WorkflowState
+		want  string
+	}{
+		{state: tinkv1alpha1.WorkflowStatePending, want: ""},
+		{state: tinkv1alpha1.WorkflowStateRunning, want: ""},
+		{state: tinkv1alpha1.WorkflowStateSuccess, want: hardware.ValidationValidated},
+		{state: tinkv1alpha1.WorkflowStateFailed, want: hardware.ValidationQuarantined},
+		{state: tinkv1alpha1.WorkflowStateTimeout, want: hardware.ValidationQuarantined},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.state), func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			w := &tinkv1alpha1.Workflow{Status: tinkv1alpha1.WorkflowStatus{State: tt.state}}
+			g.Expect(hardware.ValidationStatusFromWorkflow(w)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestNewBurnInWorkflow(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	machine := NewValidMachine()
+	w := hardware.NewBurnInWorkflow(machine)
+
+	g.Expect(w.Name).To(gomega.Equal("localhost-burn-in"))
+	g.Expect(w.Labels).To(gomega.HaveKeyWithValue(hardware.BurnInHardwareLabel, machine.Hostname))
+	g.Expect(w.Spec.TemplateRef).To(gomega.Equal(hardware.BurnInTemplateName))
+	g.Expect(w.Spec.HardwareRef).To(gomega.Equal(machine.Hostname))
+	g.Expect(w.Spec.HardwareMap).To(gomega.HaveKeyWithValue("device_1", machine.MACAddress))
+	g.Expect(w.Spec.BootOptions.BootMode).To(gomega.Equal(tinkv1alpha1.BootModeNetboot))
+
+	machine.BMCIPAddress, machine.BMCUsername, machine.BMCPassword = "", "", ""
+	w = hardware.NewBurnInWorkflow(machine)
+	g.Expect(w.Spec.BootOptions.BootMode).To(gomega.BeEmpty())
+	g.Expect(w.Spec.BootOptions.ToggleAllowNetboot).To(gomega.BeTrue())
+}
+
+func TestNewBurnInTemplate(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	tmpl := hardware.NewBurnInTemplate("public.ecr.aws/example/burn-in:latest")
+	g.Expect(tmpl.Name).To(gomega.Equal(hardware.BurnInTemplateName))
+	g.Expect(tmpl.Spec.Data).ToNot(gomega.BeNil())
+	for _, action := range []string{"disk-check", "memory-check", "nic-check"} {
+		g.Expect(*tmpl.Spec.Data).To(gomega.ContainSubstring(action))
+	}
+	g.Expect(*tmpl.Spec.Data).To(gomega.ContainSubstring("image: public.ecr.aws/example/burn-in:latest"))
+}
+
+func TestBurnInManifestYAMLWritesTemplateOnce(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	var b bytes.Buffer
+	writer := hardware.NewBurnInManifestYAML(&b, "burn-in:latest")
+
+	first := NewValidMachine()
+	second := NewValidMachine()
+	second.Hostname = "other"
+
+	g.Expect(writer.Write(first)).To(gomega.Succeed())
+	g.Expect(writer.Write(second)).To(gomega.Succeed())
+
+	g.Expect(strings.Count(b.String(), "kind: Template\n")).To(gomega.Equal(1))
+	g.Expect(strings.Count(b.String(), "kind: Workflow\n")).To(gomega.Equal(2))
+}
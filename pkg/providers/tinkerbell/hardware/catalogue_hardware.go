@@ -155,9 +155,10 @@ func hardwareFromMachine(m Machine) *tinkv1alpha1.Hardware {
 	return &tinkv1alpha1.Hardware{
 		TypeMeta: newHardwareTypeMeta(),
 		ObjectMeta: v1.ObjectMeta{
-			Name:      m.Hostname,
-			Namespace: constants.EksaSystemNamespace,
-			Labels:    m.Labels,
+			Name:        m.Hostname,
+			Namespace:   constants.EksaSystemNamespace,
+			Labels:      m.Labels,
+			Annotations: m.Annotations,
 		},
 		Spec: tinkv1alpha1.HardwareSpec{
 			BMCRef: newBMCRefFromMachine(m),
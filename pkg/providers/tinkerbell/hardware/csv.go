@@ -105,12 +105,26 @@ func BuildHardwareYAML(path string, opts *BMCOptions) ([]byte, error) {
 		return nil, fmt.Errorf("reading csv: %v", err)
 	}
 
+	return BuildHardwareYAMLFromReader(reader, "")
+}
+
+// BuildHardwareYAMLFromReader builds a hardware yaml from the machines read from reader. When
+// burnInImage is not empty, the hardware is marked as pending validation and a burn-in Workflow
+// running checks with burnInImage is included for each machine.
+func BuildHardwareYAMLFromReader(reader MachineReader, burnInImage string) ([]byte, error) {
 	var b bytes.Buffer
-	writer := NewTinkerbellManifestYAML(&b)
+	var writer MachineWriter = NewTinkerbellManifestYAML(&b)
+
+	if burnInImage != "" {
+		normalizer := NewRawNormalizer(reader)
+		normalizer.Register(MarkValidationPending)
+		reader = normalizer
+		writer = MultiMachineWriter(writer, NewBurnInManifestYAML(&b, burnInImage))
+	}
 
 	validator := NewDefaultMachineValidator()
 
-	err = TranslateAll(reader, writer, validator)
+	err := TranslateAll(reader, writer, validator)
 	if err != nil {
 		return nil, fmt.Errorf("generating hardware yaml: %v", err)
 	}
@@ -0,0 +1,358 @@
+package hardware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bmc-toolbox/bmclib/v2"
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Inventory label keys applied to machines by the InventoryCollector. The labels are propagated
+// to Tinkerbell Hardware resources and can be used in TinkerbellMachineConfig hardware selectors.
+const (
+	InventoryLabelPrefix = "hardware.anywhere.eks.amazonaws.com/"
+
+	BIOSVersionLabel        = InventoryLabelPrefix + "bios-version"
+	BIOSMeetsMinimumLabel   = InventoryLabelPrefix + "bios-meets-minimum"
+	BMCFirmwareVersionLabel = InventoryLabelPrefix + "bmc-firmware-version"
+	CPUCoresLabel           = InventoryLabelPrefix + "cpu-cores"
+	MemoryGiBLabel          = InventoryLabelPrefix + "memory-gib"
+	DiskCountLabel          = InventoryLabelPrefix + "disk-count"
+	HealthLabel             = InventoryLabelPrefix + "health"
+
+	// DiskModelLabelPrefix is suffixed with the index of each disk reported by the BMC, for
+	// example hardware.anywhere.eks.amazonaws.com/disk-model-0.
+	DiskModelLabelPrefix = InventoryLabelPrefix + "disk-model-"
+)
+
+// Inventory annotation keys applied to machines by the InventoryCollector. Label values are
+// sanitized and truncated so the annotations retain the values reported by the BMC.
+const (
+	BIOSVersionAnnotation = InventoryLabelPrefix + "bios-version"
+	DiskModelsAnnotation  = InventoryLabelPrefix + "disk-models"
+)
+
+const defaultInventoryTimeout = 2 * time.Minute
+
+// Inventory is the hardware and firmware inventory of a machine as reported by its BMC.
+type Inventory struct {
+	BIOSVersion        string
+	BMCFirmwareVersion string
+	CPUCores           int
+	MemoryBytes        int64
+	DiskModels         []string
+	Health             string
+}
+
+// InventoryClient retrieves the Inventory of a machine.
+type InventoryClient interface {
+	Inventory(ctx context.Context, m Machine) (Inventory, error)
+}
+
+// InventoryClientFunc is a function that satisfies InventoryClient.
+type InventoryClientFunc func(ctx context.Context, m Machine) (Inventory, error)
+
+// Inventory satisfies InventoryClient.
+func (f InventoryClientFunc) Inventory(ctx context.Context, m Machine) (Inventory, error) {
+	return f(ctx, m)
+}
+
+// InventoryOptions configure inventory collection for machines.
+type InventoryOptions struct {
+	// Enabled turns on inventory collection.
+	Enabled bool
+
+	// Timeout is the maximum duration of a single machine inventory query. Defaults to 2 minutes.
+	Timeout time.Duration
+
+	// MinimumBIOSVersion, when set, causes the BIOSMeetsMinimumLabel label to be applied to
+	// hardware indicating whether their BIOS version is greater than or equal to it. The label is
+	// computed when hardware is selected for a cluster, see LabelBIOSMeetsMinimum.
+	MinimumBIOSVersion string
+}
+
+// InventoryCollector is a decorator for a MachineReader that queries the BMC of each machine
+// for its inventory and records it as labels and annotations on the machine. Machines without BMC
+// configuration are returned unmodified.
+type InventoryCollector struct {
+	reader  MachineReader
+	client  InventoryClient
+	options InventoryOptions
+
+	collected bool
+	machines  []Machine
+	err       error
+}
+
+// NewInventoryCollector creates an InventoryCollector instance that decorates r's Read().
+func NewInventoryCollector(r MachineReader, client InventoryClient, opts InventoryOptions) *InventoryCollector {
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultInventoryTimeout
+	}
+	return &InventoryCollector{
+		reader:  r,
+		client:  client,
+		options: opts,
+	}
+}
+
+// WithInventoryCollection decorates r with an InventoryCollector backed by a BMCInventoryClient
+// when opts enable inventory collection. Otherwise r is returned.
+func WithInventoryCollection(r MachineReader, opts *InventoryOptions, log logr.Logger) MachineReader {
+	if opts == nil || !opts.Enabled {
+		return r
+	}
+	return NewInventoryCollector(r, NewBMCInventoryClient(log), *opts)
+}
+
+// Read returns the next Machine from the decorated MachineReader with its inventory applied. The
+// first call reads all machines and queries their BMCs concurrently. If the decorated MachineReader
+// errors, or the inventory of any machine can't be collected, an error listing every failed host
+// is returned.
+func (c *InventoryCollector) Read() (Machine, error) {
+	if !c.collected {
+		c.collected = true
+		c.err = c.collect()
+	}
+	if c.err != nil {
+		return Machine{}, c.err
+	}
+	if len(c.machines) == 0 {
+		return Machine{}, io.EOF
+	}
+
+	machine := c.machines[0]
+	c.machines = c.machines[1:]
+
+	return machine, nil
+}
+
+func (c *InventoryCollector) collect() error {
+	for {
+		machine, err := c.reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		c.machines = append(c.machines, machine)
+	}
+
+	errs := make([]error, len(c.machines))
+	var wg sync.WaitGroup
+	for i := range c.machines {
+		if !c.machines[i].HasBMC() {
+			continue
+		}
+		wg.Add(1)
+		go func(m *Machine, errp *error) {
+			defer wg.Done()
+			*errp = c.apply(m)
+		}(&c.machines[i], &errs[i])
+	}
+	wg.Wait()
+
+	if err := kerrors.NewAggregate(errs); err != nil {
+		return fmt.Errorf("collecting inventory: %v", err)
+	}
+
+	return nil
+}
+
+func (c *InventoryCollector) apply(machine *Machine) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.options.Timeout)
+	defer cancel()
+
+	inventory, err := c.client.Inventory(ctx, *machine)
+	if err != nil {
+		return fmt.Errorf("%v: %v", machine.Hostname, err)
+	}
+
+	labels := make(Labels, len(machine.Labels))
+	for k, v := range machine.Labels {
+		labels[k] = v
+	}
+	for k, v := range inventoryLabels(inventory) {
+		// User supplied labels take precedence over collected ones.
+		if !labels.Has(k) {
+			labels[k] = v
+		}
+	}
+	machine.Labels = labels
+
+	annotations := make(map[string]string, len(machine.Annotations)+2)
+	for k, v := range machine.Annotations {
+		annotations[k] = v
+	}
+	if inventory.BIOSVersion != "" {
+		annotations[BIOSVersionAnnotation] = inventory.BIOSVersion
+	}
+	if len(inventory.DiskModels) > 0 {
+		annotations[DiskModelsAnnotation] = strings.Join(inventory.DiskModels, ",")
+	}
+	machine.Annotations = annotations
+
+	return nil
+}
+
+// LabelBIOSMeetsMinimum sets the BIOSMeetsMinimumLabel on all hardware in catalogue that has its
+// BIOS version recorded in the BIOSVersionAnnotation. The comparison happens when hardware is
+// selected for a cluster so the label always reflects the minimum in use, not the one in use
+// when the inventory was collected. Hardware without a recorded BIOS version is left unmodified.
+func LabelBIOSMeetsMinimum(catalogue *Catalogue, minimum string) {
+	if minimum == "" {
+		return
+	}
+
+	for _, h := range catalogue.AllHardware() {
+		version, ok := h.Annotations[BIOSVersionAnnotation]
+		if !ok {
+			continue
+		}
+		if h.Labels == nil {
+			h.Labels = make(map[string]string)
+		}
+		h.Labels[BIOSMeetsMinimumLabel] = strconv.FormatBool(CompareVersions(version, minimum) >= 0)
+	}
+}
+
+func inventoryLabels(inv Inventory) Labels {
+	labels := make(Labels)
+	set := func(k, v string) {
+		if v := sanitizeLabelValue(v); v != "" {
+			labels[k] = v
+		}
+	}
+
+	set(BIOSVersionLabel, inv.BIOSVersion)
+	set(BMCFirmwareVersionLabel, inv.BMCFirmwareVersion)
+	set(HealthLabel, strings.ToLower(inv.Health))
+	if inv.CPUCores > 0 {
+		set(CPUCoresLabel, strconv.Itoa(inv.CPUCores))
+	}
+	if inv.MemoryBytes > 0 {
+		set(MemoryGiBLabel, strconv.FormatInt(inv.MemoryBytes/(1<<30), 10))
+	}
+	if len(inv.DiskModels) > 0 {
+		set(DiskCountLabel, strconv.Itoa(len(inv.DiskModels)))
+	}
+	for i, model := range inv.DiskModels {
+		set(DiskModelLabelPrefix+strconv.Itoa(i), model)
+	}
+
+	return labels
+}
+
+var invalidLabelValueChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// sanitizeLabelValue converts v into a valid Kubernetes label value by replacing invalid
+// characters, truncating to 63 characters and trimming non-alphanumeric leading and trailing
+// characters.
+func sanitizeLabelValue(v string) string {
+	v = invalidLabelValueChars.ReplaceAllString(strings.TrimSpace(v), "_")
+	if len(v) > 63 {
+		v = v[:63]
+	}
+	return strings.Trim(v, "._-")
+}
+
+var versionSegment = regexp.MustCompile(`\d+`)
+
+// CompareVersions compares the numeric segments of version strings a and b such as BIOS or
+// firmware versions. It returns -1 if a is less than b, 1 if a is greater than b and 0 otherwise.
+// Versions without numeric segments are considered lower than any version with numeric segments.
+func CompareVersions(a, b string) int {
+	as, bs := versionSegment.FindAllString(a, -1), versionSegment.FindAllString(b, -1)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv int
+		if i < len(as) {
+			av, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			bv, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	}
+
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+
+	return 0
+}
+
+// BMCInventoryClient is an InventoryClient that queries machine BMCs using bmclib, preferring
+// the redfish protocol.
+type BMCInventoryClient struct {
+	Log logr.Logger
+}
+
+// NewBMCInventoryClient creates a new BMCInventoryClient instance.
+func NewBMCInventoryClient(log logr.Logger) BMCInventoryClient {
+	return BMCInventoryClient{Log: log}
+}
+
+// Inventory satisfies InventoryClient.
+func (b BMCInventoryClient) Inventory(ctx context.Context, m Machine) (inv Inventory, reterr error) {
+	log := b.Log.WithValues("host", m.BMCIPAddress, "username", m.BMCUsername)
+	client := bmclib.NewClient(m.BMCIPAddress, m.BMCUsername, m.BMCPassword, bmclib.WithLogger(log))
+	client.Registry.Drivers = client.Registry.PreferProtocol("redfish")
+
+	if err := client.Open(ctx); err != nil {
+		return Inventory{}, fmt.Errorf("opening connection to bmc: %v", err)
+	}
+	defer func() {
+		if err := client.Close(ctx); err != nil && reterr == nil {
+			reterr = fmt.Errorf("closing connection to bmc: %v", err)
+		}
+	}()
+
+	device, err := client.Inventory(ctx)
+	if err != nil {
+		return Inventory{}, fmt.Errorf("retrieving bmc inventory: %v", err)
+	}
+
+	if device.BIOS != nil && device.BIOS.Firmware != nil {
+		inv.BIOSVersion = device.BIOS.Firmware.Installed
+	}
+	if device.BMC != nil && device.BMC.Firmware != nil {
+		inv.BMCFirmwareVersion = device.BMC.Firmware.Installed
+	}
+	if device.Status != nil {
+		inv.Health = device.Status.Health
+	}
+	for _, cpu := range device.CPUs {
+		if cpu != nil {
+			inv.CPUCores += cpu.Cores
+		}
+	}
+	for _, mem := range device.Memory {
+		if mem != nil {
+			inv.MemoryBytes += mem.SizeBytes
+		}
+	}
+	for _, drive := range device.Drives {
+		if drive != nil && drive.Model != "" {
+			inv.DiskModels = append(inv.DiskModels, drive.Model)
+		}
+	}
+
+	return inv, nil
+}
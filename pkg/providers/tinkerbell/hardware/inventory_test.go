@@ -0,0 +1,238 @@
+package hardware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/onsi/gomega"
+	tinkv1alpha1 "github.com/tinkerbell/tink/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware/mocks"
+)
+
+func TestInventoryCollectorAppliesInventory(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	machine := NewValidMachine()
+	gomock.InOrder(
+		reader.EXPECT().Read().Return(machine, (error)(nil)),
+		reader.EXPECT().Read().Return(hardware.Machine{}, io.EOF),
+	)
+
+	client := hardware.InventoryClientFunc(func(_ context.Context, m hardware.Machine) (hardware.Inventory, error) {
+		if m.BMCIPAddress != machine.BMCIPAddress {
+			return hardware.Inventory{}, errors.New("unexpected machine")
+		}
+		return hardware.Inventory{
+			BIOSVersion:        "U46 v2.14.1 (03/21/2023)",
+			BMCFirmwareVersion: "6.10.30.00",
+			CPUCores:           32,
+			MemoryBytes:        256 << 30,
+			DiskModels:         []string{"Dell Ent NVMe P5600 MU U.2 1.6TB", "other"},
+			Health:             "OK",
+		}, nil
+	})
+
+	collector := hardware.NewInventoryCollector(reader, client, hardware.InventoryOptions{
+		MinimumBIOSVersion: "2.10",
+	})
+
+	got, err := collector.Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(got.Labels).To(gomega.Equal(hardware.Labels{
+		"type":                              "cp",
+		hardware.BIOSVersionLabel:           "U46_v2.14.1_03_21_2023",
+		hardware.BMCFirmwareVersionLabel:    "6.10.30.00",
+		hardware.CPUCoresLabel:              "32",
+		hardware.MemoryGiBLabel:             "256",
+		hardware.DiskCountLabel:             "2",
+		hardware.DiskModelLabelPrefix + "0": "Dell_Ent_NVMe_P5600_MU_U.2_1.6TB",
+		hardware.DiskModelLabelPrefix + "1": "other",
+		hardware.HealthLabel:                "ok",
+	}))
+	g.Expect(got.Annotations).To(gomega.Equal(map[string]string{
+		hardware.BIOSVersionAnnotation: "U46 v2.14.1 (03/21/2023)",
+		hardware.DiskModelsAnnotation:  "Dell Ent NVMe P5600 MU U.2 1.6TB,other",
+	}))
+
+	// The labels of the source machine must not be mutated.
+	g.Expect(machine.Labels).To(gomega.Equal(hardware.Labels{"type": "cp"}))
+
+	_, err = collector.Read()
+	g.Expect(err).To(gomega.Equal(io.EOF))
+}
+
+func TestInventoryCollectorUserLabelsTakePrecedence(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	machine := NewValidMachine()
+	machine.Labels[hardware.HealthLabel] = "degraded"
+	gomock.InOrder(
+		reader.EXPECT().Read().Return(machine, (error)(nil)),
+		reader.EXPECT().Read().Return(hardware.Machine{}, io.EOF),
+	)
+
+	client := hardware.InventoryClientFunc(func(context.Context, hardware.Machine) (hardware.Inventory, error) {
+		return hardware.Inventory{Health: "OK"}, nil
+	})
+
+	got, err := hardware.NewInventoryCollector(reader, client, hardware.InventoryOptions{}).Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(got.Labels).To(gomega.HaveKeyWithValue(hardware.HealthLabel, "degraded"))
+}
+
+func TestInventoryCollectorSkipsMachinesWithoutBMC(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	machine := NewValidMachine()
+	machine.BMCIPAddress = ""
+	machine.BMCUsername = ""
+	machine.BMCPassword = ""
+	gomock.InOrder(
+		reader.EXPECT().Read().Return(machine, (error)(nil)),
+		reader.EXPECT().Read().Return(hardware.Machine{}, io.EOF),
+	)
+
+	var queried atomic.Bool
+	client := hardware.InventoryClientFunc(func(context.Context, hardware.Machine) (hardware.Inventory, error) {
+		queried.Store(true)
+		return hardware.Inventory{}, nil
+	})
+
+	got, err := hardware.NewInventoryCollector(reader, client, hardware.InventoryOptions{}).Read()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(got).To(gomega.Equal(machine))
+	g.Expect(queried.Load()).To(gomega.BeFalse())
+}
+
+func TestInventoryCollectorReportsEveryFailedHost(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	first, second, third := NewValidMachine(), NewValidMachine(), NewValidMachine()
+	first.Hostname = "host-1"
+	second.Hostname = "host-2"
+	third.Hostname = "host-3"
+	gomock.InOrder(
+		reader.EXPECT().Read().Return(first, (error)(nil)),
+		reader.EXPECT().Read().Return(second, (error)(nil)),
+		reader.EXPECT().Read().Return(third, (error)(nil)),
+		reader.EXPECT().Read().Return(hardware.Machine{}, io.EOF),
+	)
+
+	client := hardware.InventoryClientFunc(func(_ context.Context, m hardware.Machine) (hardware.Inventory, error) {
+		if m.Hostname == "host-2" {
+			return hardware.Inventory{}, nil
+		}
+		return hardware.Inventory{}, errors.New("connection refused")
+	})
+
+	_, err := hardware.NewInventoryCollector(reader, client, hardware.InventoryOptions{}).Read()
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("host-1: connection refused")))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("host-3: connection refused")))
+	g.Expect(err).ToNot(gomega.MatchError(gomega.ContainSubstring("host-2")))
+}
+
+func TestInventoryCollectorReaderError(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	expect := errors.New("read error")
+	reader.EXPECT().Read().Return(hardware.Machine{}, expect)
+
+	client := hardware.InventoryClientFunc(func(context.Context, hardware.Machine) (hardware.Inventory, error) {
+		return hardware.Inventory{}, nil
+	})
+
+	_, err := hardware.NewInventoryCollector(reader, client, hardware.InventoryOptions{}).Read()
+	g.Expect(err).To(gomega.MatchError(expect))
+}
+
+func TestLabelBIOSMeetsMinimum(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	newHardware := func(name, biosVersion string) *tinkv1alpha1.Hardware {
+		h := &tinkv1alpha1.Hardware{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if biosVersion != "" {
+			h.Annotations = map[string]string{hardware.BIOSVersionAnnotation: biosVersion}
+		}
+		return h
+	}
+	catalogue := hardware.NewCatalogue()
+	current := newHardware("current", "U46 v2.14.1 (03/21/2023)")
+	// The label recorded when the inventory was collected is stale for the new minimum.
+	current.Labels = map[string]string{hardware.BIOSMeetsMinimumLabel: "false"}
+	outdated := newHardware("outdated", "1.9.3")
+	unknown := newHardware("unknown", "")
+	for _, h := range []*tinkv1alpha1.Hardware{current, outdated, unknown} {
+		g.Expect(catalogue.InsertHardware(h)).To(gomega.Succeed())
+	}
+
+	hardware.LabelBIOSMeetsMinimum(catalogue, "2.10")
+
+	g.Expect(current.Labels).To(gomega.HaveKeyWithValue(hardware.BIOSMeetsMinimumLabel, "true"))
+	g.Expect(outdated.Labels).To(gomega.HaveKeyWithValue(hardware.BIOSMeetsMinimumLabel, "false"))
+	g.Expect(unknown.Labels).ToNot(gomega.HaveKey(hardware.BIOSMeetsMinimumLabel))
+}
+
+func TestLabelBIOSMeetsMinimumNoMinimum(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	h := &tinkv1alpha1.Hardware{ObjectMeta: metav1.ObjectMeta{
+		Name:        "hw",
+		Annotations: map[string]string{hardware.BIOSVersionAnnotation: "2.14.1"},
+	}}
+	catalogue := hardware.NewCatalogue()
+	g.Expect(catalogue.InsertHardware(h)).To(gomega.Succeed())
+
+	hardware.LabelBIOSMeetsMinimum(catalogue, "")
+
+	g.Expect(h.Labels).To(gomega.BeEmpty())
+}
+
+func TestWithInventoryCollectionDisabled(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctrl := gomock.NewController(t)
+	reader := mocks.NewMockMachineReader(ctrl)
+
+	g.Expect(hardware.WithInventoryCollection(reader, nil, logr.Discard())).To(gomega.BeIdenticalTo(reader))
+	g.Expect(hardware.WithInventoryCollection(reader, &hardware.InventoryOptions{}, logr.Discard())).To(gomega.BeIdenticalTo(reader))
+	g.Expect(hardware.WithInventoryCollection(reader, &hardware.InventoryOptions{Enabled: true}, logr.Discard())).
+		To(gomega.BeAssignableToTypeOf(&hardware.InventoryCollector{}))
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "2.14.1", b: "2.14.1", want: 0},
+		{a: "2.14.1", b: "2.9", want: 1},
+		{a: "2.9", b: "2.14.1", want: -1},
+		{a: "2.10", b: "2.10.0", want: -1},
+		{a: "U46 v1.20", b: "U46 v1.3", want: 1},
+		{a: "", b: "1.0", want: -1},
+		{a: "unknown", b: "", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			g.Expect(hardware.CompareVersions(tt.a, tt.b)).To(gomega.Equal(tt.want))
+		})
+	}
+}
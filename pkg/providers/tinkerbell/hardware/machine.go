@@ -24,6 +24,9 @@ type Machine struct {
 	// Labels to be applied to the Hardware resource.
 	Labels Labels `csv:"labels"`
 
+	// Annotations to be applied to the Hardware resource.
+	Annotations map[string]string `csv:"-"`
+
 	BMCIPAddress string `csv:"bmc_ip, omitempty"`
 	BMCUsername  string `csv:"bmc_username, omitempty"`
 	BMCPassword  string `csv:"bmc_password, omitempty"`
@@ -42,6 +42,11 @@ type CNIReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
 }
 
+// ServiceLoadBalancerReconciler is an interface for reconciling the built-in service load balancer in the Tinkerbell cluster reconciler.
+type ServiceLoadBalancerReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
 // RemoteClientRegistry is an interface that defines methods for remote clients.
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
@@ -68,19 +73,21 @@ func NewScope(clusterSpec *c.Spec) *Scope {
 
 // Reconciler for Tinkerbell.
 type Reconciler struct {
-	client               client.Client
-	cniReconciler        CNIReconciler
-	remoteClientRegistry RemoteClientRegistry
-	ipValidator          IPValidator
+	client                        client.Client
+	cniReconciler                 CNIReconciler
+	remoteClientRegistry          RemoteClientRegistry
+	ipValidator                   IPValidator
+	serviceLoadBalancerReconciler ServiceLoadBalancerReconciler
 }
 
 // New defines a new Tinkerbell reconciler.
-func New(client client.Client, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+func New(client client.Client, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator, serviceLoadBalancerReconciler ServiceLoadBalancerReconciler) *Reconciler {
 	return &Reconciler{
-		client:               client,
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		ipValidator:          ipValidator,
+		client:                        client,
+		cniReconciler:                 cniReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		ipValidator:                   ipValidator,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
 	}
 }
 
@@ -107,6 +114,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
+		r.ReconcileServiceLoadBalancer,
 	).Run(ctx, log, NewScope(clusterSpec))
 }
 
@@ -243,6 +251,19 @@ func (r *Reconciler) ReconcileCNI(ctx context.Context, log logr.Logger, tinkerbe
 	return r.cniReconciler.Reconcile(ctx, log, client, clusterSpec)
 }
 
+// ReconcileServiceLoadBalancer installs and configures the built-in service load balancer when the cluster has one.
+func (r *Reconciler) ReconcileServiceLoadBalancer(ctx context.Context, log logr.Logger, tinkerbellScope *Scope) (controller.Result, error) {
+	clusterSpec := tinkerbellScope.ClusterSpec
+	log = log.WithValues("phase", "reconcileServiceLoadBalancer")
+
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(clusterSpec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.serviceLoadBalancerReconciler.Reconcile(ctx, log, client, clusterSpec)
+}
+
 func (r *Reconciler) validateTinkerbellIPMatch(ctx context.Context, clusterSpec *c.Spec) error {
 	if clusterSpec.Cluster.IsManaged() {
 
@@ -303,6 +324,7 @@ func (r *Reconciler) ValidateHardware(ctx context.Context, log logr.Logger, tink
 
 	var v tinkerbell.ClusterSpecValidator
 	v.Register(tinkerbell.HardwareSatisfiesOnlyOneSelectorAssertion(kubeReader.GetCatalogue()))
+	v.Register(tinkerbell.ValidatedHardwareAssertion(kubeReader.GetCatalogue()))
 
 	o, err := r.DetectOperation(ctx, log, tinkerbellScope)
 	if err != nil {
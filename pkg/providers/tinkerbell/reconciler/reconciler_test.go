@@ -32,6 +32,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/controller"
 	"github.com/aws/eks-anywhere/pkg/controller/clientutil"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler"
 	tinkerbellreconcilermocks "github.com/aws/eks-anywhere/pkg/providers/tinkerbell/reconciler/mocks"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
@@ -74,12 +75,13 @@ func TestReconcilerReconcileSuccess(t *testing.T) {
 
 	tt.remoteClientRegistry.EXPECT().GetClient(
 		tt.ctx, client.ObjectKey{Name: workloadClusterName, Namespace: constants.EksaSystemNamespace},
-	).Return(remoteClient, nil)
+	).Return(remoteClient, nil).Times(2)
 	spec := tt.buildSpec()
 	for _, mc := range spec.TinkerbellMachineConfigs {
 		mc.Spec.OSImageURL = "http://tinkerbell-example:8080/bottlerocket-2004-kube-v1.22.5.gz"
 	}
 	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
 
 	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
 
@@ -189,6 +191,26 @@ func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt.cleanup()
 }
 
+func TestReconcileServiceLoadBalancerSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	scope := tt.buildScope()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: workloadClusterName, Namespace: constants.EksaSystemNamespace},
+	).Return(remoteClient, nil)
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, scope.ClusterSpec)
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, scope)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+	tt.cleanup()
+}
+
 func TestReconcilerReconcileControlPlaneScaleSuccess(t *testing.T) {
 	tt := newReconcilerTest(t)
 
@@ -536,6 +558,27 @@ func TestReconcilerValidateHardwareNoHardware(t *testing.T) {
 	tt.cleanup()
 }
 
+func TestReconcilerValidateHardwareRequireValidatedHardware(t *testing.T) {
+	tt := newReconcilerTest(t)
+	logger := test.NewNullLogger()
+
+	tt.datacenterConfig.Spec.RequireValidatedHardware = true
+	validated := tinkHardware("hw2", "worker")
+	validated.Labels[hardware.ValidationStatusLabel] = hardware.ValidationValidated
+	tt.eksaSupportObjs = append(tt.eksaSupportObjs, tinkHardware("hw1", "cp"), validated)
+
+	tt.withFakeClient()
+	tt.ipValidator.EXPECT().ValidateControlPlaneIP(tt.ctx, logger, gomock.Any()).Return(controller.Result{}, nil)
+
+	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
+
+	tt.Expect(err).NotTo(BeNil())
+	tt.Expect(result).To(Equal(controller.Result{}), "result should not stop reconciliation")
+	tt.Expect(*tt.cluster.Status.FailureMessage).To(ContainSubstring("hardware selectable by machine configs has not been validated while requireValidatedHardware is set: hw1"))
+	tt.Expect(tt.cluster.Status.FailureReason).To(HaveValue(Equal(anywherev1.HardwareInvalidReason)))
+	tt.cleanup()
+}
+
 func TestReconcilerValidateHardwareControlPlaneOSImageChangeError(t *testing.T) {
 	tt := newReconcilerTest(t)
 	worker := tinkWorker(tt.cluster.Name, func(w *tinkerbell.Workers) {
@@ -918,7 +961,7 @@ func (tt *reconcilerTest) withFakeClient() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+	return reconciler.New(tt.client, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator, tt.serviceLoadBalancerReconciler)
 }
 
 func (tt *reconcilerTest) buildScope() *reconciler.Scope {
@@ -954,18 +997,19 @@ type reconcilerTest struct {
 	t testing.TB
 	*WithT
 	*envtest.APIExpecter
-	ctx                       context.Context
-	cluster                   *anywherev1.Cluster
-	managementCluster         *anywherev1.Cluster
-	client                    client.Client
-	eksaSupportObjs           []client.Object
-	datacenterConfig          *anywherev1.TinkerbellDatacenterConfig
-	machineConfigControlPlane *anywherev1.TinkerbellMachineConfig
-	machineConfigWorker       *anywherev1.TinkerbellMachineConfig
-	ipValidator               *tinkerbellreconcilermocks.MockIPValidator
-	cniReconciler             *tinkerbellreconcilermocks.MockCNIReconciler
-	remoteClientRegistry      *tinkerbellreconcilermocks.MockRemoteClientRegistry
-	kcp                       *controlplanev1.KubeadmControlPlane
+	ctx                           context.Context
+	cluster                       *anywherev1.Cluster
+	managementCluster             *anywherev1.Cluster
+	client                        client.Client
+	eksaSupportObjs               []client.Object
+	datacenterConfig              *anywherev1.TinkerbellDatacenterConfig
+	machineConfigControlPlane     *anywherev1.TinkerbellMachineConfig
+	machineConfigWorker           *anywherev1.TinkerbellMachineConfig
+	ipValidator                   *tinkerbellreconcilermocks.MockIPValidator
+	cniReconciler                 *tinkerbellreconcilermocks.MockCNIReconciler
+	serviceLoadBalancerReconciler *tinkerbellreconcilermocks.MockServiceLoadBalancerReconciler
+	remoteClientRegistry          *tinkerbellreconcilermocks.MockRemoteClientRegistry
+	kcp                           *controlplanev1.KubeadmControlPlane
 }
 
 func newReconcilerTest(t testing.TB) *reconcilerTest {
@@ -973,6 +1017,7 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 	c := env.Client()
 
 	cniReconciler := tinkerbellreconcilermocks.NewMockCNIReconciler(ctrl)
+	serviceLoadBalancerReconciler := tinkerbellreconcilermocks.NewMockServiceLoadBalancerReconciler(ctrl)
 	remoteClientRegistry := tinkerbellreconcilermocks.NewMockRemoteClientRegistry(ctrl)
 	ipValidator := tinkerbellreconcilermocks.NewMockIPValidator(ctrl)
 
@@ -1077,14 +1122,15 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 	})
 
 	tt := &reconcilerTest{
-		t:                    t,
-		WithT:                NewWithT(t),
-		APIExpecter:          envtest.NewAPIExpecter(t, c),
-		ctx:                  context.Background(),
-		ipValidator:          ipValidator,
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		client:               c,
+		t:                             t,
+		WithT:                         NewWithT(t),
+		APIExpecter:                   envtest.NewAPIExpecter(t, c),
+		ctx:                           context.Background(),
+		ipValidator:                   ipValidator,
+		cniReconciler:                 cniReconciler,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		client:                        c,
 		eksaSupportObjs: []client.Object{
 			test.Namespace(clusterNamespace),
 			test.Namespace(constants.EksaSystemNamespace),
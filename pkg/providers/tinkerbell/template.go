@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
@@ -23,6 +24,7 @@ import (
 	"github.com/aws/eks-anywhere/pkg/crypto"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/registrymirror"
 	"github.com/aws/eks-anywhere/pkg/registrymirror/containerd"
 	"github.com/aws/eks-anywhere/pkg/templater"
@@ -166,6 +168,9 @@ func (tb *TemplateBuilder) GenerateCAPISpecWorkers(clusterSpec *cluster.Spec, wo
 			values["upgradeRolloutStrategy"] = true
 			if workerNodeGroupConfiguration.UpgradeRolloutStrategy.Type == v1alpha1.InPlaceStrategyType {
 				values["upgradeRolloutStrategyType"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.Type
+				if workerNodeGroupConfiguration.UpgradeRolloutStrategy.InPlace.OSImageUpgradeEnabled() {
+					values["inPlaceOSImageURL"] = machineOSImageURL(*tb.datacenterSpec, workerNodeMachineSpec)
+				}
 			} else {
 				values["maxSurge"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
 				values["maxUnavailable"] = workerNodeGroupConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxUnavailable
@@ -216,6 +221,8 @@ func buildTemplateMapCP(
 	values := map[string]interface{}{
 		"auditPolicy":                   auditPolicy,
 		"clusterName":                   clusterSpec.Cluster.Name,
+		"kubeletNodeIPCommand":          clusterapi.KubeletNodeIPCommand(&clusterSpec.Cluster.Spec.ClusterNetwork),
+		"kubeVipCidr":                   clusterapi.KubeVipCIDR(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host),
 		"controlPlaneEndpointIp":        clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host,
 		"controlPlaneReplicas":          clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
 		"apiServerCertSANs":             clusterSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs,
@@ -225,7 +232,7 @@ func buildTemplateMapCP(
 		"format":                        format,
 		"kubernetesVersion":             versionsBundle.KubeDistro.Kubernetes.Tag,
 		"kubeVipImage":                  versionsBundle.Tinkerbell.KubeVip.VersionedImage(),
-		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
+		"podCidrs":                      clusterSpec.Cluster.Spec.ClusterNetwork.PrimaryPodCIDRBlocks(),
 		"serviceCidrs":                  clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"apiserverExtraArgs":            apiServerExtraArgs.ToPartialYaml(),
 		"baseRegistry":                  "", // TODO: need to get this values for creating template IMAGE_URL
@@ -239,7 +246,7 @@ func buildTemplateMapCP(
 		"externalEtcdVersion":           versionsBundle.KubeDistro.EtcdVersion,
 		"etcdCipherSuites":              crypto.SecureCipherSuitesString(),
 		"hardwareSelector":              controlPlaneMachineSpec.HardwareSelector,
-		"hardwareAffinity":              controlPlaneMachineSpec.HardwareAffinity,
+		"hardwareAffinity":              machineHardwareAffinity(controlPlaneMachineSpec),
 		"controlPlaneTaints":            clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Taints,
 		"workerNodeGroupConfigurations": clusterSpec.Cluster.Spec.WorkerNodeGroupConfigurations,
 		"skipLoadBalancerDeployment":    datacenterSpec.SkipLoadBalancerDeployment,
@@ -250,6 +257,9 @@ func buildTemplateMapCP(
 		values["upgradeRolloutStrategy"] = true
 		if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.Type == v1alpha1.InPlaceStrategyType {
 			values["upgradeRolloutStrategyType"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.Type
+			if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.InPlace.OSImageUpgradeEnabled() {
+				values["inPlaceOSImageURL"] = machineOSImageURL(datacenterSpec, controlPlaneMachineSpec)
+			}
 		} else {
 			values["maxSurge"] = clusterSpec.Cluster.Spec.ControlPlaneConfiguration.UpgradeRolloutStrategy.RollingUpdate.MaxSurge
 		}
@@ -285,7 +295,7 @@ func buildTemplateMapCP(
 		values["etcdSshUsername"] = etcdMachineSpec.Users[0].Name
 		values["etcdTemplateOverride"] = etcdTemplateOverride
 		values["etcdHardwareSelector"] = etcdMachineSpec.HardwareSelector
-		values["etcdHardwareAffinity"] = etcdMachineSpec.HardwareAffinity
+		values["etcdHardwareAffinity"] = machineHardwareAffinity(etcdMachineSpec)
 		etcdURL, _ := common.GetExternalEtcdReleaseURL(clusterSpec.Cluster.Spec.EksaVersion, versionsBundle)
 		if etcdURL != "" {
 			values["externalEtcdReleaseUrl"] = etcdURL
@@ -395,6 +405,7 @@ func buildTemplateMapMD(
 
 	values := map[string]interface{}{
 		"clusterName":            clusterSpec.Cluster.Name,
+		"kubeletNodeIPCommand":   clusterapi.KubeletNodeIPCommand(&clusterSpec.Cluster.Spec.ClusterNetwork),
 		"eksaSystemNamespace":    constants.EksaSystemNamespace,
 		"format":                 format,
 		"kubernetesVersion":      versionsBundle.KubeDistro.Kubernetes.Tag,
@@ -402,7 +413,7 @@ func buildTemplateMapMD(
 		"workerSshAuthorizedKey": workerNodeGroupMachineSpec.Users[0].SshAuthorizedKeys[0],
 		"workerSshUsername":      workerNodeGroupMachineSpec.Users[0].Name,
 		"hardwareSelector":       workerNodeGroupMachineSpec.HardwareSelector,
-		"hardwareAffinity":       workerNodeGroupMachineSpec.HardwareAffinity,
+		"hardwareAffinity":       machineHardwareAffinity(workerNodeGroupMachineSpec),
 		"workerNodeGroupTaints":  workerNodeGroupConfiguration.Taints,
 	}
 
@@ -558,6 +569,50 @@ func populateRegistryMirrorValues(clusterSpec *cluster.Spec, values map[string]i
 	return values, nil
 }
 
+// eligibleHardwareRequirement excludes hardware that is awaiting or failed burn-in validation. It
+// doesn't depend on the datacenter RequireValidatedHardware setting so toggling it doesn't change
+// the machine templates and roll the cluster machines.
+var eligibleHardwareRequirement = metav1.LabelSelectorRequirement{
+	Key:      hardware.ValidationStatusLabel,
+	Operator: metav1.LabelSelectorOpNotIn,
+	Values:   []string{hardware.ValidationPending, hardware.ValidationQuarantined},
+}
+
+// machineHardwareAffinity returns the hardware affinity used by machines of the given machine
+// config. A HardwareSelector is converted to a single required term and every required term is
+// restricted to hardware eligible for provisioning.
+func machineHardwareAffinity(machineSpec v1alpha1.TinkerbellMachineConfigSpec) *v1alpha1.HardwareAffinity {
+	affinity := machineSpec.HardwareAffinity.DeepCopy()
+	if affinity == nil {
+		if len(machineSpec.HardwareSelector) == 0 {
+			return nil
+		}
+		selector := make(map[string]string, len(machineSpec.HardwareSelector))
+		for k, v := range machineSpec.HardwareSelector {
+			selector[k] = v
+		}
+		affinity = &v1alpha1.HardwareAffinity{
+			Required: []v1alpha1.HardwareAffinityTerm{{LabelSelector: metav1.LabelSelector{MatchLabels: selector}}},
+		}
+	}
+
+	for i := range affinity.Required {
+		expressions := affinity.Required[i].LabelSelector.MatchExpressions
+		affinity.Required[i].LabelSelector.MatchExpressions = append(expressions, *eligibleHardwareRequirement.DeepCopy())
+	}
+
+	return affinity
+}
+
+// machineOSImageURL returns the OS image URL used by machines of the given machine config,
+// which takes precedence over the one set at the datacenter level.
+func machineOSImageURL(datacenterSpec v1alpha1.TinkerbellDatacenterConfigSpec, machineSpec v1alpha1.TinkerbellMachineConfigSpec) string {
+	if machineSpec.OSImageURL != "" {
+		return machineSpec.OSImageURL
+	}
+	return datacenterSpec.OSImageURL
+}
+
 func getControlPlaneMachineSpec(clusterSpec *cluster.Spec) (*v1alpha1.TinkerbellMachineConfigSpec, error) {
 	var controlPlaneMachineSpec *v1alpha1.TinkerbellMachineConfigSpec
 	if clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef != nil && clusterSpec.TinkerbellMachineConfigs[clusterSpec.Cluster.Spec.ControlPlaneConfiguration.MachineGroupRef.Name] != nil {
@@ -62,6 +62,9 @@ type Provider struct {
 	tinkerbellIP    string
 	// BMCOptions are Rufio BMC options that are used when creating Rufio machine CRDs.
 	BMCOptions *hardware.BMCOptions
+	// InventoryOptions configure collection of hardware inventory from machine BMCs. Collected
+	// inventory is applied as labels to the Hardware resources.
+	InventoryOptions *hardware.InventoryOptions
 
 	// TODO(chrisdoheryt4) Temporarily depend on the netclient until the validator can be injected.
 	// This is already a dependency, just uncached, because we require it during the initializing
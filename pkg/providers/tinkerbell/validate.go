@@ -312,12 +312,16 @@ func (r *MinimumHardwareRequirements) Add(selector v1alpha1.HardwareSelector, mi
 }
 
 // validateMinimumHardwareRequirements validates all requirements can be satisfied using hardware
-// registered with catalogue.
-func validateMinimumHardwareRequirements(requirements MinimumHardwareRequirements, catalogue *hardware.Catalogue) error {
+// registered with catalogue. Hardware quarantined by burn-in validation is never counted and,
+// when requireValidated is true, only hardware that passed burn-in validation is counted.
+func validateMinimumHardwareRequirements(requirements MinimumHardwareRequirements, catalogue *hardware.Catalogue, requireValidated bool) error {
 	// Count all hardware that meets the selector requirements for each requirement.
 	// This does not consider whether or not a piece of hardware is selectable by multiple
 	// selectors. That requires a different validation ideally run before this one.
 	for _, h := range catalogue.AllHardware() {
+		if !hardware.IsEligible(h.Labels, requireValidated) {
+			continue
+		}
 		for _, r := range requirements {
 			if hardware.LabelsMatchSelector(r.Selector, h.Labels) {
 				r.count++
@@ -328,6 +332,14 @@ func validateMinimumHardwareRequirements(requirements MinimumHardwareRequirement
 	// Validate counts of hardware meet the minimum required count.
 	for name, r := range requirements {
 		if r.count < r.MinCount {
+			if requireValidated {
+				return fmt.Errorf(
+					"minimum validated hardware count not met for selector '%v': have %v, require %v",
+					name,
+					r.count,
+					r.MinCount,
+				)
+			}
 			return fmt.Errorf(
 				"minimum hardware count not met for selector '%v': have %v, require %v",
 				name,
@@ -340,6 +352,12 @@ func validateMinimumHardwareRequirements(requirements MinimumHardwareRequirement
 	return nil
 }
 
+// requiresValidatedHardware returns true when spec restricts provisioning to hardware that passed
+// burn-in validation.
+func requiresValidatedHardware(spec *ClusterSpec) bool {
+	return spec != nil && spec.DatacenterConfig != nil && spec.DatacenterConfig.Spec.RequireValidatedHardware
+}
+
 // validateHardwareSatisfiesOnlyOneSelector ensures hardware in allHardware meets one and only one
 // selector in selectors. selectors uses the selectorSet construct to ensure we don't
 // operate on duplicate selectors given a selector can be re-used among groups as they may reference
@@ -16,7 +16,9 @@ import (
 	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
 	tinkerbellv1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1/thirdparty/tinkerbell/capt/v1beta1"
 	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
 	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell"
+	"github.com/aws/eks-anywhere/pkg/providers/tinkerbell/hardware"
 	"github.com/aws/eks-anywhere/pkg/utils/ptr"
 )
 
@@ -127,6 +129,35 @@ func TestWorkersSpecNewClusterInPlaceRolloutStrategy(t *testing.T) {
 	))
 }
 
+func TestWorkersSpecNewClusterInPlaceOSImageUpgrade(t *testing.T) {
+	g := NewWithT(t)
+	logger := test.NewNullLogger()
+	ctx := context.Background()
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_tinkerbell_multiple_node_groups.yaml")
+	spec.TinkerbellDatacenter.Spec.OSImageURL = "http://tinkerbell-example:8080/ubuntu-2204-kube-1-28.gz"
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[0].UpgradeRolloutStrategy = &anywherev1.WorkerNodesUpgradeRolloutStrategy{
+		Type: "InPlace",
+		InPlace: &anywherev1.InPlaceUpgradeParams{
+			OSImageUpgrade: true,
+		},
+	}
+	spec.Cluster.Spec.WorkerNodeGroupConfigurations[1].UpgradeRolloutStrategy = &anywherev1.WorkerNodesUpgradeRolloutStrategy{
+		Type: "InPlace",
+	}
+	client := test.NewFakeKubeClient()
+
+	workers, err := tinkerbell.WorkersSpec(ctx, logger, client, spec)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(workers).NotTo(BeNil())
+	g.Expect(workers.Groups).To(HaveLen(2))
+	annotations := map[string]map[string]string{}
+	for _, group := range workers.Groups {
+		annotations[group.MachineDeployment.Name] = group.MachineDeployment.Annotations
+	}
+	g.Expect(annotations["test-md-0"]).To(HaveKeyWithValue(constants.InPlaceOSImageURLAnnotation, "http://tinkerbell-example:8080/ubuntu-2204-kube-1-28.gz"))
+	g.Expect(annotations["test-md-1"]).ToNot(HaveKey(constants.InPlaceOSImageURLAnnotation))
+}
+
 func TestWorkersSpecUpgradeClusterNoMachineTemplateChanges(t *testing.T) {
 	g := NewWithT(t)
 	logger := test.NewNullLogger()
@@ -505,7 +536,16 @@ func machineTemplate(opts ...func(*tinkerbellv1.TinkerbellMachineTemplate)) *tin
 					HardwareAffinity: &tinkerbellv1.HardwareAffinity{
 						Required: []tinkerbellv1.HardwareAffinityTerm{
 							{
-								LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"type": "worker"}},
+								LabelSelector: metav1.LabelSelector{
+									MatchLabels: map[string]string{"type": "worker"},
+									MatchExpressions: []metav1.LabelSelectorRequirement{
+										{
+											Key:      hardware.ValidationStatusLabel,
+											Operator: metav1.LabelSelectorOpNotIn,
+											Values:   []string{hardware.ValidationPending, hardware.ValidationQuarantined},
+										},
+									},
+								},
 							},
 						},
 					},
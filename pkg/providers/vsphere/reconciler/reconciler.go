@@ -30,6 +30,11 @@ type CNIReconciler interface {
 	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
 }
 
+// ServiceLoadBalancerReconciler is an interface for reconciling the built-in service load balancer in the VSphere cluster reconciler.
+type ServiceLoadBalancerReconciler interface {
+	Reconcile(ctx context.Context, logger logr.Logger, client client.Client, spec *c.Spec) (controller.Result, error)
+}
+
 // RemoteClientRegistry is an interface that defines methods for remote clients.
 type RemoteClientRegistry interface {
 	GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error)
@@ -41,25 +46,27 @@ type IPValidator interface {
 }
 
 type Reconciler struct {
-	client               client.Client
-	validator            *vsphere.Validator
-	defaulter            *vsphere.Defaulter
-	cniReconciler        CNIReconciler
-	remoteClientRegistry RemoteClientRegistry
-	ipValidator          IPValidator
+	client                        client.Client
+	validator                     *vsphere.Validator
+	defaulter                     *vsphere.Defaulter
+	cniReconciler                 CNIReconciler
+	remoteClientRegistry          RemoteClientRegistry
+	ipValidator                   IPValidator
+	serviceLoadBalancerReconciler ServiceLoadBalancerReconciler
 	*serverside.ObjectApplier
 }
 
 // New defines a new VSphere reconciler.
-func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator) *Reconciler {
+func New(client client.Client, validator *vsphere.Validator, defaulter *vsphere.Defaulter, cniReconciler CNIReconciler, remoteClientRegistry RemoteClientRegistry, ipValidator IPValidator, serviceLoadBalancerReconciler ServiceLoadBalancerReconciler) *Reconciler {
 	return &Reconciler{
-		client:               client,
-		validator:            validator,
-		defaulter:            defaulter,
-		cniReconciler:        cniReconciler,
-		remoteClientRegistry: remoteClientRegistry,
-		ipValidator:          ipValidator,
-		ObjectApplier:        serverside.NewObjectApplier(client),
+		client:                        client,
+		validator:                     validator,
+		defaulter:                     defaulter,
+		cniReconciler:                 cniReconciler,
+		remoteClientRegistry:          remoteClientRegistry,
+		ipValidator:                   ipValidator,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		ObjectApplier:                 serverside.NewObjectApplier(client),
 	}
 }
 
@@ -128,6 +135,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, log logr.Logger, cluster *an
 		r.CheckControlPlaneReady,
 		r.ReconcileCNI,
 		r.ReconcileWorkers,
+		r.ReconcileServiceLoadBalancer,
 	).Run(ctx, log, clusterSpec)
 }
 
@@ -279,6 +287,17 @@ func (r *Reconciler) ReconcileWorkers(ctx context.Context, log logr.Logger, spec
 	return clusters.ReconcileWorkersForEKSA(ctx, log, r.client, spec.Cluster, clusters.ToWorkers(w))
 }
 
+// ReconcileServiceLoadBalancer installs and configures the built-in service load balancer when the cluster has one.
+func (r *Reconciler) ReconcileServiceLoadBalancer(ctx context.Context, log logr.Logger, spec *c.Spec) (controller.Result, error) {
+	log = log.WithValues("phase", "reconcileServiceLoadBalancer")
+	client, err := r.remoteClientRegistry.GetClient(ctx, controller.CapiClusterObjectKey(spec.Cluster))
+	if err != nil {
+		return controller.Result{}, err
+	}
+
+	return r.serviceLoadBalancerReconciler.Reconcile(ctx, log, client, spec)
+}
+
 func toClientControlPlane(cp *vsphere.ControlPlane) *clusters.ControlPlane {
 	other := make([]client.Object, 0, len(cp.ConfigMaps)+len(cp.Secrets)+len(cp.ClusterResourceSets)+1)
 	for _, o := range cp.ClusterResourceSets {
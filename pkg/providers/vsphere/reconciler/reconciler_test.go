@@ -76,8 +76,9 @@ func TestReconcilerReconcileSuccess(t *testing.T) {
 
 	tt.remoteClientRegistry.EXPECT().GetClient(
 		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
-	).Return(remoteClient, nil).Times(1)
+	).Return(remoteClient, nil).Times(2)
 	tt.cniReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, tt.buildSpec())
 
 	result, err := tt.reconciler().Reconcile(tt.ctx, logger, tt.cluster)
 
@@ -318,6 +319,42 @@ func TestReconcileCNISuccess(t *testing.T) {
 	tt.Expect(result).To(Equal(controller.Result{}))
 }
 
+func TestReconcileServiceLoadBalancerSuccess(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	remoteClient := fake.NewClientBuilder().Build()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
+	).Return(remoteClient, nil)
+	tt.serviceLoadBalancerReconciler.EXPECT().Reconcile(tt.ctx, logger, remoteClient, spec)
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, spec)
+
+	tt.Expect(err).NotTo(HaveOccurred())
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
+func TestReconcileServiceLoadBalancerErrorClientRegistry(t *testing.T) {
+	tt := newReconcilerTest(t)
+	tt.withFakeClient()
+
+	logger := test.NewNullLogger()
+	spec := tt.buildSpec()
+
+	tt.remoteClientRegistry.EXPECT().GetClient(
+		tt.ctx, client.ObjectKey{Name: tt.cluster.Name, Namespace: "eksa-system"},
+	).Return(nil, errors.New("building client"))
+
+	result, err := tt.reconciler().ReconcileServiceLoadBalancer(tt.ctx, logger, spec)
+
+	tt.Expect(err).To(MatchError(ContainSubstring("building client")))
+	tt.Expect(result).To(Equal(controller.Result{}))
+}
+
 func TestReconcileCNIErrorClientRegistry(t *testing.T) {
 	tt := newReconcilerTest(t)
 	tt.withFakeClient()
@@ -389,28 +426,30 @@ type reconcilerTest struct {
 	t testing.TB
 	*WithT
 	*envtest.APIExpecter
-	ctx                       context.Context
-	cniReconciler             *vspherereconcilermocks.MockCNIReconciler
-	govcClient                *mocks.MockProviderGovcClient
-	validator                 *vsphere.Validator
-	defaulter                 *vsphere.Defaulter
-	remoteClientRegistry      *vspherereconcilermocks.MockRemoteClientRegistry
-	cluster                   *anywherev1.Cluster
-	client                    client.Client
-	env                       *envtest.Environment
-	bundle                    *releasev1.Bundles
-	eksaSupportObjs           []client.Object
-	datacenterConfig          *anywherev1.VSphereDatacenterConfig
-	machineConfigControlPlane *anywherev1.VSphereMachineConfig
-	machineConfigWorker       *anywherev1.VSphereMachineConfig
-	ipValidator               *vspherereconcilermocks.MockIPValidator
-	kcp                       *controlplanev1.KubeadmControlPlane
-	vsphereDeploymentZone     *vspherev1.VSphereDeploymentZone
+	ctx                           context.Context
+	cniReconciler                 *vspherereconcilermocks.MockCNIReconciler
+	serviceLoadBalancerReconciler *vspherereconcilermocks.MockServiceLoadBalancerReconciler
+	govcClient                    *mocks.MockProviderGovcClient
+	validator                     *vsphere.Validator
+	defaulter                     *vsphere.Defaulter
+	remoteClientRegistry          *vspherereconcilermocks.MockRemoteClientRegistry
+	cluster                       *anywherev1.Cluster
+	client                        client.Client
+	env                           *envtest.Environment
+	bundle                        *releasev1.Bundles
+	eksaSupportObjs               []client.Object
+	datacenterConfig              *anywherev1.VSphereDatacenterConfig
+	machineConfigControlPlane     *anywherev1.VSphereMachineConfig
+	machineConfigWorker           *anywherev1.VSphereMachineConfig
+	ipValidator                   *vspherereconcilermocks.MockIPValidator
+	kcp                           *controlplanev1.KubeadmControlPlane
+	vsphereDeploymentZone         *vspherev1.VSphereDeploymentZone
 }
 
 func newReconcilerTest(t testing.TB) *reconcilerTest {
 	ctrl := gomock.NewController(t)
 	cniReconciler := vspherereconcilermocks.NewMockCNIReconciler(ctrl)
+	serviceLoadBalancerReconciler := vspherereconcilermocks.NewMockServiceLoadBalancerReconciler(ctrl)
 	remoteClientRegistry := vspherereconcilermocks.NewMockRemoteClientRegistry(ctrl)
 	c := env.Client()
 
@@ -517,18 +556,19 @@ func newReconcilerTest(t testing.TB) *reconcilerTest {
 	vsphereDeploymentZone := VSphereDeploymentZone(strings.ToLower(t.Name()), workloadClusterDatacenter.Name)
 
 	tt := &reconcilerTest{
-		t:                    t,
-		WithT:                NewWithT(t),
-		APIExpecter:          envtest.NewAPIExpecter(t, c),
-		ctx:                  context.Background(),
-		cniReconciler:        cniReconciler,
-		govcClient:           govcClient,
-		validator:            validator,
-		defaulter:            defaulter,
-		ipValidator:          ipValidator,
-		remoteClientRegistry: remoteClientRegistry,
-		client:               c,
-		env:                  env,
+		t:                             t,
+		WithT:                         NewWithT(t),
+		APIExpecter:                   envtest.NewAPIExpecter(t, c),
+		ctx:                           context.Background(),
+		cniReconciler:                 cniReconciler,
+		serviceLoadBalancerReconciler: serviceLoadBalancerReconciler,
+		govcClient:                    govcClient,
+		validator:                     validator,
+		defaulter:                     defaulter,
+		ipValidator:                   ipValidator,
+		remoteClientRegistry:          remoteClientRegistry,
+		client:                        c,
+		env:                           env,
 		eksaSupportObjs: []client.Object{
 			test.Namespace(clusterNamespace),
 			test.Namespace(constants.EksaSystemNamespace),
@@ -573,7 +613,7 @@ func (tt *reconcilerTest) withFakeClient() {
 }
 
 func (tt *reconcilerTest) reconciler() *reconciler.Reconciler {
-	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator)
+	return reconciler.New(tt.client, tt.validator, tt.defaulter, tt.cniReconciler, tt.remoteClientRegistry, tt.ipValidator, tt.serviceLoadBalancerReconciler)
 }
 
 func (tt *reconcilerTest) createAllObjs() {
@@ -188,6 +188,8 @@ func buildTemplateMapCP(
 
 	values := map[string]interface{}{
 		"clusterName":                          clusterSpec.Cluster.Name,
+		"kubeletNodeIPCommand":                 clusterapi.KubeletNodeIPCommand(&clusterSpec.Cluster.Spec.ClusterNetwork),
+		"kubeVipCidr":                          clusterapi.KubeVipCIDR(clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host),
 		"controlPlaneEndpointIp":               clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host,
 		"controlPlaneReplicas":                 clusterSpec.Cluster.Spec.ControlPlaneConfiguration.Count,
 		"apiServerCertSANs":                    clusterSpec.Cluster.Spec.ControlPlaneConfiguration.CertSANs,
@@ -217,7 +219,7 @@ func buildTemplateMapCP(
 		"etcdTagIDs":                           etcdMachineSpec.TagIDs,
 		"controlPlaneSshUsername":              firstControlPlaneMachinesUser.Name,
 		"vsphereControlPlaneSshAuthorizedKey":  controlPlaneSSHKey,
-		"podCidrs":                             clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks,
+		"podCidrs":                             clusterSpec.Cluster.Spec.ClusterNetwork.PrimaryPodCIDRBlocks(),
 		"serviceCidrs":                         clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks,
 		"etcdExtraArgs":                        etcdExtraArgs.ToPartialYaml(),
 		"etcdCipherSuites":                     crypto.SecureCipherSuitesString(),
@@ -468,6 +470,7 @@ func buildTemplateMapMD(
 
 	values := map[string]interface{}{
 		"clusterName":                    clusterSpec.Cluster.Name,
+		"kubeletNodeIPCommand":           clusterapi.KubeletNodeIPCommand(&clusterSpec.Cluster.Spec.ClusterNetwork),
 		"kubernetesVersion":              bundle.KubeDistro.Kubernetes.Tag,
 		"thumbprint":                     datacenterSpec.Thumbprint,
 		"vsphereDatacenter":              datacenterSpec.Datacenter,
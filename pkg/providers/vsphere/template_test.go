@@ -270,3 +270,24 @@ func TestVsphereTemplateBuilderGenerateCAPISpecControlPlaneWithDefaultAuditPolic
 
 	g.Expect(collapseWhitespace(string(data))).To(ContainSubstring(collapseWhitespace(defaultAuditPolicy)))
 }
+
+func TestVsphereTemplateBuilderGenerateCAPISpecDualStack(t *testing.T) {
+	g := NewWithT(t)
+	spec := test.NewFullClusterSpec(t, "testdata/cluster_main.yaml")
+	spec.Cluster.Spec.ControlPlaneConfiguration.Endpoint.Host = "fd00:3::10"
+	spec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"fd00:1::/56", "192.168.0.0/16"}
+	spec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"fd00:2::/108", "10.96.0.0/12"}
+	nodeIPCommand := clusterapi.KubeletNodeIPCommand(&spec.Cluster.Spec.ClusterNetwork)
+	builder := vsphere.NewVsphereTemplateBuilder(time.Now)
+
+	cp, err := builder.GenerateCAPISpecControlPlane(spec)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(cp)).To(ContainSubstring("cidrBlocks: [fd00:1::/56, 192.168.0.0/16]"))
+	g.Expect(string(cp)).To(ContainSubstring("cidrBlocks: [fd00:2::/108, 10.96.0.0/12]"))
+	g.Expect(string(cp)).To(MatchRegexp(`name: vip_cidr\s+value: "128"`))
+	g.Expect(string(cp)).To(ContainSubstring(`- "` + nodeIPCommand + `"`))
+
+	workers, err := builder.GenerateCAPISpecWorkers(spec, nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(workers)).To(ContainSubstring(`- "` + nodeIPCommand + `"`))
+}
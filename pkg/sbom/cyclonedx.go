@@ -0,0 +1,170 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	cycloneDXSpecVersion = "1.5"
+	// cycloneDXPredicatePrefix identifies the CycloneDX SBOM attestations.
+	cycloneDXPredicatePrefix = "https://cyclonedx.org/bom"
+	// propertyPrefix namespaces the EKS Anywhere properties of the CycloneDX components.
+	propertyPrefix = "eks-anywhere:"
+	toolName       = "eksctl-anywhere"
+)
+
+type cycloneDXDocument struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDXMetadata     `json:"metadata"`
+	Components   []cycloneDXComponent  `json:"components"`
+	Dependencies []cycloneDXDependency `json:"dependencies"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     cycloneDXTools     `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTools struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type        string              `json:"type"`
+	BOMRef      string              `json:"bom-ref,omitempty"`
+	Name        string              `json:"name"`
+	Version     string              `json:"version,omitempty"`
+	Description string              `json:"description,omitempty"`
+	PURL        string              `json:"purl,omitempty"`
+	Hashes      []cycloneDXHash     `json:"hashes,omitempty"`
+	Properties  []cycloneDXProperty `json:"properties,omitempty"`
+	Components  []json.RawMessage   `json:"components,omitempty"`
+}
+
+type cycloneDXHash struct {
+	Algorithm string `json:"alg"`
+	Content   string `json:"content"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// WriteCycloneDX writes the SBOM as a CycloneDX JSON document. The components of the CycloneDX SBOM
+// attestations of an image are nested in the image component.
+func WriteCycloneDX(w io.Writer, s *SBOM, toolVersion string) error {
+	clusterRef := "cluster/" + s.ClusterName
+	doc := cycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  cycloneDXSpecVersion,
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: s.Timestamp.Format(time.RFC3339),
+			Tools: cycloneDXTools{
+				Components: []cycloneDXComponent{{Type: "application", Name: toolName, Version: toolVersion}},
+			},
+			Component: cycloneDXComponent{
+				Type:    "platform",
+				BOMRef:  clusterRef,
+				Name:    s.ClusterName,
+				Version: s.EKSAVersion,
+				Properties: []cycloneDXProperty{
+					{Name: propertyPrefix + "bundles-number", Value: fmt.Sprint(s.BundlesNumber)},
+				},
+			},
+		},
+		Components:   make([]cycloneDXComponent, 0, len(s.Components)),
+		Dependencies: []cycloneDXDependency{{Ref: clusterRef, DependsOn: []string{}}},
+	}
+
+	for _, c := range s.Components {
+		component := cycloneDXComponent{
+			Type:        "container",
+			BOMRef:      c.Image.URI,
+			Name:        c.Name(),
+			Version:     c.Version(),
+			Description: c.Image.Description,
+			PURL:        purl(c),
+			Properties: []cycloneDXProperty{
+				{Name: propertyPrefix + "image", Value: c.Image.URI},
+				{Name: propertyPrefix + "in-bundle", Value: fmt.Sprint(c.InBundle)},
+			},
+		}
+		if hash := digestHex(c.Image.ImageDigest); hash != "" {
+			component.Hashes = []cycloneDXHash{{Algorithm: "SHA-256", Content: hash}}
+		}
+
+		for _, a := range c.Attestations {
+			component.Properties = append(component.Properties, cycloneDXProperty{Name: propertyPrefix + "attestation", Value: a.PredicateType})
+			if !strings.HasPrefix(a.PredicateType, cycloneDXPredicatePrefix) {
+				continue
+			}
+			nested := struct {
+				Components []json.RawMessage `json:"components"`
+			}{}
+			if err := json.Unmarshal(a.Predicate, &nested); err != nil {
+				return fmt.Errorf("reading CycloneDX attestation of image %s: %v", c.Image.URI, err)
+			}
+			component.Components = append(component.Components, nested.Components...)
+		}
+
+		doc.Components = append(doc.Components, component)
+		doc.Dependencies[0].DependsOn = append(doc.Dependencies[0].DependsOn, component.BOMRef)
+	}
+
+	return writeJSON(w, doc)
+}
+
+// purl returns the package URL of the image of a component, https://github.com/package-url/purl-spec.
+func purl(c Component) string {
+	ref := parseReference(c.Image.URI)
+	name := ref.repository[strings.LastIndex(ref.repository, "/")+1:]
+	repositoryURL := strings.TrimSuffix(strings.Split(c.Image.URI, "@")[0], ":"+ref.tag)
+
+	p := "pkg:oci/" + name
+	if c.Image.ImageDigest != "" {
+		p += "@" + strings.ReplaceAll(c.Image.ImageDigest, ":", "%3A")
+	}
+	query := url.Values{}
+	query.Set("repository_url", repositoryURL)
+	if ref.tag != "" {
+		query.Set("tag", ref.tag)
+	}
+
+	return p + "?" + query.Encode()
+}
+
+func digestHex(digest string) string {
+	if hex, found := strings.CutPrefix(digest, "sha256:"); found {
+		return hex
+	}
+	return ""
+}
+
+func writeJSON(w io.Writer, doc interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("writing sbom: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package sbom_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/sbom"
+	"github.com/aws/eks-anywhere/pkg/signature"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+func testSBOM() *sbom.SBOM {
+	return &sbom.SBOM{
+		ClusterName:   "prod",
+		EKSAVersion:   "v0.23.0",
+		BundlesNumber: 7,
+		Timestamp:     time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC),
+		Components: []sbom.Component{
+			{
+				Image:    ciliumImage,
+				InBundle: true,
+				Attestations: []signature.Attestation{
+					{PredicateType: "https://cyclonedx.org/bom", Predicate: json.RawMessage(`{"components":[{"type":"library","name":"golang.org/x/net","version":"v0.38.0"}]}`)},
+					{PredicateType: "https://spdx.dev/Document", Predicate: json.RawMessage(`{"packages":[{"SPDXID":"SPDXRef-Package-1","name":"glibc","versionInfo":"2.34"}]}`)},
+				},
+			},
+			{Image: releasev1.Image{Name: "kube-vip", URI: "public.ecr.aws/eks-anywhere/kube-vip/kube-vip:v0.7.0"}},
+		},
+	}
+}
+
+func TestWriteCycloneDX(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	g.Expect(sbom.WriteCycloneDX(out, testSBOM(), "v0.23.0")).To(Succeed())
+
+	doc := map[string]interface{}{}
+	g.Expect(json.Unmarshal(out.Bytes(), &doc)).To(Succeed())
+	g.Expect(doc).To(HaveKeyWithValue("bomFormat", "CycloneDX"))
+	g.Expect(doc).To(HaveKeyWithValue("specVersion", "1.5"))
+	g.Expect(doc["serialNumber"]).To(HavePrefix("urn:uuid:"))
+	g.Expect(doc["metadata"]).To(HaveKeyWithValue("timestamp", "2026-10-19T08:00:00Z"))
+	g.Expect(doc["metadata"]).To(HaveKeyWithValue("component", HaveKeyWithValue("name", "prod")))
+
+	components := doc["components"].([]interface{})
+	g.Expect(components).To(HaveLen(2))
+	cilium := components[0].(map[string]interface{})
+	g.Expect(cilium).To(HaveKeyWithValue("type", "container"))
+	g.Expect(cilium).To(HaveKeyWithValue("name", "cilium"))
+	g.Expect(cilium).To(HaveKeyWithValue("version", "v1.15.16-eksa.1"))
+	g.Expect(cilium).To(HaveKeyWithValue("purl", "pkg:oci/cilium@sha256%3A"+ciliumDigest[len("sha256:"):]+"?repository_url=public.ecr.aws%2Fisovalent%2Fcilium&tag=v1.15.16-eksa.1"))
+	g.Expect(cilium).To(HaveKeyWithValue("hashes", ConsistOf(HaveKeyWithValue("content", ciliumDigest[len("sha256:"):]))))
+	g.Expect(cilium).To(HaveKeyWithValue("components", ConsistOf(HaveKeyWithValue("name", "golang.org/x/net"))))
+	g.Expect(cilium["properties"]).To(ContainElements(
+		map[string]interface{}{"name": "eks-anywhere:in-bundle", "value": "true"},
+		map[string]interface{}{"name": "eks-anywhere:attestation", "value": "https://spdx.dev/Document"},
+	))
+
+	kubeVip := components[1].(map[string]interface{})
+	g.Expect(kubeVip).NotTo(HaveKey("hashes"))
+	g.Expect(kubeVip["properties"]).To(ContainElement(map[string]interface{}{"name": "eks-anywhere:in-bundle", "value": "false"}))
+	g.Expect(doc["dependencies"]).To(ConsistOf(HaveKeyWithValue("dependsOn", ConsistOf(ciliumImage.URI, kubeVip["bom-ref"]))))
+}
+
+func TestWriteCycloneDXInvalidAttestation(t *testing.T) {
+	g := NewWithT(t)
+	s := testSBOM()
+	s.Components[0].Attestations[0].Predicate = json.RawMessage(`"not a bom"`)
+
+	g.Expect(sbom.WriteCycloneDX(&bytes.Buffer{}, s, "v0.23.0")).To(MatchError(ContainSubstring("reading CycloneDX attestation of image " + ciliumImage.URI)))
+}
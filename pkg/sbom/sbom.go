@@ -0,0 +1,333 @@
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/logger"
+	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
+	"github.com/aws/eks-anywhere/pkg/signature"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// SBOM is the software bill of materials of a cluster: the images of the EKS Anywhere components deployed in it.
+type SBOM struct {
+	ClusterName   string
+	EKSAVersion   string
+	BundlesNumber int
+	Timestamp     time.Time
+	Components    []Component
+}
+
+// Component is an image of an EKS Anywhere component running in a cluster.
+type Component struct {
+	// Image is the image in the Bundles. For images that don't match the Bundles, its URI and digest
+	// are the ones running in the cluster.
+	Image releasev1.Image
+	// InBundle is false for images running in the cluster from the repository of a component
+	// in the Bundles but with a different version.
+	InBundle bool
+	// Attestations are the attestations of the image signed by the trust root.
+	Attestations []signature.Attestation
+}
+
+// Name returns the name of the component.
+func (c Component) Name() string {
+	if c.Image.Name != "" {
+		return c.Image.Name
+	}
+	return c.Image.Image()
+}
+
+// Version returns the version of the component, its image tag.
+func (c Component) Version() string {
+	return parseReference(c.Image.URI).tag
+}
+
+// AttestationSource retrieves the attestations of an image.
+type AttestationSource interface {
+	Attestations(ctx context.Context, image releasev1.Image) ([]signature.Attestation, error)
+}
+
+// Collector builds the SBOM of a cluster from its Bundles, cross-checked with the images running in the cluster.
+type Collector struct {
+	management   kubernetes.Reader
+	workload     kubernetes.Reader
+	attestations AttestationSource
+	now          func() time.Time
+}
+
+// CollectorOpt configures a Collector.
+type CollectorOpt func(*Collector)
+
+// WithAttestations makes the Collector merge the attestations of the images in the SBOM.
+func WithAttestations(source AttestationSource) CollectorOpt {
+	return func(c *Collector) {
+		c.attestations = source
+	}
+}
+
+// WithTimestamp sets the function returning the SBOM timestamp.
+func WithTimestamp(now func() time.Time) CollectorOpt {
+	return func(c *Collector) {
+		c.now = now
+	}
+}
+
+// NewCollector builds a Collector. The management client reads the cluster and its Bundles and the workload
+// client lists the images running in the cluster. Both are the same client for a self-managed cluster.
+func NewCollector(management, workload kubernetes.Reader, opts ...CollectorOpt) *Collector {
+	c := &Collector{
+		management: management,
+		workload:   workload,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Collect builds the SBOM of a cluster. It lists the images of the Bundles and EKS-D releases for the
+// cluster Kubernetes versions that are running in the cluster.
+func (c *Collector) Collect(ctx context.Context, clusterName, namespace string) (*SBOM, error) {
+	eksaCluster := &anywherev1.Cluster{}
+	if err := c.management.Get(ctx, clusterName, namespace, eksaCluster); err != nil {
+		return nil, fmt.Errorf("reading cluster %s: %v", clusterName, err)
+	}
+
+	b, err := cluster.BundlesForCluster(ctx, c.management, eksaCluster)
+	if err != nil {
+		return nil, fmt.Errorf("reading bundles for cluster %s: %v", clusterName, err)
+	}
+
+	images, err := c.bundleImages(ctx, eksaCluster, b)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := &corev1.PodList{}
+	if err := c.workload.List(ctx, pods); err != nil {
+		return nil, fmt.Errorf("listing pods of cluster %s: %v", clusterName, err)
+	}
+
+	s := &SBOM{
+		ClusterName:   clusterName,
+		BundlesNumber: b.Spec.Number,
+		Timestamp:     c.now().UTC(),
+		Components:    Components(images, RunningImages(pods.Items)),
+	}
+	if eksaCluster.Spec.EksaVersion != nil {
+		s.EKSAVersion = string(*eksaCluster.Spec.EksaVersion)
+	}
+
+	if c.attestations == nil {
+		return s, nil
+	}
+	for i := range s.Components {
+		if !s.Components[i].InBundle {
+			continue
+		}
+		attestations, err := c.attestations.Attestations(ctx, s.Components[i].Image)
+		if err != nil {
+			return nil, err
+		}
+		s.Components[i].Attestations = attestations
+	}
+
+	return s, nil
+}
+
+// bundleImages returns the images of the versions bundles and EKS-D releases of the cluster Kubernetes versions.
+func (c *Collector) bundleImages(ctx context.Context, eksaCluster *anywherev1.Cluster, b *releasev1.Bundles) ([]releasev1.Image, error) {
+	var images []releasev1.Image
+	for _, version := range eksaCluster.KubernetesVersions() {
+		vb, err := cluster.GetVersionsBundle(version, b)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, vb.Images()...)
+
+		// The EKS-D releases are always in eksa-system, see cluster.BuildSpec.
+		eksdRelease := &eksdv1.Release{}
+		if err := c.management.Get(ctx, vb.EksD.Name, constants.EksaSystemNamespace, eksdRelease); err != nil {
+			return nil, fmt.Errorf("reading eks-d release %s: %v", vb.EksD.Name, err)
+		}
+		images = append(images, bundles.EKSDImages(eksdRelease)...)
+	}
+
+	return images, nil
+}
+
+// RunningImage is an image of a container running in a cluster.
+type RunningImage struct {
+	// Reference is the image of the container, as in the pod spec.
+	Reference string
+	// Digest is the digest of the image resolved by the container runtime, if known.
+	Digest string
+}
+
+// RunningImages returns the images of the containers of pods, deduplicated.
+func RunningImages(pods []corev1.Pod) []RunningImage {
+	seen := map[RunningImage]bool{}
+	var images []RunningImage
+	add := func(i RunningImage) {
+		if i.Reference == "" || seen[i] {
+			return
+		}
+		seen[i] = true
+		images = append(images, i)
+	}
+
+	for _, pod := range pods {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, s := range statuses {
+			add(RunningImage{Reference: s.Image, Digest: parseReference(s.ImageID).digest})
+		}
+		if len(statuses) > 0 {
+			continue
+		}
+		// Pods without statuses haven't started yet, their images are not resolved.
+		for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			add(RunningImage{Reference: container.Image})
+		}
+	}
+
+	return images
+}
+
+// Components returns the components of the bundle images that are running. Running images from the repository
+// of a bundle image but with another version are returned as components not in the bundle.
+// Other running images, like user workloads, are not EKS Anywhere components and are ignored.
+func Components(bundleImages []releasev1.Image, running []RunningImage) []Component {
+	seen := map[string]bool{}
+	var images []releasev1.Image
+	for _, i := range bundleImages {
+		if i.URI == "" || seen[i.URI] {
+			continue
+		}
+		seen[i.URI] = true
+		images = append(images, i)
+	}
+
+	deployed := map[string]bool{}
+	var components []Component
+	for _, r := range running {
+		image, found := matchBundleImage(images, r)
+		if found {
+			if !deployed[image.URI] {
+				deployed[image.URI] = true
+				components = append(components, Component{Image: image, InBundle: true})
+			}
+			continue
+		}
+
+		ref := parseReference(r.Reference)
+		bundleImage, found := matchRepository(images, ref.repository)
+		if !found {
+			continue
+		}
+		logger.MarkWarning("Image running in the cluster doesn't match the Bundles", "image", r.Reference, "bundle", bundleImage.URI)
+		digest := r.Digest
+		if digest == "" {
+			digest = ref.digest
+		}
+		components = append(components, Component{
+			Image: releasev1.Image{
+				Name:        bundleImage.Name,
+				Description: bundleImage.Description,
+				URI:         r.Reference,
+				ImageDigest: digest,
+			},
+		})
+	}
+
+	sort.SliceStable(components, func(i, j int) bool {
+		if components[i].Name() != components[j].Name() {
+			return components[i].Name() < components[j].Name()
+		}
+		return components[i].Image.URI < components[j].Image.URI
+	})
+
+	return components
+}
+
+// matchBundleImage finds the bundle image of a running image, by digest or by repository and tag.
+// The registry is ignored so images pulled from a registry mirror match. An image matching by tag
+// but running a digest other than the one in the bundle doesn't match, the tag was moved.
+func matchBundleImage(images []releasev1.Image, r RunningImage) (releasev1.Image, bool) {
+	ref := parseReference(r.Reference)
+	digest := r.Digest
+	if digest == "" {
+		digest = ref.digest
+	}
+
+	for _, image := range images {
+		if digest != "" && image.ImageDigest == digest {
+			return image, true
+		}
+	}
+	for _, image := range images {
+		bundleRef := parseReference(image.URI)
+		if ref.tag == "" || ref.tag != bundleRef.tag || !repositoryMatches(ref.repository, bundleRef.repository) {
+			continue
+		}
+		if digest != "" && image.ImageDigest != "" && digest != image.ImageDigest {
+			continue
+		}
+		return image, true
+	}
+
+	return releasev1.Image{}, false
+}
+
+func matchRepository(images []releasev1.Image, repository string) (releasev1.Image, bool) {
+	for _, image := range images {
+		if repositoryMatches(repository, parseReference(image.URI).repository) {
+			return image, true
+		}
+	}
+
+	return releasev1.Image{}, false
+}
+
+// repositoryMatches returns true if the running repository is the bundle one, including
+// when a registry mirror adds a namespace in front of it.
+func repositoryMatches(running, bundle string) bool {
+	return running == bundle || strings.HasSuffix(running, "/"+bundle)
+}
+
+type reference struct {
+	repository string
+	tag        string
+	digest     string
+}
+
+// parseReference splits an image reference in its repository, without the registry, tag and digest.
+func parseReference(ref string) reference {
+	r := reference{}
+	ref = strings.TrimPrefix(ref, "docker-pullable://")
+	if name, digest, found := strings.Cut(ref, "@"); found {
+		ref, r.digest = name, digest
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref, r.tag = ref[:i], ref[i+1:]
+	}
+	if registry, repository, found := strings.Cut(ref, "/"); found && (strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		ref = repository
+	}
+	r.repository = ref
+
+	return r
+}
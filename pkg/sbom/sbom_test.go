@@ -0,0 +1,246 @@
+package sbom_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	eksdv1 "github.com/aws/eks-distro-build-tooling/release/api/v1alpha1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/sbom"
+	"github.com/aws/eks-anywhere/pkg/signature"
+	releasev1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	ciliumDigest  = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	kubeVipDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+var (
+	ciliumImage = releasev1.Image{
+		Name:        "cilium",
+		Description: "Container image for cilium image",
+		URI:         "public.ecr.aws/isovalent/cilium:v1.15.16-eksa.1",
+		ImageDigest: ciliumDigest,
+	}
+	kubeVipImage = releasev1.Image{
+		Name:        "kube-vip",
+		URI:         "public.ecr.aws/eks-anywhere/kube-vip/kube-vip:v0.8.9-eks-a-1",
+		ImageDigest: kubeVipDigest,
+	}
+	apiServerImage = releasev1.Image{
+		Name:        "kube-apiserver-image",
+		Description: "kube-apiserver container image",
+		URI:         "public.ecr.aws/eks-distro/kubernetes/kube-apiserver:v1.29.10-eks-1-29-26",
+		OS:          "linux",
+		Arch:        []string{"amd64"},
+	}
+)
+
+func pod(namespace, name string, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Status:     corev1.PodStatus{ContainerStatuses: statuses},
+	}
+	for _, s := range statuses {
+		p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: s.Name, Image: s.Image})
+	}
+	return p
+}
+
+type fakeAttestations map[string][]signature.Attestation
+
+func (f fakeAttestations) Attestations(_ context.Context, image releasev1.Image) ([]signature.Attestation, error) {
+	return f[image.URI], nil
+}
+
+func TestCollectorCollect(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	version := anywherev1.EksaVersion("v0.23.0")
+	eksaCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube129,
+			EksaVersion:       &version,
+			BundlesRef:        &anywherev1.BundlesRef{Name: "bundles-1", Namespace: "default"},
+		},
+	}
+	bundles := &releasev1.Bundles{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundles-1", Namespace: "default"},
+		Spec: releasev1.BundlesSpec{
+			Number: 7,
+			VersionsBundles: []releasev1.VersionsBundle{{
+				KubeVersion: "1.29",
+				EksD:        releasev1.EksDRelease{Name: "kubernetes-1-29-eks-26"},
+				Cilium:      releasev1.CiliumBundle{Cilium: ciliumImage},
+				VSphere:     releasev1.VSphereBundle{KubeVip: kubeVipImage},
+			}},
+		},
+	}
+	eksdRelease := &eksdv1.Release{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubernetes-1-29-eks-26", Namespace: constants.EksaSystemNamespace},
+		Status: eksdv1.ReleaseStatus{Components: []eksdv1.Component{{
+			Name: "kubernetes",
+			Assets: []eksdv1.Asset{{
+				Name:        "kube-apiserver-image",
+				Type:        "Image",
+				Description: "kube-apiserver container image",
+				OS:          "linux",
+				Arch:        []string{"amd64"},
+				Image:       &eksdv1.AssetImage{URI: apiServerImage.URI},
+			}},
+		}}},
+	}
+	kubeClient := test.NewFakeKubeClient(
+		eksaCluster, bundles, eksdRelease,
+		pod("kube-system", "cilium-abcde", corev1.ContainerStatus{
+			Name:    "cilium-agent",
+			Image:   "mirror.local:443/isovalent/cilium:v1.15.16-eksa.1",
+			ImageID: "mirror.local:443/isovalent/cilium@" + ciliumDigest,
+		}),
+		pod("kube-system", "kube-apiserver-cp-1", corev1.ContainerStatus{Name: "kube-apiserver", Image: apiServerImage.URI}),
+		pod("kube-system", "kube-vip-cp-1", corev1.ContainerStatus{Name: "kube-vip", Image: "public.ecr.aws/eks-anywhere/kube-vip/kube-vip:v0.7.0"}),
+		pod("default", "nginx", corev1.ContainerStatus{Name: "nginx", Image: "nginx:1.27"}),
+	)
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	attestation := signature.Attestation{PredicateType: "https://slsa.dev/provenance/v1", Predicate: json.RawMessage(`{}`)}
+
+	collector := sbom.NewCollector(kubeClient, kubeClient,
+		sbom.WithTimestamp(func() time.Time { return now }),
+		sbom.WithAttestations(fakeAttestations{ciliumImage.URI: {attestation}}),
+	)
+	got, err := collector.Collect(ctx, "prod", "default")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got).To(Equal(&sbom.SBOM{
+		ClusterName:   "prod",
+		EKSAVersion:   "v0.23.0",
+		BundlesNumber: 7,
+		Timestamp:     now,
+		Components: []sbom.Component{
+			{Image: ciliumImage, InBundle: true, Attestations: []signature.Attestation{attestation}},
+			{Image: apiServerImage, InBundle: true},
+			{Image: releasev1.Image{Name: "kube-vip", URI: "public.ecr.aws/eks-anywhere/kube-vip/kube-vip:v0.7.0"}},
+		},
+	}))
+}
+
+func TestCollectorCollectErrors(t *testing.T) {
+	ctx := context.Background()
+	eksaCluster := &anywherev1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Namespace: "default"},
+		Spec: anywherev1.ClusterSpec{
+			KubernetesVersion: anywherev1.Kube129,
+			BundlesRef:        &anywherev1.BundlesRef{Name: "bundles-1", Namespace: "default"},
+		},
+	}
+	bundles := &releasev1.Bundles{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundles-1", Namespace: "default"},
+		Spec: releasev1.BundlesSpec{VersionsBundles: []releasev1.VersionsBundle{{
+			KubeVersion: "1.29",
+			EksD:        releasev1.EksDRelease{Name: "kubernetes-1-29-eks-26"},
+		}}},
+	}
+
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		wantErr string
+	}{
+		{
+			name:    "missing cluster",
+			wantErr: "reading cluster prod",
+		},
+		{
+			name:    "missing bundles",
+			objs:    []client.Object{eksaCluster.DeepCopy()},
+			wantErr: "reading bundles for cluster prod",
+		},
+		{
+			name:    "missing eks-d release",
+			objs:    []client.Object{eksaCluster.DeepCopy(), bundles.DeepCopy()},
+			wantErr: "reading eks-d release kubernetes-1-29-eks-26",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			kubeClient := test.NewFakeKubeClient(tt.objs...)
+			_, err := sbom.NewCollector(kubeClient, kubeClient).Collect(ctx, "prod", "default")
+			g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+		})
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g := NewWithT(t)
+	images := []releasev1.Image{ciliumImage, kubeVipImage, ciliumImage, {Name: "empty"}}
+	running := []sbom.RunningImage{
+		{Reference: "docker.io/library/nginx:1.27"},
+		{Reference: "public.ecr.aws/eks-anywhere/kube-vip/kube-vip@" + kubeVipDigest},
+		{Reference: "registry.local/mirror/isovalent/cilium:v1.15.16-eksa.1"},
+		{Reference: "registry.local/mirror/isovalent/cilium:v1.15.16-eksa.1", Digest: ciliumDigest},
+	}
+
+	g.Expect(sbom.Components(images, running)).To(Equal([]sbom.Component{
+		{Image: ciliumImage, InBundle: true},
+		{Image: kubeVipImage, InBundle: true},
+	}))
+}
+
+func TestComponentsTagMovedToOtherDigest(t *testing.T) {
+	g := NewWithT(t)
+	images := []releasev1.Image{ciliumImage}
+	running := []sbom.RunningImage{
+		{Reference: "registry.local/mirror/isovalent/cilium:v1.15.16-eksa.1", Digest: kubeVipDigest},
+	}
+
+	g.Expect(sbom.Components(images, running)).To(Equal([]sbom.Component{
+		{Image: releasev1.Image{
+			Name:        ciliumImage.Name,
+			Description: ciliumImage.Description,
+			URI:         "registry.local/mirror/isovalent/cilium:v1.15.16-eksa.1",
+			ImageDigest: kubeVipDigest,
+		}},
+	}))
+}
+
+func TestRunningImages(t *testing.T) {
+	g := NewWithT(t)
+	running := pod("kube-system", "cilium", corev1.ContainerStatus{
+		Name:    "cilium-agent",
+		Image:   ciliumImage.URI,
+		ImageID: "docker-pullable://public.ecr.aws/isovalent/cilium@" + ciliumDigest,
+	})
+	pending := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Image: ciliumImage.URI}},
+		Containers:     []corev1.Container{{Image: kubeVipImage.URI}},
+	}}
+
+	g.Expect(sbom.RunningImages([]corev1.Pod{*running, *running, *pending})).To(Equal([]sbom.RunningImage{
+		{Reference: ciliumImage.URI, Digest: ciliumDigest},
+		{Reference: ciliumImage.URI},
+		{Reference: kubeVipImage.URI},
+	}))
+}
+
+func TestComponentNameAndVersion(t *testing.T) {
+	g := NewWithT(t)
+	c := sbom.Component{Image: releasev1.Image{URI: "localhost:5000/eks-anywhere/cli-tools:v0.23.0-eks-a-1"}}
+	g.Expect(c.Name()).To(Equal("localhost:5000/eks-anywhere/cli-tools"))
+	g.Expect(c.Version()).To(Equal("v0.23.0-eks-a-1"))
+
+	c = sbom.Component{Image: releasev1.Image{Name: "cli-tools", URI: "localhost:5000/eks-anywhere/cli-tools@" + ciliumDigest}}
+	g.Expect(c.Name()).To(Equal("cli-tools"))
+	g.Expect(c.Version()).To(BeEmpty())
+}
@@ -0,0 +1,162 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	spdxVersion = "SPDX-2.3"
+	// spdxPredicatePrefix identifies the SPDX SBOM attestations.
+	spdxPredicatePrefix = "https://spdx.dev/Document"
+	spdxDocumentID      = "SPDXRef-DOCUMENT"
+	spdxNoAssertion     = "NOASSERTION"
+)
+
+// spdxInvalidIDChars matches the characters not allowed in SPDX identifiers.
+var spdxInvalidIDChars = regexp.MustCompile(`[^a-zA-Z0-9.-]`)
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Description      string            `json:"description,omitempty"`
+	PrimaryPurpose   string            `json:"primaryPackagePurpose,omitempty"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+	Annotations      []spdxAnnotation  `json:"annotations,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	Category string `json:"referenceCategory"`
+	Type     string `json:"referenceType"`
+	Locator  string `json:"referenceLocator"`
+}
+
+type spdxAnnotation struct {
+	Annotator string `json:"annotator"`
+	Date      string `json:"annotationDate"`
+	Type      string `json:"annotationType"`
+	Comment   string `json:"comment"`
+}
+
+type spdxRelationship struct {
+	Element string `json:"spdxElementId"`
+	Type    string `json:"relationshipType"`
+	Related string `json:"relatedSpdxElement"`
+}
+
+// WriteSPDX writes the SBOM as an SPDX JSON document. The packages of the SPDX SBOM attestations
+// of an image are added as packages contained by the image package.
+func WriteSPDX(w io.Writer, s *SBOM, toolVersion string) error {
+	created := s.Timestamp.Format(time.RFC3339)
+	tool := fmt.Sprintf("Tool: %s-%s", toolName, toolVersion)
+	clusterID := spdxID("Cluster", s.ClusterName)
+	doc := spdxDocument{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            spdxDocumentID,
+		Name:              "eks-anywhere-" + s.ClusterName,
+		DocumentNamespace: fmt.Sprintf("https://anywhere.eks.amazonaws.com/spdx/%s-%s", s.ClusterName, uuid.NewString()),
+		CreationInfo: spdxCreationInfo{
+			Created:  created,
+			Creators: []string{tool},
+		},
+		Packages: []spdxPackage{{
+			SPDXID:           clusterID,
+			Name:             s.ClusterName,
+			VersionInfo:      s.EKSAVersion,
+			DownloadLocation: spdxNoAssertion,
+			Description:      fmt.Sprintf("EKS Anywhere cluster, bundles number %d", s.BundlesNumber),
+			PrimaryPurpose:   "PLATFORM",
+		}},
+		Relationships: []spdxRelationship{{Element: spdxDocumentID, Type: "DESCRIBES", Related: clusterID}},
+	}
+
+	for i, c := range s.Components {
+		id := spdxID("Image", fmt.Sprintf("%d-%s", i, c.Name()))
+		p := spdxPackage{
+			SPDXID:           id,
+			Name:             c.Name(),
+			VersionInfo:      c.Version(),
+			DownloadLocation: spdxNoAssertion,
+			Description:      c.Image.Description,
+			PrimaryPurpose:   "CONTAINER",
+			ExternalRefs:     []spdxExternalRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: purl(c)}},
+		}
+		if hash := digestHex(c.Image.ImageDigest); hash != "" {
+			p.Checksums = []spdxChecksum{{Algorithm: "SHA256", Value: hash}}
+		}
+		if !c.InBundle {
+			p.Annotations = append(p.Annotations, spdxAnnotation{
+				Annotator: tool, Date: created, Type: "OTHER",
+				Comment: fmt.Sprintf("Image %s doesn't match the EKS Anywhere bundles", c.Image.URI),
+			})
+		}
+		doc.Relationships = append(doc.Relationships, spdxRelationship{Element: clusterID, Type: "CONTAINS", Related: id})
+
+		var attested []spdxPackage
+		for _, a := range c.Attestations {
+			p.Annotations = append(p.Annotations, spdxAnnotation{
+				Annotator: tool, Date: created, Type: "OTHER",
+				Comment: "Attestation " + a.PredicateType,
+			})
+			if !strings.HasPrefix(a.PredicateType, spdxPredicatePrefix) {
+				continue
+			}
+			predicate := struct {
+				Packages []spdxPackage `json:"packages"`
+			}{}
+			if err := json.Unmarshal(a.Predicate, &predicate); err != nil {
+				return fmt.Errorf("reading SPDX attestation of image %s: %v", c.Image.URI, err)
+			}
+			for _, nested := range predicate.Packages {
+				// The identifiers of the attested documents are only unique inside them.
+				nested.SPDXID = spdxID("Image", fmt.Sprintf("%d-%d-%s", i, len(attested), nested.Name))
+				if nested.DownloadLocation == "" {
+					nested.DownloadLocation = spdxNoAssertion
+				}
+				attested = append(attested, nested)
+				doc.Relationships = append(doc.Relationships, spdxRelationship{Element: id, Type: "CONTAINS", Related: nested.SPDXID})
+			}
+		}
+
+		doc.Packages = append(doc.Packages, p)
+		doc.Packages = append(doc.Packages, attested...)
+	}
+
+	return writeJSON(w, doc)
+}
+
+func spdxID(kind, name string) string {
+	return "SPDXRef-" + kind + "-" + spdxInvalidIDChars.ReplaceAllString(name, "-")
+}
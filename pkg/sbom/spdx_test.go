@@ -0,0 +1,49 @@
+package sbom_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/eks-anywhere/pkg/sbom"
+)
+
+func TestWriteSPDX(t *testing.T) {
+	g := NewWithT(t)
+	out := &bytes.Buffer{}
+	g.Expect(sbom.WriteSPDX(out, testSBOM(), "v0.23.0")).To(Succeed())
+
+	doc := map[string]interface{}{}
+	g.Expect(json.Unmarshal(out.Bytes(), &doc)).To(Succeed())
+	g.Expect(doc).To(HaveKeyWithValue("spdxVersion", "SPDX-2.3"))
+	g.Expect(doc["documentNamespace"]).To(HavePrefix("https://anywhere.eks.amazonaws.com/spdx/prod-"))
+	g.Expect(doc["creationInfo"]).To(HaveKeyWithValue("creators", ConsistOf("Tool: eksctl-anywhere-v0.23.0")))
+
+	packages := doc["packages"].([]interface{})
+	g.Expect(packages).To(HaveLen(4))
+	g.Expect(packages[0]).To(HaveKeyWithValue("SPDXID", "SPDXRef-Cluster-prod"))
+	g.Expect(packages[1]).To(HaveKeyWithValue("SPDXID", "SPDXRef-Image-0-cilium"))
+	g.Expect(packages[1]).To(HaveKeyWithValue("checksums", ConsistOf(HaveKeyWithValue("checksumValue", ciliumDigest[len("sha256:"):]))))
+	g.Expect(packages[1]).To(HaveKeyWithValue("annotations", HaveLen(2)))
+	g.Expect(packages[2]).To(HaveKeyWithValue("SPDXID", "SPDXRef-Image-0-0-glibc"))
+	g.Expect(packages[2]).To(HaveKeyWithValue("downloadLocation", "NOASSERTION"))
+	g.Expect(packages[3]).To(HaveKeyWithValue("SPDXID", "SPDXRef-Image-1-kube-vip"))
+	g.Expect(packages[3]).To(HaveKeyWithValue("annotations", ConsistOf(HaveKeyWithValue("comment", ContainSubstring("doesn't match the EKS Anywhere bundles")))))
+
+	g.Expect(doc["relationships"]).To(ConsistOf(
+		map[string]interface{}{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Cluster-prod"},
+		map[string]interface{}{"spdxElementId": "SPDXRef-Cluster-prod", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Image-0-cilium"},
+		map[string]interface{}{"spdxElementId": "SPDXRef-Image-0-cilium", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Image-0-0-glibc"},
+		map[string]interface{}{"spdxElementId": "SPDXRef-Cluster-prod", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Image-1-kube-vip"},
+	))
+}
+
+func TestWriteSPDXInvalidAttestation(t *testing.T) {
+	g := NewWithT(t)
+	s := testSBOM()
+	s.Components[0].Attestations[1].Predicate = json.RawMessage(`"not a document"`)
+
+	g.Expect(sbom.WriteSPDX(&bytes.Buffer{}, s, "v0.23.0")).To(MatchError(ContainSubstring("reading SPDX attestation of image " + ciliumImage.URI)))
+}
@@ -0,0 +1,339 @@
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+// dssePayloadType is the payload type of the DSSE envelopes of cosign attestations.
+const dssePayloadType = "application/vnd.in-toto+json"
+
+// ImageSignature is a cosign signature of an image, in the format of `cosign download signature`.
+type ImageSignature struct {
+	// Base64Signature is the signature of the payload.
+	Base64Signature string `json:"Base64Signature"`
+	// Payload is the signed simple signing payload, it references the digest of the image.
+	Payload []byte `json:"Payload"`
+}
+
+// Envelope is a DSSE envelope holding a cosign attestation of an image,
+// in the format of `cosign download attestation`.
+type Envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     string              `json:"payload"`
+	Signatures  []EnvelopeSignature `json:"signatures"`
+}
+
+// EnvelopeSignature is a signature of a DSSE envelope.
+type EnvelopeSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// simpleSigningPayload is the payload signed by cosign for an image.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// inTotoStatement is the statement wrapped in the envelope of an attestation.
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+	Subject       []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	Predicate json.RawMessage `json:"predicate"`
+}
+
+// Attestation is an attestation of an image signed by the trust root, like an SBOM or a provenance.
+type Attestation struct {
+	// PredicateType identifies the type of the predicate, like https://cyclonedx.org/bom.
+	PredicateType string
+	// Predicate is the attested document.
+	Predicate json.RawMessage
+}
+
+// ImageSignatureSource retrieves the cosign signatures and attestations of images.
+// It returns no signatures or attestations, and no error, when the image has none.
+type ImageSignatureSource interface {
+	Signatures(ctx context.Context, image anywherev1alpha1.Image) ([]ImageSignature, error)
+	Attestations(ctx context.Context, image anywherev1alpha1.Image) ([]Envelope, error)
+}
+
+// TagResolver resolves the digest the tag of an image points to in the registry the image is pulled from.
+type TagResolver interface {
+	ResolveTag(ctx context.Context, image anywherev1alpha1.Image) (string, error)
+}
+
+// ImageVerifier verifies the cosign signatures and attestations of the images of a bundle
+// against a set of trusted public keys.
+type ImageVerifier struct {
+	trustRoot           []crypto.PublicKey
+	source              ImageSignatureSource
+	resolver            TagResolver
+	requireAttestations bool
+}
+
+// ImageVerifierOpt configures an ImageVerifier.
+type ImageVerifierOpt func(*ImageVerifier)
+
+// WithRequiredAttestations makes the ImageVerifier require, on top of a signature, an attestation
+// signed by the trust root for every image.
+func WithRequiredAttestations() ImageVerifierOpt {
+	return func(v *ImageVerifier) {
+		v.requireAttestations = true
+	}
+}
+
+// WithTagResolver makes the ImageVerifier check that the tag of every image points to the digest of the
+// image in the bundle. The images are pulled by tag, so verifying the digest alone doesn't verify the image
+// that is pulled.
+func WithTagResolver(resolver TagResolver) ImageVerifierOpt {
+	return func(v *ImageVerifier) {
+		v.resolver = resolver
+	}
+}
+
+// NewImageVerifier builds an ImageVerifier that trusts the signatures made by the keys of trustRoot.
+func NewImageVerifier(trustRoot []crypto.PublicKey, source ImageSignatureSource, opts ...ImageVerifierOpt) *ImageVerifier {
+	v := &ImageVerifier{
+		trustRoot: trustRoot,
+		source:    source,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// ParseTrustRoot parses the PEM encoded public keys and certificates in data. Only the public
+// key of a certificate is trusted, its chain and identity aren't verified.
+func ParseTrustRoot(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parsing public key: %v", err)
+			}
+			keys = append(keys, key)
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parsing certificate: %v", err)
+			}
+			keys = append(keys, cert.PublicKey)
+		default:
+			return nil, fmt.Errorf("unsupported PEM block %s, only PUBLIC KEY and CERTIFICATE are supported", block.Type)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no public key or certificate found in trust root")
+	}
+
+	return keys, nil
+}
+
+// Verify checks that every image has a signature, and an attestation if required, made by the trust root
+// for the digest of the image in the bundle and, with a TagResolver, that the tag of the image points to
+// that digest. Images without a URI aren't used by the bundle and are skipped.
+// It returns an error listing all the images that failed.
+func (v *ImageVerifier) Verify(ctx context.Context, images ...anywherev1alpha1.Image) error {
+	verified := map[string]bool{}
+	var errs []error
+	for _, image := range images {
+		key := image.URI + "@" + image.ImageDigest
+		if image.URI == "" || verified[key] {
+			continue
+		}
+		if err := v.verifyImage(ctx, image); err != nil {
+			errs = append(errs, fmt.Errorf("verifying image %s: %v", image.VersionedImage(), err))
+			continue
+		}
+		verified[key] = true
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+func (v *ImageVerifier) verifyImage(ctx context.Context, image anywherev1alpha1.Image) error {
+	if image.ImageDigest == "" {
+		return errors.New("image has no digest in the bundle")
+	}
+
+	if v.resolver != nil {
+		digest, err := v.resolver.ResolveTag(ctx, image)
+		if err != nil {
+			return fmt.Errorf("resolving image tag: %v", err)
+		}
+		if digest != image.ImageDigest {
+			return fmt.Errorf("image tag points to digest %s instead of digest %s in the bundle", digest, image.ImageDigest)
+		}
+	}
+
+	signatures, err := v.source.Signatures(ctx, image)
+	if err != nil {
+		return fmt.Errorf("retrieving signatures: %v", err)
+	}
+	if !v.anySignatureValid(signatures, image.ImageDigest) {
+		return fmt.Errorf("no valid signature for digest %s found in %d signature(s)", image.ImageDigest, len(signatures))
+	}
+
+	if !v.requireAttestations {
+		return nil
+	}
+
+	attestations, err := v.source.Attestations(ctx, image)
+	if err != nil {
+		return fmt.Errorf("retrieving attestations: %v", err)
+	}
+	if len(v.validAttestations(attestations, image.ImageDigest)) == 0 {
+		return fmt.Errorf("no valid attestation for digest %s found in %d attestation(s)", image.ImageDigest, len(attestations))
+	}
+
+	return nil
+}
+
+// Attestations returns the attestations of an image signed by the trust root with the digest
+// of the image in the bundle as subject. Attestations that fail the verification are ignored.
+func (v *ImageVerifier) Attestations(ctx context.Context, image anywherev1alpha1.Image) ([]Attestation, error) {
+	if image.ImageDigest == "" {
+		return nil, nil
+	}
+
+	envelopes, err := v.source.Attestations(ctx, image)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving attestations of image %s: %v", image.VersionedImage(), err)
+	}
+
+	statements := v.validAttestations(envelopes, image.ImageDigest)
+	attestations := make([]Attestation, 0, len(statements))
+	for _, s := range statements {
+		attestations = append(attestations, Attestation{PredicateType: s.PredicateType, Predicate: s.Predicate})
+	}
+
+	return attestations, nil
+}
+
+func (v *ImageVerifier) anySignatureValid(signatures []ImageSignature, digest string) bool {
+	for _, s := range signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Base64Signature)
+		if err != nil || !v.signedByTrustRoot(s.Payload, sig) {
+			continue
+		}
+
+		payload := &simpleSigningPayload{}
+		if err := json.Unmarshal(s.Payload, payload); err != nil {
+			continue
+		}
+		if payload.Critical.Image.DockerManifestDigest == digest {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *ImageVerifier) validAttestations(attestations []Envelope, digest string) []inTotoStatement {
+	algorithm, hex, found := strings.Cut(digest, ":")
+	if !found {
+		return nil
+	}
+
+	var valid []inTotoStatement
+	for _, a := range attestations {
+		if a.PayloadType != dssePayloadType {
+			continue
+		}
+		payload, err := base64.StdEncoding.DecodeString(a.Payload)
+		if err != nil {
+			continue
+		}
+
+		signed := false
+		pae := preAuthEncoding(a.PayloadType, payload)
+		for _, s := range a.Signatures {
+			sig, err := base64.StdEncoding.DecodeString(s.Sig)
+			if err == nil && v.signedByTrustRoot(pae, sig) {
+				signed = true
+				break
+			}
+		}
+		if !signed {
+			continue
+		}
+
+		statement := &inTotoStatement{}
+		if err := json.Unmarshal(payload, statement); err != nil {
+			continue
+		}
+		for _, subject := range statement.Subject {
+			if subject.Digest[algorithm] == hex {
+				valid = append(valid, *statement)
+				break
+			}
+		}
+	}
+
+	return valid
+}
+
+func (v *ImageVerifier) signedByTrustRoot(message, sig []byte) bool {
+	for _, key := range v.trustRoot {
+		if verifySignature(key, message, sig) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifySignature verifies a signature the way cosign does for each type of key:
+// ECDSA and RSA PKCS #1 v1.5 signatures of the SHA256 digest, and ed25519 signatures of the message.
+func verifySignature(key crypto.PublicKey, message, sig []byte) bool {
+	digest := sha256.Sum256(message)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, message, sig)
+	default:
+		return false
+	}
+}
+
+// preAuthEncoding returns the DSSE pre-authentication encoding of a payload, the message
+// signed in an envelope.
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
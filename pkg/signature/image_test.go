@@ -0,0 +1,352 @@
+package signature
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const testImageDigest = "sha256:0ae2a2c1b3c1a5b6f3b3a0d5c6f1e4f0c2a8c8c1f8e1f2a3b4c5d6e7f8091a2b"
+
+var testImage = anywherev1alpha1.Image{
+	URI:         "public.ecr.aws/eks-anywhere/cluster-controller:v0.20.0-eks-a-1",
+	ImageDigest: testImageDigest,
+}
+
+type fakeSource struct {
+	signatures   []ImageSignature
+	attestations []Envelope
+}
+
+func (s fakeSource) Signatures(context.Context, anywherev1alpha1.Image) ([]ImageSignature, error) {
+	return s.signatures, nil
+}
+
+func (s fakeSource) Attestations(context.Context, anywherev1alpha1.Image) ([]Envelope, error) {
+	return s.attestations, nil
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, message []byte) string {
+	digest := sha256.Sum256(message)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func signImage(t *testing.T, key *ecdsa.PrivateKey, imageDigest string) ImageSignature {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"public.ecr.aws/eks-anywhere/cluster-controller"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, imageDigest))
+	return ImageSignature{Base64Signature: sign(t, key, payload), Payload: payload}
+}
+
+func attestImage(t *testing.T, key *ecdsa.PrivateKey, imageDigest string) Envelope {
+	statement := []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://spdx.dev/Document","subject":[{"name":"public.ecr.aws/eks-anywhere/cluster-controller","digest":{"sha256":%q}}],"predicate":{}}`, strings.TrimPrefix(imageDigest, "sha256:")))
+	return Envelope{
+		PayloadType: dssePayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(statement),
+		Signatures:  []EnvelopeSignature{{Sig: sign(t, key, preAuthEncoding(dssePayloadType, statement))}},
+	}
+}
+
+type fakeResolver map[string]string
+
+func (r fakeResolver) ResolveTag(_ context.Context, image anywherev1alpha1.Image) (string, error) {
+	digest, ok := r[image.URI]
+	if !ok {
+		return "", errors.New("tag not found")
+	}
+	return digest, nil
+}
+
+func TestImageVerifierVerify(t *testing.T) {
+	trusted := newKey(t)
+	untrusted := newKey(t)
+	otherDigest := "sha256:" + strings.Repeat("1", 64)
+
+	tests := []struct {
+		name    string
+		source  fakeSource
+		opts    []ImageVerifierOpt
+		image   anywherev1alpha1.Image
+		wantErr string
+	}{
+		{
+			name:   "valid signature",
+			source: fakeSource{signatures: []ImageSignature{signImage(t, untrusted, testImageDigest), signImage(t, trusted, testImageDigest)}},
+			image:  testImage,
+		},
+		{
+			name:    "no signature",
+			image:   testImage,
+			wantErr: "no valid signature for digest " + testImageDigest + " found in 0 signature(s)",
+		},
+		{
+			name:    "signed by untrusted key",
+			source:  fakeSource{signatures: []ImageSignature{signImage(t, untrusted, testImageDigest)}},
+			image:   testImage,
+			wantErr: "no valid signature",
+		},
+		{
+			name:    "signature of other digest",
+			source:  fakeSource{signatures: []ImageSignature{signImage(t, trusted, otherDigest)}},
+			image:   testImage,
+			wantErr: "no valid signature",
+		},
+		{
+			name:    "image without digest",
+			image:   anywherev1alpha1.Image{URI: testImage.URI},
+			wantErr: "image has no digest in the bundle",
+		},
+		{
+			name:   "tag points to digest in bundle",
+			source: fakeSource{signatures: []ImageSignature{signImage(t, trusted, testImageDigest)}},
+			opts:   []ImageVerifierOpt{WithTagResolver(fakeResolver{testImage.URI: testImageDigest})},
+			image:  testImage,
+		},
+		{
+			name:    "tag points to other digest",
+			source:  fakeSource{signatures: []ImageSignature{signImage(t, trusted, testImageDigest)}},
+			opts:    []ImageVerifierOpt{WithTagResolver(fakeResolver{testImage.URI: otherDigest})},
+			image:   testImage,
+			wantErr: "image tag points to digest " + otherDigest + " instead of digest " + testImageDigest + " in the bundle",
+		},
+		{
+			name:    "tag not resolved",
+			source:  fakeSource{signatures: []ImageSignature{signImage(t, trusted, testImageDigest)}},
+			opts:    []ImageVerifierOpt{WithTagResolver(fakeResolver{})},
+			image:   testImage,
+			wantErr: "resolving image tag: tag not found",
+		},
+		{
+			name: "valid signature and attestation",
+			source: fakeSource{
+				signatures:   []ImageSignature{signImage(t, trusted, testImageDigest)},
+				attestations: []Envelope{attestImage(t, trusted, testImageDigest)},
+			},
+			opts:  []ImageVerifierOpt{WithRequiredAttestations()},
+			image: testImage,
+		},
+		{
+			name:    "missing attestation",
+			source:  fakeSource{signatures: []ImageSignature{signImage(t, trusted, testImageDigest)}},
+			opts:    []ImageVerifierOpt{WithRequiredAttestations()},
+			image:   testImage,
+			wantErr: "no valid attestation for digest " + testImageDigest,
+		},
+		{
+			name: "attestation of other digest",
+			source: fakeSource{
+				signatures:   []ImageSignature{signImage(t, trusted, testImageDigest)},
+				attestations: []Envelope{attestImage(t, trusted, otherDigest)},
+			},
+			opts:    []ImageVerifierOpt{WithRequiredAttestations()},
+			image:   testImage,
+			wantErr: "no valid attestation",
+		},
+		{
+			name: "attestation signed by untrusted key",
+			source: fakeSource{
+				signatures:   []ImageSignature{signImage(t, trusted, testImageDigest)},
+				attestations: []Envelope{attestImage(t, untrusted, testImageDigest)},
+			},
+			opts:    []ImageVerifierOpt{WithRequiredAttestations()},
+			image:   testImage,
+			wantErr: "no valid attestation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			v := NewImageVerifier([]crypto.PublicKey{trusted.Public()}, tt.source, tt.opts...)
+			err := v.Verify(context.Background(), tt.image)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(tt.wantErr)))
+			}
+		})
+	}
+}
+
+func TestImageVerifierAttestations(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	trusted := newKey(t)
+	untrusted := newKey(t)
+	source := fakeSource{attestations: []Envelope{
+		attestImage(t, untrusted, testImageDigest),
+		attestImage(t, trusted, "sha256:"+strings.Repeat("1", 64)),
+		attestImage(t, trusted, testImageDigest),
+	}}
+
+	v := NewImageVerifier([]crypto.PublicKey{trusted.Public()}, source)
+	attestations, err := v.Attestations(ctx, testImage)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(attestations).To(gomega.ConsistOf(Attestation{
+		PredicateType: "https://spdx.dev/Document",
+		Predicate:     json.RawMessage(`{}`),
+	}))
+
+	attestations, err = v.Attestations(ctx, anywherev1alpha1.Image{URI: testImage.URI})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(attestations).To(gomega.BeEmpty())
+}
+
+func TestParseTrustRoot(t *testing.T) {
+	g := gomega.NewWithT(t)
+	key := newKey(t)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	data := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	keys, err := ParseTrustRoot(data)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(keys).To(gomega.HaveLen(1))
+	g.Expect(key.PublicKey.Equal(keys[0])).To(gomega.BeTrue())
+
+	_, err = ParseTrustRoot([]byte("not a key"))
+	g.Expect(err).To(gomega.MatchError("no public key or certificate found in trust root"))
+
+	_, err = ParseTrustRoot(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unsupported PEM block PRIVATE KEY")))
+}
+
+func TestDirectorySource(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	key := newKey(t)
+	dir := t.TempDir()
+	tag := strings.Replace(testImageDigest, ":", "-", 1)
+
+	var sigs []byte
+	for _, s := range []ImageSignature{signImage(t, key, testImageDigest), signImage(t, key, testImageDigest)} {
+		line, err := json.Marshal(s)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		sigs = append(append(sigs, line...), '\n')
+	}
+	g.Expect(os.WriteFile(filepath.Join(dir, tag+".sig"), sigs, 0o644)).To(gomega.Succeed())
+	att, err := json.Marshal(attestImage(t, key, testImageDigest))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(os.WriteFile(filepath.Join(dir, tag+".att"), att, 0o644)).To(gomega.Succeed())
+
+	source := NewDirectorySource(dir)
+	signatures, err := source.Signatures(ctx, testImage)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(signatures).To(gomega.HaveLen(2))
+
+	v := NewImageVerifier([]crypto.PublicKey{key.Public()}, source, WithRequiredAttestations())
+	g.Expect(v.Verify(ctx, testImage)).To(gomega.Succeed())
+
+	unsigned := anywherev1alpha1.Image{URI: testImage.URI, ImageDigest: "sha256:" + strings.Repeat("2", 64)}
+	signatures, err = source.Signatures(ctx, unsigned)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(signatures).To(gomega.BeEmpty())
+}
+
+type mirror struct {
+	host string
+}
+
+func (m mirror) ReplaceRegistry(url string) string {
+	return strings.Replace(url, "public.ecr.aws", m.host, 1)
+}
+
+func TestRegistrySource(t *testing.T) {
+	g := gomega.NewWithT(t)
+	ctx := context.Background()
+	key := newKey(t)
+	sig := signImage(t, key, testImageDigest)
+	envelope, err := json.Marshal(attestImage(t, key, testImageDigest))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	blobs := map[string][]byte{}
+	manifests := map[string][]byte{}
+	addManifest := func(tag string, layers ...ocispec.Descriptor) {
+		m, err := json.Marshal(ocispec.Manifest{
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    ocispec.DescriptorEmptyJSON,
+			Layers:    layers,
+		})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		manifests[tag] = m
+	}
+	addBlob := func(mediaType string, data []byte, annotations map[string]string) ocispec.Descriptor {
+		d := digest.FromBytes(data)
+		blobs[d.String()] = data
+		return ocispec.Descriptor{MediaType: mediaType, Digest: d, Size: int64(len(data)), Annotations: annotations}
+	}
+	tag := strings.Replace(testImageDigest, ":", "-", 1)
+	addManifest(tag+".sig", addBlob("application/vnd.dev.cosign.simplesigning.v1+json", sig.Payload, map[string]string{cosignSignatureAnnotation: sig.Base64Signature}))
+	addManifest(tag+".att", addBlob(dsseEnvelopeMediaType, envelope, nil))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := "/v2/mirror/eks-anywhere/cluster-controller/"
+		name := strings.TrimPrefix(r.URL.Path, prefix)
+		switch {
+		case !strings.HasPrefix(r.URL.Path, prefix):
+			w.WriteHeader(http.StatusNotFound)
+		case name == "manifests/v0.20.0-eks-a-1":
+			// The image manifest itself isn't needed, the tag only has to resolve to the digest in the bundle.
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+			w.Header().Set("Docker-Content-Digest", testImageDigest)
+			w.Header().Set("Content-Length", "2")
+		case strings.HasPrefix(name, "manifests/") && manifests[strings.TrimPrefix(name, "manifests/")] != nil:
+			m := manifests[strings.TrimPrefix(name, "manifests/")]
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(m).String())
+			_, _ = w.Write(m)
+		case strings.HasPrefix(name, "blobs/") && blobs[strings.TrimPrefix(name, "blobs/")] != nil:
+			_, _ = w.Write(blobs[strings.TrimPrefix(name, "blobs/")])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://") + "/mirror"
+	source := NewRegistrySource(server.Client(), WithRegistryMirror(mirror{host: host}), WithPlainHTTP())
+
+	signatures, err := source.Signatures(ctx, testImage)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(signatures).To(gomega.Equal([]ImageSignature{sig}))
+
+	v := NewImageVerifier([]crypto.PublicKey{key.Public()}, source, WithRequiredAttestations(), WithTagResolver(source))
+	g.Expect(v.Verify(ctx, testImage)).To(gomega.Succeed())
+
+	retagged := anywherev1alpha1.Image{URI: testImage.URI, ImageDigest: "sha256:" + strings.Repeat("2", 64)}
+	g.Expect(v.Verify(ctx, retagged)).To(gomega.MatchError(gomega.ContainSubstring("image tag points to digest " + testImageDigest)))
+
+	v = NewImageVerifier([]crypto.PublicKey{key.Public()}, source)
+	g.Expect(v.Verify(ctx, retagged)).To(gomega.MatchError(gomega.ContainSubstring("found in 0 signature(s)")))
+}
@@ -0,0 +1,256 @@
+package signature
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+	orasregistry "oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+)
+
+const (
+	signatureSuffix   = ".sig"
+	attestationSuffix = ".att"
+
+	// cosignSignatureAnnotation is the annotation of the layers of a cosign signature manifest holding the signature.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	dsseEnvelopeMediaType     = "application/vnd.dsse.envelope.v1+json"
+)
+
+// RegistryMirror replaces the registry of an image with its mirror.
+type RegistryMirror interface {
+	ReplaceRegistry(url string) string
+}
+
+// RegistrySource retrieves the signatures and attestations of images from the tags cosign attaches them to
+// in the registry of the images, or in their registry mirror.
+type RegistrySource struct {
+	client    remote.Client
+	mirror    RegistryMirror
+	plainHTTP bool
+}
+
+// RegistrySourceOpt configures a RegistrySource.
+type RegistrySourceOpt func(*RegistrySource)
+
+// WithRegistryMirror makes the RegistrySource read the signatures from a registry mirror.
+func WithRegistryMirror(mirror RegistryMirror) RegistrySourceOpt {
+	return func(s *RegistrySource) {
+		s.mirror = mirror
+	}
+}
+
+// WithPlainHTTP makes the RegistrySource use HTTP instead of HTTPS.
+func WithPlainHTTP() RegistrySourceOpt {
+	return func(s *RegistrySource) {
+		s.plainHTTP = true
+	}
+}
+
+// NewRegistrySource builds a RegistrySource that makes requests with client.
+func NewRegistrySource(client remote.Client, opts ...RegistrySourceOpt) *RegistrySource {
+	s := &RegistrySource{client: client}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Signatures returns the signatures of the image.
+func (s *RegistrySource) Signatures(ctx context.Context, image anywherev1alpha1.Image) ([]ImageSignature, error) {
+	repo, layers, err := s.fetchLayers(ctx, image, signatureSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]ImageSignature, 0, len(layers))
+	for _, layer := range layers {
+		sig, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, err := content.FetchAll(ctx, repo, layer)
+		if err != nil {
+			return nil, fmt.Errorf("fetching signature payload %s: %v", layer.Digest, err)
+		}
+		signatures = append(signatures, ImageSignature{Base64Signature: sig, Payload: payload})
+	}
+
+	return signatures, nil
+}
+
+// Attestations returns the attestations of the image.
+func (s *RegistrySource) Attestations(ctx context.Context, image anywherev1alpha1.Image) ([]Envelope, error) {
+	repo, layers, err := s.fetchLayers(ctx, image, attestationSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	attestations := make([]Envelope, 0, len(layers))
+	for _, layer := range layers {
+		if layer.MediaType != dsseEnvelopeMediaType {
+			continue
+		}
+		data, err := content.FetchAll(ctx, repo, layer)
+		if err != nil {
+			return nil, fmt.Errorf("fetching attestation %s: %v", layer.Digest, err)
+		}
+		envelope := Envelope{}
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, fmt.Errorf("parsing attestation %s: %v", layer.Digest, err)
+		}
+		attestations = append(attestations, envelope)
+	}
+
+	return attestations, nil
+}
+
+// ResolveTag returns the digest the tag of the image points to in its registry, or its registry mirror.
+func (s *RegistrySource) ResolveTag(ctx context.Context, image anywherev1alpha1.Image) (string, error) {
+	repo, reference, err := s.repository(image)
+	if err != nil {
+		return "", err
+	}
+
+	desc, err := repo.Resolve(ctx, reference)
+	if err != nil {
+		return "", fmt.Errorf("resolving %s:%s: %v", repo.Reference.Repository, reference, err)
+	}
+
+	return desc.Digest.String(), nil
+}
+
+// repository returns the repository of the image and the reference of the image in it.
+func (s *RegistrySource) repository(image anywherev1alpha1.Image) (*remote.Repository, string, error) {
+	uri := image.URI
+	if s.mirror != nil {
+		uri = s.mirror.ReplaceRegistry(uri)
+	}
+	ref, err := orasregistry.ParseReference(uri)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing image uri %s: %v", uri, err)
+	}
+
+	repo, err := remote.NewRepository(ref.Registry + "/" + ref.Repository)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating repository for %s: %v", uri, err)
+	}
+	repo.Client = s.client
+	repo.PlainHTTP = s.plainHTTP
+
+	return repo, ref.Reference, nil
+}
+
+// fetchLayers returns the layers of the manifest tagged by cosign with the suffix for the image.
+func (s *RegistrySource) fetchLayers(ctx context.Context, image anywherev1alpha1.Image, suffix string) (orasregistry.Repository, []ocispec.Descriptor, error) {
+	repo, _, err := s.repository(image)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tag := cosignTag(image.ImageDigest, suffix)
+	_, manifestBytes, err := oras.FetchBytes(ctx, repo, tag, oras.DefaultFetchBytesOptions)
+	if errors.Is(err, errdef.ErrNotFound) {
+		return repo, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching %s:%s: %v", repo.Reference.Repository, tag, err)
+	}
+
+	manifest := ocispec.Manifest{}
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("parsing manifest %s:%s: %v", repo.Reference.Repository, tag, err)
+	}
+
+	return repo, manifest.Layers, nil
+}
+
+// DirectorySource reads the signatures and attestations of images from a local directory, to verify
+// images without access to the registry that stores them. The signatures of an image are stored in the
+// file sha256-<digest>.sig, as output by `cosign download signature`, and the attestations in the
+// file sha256-<digest>.att, as output by `cosign download attestation`.
+type DirectorySource struct {
+	dir string
+}
+
+// NewDirectorySource builds a DirectorySource reading from dir.
+func NewDirectorySource(dir string) *DirectorySource {
+	return &DirectorySource{dir: dir}
+}
+
+// Signatures returns the signatures of the image.
+func (s *DirectorySource) Signatures(_ context.Context, image anywherev1alpha1.Image) ([]ImageSignature, error) {
+	var signatures []ImageSignature
+	err := s.decodeFile(cosignTag(image.ImageDigest, signatureSuffix), func(d *json.Decoder) error {
+		sig := ImageSignature{}
+		if err := d.Decode(&sig); err != nil {
+			return err
+		}
+		signatures = append(signatures, sig)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return signatures, nil
+}
+
+// Attestations returns the attestations of the image.
+func (s *DirectorySource) Attestations(_ context.Context, image anywherev1alpha1.Image) ([]Envelope, error) {
+	var attestations []Envelope
+	err := s.decodeFile(cosignTag(image.ImageDigest, attestationSuffix), func(d *json.Decoder) error {
+		envelope := Envelope{}
+		if err := d.Decode(&envelope); err != nil {
+			return err
+		}
+		attestations = append(attestations, envelope)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attestations, nil
+}
+
+// decodeFile calls decode until all the JSON documents of the file are decoded.
+// It doesn't fail when the file doesn't exist.
+func (s *DirectorySource) decodeFile(name string, decode func(*json.Decoder) error) error {
+	path := filepath.Join(s.dir, name)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("opening %s: %v", path, err)
+	}
+	defer f.Close()
+
+	d := json.NewDecoder(f)
+	for {
+		if err := decode(d); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("parsing %s: %v", path, err)
+		}
+	}
+}
+
+// cosignTag returns the tag cosign attaches signatures or attestations to for an image digest.
+func cosignTag(digest, suffix string) string {
+	return strings.Replace(digest, ":", "-", 1) + suffix
+}
@@ -16,6 +16,7 @@ package signature
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/sha256"
 	"crypto/x509"
@@ -251,3 +252,52 @@ func ParseLicense(licenseToken string, key string) (*jwt.Token, error) {
 
 	return token, nil
 }
+
+// PublicKeyFromBase64 parses a base64 encoded PKIX ECDSA public key, like the KMS public keys.
+func PublicKeyFromBase64(key string) (crypto.PublicKey, error) {
+	return parsePublicKey(key)
+}
+
+// VerifyBundlesSignature checks that the signature annotation of the bundles object is made by one of the keys.
+func VerifyBundlesSignature(bundle *anywherev1alpha1.Bundles, keys []crypto.PublicKey) error {
+	bundleSig := bundle.Annotations[constants.SignatureAnnotation]
+	if bundleSig == "" {
+		return errors.New("missing bundle signature annotation")
+	}
+
+	_, filtered, err := getBundleDigest(bundle)
+	if err != nil {
+		return err
+	}
+
+	return verifyManifestSignature(filtered, bundleSig, keys)
+}
+
+// VerifyEKSDistroManifestSignature checks that the signature of an EKS Distro release manifest is made by one of the keys.
+func VerifyEKSDistroManifestSignature(release *eksdv1alpha1.Release, signature string, keys []crypto.PublicKey) error {
+	if signature == "" {
+		return errors.New("missing eks distro manifest signature annotation")
+	}
+
+	_, filtered, err := getEKSDistroReleaseDigest(release)
+	if err != nil {
+		return err
+	}
+
+	return verifyManifestSignature(filtered, signature, keys)
+}
+
+func verifyManifestSignature(filtered []byte, signature string, keys []crypto.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("signature isn't base64 encoded: %w", err)
+	}
+
+	for _, key := range keys {
+		if verifySignature(key, filtered, sig) {
+			return nil
+		}
+	}
+
+	return errors.New("signature is not valid for any of the trusted public keys")
+}
@@ -1,9 +1,11 @@
 package signature
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"fmt"
@@ -893,3 +895,98 @@ func TestParsePublicKey_NotECDSAError(t *testing.T) {
 		t.Errorf("Expected 'parsing the public key (not ECDSA)' error, got: %v", err)
 	}
 }
+
+func TestVerifyBundlesSignature(t *testing.T) {
+	g := gomega.NewWithT(t)
+	bundle := &anywherev1alpha1.Bundles{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Bundles",
+			APIVersion: anywherev1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				constants.SignatureAnnotation: "MEYCIQCiWwxw/Nchkgtan47FzagXHgB45Op7YWxvSZjFzHau8wIhALG2kbm+H8HJEfN/rUQ0ldo298MnzyhukBptUm0jCtZZ",
+			},
+		},
+		Spec: anywherev1alpha1.BundlesSpec{
+			Number: 1,
+			VersionsBundles: []anywherev1alpha1.VersionsBundle{
+				{
+					KubeVersion: "1.31",
+				},
+			},
+		},
+	}
+	releaseKey, err := PublicKeyFromBase64(constants.KMSPublicKey)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	g.Expect(VerifyBundlesSignature(bundle, []crypto.PublicKey{releaseKey})).To(gomega.Succeed())
+	g.Expect(VerifyBundlesSignature(bundle, []crypto.PublicKey{otherKey.Public(), releaseKey})).To(gomega.Succeed())
+	g.Expect(VerifyBundlesSignature(bundle, []crypto.PublicKey{otherKey.Public()})).To(
+		gomega.MatchError("signature is not valid for any of the trusted public keys"),
+	)
+
+	// A bundle re-signed with another key is trusted when that key is.
+	bundle.Spec.Number = 2
+	_, filtered, err := getBundleDigest(bundle)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	digest := sha256.Sum256(filtered)
+	sig, err := ecdsa.SignASN1(rand.Reader, otherKey, digest[:])
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	bundle.Annotations[constants.SignatureAnnotation] = base64.StdEncoding.EncodeToString(sig)
+
+	g.Expect(VerifyBundlesSignature(bundle, []crypto.PublicKey{releaseKey, otherKey.Public()})).To(gomega.Succeed())
+	g.Expect(VerifyBundlesSignature(bundle, []crypto.PublicKey{releaseKey})).NotTo(gomega.Succeed())
+
+	delete(bundle.Annotations, constants.SignatureAnnotation)
+	g.Expect(VerifyBundlesSignature(bundle, []crypto.PublicKey{releaseKey})).To(gomega.MatchError("missing bundle signature annotation"))
+}
+
+func TestVerifyEKSDistroManifestSignature(t *testing.T) {
+	g := gomega.NewWithT(t)
+	release := &eksdv1alpha1.Release{
+		TypeMeta: v1.TypeMeta{
+			Kind:       "Release",
+			APIVersion: eksdv1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "kubernetes-1-28-46",
+			Namespace: constants.EksaSystemNamespace,
+		},
+		Spec: eksdv1alpha1.ReleaseSpec{
+			Channel: "1-28",
+			Number:  46,
+		},
+		Status: eksdv1alpha1.ReleaseStatus{
+			Components: []eksdv1alpha1.Component{
+				{
+					Name:   "metrics-server",
+					GitTag: "v0.7.2",
+					Assets: []eksdv1alpha1.Asset{
+						{
+							Name: "metrics-server-image",
+						},
+					},
+				},
+			},
+		},
+	}
+	signature := "MEUCIQC3uP3Dhfb/nhCeir0Hwtf4bddKVfVIauFWBidT18XZOwIgHjzH1mOxBm1N2l2w9wBVy9W1o6CQXpdDz7UcbCszZYc="
+	releaseKey, err := PublicKeyFromBase64(constants.EKSDistroKMSPublicKey)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	bundlesKey, err := PublicKeyFromBase64(constants.KMSPublicKey)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	g.Expect(VerifyEKSDistroManifestSignature(release, signature, []crypto.PublicKey{releaseKey})).To(gomega.Succeed())
+	g.Expect(VerifyEKSDistroManifestSignature(release, signature, []crypto.PublicKey{bundlesKey})).To(
+		gomega.MatchError("signature is not valid for any of the trusted public keys"),
+	)
+	g.Expect(VerifyEKSDistroManifestSignature(release, "", []crypto.PublicKey{releaseKey})).To(
+		gomega.MatchError("missing eks distro manifest signature annotation"),
+	)
+	g.Expect(VerifyEKSDistroManifestSignature(release, "invalid", []crypto.PublicKey{releaseKey})).To(
+		gomega.MatchError(gomega.ContainSubstring("signature isn't base64 encoded")),
+	)
+}
@@ -20,7 +20,6 @@ import (
 	"github.com/aws/eks-anywhere/pkg/features"
 	"github.com/aws/eks-anywhere/pkg/logger"
 	"github.com/aws/eks-anywhere/pkg/manifests"
-	"github.com/aws/eks-anywhere/pkg/manifests/bundles"
 	"github.com/aws/eks-anywhere/pkg/providers"
 	"github.com/aws/eks-anywhere/pkg/providers/common"
 	"github.com/aws/eks-anywhere/pkg/semver"
@@ -60,6 +59,22 @@ func ValidateOSForRegistryMirror(clusterSpec *cluster.Spec, provider providers.P
 	return nil
 }
 
+// ValidateOSForDualStack checks if the OS of the machines is compatible with a dual-stack cluster network.
+// Bottlerocket doesn't run the prekubeadm command that sets the kubelet node IP of each IP family.
+func ValidateOSForDualStack(clusterSpec *cluster.Spec, provider providers.Provider) error {
+	if !clusterSpec.Cluster.Spec.ClusterNetwork.IsDualStack() {
+		return nil
+	}
+
+	for _, mc := range provider.MachineConfigs(clusterSpec) {
+		if mc.OSFamily() == v1alpha1.Bottlerocket {
+			return errors.New("dual-stack clusters are not supported for bottlerocket")
+		}
+	}
+
+	return nil
+}
+
 func ValidateCertForRegistryMirror(clusterSpec *cluster.Spec, tlsValidator TlsValidator) error {
 	cluster := clusterSpec.Cluster
 	if cluster.Spec.RegistryMirrorConfiguration == nil {
@@ -308,7 +323,7 @@ func ValidateExtendedKubernetesVersionSupport(ctx context.Context, clusterSpec v
 	var b *releasev1alpha1.Bundles
 	var err error
 	if bundlesOverride != "" {
-		b, err = bundles.Read(reader, bundlesOverride)
+		b, err = reader.ReadBundles(bundlesOverride)
 		if err != nil {
 			return fmt.Errorf("getting bundle for cluster: %w", err)
 		}
@@ -270,6 +270,47 @@ func TestValidateOSForRegistryMirrorInsecureSkipVerifyEnabled(t *testing.T) {
 	}
 }
 
+func TestValidateOSForDualStackSingleStack(t *testing.T) {
+	tt := newTest(t)
+	tt.Expect(validations.ValidateOSForDualStack(tt.clusterSpec, tt.provider)).To(Succeed())
+}
+
+func TestValidateOSForDualStack(t *testing.T) {
+	tests := []struct {
+		name     string
+		osFamily anywherev1.OSFamily
+		wantErr  string
+	}{
+		{
+			name:     "ubuntu",
+			osFamily: anywherev1.Ubuntu,
+		},
+		{
+			name:     "bottlerocket",
+			osFamily: anywherev1.Bottlerocket,
+			wantErr:  "dual-stack clusters are not supported for bottlerocket",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tt := newTest(t)
+			tt.clusterSpec.Cluster.Spec.ClusterNetwork.Pods.CidrBlocks = []string{"10.1.0.0/16", "fd00:1::/56"}
+			tt.clusterSpec.Cluster.Spec.ClusterNetwork.Services.CidrBlocks = []string{"10.96.0.0/12", "fd00:2::/108"}
+			tt.provider.EXPECT().MachineConfigs(tt.clusterSpec).Return([]providers.MachineConfig{
+				&anywherev1.VSphereMachineConfig{Spec: anywherev1.VSphereMachineConfigSpec{OSFamily: tc.osFamily}},
+			})
+
+			err := validations.ValidateOSForDualStack(tt.clusterSpec, tt.provider)
+			if tc.wantErr == "" {
+				tt.Expect(err).To(Succeed())
+			} else {
+				tt.Expect(err).To(MatchError(tc.wantErr))
+			}
+		})
+	}
+}
+
 func TestValidateOSForRegistryMirrorNoPublicEcrRegistry(t *testing.T) {
 	tt := newTest(t, withTLS())
 	tests := []struct {
@@ -934,9 +975,9 @@ spec:
 
 	err := validations.ValidateExtendedKubernetesVersionSupport(ctx, *cluster, manifests.NewReader(reader), fakeClient, "")
 	if err == nil {
-		t.Errorf("got = nil, \nwant error: validating bundle signature")
-	} else if !strings.Contains(err.Error(), "validating bundle signature") {
-		t.Errorf("got error = %v, \nwant error containing 'validating bundle signature'", err)
+		t.Errorf("got = nil, \nwant error: verifying signature of Bundles manifest https://bundles/bundles.yaml")
+	} else if !strings.Contains(err.Error(), "verifying signature of Bundles manifest https://bundles/bundles.yaml") {
+		t.Errorf("got error = %v, \nwant error containing 'verifying signature of Bundles manifest https://bundles/bundles.yaml'", err)
 	}
 }
 
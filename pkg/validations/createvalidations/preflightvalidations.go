@@ -29,6 +29,13 @@ func (v *CreateValidations) PreflightValidations(ctx context.Context) []validati
 				Err:         validations.ValidateOSForRegistryMirror(v.Opts.Spec, v.Opts.Provider),
 			}
 		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate OS is compatible with dual-stack cluster network",
+				Remediation: "use Ubuntu or RedHat machines for dual-stack clusters",
+				Err:         validations.ValidateOSForDualStack(v.Opts.Spec, v.Opts.Provider),
+			}
+		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
 				Name:        "validate certificate for registry mirror",
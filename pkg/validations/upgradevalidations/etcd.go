@@ -0,0 +1,53 @@
+package upgradevalidations
+
+import (
+	"context"
+	"fmt"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/aws/eks-anywhere/pkg/clients/kubernetes"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/clusterapi"
+	"github.com/aws/eks-anywhere/pkg/constants"
+)
+
+// ValidateEtcdScaling checks that changing the number of external etcd members doesn't drop the etcd
+// cluster below its current quorum and that all the current members are healthy before scaling.
+func ValidateEtcdScaling(ctx context.Context, k kubernetes.Client, spec *cluster.Spec) error {
+	etcdConfig := spec.Cluster.Spec.ExternalEtcdConfiguration
+	if etcdConfig == nil {
+		return nil
+	}
+
+	etcdadmCluster := &etcdv1.EtcdadmCluster{}
+	err := k.Get(ctx, clusterapi.EtcdClusterName(spec.Cluster.Name), constants.EksaSystemNamespace, etcdadmCluster)
+	if apierrors.IsNotFound(err) {
+		// Adding external etcd to a cluster is not supported, this is caught by the immutable fields validation.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading etcdadm cluster: %v", err)
+	}
+
+	if etcdadmCluster.Spec.Replicas == nil {
+		return nil
+	}
+
+	current := *etcdadmCluster.Spec.Replicas
+	desired := int32(etcdConfig.Count)
+	if current == desired {
+		return nil
+	}
+
+	if err := clusterapi.ValidateEtcdScaling(current, desired); err != nil {
+		return err
+	}
+
+	if !clusterapi.EtcdadmClusterHealthy(etcdadmCluster) {
+		return fmt.Errorf("etcd has %d of %d members ready, all the members must be healthy before scaling etcd", etcdadmCluster.Status.ReadyReplicas, current)
+	}
+
+	return nil
+}
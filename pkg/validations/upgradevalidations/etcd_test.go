@@ -0,0 +1,79 @@
+package upgradevalidations_test
+
+import (
+	"context"
+	"testing"
+
+	etcdv1 "github.com/aws/etcdadm-controller/api/v1beta1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/aws/eks-anywhere/internal/test"
+	anywherev1 "github.com/aws/eks-anywhere/pkg/api/v1alpha1"
+	"github.com/aws/eks-anywhere/pkg/cluster"
+	"github.com/aws/eks-anywhere/pkg/constants"
+	"github.com/aws/eks-anywhere/pkg/utils/ptr"
+	"github.com/aws/eks-anywhere/pkg/validations/upgradevalidations"
+)
+
+func TestValidateEtcdScaling(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		replicas   int32
+		ready      bool
+		readyCount int32
+		noEtcd     bool
+		noEtcdadm  bool
+		wantErr    string
+	}{
+		{name: "no external etcd", noEtcd: true},
+		{name: "no etcdadm cluster", count: 3, noEtcdadm: true},
+		{name: "no change with unhealthy members", count: 3, replicas: 3, readyCount: 2},
+		{name: "scale up", count: 3, replicas: 1, ready: true, readyCount: 1},
+		{name: "scale down to quorum", count: 3, replicas: 5, ready: true, readyCount: 5},
+		{
+			name: "scale down below quorum", count: 1, replicas: 3, ready: true, readyCount: 3,
+			wantErr: "scaling etcd from 3 to 1 members would drop below the current quorum of 2 members",
+		},
+		{
+			name: "scale up with unhealthy members", count: 5, replicas: 3, readyCount: 2,
+			wantErr: "etcd has 2 of 3 members ready, all the members must be healthy before scaling etcd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			spec := test.NewClusterSpec(func(s *cluster.Spec) {
+				s.Cluster.Name = "my-cluster"
+				if !tt.noEtcd {
+					s.Cluster.Spec.ExternalEtcdConfiguration = &anywherev1.ExternalEtcdConfiguration{Count: tt.count}
+				}
+			})
+
+			scheme := runtime.NewScheme()
+			g.Expect(etcdv1.AddToScheme(scheme)).To(Succeed())
+			cb := fake.NewClientBuilder().WithScheme(scheme)
+			if !tt.noEtcdadm {
+				cb = cb.WithObjects(&etcdv1.EtcdadmCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-etcd", Namespace: constants.EksaSystemNamespace},
+					Spec:       etcdv1.EtcdadmClusterSpec{Replicas: ptr.Int32(tt.replicas)},
+					Status: etcdv1.EtcdadmClusterStatus{
+						Ready:         tt.ready,
+						ReadyReplicas: tt.readyCount,
+					},
+				})
+			}
+
+			err := upgradevalidations.ValidateEtcdScaling(ctx, test.NewKubeClient(cb.Build()), spec)
+			if tt.wantErr == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tt.wantErr))
+			}
+		})
+	}
+}
@@ -43,9 +43,15 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 		return fmt.Errorf("spec.controlPlaneConfiguration.endpoint is immutable")
 	}
 
-	/* compare all clusterNetwork fields individually, since we do allow updating updating fields for configuring plugins such as CiliumConfig through the cli*/
+	/* compare all clusterNetwork fields individually, since we do allow updating updating fields for configuring plugins such as CiliumConfig through the cli
+	and appending pod CIDR blocks to expand a pod network using Cilium cluster-pool IPAM*/
 	if !nSpec.ClusterNetwork.Pods.Equal(&oSpec.ClusterNetwork.Pods) {
-		return fmt.Errorf("spec.clusterNetwork.Pods is immutable")
+		if !nSpec.ClusterNetwork.IsPodNetworkExpansionOf(&oSpec.ClusterNetwork) {
+			return fmt.Errorf("spec.clusterNetwork.Pods is immutable")
+		}
+		if !prevSpec.UsesCiliumClusterPoolIPAM() {
+			return fmt.Errorf("appending spec.clusterNetwork.Pods CIDR blocks requires Cilium cluster-pool IPAM, the cluster uses kubernetes IPAM")
+		}
 	}
 	if !nSpec.ClusterNetwork.Services.Equal(&oSpec.ClusterNetwork.Services) {
 		return fmt.Errorf("spec.clusterNetwork.Services is immutable")
@@ -53,9 +59,14 @@ func ValidateImmutableFields(ctx context.Context, k validations.KubectlClient, c
 	if !nSpec.ClusterNetwork.DNS.Equal(&oSpec.ClusterNetwork.DNS) {
 		return fmt.Errorf("spec.clusterNetwork.DNS is immutable")
 	}
-	if !v1alpha1.CNIPluginSame(nSpec.ClusterNetwork, oSpec.ClusterNetwork) {
+	if !v1alpha1.CNIPluginSame(nSpec.ClusterNetwork, oSpec.ClusterNetwork) &&
+		!v1alpha1.IsKindnetdToCiliumMigration(nSpec.ClusterNetwork, oSpec.ClusterNetwork) {
 		return fmt.Errorf("spec.clusterNetwork.CNI/CNIConfig is immutable")
 	}
+	if v1alpha1.IsKindnetdToCiliumMigration(nSpec.ClusterNetwork, oSpec.ClusterNetwork) &&
+		nSpec.ClusterNetwork.CNIConfig.Cilium.RoutingMode != v1alpha1.CiliumRoutingModeDirect {
+		return fmt.Errorf("migrating from Kindnetd to Cilium requires spec.clusterNetwork.cniConfig.cilium.routingMode to be %s", v1alpha1.CiliumRoutingModeDirect)
+	}
 
 	if !nSpec.ProxyConfiguration.Equal(oSpec.ProxyConfiguration) {
 		return fmt.Errorf("spec.proxyConfiguration is immutable")
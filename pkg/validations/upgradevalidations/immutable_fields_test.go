@@ -300,6 +300,36 @@ func TestValidateImmutableFields(t *testing.T) {
 				}
 			},
 		},
+		{
+			Name: "Migrate from Kindnetd to Cilium with direct routing",
+			ConfigureCurrent: func(current *v1alpha1.Cluster) {
+				current.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Kindnetd: &v1alpha1.KindnetdConfig{},
+				}
+			},
+			ConfigureDesired: func(desired *v1alpha1.Cluster) {
+				desired.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Cilium: &v1alpha1.CiliumConfig{
+						RoutingMode:           v1alpha1.CiliumRoutingModeDirect,
+						IPv4NativeRoutingCIDR: "192.168.0.0/16",
+					},
+				}
+			},
+		},
+		{
+			Name: "Migrate from Kindnetd to Cilium with overlay routing",
+			ConfigureCurrent: func(current *v1alpha1.Cluster) {
+				current.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Kindnetd: &v1alpha1.KindnetdConfig{},
+				}
+			},
+			ConfigureDesired: func(desired *v1alpha1.Cluster) {
+				desired.Spec.ClusterNetwork.CNIConfig = &v1alpha1.CNIConfig{
+					Cilium: &v1alpha1.CiliumConfig{},
+				}
+			},
+			ExpectedError: "migrating from Kindnetd to Cilium requires spec.clusterNetwork.cniConfig.cilium.routingMode to be direct",
+		},
 		{
 			Name: "Add AWS IAM identity provider when none existed",
 			ConfigureCurrent: func(current *v1alpha1.Cluster) {
@@ -39,6 +39,13 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) []validat
 				Err:         validations.ValidateOSForRegistryMirror(u.Opts.Spec, u.Opts.Provider),
 			}
 		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate OS is compatible with dual-stack cluster network",
+				Remediation: "use Ubuntu or RedHat machines for dual-stack clusters",
+				Err:         validations.ValidateOSForDualStack(u.Opts.Spec, u.Opts.Provider),
+			}
+		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
 				Name:        "validate certificate for registry mirror",
@@ -109,6 +116,13 @@ func (u *UpgradeValidations) PreflightValidations(ctx context.Context) []validat
 				Err:         ValidateImmutableFields(ctx, k, targetCluster, u.Opts.Spec, u.Opts.Provider),
 			}
 		},
+		func() *validations.ValidationResult {
+			return &validations.ValidationResult{
+				Name:        "validate etcd scaling",
+				Remediation: "ensure all the etcd members are healthy and scale down etcd by at most the number of members it can lose without losing quorum",
+				Err:         ValidateEtcdScaling(ctx, u.Opts.KubeClient, u.Opts.Spec),
+			}
+		},
 		func() *validations.ValidationResult {
 			return &validations.ValidationResult{
 				Name:        "validate cluster's eksaVersion matches EKS-Anywhere Version",
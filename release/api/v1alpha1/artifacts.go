@@ -172,6 +172,22 @@ func (vb *VersionsBundle) NutanixImages() []Image {
 	return i
 }
 
+// MetalLBImages returns the images of the MetalLB service load balancer in a VersionsBundle.
+func (vb *VersionsBundle) MetalLBImages() []Image {
+	i := make([]Image, 0, 2)
+	if vb.MetalLB == nil {
+		return i
+	}
+	if vb.MetalLB.Controller.URI != "" {
+		i = append(i, vb.MetalLB.Controller)
+	}
+	if vb.MetalLB.Speaker.URI != "" {
+		i = append(i, vb.MetalLB.Speaker)
+	}
+
+	return i
+}
+
 // SharedImages returns images that are shared across different providers in a VersionsBundle.
 func (vb *VersionsBundle) SharedImages() []Image {
 	return []Image{
@@ -221,6 +237,7 @@ func (vb *VersionsBundle) Images() []Image {
 		vb.SnowImages(),
 		vb.TinkerbellImages(),
 		vb.NutanixImages(),
+		vb.MetalLBImages(),
 	}
 
 	size := 0
@@ -238,11 +255,16 @@ func (vb *VersionsBundle) Images() []Image {
 
 // Charts returns a map of Helm chart images used by different components in a VersionsBundle.
 func (vb *VersionsBundle) Charts() map[string]*Image {
-	return map[string]*Image{
+	charts := map[string]*Image{
 		"cilium":                &vb.Cilium.HelmChart,
 		"eks-anywhere-packages": &vb.PackageController.HelmChart,
 		"tinkerbell-chart":      &vb.Tinkerbell.TinkerbellStack.TinkebellChart,
 		"tinkerbell-crds":       &vb.Tinkerbell.TinkerbellStack.TinkerbellCrds,
 		"tinkerbell-stack":      &vb.Tinkerbell.TinkerbellStack.Stack,
 	}
+	if vb.MetalLB != nil && vb.MetalLB.HelmChart.URI != "" {
+		charts["metallb"] = &vb.MetalLB.HelmChart
+	}
+
+	return charts
 }
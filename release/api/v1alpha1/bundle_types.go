@@ -86,6 +86,7 @@ type VersionsBundle struct {
 	Snow                            SnowBundle                            `json:"snow,omitempty"`
 	Nutanix                         NutanixBundle                         `json:"nutanix,omitempty"`
 	Upgrader                        UpgraderBundle                        `json:"upgrader,omitempty"`
+	MetalLB                         *MetalLBBundle                        `json:"metalLB,omitempty"`
 	// This field has been deprecated
 	Aws *AwsBundle `json:"aws,omitempty"`
 }
@@ -145,6 +146,14 @@ type UpgraderBundle struct {
 	Upgrader Image `json:"upgrader"`
 }
 
+// MetalLBBundle is a bundle for the MetalLB service load balancer.
+type MetalLBBundle struct {
+	Version    string `json:"version,omitempty"`
+	Controller Image  `json:"controller"`
+	Speaker    Image  `json:"speaker"`
+	HelmChart  Image  `json:"helmChart"`
+}
+
 // OSImageBundle defines a set of OS images (e.g., Bottlerocket) for this bundle.
 type OSImageBundle struct {
 	Bottlerocket Archive `json:"bottlerocket,omitempty"`
@@ -718,6 +718,24 @@ func (in *Manifest) DeepCopy() *Manifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetalLBBundle) DeepCopyInto(out *MetalLBBundle) {
+	*out = *in
+	in.Controller.DeepCopyInto(&out.Controller)
+	in.Speaker.DeepCopyInto(&out.Speaker)
+	in.HelmChart.DeepCopyInto(&out.HelmChart)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetalLBBundle.
+func (in *MetalLBBundle) DeepCopy() *MetalLBBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(MetalLBBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NutanixBundle) DeepCopyInto(out *NutanixBundle) {
 	*out = *in
@@ -1047,6 +1065,11 @@ func (in *VersionsBundle) DeepCopyInto(out *VersionsBundle) {
 	in.Snow.DeepCopyInto(&out.Snow)
 	in.Nutanix.DeepCopyInto(&out.Nutanix)
 	in.Upgrader.DeepCopyInto(&out.Upgrader)
+	if in.MetalLB != nil {
+		in, out := &in.MetalLB, &out.MetalLB
+		*out = new(MetalLBBundle)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Aws != nil {
 		in, out := &in.Aws, &out.Aws
 		*out = new(AwsBundle)
@@ -763,6 +763,32 @@ var bundleReleaseAssetsConfigMap = []assettypes.AssetConfig{
 			"projectPath",
 		},
 	},
+	// MetalLB artifacts
+	{
+		ProjectName: "metallb",
+		ProjectPath: "projects/metallb/metallb",
+		Images: []*assettypes.Image{
+			{
+				RepoName: "controller",
+			},
+			{
+				RepoName: "speaker",
+			},
+			{
+				AssetName:            "metallb-helm",
+				RepoName:             "metallb",
+				TrimVersionSignifier: true,
+				ImageTagConfiguration: assettypes.ImageTagConfiguration{
+					NonProdSourceImageTagFormat: "<gitTag>",
+				},
+			},
+		},
+		ImageRepoPrefix: "metallb",
+		ImageTagOptions: []string{
+			"gitTag",
+			"projectPath",
+		},
+	},
 	// Notification-controller artifacts
 	{
 		ProjectName: "notification-controller",
@@ -106,6 +106,11 @@ func GetVersionsBundles(r *releasetypes.ReleaseConfig, imageDigests releasetypes
 		return nil, errors.Wrapf(err, "Error getting bundle for Flux controllers")
 	}
 
+	metallbBundle, err := GetMetalLBBundle(r, imageDigests)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error getting bundle for MetalLB")
+	}
+
 	etcdadmBootstrapBundle, err := GetEtcdadmBootstrapBundle(r, imageDigests)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Error getting bundle for external Etcdadm bootstrap")
@@ -212,6 +217,7 @@ func GetVersionsBundles(r *releasetypes.ReleaseConfig, imageDigests releasetypes
 			Snow:                            snowBundle,
 			Nutanix:                         nutanixBundle,
 			Upgrader:                        upgraderBundle,
+			MetalLB:                         &metallbBundle,
 		}
 		if endOfStandardSupport != "" {
 			versionsBundle.EndOfStandardSupport = endOfStandardSupport
@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	anywherev1alpha1 "github.com/aws/eks-anywhere/release/api/v1alpha1"
+	"github.com/aws/eks-anywhere/release/cli/pkg/constants"
+	releasetypes "github.com/aws/eks-anywhere/release/cli/pkg/types"
+	"github.com/aws/eks-anywhere/release/cli/pkg/version"
+)
+
+// GetMetalLBBundle returns the bundle with the MetalLB images and Helm chart used by the built-in service load balancer.
+func GetMetalLBBundle(r *releasetypes.ReleaseConfig, imageDigests releasetypes.ImageDigestsTable) (anywherev1alpha1.MetalLBBundle, error) {
+	metallbArtifacts, err := r.BundleArtifactsTable.Load("metallb")
+	if err != nil {
+		return anywherev1alpha1.MetalLBBundle{}, fmt.Errorf("artifacts for project metallb not found in bundle artifacts table")
+	}
+
+	var sourceBranch string
+	var componentChecksum string
+	bundleImageArtifacts := map[string]anywherev1alpha1.Image{}
+	artifactHashes := []string{}
+
+	for _, artifact := range metallbArtifacts {
+		imageArtifact := artifact.Image
+		sourceBranch = imageArtifact.SourcedFromBranch
+		imageDigest, err := imageDigests.Load(imageArtifact.ReleaseImageURI)
+		if err != nil {
+			return anywherev1alpha1.MetalLBBundle{}, fmt.Errorf("loading digest from image digests table: %v", err)
+		}
+
+		var bundleImageArtifact anywherev1alpha1.Image
+		if strings.HasSuffix(imageArtifact.AssetName, "helm") {
+			assetName := strings.TrimSuffix(imageArtifact.AssetName, "-helm")
+			bundleImageArtifact = anywherev1alpha1.Image{
+				Name:        assetName,
+				Description: fmt.Sprintf("Helm chart for %s", assetName),
+				URI:         imageArtifact.ReleaseImageURI,
+				ImageDigest: imageDigest,
+			}
+		} else {
+			bundleImageArtifact = anywherev1alpha1.Image{
+				Name:        imageArtifact.AssetName,
+				Description: fmt.Sprintf("Container image for %s image", imageArtifact.AssetName),
+				OS:          imageArtifact.OS,
+				Arch:        imageArtifact.Arch,
+				URI:         imageArtifact.ReleaseImageURI,
+				ImageDigest: imageDigest,
+			}
+		}
+		bundleImageArtifacts[imageArtifact.AssetName] = bundleImageArtifact
+		artifactHashes = append(artifactHashes, bundleImageArtifact.ImageDigest)
+	}
+
+	if r.DryRun {
+		componentChecksum = version.FakeComponentChecksum
+	} else {
+		componentChecksum = version.GenerateComponentHash(artifactHashes, r.DryRun)
+	}
+	version, err := version.BuildComponentVersion(
+		version.NewVersionerWithGITTAG(r.BuildRepoSource, constants.MetalLBProjectPath, sourceBranch, r),
+		componentChecksum,
+	)
+	if err != nil {
+		return anywherev1alpha1.MetalLBBundle{}, errors.Wrapf(err, "Error getting version for metallb")
+	}
+
+	bundle := anywherev1alpha1.MetalLBBundle{
+		Version:    version,
+		Controller: bundleImageArtifacts["controller"],
+		Speaker:    bundleImageArtifacts["speaker"],
+		HelmChart:  bundleImageArtifacts["metallb-helm"],
+	}
+
+	return bundle, nil
+}
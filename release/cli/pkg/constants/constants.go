@@ -43,6 +43,7 @@ const (
 	ImageBuilderProjectPath             = "projects/kubernetes-sigs/image-builder"
 	KindProjectPath                     = "projects/kubernetes-sigs/kind"
 	KubeRbacProxyProjectPath            = "projects/brancz/kube-rbac-proxy"
+	MetalLBProjectPath                  = "projects/metallb/metallb"
 	PackagesProjectPath                 = "projects/aws/eks-anywhere-packages"
 	UpgraderProjectPath                 = "projects/aws/upgrader"
 